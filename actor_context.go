@@ -0,0 +1,39 @@
+package job
+
+import "context"
+
+type actorContextKey struct{}
+
+type actorContextValue struct {
+	actor *Actor
+	scope Scope
+}
+
+// ContextWithActor stores actor/scope metadata on the context so downstream
+// components (audit, authorization, logging) can attribute work to a caller.
+func ContextWithActor(ctx context.Context, actor *Actor, scope Scope) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, actorContextKey{}, actorContextValue{
+		actor: actor.clone(),
+		scope: scope.clone(),
+	})
+}
+
+// ContextWithEnvelope stores the envelope actor/scope on the context.
+func ContextWithEnvelope(ctx context.Context, env Envelope) context.Context {
+	return ContextWithActor(ctx, env.Actor, env.Scope)
+}
+
+// ActorFromContext returns the actor/scope previously stored with ContextWithActor.
+func ActorFromContext(ctx context.Context) (*Actor, Scope, bool) {
+	if ctx == nil {
+		return nil, Scope{}, false
+	}
+	value, ok := ctx.Value(actorContextKey{}).(actorContextValue)
+	if !ok {
+		return nil, Scope{}, false
+	}
+	return value.actor.clone(), value.scope.clone(), true
+}
@@ -0,0 +1,336 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	job "github.com/goliatone/go-job"
+	adminv1 "github.com/goliatone/go-job/admin/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ adminv1.JobAdminServiceServer = &GRPCServer{}
+
+// GRPCServer serves adminv1.JobAdminService by translating its messages and
+// delegating to a Service. Errors are mapped to gRPC status codes from their
+// go-errors category.
+type GRPCServer struct {
+	adminv1.UnimplementedJobAdminServiceServer
+	service *Service
+}
+
+// NewGRPCServer wraps service.
+func NewGRPCServer(service *Service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// RegisterGRPC registers service as the JobAdminService of registrar:
+//
+//	server := grpc.NewServer()
+//	admin.RegisterGRPC(server, admin.NewService(registry, admin.WithCronManager(cron)))
+func RegisterGRPC(registrar grpc.ServiceRegistrar, service *Service) {
+	adminv1.RegisterJobAdminServiceServer(registrar, NewGRPCServer(service))
+}
+
+// ListTasks implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) ListTasks(ctx context.Context, _ *adminv1.ListTasksRequest) (*adminv1.ListTasksResponse, error) {
+	tasks, err := g.service.ListTasks(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &adminv1.ListTasksResponse{Tasks: make([]*adminv1.Task, 0, len(tasks))}
+	for _, task := range tasks {
+		pb, err := taskToProto(task)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		resp.Tasks = append(resp.Tasks, pb)
+	}
+	return resp, nil
+}
+
+// GetTask implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) GetTask(ctx context.Context, req *adminv1.GetTaskRequest) (*adminv1.Task, error) {
+	task, err := g.service.GetTask(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	pb, err := taskToProto(task)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return pb, nil
+}
+
+// Trigger implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) Trigger(ctx context.Context, req *adminv1.TriggerRequest) (*adminv1.TriggerResponse, error) {
+	resp, err := g.service.Trigger(ctx, TriggerRequest{
+		JobID:          req.GetJobId(),
+		Params:         req.GetParams().AsMap(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+		TraceID:        req.GetTraceId(),
+		Async:          req.GetAsync(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &adminv1.TriggerResponse{RunId: resp.RunID, Status: resp.Status, Error: resp.Error}, nil
+}
+
+// ListSchedules implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) ListSchedules(ctx context.Context, _ *adminv1.ListSchedulesRequest) (*adminv1.ListSchedulesResponse, error) {
+	defs, err := g.service.ListSchedules(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &adminv1.ListSchedulesResponse{Schedules: make([]*adminv1.Schedule, 0, len(defs))}
+	for _, def := range defs {
+		pb, err := scheduleToProto(def)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		resp.Schedules = append(resp.Schedules, pb)
+	}
+	return resp, nil
+}
+
+// Reconcile implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) Reconcile(ctx context.Context, req *adminv1.ReconcileRequest) (*adminv1.ReconcileResponse, error) {
+	desired := make([]job.ScheduleDefinition, 0, len(req.GetSchedules()))
+	for _, pb := range req.GetSchedules() {
+		def, err := scheduleFromProto(pb)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		desired = append(desired, def)
+	}
+	result, err := g.service.Reconcile(ctx, desired)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &adminv1.ReconcileResponse{Added: result.Added, Updated: result.Updated, Removed: result.Removed}, nil
+}
+
+// GetRun implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) GetRun(ctx context.Context, req *adminv1.GetRunRequest) (*adminv1.Run, error) {
+	run, err := g.service.GetRun(ctx, req.GetRunId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	logs, err := logEntriesToProto(run.Logs)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &adminv1.Run{
+		RunId:      run.RunID,
+		JobId:      run.JobID,
+		TraceId:    run.TraceID,
+		Status:     run.Status,
+		StartedAt:  timestampToProto(run.StartedAt),
+		FinishedAt: timestampToProto(run.FinishedAt),
+		Error:      run.Error,
+		Logs:       logs,
+	}, nil
+}
+
+// GetRunLogs implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) GetRunLogs(ctx context.Context, req *adminv1.GetRunLogsRequest) (*adminv1.RunLogs, error) {
+	logs, err := g.service.GetRunLogs(ctx, req.GetRunId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	entries, err := logEntriesToProto(logs.Entries)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &adminv1.RunLogs{
+		RunId:     logs.RunID,
+		JobId:     logs.JobID,
+		StartedAt: timestampToProto(logs.StartedAt),
+		Entries:   entries,
+		Dropped:   int32(logs.Dropped),
+	}, nil
+}
+
+// TriggerSelector implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) TriggerSelector(ctx context.Context, req *adminv1.TriggerSelectorRequest) (*adminv1.BulkResponse, error) {
+	results, err := g.service.TriggerSelector(ctx, req.GetSelector(), req.GetParams().AsMap())
+	return bulkToProto(results, err)
+}
+
+// PauseSelector implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) PauseSelector(ctx context.Context, req *adminv1.SelectorRequest) (*adminv1.BulkResponse, error) {
+	results, err := g.service.PauseSelector(ctx, req.GetSelector())
+	return bulkToProto(results, err)
+}
+
+// ResumeSelector implements adminv1.JobAdminServiceServer.
+func (g *GRPCServer) ResumeSelector(ctx context.Context, req *adminv1.SelectorRequest) (*adminv1.BulkResponse, error) {
+	results, err := g.service.ResumeSelector(ctx, req.GetSelector())
+	return bulkToProto(results, err)
+}
+
+// grpcError maps err to a status from its go-errors category.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Internal
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		switch typed.Category {
+		case errors.CategoryValidation, errors.CategoryBadInput:
+			code = codes.InvalidArgument
+		case errors.CategoryNotFound:
+			code = codes.NotFound
+		case errors.CategoryAuth:
+			code = codes.Unauthenticated
+		case errors.CategoryAuthz:
+			code = codes.PermissionDenied
+		case errors.CategoryConflict:
+			code = codes.AlreadyExists
+		case errors.CategoryRateLimit:
+			code = codes.ResourceExhausted
+		case errors.CategoryOperation:
+			code = codes.FailedPrecondition
+		case errors.CategoryExternal:
+			code = codes.Unavailable
+		}
+	}
+	return status.Error(code, err.Error())
+}
+
+func taskToProto(task Task) (*adminv1.Task, error) {
+	metadata, err := toStruct(task.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.Task{
+		Id:         task.ID,
+		ScriptPath: task.ScriptPath,
+		Engine:     task.Engine,
+		Schedule:   task.Schedule,
+		Timeout:    durationpb.New(task.Timeout),
+		Retries:    int32(task.Retries),
+		Metadata:   metadata,
+	}, nil
+}
+
+func scheduleToProto(def job.ScheduleDefinition) (*adminv1.Schedule, error) {
+	params, err := toStruct(def.Message.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	config, err := toStruct(def.Message.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.Schedule{
+		Id:         def.ID,
+		Expression: def.Expression,
+		JobId:      def.Message.JobID,
+		Params:     params,
+		ScriptPath: def.Message.ScriptPath,
+		Config:     config,
+		Calendar:   def.Calendar,
+		Owner:      def.Owner,
+		Team:       def.Team,
+	}, nil
+}
+
+func scheduleFromProto(pb *adminv1.Schedule) (job.ScheduleDefinition, error) {
+	def := job.ScheduleDefinition{
+		ID:         pb.GetId(),
+		Expression: pb.GetExpression(),
+		Calendar:   pb.GetCalendar(),
+		Owner:      pb.GetOwner(),
+		Team:       pb.GetTeam(),
+		Message: job.ExecutionMessage{
+			JobID:      pb.GetJobId(),
+			ScriptPath: pb.GetScriptPath(),
+			Parameters: pb.GetParams().AsMap(),
+		},
+	}
+	if cfg := pb.GetConfig(); cfg != nil {
+		data, err := json.Marshal(cfg.AsMap())
+		if err != nil {
+			return job.ScheduleDefinition{}, err
+		}
+		if err := json.Unmarshal(data, &def.Message.Config); err != nil {
+			return job.ScheduleDefinition{}, fmt.Errorf("invalid config for schedule %s: %w", def.ID, err)
+		}
+	}
+	return def, nil
+}
+
+func logEntriesToProto(entries []job.RunLogEntry) ([]*adminv1.RunLogEntry, error) {
+	out := make([]*adminv1.RunLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		fields, err := toStruct(entry.Fields)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &adminv1.RunLogEntry{
+			Time:    timestampToProto(entry.Time),
+			Level:   entry.Level.String(),
+			Message: entry.Message,
+			Fields:  fields,
+		})
+	}
+	return out, nil
+}
+
+func bulkToProto(results []job.BulkResult, err error) (*adminv1.BulkResponse, error) {
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &adminv1.BulkResponse{Results: make([]*adminv1.BulkResult, 0, len(results))}
+	for _, result := range results {
+		out := &adminv1.BulkResult{
+			JobId:  result.JobID,
+			RunId:  result.RunID,
+			Status: result.Status,
+		}
+		if result.Error != nil {
+			out.Error = result.Error.Error()
+		}
+		resp.Results = append(resp.Results, out)
+	}
+	return resp, nil
+}
+
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toStruct converts v to a Struct through its JSON form, so maps holding
+// any JSON encodable value (durations, nested structs) convert cleanly.
+func toStruct(v any) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, nil
+	}
+	return structpb.NewStruct(fields)
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	job "github.com/goliatone/go-job"
+	adminv1 "github.com/goliatone/go-job/admin/v1"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newGRPCClient(t *testing.T, svc *Service) adminv1.JobAdminServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterGRPC(server, svc)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return adminv1.NewJobAdminServiceClient(conn)
+}
+
+func TestGRPCServerTriggersAndReportsRuns(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("report", "/jobs/report.js", "js",
+		job.Config{Schedule: "@daily", Timeout: time.Minute, Metadata: map[string]any{"team": "data"}}, "", testEngine{})))
+	client := newGRPCClient(t, NewService(reg, WithRunLogStore(job.NewRunLogStore(16, 4))))
+	ctx := context.Background()
+
+	tasks, err := client.ListTasks(ctx, &adminv1.ListTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, tasks.GetTasks(), 1)
+	assert.Equal(t, "report", tasks.GetTasks()[0].GetId())
+	assert.Equal(t, time.Minute, tasks.GetTasks()[0].GetTimeout().AsDuration())
+	assert.Equal(t, "data", tasks.GetTasks()[0].GetMetadata().AsMap()["team"])
+
+	_, err = client.GetTask(ctx, &adminv1.GetTaskRequest{Id: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	params, err := structpb.NewStruct(map[string]any{"month": "2026-01"})
+	require.NoError(t, err)
+	resp, err := client.Trigger(ctx, &adminv1.TriggerRequest{JobId: "report", Params: params})
+	require.NoError(t, err)
+	assert.Equal(t, RunSucceeded, resp.GetStatus())
+
+	run, err := client.GetRun(ctx, &adminv1.GetRunRequest{RunId: resp.GetRunId()})
+	require.NoError(t, err)
+	assert.Equal(t, "report", run.GetJobId())
+	assert.NotNil(t, run.GetFinishedAt())
+	assert.NotEmpty(t, run.GetLogs())
+
+	_, err = client.ListSchedules(ctx, &adminv1.ListSchedulesRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGRPCServerReconcilesScheduleConfig(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("report", "/jobs/report.js", "js", job.Config{}, "", testEngine{})))
+	cron := job.NewCronManager(reg, jobtest.NewScheduler(jobtest.NewFakeClock(jobtest.DefaultFakeClockStart)))
+	client := newGRPCClient(t, NewService(reg, WithCronManager(cron)))
+	ctx := context.Background()
+
+	config, err := structpb.NewStruct(map[string]any{"duration": float64(30 * time.Second), "retries": 2})
+	require.NoError(t, err)
+	result, err := client.Reconcile(ctx, &adminv1.ReconcileRequest{Schedules: []*adminv1.Schedule{{
+		Id:         "report-nightly",
+		Expression: "0 2 * * *",
+		JobId:      "report",
+		ScriptPath: "/jobs/report.js",
+		Config:     config,
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report-nightly"}, result.GetAdded())
+
+	schedules, err := client.ListSchedules(ctx, &adminv1.ListSchedulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, schedules.GetSchedules(), 1)
+	got := schedules.GetSchedules()[0]
+	assert.Equal(t, "/jobs/report.js", got.GetScriptPath())
+	assert.Equal(t, float64(2), got.GetConfig().AsMap()["retries"])
+	assert.Equal(t, float64(30*time.Second), got.GetConfig().AsMap()["duration"])
+}
+
+func TestGRPCServerRedactsTasksAndSchedules(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("report", "/jobs/report.sql", "sql", job.Config{
+		Metadata: map[string]any{"dsn": "postgres://app:hunter2@db/reports", "owner": "ana"},
+	}, "", testEngine{})))
+	cron := job.NewCronManager(reg, jobtest.NewScheduler(jobtest.NewFakeClock(jobtest.DefaultFakeClockStart)))
+	require.NoError(t, cron.Register(context.Background(), job.ScheduleDefinition{
+		ID:         "report-nightly",
+		Expression: "0 2 * * *",
+		Message: job.ExecutionMessage{
+			JobID:      "report",
+			ScriptPath: "/jobs/report.sql",
+			Parameters: map[string]any{"api_token": "t0k3n", "month": "2026-01"},
+			Config: job.Config{
+				Metadata: map[string]any{"dsn": "postgres://app:hunter2@db/reports"},
+				Env:      map[string]string{"DB_PASSWORD": "hunter2", "REGION": "eu"},
+			},
+		},
+	}))
+	client := newGRPCClient(t, NewService(reg, WithCronManager(cron)))
+	ctx := context.Background()
+
+	tasks, err := client.ListTasks(ctx, &adminv1.ListTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, tasks.GetTasks(), 1)
+	metadata := tasks.GetTasks()[0].GetMetadata().AsMap()
+	assert.Equal(t, job.RedactedValue, metadata["dsn"])
+	assert.Equal(t, "ana", metadata["owner"])
+
+	schedules, err := client.ListSchedules(ctx, &adminv1.ListSchedulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, schedules.GetSchedules(), 1)
+	got := schedules.GetSchedules()[0]
+	assert.Equal(t, job.RedactedValue, got.GetParams().AsMap()["api_token"])
+	assert.Equal(t, "2026-01", got.GetParams().AsMap()["month"])
+	config := got.GetConfig().AsMap()
+	assert.Equal(t, job.RedactedValue, config["metadata"].(map[string]any)["dsn"])
+	assert.Equal(t, map[string]any{"DB_PASSWORD": job.RedactedValue, "REGION": "eu"}, config["env"])
+
+	stored := cron.List()[0].Message
+	assert.Equal(t, "t0k3n", stored.Parameters["api_token"], "listing does not change the stored schedule")
+	assert.Equal(t, "hunter2", stored.Config.Env["DB_PASSWORD"])
+}
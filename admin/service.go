@@ -0,0 +1,618 @@
+// Package admin implements the runner management surface described by
+// admin/v1/admin.proto. Service is transport agnostic; GRPCServer serves it
+// as the generated JobAdminService, and other transports can delegate to it
+// the same way.
+package admin
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	job "github.com/goliatone/go-job"
+)
+
+const defaultMaxRuns = 500
+
+// Run statuses reported by GetRun.
+const (
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	RunDuplicate = "duplicate"
+)
+
+// Task describes a registered task.
+type Task struct {
+	ID         string `json:"id"`
+	ScriptPath string `json:"script_path"`
+	Engine     string `json:"engine,omitempty"`
+	Schedule   string `json:"schedule,omitempty"`
+	// ScheduleText explains Schedule in words, see job.ExplainSchedule.
+	ScheduleText string         `json:"schedule_text,omitempty"`
+	Timeout      time.Duration  `json:"timeout,omitempty"`
+	Retries      int            `json:"retries,omitempty"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
+// TriggerRequest asks the service to execute a job.
+type TriggerRequest struct {
+	JobID          string         `json:"job_id"`
+	Params         map[string]any `json:"params,omitempty"`
+	IdempotencyKey string         `json:"idempotency_key,omitempty"`
+	TraceID        string         `json:"trace_id,omitempty"`
+	Async          bool           `json:"async,omitempty"`
+}
+
+// TriggerResponse reports the run created by Trigger.
+type TriggerResponse struct {
+	RunID  string `json:"run_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Run is the recorded state of a triggered execution.
+type Run struct {
+	RunID      string            `json:"run_id"`
+	JobID      string            `json:"job_id"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	Status     string            `json:"status"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Logs       []job.RunLogEntry `json:"logs,omitempty"`
+}
+
+// Option customises a Service.
+type Option func(*Service)
+
+// WithCronManager enables ListSchedules and Reconcile.
+func WithCronManager(manager *job.CronManager) Option {
+	return func(s *Service) {
+		s.cron = manager
+	}
+}
+
+// WithRunLogStore attaches captured logs to GetRun responses and enables
+// GetRunLogs. Share the store with the runner (job.WithRunLogStore) to also
+// serve the logs of scheduled runs.
+func WithRunLogStore(store *job.RunLogStore) Option {
+	return func(s *Service) {
+		s.logs = store
+	}
+}
+
+// WithExecutionHistory lets GetRun report runs that were not triggered
+// through the service, e.g. scheduled or queued runs. Share the history with
+// the runner (job.WithExecutionHistory).
+func WithExecutionHistory(history job.ExecutionHistory) Option {
+	return func(s *Service) {
+		s.history = history
+	}
+}
+
+// WithRunArchive archives triggered runs and enables Replay.
+func WithRunArchive(archive *job.RunArchive) Option {
+	return func(s *Service) {
+		s.archive = archive
+	}
+}
+
+// WithCommander customises the TaskCommander used by Trigger. Pass
+// Runner.Commander so triggered runs honour the runner's maintenance mode,
+// hooks, run cache and history; by default only task toggles are honoured.
+func WithCommander(fn func(job.Task) *job.TaskCommander) Option {
+	return func(s *Service) {
+		if fn != nil {
+			s.commander = fn
+		}
+	}
+}
+
+// WithAuthorizer guards every operation with policy.
+func WithAuthorizer(policy job.AuthzPolicy) Option {
+	return func(s *Service) {
+		s.authorize = policy
+	}
+}
+
+// WithRedactor sets the sanitizer applied to task metadata and to the params,
+// config metadata and env of listed schedules. Defaults to
+// job.RedactingSanitizer with job.ConfigRedactKeys; nil disables it.
+func WithRedactor(redactor job.EnvelopeSanitizer) Option {
+	return func(s *Service) {
+		s.redactor = redactor
+	}
+}
+
+// WithBulkConcurrency bounds how many jobs TriggerSelector runs at once.
+// Defaults to job.DefaultBulkConcurrency.
+func WithBulkConcurrency(limit int) Option {
+	return func(s *Service) {
+		if limit > 0 {
+			s.bulkLimit = limit
+		}
+	}
+}
+
+// WithMaxRuns bounds how many runs are retained for GetRun.
+func WithMaxRuns(limit int) Option {
+	return func(s *Service) {
+		if limit > 0 {
+			s.maxRuns = limit
+		}
+	}
+}
+
+// Service implements the JobAdminService operations.
+type Service struct {
+	registry  job.Registry
+	cron      *job.CronManager
+	logs      *job.RunLogStore
+	archive   *job.RunArchive
+	history   job.ExecutionHistory
+	commander func(job.Task) *job.TaskCommander
+	authorize job.AuthzPolicy
+	redactor  job.EnvelopeSanitizer
+	maxRuns   int
+	bulkLimit int
+
+	mu    sync.RWMutex
+	runs  map[string]*Run
+	order []string
+}
+
+// NewService creates an admin service backed by registry.
+func NewService(registry job.Registry, opts ...Option) *Service {
+	s := &Service{
+		registry:  registry,
+		commander: job.RegistryCommander(registry),
+		redactor:  job.RedactingSanitizer(job.ConfigRedactKeys...),
+		maxRuns:   defaultMaxRuns,
+		bulkLimit: job.DefaultBulkConcurrency,
+		runs:      make(map[string]*Run),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.logs != nil {
+		commander := s.commander
+		s.commander = func(task job.Task) *job.TaskCommander {
+			return commander(task).WithRunLogStore(s.logs)
+		}
+	}
+	if s.archive != nil {
+		commander := s.commander
+		s.commander = func(task job.Task) *job.TaskCommander {
+			return commander(task).WithRunArchive(s.archive)
+		}
+	}
+	return s
+}
+
+// ListTasks returns registered tasks ordered by ID.
+func (s *Service) ListTasks(ctx context.Context) ([]Task, error) {
+	if err := job.Authorize(ctx, s.authorize, job.AuthzRequest{Action: job.AuthzActionReadJob}); err != nil {
+		return nil, err
+	}
+	if s.registry == nil {
+		return nil, nil
+	}
+	tasks := s.registry.List()
+	out := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil {
+			out = append(out, s.toTask(task))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// GetTask returns a single task.
+func (s *Service) GetTask(ctx context.Context, id string) (Task, error) {
+	task, err := s.lookupAuthorized(ctx, job.AuthzActionReadJob, id)
+	if err != nil {
+		return Task{}, err
+	}
+	return s.toTask(task), nil
+}
+
+// Trigger executes a job, synchronously unless req.Async is set. Params are
+// validated and coerced against the parameters declared in the task metadata
+// (see job.ParamSchema).
+func (s *Service) Trigger(ctx context.Context, req TriggerRequest) (TriggerResponse, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	task, err := s.lookupAuthorized(ctx, job.AuthzActionTriggerJob, req.JobID)
+	if err != nil {
+		return TriggerResponse{}, err
+	}
+
+	params, err := job.ValidateTaskParams(task, req.Params)
+	if err != nil {
+		return TriggerResponse{}, err
+	}
+
+	runID := job.RunIDFromContext(ctx)
+	if runID == "" {
+		runID = job.NewRunID()
+		ctx = job.ContextWithRunID(ctx, runID)
+	}
+
+	msg := &job.ExecutionMessage{
+		JobID:          task.GetID(),
+		Parameters:     params,
+		IdempotencyKey: req.IdempotencyKey,
+		TraceID:        req.TraceID,
+	}
+	if msg.IdempotencyKey != "" {
+		msg.DedupPolicy = job.DedupPolicyDrop
+	}
+
+	run := &Run{RunID: runID, JobID: task.GetID(), TraceID: req.TraceID, Status: RunRunning, StartedAt: time.Now().UTC()}
+	s.track(run)
+	cmd := s.commander(task)
+
+	if req.Async {
+		go func(ctx context.Context) {
+			s.finish(runID, cmd.Execute(ctx, msg))
+		}(context.WithoutCancel(ctx))
+		return TriggerResponse{RunID: runID, Status: RunRunning}, nil
+	}
+
+	status, errMsg := s.finish(runID, cmd.Execute(ctx, msg))
+	return TriggerResponse{RunID: runID, Status: status, Error: errMsg}, nil
+}
+
+// Replay re-executes an archived run with its recorded message and waits for
+// it to finish. It requires WithRunArchive.
+func (s *Service) Replay(ctx context.Context, runID string) (TriggerResponse, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	archived, ok := s.archive.Get(runID)
+	if !ok {
+		// Authorize before reporting that the run is missing, so denied
+		// callers cannot probe for run IDs.
+		if err := job.Authorize(ctx, s.authorize, job.AuthzRequest{Action: job.AuthzActionTriggerJob}); err != nil {
+			return TriggerResponse{}, err
+		}
+		return TriggerResponse{}, errors.New("run not found in archive", errors.CategoryNotFound).
+			WithTextCode("ADMIN_RUN_NOT_ARCHIVED").
+			WithMetadata(map[string]any{"run_id": runID})
+	}
+	task, err := s.lookupAuthorized(ctx, job.AuthzActionTriggerJob, archived.JobID)
+	if err != nil {
+		return TriggerResponse{}, err
+	}
+
+	replayID := job.NewRunID()
+	ctx = job.ContextWithRunID(ctx, replayID)
+
+	run := &Run{RunID: replayID, JobID: task.GetID(), TraceID: archived.Message.TraceID, Status: RunRunning, StartedAt: time.Now().UTC()}
+	s.track(run)
+
+	_, execErr := s.commander(task).Replay(ctx, runID)
+	status, errMsg := s.finish(replayID, execErr)
+	return TriggerResponse{RunID: replayID, Status: status, Error: errMsg}, nil
+}
+
+// TriggerSelector triggers every job matching selector (e.g. "tag=backfill tenant=acme"),
+// at most WithBulkConcurrency at a time, and waits for the runs to finish.
+func (s *Service) TriggerSelector(ctx context.Context, selector string, params map[string]any) ([]job.BulkResult, error) {
+	tasks, err := s.selectTasks(selector)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results := make([]job.BulkResult, len(tasks))
+	slots := make(chan struct{}, s.bulkLimit)
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, task job.Task) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result := job.BulkResult{JobID: task.GetID()}
+			resp, err := s.Trigger(ctx, TriggerRequest{JobID: task.GetID(), Params: params})
+			if err != nil {
+				result.Error = err
+			} else {
+				result.RunID, result.Status = resp.RunID, resp.Status
+				if resp.Error != "" {
+					result.Error = stderrors.New(resp.Error)
+				}
+			}
+			results[i] = result
+		}(i, task)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// PauseSelector pauses scheduled runs for every job matching selector.
+func (s *Service) PauseSelector(ctx context.Context, selector string) ([]job.BulkResult, error) {
+	return s.setPausedSelector(ctx, selector, true)
+}
+
+// ResumeSelector resumes scheduled runs for every job matching selector.
+func (s *Service) ResumeSelector(ctx context.Context, selector string) ([]job.BulkResult, error) {
+	return s.setPausedSelector(ctx, selector, false)
+}
+
+func (s *Service) setPausedSelector(ctx context.Context, selector string, paused bool) ([]job.BulkResult, error) {
+	if s.cron == nil {
+		return nil, errNoCronManager()
+	}
+	tasks, err := s.selectTasks(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "resumed"
+	if paused {
+		status = "paused"
+	}
+	results := make([]job.BulkResult, 0, len(tasks))
+	for _, task := range tasks {
+		result := job.BulkResult{JobID: task.GetID(), Status: status}
+		err := s.authorizeTask(ctx, job.AuthzActionModifySchedule, task)
+		if err == nil {
+			if paused {
+				err = s.cron.PauseJob(ctx, task.GetID())
+			} else {
+				err = s.cron.ResumeJob(ctx, task.GetID())
+			}
+		}
+		if err != nil {
+			result.Status, result.Error = "", err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *Service) selectTasks(raw string) ([]job.Task, error) {
+	selector, err := job.ParseSelector(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CategoryBadInput, "invalid selector").
+			WithTextCode("ADMIN_SELECTOR_INVALID")
+	}
+	if len(selector) == 0 {
+		return nil, errors.NewValidation("selector required",
+			errors.FieldError{Field: "selector", Message: "provide at least one key=value term"},
+		).WithTextCode("ADMIN_SELECTOR_REQUIRED")
+	}
+	return job.SelectTasks(s.registry, selector), nil
+}
+
+// ListSchedules returns the schedules managed by the CronManager, with their
+// params, config metadata and env redacted.
+func (s *Service) ListSchedules(ctx context.Context) ([]job.ScheduleDefinition, error) {
+	if s.cron == nil {
+		return nil, errNoCronManager()
+	}
+	if err := job.Authorize(ctx, s.authorize, job.AuthzRequest{Action: job.AuthzActionReadSchedule}); err != nil {
+		return nil, err
+	}
+	out := s.cron.List()
+	for i := range out {
+		out[i].Message.Parameters = s.redact(out[i].Message.Parameters)
+		out[i].Message.Config = s.redactConfig(out[i].Message.Config)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// Reconcile aligns the CronManager with the desired schedules.
+func (s *Service) Reconcile(ctx context.Context, desired []job.ScheduleDefinition) (job.ReconcileResult, error) {
+	if s.cron == nil {
+		return job.ReconcileResult{}, errNoCronManager()
+	}
+	for _, def := range desired {
+		req := job.AuthzRequest{Action: job.AuthzActionModifySchedule, JobID: def.Message.JobID}
+		if s.registry != nil {
+			if task, ok := s.registry.Get(def.Message.JobID); ok && task != nil {
+				req.Metadata = task.GetConfig().Metadata
+			}
+		}
+		if err := job.Authorize(ctx, s.authorize, req); err != nil {
+			return job.ReconcileResult{}, err
+		}
+	}
+	return s.cron.Reconcile(ctx, desired)
+}
+
+// GetRun returns a run triggered through this service, or recorded in the
+// execution history set with WithExecutionHistory, including captured logs.
+func (s *Service) GetRun(ctx context.Context, runID string) (Run, error) {
+	s.mu.RLock()
+	run, ok := s.runs[runID]
+	var out Run
+	if ok {
+		out = *run
+	}
+	s.mu.RUnlock()
+
+	if !ok && s.history != nil && runID != "" {
+		records, err := s.history.List(ctx, job.HistoryFilter{RunID: runID, Limit: 1})
+		if err != nil {
+			return Run{}, err
+		}
+		if len(records) > 0 {
+			out, ok = fromRunRecord(records[0]), true
+		}
+	}
+	if !ok {
+		return Run{}, errors.New("run not found", errors.CategoryNotFound).
+			WithTextCode("ADMIN_RUN_NOT_FOUND").
+			WithMetadata(map[string]any{"run_id": runID})
+	}
+	if err := job.Authorize(ctx, s.authorize, job.AuthzRequest{Action: job.AuthzActionReadRun, JobID: out.JobID, RunID: runID}); err != nil {
+		return Run{}, err
+	}
+	if entries, found := s.logs.Logs(runID); found {
+		out.Logs = entries
+	}
+	return out, nil
+}
+
+// GetRunLogs returns the captured log of any run recorded in the service's
+// RunLogStore, not only the runs triggered through the service.
+func (s *Service) GetRunLogs(ctx context.Context, runID string) (job.RunLogs, error) {
+	logs, err := s.logs.GetRunLogs(runID)
+	if err != nil {
+		return job.RunLogs{}, err
+	}
+	if err := job.Authorize(ctx, s.authorize, job.AuthzRequest{Action: job.AuthzActionReadRun, JobID: logs.JobID, RunID: runID}); err != nil {
+		return job.RunLogs{}, err
+	}
+	return logs, nil
+}
+
+// lookupAuthorized authorizes action before reporting whether the task
+// exists, so callers denied by role see the same error for missing and
+// existing jobs. Policies see nil metadata for unknown jobs.
+func (s *Service) lookupAuthorized(ctx context.Context, action job.AuthzAction, id string) (job.Task, error) {
+	if id == "" {
+		return nil, errors.NewValidation("task id required",
+			errors.FieldError{Field: "id", Message: "cannot be empty"},
+		).WithTextCode("ADMIN_TASK_ID_REQUIRED")
+	}
+	var task job.Task
+	if s.registry != nil {
+		if found, ok := s.registry.Get(id); ok && found != nil {
+			task = found
+		}
+	}
+	req := job.AuthzRequest{Action: action, JobID: id}
+	if task != nil {
+		req.Metadata = task.GetConfig().Metadata
+	}
+	if err := job.Authorize(ctx, s.authorize, req); err != nil {
+		return nil, err
+	}
+	if task != nil {
+		return task, nil
+	}
+	return nil, errors.New("task not found", errors.CategoryNotFound).
+		WithTextCode("ADMIN_TASK_NOT_FOUND").
+		WithMetadata(map[string]any{"task_id": id})
+}
+
+func (s *Service) authorizeTask(ctx context.Context, action job.AuthzAction, task job.Task) error {
+	return job.Authorize(ctx, s.authorize, job.AuthzRequest{
+		Action:   action,
+		JobID:    task.GetID(),
+		Metadata: task.GetConfig().Metadata,
+	})
+}
+
+func (s *Service) track(run *Run) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs[run.RunID] = run
+	s.order = append(s.order, run.RunID)
+	for len(s.order) > s.maxRuns {
+		delete(s.runs, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func (s *Service) finish(runID string, execErr error) (string, string) {
+	status, errMsg := RunSucceeded, ""
+	switch {
+	case execErr == nil:
+	case stderrors.Is(execErr, job.ErrIdempotentDrop):
+		status = RunDuplicate
+	default:
+		status, errMsg = RunFailed, execErr.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if run, ok := s.runs[runID]; ok {
+		run.Status = status
+		run.Error = errMsg
+		run.FinishedAt = time.Now().UTC()
+	}
+	return status, errMsg
+}
+
+func fromRunRecord(record job.RunRecord) Run {
+	status := record.Status
+	switch status {
+	case job.ResultStatusSucceeded:
+		status = RunSucceeded
+	case job.ResultStatusFailed:
+		status = RunFailed
+	}
+	return Run{
+		RunID:      record.RunID,
+		JobID:      record.JobID,
+		Status:     status,
+		StartedAt:  record.StartedAt,
+		FinishedAt: record.EndedAt,
+		Error:      record.Error,
+	}
+}
+
+func (s *Service) toTask(task job.Task) Task {
+	cfg := task.GetConfig()
+	out := Task{
+		ID:         task.GetID(),
+		ScriptPath: task.GetPath(),
+		Schedule:   cfg.Schedule,
+		Timeout:    cfg.Timeout,
+		Retries:    cfg.Retries,
+		Metadata:   s.redact(cfg.Metadata),
+	}
+	if engine := task.GetEngine(); engine != nil {
+		out.Engine = engine.Name()
+	}
+	if text, err := job.ExplainSchedule(cfg.Schedule); err == nil {
+		out.ScheduleText = text
+	}
+	return out
+}
+
+func (s *Service) redact(values map[string]any) map[string]any {
+	if s.redactor == nil || values == nil {
+		return values
+	}
+	return s.redactor(values)
+}
+
+// redactConfig returns cfg with its metadata and env redacted.
+func (s *Service) redactConfig(cfg job.Config) job.Config {
+	cfg.Metadata = s.redact(cfg.Metadata)
+	if s.redactor == nil || len(cfg.Env) == 0 {
+		return cfg
+	}
+	env := make(map[string]any, len(cfg.Env))
+	for key, value := range cfg.Env {
+		env[key] = value
+	}
+	cfg.Env = make(map[string]string, len(env))
+	for key, value := range s.redactor(env) {
+		cfg.Env[key] = fmt.Sprint(value)
+	}
+	return cfg
+}
+
+func errNoCronManager() error {
+	return errors.New("cron manager not configured", errors.CategoryOperation).
+		WithTextCode("ADMIN_CRON_MANAGER_MISSING")
+}
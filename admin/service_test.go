@@ -0,0 +1,218 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	job "github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEngine struct{ err error }
+
+func (testEngine) Name() string                              { return "test" }
+func (testEngine) ParseJob(string, []byte) (job.Task, error) { return nil, nil }
+func (testEngine) CanHandle(string) bool                     { return true }
+func (e testEngine) Execute(context.Context, *job.ExecutionMessage) error {
+	return e.err
+}
+
+func TestServiceListsAndTriggersTasks(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("b-task", "/jobs/b.js", "js", job.Config{Schedule: "@daily"}, "", testEngine{})))
+	require.NoError(t, reg.Add(job.NewBaseTask("a-task", "/jobs/a.js", "js", job.Config{}, "", testEngine{err: errors.New("boom")})))
+
+	svc := NewService(reg, WithRunLogStore(job.NewRunLogStore(16, 4)))
+	ctx := context.Background()
+
+	tasks, err := svc.ListTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "a-task", tasks[0].ID)
+	assert.Equal(t, "test", tasks[1].Engine)
+
+	task, err := svc.GetTask(ctx, "b-task")
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", task.Schedule)
+
+	_, err = svc.GetTask(ctx, "missing")
+	require.Error(t, err)
+
+	resp, err := svc.Trigger(ctx, TriggerRequest{JobID: "b-task"})
+	require.NoError(t, err)
+	assert.Equal(t, RunSucceeded, resp.Status)
+
+	run, err := svc.GetRun(ctx, resp.RunID)
+	require.NoError(t, err)
+	assert.Equal(t, "b-task", run.JobID)
+	assert.False(t, run.FinishedAt.IsZero())
+	assert.NotEmpty(t, run.Logs)
+
+	resp, err = svc.Trigger(ctx, TriggerRequest{JobID: "a-task"})
+	require.NoError(t, err)
+	assert.Equal(t, RunFailed, resp.Status)
+	assert.Equal(t, "boom", resp.Error)
+
+	_, err = svc.GetRun(ctx, "unknown")
+	require.Error(t, err)
+}
+
+func TestServiceTriggerValidatesParams(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	cfg := job.Config{Metadata: map[string]any{"params": map[string]any{
+		"tenant": map[string]any{"type": "string", "required": true},
+	}}}
+	require.NoError(t, reg.Add(job.NewBaseTask("backfill", "/jobs/backfill.js", "js", cfg, "", testEngine{})))
+	svc := NewService(reg)
+
+	_, err := svc.Trigger(context.Background(), TriggerRequest{JobID: "backfill"})
+	require.ErrorContains(t, err, "missing required parameters: tenant")
+
+	resp, err := svc.Trigger(context.Background(), TriggerRequest{JobID: "backfill", Params: map[string]any{"tenant": "acme"}})
+	require.NoError(t, err)
+	assert.Equal(t, RunSucceeded, resp.Status)
+}
+
+func TestServiceSchedulesRequireCronManager(t *testing.T) {
+	svc := NewService(job.NewMemoryRegistry())
+	_, err := svc.ListSchedules(context.Background())
+	require.Error(t, err)
+	_, err = svc.Reconcile(context.Background(), nil)
+	require.Error(t, err)
+}
+
+func TestServiceEnforcesAuthorizer(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("a-task", "/jobs/a.js", "js", job.Config{}, "", testEngine{})))
+
+	svc := NewService(reg, WithAuthorizer(job.RolePolicy(map[job.AuthzAction][]string{
+		job.AuthzActionReadJob:    {"viewer", "operator"},
+		job.AuthzActionTriggerJob: {"operator"},
+	})))
+
+	viewer := job.ContextWithActor(context.Background(), &job.Actor{ID: "v", Role: "viewer"}, job.Scope{})
+	_, err := svc.ListTasks(viewer)
+	require.NoError(t, err)
+
+	_, err = svc.Trigger(viewer, TriggerRequest{JobID: "a-task"})
+	require.Error(t, err)
+	assert.True(t, job.IsForbidden(err))
+
+	// unknown jobs are authorized first, so denied callers cannot probe IDs
+	_, err = svc.Trigger(viewer, TriggerRequest{JobID: "missing"})
+	assert.True(t, job.IsForbidden(err))
+	anonymous := context.Background()
+	_, err = svc.GetTask(anonymous, "missing")
+	assert.True(t, job.IsForbidden(err))
+	_, err = svc.GetTask(viewer, "missing")
+	require.Error(t, err)
+	assert.False(t, job.IsForbidden(err))
+
+	operator := job.ContextWithActor(context.Background(), &job.Actor{ID: "o", Role: "operator"}, job.Scope{})
+	resp, err := svc.Trigger(operator, TriggerRequest{JobID: "a-task"})
+	require.NoError(t, err)
+	assert.Equal(t, RunSucceeded, resp.Status)
+}
+
+func TestServiceReplayAuthorizesBeforeArchiveLookup(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("a-task", "/jobs/a.js", "js", job.Config{}, "", testEngine{})))
+	svc := NewService(reg, WithRunArchive(job.NewRunArchive(4)), WithAuthorizer(job.RolePolicy(map[job.AuthzAction][]string{
+		job.AuthzActionTriggerJob: {"operator"},
+	})))
+
+	operator := job.ContextWithActor(context.Background(), &job.Actor{ID: "o", Role: "operator"}, job.Scope{})
+	resp, err := svc.Trigger(operator, TriggerRequest{JobID: "a-task"})
+	require.NoError(t, err)
+
+	viewer := job.ContextWithActor(context.Background(), &job.Actor{ID: "v", Role: "viewer"}, job.Scope{})
+	for _, runID := range []string{resp.RunID, "unknown"} {
+		_, err = svc.Replay(viewer, runID)
+		assert.True(t, job.IsForbidden(err), runID)
+	}
+
+	_, err = svc.Replay(operator, "unknown")
+	require.Error(t, err)
+	assert.False(t, job.IsForbidden(err))
+}
+
+func TestServiceReplaysArchivedRun(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("a-task", "/jobs/a.js", "js", job.Config{}, "", testEngine{})))
+
+	svc := NewService(reg, WithRunArchive(job.NewRunArchive(4)))
+	ctx := context.Background()
+
+	resp, err := svc.Trigger(ctx, TriggerRequest{JobID: "a-task", Params: map[string]any{"n": 1}})
+	require.NoError(t, err)
+
+	replay, err := svc.Replay(ctx, resp.RunID)
+	require.NoError(t, err)
+	assert.Equal(t, RunSucceeded, replay.Status)
+	assert.NotEqual(t, resp.RunID, replay.RunID)
+
+	run, err := svc.GetRun(ctx, replay.RunID)
+	require.NoError(t, err)
+	assert.Equal(t, "a-task", run.JobID)
+
+	_, err = svc.Replay(ctx, "unknown")
+	require.Error(t, err)
+}
+
+func TestServiceGetRunLogsServesSharedStore(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	task := job.NewBaseTask("nightly", "/jobs/nightly.js", "js", job.Config{}, "", testEngine{})
+	require.NoError(t, reg.Add(task))
+	store := job.NewRunLogStore(16, 4)
+
+	// A run executed outside the service, e.g. by the cron manager.
+	ctx := job.ContextWithRunID(context.Background(), "scheduled-1")
+	require.NoError(t, job.NewTaskCommander(task).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{}))
+
+	svc := NewService(reg, WithRunLogStore(store))
+	logs, err := svc.GetRunLogs(context.Background(), "scheduled-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", logs.JobID)
+	assert.NotEmpty(t, logs.Entries)
+
+	_, err = svc.GetRunLogs(context.Background(), "missing")
+	require.Error(t, err)
+}
+
+func TestServiceGetRunFallsBackToHistory(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	task := job.NewBaseTask("nightly", "/jobs/nightly.js", "js", job.Config{}, "", testEngine{err: errors.New("boom")})
+	require.NoError(t, reg.Add(task))
+	history := job.NewMemoryHistory(0)
+
+	ctx := job.ContextWithRunID(context.Background(), "scheduled-1")
+	require.Error(t, job.NewTaskCommander(task).WithExecutionHistory(history).Execute(ctx, &job.ExecutionMessage{}))
+
+	run, err := NewService(reg, WithExecutionHistory(history)).GetRun(context.Background(), "scheduled-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", run.JobID)
+	assert.Equal(t, RunFailed, run.Status)
+	assert.Equal(t, "boom", run.Error)
+	assert.False(t, run.FinishedAt.IsZero())
+}
+
+func TestServiceTriggerHonoursRunnerState(t *testing.T) {
+	reg := job.NewMemoryRegistry()
+	require.NoError(t, reg.Add(job.NewBaseTask("sync", "/jobs/sync.js", "js", job.Config{}, "", testEngine{})))
+	require.NoError(t, reg.SetEnabled("sync", false))
+
+	resp, err := NewService(reg).Trigger(context.Background(), TriggerRequest{JobID: "sync"})
+	require.NoError(t, err)
+	assert.Equal(t, RunFailed, resp.Status)
+	assert.Contains(t, resp.Error, "disabled")
+
+	require.NoError(t, reg.SetEnabled("sync", true))
+	runner := job.NewRunner(job.WithRegistry(reg))
+	runner.Maintenance().Enable("upgrade")
+	resp, err = NewService(reg, WithCommander(runner.Commander)).Trigger(context.Background(), TriggerRequest{JobID: "sync"})
+	require.NoError(t, err)
+	assert.Equal(t, RunFailed, resp.Status)
+	assert.Contains(t, resp.Error, "maintenance")
+}
@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: admin.proto
+
+package adminv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JobAdminService_ListTasks_FullMethodName       = "/goliatone.job.admin.v1.JobAdminService/ListTasks"
+	JobAdminService_GetTask_FullMethodName         = "/goliatone.job.admin.v1.JobAdminService/GetTask"
+	JobAdminService_Trigger_FullMethodName         = "/goliatone.job.admin.v1.JobAdminService/Trigger"
+	JobAdminService_ListSchedules_FullMethodName   = "/goliatone.job.admin.v1.JobAdminService/ListSchedules"
+	JobAdminService_Reconcile_FullMethodName       = "/goliatone.job.admin.v1.JobAdminService/Reconcile"
+	JobAdminService_GetRun_FullMethodName          = "/goliatone.job.admin.v1.JobAdminService/GetRun"
+	JobAdminService_GetRunLogs_FullMethodName      = "/goliatone.job.admin.v1.JobAdminService/GetRunLogs"
+	JobAdminService_TriggerSelector_FullMethodName = "/goliatone.job.admin.v1.JobAdminService/TriggerSelector"
+	JobAdminService_PauseSelector_FullMethodName   = "/goliatone.job.admin.v1.JobAdminService/PauseSelector"
+	JobAdminService_ResumeSelector_FullMethodName  = "/goliatone.job.admin.v1.JobAdminService/ResumeSelector"
+)
+
+// JobAdminServiceClient is the client API for JobAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JobAdminService exposes the runner management surface to non-Go control planes.
+// github.com/goliatone/go-job/admin.GRPCServer implements it on top of
+// admin.Service.
+type JobAdminServiceClient interface {
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	Trigger(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error)
+	GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*Run, error)
+	// Logs of any run captured by the shared RunLogStore, including scheduled runs.
+	GetRunLogs(ctx context.Context, in *GetRunLogsRequest, opts ...grpc.CallOption) (*RunLogs, error)
+	// Selector based sweeps, e.g. selector "tag=backfill tenant=acme".
+	TriggerSelector(ctx context.Context, in *TriggerSelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	PauseSelector(ctx context.Context, in *SelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	ResumeSelector(ctx context.Context, in *SelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+}
+
+type jobAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobAdminServiceClient(cc grpc.ClientConnInterface) JobAdminServiceClient {
+	return &jobAdminServiceClient{cc}
+}
+
+func (c *jobAdminServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, JobAdminService_GetTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) Trigger(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_Trigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_Reconcile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*Run, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Run)
+	err := c.cc.Invoke(ctx, JobAdminService_GetRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) GetRunLogs(ctx context.Context, in *GetRunLogsRequest, opts ...grpc.CallOption) (*RunLogs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunLogs)
+	err := c.cc.Invoke(ctx, JobAdminService_GetRunLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) TriggerSelector(ctx context.Context, in *TriggerSelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_TriggerSelector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) PauseSelector(ctx context.Context, in *SelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_PauseSelector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) ResumeSelector(ctx context.Context, in *SelectorRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, JobAdminService_ResumeSelector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobAdminServiceServer is the server API for JobAdminService service.
+// All implementations must embed UnimplementedJobAdminServiceServer
+// for forward compatibility.
+//
+// JobAdminService exposes the runner management surface to non-Go control planes.
+// github.com/goliatone/go-job/admin.GRPCServer implements it on top of
+// admin.Service.
+type JobAdminServiceServer interface {
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetTask(context.Context, *GetTaskRequest) (*Task, error)
+	Trigger(context.Context, *TriggerRequest) (*TriggerResponse, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ReconcileResponse, error)
+	GetRun(context.Context, *GetRunRequest) (*Run, error)
+	// Logs of any run captured by the shared RunLogStore, including scheduled runs.
+	GetRunLogs(context.Context, *GetRunLogsRequest) (*RunLogs, error)
+	// Selector based sweeps, e.g. selector "tag=backfill tenant=acme".
+	TriggerSelector(context.Context, *TriggerSelectorRequest) (*BulkResponse, error)
+	PauseSelector(context.Context, *SelectorRequest) (*BulkResponse, error)
+	ResumeSelector(context.Context, *SelectorRequest) (*BulkResponse, error)
+	mustEmbedUnimplementedJobAdminServiceServer()
+}
+
+// UnimplementedJobAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJobAdminServiceServer struct{}
+
+func (UnimplementedJobAdminServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedJobAdminServiceServer) GetTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedJobAdminServiceServer) Trigger(context.Context, *TriggerRequest) (*TriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trigger not implemented")
+}
+func (UnimplementedJobAdminServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedJobAdminServiceServer) Reconcile(context.Context, *ReconcileRequest) (*ReconcileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedJobAdminServiceServer) GetRun(context.Context, *GetRunRequest) (*Run, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRun not implemented")
+}
+func (UnimplementedJobAdminServiceServer) GetRunLogs(context.Context, *GetRunLogsRequest) (*RunLogs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunLogs not implemented")
+}
+func (UnimplementedJobAdminServiceServer) TriggerSelector(context.Context, *TriggerSelectorRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSelector not implemented")
+}
+func (UnimplementedJobAdminServiceServer) PauseSelector(context.Context, *SelectorRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSelector not implemented")
+}
+func (UnimplementedJobAdminServiceServer) ResumeSelector(context.Context, *SelectorRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSelector not implemented")
+}
+func (UnimplementedJobAdminServiceServer) mustEmbedUnimplementedJobAdminServiceServer() {}
+func (UnimplementedJobAdminServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeJobAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobAdminServiceServer will
+// result in compilation errors.
+type UnsafeJobAdminServiceServer interface {
+	mustEmbedUnimplementedJobAdminServiceServer()
+}
+
+func RegisterJobAdminServiceServer(s grpc.ServiceRegistrar, srv JobAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJobAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JobAdminService_ServiceDesc, srv)
+}
+
+func _JobAdminService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).GetTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_Trigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).Trigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_Trigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).Trigger(ctx, req.(*TriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_Reconcile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_GetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).GetRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_GetRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).GetRun(ctx, req.(*GetRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_GetRunLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).GetRunLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_GetRunLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).GetRunLogs(ctx, req.(*GetRunLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_TriggerSelector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerSelectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).TriggerSelector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_TriggerSelector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).TriggerSelector(ctx, req.(*TriggerSelectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_PauseSelector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).PauseSelector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_PauseSelector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).PauseSelector(ctx, req.(*SelectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_ResumeSelector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).ResumeSelector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobAdminService_ResumeSelector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).ResumeSelector(ctx, req.(*SelectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JobAdminService_ServiceDesc is the grpc.ServiceDesc for JobAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JobAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goliatone.job.admin.v1.JobAdminService",
+	HandlerType: (*JobAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTasks",
+			Handler:    _JobAdminService_ListTasks_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _JobAdminService_GetTask_Handler,
+		},
+		{
+			MethodName: "Trigger",
+			Handler:    _JobAdminService_Trigger_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _JobAdminService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _JobAdminService_Reconcile_Handler,
+		},
+		{
+			MethodName: "GetRun",
+			Handler:    _JobAdminService_GetRun_Handler,
+		},
+		{
+			MethodName: "GetRunLogs",
+			Handler:    _JobAdminService_GetRunLogs_Handler,
+		},
+		{
+			MethodName: "TriggerSelector",
+			Handler:    _JobAdminService_TriggerSelector_Handler,
+		},
+		{
+			MethodName: "PauseSelector",
+			Handler:    _JobAdminService_PauseSelector_Handler,
+		},
+		{
+			MethodName: "ResumeSelector",
+			Handler:    _JobAdminService_ResumeSelector_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
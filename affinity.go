@@ -0,0 +1,30 @@
+package job
+
+import "sort"
+
+// AffinityFor returns the affinity a run of task with msg requires: the
+// task's Config.Affinity overlaid per key by the message's.
+func AffinityFor(task Task, msg *ExecutionMessage) map[string]string {
+	var base, override map[string]string
+	if task != nil {
+		base = task.GetConfig().Affinity
+	}
+	if msg != nil {
+		override = msg.Config.Affinity
+	}
+	return mergeStringMaps(base, override)
+}
+
+// UnmetAffinity lists, sorted, the affinity keys labels does not satisfy. A
+// key is satisfied when labels holds the same value; "*" accepts any value.
+func UnmetAffinity(affinity, labels map[string]string) []string {
+	var unmet []string
+	for key, want := range affinity {
+		got, ok := labels[key]
+		if !ok || (want != "*" && got != want) {
+			unmet = append(unmet, key)
+		}
+	}
+	sort.Strings(unmet)
+	return unmet
+}
@@ -0,0 +1,23 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffinityFromMetadataAndMessage(t *testing.T) {
+	cfg, _, err := job.NewYAMLMetadataParser().Parse([]byte("// config\n// affinity:\n//   gpu: \"true\"\n//   disk: large\n\nconsole.log('train')"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"gpu": "true", "disk": "large"}, cfg.Affinity)
+
+	task := job.NewBaseTask("train", "jobs/train.js", "js", cfg, "", nil)
+	affinity := job.AffinityFor(task, &job.ExecutionMessage{Config: job.Config{Affinity: map[string]string{"disk": "*", "vpn": "corp"}}})
+	assert.Equal(t, map[string]string{"gpu": "true", "disk": "*", "vpn": "corp"}, affinity)
+
+	assert.Empty(t, job.UnmetAffinity(affinity, map[string]string{"gpu": "true", "disk": "ssd", "vpn": "corp"}))
+	assert.Equal(t, []string{"gpu", "vpn"}, job.UnmetAffinity(affinity, map[string]string{"gpu": "false", "disk": "ssd"}))
+	assert.Empty(t, job.UnmetAffinity(nil, nil))
+}
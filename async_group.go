@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultAsyncLimit bounds how many background runs a trigger handler keeps
+// in flight.
+const DefaultAsyncLimit = 64
+
+// asyncGroup runs background work with a bound on how many run at once and
+// a way to wait for them on shutdown.
+type asyncGroup struct {
+	slots  chan struct{}
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func newAsyncGroup(limit int) *asyncGroup {
+	if limit <= 0 {
+		limit = DefaultAsyncLimit
+	}
+	return &asyncGroup{slots: make(chan struct{}, limit)}
+}
+
+// Go starts fn in the background. It reports false without starting fn when
+// the limit is reached or the group is closed.
+func (g *asyncGroup) Go(fn func()) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return false
+	}
+	select {
+	case g.slots <- struct{}{}:
+	default:
+		return false
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			<-g.slots
+			g.wg.Done()
+		}()
+		fn()
+	}()
+	return true
+}
+
+// Close stops accepting work and waits for running work to finish or ctx to
+// end, whichever comes first.
+func (g *asyncGroup) Close(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
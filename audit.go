@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AuditAction identifies the operation captured by an AuditEntry.
+type AuditAction string
+
+const (
+	AuditActionScheduleRegistered AuditAction = "schedule.registered"
+	AuditActionScheduleUpdated    AuditAction = "schedule.updated"
+	AuditActionScheduleDeleted    AuditAction = "schedule.deleted"
+	AuditActionJobTriggered       AuditAction = "job.triggered"
+	AuditActionJobDenied          AuditAction = "job.denied"
+	AuditActionJobDisabled        AuditAction = "job.disabled"
+	AuditActionJobPaused          AuditAction = "job.paused"
+	AuditActionJobResumed         AuditAction = "job.resumed"
+)
+
+// AuditEntry records who changed a schedule or triggered a job, and what changed.
+type AuditEntry struct {
+	Action     AuditAction         `json:"action"`
+	Actor      *Actor              `json:"actor,omitempty"`
+	Scope      Scope               `json:"scope,omitempty"`
+	ScheduleID string              `json:"schedule_id,omitempty"`
+	JobID      string              `json:"job_id,omitempty"`
+	Before     *ScheduleDefinition `json:"before,omitempty"`
+	After      *ScheduleDefinition `json:"after,omitempty"`
+	Metadata   map[string]any      `json:"metadata,omitempty"`
+	Timestamp  time.Time           `json:"timestamp"`
+}
+
+// AuditSink persists audit entries. Implementations should be safe for concurrent use.
+type AuditSink interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditSinkFunc adapts a function into an AuditSink.
+type AuditSinkFunc func(ctx context.Context, entry AuditEntry) error
+
+// Record satisfies AuditSink.
+func (f AuditSinkFunc) Record(ctx context.Context, entry AuditEntry) error {
+	if f == nil {
+		return nil
+	}
+	return f(ctx, entry)
+}
+
+// MemoryAuditSink keeps audit entries in memory, mainly for tests and local tooling.
+type MemoryAuditSink struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// NewMemoryAuditSink returns an empty in-memory audit sink.
+func NewMemoryAuditSink() *MemoryAuditSink {
+	return &MemoryAuditSink{}
+}
+
+// Record appends the entry.
+func (s *MemoryAuditSink) Record(_ context.Context, entry AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// Entries returns a copy of the recorded entries in insertion order.
+func (s *MemoryAuditSink) Entries() []AuditEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]AuditEntry(nil), s.entries...)
+}
+
+// recordAudit fills actor/scope from the context and writes the entry. Audit is
+// best-effort: sink failures never block the audited operation.
+func recordAudit(ctx context.Context, sink AuditSink, entry AuditEntry) {
+	if sink == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if entry.Actor == nil {
+		if actor, scope, ok := ActorFromContext(ctx); ok {
+			entry.Actor = actor
+			if entry.Scope.isEmpty() {
+				entry.Scope = scope
+			}
+		}
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	_ = sink.Record(ctx, entry)
+}
+
+func auditScheduleSnapshot(def ScheduleDefinition) *ScheduleDefinition {
+	snapshot := cloneScheduleDefinition(def)
+	return &snapshot
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronManagerAuditsScheduleChangesWithActor(t *testing.T) {
+	reg := newStubRegistry()
+	task := newStubTask("job-1", Config{Schedule: "@hourly"})
+	require.NoError(t, reg.Add(task))
+
+	sink := NewMemoryAuditSink()
+	manager := NewCronManager(reg, newStubScheduler()).WithAuditSink(sink)
+
+	ctx := ContextWithActor(context.Background(), &Actor{ID: "user-1", Role: "admin"}, Scope{TenantID: "acme"})
+	def := ScheduleDefinition{ID: "nightly", Expression: "0 0 * * *", Message: ExecutionMessage{JobID: task.GetID()}}
+
+	require.NoError(t, manager.Register(ctx, def))
+	def.Expression = "0 1 * * *"
+	require.NoError(t, manager.Update(ctx, def))
+	require.NoError(t, manager.Delete(context.Background(), def.ID))
+
+	entries := sink.Entries()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, AuditActionScheduleRegistered, entries[0].Action)
+	require.NotNil(t, entries[0].Actor)
+	assert.Equal(t, "user-1", entries[0].Actor.ID)
+	assert.Equal(t, "acme", entries[0].Scope.TenantID)
+	assert.Nil(t, entries[0].Before)
+	require.NotNil(t, entries[0].After)
+	assert.Equal(t, "0 0 * * *", entries[0].After.Expression)
+
+	assert.Equal(t, AuditActionScheduleUpdated, entries[1].Action)
+	assert.Equal(t, "0 0 * * *", entries[1].Before.Expression)
+	assert.Equal(t, "0 1 * * *", entries[1].After.Expression)
+
+	assert.Equal(t, AuditActionScheduleDeleted, entries[2].Action)
+	assert.Nil(t, entries[2].Actor)
+	assert.Equal(t, "job-1", entries[2].JobID)
+	assert.False(t, entries[2].Timestamp.IsZero())
+}
+
+func TestTaskCommanderAuditsManualTrigger(t *testing.T) {
+	sink := NewMemoryAuditSink()
+	task := newStubTask("job-1", Config{})
+	cmd := NewTaskCommander(task).WithAuditSink(sink)
+
+	ctx := ContextWithActor(context.Background(), &Actor{ID: "ops"}, Scope{})
+	require.NoError(t, cmd.Execute(ctx, &ExecutionMessage{IdempotencyKey: "k-1"}))
+
+	entries := sink.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, AuditActionJobTriggered, entries[0].Action)
+	assert.Equal(t, "job-1", entries[0].JobID)
+	assert.Equal(t, "ops", entries[0].Actor.ID)
+	assert.Equal(t, "k-1", entries[0].Metadata["idempotency_key"])
+}
@@ -0,0 +1,145 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/goliatone/go-errors"
+)
+
+// AuthzAction names an operation guarded by an AuthzPolicy.
+type AuthzAction string
+
+const (
+	AuthzActionTriggerJob     AuthzAction = "job.trigger"
+	AuthzActionReadJob        AuthzAction = "job.read"
+	AuthzActionReadRun        AuthzAction = "run.read"
+	AuthzActionReadSchedule   AuthzAction = "schedule.read"
+	AuthzActionModifySchedule AuthzAction = "schedule.modify"
+)
+
+// AuthzRequest describes who is attempting what. Metadata carries the target
+// task's config metadata (when known) so policies can, for example, restrict
+// jobs labelled `environment: production`.
+type AuthzRequest struct {
+	Action   AuthzAction
+	Actor    *Actor
+	Scope    Scope
+	JobID    string
+	RunID    string
+	Metadata map[string]any
+}
+
+// AuthzPolicy returns nil when the request is allowed.
+type AuthzPolicy func(ctx context.Context, req AuthzRequest) error
+
+// Authorize evaluates policy, filling the actor/scope from ctx when missing.
+// A nil policy allows everything.
+func Authorize(ctx context.Context, policy AuthzPolicy, req AuthzRequest) error {
+	if policy == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if req.Actor == nil {
+		if actor, scope, ok := ActorFromContext(ctx); ok {
+			req.Actor = actor
+			if req.Scope.isEmpty() {
+				req.Scope = scope
+			}
+		}
+	}
+	return policy(ctx, req)
+}
+
+// RolePolicy allows an action when the actor's Role, or its ResourceRoles entry
+// for the target job, is listed for that action. Actions without rules are denied.
+func RolePolicy(rules map[AuthzAction][]string) AuthzPolicy {
+	return func(_ context.Context, req AuthzRequest) error {
+		if req.Actor == nil {
+			return forbidden(req, "actor required")
+		}
+		allowed := rules[req.Action]
+		for _, role := range allowed {
+			if role == req.Actor.Role {
+				return nil
+			}
+			if req.JobID != "" && req.Actor.ResourceRoles[req.JobID] == role {
+				return nil
+			}
+		}
+		return forbidden(req, "role not permitted")
+	}
+}
+
+// AllPolicies requires every policy to allow the request.
+func AllPolicies(policies ...AuthzPolicy) AuthzPolicy {
+	return func(ctx context.Context, req AuthzRequest) error {
+		for _, policy := range policies {
+			if policy == nil {
+				continue
+			}
+			if err := policy(ctx, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// IsForbidden reports whether err is an authorization failure.
+func IsForbidden(err error) bool {
+	return errors.IsCategory(err, errors.CategoryAuthz)
+}
+
+func forbidden(req AuthzRequest, reason string) error {
+	metadata := map[string]any{
+		"action": string(req.Action),
+		"reason": reason,
+	}
+	if req.JobID != "" {
+		metadata["job_id"] = req.JobID
+	}
+	if req.Actor != nil {
+		metadata["actor_id"] = req.Actor.ID
+	}
+	return errors.New("forbidden", errors.CategoryAuthz).
+		WithTextCode("JOB_FORBIDDEN").
+		WithMetadata(metadata)
+}
+
+// AuthzMiddleware guards an HTTP handler with policy. The actor is taken from the
+// request context (see ContextWithActor) or resolved through the adapter when set.
+// resolveJob may be nil for endpoints not addressing a single job.
+func AuthzMiddleware(policy AuthzPolicy, action AuthzAction, adapter *GoAuthAdapter, resolveJob func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if _, _, ok := ActorFromContext(ctx); !ok && adapter != nil {
+				env := adapter.AttachActor(ctx, Envelope{})
+				if env.Actor != nil {
+					ctx = ContextWithEnvelope(ctx, env)
+					r = r.WithContext(ctx)
+				}
+			}
+
+			req := AuthzRequest{Action: action}
+			if resolveJob != nil {
+				req.JobID = resolveJob(r)
+			}
+			if err := Authorize(ctx, policy, req); err != nil {
+				writeForbidden(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeForbidden(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolePolicy(t *testing.T) {
+	policy := RolePolicy(map[AuthzAction][]string{
+		AuthzActionTriggerJob: {"operator"},
+	})
+
+	operator := ContextWithActor(context.Background(), &Actor{ID: "u1", Role: "operator"}, Scope{})
+	viewer := ContextWithActor(context.Background(), &Actor{ID: "u2", Role: "viewer", ResourceRoles: map[string]string{"report": "operator"}}, Scope{})
+
+	assert.NoError(t, Authorize(operator, policy, AuthzRequest{Action: AuthzActionTriggerJob, JobID: "sync"}))
+	assert.NoError(t, Authorize(viewer, policy, AuthzRequest{Action: AuthzActionTriggerJob, JobID: "report"}))
+
+	err := Authorize(viewer, policy, AuthzRequest{Action: AuthzActionTriggerJob, JobID: "sync"})
+	require.Error(t, err)
+	assert.True(t, IsForbidden(err))
+
+	assert.Error(t, Authorize(operator, policy, AuthzRequest{Action: AuthzActionModifySchedule}))
+	assert.Error(t, Authorize(context.Background(), policy, AuthzRequest{Action: AuthzActionTriggerJob}))
+	assert.NoError(t, Authorize(context.Background(), nil, AuthzRequest{Action: AuthzActionTriggerJob}))
+}
+
+func TestWebhookTriggerRejectsUnauthorizedActors(t *testing.T) {
+	reg := newStubRegistry()
+	task := &capturingTask{stubTask: newStubTask("deploy", Config{Metadata: map[string]any{"environment": "production"}})}
+	require.NoError(t, reg.Add(task))
+
+	productionAdmins := func(_ context.Context, req AuthzRequest) error {
+		if req.Metadata["environment"] == "production" && (req.Actor == nil || req.Actor.Role != "admin") {
+			return forbidden(req, "production requires admin")
+		}
+		return nil
+	}
+	auth := GoAuthAdapter{Authenticator: staticAuthenticator{actor: map[string]any{"actor_id": "dev", "role": "developer"}}}
+	handler := NewWebhookTriggerHandler(reg, WithWebhookAuthAdapter(auth), WithWebhookAuthorizer(productionAdmins))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=deploy", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, task.msgs)
+}
+
+func TestAuthzMiddleware(t *testing.T) {
+	policy := RolePolicy(map[AuthzAction][]string{AuthzActionReadRun: {"viewer"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	allowed := AuthzMiddleware(policy, AuthzActionReadRun, &GoAuthAdapter{
+		Authenticator: staticAuthenticator{actor: map[string]any{"actor_id": "v", "role": "viewer"}},
+	}, nil)(next)
+	rec := httptest.NewRecorder()
+	allowed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/runs/1/events", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	denied := AuthzMiddleware(policy, AuthzActionReadRun, nil, nil)(next)
+	rec = httptest.NewRecorder()
+	denied.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/runs/1/events", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
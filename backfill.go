@@ -0,0 +1,229 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// Default parameter names carrying a backfill window.
+const (
+	DefaultBackfillStartParam = "window_start"
+	DefaultBackfillEndParam   = "window_end"
+)
+
+// maxBackfillWindows guards against ranges accidentally split into millions
+// of runs, e.g. a step given in nanoseconds.
+const maxBackfillWindows = 10000
+
+// ErrBackfillFailed is returned when any backfill window failed or was skipped.
+var ErrBackfillFailed = errors.New("backfill failed", errors.CategoryInternal).
+	WithTextCode("JOB_BACKFILL_FAILED")
+
+// BackfillWindow is one [Start, End) slice of a backfill range.
+type BackfillWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// BackfillWindows splits [from, to) into consecutive windows of step; the last
+// window ends at to.
+func BackfillWindows(from, to time.Time, step time.Duration) ([]BackfillWindow, error) {
+	if step <= 0 {
+		return nil, errors.NewValidation("invalid backfill range",
+			errors.FieldError{Field: "step", Message: "must be positive", Value: step.String()},
+		).WithTextCode("JOB_BACKFILL_INVALID")
+	}
+	if !from.Before(to) {
+		return nil, errors.NewValidation("invalid backfill range",
+			errors.FieldError{Field: "to", Message: "must be after from", Value: to.String()},
+		).WithTextCode("JOB_BACKFILL_INVALID")
+	}
+	if count := (to.Sub(from) + step - 1) / step; count > maxBackfillWindows {
+		return nil, errors.NewValidation("invalid backfill range",
+			errors.FieldError{Field: "step", Message: fmt.Sprintf("splits the range into %d windows, more than %d", count, maxBackfillWindows), Value: step.String()},
+		).WithTextCode("JOB_BACKFILL_INVALID")
+	}
+
+	var windows []BackfillWindow
+	for start := from; start.Before(to); start = start.Add(step) {
+		end := start.Add(step)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, BackfillWindow{Start: start, End: end})
+	}
+	return windows, nil
+}
+
+// BackfillOptions configures a backfill.
+type BackfillOptions struct {
+	// Parallelism bounds how many windows run at once; defaults to 1, running
+	// windows in order.
+	Parallelism int
+	// Mode defaults to BatchBestEffort.
+	Mode BatchMode
+	// StartParam and EndParam name the parameters carrying the window bounds,
+	// formatted with Layout. Default to window_start, window_end and RFC3339.
+	StartParam string
+	EndParam   string
+	Layout     string
+	// DedupPolicy applies to the per-window idempotency keys. Defaults to
+	// DedupPolicyMerge, so repeating a backfill with the same Tracker reuses
+	// the outcome of windows that already ran; DedupPolicyReplace forces them
+	// to run again.
+	DedupPolicy DeduplicationPolicy
+	// Tracker records which windows ran. When nil, each BackfillTask call
+	// uses a fresh tracker, so only windows repeated within the call merge;
+	// Runner.Backfill shares one tracker across the runner's backfills.
+	Tracker *IdempotencyTracker
+	// Commander builds the commander for each window. Defaults to
+	// NewTaskCommander. Commanders keep their own tracker unless Tracker is
+	// set.
+	Commander func(Task) *TaskCommander
+}
+
+func (o BackfillOptions) withDefaults() BackfillOptions {
+	if o.Parallelism <= 0 {
+		o.Parallelism = 1
+	}
+	if o.StartParam == "" {
+		o.StartParam = DefaultBackfillStartParam
+	}
+	if o.EndParam == "" {
+		o.EndParam = DefaultBackfillEndParam
+	}
+	if o.Layout == "" {
+		o.Layout = time.RFC3339
+	}
+	if o.DedupPolicy == "" {
+		o.DedupPolicy = DedupPolicyMerge
+	}
+	build := o.Commander
+	tracker := o.Tracker
+	if build == nil {
+		build = NewTaskCommander
+		if tracker == nil {
+			tracker = NewIdempotencyTracker()
+		}
+	}
+	if tracker != nil {
+		o.Commander = func(task Task) *TaskCommander {
+			return build(task).WithIdempotencyTracker(tracker)
+		}
+	} else {
+		o.Commander = build
+	}
+	return o
+}
+
+// BackfillResult aggregates a backfill; Windows[i] is the window of Results[i].
+type BackfillResult struct {
+	BatchResult
+	Windows []BackfillWindow `json:"windows"`
+}
+
+// FailedWindows lists the windows that failed, in order.
+func (r BackfillResult) FailedWindows() []BackfillWindow {
+	var windows []BackfillWindow
+	for i, res := range r.Results {
+		if res.Result.Status == ResultStatusFailed {
+			windows = append(windows, r.Windows[i])
+		}
+	}
+	return windows
+}
+
+// BackfillIdempotencyKey is the idempotency key of one backfill window.
+func BackfillIdempotencyKey(jobID string, window BackfillWindow) string {
+	return fmt.Sprintf("backfill:%s:%s/%s", jobID,
+		window.Start.UTC().Format(time.RFC3339Nano), window.End.UTC().Format(time.RFC3339Nano))
+}
+
+// BackfillTask runs task once per step-sized window of [from, to), with the
+// window bounds added to params and a per-window idempotency key. The
+// returned error (JOB_BACKFILL_FAILED) is non-nil when any window failed or
+// was skipped; the BackfillResult is always complete.
+func BackfillTask(ctx context.Context, task Task, from, to time.Time, step time.Duration, params map[string]any, opts BackfillOptions) (BackfillResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if task == nil {
+		return BackfillResult{}, fmt.Errorf("task is nil")
+	}
+	windows, err := BackfillWindows(from, to, step)
+	if err != nil {
+		return BackfillResult{}, err
+	}
+	opts = opts.withDefaults()
+	jobID := task.GetID()
+
+	started := time.Now()
+	results := runBatch(ctx, len(windows), opts.Parallelism, opts.Mode,
+		func(int) string { return jobID },
+		func(ctx context.Context, i int) BatchTaskResult {
+			window := windows[i]
+			msg := &ExecutionMessage{
+				Parameters:     cloneParams(params),
+				IdempotencyKey: BackfillIdempotencyKey(jobID, window),
+				DedupPolicy:    opts.DedupPolicy,
+			}
+			if msg.Parameters == nil {
+				msg.Parameters = make(map[string]any, 2)
+			}
+			msg.Parameters[opts.StartParam] = window.Start.Format(opts.Layout)
+			msg.Parameters[opts.EndParam] = window.End.Format(opts.Layout)
+			return runBatchTask(ctx, opts.Commander(task), task, msg)
+		})
+
+	backfill := BackfillResult{BatchResult: summarizeBatch(results, started), Windows: windows}
+	if backfill.Failed == 0 && backfill.Skipped == 0 {
+		return backfill, nil
+	}
+
+	failed := make([]string, 0, backfill.Failed)
+	for _, window := range backfill.FailedWindows() {
+		failed = append(failed, window.Start.Format(opts.Layout))
+	}
+	failure := errors.New(fmt.Sprintf("backfill %s: %d of %d windows failed, %d skipped", jobID, backfill.Failed, len(windows), backfill.Skipped), errors.CategoryInternal).
+		WithTextCode("JOB_BACKFILL_FAILED").
+		WithMetadata(map[string]any{
+			"job_id":    jobID,
+			"failed":    failed,
+			"succeeded": backfill.Succeeded,
+			"skipped":   backfill.Skipped,
+		})
+	failure.Source = ErrBackfillFailed
+	return backfill, failure
+}
+
+// WithBackfillOptions sets the options Runner.Backfill uses.
+func WithBackfillOptions(opts BackfillOptions) Option {
+	return func(r *Runner) {
+		r.backfill = opts
+	}
+}
+
+// Backfill runs the registered job once per step-sized window of [from, to);
+// see BackfillTask. Options come from WithBackfillOptions, with windows run
+// sequentially by default.
+func (r *Runner) Backfill(ctx context.Context, jobID string, from, to time.Time, step time.Duration, params map[string]any) (BackfillResult, error) {
+	if r == nil || r.registry == nil {
+		return BackfillResult{}, fmt.Errorf("runner registry not configured")
+	}
+	task, ok := r.registry.Get(jobID)
+	if !ok || task == nil {
+		return BackfillResult{}, errors.New(fmt.Sprintf("job %q not found", jobID), errors.CategoryNotFound).
+			WithTextCode("JOB_NOT_FOUND")
+	}
+	opts := r.backfill
+	if opts.Commander == nil {
+		opts.Commander = r.commander
+		if opts.Tracker == nil {
+			opts.Tracker = r.backfillTracker
+		}
+	}
+	return BackfillTask(ctx, task, from, to, step, params, opts)
+}
@@ -0,0 +1,130 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type windowEngine struct {
+	noopEngine
+	mu      sync.Mutex
+	windows []string
+	fail    map[string]bool
+}
+
+func (e *windowEngine) Execute(_ context.Context, msg *job.ExecutionMessage) error {
+	start, _ := msg.Parameters[job.DefaultBackfillStartParam].(string)
+	e.mu.Lock()
+	e.windows = append(e.windows, start+"|"+msg.Parameters["tenant"].(string))
+	e.mu.Unlock()
+	if e.fail[start] {
+		return stderrors.New("boom " + start)
+	}
+	return nil
+}
+
+func TestBackfillWindows(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows, err := job.BackfillWindows(from, from.Add(60*time.Hour), 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, windows, 3)
+	assert.Equal(t, from.Add(48*time.Hour), windows[2].Start)
+	assert.Equal(t, from.Add(60*time.Hour), windows[2].End)
+
+	_, err = job.BackfillWindows(from, from, time.Hour)
+	assert.Error(t, err)
+	_, err = job.BackfillWindows(from, from.Add(time.Hour), 0)
+	assert.Error(t, err)
+	_, err = job.BackfillWindows(from, from.Add(time.Hour), time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestBackfillTaskRunsEachWindowOnce(t *testing.T) {
+	engine := &windowEngine{fail: map[string]bool{"2026-01-02T00:00:00Z": true}}
+	task := job.NewBaseTask("daily-rollup", "jobs/daily-rollup.js", "js", job.Config{}, "", engine)
+	tracker := job.NewIdempotencyTracker()
+	opts := job.BackfillOptions{Commander: func(t job.Task) *job.TaskCommander {
+		return job.NewTaskCommander(t).WithIdempotencyTracker(tracker)
+	}}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := map[string]any{"tenant": "acme"}
+
+	result, err := job.BackfillTask(context.Background(), task, from, from.AddDate(0, 0, 3), 24*time.Hour, params, opts)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, job.ErrBackfillFailed)
+	assert.Equal(t, []string{
+		"2026-01-01T00:00:00Z|acme",
+		"2026-01-02T00:00:00Z|acme",
+		"2026-01-03T00:00:00Z|acme",
+	}, engine.windows)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []job.BackfillWindow{{Start: from.AddDate(0, 0, 1), End: from.AddDate(0, 0, 2)}}, result.FailedWindows())
+	assert.Equal(t, map[string]any{"tenant": "acme"}, params)
+
+	engine.windows = nil
+	result, err = job.BackfillTask(context.Background(), task, from, from.AddDate(0, 0, 3), 24*time.Hour, params, opts)
+	require.Error(t, err)
+	assert.Empty(t, engine.windows, "completed windows are merged, not rerun")
+	assert.Equal(t, 2, result.Succeeded, "merged windows keep their earlier outcome")
+	assert.Equal(t, 1, result.Failed)
+	assert.Zero(t, result.Skipped)
+
+	opts.DedupPolicy = job.DedupPolicyReplace
+	delete(engine.fail, "2026-01-02T00:00:00Z")
+	result, err = job.BackfillTask(context.Background(), task, from, from.AddDate(0, 0, 3), 24*time.Hour, params, opts)
+	require.NoError(t, err)
+	assert.Len(t, engine.windows, 3)
+	assert.Equal(t, 3, result.Succeeded)
+}
+
+func TestBackfillTaskRerunOfCompletedRange(t *testing.T) {
+	engine := &windowEngine{}
+	task := job.NewBaseTask("hourly-rollup", "jobs/hourly-rollup.js", "js", job.Config{}, "", engine)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := map[string]any{"tenant": "acme"}
+
+	opts := job.BackfillOptions{Tracker: job.NewIdempotencyTracker()}
+	for i := 0; i < 2; i++ {
+		result, err := job.BackfillTask(context.Background(), task, from, from.Add(2*time.Hour), time.Hour, params, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Succeeded)
+	}
+	assert.Len(t, engine.windows, 2, "the rerun merges into the completed windows")
+
+	engine.windows = nil
+	for i := 0; i < 2; i++ {
+		_, err := job.BackfillTask(context.Background(), task, from, from.Add(2*time.Hour), time.Hour, params, job.BackfillOptions{})
+		require.NoError(t, err)
+	}
+	assert.Len(t, engine.windows, 4, "without a tracker each call starts fresh")
+}
+
+func TestRunnerBackfillMergesCompletedWindows(t *testing.T) {
+	engine := &windowEngine{}
+	task := job.NewBaseTask("hourly-export", "jobs/hourly-export.js", "js", job.Config{}, "", engine)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(task))
+	runner := job.NewRunner(job.WithRegistry(registry))
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := map[string]any{"tenant": "acme"}
+
+	for i := 0; i < 2; i++ {
+		result, err := runner.Backfill(context.Background(), "hourly-export", from, from.Add(time.Hour), time.Hour, params)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+	}
+	assert.Len(t, engine.windows, 1)
+
+	other := job.NewRunner(job.WithRegistry(registry))
+	_, err := other.Backfill(context.Background(), "hourly-export", from, from.Add(time.Hour), time.Hour, params)
+	require.NoError(t, err)
+	assert.Len(t, engine.windows, 2, "runners do not share backfill state")
+}
@@ -23,6 +23,13 @@ type BaseEngine struct {
 	logger         Logger
 	loggerProvider LoggerProvider
 	taskIDProvider TaskIDProvider
+	configDefaults ConfigDefaults
+	secrets        SecretsProvider
+	profiles       ProfileStore
+	invoker        JobInvoker
+	globalEnv      map[string]string
+	scripts        *ScriptCache
+	clock          Clock
 }
 
 func NewBaseEngine(self Engine, engingeType string, exts ...string) *BaseEngine {
@@ -37,6 +44,7 @@ func NewBaseEngine(self Engine, engingeType string, exts ...string) *BaseEngine
 		loggerProvider: provider,
 		logger:         provider.GetLogger("job:engine:" + engingeType),
 		taskIDProvider: DefaultTaskIDProvider,
+		scripts:        NewScriptCache(),
 	}
 }
 
@@ -64,14 +72,20 @@ func (e *BaseEngine) ParseJob(path string, content []byte) (Task, error) {
 		return nil, fmt.Errorf("failed to parse metadata: %w", err)
 	}
 
+	config = e.configDefaults.apply(e.EngineType, config)
+
 	provider := e.taskIDProvider
 	if provider == nil {
 		provider = DefaultTaskIDProvider
 	}
 	jobID := provider(path)
+	checksum := ScriptChecksum(content)
+	e.scripts.Put(path, checksum, scriptContent)
+
 	job := NewBaseTask(jobID, path, e.EngineType, config, scriptContent, e.Self)
 	if bt, ok := job.(*baseTask); ok {
 		bt.logger = e.taskLogger(path)
+		bt.scriptChecksum = checksum
 	}
 	return job, nil
 }
@@ -81,6 +95,69 @@ func (e *BaseEngine) SetTaskIDProvider(provider TaskIDProvider) {
 	e.taskIDProvider = provider
 }
 
+// SetConfigDefaults sets the site-wide config applied beneath script metadata.
+func (e *BaseEngine) SetConfigDefaults(defaults ConfigDefaults) {
+	e.configDefaults = defaults
+}
+
+// SetSecretsProvider sets the provider resolving `${secret:...}` references.
+func (e *BaseEngine) SetSecretsProvider(provider SecretsProvider) {
+	e.secrets = provider
+}
+
+// SetProfileStore sets the store resolving the `profile` metadata key.
+func (e *BaseEngine) SetProfileStore(store ProfileStore) {
+	e.profiles = store
+}
+
+// SetJobInvoker sets the invoker behind script-to-script invocation.
+func (e *BaseEngine) SetJobInvoker(invoker JobInvoker) {
+	e.invoker = invoker
+}
+
+// resolveEnv layers the global env, the env of the script's connection
+// profile and the script env, later layers winning, and resolves secret
+// references in all three.
+func (e *BaseEngine) resolveEnv(ctx context.Context, msg *ExecutionMessage) (map[string]string, error) {
+	env, err := resolveSecretEnv(ctx, e.secrets, msg.Config.Env)
+	if err != nil {
+		return nil, err
+	}
+	profile, ok, err := resolveProfile(ctx, e.profiles, e.secrets, msg.Config)
+	if err != nil {
+		return env, err
+	}
+	global, err := resolveSecretEnv(ctx, e.secrets, e.globalEnv)
+	if err != nil {
+		return nil, fmt.Errorf("global %w", err)
+	}
+	if len(global) == 0 && (!ok || len(profile.Env) == 0) {
+		return env, nil
+	}
+	merged := make(map[string]string, len(global)+len(profile.Env)+len(env))
+	for _, layer := range []map[string]string{global, profile.Env, env} {
+		for k, v := range layer {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// SetScriptCache replaces the cache of parsed script bodies; nil disables caching.
+func (e *BaseEngine) SetScriptCache(cache *ScriptCache) {
+	e.scripts = cache
+}
+
+// ScriptCache returns the cache of parsed script bodies, nil when disabled.
+func (e *BaseEngine) ScriptCache() *ScriptCache {
+	return e.scripts
+}
+
+// SetClock sets the time source used to compute remaining execution time.
+func (e *BaseEngine) SetClock(clock Clock) {
+	e.clock = clock
+}
+
 // SetLogger replaces the engine logger, falling back to the default provider when nil.
 func (e *BaseEngine) SetLogger(logger Logger) {
 	if logger == nil {
@@ -122,6 +199,33 @@ func (e *BaseEngine) taskLogger(scriptPath string) Logger {
 	return logger
 }
 
+// executionLogger scopes the engine logger to a single execution, adding the
+// run fields and log capture carried by ctx.
+func (e *BaseEngine) executionLogger(ctx context.Context, msg *ExecutionMessage) Logger {
+	logger := e.logger
+	if logger == nil {
+		logger = e.taskLogger(msg.ScriptPath)
+	}
+
+	if fl, ok := logger.(FieldsLogger); ok {
+		fields := map[string]any{
+			"engine":      e.EngineType,
+			"script_path": msg.ScriptPath,
+		}
+		if msg.TraceID != "" {
+			fields["trace_id"] = msg.TraceID
+		}
+		logger = fl.WithFields(fields)
+	}
+
+	return withRunScope(ctx, logger)
+}
+
+// GetScriptContent resolves the script body for an execution. Inline
+// content in the "script" parameter wins; otherwise a message carrying
+// ScriptChecksum is served from the script cache, and only a cache miss
+// reads from the SourceProvider. Fetched content whose checksum is already
+// cached is not parsed again.
 func (e *BaseEngine) GetScriptContent(msg *ExecutionMessage) (string, error) {
 	if msg.Parameters != nil {
 		if content, ok := msg.Parameters["script"].(string); ok {
@@ -129,6 +233,10 @@ func (e *BaseEngine) GetScriptContent(msg *ExecutionMessage) (string, error) {
 		}
 	}
 
+	if content, ok := e.scripts.Get(msg.ScriptPath, msg.ScriptChecksum); ok {
+		return content, nil
+	}
+
 	if e.SourceProvider == nil {
 		e.SourceProvider = NewFileSystemSourceProvider(".", e.FS)
 	}
@@ -144,7 +252,12 @@ func (e *BaseEngine) GetScriptContent(msg *ExecutionMessage) (string, error) {
 			})
 	}
 
-	_, scriptContent, err := e.MetadataParser.Parse(content)
+	checksum := ScriptChecksum(content)
+	if cached, ok := e.scripts.Get(msg.ScriptPath, checksum); ok {
+		return cached, nil
+	}
+
+	scriptContent, err := parseScriptContent(e.MetadataParser, content)
 	if err != nil {
 		return "", errors.Wrap(err, errors.CategoryInternal, "failed to parse script content").
 			WithTextCode("SCRIPT_PARSE_ERROR").
@@ -155,20 +268,48 @@ func (e *BaseEngine) GetScriptContent(msg *ExecutionMessage) (string, error) {
 				"content_size": len(content),
 			})
 	}
+	e.scripts.Put(msg.ScriptPath, checksum, scriptContent)
 	return scriptContent, nil
 }
 
+// scriptContentParser is implemented by metadata parsers that can strip the
+// metadata header without decoding it.
+type scriptContentParser interface {
+	ParseScript(content []byte) (string, error)
+}
+
+// parseScriptContent returns the script body, skipping metadata decoding
+// when the parser supports it since the task config is already known.
+func parseScriptContent(parser MetadataParser, content []byte) (string, error) {
+	if sp, ok := parser.(scriptContentParser); ok {
+		return sp.ParseScript(content)
+	}
+	_, scriptContent, err := parser.Parse(content)
+	return scriptContent, err
+}
+
 func (e *BaseEngine) GetExecutionTimeout(ctx context.Context) time.Duration {
 	if ctx == nil {
 		return e.Timeout
 	}
 	if deadline, ok := ctx.Deadline(); ok {
-		return time.Until(deadline)
+		return deadline.Sub(clockOrSystem(e.clock).Now())
 	}
 	return e.Timeout
 }
 
+// GetExecutionContext derives the execution context for ctx using the engine
+// default timeout. Engines running a message should use
+// GetMessageExecutionContext so per-job timeouts apply.
 func (e *BaseEngine) GetExecutionContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return e.GetMessageExecutionContext(ctx, nil)
+}
+
+// GetMessageExecutionContext derives the execution context for msg. An
+// inbound deadline is kept as is; otherwise msg.Config.Timeout applies, then
+// the engine default. NoTimeout, or a non-positive resolved timeout, leaves
+// the execution unbounded.
+func (e *BaseEngine) GetMessageExecutionContext(ctx context.Context, msg *ExecutionMessage) (context.Context, context.CancelFunc) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -177,6 +318,23 @@ func (e *BaseEngine) GetExecutionContext(ctx context.Context) (context.Context,
 		return context.WithCancel(ctx)
 	}
 
-	execTimeout := e.GetExecutionTimeout(ctx)
-	return context.WithTimeout(ctx, execTimeout)
+	timeout, bounded := e.resolveMessageTimeout(msg)
+	if !bounded {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// resolveMessageTimeout picks the timeout for msg, preferring the message
+// config over the engine default. bounded is false when no timeout applies.
+func (e *BaseEngine) resolveMessageTimeout(msg *ExecutionMessage) (timeout time.Duration, bounded bool) {
+	if msg != nil {
+		if msg.Config.NoTimeout {
+			return 0, false
+		}
+		if msg.Config.Timeout > 0 {
+			return msg.Config.Timeout, true
+		}
+	}
+	return e.Timeout, e.Timeout > 0
 }
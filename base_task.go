@@ -15,8 +15,11 @@ type baseTask struct {
 	handlerOpts   HandlerOptions
 	config        Config
 	scriptContent string
-	engine        Engine
-	logger        Logger
+	// scriptChecksum is the checksum of the raw file, see ScriptChecksum.
+	scriptChecksum string
+	engine         Engine
+	logger         Logger
+	parseDuration  time.Duration
 }
 
 var _ Task = &baseTask{}
@@ -43,6 +46,34 @@ func (j *baseTask) GetPath() string {
 	return j.scriptPath
 }
 
+// GetScriptContent returns the script body without its metadata block.
+func (j *baseTask) GetScriptContent() string {
+	return j.scriptContent
+}
+
+// GetScriptChecksum returns the checksum of the script file the task was
+// parsed from (see ScriptChecksum). Tasks built directly with NewBaseTask
+// fall back to the checksum of their script body.
+func (j *baseTask) GetScriptChecksum() string {
+	if j.scriptChecksum == "" {
+		return ScriptChecksum([]byte(j.scriptContent))
+	}
+	return j.scriptChecksum
+}
+
+// GetParseDuration reports how long discovery spent parsing the script.
+func (j *baseTask) GetParseDuration() time.Duration {
+	return j.parseDuration
+}
+
+func (j *baseTask) setParseDuration(d time.Duration) {
+	j.parseDuration = d
+}
+
+func (j *baseTask) setID(id string) {
+	j.id = id
+}
+
 func (j *baseTask) GetEngine() Engine {
 	return j.engine
 }
@@ -56,18 +87,31 @@ func (j *baseTask) Execute(ctx context.Context, msg *ExecutionMessage) error {
 	if err != nil {
 		return err
 	}
+	ctx = resolveTraceID(ctx, execMsg)
 
-	logger := j.taskLogger()
+	logger := withRunScope(ctx, j.taskLogger(execMsg.TraceID))
 	baseArgs := []any{"task_id", j.id, "script_path", j.scriptPath}
 	if j.engine != nil {
 		baseArgs = append(baseArgs, "engine", j.engine.Name())
 	}
 
-	logger.Debug("task execution started", baseArgs...)
+	startArgs := baseArgs
+	if params := redactForLog(execMsg.Parameters); params != nil {
+		startArgs = append(append([]any{}, baseArgs...), "parameters", params)
+	}
+	logger.Debug("task execution started", startArgs...)
+
+	stopWatch := watchSlowExecution(execMsg, 1, func(event SlowExecutionEvent) {
+		logger.Warn("task execution exceeded warn_after",
+			append(append([]any{}, baseArgs...), "warn_after", event.WarnAfter, "elapsed", event.Elapsed)...)
+	})
 
 	start := time.Now()
-	err = j.engine.Execute(ctx, execMsg)
+	err = recoverExecution(j.id, func() error {
+		return j.engine.Execute(ctx, execMsg)
+	})
 	duration := time.Since(start)
+	stopWatch()
 
 	durationArgs := append(append([]any{}, baseArgs...), "duration", duration)
 
@@ -97,7 +141,16 @@ func (j *baseTask) buildExecutionMessage(msg *ExecutionMessage) (*ExecutionMessa
 		msg.ScriptPath = j.scriptPath
 	}
 
+	if msg.ScriptChecksum == "" && msg.ScriptPath == j.scriptPath {
+		msg.ScriptChecksum = j.GetScriptChecksum()
+	}
+
 	msg.Config = mergeConfigDefaults(j.config, msg.Config)
+	if msg.DedupPolicy == "" {
+		if policy, ok := msg.Config.Metadata["dedup_policy"].(string); ok {
+			msg.DedupPolicy = DeduplicationPolicy(policy)
+		}
+	}
 
 	if msg.Parameters == nil {
 		msg.Parameters = make(map[string]any)
@@ -127,7 +180,46 @@ func NewBaseTask(
 	scriptContent string,
 	engine Engine,
 ) Task {
-	handlerOpts := &HandlerOptions{
+	return &baseTask{
+		id:            id,
+		scriptPath:    path,
+		scriptType:    scriptType,
+		handlerOpts:   handlerOptionsFor(config),
+		scriptContent: scriptContent,
+		engine:        engine,
+		config:        config,
+		logger:        newStdLoggerProvider().GetLogger("job:task"),
+	}
+}
+
+func (j *baseTask) taskLogger(traceID string) Logger {
+	logger := j.logger
+	if logger == nil {
+		logger = newStdLoggerProvider().GetLogger("job:task")
+	}
+
+	fields := map[string]any{
+		"task_id":     j.id,
+		"script_path": j.scriptPath,
+	}
+
+	if j.engine != nil {
+		fields["engine"] = j.engine.Name()
+	}
+
+	if traceID != "" {
+		fields["trace_id"] = traceID
+	}
+
+	if fl, ok := logger.(FieldsLogger); ok {
+		return fl.WithFields(fields)
+	}
+
+	return logger
+}
+
+func handlerOptionsFor(config Config) HandlerOptions {
+	handlerOpts := HandlerOptions{
 		HandlerConfig: command.HandlerConfig{
 			Expression: DefaultSchedule,
 			Timeout:    DefaultTimeout,
@@ -135,7 +227,7 @@ func NewBaseTask(
 	}
 
 	if config.Schedule != "" {
-		handlerOpts.Expression = config.Schedule
+		handlerOpts.Expression = expandScheduleOrKeep(config.Schedule)
 	}
 
 	if !config.Deadline.IsZero() {
@@ -166,36 +258,5 @@ func NewBaseTask(
 		handlerOpts.ExitOnError = true
 	}
 
-	return &baseTask{
-		id:            id,
-		scriptPath:    path,
-		scriptType:    scriptType,
-		handlerOpts:   *handlerOpts,
-		scriptContent: scriptContent,
-		engine:        engine,
-		config:        config,
-		logger:        newStdLoggerProvider().GetLogger("job:task"),
-	}
-}
-
-func (j *baseTask) taskLogger() Logger {
-	logger := j.logger
-	if logger == nil {
-		logger = newStdLoggerProvider().GetLogger("job:task")
-	}
-
-	fields := map[string]any{
-		"task_id":     j.id,
-		"script_path": j.scriptPath,
-	}
-
-	if j.engine != nil {
-		fields["engine"] = j.engine.Name()
-	}
-
-	if fl, ok := logger.(FieldsLogger); ok {
-		return fl.WithFields(fields)
-	}
-
-	return logger
+	return handlerOpts
 }
@@ -0,0 +1,197 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	// ResultStatusSucceeded marks a task that completed without error.
+	ResultStatusSucceeded = "succeeded"
+	// ResultStatusSkipped marks a batch task that never started because a
+	// fail-fast batch stopped early.
+	ResultStatusSkipped = "skipped"
+
+	defaultBatchParallelism = 4
+)
+
+// BatchMode controls how ExecuteAll reacts to a failing task.
+type BatchMode string
+
+const (
+	// BatchBestEffort runs every task regardless of failures.
+	BatchBestEffort BatchMode = "best_effort"
+	// BatchFailFast cancels running tasks and skips pending ones after the
+	// first failure.
+	BatchFailFast BatchMode = "fail_fast"
+)
+
+// BatchOptions configures ExecuteAll.
+type BatchOptions struct {
+	// Parallelism bounds how many tasks run at once; defaults to 4.
+	Parallelism int
+	// Mode defaults to BatchBestEffort.
+	Mode BatchMode
+	// Parameters are passed to every task.
+	Parameters map[string]any
+	// Commander builds the commander for each task, e.g. to attach archives
+	// or notifiers. Defaults to NewTaskCommander.
+	Commander func(Task) *TaskCommander
+}
+
+// BatchTaskResult is the outcome of one task in a batch.
+type BatchTaskResult struct {
+	TaskID    string    `json:"task_id"`
+	RunID     string    `json:"run_id,omitempty"`
+	Result    Result    `json:"result"`
+	Err       error     `json:"-"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+}
+
+// BatchResult aggregates a batch; Results follow the order of the tasks.
+type BatchResult struct {
+	Results   []BatchTaskResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Duration  time.Duration     `json:"duration"`
+}
+
+// FailedTaskIDs lists the tasks that failed, in batch order.
+func (r BatchResult) FailedTaskIDs() []string {
+	var ids []string
+	for _, res := range r.Results {
+		if res.Result.Status == ResultStatusFailed {
+			ids = append(ids, res.TaskID)
+		}
+	}
+	return ids
+}
+
+// ExecuteAll runs tasks with bounded parallelism through a TaskCommander
+// each, and collects their outcomes. The returned error (JOB_BATCH_FAILED)
+// is non-nil when any task failed or was skipped; the BatchResult is always
+// complete.
+func ExecuteAll(ctx context.Context, tasks []Task, opts BatchOptions) (BatchResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultBatchParallelism
+	}
+	commander := opts.Commander
+	if commander == nil {
+		commander = NewTaskCommander
+	}
+
+	started := time.Now()
+	results := runBatch(ctx, len(tasks), parallelism, opts.Mode,
+		func(i int) string {
+			if tasks[i] == nil {
+				return ""
+			}
+			return tasks[i].GetID()
+		},
+		func(ctx context.Context, i int) BatchTaskResult {
+			if tasks[i] == nil {
+				return batchFailure("", fmt.Errorf("task is nil"))
+			}
+			return runBatchTask(ctx, commander(tasks[i]), tasks[i], &ExecutionMessage{Parameters: cloneParams(opts.Parameters)})
+		})
+
+	batch := summarizeBatch(results, started)
+	if batch.Failed == 0 && batch.Skipped == 0 {
+		return batch, nil
+	}
+	return batch, errors.New(fmt.Sprintf("batch: %d of %d tasks failed, %d skipped", batch.Failed, len(tasks), batch.Skipped), errors.CategoryInternal).
+		WithTextCode("JOB_BATCH_FAILED").
+		WithMetadata(map[string]any{
+			"failed":    batch.FailedTaskIDs(),
+			"succeeded": batch.Succeeded,
+			"skipped":   batch.Skipped,
+		})
+}
+
+// runBatch runs n items with at most parallelism at once, collecting their
+// results in order. In fail-fast mode the first failure cancels running items
+// and marks pending ones skipped.
+func runBatch(ctx context.Context, n, parallelism int, mode BatchMode, id func(int) string, run func(context.Context, int) BatchTaskResult) []BatchTaskResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]BatchTaskResult, n)
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			results[i] = BatchTaskResult{TaskID: id(i), Result: Result{Status: ResultStatusSkipped, Message: ctx.Err().Error()}}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = run(ctx, i)
+			if results[i].Err != nil && mode == BatchFailFast {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// summarizeBatch counts results by status.
+func summarizeBatch(results []BatchTaskResult, started time.Time) BatchResult {
+	batch := BatchResult{Results: results, Duration: time.Since(started)}
+	for _, res := range results {
+		switch res.Result.Status {
+		case ResultStatusSucceeded:
+			batch.Succeeded++
+		case ResultStatusSkipped:
+			batch.Skipped++
+		default:
+			batch.Failed++
+		}
+	}
+	return batch
+}
+
+func runBatchTask(ctx context.Context, cmd *TaskCommander, task Task, msg *ExecutionMessage) BatchTaskResult {
+	runID := NewRunID()
+	res := BatchTaskResult{TaskID: task.GetID(), RunID: runID, StartedAt: time.Now()}
+	if cmd == nil {
+		failed := batchFailure(res.TaskID, fmt.Errorf("no commander for task %s", res.TaskID))
+		failed.RunID = runID
+		return failed
+	}
+
+	report, err := cmd.ExecuteWithReport(ContextWithRunID(ctx, runID), msg)
+	res.Result = report.Result
+	res.Result.Duration = time.Since(res.StartedAt)
+	res.Err = err
+	if err != nil {
+		// Disabled and deduplicated tasks still count as failed in a batch.
+		res.Result.Status = ResultStatusFailed
+	}
+	return res
+}
+
+func batchFailure(taskID string, err error) BatchTaskResult {
+	return BatchTaskResult{
+		TaskID: taskID,
+		Err:    err,
+		Result: Result{Status: ResultStatusFailed, Message: err.Error()},
+	}
+}
@@ -0,0 +1,78 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchEngine struct {
+	noopEngine
+	fail    map[string]bool
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (e *batchEngine) Execute(_ context.Context, msg *job.ExecutionMessage) error {
+	n := e.running.Add(1)
+	defer e.running.Add(-1)
+	for {
+		peak := e.peak.Load()
+		if n <= peak || e.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	if e.fail[msg.JobID] {
+		return stderrors.New("boom " + msg.JobID)
+	}
+	return nil
+}
+
+func batchTasks(engine job.Engine, ids ...string) []job.Task {
+	tasks := make([]job.Task, 0, len(ids))
+	for _, id := range ids {
+		tasks = append(tasks, job.NewBaseTask(id, "jobs/"+id+".js", "js", job.Config{}, "", engine))
+	}
+	return tasks
+}
+
+func TestExecuteAllBestEffort(t *testing.T) {
+	engine := &batchEngine{fail: map[string]bool{"b": true}}
+	tasks := batchTasks(engine, "a", "b", "c", "d", "e")
+
+	batch, err := job.ExecuteAll(context.Background(), tasks, job.BatchOptions{Parallelism: 2})
+	require.Error(t, err)
+	var target *errors.Error
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_BATCH_FAILED", target.TextCode)
+
+	assert.Equal(t, 4, batch.Succeeded)
+	assert.Equal(t, 1, batch.Failed)
+	assert.Equal(t, []string{"b"}, batch.FailedTaskIDs())
+	require.Len(t, batch.Results, 5)
+	assert.Equal(t, "a", batch.Results[0].TaskID)
+	assert.NotEmpty(t, batch.Results[0].RunID)
+	assert.EqualError(t, batch.Results[1].Err, "boom b")
+	assert.LessOrEqual(t, engine.peak.Load(), int32(2))
+
+	batch, err = job.ExecuteAll(context.Background(), batchTasks(engine, "a", "c"), job.BatchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, batch.Succeeded)
+}
+
+func TestExecuteAllFailFast(t *testing.T) {
+	engine := &batchEngine{fail: map[string]bool{"a": true}}
+	tasks := batchTasks(engine, "a", "b", "c")
+
+	batch, err := job.ExecuteAll(context.Background(), tasks, job.BatchOptions{Parallelism: 1, Mode: job.BatchFailFast})
+	require.Error(t, err)
+	assert.Equal(t, 1, batch.Failed)
+	assert.Equal(t, 2, batch.Skipped)
+	assert.Equal(t, job.ResultStatusSkipped, batch.Results[2].Result.Status)
+}
@@ -0,0 +1,319 @@
+package job
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// HolidayProvider reports whether a calendar day is a holiday. The day is
+// passed at midnight in the calendar's location.
+type HolidayProvider interface {
+	IsHoliday(day time.Time) bool
+}
+
+// HolidayProviderFunc adapts a function into a HolidayProvider.
+type HolidayProviderFunc func(day time.Time) bool
+
+// IsHoliday satisfies HolidayProvider.
+func (f HolidayProviderFunc) IsHoliday(day time.Time) bool {
+	if f == nil {
+		return false
+	}
+	return f(day)
+}
+
+// HolidaySet is a fixed set of holiday dates.
+type HolidaySet struct {
+	mu   sync.RWMutex
+	days map[string]string
+}
+
+// NewHolidaySet returns a set containing the given dates.
+func NewHolidaySet(days ...time.Time) *HolidaySet {
+	set := &HolidaySet{days: make(map[string]string)}
+	for _, day := range days {
+		set.Add(day, "")
+	}
+	return set
+}
+
+// Add marks day as a holiday; name is informational.
+func (s *HolidaySet) Add(day time.Time, name string) {
+	s.mu.Lock()
+	s.days[day.Format(time.DateOnly)] = name
+	s.mu.Unlock()
+}
+
+// IsHoliday satisfies HolidayProvider.
+func (s *HolidaySet) IsHoliday(day time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.days[day.Format(time.DateOnly)]
+	return ok
+}
+
+// Len returns the number of holidays in the set.
+func (s *HolidaySet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.days)
+}
+
+// LoadICSHolidays reads the VEVENTs of an iCalendar file into a HolidaySet.
+// Every day from DTSTART up to (excluding) DTEND is a holiday; events without
+// DTEND cover a single day. Recurrence rules are not expanded, so publish
+// calendars with one event per occurrence.
+func LoadICSHolidays(r io.Reader) (*HolidaySet, error) {
+	set := NewHolidaySet()
+	scanner := bufio.NewScanner(r)
+
+	var (
+		inEvent    bool
+		start, end time.Time
+		summary    string
+		line       int
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		name, value, _ := strings.Cut(text, ":")
+		prop, _, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, summary = true, time.Time{}, time.Time{}, ""
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, icsError(line, "event without DTSTART")
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				set.Add(day, summary)
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			day, err := parseICSDate(value)
+			if err != nil {
+				return nil, icsError(line, err.Error())
+			}
+			if strings.EqualFold(prop, "DTSTART") {
+				start = day
+			} else {
+				end = day
+			}
+		case "SUMMARY":
+			if inEvent {
+				summary = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return day, nil
+}
+
+func icsError(line int, msg string) error {
+	return errors.New(fmt.Sprintf("invalid ics calendar: %s", msg), errors.CategoryBadInput).
+		WithTextCode("JOB_CALENDAR_INVALID").
+		WithMetadata(map[string]any{"line": line})
+}
+
+// CalendarPolicy decides what happens to a firing on a non-business day.
+type CalendarPolicy string
+
+const (
+	// CalendarSkip drops firings on holidays and excluded weekends.
+	CalendarSkip CalendarPolicy = "skip"
+	// CalendarShift runs the firing at the same time of day on the next business day.
+	CalendarShift CalendarPolicy = "shift"
+)
+
+// Calendar filters schedule firings by business day.
+type Calendar struct {
+	// Holidays lists non-business days; nil means none.
+	Holidays HolidayProvider
+	// SkipWeekends treats Saturday and Sunday as non-business days.
+	SkipWeekends bool
+	// Policy defaults to CalendarSkip.
+	Policy CalendarPolicy
+	// Location is the time zone days are evaluated in; defaults to time.Local.
+	Location *time.Location
+}
+
+// IsBusinessDay reports whether t falls on a business day.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	t = t.In(c.location())
+	if c.SkipWeekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return false
+	}
+	if c.Holidays != nil {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.location())
+		if c.Holidays.IsHoliday(day) {
+			return false
+		}
+	}
+	return true
+}
+
+// NextBusinessDay returns t moved to the same wall clock time on the first
+// following business day. It gives up after a year of non-business days.
+func (c *Calendar) NextBusinessDay(t time.Time) (time.Time, bool) {
+	t = t.In(c.location())
+	for i := 1; i <= 366; i++ {
+		next := t.AddDate(0, 0, i)
+		if c.IsBusinessDay(next) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c *Calendar) policy() CalendarPolicy {
+	if c.Policy == "" {
+		return CalendarSkip
+	}
+	return c.Policy
+}
+
+func (c *Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+// CalendarMetadataKey names the script metadata key selecting a calendar
+// registered with CronManager.WithCalendar.
+const CalendarMetadataKey = "calendar"
+
+// CalendarSkipHandler is notified when a calendar skips or shifts a firing.
+// ShiftedTo is zero for skipped firings.
+type CalendarSkipHandler func(scheduleID, jobID string, firedAt, shiftedTo time.Time)
+
+// WithCalendar registers a named calendar schedules can reference through
+// ScheduleDefinition.Calendar or the `calendar` metadata key.
+func (m *CronManager) WithCalendar(name string, calendar *Calendar) *CronManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calendars == nil {
+		m.calendars = make(map[string]*Calendar)
+	}
+	m.calendars[name] = calendar
+	return m
+}
+
+// WithCalendarSkipHandler observes firings skipped or shifted by a calendar.
+func (m *CronManager) WithCalendarSkipHandler(handler CalendarSkipHandler) *CronManager {
+	m.onCalendar = handler
+	return m
+}
+
+func (m *CronManager) calendar(name string) (*Calendar, error) {
+	if name == "" {
+		return nil, nil
+	}
+	m.mu.RLock()
+	calendar, ok := m.calendars[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("calendar %q is not registered", name), errors.CategoryBadInput).
+			WithTextCode("JOB_CALENDAR_UNKNOWN").
+			WithMetadata(map[string]any{"calendar": name})
+	}
+	return calendar, nil
+}
+
+// calendarGate applies the schedule's calendar to a firing. It returns true
+// when the firing should run now; shifted firings are re-run by a timer.
+func (m *CronManager) calendarGate(scheduleID string, calendar *Calendar, run func()) bool {
+	if calendar == nil {
+		return true
+	}
+	clock := clockOrSystem(m.clock)
+	now := clock.Now()
+	if calendar.IsBusinessDay(now) {
+		return true
+	}
+
+	var shiftedTo time.Time
+	if calendar.policy() == CalendarShift {
+		if next, ok := calendar.NextBusinessDay(now); ok {
+			shiftedTo = m.runShifted(scheduleID, clock, next, next.Sub(now), run)
+		}
+	}
+	if m.onCalendar != nil {
+		jobID := ""
+		m.mu.RLock()
+		if entry, ok := m.schedules[scheduleID]; ok {
+			jobID = entry.definition.Message.JobID
+		}
+		m.mu.RUnlock()
+		m.onCalendar(scheduleID, jobID, now, shiftedTo)
+	}
+	return false
+}
+
+// runShifted waits for the shifted firing time and runs it if the schedule
+// is still registered. At most one shifted run is pending per schedule:
+// firings arriving while one waits coalesce into it, and the pending time is
+// returned. Stop cancels pending runs.
+func (m *CronManager) runShifted(scheduleID string, clock Clock, at time.Time, delay time.Duration, run func()) time.Time {
+	m.mu.Lock()
+	if pending, ok := m.shifted[scheduleID]; ok {
+		m.mu.Unlock()
+		return pending
+	}
+	m.shifted[scheduleID] = at
+	entry := m.schedules[scheduleID]
+	m.mu.Unlock()
+
+	timer := clockOrSystem(clock).NewTimer(delay)
+	go func() {
+		defer timer.Stop()
+		fire := false
+		select {
+		case <-m.ctx.Done():
+		case <-timer.C():
+			fire = true
+		}
+		m.mu.Lock()
+		delete(m.shifted, scheduleID)
+		current := m.schedules[scheduleID]
+		m.mu.Unlock()
+		if fire && current != nil && current == entry {
+			run()
+		}
+	}()
+	return at
+}
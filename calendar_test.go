@@ -0,0 +1,122 @@
+package job_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingEngine struct {
+	noopEngine
+	runs atomic.Int32
+}
+
+func (e *countingEngine) Execute(context.Context, *job.ExecutionMessage) error {
+	e.runs.Add(1)
+	return nil
+}
+
+func TestLoadICSHolidays(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20241225",
+		"DTEND;VALUE=DATE:20241227",
+		"SUMMARY:Christmas",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250101T000000Z",
+		"SUMMARY:New Year",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	holidays, err := job.LoadICSHolidays(strings.NewReader(ics))
+	require.NoError(t, err)
+	assert.Equal(t, 3, holidays.Len())
+	assert.True(t, holidays.IsHoliday(time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, holidays.IsHoliday(time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, holidays.IsHoliday(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = job.LoadICSHolidays(strings.NewReader("BEGIN:VEVENT\nEND:VEVENT"))
+	require.Error(t, err)
+}
+
+func TestCalendarNextBusinessDay(t *testing.T) {
+	calendar := &job.Calendar{
+		Holidays:     job.NewHolidaySet(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		SkipWeekends: true,
+		Location:     time.UTC,
+	}
+	saturday := time.Date(2023, 12, 30, 6, 0, 0, 0, time.UTC)
+	assert.False(t, calendar.IsBusinessDay(saturday))
+
+	next, ok := calendar.NextBusinessDay(saturday)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC), next)
+}
+
+func TestCronManagerCalendarSkipsAndShifts(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 1, 6, 6, 0, 0, 0, time.UTC)) // Saturday
+	scheduler := jobtest.NewScheduler(clock)
+	engine := &countingEngine{}
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("close-books", "jobs/close.js", "js", job.Config{}, "", engine)))
+	require.NoError(t, registry.Add(job.NewBaseTask("payroll", "jobs/payroll.js", "js",
+		job.Config{Metadata: map[string]any{"calendar": "shifted"}}, "", engine)))
+
+	var skipped []string
+	manager := job.NewCronManager(registry, scheduler).
+		WithClock(clock).
+		WithCalendar("finance", &job.Calendar{SkipWeekends: true, Location: time.UTC}).
+		WithCalendar("shifted", &job.Calendar{SkipWeekends: true, Policy: job.CalendarShift, Location: time.UTC}).
+		WithCalendarSkipHandler(func(scheduleID, _ string, _, shiftedTo time.Time) {
+			skipped = append(skipped, scheduleID+"@"+shiftedTo.Format(time.DateOnly))
+		})
+
+	ctx := context.Background()
+	require.NoError(t, manager.Register(ctx, job.ScheduleDefinition{
+		ID: "close", Expression: "0 6 * * *", Calendar: "finance", Message: job.ExecutionMessage{JobID: "close-books"},
+	}))
+	require.NoError(t, manager.Register(ctx, job.ScheduleDefinition{
+		ID: "payroll", Expression: "0 6 1 * *", Message: job.ExecutionMessage{JobID: "payroll"},
+	}))
+
+	fired, err := scheduler.RunAll()
+	require.NoError(t, err)
+	assert.Equal(t, 2, fired)
+	assert.Equal(t, int32(0), engine.runs.Load())
+	assert.ElementsMatch(t, []string{"close@0001-01-01", "payroll@2024-01-08"}, skipped)
+
+	clock.BlockUntil(1)
+	clock.Advance(48 * time.Hour)
+	require.Eventually(t, func() bool { return engine.runs.Load() == 1 }, time.Second, time.Millisecond)
+
+	// firings while a shifted run waits coalesce into it
+	clock.Set(time.Date(2024, 1, 13, 6, 0, 0, 0, time.UTC)) // Saturday
+	skipped = nil
+	for range 2 {
+		_, err = scheduler.RunAll()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, clock.Pending())
+	assert.ElementsMatch(t, []string{"close@0001-01-01", "close@0001-01-01", "payroll@2024-01-15", "payroll@2024-01-15"}, skipped)
+
+	// Stop cancels the pending shifted run
+	manager.Stop()
+	require.Eventually(t, func() bool { return clock.Pending() == 0 }, time.Second, time.Millisecond)
+	clock.Advance(48 * time.Hour)
+	assert.Equal(t, int32(1), engine.runs.Load())
+
+	err = manager.Register(ctx, job.ScheduleDefinition{
+		ID: "unknown", Expression: "@daily", Calendar: "missing", Message: job.ExecutionMessage{JobID: "close-books"},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,424 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ResultCallbackSignatureHeader carries the "sha256=<hex>" HMAC of a callback
+// body, verifiable with HMACSignatureVerifier.
+const ResultCallbackSignatureHeader = "X-Signature"
+
+const (
+	defaultCallbackAttempts = 3
+	defaultCallbackBackoff  = time.Second
+	defaultCallbackTimeout  = 10 * time.Second
+	defaultCallbackQueue    = 256
+	defaultCallbackWorkers  = 4
+)
+
+// ErrCallbackQueueFull is returned by Dispatch when the delivery queue is full.
+var ErrCallbackQueueFull = errors.New("result callback queue is full", errors.CategoryRateLimit).
+	WithTextCode("CALLBACK_QUEUE_FULL")
+
+// ErrCallbackSenderClosed is returned by Dispatch after Close.
+var ErrCallbackSenderClosed = errors.New("result callback sender is closed", errors.CategoryOperation).
+	WithTextCode("CALLBACK_SENDER_CLOSED")
+
+// ResultCallback is the body POSTed to a message's callback_url once its run
+// completes.
+type ResultCallback struct {
+	RunID       string    `json:"run_id,omitempty"`
+	JobID       string    `json:"job_id"`
+	TraceID     string    `json:"trace_id,omitempty"`
+	Attempts    int       `json:"attempts"`
+	Result      Result    `json:"result"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// ResultCallbackOption customises a ResultCallbackSender.
+type ResultCallbackOption func(*ResultCallbackSender)
+
+// WithCallbackSigningKey signs every callback body with HMAC-SHA256.
+func WithCallbackSigningKey(key []byte) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		s.key = key
+	}
+}
+
+// WithCallbackHTTPClient sets the client used to deliver callbacks. The
+// client replaces the default one and with it the guard refusing private
+// addresses; pair it with WithCallbackAllowedHosts.
+func WithCallbackHTTPClient(client *http.Client) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// WithCallbackRetries sets how many times delivery is attempted and the base
+// delay, doubled after each failed attempt. Defaults to 3 attempts from 1s.
+func WithCallbackRetries(attempts int, backoff time.Duration) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		if attempts > 0 {
+			s.attempts = attempts
+		}
+		if backoff >= 0 {
+			s.backoff = backoff
+		}
+	}
+}
+
+// WithCallbackClock sets the time source for retry delays and CompletedAt.
+func WithCallbackClock(clock Clock) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		if clock != nil {
+			s.clock = clock
+		}
+	}
+}
+
+// WithCallbackAllowedHosts only delivers callbacks to the listed hosts. A
+// host is matched exactly, or as a suffix when written as "*.example.com".
+// By default any public host is accepted.
+func WithCallbackAllowedHosts(hosts ...string) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		for _, host := range hosts {
+			if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+				s.allowedHosts = append(s.allowedHosts, host)
+			}
+		}
+	}
+}
+
+// WithCallbackPrivateNetworks lets the default client reach loopback,
+// link-local and private addresses, which are refused by default because
+// callback URLs may come from untrusted requests. Enable it only when every
+// callback URL is trusted, e.g. in tests or a closed network.
+func WithCallbackPrivateNetworks() ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		s.allowPrivate = true
+	}
+}
+
+// WithCallbackQueue bounds asynchronous delivery to size queued callbacks
+// drained by workers goroutines. Defaults to 256 callbacks and 4 workers.
+func WithCallbackQueue(size, workers int) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		if size > 0 {
+			s.queueSize = size
+		}
+		if workers > 0 {
+			s.workers = workers
+		}
+	}
+}
+
+// WithCallbackLogger sets the logger reporting dropped and failed deliveries.
+func WithCallbackLogger(logger Logger) ResultCallbackOption {
+	return func(s *ResultCallbackSender) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// ResultCallbackSender POSTs run results to the callback URLs carried by
+// execution messages, so callers that triggered a job asynchronously learn
+// its outcome without polling.
+type ResultCallbackSender struct {
+	client       *http.Client
+	key          []byte
+	attempts     int
+	backoff      time.Duration
+	clock        Clock
+	allowedHosts []string
+	allowPrivate bool
+	logger       Logger
+
+	queueSize int
+	workers   int
+	startOnce sync.Once
+	mu        sync.RWMutex
+	closed    bool
+	queue     chan callbackDelivery
+	wg        sync.WaitGroup
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+type callbackDelivery struct {
+	url string
+	cb  ResultCallback
+}
+
+// NewResultCallbackSender builds a sender; without WithCallbackSigningKey
+// callbacks are delivered unsigned.
+func NewResultCallbackSender(opts ...ResultCallbackOption) *ResultCallbackSender {
+	s := &ResultCallbackSender{
+		attempts:  defaultCallbackAttempts,
+		backoff:   defaultCallbackBackoff,
+		clock:     SystemClock,
+		queueSize: defaultCallbackQueue,
+		workers:   defaultCallbackWorkers,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.client == nil {
+		s.client = newCallbackHTTPClient(s.allowPrivate)
+	}
+	if s.logger == nil {
+		s.logger = newStdLoggerProvider().GetLogger("job:callbacks")
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+// newCallbackHTTPClient returns a client that does not follow redirects and,
+// unless allowPrivate, refuses to connect to non-public addresses. The check
+// runs on the resolved address, so DNS names pointing inward are refused too.
+func newCallbackHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: defaultCallbackTimeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicCallbackIP(ip) {
+				return fmt.Errorf("callback address %s is not public", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   defaultCallbackTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// carrierGradeNAT is the RFC 6598 shared address space, not covered by
+// net.IP.IsPrivate.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicCallbackIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		carrierGradeNAT.Contains(ip))
+}
+
+// hostAllowed reports whether host passes WithCallbackAllowedHosts.
+func (s *ResultCallbackSender) hostAllowed(host string) bool {
+	if len(s.allowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range s.allowedHosts {
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatch queues cb for asynchronous delivery to callbackURL and returns
+// immediately. It fails when the queue is full or the sender is closed;
+// delivery errors are logged.
+func (s *ResultCallbackSender) Dispatch(callbackURL string, cb ResultCallback) error {
+	if s == nil || callbackURL == "" {
+		return nil
+	}
+	if cb.CompletedAt.IsZero() {
+		cb.CompletedAt = s.clock.Now().UTC()
+	}
+	s.startOnce.Do(s.start)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrCallbackSenderClosed
+	}
+	select {
+	case s.queue <- callbackDelivery{url: callbackURL, cb: cb}:
+		return nil
+	default:
+		s.logger.Warn("result callback dropped, queue full", "job_id", cb.JobID, "run_id", cb.RunID)
+		return ErrCallbackQueueFull
+	}
+}
+
+func (s *ResultCallbackSender) start() {
+	s.queue = make(chan callbackDelivery, s.queueSize)
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for delivery := range s.queue {
+				if err := s.Send(s.ctx, delivery.url, delivery.cb); err != nil {
+					s.logger.Error("result callback delivery failed", "job_id", delivery.cb.JobID, "run_id", delivery.cb.RunID, "error", err)
+				}
+			}
+		}()
+	}
+}
+
+// Close stops accepting callbacks and waits for queued ones to be delivered.
+// When ctx ends first, pending deliveries are abandoned and ctx's error is
+// returned.
+func (s *ResultCallbackSender) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.startOnce.Do(s.start)
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// Send delivers cb to callbackURL, retrying failed attempts with backoff, and
+// returns the last delivery error. It blocks until delivery ends; use
+// Dispatch to deliver in the background.
+func (s *ResultCallbackSender) Send(ctx context.Context, callbackURL string, cb ResultCallback) error {
+	if s == nil || callbackURL == "" {
+		return nil
+	}
+	if err := validateCallbackURL(callbackURL); err != nil {
+		return err
+	}
+	if u, _ := url.Parse(callbackURL); !s.hostAllowed(u.Hostname()) {
+		return fmt.Errorf("callback host %q is not allowed", u.Hostname())
+	}
+	if cb.CompletedAt.IsZero() {
+		cb.CompletedAt = s.clock.Now().UTC()
+	}
+	body, err := json.Marshal(cb)
+	if err != nil {
+		return err
+	}
+
+	delay := s.backoff
+	for attempt := 1; ; attempt++ {
+		err = s.post(ctx, callbackURL, body)
+		if err == nil || attempt >= s.attempts {
+			break
+		}
+		if sleepErr := sleepWithClock(ctx, s.clock, delay); sleepErr != nil {
+			return sleepErr
+		}
+		delay *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("result callback to %s failed after %d attempts: %w", callbackURL, s.attempts, err)
+	}
+	return nil
+}
+
+func (s *ResultCallbackSender) post(ctx context.Context, callbackURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.key) > 0 {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write(body)
+		req.Header.Set(ResultCallbackSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// validateCallbackURL accepts absolute http and https URLs.
+func validateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid callback url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid callback url %q: expected an absolute http or https url", raw)
+	}
+	return nil
+}
+
+// WithResultCallbacks delivers the result of every run whose message sets
+// CallbackURL through sender. Delivery is best-effort and queued after the
+// run completes, so it never delays or fails the execution.
+func (c *TaskCommander) WithResultCallbacks(sender *ResultCallbackSender) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.callbacks = sender
+	return c
+}
+
+// sendResultCallback queues report for msg's callback URL, if any.
+func (c *TaskCommander) sendResultCallback(msg *ExecutionMessage, report ExecutionReport) {
+	if c == nil || c.callbacks == nil || msg == nil || msg.CallbackURL == "" {
+		return
+	}
+	_ = c.callbacks.Dispatch(msg.CallbackURL, ResultCallback{
+		RunID:    report.RunID,
+		JobID:    report.JobID,
+		TraceID:  report.TraceID,
+		Attempts: report.Attempts,
+		Result:   report.Result,
+	})
+}
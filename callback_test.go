@@ -0,0 +1,169 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type callbackRecorder struct {
+	mu       sync.Mutex
+	failures int
+	bodies   [][]byte
+	sigs     []string
+}
+
+func (r *callbackRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.sigs = append(r.sigs, req.Header.Get(ResultCallbackSignatureHeader))
+	if len(r.bodies) <= r.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func TestResultCallbackSenderSignsAndRetries(t *testing.T) {
+	rec := &callbackRecorder{failures: 2}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	key := []byte("secret")
+	sender := NewResultCallbackSender(WithCallbackSigningKey(key), WithCallbackRetries(3, 0), WithCallbackPrivateNetworks())
+	err := sender.Send(context.Background(), srv.URL, ResultCallback{
+		RunID:  "run-1",
+		JobID:  "report",
+		Result: Result{Status: ResultStatusSucceeded},
+	})
+	require.NoError(t, err)
+	require.Len(t, rec.bodies, 3)
+
+	last := rec.bodies[2]
+	require.NoError(t, HMACSignatureVerifier(key)(last, rec.sigs[2]))
+	var cb ResultCallback
+	require.NoError(t, json.Unmarshal(last, &cb))
+	assert.Equal(t, "run-1", cb.RunID)
+	assert.Equal(t, ResultStatusSucceeded, cb.Result.Status)
+	assert.False(t, cb.CompletedAt.IsZero())
+
+	rec = &callbackRecorder{failures: 5}
+	failing := httptest.NewServer(rec)
+	defer failing.Close()
+	err = NewResultCallbackSender(WithCallbackRetries(2, 0), WithCallbackPrivateNetworks()).Send(context.Background(), failing.URL, ResultCallback{JobID: "report"})
+	assert.ErrorContains(t, err, "failed after 2 attempts")
+	assert.Len(t, rec.bodies, 2)
+	assert.Empty(t, rec.sigs[0])
+}
+
+func TestWebhookTriggerDeliversResultToCallbackURL(t *testing.T) {
+	rec := &callbackRecorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	reg := newStubRegistry()
+	require.NoError(t, reg.Add(&capturingTask{stubTask: newStubTask("report", Config{})}))
+	sender := NewResultCallbackSender(WithCallbackPrivateNetworks())
+	handler := NewWebhookTriggerHandler(reg, WithWebhookResultCallbacks(sender))
+
+	body := `{"params":{"month":"2026-01"},"callback_url":"` + srv.URL + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/?job=report", strings.NewReader(body))
+	req.Header.Set("Content-Type", EnvelopeContentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// A raw params body keeps a callback_url key as a param; the URL comes
+	// from the header instead.
+	req = httptest.NewRequest(http.MethodPost, "/?job=report", strings.NewReader(`{"callback_url":"kept"}`))
+	req.Header.Set(DefaultCallbackURLHeader, srv.URL)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.NoError(t, sender.Close(context.Background()))
+	require.Len(t, rec.bodies, 2)
+	var cb ResultCallback
+	require.NoError(t, json.Unmarshal(rec.bodies[0], &cb))
+	assert.Equal(t, "report", cb.JobID)
+	assert.NotEmpty(t, cb.RunID)
+	assert.Equal(t, ResultStatusSucceeded, cb.Result.Status)
+}
+
+func TestResultCallbackSenderRefusesPrivateAndUnlistedHosts(t *testing.T) {
+	rec := &callbackRecorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	err := NewResultCallbackSender(WithCallbackRetries(1, 0)).Send(context.Background(), srv.URL, ResultCallback{JobID: "report"})
+	assert.ErrorContains(t, err, "is not public")
+	err = NewResultCallbackSender(WithCallbackRetries(1, 0)).Send(context.Background(), "http://169.254.169.254/latest", ResultCallback{JobID: "report"})
+	assert.ErrorContains(t, err, "is not public")
+
+	allowlisted := NewResultCallbackSender(WithCallbackRetries(1, 0), WithCallbackAllowedHosts("*.example.com"))
+	err = allowlisted.Send(context.Background(), srv.URL, ResultCallback{JobID: "report"})
+	assert.ErrorContains(t, err, "is not allowed")
+	assert.True(t, allowlisted.hostAllowed("hooks.example.com"))
+	assert.False(t, allowlisted.hostAllowed("example.org"))
+	assert.Empty(t, rec.bodies)
+}
+
+func TestResultCallbackSenderDispatchIsBounded(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	sender := NewResultCallbackSender(WithCallbackPrivateNetworks(), WithCallbackQueue(1, 1))
+	require.NoError(t, sender.Dispatch(srv.URL, ResultCallback{JobID: "a"}))
+	// The worker may or may not have picked up the first callback yet, so
+	// at most two more fit before the queue is full.
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = sender.Dispatch(srv.URL, ResultCallback{JobID: "b"})
+	}
+	assert.ErrorIs(t, err, ErrCallbackQueueFull)
+
+	close(release)
+	require.NoError(t, sender.Close(context.Background()))
+	assert.ErrorIs(t, sender.Dispatch(srv.URL, ResultCallback{JobID: "c"}), ErrCallbackSenderClosed)
+}
+
+func TestCallbackURLValidation(t *testing.T) {
+	env := Envelope{CallbackURL: "ftp://example.com/done"}
+	assert.ErrorContains(t, env.Validate(), "envelope validation failed")
+
+	msg := ExecutionMessage{JobID: "report", ScriptPath: "report.js", CallbackURL: "/relative"}
+	assert.Error(t, msg.Validate())
+
+	msg.CallbackURL = "https://example.com/done"
+	assert.NoError(t, msg.Validate())
+}
+
+func TestResultCallbackRedactsReportedMetadata(t *testing.T) {
+	rec := &callbackRecorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	sender := NewResultCallbackSender(WithCallbackPrivateNetworks())
+	task := &reportingTask{stubTask: newStubTask("report", Config{}), result: Result{
+		Metadata: map[string]any{"api_token": "secret"},
+	}}
+	require.NoError(t, NewTaskCommander(task).WithResultCallbacks(sender).
+		Execute(context.Background(), &ExecutionMessage{JobID: "report", CallbackURL: srv.URL}))
+
+	require.NoError(t, sender.Close(context.Background()))
+	require.Len(t, rec.bodies, 1)
+	var cb ResultCallback
+	require.NoError(t, json.Unmarshal(rec.bodies[0], &cb))
+	assert.Equal(t, RedactedValue, cb.Result.Metadata["api_token"])
+}
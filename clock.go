@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the time source used by NextRun, retry backoff sleeps, std logger
+// timestamps, idempotency TTLs, run durations and deadline handling. The
+// jobtest package provides a fake implementation that is advanced manually.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the subset of time.Timer used by the package.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// SystemClock is the wall clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+type systemTimer struct{ t *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.t.C }
+
+func (t systemTimer) Stop() bool { return t.t.Stop() }
+
+// clockOrSystem returns clock, or SystemClock when nil.
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
+
+// sleepWithClock waits for delay on clock, returning early with the context error.
+func sleepWithClock(ctx context.Context, clock Clock, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := clockOrSystem(clock).NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
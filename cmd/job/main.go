@@ -0,0 +1,51 @@
+// Command job is the helper shell jobs use to talk to the runner that started
+// them. It reads the control socket from JOB_CONTROL_SOCKET, which is set when
+// the runner enables job invocation:
+//
+//	job run report month=2026-01          # run and wait for the result
+//	job run -wait=false cleanup dry_run=1 # start in the background
+//
+// The result is printed as JSON; a failed job exits with status 1.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goliatone/go-job"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "run" {
+		fmt.Fprintln(os.Stderr, "usage: job run [-wait=false] <job-id> [key=value ...]")
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	wait := flags.Bool("wait", true, "wait for the job to finish and print its result")
+	_ = flags.Parse(os.Args[2:])
+	if flags.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "usage: job run [-wait=false] <job-id> [key=value ...]")
+		os.Exit(2)
+	}
+
+	params, err := job.ParseParamFlags(flags.Args()[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "job:", err)
+		os.Exit(2)
+	}
+
+	result, err := job.InvokeViaControlSocket(context.Background(), os.Getenv(job.ControlSocketEnvVar), job.JobInvocation{
+		JobID:  flags.Arg(0),
+		Params: params,
+		Wait:   *wait,
+	})
+	_ = json.NewEncoder(os.Stdout).Encode(result)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "job:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,109 @@
+// Command joblint validates a jobs directory without running it, printing a
+// report and exiting non-zero when errors are found. Intended for CI:
+//
+//	joblint -dir ./data/jobs -format json
+//
+// With -snapshot it instead prints the discovered task configuration as a
+// deterministic YAML or JSON snapshot, for golden files and drift review:
+//
+//	joblint -dir ./data/jobs -snapshot yaml > jobs.golden.yaml
+//
+// With -describe it prints a job catalog in Markdown or JSON:
+//
+//	joblint -dir ./data/jobs -describe markdown > JOBS.md
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goliatone/go-job"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "jobs directory to lint")
+	format := flag.String("format", "text", "output format: text or json")
+	seconds := flag.Bool("seconds", false, "accept six-field cron expressions with seconds")
+	strict := flag.Bool("strict", false, "treat warnings as failures")
+	timeout := flag.Duration("timeout", time.Minute, "maximum time to spend linting")
+	idTemplate := flag.String("id-template", "", "derive task IDs from a template such as {{dir}}.{{base}}")
+	snapshot := flag.String("snapshot", "", "print a task configuration snapshot (yaml or json) instead of linting")
+	describe := flag.String("describe", "", "print a job catalog (markdown or json) instead of linting")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	engines := []job.Engine{job.NewJSRunner(), job.NewShellRunner(), job.NewPythonRunner(), job.NewSQLRunner(), job.NewHTTPRunner()}
+
+	if *snapshot != "" || *describe != "" {
+		creator := job.NewTaskCreator(job.NewFileSystemSourceProvider(*dir), engines)
+		if *idTemplate != "" {
+			provider, err := job.TemplateTaskIDProvider(*dir, *idTemplate)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "joblint:", err)
+				os.Exit(2)
+			}
+			creator.WithTaskIDProvider(provider)
+		}
+		tasks, err := creator.CreateTasks(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "joblint:", err)
+			os.Exit(2)
+		}
+		if *describe != "" {
+			registry := job.NewMemoryRegistry()
+			for _, task := range tasks {
+				_ = registry.Add(task)
+			}
+			err = job.WriteTaskCatalog(os.Stdout, job.DescribeAll(registry), job.CatalogFormat(*describe))
+		} else {
+			snapshots := job.TaskSnapshots(tasks, job.SnapshotOptions{Root: *dir})
+			err = job.WriteTaskSnapshot(os.Stdout, snapshots, job.SnapshotFormat(*snapshot))
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "joblint:", err)
+			os.Exit(2)
+		}
+		return
+	}
+
+	var opts []job.LintOption
+	if *seconds {
+		opts = append(opts, job.WithLintSchedulerOptions(job.WithSecondsPrecision()))
+	}
+	if *idTemplate != "" {
+		provider, err := job.TemplateTaskIDProvider(*dir, *idTemplate)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "joblint:", err)
+			os.Exit(2)
+		}
+		opts = append(opts, job.WithLintTaskIDProvider(provider))
+	}
+
+	report, err := job.Lint(ctx, job.NewFileSystemSourceProvider(*dir), engines, opts...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "joblint:", err)
+		os.Exit(2)
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	default:
+		for _, issue := range report.Issues {
+			fmt.Printf("%s: %s [%s] %s\n", issue.ScriptPath, issue.Severity, issue.Code, issue.Message)
+		}
+		fmt.Printf("%d scripts, %d errors, %d warnings\n", report.Scripts, report.Errors, report.Warnings)
+	}
+
+	if !report.OK() || (*strict && report.Warnings > 0) {
+		os.Exit(1)
+	}
+}
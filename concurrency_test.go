@@ -2,10 +2,12 @@ package job_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,6 +68,96 @@ func TestQuotaCheckerBlocksOversizedPayload(t *testing.T) {
 	err := cmd.Execute(context.Background(), msg)
 	require.ErrorIs(t, err, job.ErrQuotaExceeded)
 	assert.Equal(t, 0, task.count)
+
+	denial, ok := job.QuotaDenialFrom(err)
+	require.True(t, ok)
+	assert.Equal(t, job.QuotaPayloadSize, denial.Quota)
+	assert.Equal(t, int64(8), denial.Limit)
+	assert.Greater(t, denial.Usage, int64(8))
+}
+
+func TestMultiQuotaCheckerComposesQuotas(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Time{})
+	multi := job.NewMultiQuotaChecker(
+		job.BasicQuotaChecker{PayloadSizeLimit: 64},
+		job.NewTenantRunQuota(1, time.Hour).WithClock(clock),
+		job.NewRunRateQuota(job.QuotaGlobalRate, 3, time.Minute).WithClock(clock),
+	)
+	tenant := func(id string) *job.ExecutionMessage {
+		return &job.ExecutionMessage{Config: job.Config{Metadata: map[string]any{job.TenantMetadataKey: id}}}
+	}
+
+	require.NoError(t, multi.Check(tenant("acme")))
+	err := multi.Check(tenant("acme"))
+	require.ErrorIs(t, err, job.ErrQuotaExceeded)
+	denial, ok := job.QuotaDenialFrom(err)
+	require.True(t, ok)
+	assert.Equal(t, job.QuotaDenial{
+		Quota:   job.QuotaTenantRuns,
+		Scope:   "acme",
+		Usage:   1,
+		Limit:   1,
+		ResetAt: clock.Now().Add(time.Hour),
+	}, denial)
+
+	require.NoError(t, multi.Check(tenant("globex")))
+	require.NoError(t, multi.Check(&job.ExecutionMessage{}))
+	err = multi.Check(&job.ExecutionMessage{})
+	denial, _ = job.QuotaDenialFrom(err)
+	assert.Equal(t, job.QuotaGlobalRate, denial.Quota)
+
+	clock.Advance(time.Minute)
+	require.NoError(t, multi.Check(&job.ExecutionMessage{}))
+
+	err = multi.Check(&job.ExecutionMessage{Parameters: map[string]any{"blob": strings.Repeat("x", 100)}})
+	denial, _ = job.QuotaDenialFrom(err)
+	assert.Equal(t, job.QuotaPayloadSize, denial.Quota)
+
+	assert.Equal(t, map[string]int64{
+		job.QuotaTenantRuns:  1,
+		job.QuotaGlobalRate:  1,
+		job.QuotaPayloadSize: 1,
+	}, multi.Denials())
+}
+
+func TestMultiQuotaCheckerReleasesSlotsOnLaterDenial(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Time{})
+	global := job.NewRunRateQuota(job.QuotaGlobalRate, 2, time.Minute).WithClock(clock)
+	multi := job.NewMultiQuotaChecker(
+		global,
+		job.NewTenantRunQuota(1, time.Hour).WithClock(clock),
+	)
+	acme := &job.ExecutionMessage{Config: job.Config{Metadata: map[string]any{job.TenantMetadataKey: "acme"}}}
+
+	require.NoError(t, multi.Check(acme))
+	for i := 0; i < 3; i++ {
+		err := multi.Check(acme)
+		denial, _ := job.QuotaDenialFrom(err)
+		require.Equal(t, job.QuotaTenantRuns, denial.Quota)
+	}
+
+	// the tenant denials gave their global slots back
+	require.NoError(t, multi.Check(&job.ExecutionMessage{}))
+	err := multi.Check(&job.ExecutionMessage{})
+	denial, _ := job.QuotaDenialFrom(err)
+	assert.Equal(t, job.QuotaGlobalRate, denial.Quota)
+}
+
+func TestQuotaDenialInExecutionReport(t *testing.T) {
+	task := &countingTask{id: "quota-task", path: "/tmp/quota"}
+	cmd := job.NewTaskCommander(task).WithQuotaChecker(job.BasicQuotaChecker{MaxRetries: 1})
+
+	report, err := cmd.ExecuteWithReport(context.Background(), &job.ExecutionMessage{
+		JobID:      task.id,
+		ScriptPath: task.path,
+		Config:     job.Config{Retries: 3},
+	})
+	require.ErrorIs(t, err, job.ErrQuotaExceeded)
+	assert.Equal(t, job.ResultStatusFailed, report.Result.Status)
+	denial, ok := report.Result.Metadata["quota"].(job.QuotaDenial)
+	require.True(t, ok)
+	assert.Equal(t, job.QuotaRetries, denial.Quota)
+	assert.Equal(t, int64(3), denial.Usage)
 }
 
 type blockingTask struct {
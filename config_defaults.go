@@ -0,0 +1,109 @@
+package job
+
+import "strings"
+
+// ConfigDefaults holds site-wide config applied beneath script metadata.
+// Engines keys are engine types ("javascript", "shell", "python", "sql",
+// "http"); the "engine:" prefix returned by Engine.Name is accepted too.
+type ConfigDefaults struct {
+	Global  Config
+	Engines map[string]Config
+}
+
+// ConfigDefaultsAware engines and task creators accept site-wide config defaults.
+type ConfigDefaultsAware interface {
+	SetConfigDefaults(ConfigDefaults)
+}
+
+// For returns the effective defaults for engineType: the engine profile
+// merged over the global defaults.
+func (d ConfigDefaults) For(engineType string) Config {
+	engineType = strings.TrimPrefix(engineType, "engine:")
+	base := d.Global
+	if profile, ok := d.Engines[engineType]; ok {
+		base = mergeConfigDefaults(base, profile)
+		base.Env = mergeStringMaps(d.Global.Env, profile.Env)
+		base.Affinity = mergeStringMaps(d.Global.Affinity, profile.Affinity)
+		base.Metadata = mergeAnyMaps(d.Global.Metadata, profile.Metadata)
+	}
+	return base
+}
+
+func (d ConfigDefaults) isZero() bool {
+	return len(d.Engines) == 0 && configIsZero(d.Global)
+}
+
+// apply merges script metadata over the defaults for engineType. The parser
+// fills DefaultSchedule and DefaultTimeout when a script leaves them out, so
+// those values yield to configured defaults. Env, Affinity and Metadata merge
+// per key.
+func (d ConfigDefaults) apply(engineType string, script Config) Config {
+	if d.isZero() {
+		return script
+	}
+	base := d.For(engineType)
+
+	if script.Schedule == DefaultSchedule {
+		script.Schedule = ""
+	}
+	if script.Timeout == DefaultTimeout {
+		script.Timeout = 0
+	}
+
+	result := mergeConfigDefaults(base, script)
+	result.Env = mergeStringMaps(base.Env, script.Env)
+	result.Affinity = mergeStringMaps(base.Affinity, script.Affinity)
+	result.Metadata = mergeAnyMaps(base.Metadata, script.Metadata)
+	if result.Schedule == "" {
+		result.Schedule = DefaultSchedule
+	}
+	if result.Timeout == 0 {
+		result.Timeout = DefaultTimeout
+	}
+	return result
+}
+
+func (d ConfigDefaults) withEngine(engineType string, cfg Config) ConfigDefaults {
+	engines := make(map[string]Config, len(d.Engines)+1)
+	for key, value := range d.Engines {
+		engines[key] = value
+	}
+	engines[strings.TrimPrefix(engineType, "engine:")] = cfg
+	d.Engines = engines
+	return d
+}
+
+func configIsZero(cfg Config) bool {
+	return cfg.Schedule == "" && cfg.Retries == 0 && cfg.Timeout == 0 && cfg.WarnAfter == 0 &&
+		cfg.Deadline.IsZero() && !cfg.NoTimeout && !cfg.Debug && !cfg.RunOnce && cfg.MaxRuns == 0 &&
+		!cfg.ExitOnError && cfg.ScriptType == "" && !cfg.Transaction && len(cfg.Metadata) == 0 &&
+		len(cfg.Env) == 0 && cfg.Backoff.isZero() && cfg.MaxConcurrency == 0 && len(cfg.Affinity) == 0
+}
+
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	if len(base) == 0 {
+		return override
+	}
+	out := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range override {
+		out[key] = value
+	}
+	return out
+}
+
+func mergeAnyMaps(base, override map[string]any) map[string]any {
+	if len(base) == 0 {
+		return override
+	}
+	out := make(map[string]any, len(base)+len(override))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range override {
+		out[key] = value
+	}
+	return out
+}
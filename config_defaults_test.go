@@ -0,0 +1,51 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerConfigDefaultsAndEngineProfiles(t *testing.T) {
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/plain.sh", Content: []byte("echo plain")},
+			{Path: "jobs/custom.sh", Content: []byte("# config\n# timeout: 10s\n# env:\n#   REGION: eu\n\necho custom")},
+			{Path: "jobs/report.js", Content: []byte("console.log('report')")},
+		},
+	}
+
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner(), job.NewJSRunner()})),
+		job.WithConfigDefaults(job.Config{
+			Retries: 2,
+			Env:     map[string]string{"TEAM": "ops"},
+		}),
+		job.WithEngineConfigDefaults("shell", job.Config{Timeout: time.Minute * 3}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	tasks := map[string]job.Config{}
+	for _, task := range runner.RegisteredTasks() {
+		tasks[task.GetID()] = task.GetConfig()
+	}
+	require.Len(t, tasks, 3)
+
+	plain := tasks["plain.sh"]
+	assert.Equal(t, 2, plain.Retries)
+	assert.Equal(t, 3*time.Minute, plain.Timeout)
+	assert.Equal(t, job.DefaultSchedule, plain.Schedule)
+	assert.Equal(t, map[string]string{"TEAM": "ops"}, plain.Env)
+
+	custom := tasks["custom.sh"]
+	assert.Equal(t, 10*time.Second, custom.Timeout, "script metadata wins over profile")
+	assert.Equal(t, map[string]string{"TEAM": "ops", "REGION": "eu"}, custom.Env)
+
+	report := tasks["report.js"]
+	assert.Equal(t, 2, report.Retries)
+	assert.Equal(t, job.DefaultTimeout, report.Timeout, "shell profile does not leak into other engines")
+}
@@ -15,6 +15,9 @@ func mergeConfigDefaults(base, override Config) Config {
 	if override.Timeout != 0 {
 		result.Timeout = override.Timeout
 	}
+	if override.WarnAfter != 0 {
+		result.WarnAfter = override.WarnAfter
+	}
 	if !override.Deadline.IsZero() {
 		result.Deadline = override.Deadline
 	}
@@ -54,13 +57,19 @@ func mergeConfigDefaults(base, override Config) Config {
 	if override.Backoff.Jitter {
 		result.Backoff.Jitter = true
 	}
+	if override.Backoff.OnError != nil {
+		result.Backoff.OnError = override.Backoff.OnError
+	}
 	if override.Metadata != nil {
 		result.Metadata = override.Metadata
 	}
 	if override.Env != nil {
 		result.Env = override.Env
 	}
-	if override.Backoff.Strategy != "" || override.Backoff.Interval != 0 || override.Backoff.MaxInterval != 0 || override.Backoff.Jitter {
+	if override.Affinity != nil {
+		result.Affinity = override.Affinity
+	}
+	if !override.Backoff.isZero() {
 		result.Backoff = mergeBackoffDefaults(base.Backoff, override.Backoff)
 	}
 
@@ -81,5 +90,8 @@ func mergeBackoffDefaults(base, override BackoffConfig) BackoffConfig {
 	if override.Jitter {
 		result.Jitter = true
 	}
+	if override.OnError != nil {
+		result.OnError = override.OnError
+	}
 	return result
 }
@@ -0,0 +1,156 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// DefaultBulkConcurrency bounds how many jobs a bulk trigger runs at once.
+const DefaultBulkConcurrency = 8
+
+// BulkResult reports the outcome of a bulk operation for a single job.
+// Status is set by callers that track one, such as the admin service.
+type BulkResult struct {
+	JobID  string `json:"job_id"`
+	RunID  string `json:"run_id,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  error  `json:"-"`
+}
+
+// MarshalJSON renders Error as its message.
+func (r BulkResult) MarshalJSON() ([]byte, error) {
+	type plain BulkResult
+	out := struct {
+		plain
+		Error string `json:"error,omitempty"`
+	}{plain: plain(r)}
+	if r.Error != nil {
+		out.Error = r.Error.Error()
+	}
+	return json.Marshal(out)
+}
+
+// WithBulkConcurrency bounds how many jobs TriggerMatching runs at once.
+// Values below 1 keep DefaultBulkConcurrency.
+func (m *CronManager) WithBulkConcurrency(limit int) *CronManager {
+	if limit > 0 {
+		m.bulkLimit = limit
+	}
+	return m
+}
+
+// PauseJob skips scheduled runs of jobID until ResumeJob is called. Manual
+// triggers are not affected.
+func (m *CronManager) PauseJob(ctx context.Context, jobID string) error {
+	return m.setPaused(ctx, jobID, true)
+}
+
+// ResumeJob re-enables scheduled runs of jobID.
+func (m *CronManager) ResumeJob(ctx context.Context, jobID string) error {
+	return m.setPaused(ctx, jobID, false)
+}
+
+// IsPaused reports whether scheduled runs of jobID are paused.
+func (m *CronManager) IsPaused(jobID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.paused[jobID]
+	return ok
+}
+
+// TriggerMatching runs every job matching selector concurrently, at most
+// WithBulkConcurrency at a time, and waits for them to finish, returning one
+// result per job ordered by job ID.
+func (m *CronManager) TriggerMatching(ctx context.Context, selector Selector, params map[string]any) []BulkResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tasks := SelectTasks(m.registry, selector)
+	results := make([]BulkResult, len(tasks))
+	limit := m.bulkLimit
+	if limit <= 0 {
+		limit = DefaultBulkConcurrency
+	}
+	slots := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		runID := NewRunID()
+		results[i] = BulkResult{JobID: task.GetID(), RunID: runID}
+
+		cmd := m.buildCommander(task.GetID())
+		if cmd == nil {
+			results[i].Error = fmt.Errorf("task %q not found", task.GetID())
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, cmd *TaskCommander) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			msg := &ExecutionMessage{JobID: results[i].JobID, Parameters: cloneParams(params)}
+			results[i].Error = cmd.Execute(ContextWithRunID(ctx, results[i].RunID), msg)
+		}(i, cmd)
+	}
+	wg.Wait()
+	return results
+}
+
+// PauseMatching pauses scheduled runs for every job matching selector.
+func (m *CronManager) PauseMatching(ctx context.Context, selector Selector) []BulkResult {
+	return m.bulkSetPaused(ctx, selector, true)
+}
+
+// ResumeMatching resumes scheduled runs for every job matching selector.
+func (m *CronManager) ResumeMatching(ctx context.Context, selector Selector) []BulkResult {
+	return m.bulkSetPaused(ctx, selector, false)
+}
+
+func (m *CronManager) bulkSetPaused(ctx context.Context, selector Selector, paused bool) []BulkResult {
+	tasks := SelectTasks(m.registry, selector)
+	results := make([]BulkResult, 0, len(tasks))
+	for _, task := range tasks {
+		results = append(results, BulkResult{
+			JobID: task.GetID(),
+			Error: m.setPaused(ctx, task.GetID(), paused),
+		})
+	}
+	return results
+}
+
+func (m *CronManager) setPaused(ctx context.Context, jobID string, paused bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if jobID == "" {
+		return fmt.Errorf("job id is required")
+	}
+	if m.registry == nil {
+		return fmt.Errorf("registry is not configured")
+	}
+	if task, ok := m.registry.Get(jobID); !ok || task == nil {
+		return fmt.Errorf("task %q not found", jobID)
+	}
+
+	m.mu.Lock()
+	_, was := m.paused[jobID]
+	if paused {
+		m.paused[jobID] = struct{}{}
+	} else {
+		delete(m.paused, jobID)
+	}
+	m.mu.Unlock()
+
+	if was == paused {
+		return nil
+	}
+	action := AuditActionJobResumed
+	if paused {
+		action = AuditActionJobPaused
+	}
+	recordAudit(ctx, m.audit, AuditEntry{Action: action, JobID: jobID})
+	return nil
+}
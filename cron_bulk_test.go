@@ -0,0 +1,141 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	selector, err := ParseSelector("tag=backfill, tenant=acme")
+	require.NoError(t, err)
+	assert.Equal(t, Selector{"tag": "backfill", "tenant": "acme"}, selector)
+	assert.Equal(t, "tag=backfill tenant=acme", selector.String())
+
+	_, err = ParseSelector("tenant")
+	require.Error(t, err)
+}
+
+func TestSelectorMatches(t *testing.T) {
+	task := newStubTask("job-1", Config{Metadata: map[string]any{
+		"tags":   []any{"backfill", "nightly"},
+		"tenant": "acme",
+	}})
+
+	assert.True(t, Selector{"tag": "backfill"}.Matches(task))
+	assert.True(t, Selector{"tag": "nightly", "tenant": "acme"}.Matches(task))
+	assert.False(t, Selector{"tag": "backfill", "tenant": "other"}.Matches(task))
+	assert.False(t, Selector{}.Matches(task), "empty selector must not match")
+}
+
+func TestCronManagerTriggerMatching(t *testing.T) {
+	reg := newStubRegistry()
+	backfillA := &capturingTask{stubTask: newStubTask("a", Config{Metadata: map[string]any{"tags": "backfill"}})}
+	backfillB := &capturingTask{stubTask: newStubTask("b", Config{Metadata: map[string]any{"tags": "backfill,daily"}})}
+	other := &capturingTask{stubTask: newStubTask("c", Config{Metadata: map[string]any{"tags": "daily"}})}
+	for _, task := range []Task{backfillA, backfillB, other} {
+		require.NoError(t, reg.Add(task))
+	}
+
+	manager := NewCronManager(reg, newStubScheduler())
+	results := manager.TriggerMatching(context.Background(), Selector{"tag": "backfill"}, map[string]any{"since": "2024-01-01"})
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].JobID)
+	assert.Equal(t, "b", results[1].JobID)
+	for _, result := range results {
+		assert.NoError(t, result.Error)
+		assert.NotEmpty(t, result.RunID)
+	}
+	require.Len(t, backfillA.msgs, 1)
+	assert.Equal(t, "2024-01-01", backfillA.msgs[0].Parameters["since"])
+	assert.Equal(t, results[0].RunID, RunIDFromContext(backfillA.ctxs[0]))
+	assert.Empty(t, other.msgs)
+}
+
+type peakTask struct {
+	*stubTask
+	running *atomic.Int32
+	peak    *atomic.Int32
+}
+
+func (t *peakTask) Execute(context.Context, *ExecutionMessage) error {
+	n := t.running.Add(1)
+	defer t.running.Add(-1)
+	for {
+		peak := t.peak.Load()
+		if n <= peak || t.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return nil
+}
+
+func TestCronManagerTriggerMatchingBoundsConcurrency(t *testing.T) {
+	reg := newStubRegistry()
+	var running, peak atomic.Int32
+	for i := 0; i < 6; i++ {
+		task := newStubTask(fmt.Sprintf("job-%d", i), Config{Metadata: map[string]any{"tags": "backfill"}})
+		require.NoError(t, reg.Add(&peakTask{stubTask: task, running: &running, peak: &peak}))
+	}
+
+	manager := NewCronManager(reg, newStubScheduler()).WithBulkConcurrency(2)
+	results := manager.TriggerMatching(context.Background(), Selector{"tag": "backfill"}, nil)
+
+	require.Len(t, results, 6)
+	assert.Positive(t, peak.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestBulkResultJSONIncludesError(t *testing.T) {
+	raw, err := json.Marshal(BulkResult{JobID: "a", Status: "failed", Error: errors.New("boom")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"job_id":"a","status":"failed","error":"boom"}`, string(raw))
+}
+
+func TestCronManagerPauseSkipsScheduledRuns(t *testing.T) {
+	reg := newStubRegistry()
+	task := &capturingTask{stubTask: newStubTask("job-1", Config{Metadata: map[string]any{"tenant": "acme"}})}
+	require.NoError(t, reg.Add(task))
+
+	scheduler := newStubScheduler()
+	sink := NewMemoryAuditSink()
+	manager := NewCronManager(reg, scheduler).WithAuditSink(sink)
+	require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+		ID:         "hourly",
+		Expression: "@hourly",
+		Message:    ExecutionMessage{JobID: "job-1"},
+	}))
+
+	results := manager.PauseMatching(context.Background(), Selector{"tenant": "acme"})
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Error)
+	assert.True(t, manager.IsPaused("job-1"))
+
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	assert.Empty(t, task.msgs)
+
+	require.NoError(t, manager.ResumeJob(context.Background(), "job-1"))
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	assert.Len(t, task.msgs, 1)
+
+	require.Error(t, manager.PauseJob(context.Background(), "missing"))
+	actions := []AuditAction{}
+	for _, entry := range sink.Entries() {
+		actions = append(actions, entry.Action)
+	}
+	assert.Contains(t, actions, AuditActionJobPaused)
+	assert.Contains(t, actions, AuditActionJobResumed)
+}
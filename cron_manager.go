@@ -2,9 +2,12 @@ package job
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/goliatone/go-command"
 	gocron "github.com/goliatone/go-command/cron"
@@ -18,6 +21,13 @@ type ScheduleDefinition struct {
 	ID         string           `json:"id" yaml:"id"`
 	Expression string           `json:"expression" yaml:"expression"`
 	Message    ExecutionMessage `json:"message" yaml:"message"`
+	// Calendar names a calendar registered with WithCalendar. When empty the
+	// task's `calendar` metadata is used.
+	Calendar string `json:"calendar,omitempty" yaml:"calendar,omitempty"`
+	// Owner and Team override the task's ownership metadata for this
+	// schedule's runs, routing their notifications (see OwnerChannel).
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Team  string `json:"team,omitempty" yaml:"team,omitempty"`
 }
 
 // ReconcileResult captures the diff outcome when aligning schedules.
@@ -25,6 +35,8 @@ type ReconcileResult struct {
 	Added   []string
 	Updated []string
 	Removed []string
+	// Expired lists desired schedules dropped because their deadline passed.
+	Expired []string
 }
 
 type cronScheduler interface {
@@ -41,26 +53,69 @@ type CronManager struct {
 	registry  Registry
 	scheduler cronScheduler
 
-	tracker *IdempotencyTracker
-	limiter *ConcurrencyLimiter
-	quotas  QuotaChecker
-
-	mu        sync.RWMutex
-	schedules map[string]*scheduledEntry
+	tracker     *IdempotencyTracker
+	limiter     *ConcurrencyLimiter
+	quotas      QuotaChecker
+	audit       AuditSink
+	beats       *HeartbeatMonitor
+	onSlow      SlowExecutionHandler
+	runLogs     *RunLogStore
+	notify      map[string]Notifier
+	events      *RunEventBroker
+	onExit      ExitOnErrorHandler
+	failures    *FailureStore
+	faults      *FaultInjector
+	clock       Clock
+	archive     *RunArchive
+	workspaces  *WorkspaceManager
+	calendars   map[string]*Calendar
+	onCalendar  CalendarSkipHandler
+	metrics     cronStats
+	history     *ScheduleHistory
+	onReport    ExecutionReportHandler
+	skew        *time.Duration
+	onExpired   ScheduleExpiredHandler
+	runs        *RunCache
+	runHistory  ExecutionHistory
+	maintenance *MaintenanceMode
+	beforeRun   []BeforeRunHook
+	afterRun    []AfterRunHook
+	bulkLimit   int
+
+	ctx  context.Context
+	stop context.CancelFunc
+
+	mu            sync.RWMutex
+	schedules     map[string]*scheduledEntry
+	shifted       map[string]time.Time
+	paused        map[string]struct{}
+	lastReconcile *ReconcileReport
 }
 
 // NewCronManager wires schedule management against a task registry and a cron scheduler.
 func NewCronManager(registry Registry, scheduler cronScheduler) *CronManager {
+	ctx, stop := context.WithCancel(context.Background())
 	return &CronManager{
+		ctx:       ctx,
+		stop:      stop,
 		registry:  registry,
 		scheduler: scheduler,
 		tracker:   defaultIdempotencyTracker,
 		limiter:   defaultConcurrencyLimiter,
 		quotas:    defaultQuotaChecker,
 		schedules: make(map[string]*scheduledEntry),
+		shifted:   make(map[string]time.Time),
+		paused:    make(map[string]struct{}),
 	}
 }
 
+// Stop cancels the shifted calendar runs still waiting for their business
+// day. Schedules stay registered; the scheduler driving them is stopped
+// separately.
+func (m *CronManager) Stop() {
+	m.stop()
+}
+
 // WithIdempotencyTracker overrides the tracker used for scheduled runs.
 func (m *CronManager) WithIdempotencyTracker(tracker *IdempotencyTracker) *CronManager {
 	if tracker != nil {
@@ -85,6 +140,68 @@ func (m *CronManager) WithQuotaChecker(qc QuotaChecker) *CronManager {
 	return m
 }
 
+// WithAuditSink records schedule registrations, updates, and deletions.
+func (m *CronManager) WithAuditSink(sink AuditSink) *CronManager {
+	m.audit = sink
+	return m
+}
+
+// WithHeartbeatMonitor tracks liveness of scheduled runs.
+func (m *CronManager) WithHeartbeatMonitor(monitor *HeartbeatMonitor) *CronManager {
+	m.beats = monitor
+	return m
+}
+
+// WithNotifier registers a named notifier for scheduled runs.
+func (m *CronManager) WithNotifier(name string, notifier Notifier) *CronManager {
+	if notifier == nil {
+		return m
+	}
+	if m.notify == nil {
+		m.notify = make(map[string]Notifier)
+	}
+	m.notify[name] = notifier
+	return m
+}
+
+// WithRunEvents publishes lifecycle and log events for scheduled runs.
+func (m *CronManager) WithRunEvents(broker *RunEventBroker) *CronManager {
+	m.events = broker
+	return m
+}
+
+// WithClock sets the time source forwarded to scheduled run commanders and
+// used for reconcile reports.
+func (m *CronManager) WithClock(clock Clock) *CronManager {
+	m.clock = clock
+	return m
+}
+
+// WithRunArchive archives scheduled run messages so they can be replayed.
+func (m *CronManager) WithRunArchive(archive *RunArchive) *CronManager {
+	m.archive = archive
+	return m
+}
+
+// WithRunLogStore captures logs for scheduled runs.
+func (m *CronManager) WithRunLogStore(store *RunLogStore) *CronManager {
+	m.runLogs = store
+	return m
+}
+
+// WithSlowExecutionHandler forwards warn_after notifications from scheduled runs.
+func (m *CronManager) WithSlowExecutionHandler(handler SlowExecutionHandler) *CronManager {
+	m.onSlow = handler
+	return m
+}
+
+// WithExecutionReports receives the ExecutionReport of every scheduled run,
+// with ScheduleID set to the firing schedule.
+func (m *CronManager) WithExecutionReports(handler ExecutionReportHandler) *CronManager {
+	m.onReport = handler
+	return m
+}
+
 // Register registers a new cron schedule; returns an error if the ID already exists.
 func (m *CronManager) Register(ctx context.Context, def ScheduleDefinition) error {
 	if ctx == nil {
@@ -114,14 +231,18 @@ func (m *CronManager) Register(ctx context.Context, def ScheduleDefinition) erro
 	if err != nil {
 		return err
 	}
+	if err := m.expiredSchedule(resolved.ID, msg); err != nil {
+		return err
+	}
 
 	cmd := m.buildCommander(resolved.Message.JobID)
 	if cmd == nil {
 		return fmt.Errorf("task %q not found for schedule %q", resolved.Message.JobID, resolved.ID)
 	}
 
-	job := func() error {
-		return cmd.Execute(context.Background(), cloneExecutionMessage(msg))
+	job, err := m.scheduledRun(resolved, cmd, msg)
+	if err != nil {
+		return err
 	}
 
 	sub, err := m.scheduler.AddHandler(handlerOpts.ToCommandConfig(), job)
@@ -135,6 +256,15 @@ func (m *CronManager) Register(ctx context.Context, def ScheduleDefinition) erro
 		subscription: sub,
 	}
 	m.mu.Unlock()
+	m.metrics.scheduled(resolved, clockOrSystem(m.clock).Now(), false)
+	m.history.record(ctx, resolved.ID, ScheduleChangeRegistered, &resolved)
+
+	recordAudit(ctx, m.audit, AuditEntry{
+		Action:     AuditActionScheduleRegistered,
+		ScheduleID: resolved.ID,
+		JobID:      resolved.Message.JobID,
+		After:      auditScheduleSnapshot(resolved),
+	})
 
 	return nil
 }
@@ -162,14 +292,21 @@ func (m *CronManager) Update(ctx context.Context, def ScheduleDefinition) error
 	if err != nil {
 		return err
 	}
+	if err := m.expiredSchedule(resolved.ID, msg); err != nil {
+		if derr := m.Delete(ctx, def.ID); derr != nil {
+			return derr
+		}
+		return err
+	}
 
 	cmd := m.buildCommander(resolved.Message.JobID)
 	if cmd == nil {
 		return fmt.Errorf("task %q not found for schedule %q", resolved.Message.JobID, resolved.ID)
 	}
 
-	job := func() error {
-		return cmd.Execute(context.Background(), cloneExecutionMessage(msg))
+	job, err := m.scheduledRun(resolved, cmd, msg)
+	if err != nil {
+		return err
 	}
 
 	sub, err := m.scheduler.AddHandler(handlerOpts.ToCommandConfig(), job)
@@ -187,6 +324,16 @@ func (m *CronManager) Update(ctx context.Context, def ScheduleDefinition) error
 	if existing.subscription != nil {
 		existing.subscription.Unsubscribe()
 	}
+	m.metrics.scheduled(resolved, clockOrSystem(m.clock).Now(), true)
+	m.history.record(ctx, resolved.ID, ScheduleChangeUpdated, &resolved)
+
+	recordAudit(ctx, m.audit, AuditEntry{
+		Action:     AuditActionScheduleUpdated,
+		ScheduleID: resolved.ID,
+		JobID:      resolved.Message.JobID,
+		Before:     auditScheduleSnapshot(existing.definition),
+		After:      auditScheduleSnapshot(resolved),
+	})
 
 	return nil
 }
@@ -217,33 +364,48 @@ func (m *CronManager) Delete(ctx context.Context, id string) error {
 	if entry.subscription != nil {
 		entry.subscription.Unsubscribe()
 	}
+	m.metrics.removed(id)
+	m.history.record(ctx, id, ScheduleChangeDeleted, nil)
+
+	recordAudit(ctx, m.audit, AuditEntry{
+		Action:     AuditActionScheduleDeleted,
+		ScheduleID: id,
+		JobID:      entry.definition.Message.JobID,
+		Before:     auditScheduleSnapshot(entry.definition),
+	})
 	return nil
 }
 
-// List returns a copy of registered schedules.
+// List returns a copy of registered schedules ordered by ID.
 func (m *CronManager) List() []ScheduleDefinition {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	out := make([]ScheduleDefinition, 0, len(m.schedules))
 	for _, entry := range m.schedules {
 		out = append(out, cloneScheduleDefinition(entry.definition))
 	}
+	m.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
 	return out
 }
 
 // Reconcile aligns current schedules with the desired set, adding, updating, and removing as needed.
-func (m *CronManager) Reconcile(ctx context.Context, desired []ScheduleDefinition) (ReconcileResult, error) {
+func (m *CronManager) Reconcile(ctx context.Context, desired []ScheduleDefinition) (result ReconcileResult, err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	var result ReconcileResult
+	started := clockOrSystem(m.clock).Now()
+	defer func() {
+		m.recordReconcile(started, result, err)
+	}()
+
 	targets := make(map[string]ScheduleDefinition, len(desired))
 	for _, def := range desired {
 		targets[def.ID] = def
 	}
 
-	for id, def := range targets {
+	for _, id := range sortedStringKeys(targets) {
+		def := targets[id]
 		if err := ctx.Err(); err != nil {
 			return result, err
 		}
@@ -253,19 +415,27 @@ func (m *CronManager) Reconcile(ctx context.Context, desired []ScheduleDefinitio
 
 		if !ok {
 			if err := m.Register(ctx, def); err != nil {
+				if stderrors.Is(err, ErrDeadlineExpired) {
+					result.Expired = append(result.Expired, id)
+					continue
+				}
 				return result, err
 			}
 			result.Added = append(result.Added, id)
 			continue
 		}
 
-		resolved, _, _, err := m.resolve(def)
+		resolved, _, msg, err := m.resolve(def)
 		if err != nil {
 			return result, err
 		}
 
-		if !definitionsEqual(resolved, existing.definition) {
+		if !definitionsEqual(resolved, existing.definition) || DeadlineExpired(msg.Config.Deadline, clockOrSystem(m.clock).Now(), m.deadlineSkew()) {
 			if err := m.Update(ctx, def); err != nil {
+				if stderrors.Is(err, ErrDeadlineExpired) {
+					result.Expired = append(result.Expired, id)
+					continue
+				}
 				return result, err
 			}
 			result.Updated = append(result.Updated, id)
@@ -278,6 +448,7 @@ func (m *CronManager) Reconcile(ctx context.Context, desired []ScheduleDefinitio
 		currentIDs = append(currentIDs, id)
 	}
 	m.mu.RUnlock()
+	sort.Strings(currentIDs)
 
 	for _, id := range currentIDs {
 		if err := ctx.Err(); err != nil {
@@ -294,6 +465,31 @@ func (m *CronManager) Reconcile(ctx context.Context, desired []ScheduleDefinitio
 	return result, nil
 }
 
+// LastReconcile returns the outcome of the most recent Reconcile call.
+func (m *CronManager) LastReconcile() (ReconcileReport, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.lastReconcile == nil {
+		return ReconcileReport{}, false
+	}
+	return *m.lastReconcile, true
+}
+
+func (m *CronManager) recordReconcile(started time.Time, result ReconcileResult, err error) {
+	report := &ReconcileReport{
+		Result:    result,
+		StartedAt: started.UTC(),
+		Duration:  clockOrSystem(m.clock).Now().Sub(started),
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	m.metrics.reconciled(report.Duration, err)
+	m.mu.Lock()
+	m.lastReconcile = report
+	m.mu.Unlock()
+}
+
 // Validate ensures the schedule definition contains required fields.
 func (d ScheduleDefinition) Validate() error {
 	var fieldErrors []errors.FieldError
@@ -309,6 +505,12 @@ func (d ScheduleDefinition) Validate() error {
 			Field:   "expression",
 			Message: "cannot be empty",
 		})
+	} else if _, err := ExpandSchedule(d.Expression); err != nil {
+		fieldErrors = append(fieldErrors, errors.FieldError{
+			Field:   "expression",
+			Message: err.Error(),
+			Value:   d.Expression,
+		})
 	}
 	if d.Message.JobID == "" {
 		fieldErrors = append(fieldErrors, errors.FieldError{
@@ -333,6 +535,12 @@ func (m *CronManager) resolve(def ScheduleDefinition) (ScheduleDefinition, Handl
 	if def.Expression != "" {
 		mergedConfig.Schedule = def.Expression
 	}
+	expanded, err := ExpandSchedule(mergedConfig.Schedule)
+	if err != nil {
+		return ScheduleDefinition{}, HandlerOptions{}, nil, err
+	}
+	mergedConfig.Schedule = expanded
+	mergedConfig = withOwnership(mergedConfig, def.Owner, def.Team)
 
 	msg := def.Message
 	msg.Config = mergedConfig
@@ -350,16 +558,69 @@ func (m *CronManager) resolve(def ScheduleDefinition) (ScheduleDefinition, Handl
 	if handlerOpts.Expression == "" {
 		handlerOpts.Expression = DefaultSchedule
 	}
+	if !handlerOpts.Deadline.IsZero() {
+		handlerOpts.Deadline = handlerOpts.Deadline.Add(m.deadlineSkew())
+	}
+
+	calendar := def.Calendar
+	if calendar == "" {
+		calendar, _ = mergedConfig.Metadata[CalendarMetadataKey].(string)
+	}
 
 	resolved := ScheduleDefinition{
 		ID:         def.ID,
 		Expression: handlerOpts.Expression,
 		Message:    *cloneExecutionMessage(execMsg),
+		Calendar:   calendar,
+		Owner:      def.Owner,
+		Team:       def.Team,
 	}
 
 	return resolved, handlerOpts, execMsg, nil
 }
 
+// scheduledRun builds the scheduler callback; runs for paused jobs are
+// skipped, as are firings the schedule's calendar rejects. A firing past the
+// schedule's deadline drops the schedule instead of running.
+func (m *CronManager) scheduledRun(def ScheduleDefinition, cmd *TaskCommander, msg *ExecutionMessage) (func() error, error) {
+	calendar, err := m.calendar(def.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	run := func() error {
+		if m.IsPaused(msg.JobID) || !m.isEnabled(msg.JobID) {
+			m.metrics.skipped()
+			return nil
+		}
+		if err := m.expiredSchedule(def.ID, msg); err != nil {
+			m.metrics.skipped()
+			_ = m.Delete(context.Background(), def.ID)
+			return err
+		}
+		if m.onReport == nil {
+			return cmd.Execute(context.Background(), cloneExecutionMessage(msg))
+		}
+		report, err := cmd.ExecuteWithReport(context.Background(), cloneExecutionMessage(msg))
+		report.ScheduleID = def.ID
+		m.onReport(report, err)
+		return err
+	}
+	return func() error {
+		m.metrics.fired(def, clockOrSystem(m.clock).Now())
+		if !m.calendarGate(def.ID, calendar, func() { _ = run() }) {
+			m.metrics.skipped()
+			return nil
+		}
+		return run()
+	}, nil
+}
+
+// isEnabled consults the registry when it supports runtime toggles.
+func (m *CronManager) isEnabled(jobID string) bool {
+	toggles, ok := m.registry.(TaskToggler)
+	return !ok || toggles.IsEnabled(jobID)
+}
+
 func (m *CronManager) buildCommander(taskID string) *TaskCommander {
 	if m.registry == nil {
 		return nil
@@ -371,7 +632,29 @@ func (m *CronManager) buildCommander(taskID string) *TaskCommander {
 	cmd := NewTaskCommander(task).
 		WithIdempotencyTracker(m.tracker).
 		WithConcurrencyLimiter(m.limiter).
-		WithQuotaChecker(m.quotas)
+		WithQuotaChecker(m.quotas).
+		WithHeartbeatMonitor(m.beats).
+		WithSlowExecutionHandler(m.onSlow).
+		WithRunLogStore(m.runLogs).
+		WithRunEvents(m.events).
+		WithExitOnErrorHandler(m.exitOnErrorHandler()).
+		WithFailureStore(m.failures).
+		WithFaultInjector(m.faults).
+		WithClock(m.clock).
+		WithRunArchive(m.archive).
+		WithWorkspaces(m.workspaces).
+		WithDeadlineSkew(m.deadlineSkew()).
+		WithRunCache(m.runs).
+		WithExecutionHistory(m.runHistory).
+		WithMaintenanceMode(m.maintenance).
+		WithBeforeRun(m.beforeRun...).
+		WithAfterRun(m.afterRun...)
+	if toggles, ok := m.registry.(TaskToggler); ok {
+		cmd.WithTaskToggler(toggles)
+	}
+	for name, notifier := range m.notify {
+		cmd.WithNotifier(name, notifier)
+	}
 	return cmd
 }
 
@@ -424,5 +707,8 @@ func cloneScheduleDefinition(def ScheduleDefinition) ScheduleDefinition {
 		ID:         def.ID,
 		Expression: def.Expression,
 		Message:    *cloneExecutionMessage(&def.Message),
+		Calendar:   def.Calendar,
+		Owner:      def.Owner,
+		Team:       def.Team,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-command"
 	gocron "github.com/goliatone/go-command/cron"
@@ -108,12 +109,14 @@ func TestCronManagerReconcile(t *testing.T) {
 
 	result, err := manager.Reconcile(context.Background(), desired)
 	require.NoError(t, err)
-	assert.ElementsMatch(t, []string{"job-2-nightly"}, result.Added)
+	assert.Equal(t, []string{"job-2-nightly"}, result.Added)
 	assert.ElementsMatch(t, []string{"job-1-hourly"}, result.Updated)
 	assert.Empty(t, result.Removed)
 
 	schedules := manager.List()
 	require.Len(t, schedules, 2)
+	assert.Equal(t, "job-1-hourly", schedules[0].ID)
+	assert.Equal(t, "job-2-nightly", schedules[1].ID)
 	assert.Equal(t, "*/30 * * * *", findSchedule(t, schedules, "job-1-hourly").Expression)
 	assert.Equal(t, "30 1 * * *", findSchedule(t, schedules, "job-2-nightly").Expression)
 
@@ -180,6 +183,42 @@ func TestScheduleSyncCommandCronAndCLI(t *testing.T) {
 	assert.Equal(t, 4, schedules[0].Message.Config.Retries)
 }
 
+func TestScheduleSyncCommandWatch(t *testing.T) {
+	reg := newStubRegistry()
+	task := newStubTask("job-1", Config{Schedule: "@hourly"})
+	require.NoError(t, reg.Add(task))
+	manager := NewCronManager(reg, newStubScheduler())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	loader := func(context.Context) ([]ScheduleDefinition, error) {
+		calls++
+		defs := []ScheduleDefinition{{ID: "a", Expression: "@hourly", Message: ExecutionMessage{JobID: task.GetID()}}}
+		switch calls {
+		case 1:
+			return defs, nil
+		case 3:
+			return append(defs, ScheduleDefinition{ID: "b", Expression: "@daily", Message: ExecutionMessage{JobID: task.GetID()}}), nil
+		case 4:
+			cancel()
+		}
+		return nil, fmt.Errorf("settings unavailable")
+	}
+
+	cmd := NewScheduleSyncCommand(manager, loader)
+	require.NoError(t, cmd.Watch(ctx, time.Millisecond))
+
+	stats := cmd.Stats()
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, int64(4), stats.Cycles)
+	assert.Equal(t, int64(2), stats.Failures)
+	assert.Equal(t, int64(2), stats.Added)
+	assert.Equal(t, "settings unavailable", stats.LastError)
+	assert.Len(t, manager.List(), 2, "failed cycles keep the last reconciled schedules")
+}
+
 func findSchedule(t *testing.T, schedules []ScheduleDefinition, id string) ScheduleDefinition {
 	t.Helper()
 	for _, s := range schedules {
@@ -289,3 +328,108 @@ func (t *stubTask) GetConfig() Config                                { return t.
 func (t *stubTask) GetPath() string                                  { return t.path }
 func (t *stubTask) GetEngine() Engine                                { return nil }
 func (t *stubTask) Execute(context.Context, *ExecutionMessage) error { return nil }
+
+func TestCronManagerSkipsDisabledTasks(t *testing.T) {
+	reg := NewMemoryRegistry()
+	task := &capturingTask{stubTask: newStubTask("job-1", Config{})}
+	require.NoError(t, reg.Add(task))
+
+	scheduler := newStubScheduler()
+	manager := NewCronManager(reg, scheduler)
+	require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+		ID:         "hourly",
+		Expression: "@hourly",
+		Message:    ExecutionMessage{JobID: "job-1"},
+	}))
+
+	require.NoError(t, reg.SetEnabled("job-1", false))
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	assert.Empty(t, task.msgs)
+
+	require.NoError(t, reg.SetEnabled("job-1", true))
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	assert.Len(t, task.msgs, 1)
+}
+
+func TestCronManagerRefusesRunsInMaintenanceMode(t *testing.T) {
+	reg := NewMemoryRegistry()
+	task := &capturingTask{stubTask: newStubTask("job-1", Config{})}
+	require.NoError(t, reg.Add(task))
+
+	var reports []ExecutionReport
+	mode := NewMaintenanceMode()
+	scheduler := newStubScheduler()
+	manager := NewCronManager(reg, scheduler).
+		WithMaintenanceMode(mode).
+		WithExecutionReports(func(report ExecutionReport, _ error) { reports = append(reports, report) })
+	require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+		ID:         "hourly",
+		Expression: "@hourly",
+		Message:    ExecutionMessage{JobID: "job-1"},
+	}))
+
+	mode.Enable("failover")
+	for _, run := range scheduler.jobs {
+		assert.ErrorIs(t, run(), ErrMaintenanceMode)
+	}
+	assert.Empty(t, task.msgs)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "hourly", reports[0].ScheduleID)
+	assert.Equal(t, Result{Status: ResultStatusMaintenance, Message: "maintenance mode: failover"}, reports[0].Result)
+	assert.Len(t, manager.List(), 1, "schedules are kept")
+
+	mode.Disable()
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	assert.Len(t, task.msgs, 1)
+}
+
+func TestCronManagerReconcileReportsSortedIDs(t *testing.T) {
+	reg := newStubRegistry()
+	require.NoError(t, reg.Add(newStubTask("job", Config{})))
+	manager := NewCronManager(reg, newStubScheduler())
+
+	var desired []ScheduleDefinition
+	for _, id := range []string{"e", "b", "d", "a", "c"} {
+		desired = append(desired, ScheduleDefinition{ID: id, Expression: "@hourly", Message: ExecutionMessage{JobID: "job"}})
+	}
+	result, err := manager.Reconcile(context.Background(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, result.Added)
+
+	result, err = manager.Reconcile(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, result.Removed)
+}
+
+func TestCronManagerExecutionReports(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(&capturingTask{stubTask: newStubTask("job-1", Config{})}))
+
+	var reports []ExecutionReport
+	scheduler := newStubScheduler()
+	manager := NewCronManager(reg, scheduler).WithExecutionReports(func(report ExecutionReport, err error) {
+		assert.NoError(t, err)
+		reports = append(reports, report)
+	})
+	require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+		ID:         "hourly",
+		Expression: "@hourly",
+		Message:    ExecutionMessage{JobID: "job-1"},
+	}))
+
+	for _, run := range scheduler.jobs {
+		require.NoError(t, run())
+	}
+	require.Len(t, reports, 1)
+	assert.Equal(t, "hourly", reports[0].ScheduleID)
+	assert.Equal(t, "job-1", reports[0].JobID)
+	assert.Equal(t, 1, reports[0].Attempts)
+	assert.True(t, reports[0].Succeeded())
+	assert.NotEmpty(t, reports[0].RunID)
+}
@@ -0,0 +1,121 @@
+package job
+
+import (
+	"sync"
+	"time"
+)
+
+// CronStats is a point-in-time snapshot of CronManager activity. Counters
+// are cumulative since the manager was created.
+type CronStats struct {
+	ActiveSchedules int `json:"active_schedules"`
+	PausedJobs      int `json:"paused_jobs"`
+
+	Registrations uint64 `json:"registrations"`
+	Updates       uint64 `json:"updates"`
+	Removals      uint64 `json:"removals"`
+
+	Reconciles            uint64        `json:"reconciles"`
+	ReconcileFailures     uint64        `json:"reconcile_failures"`
+	LastReconcileDuration time.Duration `json:"last_reconcile_duration"`
+	MaxReconcileDuration  time.Duration `json:"max_reconcile_duration"`
+
+	// Firings counts scheduler callbacks; SkippedFirings those that did not
+	// run because the job was paused or disabled, or its calendar rejected
+	// the day.
+	Firings        uint64 `json:"firings"`
+	SkippedFirings uint64 `json:"skipped_firings"`
+
+	// Skew is how late a firing arrived compared with the time its cron
+	// expression predicted.
+	LastSkew time.Duration `json:"last_skew"`
+	MaxSkew  time.Duration `json:"max_skew"`
+}
+
+type cronStats struct {
+	mu       sync.Mutex
+	stats    CronStats
+	expected map[string]time.Time
+}
+
+// Stats returns the manager's counters and gauges.
+func (m *CronManager) Stats() CronStats {
+	m.mu.RLock()
+	active, paused := len(m.schedules), len(m.paused)
+	m.mu.RUnlock()
+
+	m.metrics.mu.Lock()
+	defer m.metrics.mu.Unlock()
+	stats := m.metrics.stats
+	stats.ActiveSchedules = active
+	stats.PausedJobs = paused
+	return stats
+}
+
+// scheduled records a registration or update and predicts the next firing.
+func (s *cronStats) scheduled(def ScheduleDefinition, now time.Time, update bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if update {
+		s.stats.Updates++
+	} else {
+		s.stats.Registrations++
+	}
+	if s.expected == nil {
+		s.expected = make(map[string]time.Time)
+	}
+	if next, err := NextRun(def.Expression, now); err == nil {
+		s.expected[def.ID] = next
+	} else {
+		delete(s.expected, def.ID)
+	}
+}
+
+func (s *cronStats) removed(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Removals++
+	delete(s.expected, id)
+}
+
+func (s *cronStats) reconciled(duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Reconciles++
+	if err != nil {
+		s.stats.ReconcileFailures++
+	}
+	s.stats.LastReconcileDuration = duration
+	if duration > s.stats.MaxReconcileDuration {
+		s.stats.MaxReconcileDuration = duration
+	}
+}
+
+// fired records a scheduler callback and measures its skew against the
+// predicted firing time.
+func (s *cronStats) fired(def ScheduleDefinition, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Firings++
+	expected, ok := s.expected[def.ID]
+	if !ok {
+		return
+	}
+	skew := now.Sub(expected)
+	if skew < 0 {
+		skew = 0
+	}
+	s.stats.LastSkew = skew
+	if skew > s.stats.MaxSkew {
+		s.stats.MaxSkew = skew
+	}
+	if next, err := NextRun(def.Expression, now); err == nil {
+		s.expected[def.ID] = next
+	}
+}
+
+func (s *cronStats) skipped() {
+	s.mu.Lock()
+	s.stats.SkippedFirings++
+	s.mu.Unlock()
+}
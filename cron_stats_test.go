@@ -0,0 +1,56 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronManagerStats(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local))
+	scheduler := jobtest.NewScheduler(clock)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("sync", "jobs/sync.js", "js", job.Config{}, "", noopEngine{})))
+	manager := job.NewCronManager(registry, scheduler).WithClock(clock)
+
+	ctx := context.Background()
+	hourly := job.ScheduleDefinition{ID: "hourly", Expression: "0 * * * *", Message: job.ExecutionMessage{JobID: "sync"}}
+	_, err := manager.Reconcile(ctx, []job.ScheduleDefinition{hourly})
+	require.NoError(t, err)
+
+	clock.Set(time.Date(2024, 1, 1, 11, 0, 5, 0, time.Local))
+	_, err = scheduler.RunAll()
+	require.NoError(t, err)
+
+	require.NoError(t, manager.PauseJob(ctx, "sync"))
+	_, err = scheduler.RunAll()
+	require.NoError(t, err)
+
+	hourly.Expression = "30 * * * *"
+	require.NoError(t, manager.Update(ctx, hourly))
+
+	stats := manager.Stats()
+	assert.Equal(t, 1, stats.ActiveSchedules)
+	assert.Equal(t, 1, stats.PausedJobs)
+	assert.Equal(t, uint64(1), stats.Registrations)
+	assert.Equal(t, uint64(1), stats.Updates)
+	assert.Equal(t, uint64(1), stats.Reconciles)
+	assert.Equal(t, uint64(2), stats.Firings)
+	assert.Equal(t, uint64(1), stats.SkippedFirings)
+	assert.Equal(t, 5*time.Second, stats.MaxSkew)
+
+	require.NoError(t, manager.Delete(ctx, "hourly"))
+	_, err = manager.Reconcile(ctx, []job.ScheduleDefinition{{ID: "bad", Expression: "@daily", Message: job.ExecutionMessage{JobID: "missing"}}})
+	require.Error(t, err)
+
+	stats = manager.Stats()
+	assert.Equal(t, 0, stats.ActiveSchedules)
+	assert.Equal(t, uint64(1), stats.Removals)
+	assert.Equal(t, uint64(2), stats.Reconciles)
+	assert.Equal(t, uint64(1), stats.ReconcileFailures)
+}
@@ -0,0 +1,206 @@
+package job
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// CrontabCommandParam is the Parameters key holding the original crontab
+// command of an imported schedule; WriteCrontab emits it back verbatim.
+const CrontabCommandParam = "command"
+
+// ParseCrontab reads a classic user crontab into schedule definitions so
+// entries can be loaded into CronManager. Each entry's JobID is the base name
+// of the command's executable (matching DefaultTaskIDProvider) and its ID
+// defaults to the JobID, suffixed "-2", "-3"... when repeated. A preceding
+// "# id: <id>" or "# job: <job id>" comment overrides either. Environment
+// assignments (NAME=value) apply to the entries that follow, as in cron.
+// @reboot entries are rejected since they have no schedule.
+func ParseCrontab(r io.Reader) ([]ScheduleDefinition, error) {
+	var (
+		defs       []ScheduleDefinition
+		env        map[string]string
+		pendingID  string
+		pendingJob string
+		seen       = map[string]int{}
+		lineNo     int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if value, ok := strings.CutPrefix(comment, "id:"); ok {
+				pendingID = strings.TrimSpace(value)
+			} else if value, ok := strings.CutPrefix(comment, "job:"); ok {
+				pendingJob = strings.TrimSpace(value)
+			}
+			continue
+		}
+
+		if name, value, ok := crontabEnvAssignment(line); ok {
+			env = mergeStringMaps(env, map[string]string{name: value})
+			continue
+		}
+
+		expression, command, err := splitCrontabEntry(line)
+		if err != nil {
+			return nil, crontabError(lineNo, err.Error())
+		}
+		if _, err := NextRun(expression, time.Now()); err != nil {
+			return nil, crontabError(lineNo, err.Error())
+		}
+
+		jobID := pendingJob
+		if jobID == "" {
+			jobID = DefaultTaskIDProvider(strings.Fields(command)[0])
+		}
+		id := pendingID
+		if id == "" {
+			id = jobID
+		}
+		if n := seen[id]; n > 0 {
+			id = fmt.Sprintf("%s-%d", id, n+1)
+		}
+		seen[id]++
+
+		def := ScheduleDefinition{
+			ID:         id,
+			Expression: expression,
+			Message: ExecutionMessage{
+				JobID:      jobID,
+				Parameters: map[string]any{CrontabCommandParam: command},
+			},
+		}
+		if len(env) > 0 {
+			def.Message.Config.Env = mergeStringMaps(nil, env)
+		}
+		defs = append(defs, def)
+		pendingID, pendingJob = "", ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read crontab: %w", err)
+	}
+	return defs, nil
+}
+
+// WriteCrontab renders defs as a crontab. Each entry is preceded by its
+// "# id:" and "# job:" annotations so ParseCrontab restores them. The command
+// comes from the CrontabCommandParam parameter, falling back to the script
+// path and then the job ID. Env changes are written as assignments before the
+// entry; keys dropped by a later entry are written as empty assignments.
+func WriteCrontab(w io.Writer, defs []ScheduleDefinition) error {
+	bw := bufio.NewWriter(w)
+	current := map[string]string{}
+
+	for i, def := range defs {
+		if def.Expression == "" {
+			return fmt.Errorf("schedule %s has no expression", def.ID)
+		}
+		expression, err := ExpandSchedule(def.Expression)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", def.ID, err)
+		}
+		def.Expression = expression
+		if strings.HasPrefix(def.Expression, "@every") {
+			return fmt.Errorf("schedule %s: %s cannot be expressed in crontab", def.ID, def.Expression)
+		}
+
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+
+		desired := def.Message.Config.Env
+		for _, key := range sortedStringKeys(current) {
+			if _, ok := desired[key]; !ok {
+				fmt.Fprintf(bw, "%s=\n", key)
+				delete(current, key)
+			}
+		}
+		for _, key := range sortedStringKeys(desired) {
+			if value, ok := current[key]; !ok || value != desired[key] {
+				fmt.Fprintf(bw, "%s=%s\n", key, desired[key])
+				current[key] = desired[key]
+			}
+		}
+
+		fmt.Fprintf(bw, "# id: %s\n# job: %s\n", def.ID, def.Message.JobID)
+		fmt.Fprintf(bw, "%s %s\n", def.Expression, crontabCommand(def))
+	}
+	return bw.Flush()
+}
+
+func crontabCommand(def ScheduleDefinition) string {
+	if command, ok := def.Message.Parameters[CrontabCommandParam].(string); ok && command != "" {
+		return command
+	}
+	if def.Message.ScriptPath != "" {
+		return def.Message.ScriptPath
+	}
+	return def.Message.JobID
+}
+
+func splitCrontabEntry(line string) (string, string, error) {
+	fields := strings.Fields(line)
+	if strings.HasPrefix(fields[0], "@") {
+		if fields[0] == "@reboot" {
+			return "", "", fmt.Errorf("@reboot entries have no schedule")
+		}
+		if len(fields) < 2 {
+			return "", "", fmt.Errorf("missing command")
+		}
+		return fields[0], strings.TrimSpace(strings.TrimPrefix(line, fields[0])), nil
+	}
+	if len(fields) < 6 {
+		return "", "", fmt.Errorf("expected five schedule fields and a command")
+	}
+
+	rest := line
+	for i := 0; i < 5; i++ {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, fields[i]))
+	}
+	return strings.Join(fields[:5], " "), rest, nil
+}
+
+// crontabEnvAssignment recognises NAME=value lines. Values may be quoted.
+func crontabEnvAssignment(line string) (string, string, bool) {
+	name, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, " \t*/,@") {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+func crontabError(line int, msg string) error {
+	return errors.New(fmt.Sprintf("crontab line %d: %s", line, msg), errors.CategoryBadInput).
+		WithTextCode("CRONTAB_INVALID").
+		WithMetadata(map[string]any{"line": line})
+}
+
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
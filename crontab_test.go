@@ -0,0 +1,70 @@
+package job_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCrontab = `
+# nightly maintenance
+MAILTO=ops@example.com
+PATH="/usr/local/bin:/usr/bin"
+30 2 * * *   /opt/jobs/backup.sh --full
+*/15 * * * * /opt/jobs/backup.sh --incremental
+
+# id: reports
+# job: report.js
+@daily node /opt/jobs/report.js
+`
+
+func TestParseCrontab(t *testing.T) {
+	defs, err := job.ParseCrontab(strings.NewReader(sampleCrontab))
+	require.NoError(t, err)
+	require.Len(t, defs, 3)
+
+	assert.Equal(t, "backup.sh", defs[0].ID)
+	assert.Equal(t, "backup.sh", defs[0].Message.JobID)
+	assert.Equal(t, "30 2 * * *", defs[0].Expression)
+	assert.Equal(t, "/opt/jobs/backup.sh --full", defs[0].Message.Parameters[job.CrontabCommandParam])
+	assert.Equal(t, map[string]string{"MAILTO": "ops@example.com", "PATH": "/usr/local/bin:/usr/bin"}, defs[0].Message.Config.Env)
+
+	assert.Equal(t, "backup.sh-2", defs[1].ID)
+	assert.Equal(t, "*/15 * * * *", defs[1].Expression)
+
+	assert.Equal(t, "reports", defs[2].ID)
+	assert.Equal(t, "report.js", defs[2].Message.JobID)
+	assert.Equal(t, "@daily", defs[2].Expression)
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	for name, input := range map[string]string{
+		"reboot":       "@reboot /opt/start.sh",
+		"short":        "* * * /opt/x.sh",
+		"bad schedule": "61 * * * * /opt/x.sh",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := job.ParseCrontab(strings.NewReader(input))
+			assert.ErrorContains(t, err, "crontab line 1")
+		})
+	}
+}
+
+func TestWriteCrontabRoundTrip(t *testing.T) {
+	defs, err := job.ParseCrontab(strings.NewReader(sampleCrontab))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, job.WriteCrontab(&buf, defs))
+
+	again, err := job.ParseCrontab(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, defs, again)
+
+	err = job.WriteCrontab(&buf, []job.ScheduleDefinition{{ID: "x", Expression: "@every 5m"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// DefaultDeadlineSkew tolerates clock drift between the host that set
+// Config.Deadline and the host running the job: a deadline only counts as
+// passed once local time is this far beyond it.
+const DefaultDeadlineSkew = 5 * time.Second
+
+// ResultStatusExpired is the Result status recorded when a run is refused,
+// or cut short, because Config.Deadline passed.
+const ResultStatusExpired = "expired"
+
+// ErrDeadlineExpired is wrapped by the error returned for runs whose
+// Config.Deadline has passed; match it with errors.Is.
+var ErrDeadlineExpired = errors.New("deadline expired", errors.CategoryOperation).
+	WithTextCode("JOB_DEADLINE_EXPIRED")
+
+// DeadlineExpired reports whether deadline passed more than skew before now.
+// A zero deadline never expires.
+func DeadlineExpired(deadline, now time.Time, skew time.Duration) bool {
+	return !deadline.IsZero() && now.After(deadline.Add(skew))
+}
+
+func newDeadlineExpiredError(jobID string, deadline, now time.Time) *errors.Error {
+	err := errors.New(fmt.Sprintf("job %s deadline %s has passed", jobID, deadline.Format(time.RFC3339)), errors.CategoryOperation).
+		WithTextCode("JOB_DEADLINE_EXPIRED").
+		WithMetadata(map[string]any{"job_id": jobID, "deadline": deadline, "detected_at": now})
+	err.Source = ErrDeadlineExpired
+	return err
+}
+
+// ScheduleExpiredHandler is notified when CronManager drops a schedule
+// because its deadline passed, e.g. while the process was down.
+type ScheduleExpiredHandler func(scheduleID, jobID string, deadline, detectedAt time.Time)
+
+// WithDeadlineSkew sets the tolerance applied to Config.Deadline checks.
+// Defaults to DefaultDeadlineSkew; negative values are treated as zero.
+func (c *TaskCommander) WithDeadlineSkew(skew time.Duration) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.skew = &skew
+	return c
+}
+
+func (c *TaskCommander) deadlineSkew() time.Duration {
+	if c.skew == nil {
+		return DefaultDeadlineSkew
+	}
+	return max(*c.skew, 0)
+}
+
+// checkDeadline refuses runs whose deadline has passed and bounds the others
+// by the deadline plus skew.
+func (c *TaskCommander) checkDeadline(ctx context.Context, msg *ExecutionMessage) (context.Context, context.CancelFunc, error) {
+	deadline := msg.Config.Deadline
+	if deadline.IsZero() {
+		return ctx, func() {}, nil
+	}
+	now := c.now()
+	if DeadlineExpired(deadline, now, c.deadlineSkew()) {
+		return ctx, func() {}, newDeadlineExpiredError(msg.JobID, deadline, now)
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline.Add(c.deadlineSkew()))
+	return ctx, cancel, nil
+}
+
+// expiredDuringRun reports whether err came from the deadline bound set by
+// checkDeadline.
+func (c *TaskCommander) expiredDuringRun(msg *ExecutionMessage, err error) bool {
+	return err != nil && stderrors.Is(err, context.DeadlineExceeded) &&
+		DeadlineExpired(msg.Config.Deadline, c.now(), c.deadlineSkew())
+}
+
+// WithDeadlineSkew sets the tolerance applied to schedule deadlines, both
+// when registering schedules and for the runs they fire. Defaults to
+// DefaultDeadlineSkew.
+func (m *CronManager) WithDeadlineSkew(skew time.Duration) *CronManager {
+	m.skew = &skew
+	return m
+}
+
+// WithScheduleExpiredHandler is notified when a schedule is dropped because
+// its deadline passed.
+func (m *CronManager) WithScheduleExpiredHandler(handler ScheduleExpiredHandler) *CronManager {
+	m.onExpired = handler
+	return m
+}
+
+func (m *CronManager) deadlineSkew() time.Duration {
+	if m.skew == nil {
+		return DefaultDeadlineSkew
+	}
+	return max(*m.skew, 0)
+}
+
+// expiredSchedule returns an ErrDeadlineExpired error when the resolved
+// schedule's deadline has passed, notifying the expired handler.
+func (m *CronManager) expiredSchedule(scheduleID string, msg *ExecutionMessage) error {
+	deadline := msg.Config.Deadline
+	now := clockOrSystem(m.clock).Now()
+	if !DeadlineExpired(deadline, now, m.deadlineSkew()) {
+		return nil
+	}
+	if m.onExpired != nil {
+		m.onExpired(scheduleID, msg.JobID, deadline, now)
+	}
+	return fmt.Errorf("schedule %q dropped: %w", scheduleID, newDeadlineExpiredError(msg.JobID, deadline, now))
+}
@@ -0,0 +1,94 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlineExpiredHonorsSkew(t *testing.T) {
+	deadline := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, job.DeadlineExpired(time.Time{}, deadline.Add(time.Hour), 0))
+	assert.False(t, job.DeadlineExpired(deadline, deadline.Add(3*time.Second), 5*time.Second))
+	assert.True(t, job.DeadlineExpired(deadline, deadline.Add(6*time.Second), 5*time.Second))
+	assert.True(t, job.DeadlineExpired(deadline, deadline.Add(time.Second), 0))
+}
+
+func TestTaskCommanderReportsExpiredDeadline(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := &countingEngine{}
+	cmd := job.NewTaskCommander(job.NewBaseTask("job-1", "jobs/one.js", "js", job.Config{}, "", engine)).WithClock(clock)
+
+	msg := &job.ExecutionMessage{JobID: "job-1", Config: job.Config{Deadline: clock.Now().Add(-3 * time.Second)}}
+	report, err := cmd.ExecuteWithReport(context.Background(), msg)
+	require.NoError(t, err, "deadline within the default skew still runs")
+	assert.True(t, report.Succeeded())
+	require.Equal(t, int32(1), engine.runs.Load())
+
+	msg = &job.ExecutionMessage{JobID: "job-1", Config: job.Config{Deadline: clock.Now().Add(-time.Minute)}}
+	report, err = cmd.ExecuteWithReport(context.Background(), msg)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, job.ErrDeadlineExpired))
+	assert.Equal(t, job.ResultStatusExpired, report.Result.Status)
+	assert.Zero(t, report.Attempts)
+	assert.Equal(t, int32(1), engine.runs.Load())
+
+	msg = &job.ExecutionMessage{JobID: "job-1", Config: job.Config{Deadline: clock.Now().Add(-3 * time.Second)}}
+	_, err = cmd.WithDeadlineSkew(0).ExecuteWithReport(context.Background(), msg)
+	assert.True(t, stderrors.Is(err, job.ErrDeadlineExpired))
+}
+
+func TestCronManagerDropsExpiredSchedules(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	reg := job.NewMemoryRegistry()
+	engine := &countingEngine{}
+	require.NoError(t, reg.Add(job.NewBaseTask("job-1", "jobs/one.js", "js", job.Config{}, "", engine)))
+
+	type expiredEvent struct {
+		scheduleID string
+		jobID      string
+	}
+	var events []expiredEvent
+	scheduler := jobtest.NewScheduler(clock)
+	manager := job.NewCronManager(reg, scheduler).
+		WithClock(clock).
+		WithScheduleExpiredHandler(func(scheduleID, jobID string, deadline, detectedAt time.Time) {
+			events = append(events, expiredEvent{scheduleID, jobID})
+		})
+
+	deadline := clock.Now().Add(time.Hour)
+	def := job.ScheduleDefinition{
+		ID:         "until-noon",
+		Expression: "@hourly",
+		Message:    job.ExecutionMessage{JobID: "job-1", Config: job.Config{Deadline: deadline}},
+	}
+	require.NoError(t, manager.Register(context.Background(), def))
+	require.Len(t, scheduler.Entries(), 1)
+	assert.Equal(t, deadline.Add(job.DefaultDeadlineSkew), scheduler.Entries()[0].Config.Deadline)
+
+	clock.Advance(2 * time.Hour)
+	_, err := scheduler.RunAll()
+	assert.True(t, stderrors.Is(err, job.ErrDeadlineExpired))
+	assert.Equal(t, int32(0), engine.runs.Load())
+	assert.Zero(t, scheduler.Len())
+	assert.Empty(t, manager.List())
+	assert.Equal(t, []expiredEvent{{"until-noon", "job-1"}}, events)
+
+	// A process restarting after the deadline reports the schedule as expired.
+	result, err := manager.Reconcile(context.Background(), []job.ScheduleDefinition{def})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"until-noon"}, result.Expired)
+	assert.Empty(t, result.Added)
+	assert.Empty(t, manager.List())
+	assert.Len(t, events, 2)
+
+	err = manager.Register(context.Background(), def)
+	assert.True(t, stderrors.Is(err, job.ErrDeadlineExpired))
+}
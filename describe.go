@@ -0,0 +1,210 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// TaskDoc is the structured description of a task, suitable for generating
+// job catalogs.
+type TaskDoc struct {
+	ID          string `json:"id" yaml:"id"`
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	Engine      string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Schedule    string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// ScheduleText is Schedule in human terms.
+	ScheduleText string      `json:"schedule_text,omitempty" yaml:"schedule_text,omitempty"`
+	Timeout      string      `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries      int         `json:"retries,omitempty" yaml:"retries,omitempty"`
+	Backoff      string      `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	Tags         []string    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters   ParamSchema `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// ParamsError reports an invalid `params` declaration.
+	ParamsError string `json:"params_error,omitempty" yaml:"params_error,omitempty"`
+}
+
+// Describe documents a task from its config and `description`, `owner`,
+// `tags` and `params` metadata.
+func Describe(task Task) TaskDoc {
+	if task == nil {
+		return TaskDoc{}
+	}
+	cfg := task.GetConfig()
+	doc := TaskDoc{
+		ID:       task.GetID(),
+		Path:     taskScriptPath(task),
+		Schedule: cfg.Schedule,
+		Retries:  cfg.Retries,
+		Tags:     stringList(cfg.Metadata["tags"]),
+	}
+	doc.Description, _ = cfg.Metadata["description"].(string)
+	doc.Owner, _ = cfg.Metadata[OwnerMetadataKey].(string)
+	if doc.Schedule == "" {
+		doc.Schedule = task.GetHandlerConfig().Expression
+	}
+	doc.ScheduleText = describeSchedule(doc.Schedule)
+	if engine := task.GetEngine(); engine != nil {
+		doc.Engine = engine.Name()
+	}
+
+	switch {
+	case cfg.NoTimeout:
+		doc.Timeout = "none"
+	case cfg.Timeout != 0:
+		doc.Timeout = cfg.Timeout.String()
+	default:
+		doc.Timeout = DefaultTimeout.String() + " (default)"
+	}
+	if cfg.Retries > 0 {
+		doc.Backoff = describeBackoff(cfg.Backoff)
+	}
+	sort.Strings(doc.Tags)
+
+	params, err := ParamSchemaFromConfig(cfg)
+	if err != nil {
+		doc.ParamsError = err.Error()
+	}
+	doc.Parameters = params
+	return doc
+}
+
+// DescribeAll documents every task in registry, ordered by ID.
+func DescribeAll(registry Registry) []TaskDoc {
+	if registry == nil {
+		return nil
+	}
+	tasks := registry.List()
+	docs := make([]TaskDoc, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil {
+			docs = append(docs, Describe(task))
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	return docs
+}
+
+// CatalogFormat selects the WriteTaskCatalog output.
+type CatalogFormat string
+
+const (
+	CatalogMarkdown CatalogFormat = "markdown"
+	CatalogJSON     CatalogFormat = "json"
+)
+
+// WriteTaskCatalog renders docs as a Markdown catalog (the default) or JSON.
+func WriteTaskCatalog(w io.Writer, docs []TaskDoc, format CatalogFormat) error {
+	switch format {
+	case CatalogJSON:
+		if docs == nil {
+			docs = []TaskDoc{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(docs)
+	case CatalogMarkdown, "":
+		var b strings.Builder
+		b.WriteString("# Jobs\n")
+		for _, doc := range docs {
+			writeTaskDocMarkdown(&b, doc)
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("unsupported catalog format %q", format)
+	}
+}
+
+func writeTaskDocMarkdown(b *strings.Builder, doc TaskDoc) {
+	fmt.Fprintf(b, "\n## %s\n\n", doc.ID)
+	if doc.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", doc.Description)
+	}
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(b, "- **%s:** %s\n", label, value)
+		}
+	}
+	row("Path", inlineCode(doc.Path))
+	row("Engine", doc.Engine)
+	row("Owner", doc.Owner)
+	schedule := inlineCode(doc.Schedule)
+	if doc.ScheduleText != "" && doc.ScheduleText != doc.Schedule {
+		schedule += " (" + doc.ScheduleText + ")"
+	}
+	row("Schedule", schedule)
+	row("Timeout", doc.Timeout)
+	if doc.Retries > 0 {
+		row("Retries", fmt.Sprintf("%d (backoff: %s)", doc.Retries, doc.Backoff))
+	}
+	if len(doc.Tags) > 0 {
+		row("Tags", strings.Join(doc.Tags, ", "))
+	}
+	if doc.ParamsError != "" {
+		row("Parameters", "invalid declaration: "+doc.ParamsError)
+	}
+	if len(doc.Parameters) == 0 {
+		return
+	}
+
+	b.WriteString("\n| Parameter | Type | Required | Default | Description |\n")
+	b.WriteString("|-----------|------|----------|---------|-------------|\n")
+	for _, spec := range doc.Parameters {
+		required := ""
+		if spec.Required {
+			required = "yes"
+		}
+		def := ""
+		if spec.Default != nil {
+			def = inlineCode(fmt.Sprint(spec.Default))
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s |\n", spec.Name, spec.Type, required, def, spec.Description)
+	}
+}
+
+func inlineCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
+
+func describeBackoff(b BackoffConfig) string {
+	if b.Strategy != BackoffFixed && b.Strategy != BackoffExponential {
+		return string(BackoffNone)
+	}
+	interval := b.Interval
+	if interval <= 0 {
+		interval = defaultBackoffInterval
+	}
+	out := fmt.Sprintf("%s %s", b.Strategy, interval)
+	if b.Strategy == BackoffExponential {
+		maxInterval := b.MaxInterval
+		if maxInterval <= 0 {
+			maxInterval = defaultBackoffMaxInterval
+		}
+		out += fmt.Sprintf(" up to %s", maxInterval)
+	}
+	if b.Jitter {
+		out += " with jitter"
+	}
+	return out
+}
+
+// describeSchedule explains expr in words and falls back to the expression
+// itself when it does not parse.
+func describeSchedule(expr string) string {
+	if expr == "" {
+		return ""
+	}
+	text, err := ExplainSchedule(expr)
+	if err != nil {
+		return expr
+	}
+	return text
+}
@@ -0,0 +1,70 @@
+package job_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeTask(t *testing.T) {
+	cfg := job.Config{
+		Schedule: "@daily",
+		Retries:  3,
+		Timeout:  2 * time.Minute,
+		Backoff:  job.BackoffConfig{Strategy: job.BackoffExponential, Interval: time.Second, MaxInterval: time.Minute},
+		Metadata: map[string]any{
+			"description": "Rebuilds the nightly revenue report",
+			"owner":       "finance-eng",
+			"tags":        []any{"reports", "finance"},
+			"params": map[string]any{
+				"days": map[string]any{"type": "int", "default": 7, "description": "Lookback window"},
+			},
+		},
+	}
+	doc := job.Describe(job.NewBaseTask("revenue.sql", "jobs/revenue.sql", "sql", cfg, "", noopEngine{}))
+
+	assert.Equal(t, "revenue.sql", doc.ID)
+	assert.Equal(t, "Rebuilds the nightly revenue report", doc.Description)
+	assert.Equal(t, "finance-eng", doc.Owner)
+	assert.Equal(t, "At 00:00 every day", doc.ScheduleText)
+	assert.Equal(t, "2m0s", doc.Timeout)
+	assert.Equal(t, "exponential 1s up to 1m0s", doc.Backoff)
+	assert.Equal(t, []string{"finance", "reports"}, doc.Tags)
+	require.Len(t, doc.Parameters, 1)
+	assert.Equal(t, "Lookback window", doc.Parameters[0].Description)
+}
+
+func TestWriteTaskCatalog(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("b.js", "jobs/b.js", "js", job.Config{Schedule: "*/5 * * * *"}, "", noopEngine{})))
+	require.NoError(t, registry.Add(job.NewBaseTask("a.js", "jobs/a.js", "js", job.Config{
+		NoTimeout: true,
+		Metadata: map[string]any{
+			"description": "Syncs accounts",
+			"params":      map[string]any{"tenant": map[string]any{"required": true}},
+		},
+	}, "", noopEngine{})))
+
+	docs := job.DescribeAll(registry)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "a.js", docs[0].ID)
+
+	var md bytes.Buffer
+	require.NoError(t, job.WriteTaskCatalog(&md, docs, job.CatalogMarkdown))
+	assert.Contains(t, md.String(), "## a.js\n\nSyncs accounts\n\n- **Path:** `jobs/a.js`\n- **Engine:** noop\n- **Schedule:** `* * * * *` (Every minute)\n- **Timeout:** none\n")
+	assert.Contains(t, md.String(), "| `tenant` | any | yes |  |  |\n")
+	assert.Contains(t, md.String(), "- **Schedule:** `*/5 * * * *` (Every 5 minutes)\n")
+
+	var out bytes.Buffer
+	require.NoError(t, job.WriteTaskCatalog(&out, docs, job.CatalogJSON))
+	var decoded []job.TaskDoc
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "b.js", decoded[1].ID)
+
+	require.Error(t, job.WriteTaskCatalog(&out, docs, "html"))
+}
@@ -0,0 +1,100 @@
+package job_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goliatone/go-job"
+)
+
+const benchScriptBody = `
+const started = Date.now();
+for (let i = 0; i < 100; i++) {
+	console.log("processing item", i);
+}
+console.log("done in", Date.now() - started);
+`
+
+func benchmarkScriptTree(n int) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for i := 0; i < n; i++ {
+		var content string
+		var path string
+		switch i % 3 {
+		case 0:
+			path = fmt.Sprintf("jobs/js/%03d/job_%d.js", i%100, i)
+			content = "// config\n// schedule: \"@every 1m\"\n// retries: 2\n// timeout: 30s\n// metadata:\n//   team: data\n" + benchScriptBody
+		case 1:
+			path = fmt.Sprintf("jobs/sh/%03d/job_%d.sh", i%100, i)
+			content = "#!/bin/sh\n# config\n# schedule: \"*/5 * * * *\"\n# retries: 1\n# env:\n#   MODE: batch\necho start\nfor i in 1 2 3; do echo $i; done\n"
+		default:
+			path = fmt.Sprintf("jobs/sql/%03d/job_%d.sql", i%100, i)
+			content = "-- config\n-- schedule: \"@daily\"\n-- transaction: true\nSELECT 1;\nUPDATE items SET processed = true WHERE processed = false;\n"
+		}
+		fsys[path] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return fsys
+}
+
+func BenchmarkDiscovery(b *testing.B) {
+	for _, n := range []int{500, 5000} {
+		fsys := benchmarkScriptTree(n)
+		b.Run(fmt.Sprintf("scripts=%d", n), func(b *testing.B) {
+			engines := []job.Engine{
+				job.NewJSRunner(),
+				job.NewShellRunner(),
+				job.NewSQLRunner(),
+			}
+			provider := job.NewFileSystemSourceProvider("", fsys)
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tasks, err := job.NewTaskCreator(provider, engines).CreateTasks(ctx)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(tasks) != n {
+					b.Fatalf("expected %d tasks, got %d", n, len(tasks))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkListScripts(b *testing.B) {
+	fsys := benchmarkScriptTree(5000)
+	provider := job.NewFileSystemSourceProvider("", fsys)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := provider.ListScripts(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkYAMLMetadataParser(b *testing.B) {
+	cases := map[string][]byte{
+		"js":    []byte("// config\n// schedule: \"@every 1m\"\n// retries: 2\n// timeout: 30s\n" + benchScriptBody),
+		"block": []byte("/** config\n * schedule: \"@hourly\"\n * retries: 2\n */\n" + benchScriptBody),
+		"yaml":  []byte("---\nschedule: \"@daily\"\nretries: 2\n---\n" + benchScriptBody),
+		"none":  []byte(benchScriptBody),
+	}
+	parser := job.NewYAMLMetadataParser()
+	for name, content := range cases {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := parser.Parse(content); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// EngineHealthChecker engines can implement this to probe their external
+// dependencies (database pool, shell binary) so misconfiguration surfaces at
+// startup and in Runner.Health instead of on the first run.
+type EngineHealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheck pings the engine's database, opening its configured DSN when
+// it has no client. Engines connecting only per script, through `dsn`
+// metadata or connection profiles, have nothing to check.
+func (e *SQLEngine) HealthCheck(ctx context.Context) error {
+	if e.db != nil {
+		return e.db.PingContext(ctx)
+	}
+	if e.driverName == "" || e.dataSourceName == "" {
+		return nil
+	}
+	db, err := e.getDBConnection(ctx, &ExecutionMessage{})
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// HealthCheck verifies the shell binary and working directory exist.
+func (e *ShellEngine) HealthCheck(context.Context) error {
+	return checkProcess("shell", e.shell, e.workDir)
+}
+
+// checkProcess verifies the command and working directory of a process
+// engine exist.
+func checkProcess(kind, command, workDir string) error {
+	if _, err := exec.LookPath(command); err != nil {
+		return fmt.Errorf("%s %s: %w", kind, command, err)
+	}
+	if workDir != "" {
+		info, err := os.Stat(workDir)
+		if err != nil {
+			return fmt.Errorf("working directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("working directory %s is not a directory", workDir)
+		}
+	}
+	return nil
+}
+
+// engineCheckPrefix namespaces engine health checks.
+const engineCheckPrefix = "engine:"
+
+// engineHealthChecks returns the health checks of the engines registered on
+// the runner's task creators, keyed by engine name under the "engine:"
+// namespace (e.g. "engine:sql"). Engines sharing a name get numbered keys.
+func (r *Runner) engineHealthChecks() map[string]HealthCheck {
+	checks := make(map[string]HealthCheck)
+	for _, creator := range r.taskCreators {
+		lister, ok := creator.(interface{ Engines() []Engine })
+		if !ok {
+			continue
+		}
+		for _, engine := range lister.Engines() {
+			if checker, ok := engine.(EngineHealthChecker); ok {
+				name := engine.Name()
+				if !strings.HasPrefix(name, engineCheckPrefix) {
+					name = engineCheckPrefix + name
+				}
+				addHealthCheck(checks, name, checker.HealthCheck)
+			}
+		}
+	}
+	return checks
+}
+
+// checkEngines runs the engine health checks, logging failures, and returns
+// the failing engines as discovery errors.
+func (r *Runner) checkEngines(ctx context.Context) []DiscoveryError {
+	checks := r.engineHealthChecks()
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failures []DiscoveryError
+	for _, name := range names {
+		if err := runHealthCheck(ctx, checks[name], r.healthTimeout); err != nil {
+			r.logger.Error("engine health check failed", "check", name, "error", err)
+			failures = append(failures, DiscoveryError{TaskID: name, Error: err.Error()})
+		}
+	}
+	return failures
+}
@@ -0,0 +1,65 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineHealthChecks(t *testing.T) {
+	assert.NoError(t, job.NewShellRunner().HealthCheck(context.Background()))
+	assert.Error(t, job.NewShellRunner(job.WithShellShell("/nonexistent/sh")).HealthCheck(context.Background()))
+	assert.Error(t, job.NewShellRunner(job.WithShellWorkingDirectory("/nonexistent/dir")).HealthCheck(context.Background()))
+
+	assert.NoError(t, job.NewSQLRunner().HealthCheck(context.Background()))
+	assert.NoError(t, job.NewSQLRunner(job.WithSQLDatabase("sqlite3", ":memory:")).HealthCheck(context.Background()))
+	assert.Error(t, job.NewSQLRunner(job.WithSQLDatabase("missing-driver", "dsn")).HealthCheck(context.Background()))
+}
+
+func TestRunnerSurfacesEngineHealth(t *testing.T) {
+	creator := job.NewTaskCreator(&staticSourceProvider{}, []job.Engine{
+		job.NewShellRunner(job.WithShellShell("/nonexistent/sh")),
+		job.NewSQLRunner(job.WithSQLDatabase("sqlite3", ":memory:")),
+		job.NewJSRunner(),
+	})
+
+	runner := job.NewRunner(job.WithTaskCreator(creator))
+	require.NoError(t, runner.Start(context.Background()))
+
+	report := runner.Health(context.Background())
+	assert.Equal(t, job.HealthStatusUnavailable, report.Status)
+	assert.Equal(t, job.HealthStatusUnavailable, report.Checks["engine:shell"].Status)
+	assert.Contains(t, report.Checks["engine:shell"].Error, "/nonexistent/sh")
+	assert.Equal(t, job.HealthStatusOK, report.Checks["engine:sql"].Status)
+	assert.NotContains(t, report.Checks, "engine:javascript")
+
+	strict := job.NewRunner(job.WithStrictStartup(), job.WithTaskCreator(creator))
+	err := strict.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 engine(s) failed health checks")
+}
+
+func TestRunnerKeepsCollidingHealthChecks(t *testing.T) {
+	creator := job.NewTaskCreator(&staticSourceProvider{}, []job.Engine{
+		job.NewShellRunner(),
+		job.NewShellRunner(job.WithShellShell("/nonexistent/sh")),
+	})
+
+	runner := job.NewRunner(
+		job.WithTaskCreator(creator),
+		job.WithHealthCheck("engine:shell", func(context.Context) error { return nil }),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	report := runner.Health(context.Background())
+	require.Contains(t, report.Checks, "engine:shell")
+	require.Contains(t, report.Checks, "engine:shell#2")
+	require.Contains(t, report.Checks, "engine:shell#3")
+	assert.Equal(t, job.HealthStatusOK, report.Checks["engine:shell"].Status)
+	assert.Equal(t, job.HealthStatusUnavailable, report.Checks["engine:shell#2"].Status)
+	assert.Equal(t, job.HealthStatusOK, report.Checks["engine:shell#3"].Status)
+}
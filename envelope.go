@@ -13,6 +13,7 @@ type Envelope struct {
 	Scope           Scope          `json:"scope,omitempty"`
 	Params          map[string]any `json:"params,omitempty"`
 	IdempotencyKey  string         `json:"idempotency_key,omitempty"`
+	CallbackURL     string         `json:"callback_url,omitempty"`
 	RawContentBytes int            `json:"-"`
 }
 
@@ -94,6 +95,16 @@ func (env Envelope) Validate() error {
 		})
 	}
 
+	if env.CallbackURL != "" {
+		if err := validateCallbackURL(env.CallbackURL); err != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{
+				Field:   "callback_url",
+				Message: err.Error(),
+				Value:   env.CallbackURL,
+			})
+		}
+	}
+
 	if env.Actor != nil && env.Actor.IsImpersonated && env.Actor.ImpersonatorID == "" {
 		fieldErrors = append(fieldErrors, errors.FieldError{
 			Field:   "actor.impersonator_id",
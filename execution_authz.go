@@ -0,0 +1,128 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ExecutionAuthzRequest describes an execution about to start.
+type ExecutionAuthzRequest struct {
+	Actor *Actor
+	Scope Scope
+	JobID string
+	// Parameters are a copy of the execution's parameters, unredacted so
+	// policies can check real values. Redact them before logging.
+	Parameters map[string]any
+	// Metadata is the resolved task config metadata.
+	Metadata map[string]any
+}
+
+// ExecutionDecision is an authorizer verdict. Reason is reported to the
+// caller and recorded in the audit log when the execution is denied.
+type ExecutionDecision struct {
+	Allowed bool
+	Reason  string
+}
+
+// AllowExecution and DenyExecution build ExecutionDecisions.
+func AllowExecution() ExecutionDecision { return ExecutionDecision{Allowed: true} }
+
+func DenyExecution(reason string) ExecutionDecision {
+	return ExecutionDecision{Reason: reason}
+}
+
+// ExecutionAuthorizer is consulted by TaskCommander before every execution,
+// so manual and webhook triggers share the same checks. Scheduled runs reach
+// it with a nil Actor. An error denies the execution.
+type ExecutionAuthorizer interface {
+	AuthorizeExecution(ctx context.Context, req ExecutionAuthzRequest) (ExecutionDecision, error)
+}
+
+// ExecutionAuthorizerFunc adapts a function to ExecutionAuthorizer.
+type ExecutionAuthorizerFunc func(ctx context.Context, req ExecutionAuthzRequest) (ExecutionDecision, error)
+
+// AuthorizeExecution implements ExecutionAuthorizer.
+func (f ExecutionAuthorizerFunc) AuthorizeExecution(ctx context.Context, req ExecutionAuthzRequest) (ExecutionDecision, error) {
+	return f(ctx, req)
+}
+
+// PolicyExecutionAuthorizer evaluates policy for AuthzActionTriggerJob, so a
+// RolePolicy honours the actor's ResourceRoles for the job. Executions without
+// an actor (the scheduler, in-process callers) are allowed.
+func PolicyExecutionAuthorizer(policy AuthzPolicy) ExecutionAuthorizer {
+	return ExecutionAuthorizerFunc(func(ctx context.Context, req ExecutionAuthzRequest) (ExecutionDecision, error) {
+		if req.Actor == nil {
+			return AllowExecution(), nil
+		}
+		err := Authorize(ctx, policy, AuthzRequest{
+			Action:   AuthzActionTriggerJob,
+			Actor:    req.Actor,
+			Scope:    req.Scope,
+			JobID:    req.JobID,
+			Metadata: req.Metadata,
+		})
+		if err != nil {
+			return DenyExecution(forbiddenReason(err)), nil
+		}
+		return AllowExecution(), nil
+	})
+}
+
+// WithExecutionAuthorizer makes the commander consult authorizer before
+// running; denied executions return an IsForbidden error.
+func (c *TaskCommander) WithExecutionAuthorizer(authorizer ExecutionAuthorizer) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.authorizer = authorizer
+	return c
+}
+
+func (c *TaskCommander) authorizeExecution(ctx context.Context, msg *ExecutionMessage) error {
+	if c.authorizer == nil {
+		return nil
+	}
+	actor, scope, _ := ActorFromContext(ctx)
+	req := ExecutionAuthzRequest{
+		Actor:      actor,
+		Scope:      scope,
+		JobID:      msg.JobID,
+		Parameters: cloneParams(msg.Parameters),
+		Metadata:   msg.Config.Metadata,
+	}
+
+	decision, err := c.authorizer.AuthorizeExecution(ctx, req)
+	if err == nil && decision.Allowed {
+		return nil
+	}
+	reason := decision.Reason
+	if err != nil {
+		reason = err.Error()
+	}
+	if reason == "" {
+		reason = "execution denied"
+	}
+
+	recordAudit(ctx, c.audit, AuditEntry{
+		Action: AuditActionJobDenied,
+		JobID:  msg.JobID,
+		Metadata: map[string]any{
+			"reason":     reason,
+			"parameters": redactForLog(msg.Parameters),
+		},
+	})
+	return forbidden(AuthzRequest{Action: AuthzActionTriggerJob, Actor: actor, JobID: msg.JobID}, reason)
+}
+
+// forbiddenReason extracts the reason recorded by forbidden, if any.
+func forbiddenReason(err error) string {
+	var target *errors.Error
+	if stderrors.As(err, &target) {
+		if reason, ok := target.Metadata["reason"].(string); ok && reason != "" {
+			return reason
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,206 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultHistorySize = 1000
+
+// RunRecord is the history entry of one run.
+type RunRecord struct {
+	RunID      string `json:"run_id"`
+	JobID      string `json:"job_id"`
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// Status is the final Result status, e.g. "succeeded" or "failed".
+	Status    string        `json:"status"`
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   time.Time     `json:"ended_at"`
+	Duration  time.Duration `json:"duration"`
+	Attempts  int           `json:"attempts"`
+	Error     string        `json:"error,omitempty"`
+	// Actor is the ID, or failing that the subject, of the actor that
+	// triggered the run.
+	Actor          string `json:"actor,omitempty"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// Environment fingerprints the host and engine that executed the run;
+	// nil when the run never started.
+	Environment *RunEnvironment `json:"environment,omitempty"`
+	// AttemptLog records each attempt's timing, error and backoff.
+	AttemptLog []AttemptRecord `json:"attempt_log,omitempty"`
+}
+
+// HistoryFilter selects run records; zero fields match every record.
+type HistoryFilter struct {
+	RunID  string
+	JobID  string
+	Status string
+	Actor  string
+	// Since and Until bound StartedAt, inclusive.
+	Since time.Time
+	Until time.Time
+	// Limit caps the records returned, newest first; zero returns all.
+	Limit int
+}
+
+// Matches reports whether record passes every filter field.
+func (f HistoryFilter) Matches(record RunRecord) bool {
+	switch {
+	case f.RunID != "" && record.RunID != f.RunID:
+		return false
+	case f.JobID != "" && record.JobID != f.JobID:
+		return false
+	case f.Status != "" && record.Status != f.Status:
+		return false
+	case f.Actor != "" && record.Actor != f.Actor:
+		return false
+	case !f.Since.IsZero() && record.StartedAt.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && record.StartedAt.After(f.Until):
+		return false
+	}
+	return true
+}
+
+// ExecutionHistory stores a record of every run so operators can query past
+// runs, e.g. the recent failures of a task. MemoryHistory and SQLHistory
+// implement it.
+type ExecutionHistory interface {
+	Record(ctx context.Context, record RunRecord) error
+	// List returns the records matching filter, newest first.
+	List(ctx context.Context, filter HistoryFilter) ([]RunRecord, error)
+}
+
+var _ ExecutionHistory = &MemoryHistory{}
+
+// MemoryHistory keeps the most recent run records in memory.
+type MemoryHistory struct {
+	mu      sync.RWMutex
+	records []RunRecord
+	max     int
+}
+
+// NewMemoryHistory retains up to maxRecords runs, dropping the oldest first;
+// non-positive values fall back to 1000.
+func NewMemoryHistory(maxRecords int) *MemoryHistory {
+	if maxRecords <= 0 {
+		maxRecords = defaultHistorySize
+	}
+	return &MemoryHistory{max: maxRecords}
+}
+
+// Record stores record.
+func (h *MemoryHistory) Record(_ context.Context, record RunRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record)
+	if over := len(h.records) - h.max; over > 0 {
+		h.records = append(h.records[:0:0], h.records[over:]...)
+	}
+	return nil
+}
+
+// List returns the records matching filter, newest first.
+func (h *MemoryHistory) List(_ context.Context, filter HistoryFilter) ([]RunRecord, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var out []RunRecord
+	for i := len(h.records) - 1; i >= 0; i-- {
+		if !filter.Matches(h.records[i]) {
+			continue
+		}
+		out = append(out, h.records[i])
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// runRecordFor builds the history record of a finished run.
+func runRecordFor(ctx context.Context, msg *ExecutionMessage, report ExecutionReport, err error, now time.Time) RunRecord {
+	record := RunRecord{
+		RunID:       report.RunID,
+		JobID:       report.JobID,
+		ScheduleID:  report.ScheduleID,
+		Status:      report.Result.Status,
+		StartedAt:   report.StartedAt,
+		Duration:    report.Duration,
+		Attempts:    report.Attempts,
+		Environment: report.Environment,
+		AttemptLog:  append([]AttemptRecord(nil), report.AttemptLog...),
+	}
+	if record.StartedAt.IsZero() {
+		record.StartedAt = now.Add(-report.Duration)
+	}
+	record.EndedAt = record.StartedAt.Add(report.Duration)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if msg != nil {
+		if record.JobID == "" {
+			record.JobID = msg.JobID
+		}
+		record.IdempotencyKey = msg.IdempotencyKey
+	}
+	if actor, _, ok := ActorFromContext(ctx); ok && actor != nil {
+		record.Actor = actor.ID
+		if record.Actor == "" {
+			record.Actor = actor.Subject
+		}
+	}
+	return record
+}
+
+// WithExecutionHistory records every run in history. Recording failures
+// never fail the run; they are logged (see WithLogger).
+func (c *TaskCommander) WithExecutionHistory(history ExecutionHistory) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.history = history
+	return c
+}
+
+func (c *TaskCommander) recordHistory(ctx context.Context, msg *ExecutionMessage, report ExecutionReport, err error) {
+	if c.history == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	record := runRecordFor(ctx, msg, report, err, c.now())
+	if recordErr := c.history.Record(context.WithoutCancel(ctx), record); recordErr != nil {
+		c.log().Error("run history record failed", "job_id", record.JobID, "run_id", record.RunID, "error", recordErr)
+	}
+}
+
+// WithExecutionHistory records every scheduled run in history.
+func (m *CronManager) WithExecutionHistory(history ExecutionHistory) *CronManager {
+	m.runHistory = history
+	return m
+}
+
+// WithExecutionHistory sets the history behind Runner.History and shares it
+// with the commanders the runner builds and its attached CronManager.
+func WithExecutionHistory(history ExecutionHistory) Option {
+	return func(r *Runner) {
+		r.history = history
+	}
+}
+
+// History returns the runner's execution history, nil unless configured
+// with WithExecutionHistory:
+//
+//	failures, err := runner.History().List(ctx, job.HistoryFilter{
+//		JobID:  "export",
+//		Status: job.ResultStatusFailed,
+//		Limit:  20,
+//	})
+func (r *Runner) History() ExecutionHistory {
+	if r == nil {
+		return nil
+	}
+	return r.history
+}
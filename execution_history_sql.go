@@ -0,0 +1,241 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultHistoryTable = "job_run_history"
+
+var _ ExecutionHistory = &SQLHistory{}
+
+// historyAddedColumns are columns added after the table was first released.
+// Migrate adds them to tables created by earlier versions.
+var historyAddedColumns = []struct{ name, definition string }{
+	{"environment", "TEXT NOT NULL DEFAULT ''"},
+	{"attempt_log", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// SQLHistory stores run records in a SQL table, by default job_run_history.
+// Call Migrate to create the table. Queries use Postgres placeholders unless
+// WithPlaceholder says otherwise.
+type SQLHistory struct {
+	db          *sql.DB
+	table       string
+	placeholder func(int) string
+}
+
+// NewSQLHistory builds a history backed by table; empty uses job_run_history.
+func NewSQLHistory(db *sql.DB, table string) *SQLHistory {
+	if table == "" {
+		table = defaultHistoryTable
+	}
+	return &SQLHistory{db: db, table: table, placeholder: defaultPostgresPlaceholder}
+}
+
+// WithPlaceholder overrides the SQL placeholder generator, e.g.
+// SQLQuestionPlaceholder for SQLite or MySQL.
+func (h *SQLHistory) WithPlaceholder(fn func(int) string) *SQLHistory {
+	if fn == nil {
+		fn = defaultPostgresPlaceholder
+	}
+	h.placeholder = fn
+	return h
+}
+
+// Migrate creates the history table and its job index when missing, and
+// adds columns introduced since the table was created.
+func (h *SQLHistory) Migrate(ctx context.Context) error {
+	table, err := h.safeTable()
+	if err != nil {
+		return err
+	}
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	run_id VARCHAR(64) NOT NULL,
+	job_id VARCHAR(255) NOT NULL,
+	schedule_id VARCHAR(255) NOT NULL DEFAULT '',
+	status VARCHAR(32) NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP NOT NULL,
+	duration_ns BIGINT NOT NULL,
+	attempts INTEGER NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	actor VARCHAR(255) NOT NULL DEFAULT '',
+	idempotency_key VARCHAR(255) NOT NULL DEFAULT '',
+	environment TEXT NOT NULL DEFAULT '',
+	attempt_log TEXT NOT NULL DEFAULT ''
+)`, table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_job_started_idx ON %s (job_id, started_at)",
+			strings.ReplaceAll(table, ".", "_"), table),
+	}
+	for _, stmt := range statements {
+		if _, err := h.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to migrate run history: %w", err)
+		}
+	}
+	for _, column := range historyAddedColumns {
+		if err := h.addColumnIfMissing(ctx, table, column.name, column.definition); err != nil {
+			return fmt.Errorf("failed to migrate run history: %w", err)
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing probes for column with a query that reads no rows, so
+// it works on any dialect, and adds the column when the probe fails.
+func (h *SQLHistory) addColumnIfMissing(ctx context.Context, table, column, definition string) error {
+	rows, err := h.db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", column, table))
+	if err == nil {
+		return rows.Close()
+	}
+	_, err = h.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// Record inserts record.
+func (h *SQLHistory) Record(ctx context.Context, record RunRecord) error {
+	table, err := h.safeTable()
+	if err != nil {
+		return err
+	}
+	environment, err := encodeRunEnvironment(record.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to record run %s: %w", record.RunID, err)
+	}
+	attemptLog, err := encodeAttemptLog(record.AttemptLog)
+	if err != nil {
+		return fmt.Errorf("failed to record run %s: %w", record.RunID, err)
+	}
+	placeholders := make([]string, 13)
+	for i := range placeholders {
+		placeholders[i] = h.placeholder(i + 1)
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (run_id, job_id, schedule_id, status, started_at, ended_at,
+	duration_ns, attempts, error, actor, idempotency_key, environment, attempt_log) VALUES (%s)`, table, strings.Join(placeholders, ", "))
+	_, err = h.db.ExecContext(ctx, query,
+		record.RunID, record.JobID, record.ScheduleID, record.Status,
+		record.StartedAt.UTC(), record.EndedAt.UTC(), int64(record.Duration), record.Attempts,
+		record.Error, record.Actor, record.IdempotencyKey, environment, attemptLog,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record run %s: %w", record.RunID, err)
+	}
+	return nil
+}
+
+// List returns the records matching filter, newest first.
+func (h *SQLHistory) List(ctx context.Context, filter HistoryFilter) ([]RunRecord, error) {
+	table, err := h.safeTable()
+	if err != nil {
+		return nil, err
+	}
+
+	var where []string
+	var args []any
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(clause, h.placeholder(len(args))))
+	}
+	if filter.RunID != "" {
+		add("run_id = %s", filter.RunID)
+	}
+	if filter.JobID != "" {
+		add("job_id = %s", filter.JobID)
+	}
+	if filter.Status != "" {
+		add("status = %s", filter.Status)
+	}
+	if filter.Actor != "" {
+		add("actor = %s", filter.Actor)
+	}
+	if !filter.Since.IsZero() {
+		add("started_at >= %s", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		add("started_at <= %s", filter.Until.UTC())
+	}
+
+	query := fmt.Sprintf(`SELECT run_id, job_id, schedule_id, status, started_at, ended_at,
+	duration_ns, attempts, error, actor, idempotency_key, environment, attempt_log FROM %s`, table)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY started_at DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := h.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query run history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []RunRecord
+	for rows.Next() {
+		var record RunRecord
+		var duration int64
+		var environment, attemptLog string
+		if err := rows.Scan(&record.RunID, &record.JobID, &record.ScheduleID, &record.Status,
+			&record.StartedAt, &record.EndedAt, &duration, &record.Attempts,
+			&record.Error, &record.Actor, &record.IdempotencyKey, &environment, &attemptLog); err != nil {
+			return nil, fmt.Errorf("failed to scan run history: %w", err)
+		}
+		record.Duration = time.Duration(duration)
+		if environment != "" {
+			record.Environment = &RunEnvironment{}
+			if err := json.Unmarshal([]byte(environment), record.Environment); err != nil {
+				return nil, fmt.Errorf("failed to decode environment of run %s: %w", record.RunID, err)
+			}
+		}
+		if attemptLog != "" {
+			if err := json.Unmarshal([]byte(attemptLog), &record.AttemptLog); err != nil {
+				return nil, fmt.Errorf("failed to decode attempt log of run %s: %w", record.RunID, err)
+			}
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating run history: %w", err)
+	}
+	return records, nil
+}
+
+// encodeRunEnvironment stores env as JSON, or an empty string when nil.
+func encodeRunEnvironment(env *RunEnvironment) (string, error) {
+	if env == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// encodeAttemptLog stores log as JSON, or an empty string when empty.
+func encodeAttemptLog(log []AttemptRecord) (string, error) {
+	if len(log) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(log)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (h *SQLHistory) safeTable() (string, error) {
+	if h == nil || h.db == nil {
+		return "", fmt.Errorf("run history database not configured")
+	}
+	if !sqlIdentifierPattern.MatchString(h.table) {
+		return "", fmt.Errorf("invalid table name %q", h.table)
+	}
+	return h.table, nil
+}
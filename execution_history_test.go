@@ -0,0 +1,228 @@
+package job_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyFixtures(base time.Time) []job.RunRecord {
+	return []job.RunRecord{
+		{RunID: "r1", JobID: "export", Status: "succeeded", StartedAt: base, EndedAt: base.Add(time.Second), Duration: time.Second, Attempts: 1},
+		{RunID: "r2", JobID: "export", Status: job.ResultStatusFailed, StartedAt: base.Add(time.Hour), EndedAt: base.Add(time.Hour + time.Second), Duration: time.Second, Attempts: 3, Error: "boom", Actor: "ops"},
+		{RunID: "r3", JobID: "cleanup", Status: job.ResultStatusFailed, StartedAt: base.Add(2 * time.Hour), EndedAt: base.Add(2 * time.Hour), Attempts: 1, Error: "denied"},
+		{RunID: "r4", JobID: "export", Status: job.ResultStatusFailed, StartedAt: base.Add(3 * time.Hour), EndedAt: base.Add(3*time.Hour + 2*time.Second), Duration: 2 * time.Second, Attempts: 1, Error: "timeout", IdempotencyKey: "export-42",
+			Environment: &job.RunEnvironment{Host: "worker-2", OS: "linux", Arch: "amd64", GoVersion: "go1.23.4", Engine: "engine:sql",
+				EngineVersions: map[string]string{"driver": "postgres"}}},
+	}
+}
+
+func assertHistoryQueries(t *testing.T, history job.ExecutionHistory, base time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	for _, record := range historyFixtures(base) {
+		require.NoError(t, history.Record(ctx, record))
+	}
+
+	runIDs := func(filter job.HistoryFilter) []string {
+		records, err := history.List(ctx, filter)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.RunID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"r4", "r3", "r2", "r1"}, runIDs(job.HistoryFilter{}))
+	assert.Equal(t, []string{"r4", "r2"}, runIDs(job.HistoryFilter{JobID: "export", Status: job.ResultStatusFailed}))
+	assert.Equal(t, []string{"r4"}, runIDs(job.HistoryFilter{JobID: "export", Status: job.ResultStatusFailed, Limit: 1}))
+	assert.Equal(t, []string{"r3", "r2"}, runIDs(job.HistoryFilter{Since: base.Add(time.Hour), Until: base.Add(2 * time.Hour)}))
+	assert.Equal(t, []string{"r2"}, runIDs(job.HistoryFilter{Actor: "ops"}))
+
+	records, err := history.List(ctx, job.HistoryFilter{JobID: "export", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	got := records[0]
+	assert.True(t, got.StartedAt.Equal(base.Add(3*time.Hour)))
+	assert.True(t, got.EndedAt.Equal(base.Add(3*time.Hour+2*time.Second)))
+	got.StartedAt, got.EndedAt = time.Time{}, time.Time{}
+	assert.Equal(t, job.RunRecord{
+		RunID: "r4", JobID: "export", Status: job.ResultStatusFailed, Duration: 2 * time.Second,
+		Attempts: 1, Error: "timeout", IdempotencyKey: "export-42",
+		Environment: &job.RunEnvironment{Host: "worker-2", OS: "linux", Arch: "amd64", GoVersion: "go1.23.4", Engine: "engine:sql",
+			EngineVersions: map[string]string{"driver": "postgres"}},
+	}, got)
+}
+
+func TestMemoryHistoryList(t *testing.T) {
+	assertHistoryQueries(t, job.NewMemoryHistory(0), time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC))
+}
+
+func TestMemoryHistoryDropsOldestRecords(t *testing.T) {
+	history := job.NewMemoryHistory(2)
+	for _, id := range []string{"r1", "r2", "r3"} {
+		require.NoError(t, history.Record(context.Background(), job.RunRecord{RunID: id, JobID: "export"}))
+	}
+	records, err := history.List(context.Background(), job.HistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "r3", records[0].RunID)
+	assert.Equal(t, "r2", records[1].RunID)
+}
+
+func TestSQLHistoryList(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	history := job.NewSQLHistory(db, "").WithPlaceholder(job.SQLQuestionPlaceholder)
+	require.NoError(t, history.Migrate(context.Background()))
+	require.NoError(t, history.Migrate(context.Background()), "migrations are idempotent")
+
+	assertHistoryQueries(t, history, time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC))
+
+	_, err = job.NewSQLHistory(db, "runs; DROP TABLE x").List(context.Background(), job.HistoryFilter{})
+	assert.ErrorContains(t, err, "invalid table name")
+}
+
+func TestSQLHistoryMigratesLegacyTable(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE job_run_history (
+	run_id TEXT PRIMARY KEY,
+	job_id TEXT NOT NULL,
+	schedule_id TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP NOT NULL,
+	duration_ns BIGINT NOT NULL DEFAULT 0,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	error TEXT NOT NULL DEFAULT '',
+	actor TEXT NOT NULL DEFAULT '',
+	idempotency_key TEXT NOT NULL DEFAULT ''
+)`)
+	require.NoError(t, err)
+
+	history := job.NewSQLHistory(db, "").WithPlaceholder(job.SQLQuestionPlaceholder)
+	require.NoError(t, history.Migrate(context.Background()))
+	require.NoError(t, history.Migrate(context.Background()), "migrations are idempotent")
+
+	started := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	log := []job.AttemptRecord{
+		{Attempt: 1, StartedAt: started, Duration: time.Second, Error: "timeout", Backoff: 2 * time.Second},
+		{Attempt: 2, StartedAt: started.Add(3 * time.Second), Duration: time.Second},
+	}
+	require.NoError(t, history.Record(context.Background(), job.RunRecord{
+		RunID: "r1", JobID: "export", Status: "succeeded", StartedAt: started, EndedAt: started.Add(4 * time.Second),
+		Attempts: 2, AttemptLog: log, Environment: &job.RunEnvironment{Engine: "engine:shell"},
+	}))
+	require.NoError(t, history.Record(context.Background(), job.RunRecord{
+		RunID: "r2", JobID: "export", Status: "succeeded", StartedAt: started.Add(time.Minute), EndedAt: started.Add(time.Minute),
+	}))
+
+	records, err := history.List(context.Background(), job.HistoryFilter{JobID: "export"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Empty(t, records[0].AttemptLog)
+	require.Len(t, records[1].AttemptLog, 2)
+	assert.Equal(t, "timeout", records[1].AttemptLog[0].Error)
+	assert.Equal(t, 2*time.Second, records[1].AttemptLog[0].Backoff)
+	assert.True(t, started.Equal(records[1].AttemptLog[0].StartedAt))
+	require.NotNil(t, records[1].Environment)
+	assert.Equal(t, "engine:shell", records[1].Environment.Engine)
+}
+
+type failingHistory struct{ job.ExecutionHistory }
+
+func (failingHistory) Record(context.Context, job.RunRecord) error {
+	return errors.New("history unavailable")
+}
+
+type capturingLogger struct{ errors []string }
+
+func (l *capturingLogger) Trace(string, ...any)                   {}
+func (l *capturingLogger) Debug(string, ...any)                   {}
+func (l *capturingLogger) Info(string, ...any)                    {}
+func (l *capturingLogger) Warn(string, ...any)                    {}
+func (l *capturingLogger) Error(msg string, _ ...any)             { l.errors = append(l.errors, msg) }
+func (l *capturingLogger) Fatal(string, ...any)                   {}
+func (l *capturingLogger) WithContext(context.Context) job.Logger { return l }
+
+func TestTaskCommanderLogsHistoryFailures(t *testing.T) {
+	logger := &capturingLogger{}
+	task := job.NewBaseTask("export", "export.sh", "shell", job.Config{}, "", job.NewShellRunner())
+	cmd := job.NewTaskCommander(task).WithExecutionHistory(failingHistory{}).WithLogger(logger)
+
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "export",
+		Parameters: map[string]any{"script": "echo ok"},
+	}), "history failures do not fail the run")
+	assert.Equal(t, []string{"run history record failed"}, logger.errors)
+}
+
+func TestTaskCommanderRecordsHistory(t *testing.T) {
+	history := job.NewMemoryHistory(10)
+	task := job.NewBaseTask("export", "export.sh", "shell", job.Config{}, "", job.NewShellRunner())
+	cmd := job.NewTaskCommander(task).WithExecutionHistory(history)
+
+	ctx := job.ContextWithActor(context.Background(), &job.Actor{ID: "ops-1"}, job.Scope{})
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{
+		JobID:          "export",
+		IdempotencyKey: "export-42",
+		Parameters:     map[string]any{"script": "echo ok"},
+	}))
+	require.Error(t, cmd.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "export",
+		Parameters: map[string]any{"script": "exit 3"},
+	}))
+
+	records, err := history.List(context.Background(), job.HistoryFilter{JobID: "export"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	failed, ok := records[0], records[1]
+	assert.Equal(t, job.ResultStatusFailed, failed.Status)
+	assert.NotEmpty(t, failed.Error)
+	assert.Empty(t, failed.Actor)
+
+	assert.Equal(t, "succeeded", ok.Status)
+	assert.NotEmpty(t, ok.RunID)
+	assert.Equal(t, 1, ok.Attempts)
+	assert.Equal(t, "ops-1", ok.Actor)
+	assert.Equal(t, "export-42", ok.IdempotencyKey)
+	require.NotNil(t, ok.Environment)
+	assert.Equal(t, "engine:shell", ok.Environment.Engine)
+	assert.False(t, ok.StartedAt.IsZero())
+	assert.Equal(t, ok.StartedAt.Add(ok.Duration), ok.EndedAt)
+	assert.Empty(t, ok.Error)
+	require.Len(t, ok.AttemptLog, 1)
+	assert.Equal(t, 1, ok.AttemptLog[0].Attempt)
+}
+
+func TestRunnerHistory(t *testing.T) {
+	assert.Nil(t, job.NewRunner().History())
+
+	history := job.NewMemoryHistory(10)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("nightly-report", "nightly-report.sh", "shell", job.Config{}, "", job.NewShellRunner())))
+	runner := job.NewRunner(job.WithRegistry(registry), job.WithExecutionHistory(history))
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := runner.Backfill(context.Background(), "nightly-report", from, from.Add(2*time.Hour), time.Hour,
+		map[string]any{"script": "exit 1"})
+	require.Error(t, err)
+
+	failures, err := runner.History().List(context.Background(), job.HistoryFilter{JobID: "nightly-report", Status: job.ResultStatusFailed})
+	require.NoError(t, err)
+	assert.Len(t, failures, 2)
+}
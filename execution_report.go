@@ -0,0 +1,151 @@
+package job
+
+import "time"
+
+// ExecutionReport summarises one TaskCommander run so callers can record the
+// outcome without re-deriving it from the error.
+type ExecutionReport struct {
+	RunID   string `json:"run_id,omitempty"`
+	JobID   string `json:"job_id"`
+	TraceID string `json:"trace_id,omitempty"`
+	// ScheduleID is set by CronManager for scheduled runs.
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// Attempts counts the tries made; zero when the run never started, e.g.
+	// the task was disabled or the message deduplicated.
+	Attempts  int           `json:"attempts"`
+	StartedAt time.Time     `json:"started_at,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	// Backoff lists the delays waited between attempts, in order.
+	Backoff []time.Duration `json:"backoff,omitempty"`
+	// AttemptLog records each attempt's timing, error and the backoff
+	// waited after it.
+	AttemptLog []AttemptRecord `json:"attempt_log,omitempty"`
+	// Environment fingerprints the host and engine that executed the run.
+	Environment *RunEnvironment `json:"environment,omitempty"`
+	// Result is the final outcome; Metadata carries run_id and attempts.
+	Result Result `json:"result"`
+}
+
+// AttemptRecord describes one attempt of a run.
+type AttemptRecord struct {
+	Attempt   int           `json:"attempt"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+	// Backoff is the delay waited before the next attempt; zero for the last.
+	Backoff time.Duration `json:"backoff,omitempty"`
+}
+
+func (r *ExecutionReport) recordAttempt(attempt int, started, ended time.Time, err error) {
+	record := AttemptRecord{Attempt: attempt, StartedAt: started, Duration: ended.Sub(started)}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	r.AttemptLog = append(r.AttemptLog, record)
+}
+
+// Retried reports whether the run took more than one attempt.
+func (r ExecutionReport) Retried() bool {
+	return r.Attempts > 1
+}
+
+// TotalBackoff is the time spent waiting between attempts.
+func (r ExecutionReport) TotalBackoff() time.Duration {
+	var total time.Duration
+	for _, d := range r.Backoff {
+		total += d
+	}
+	return total
+}
+
+// Succeeded reports whether the run completed without error.
+func (r ExecutionReport) Succeeded() bool {
+	return r.Result.Status == ResultStatusSucceeded
+}
+
+// ExecutionReportHandler receives the report and error of a completed run.
+type ExecutionReportHandler func(report ExecutionReport, err error)
+
+// finish derives the final Result from the run error, keeping a status
+// already set for runs that never started.
+func (r *ExecutionReport) finish(err error) {
+	if r.Result.Status == "" {
+		r.Result.Status = ResultStatusSucceeded
+		if err != nil {
+			r.Result.Status = ResultStatusFailed
+		}
+	}
+	if err != nil && r.Result.Message == "" {
+		r.Result.Message = err.Error()
+	}
+	r.Result.Duration = r.Duration
+	if r.RunID == "" {
+		return
+	}
+	if r.Result.Metadata == nil {
+		r.Result.Metadata = make(map[string]any, 2)
+	}
+	r.Result.Metadata["run_id"] = r.RunID
+	r.Result.Metadata["attempts"] = r.Attempts
+}
+
+// RetryStats summarises whether retries help across a set of runs: runs that
+// recovered after failing at least once versus runs that failed anyway, and
+// the extra attempts and backoff they cost.
+type RetryStats struct {
+	Runs int `json:"runs"`
+	// Retried counts runs that took more than one attempt.
+	Retried int `json:"retried"`
+	// Recovered counts retried runs that eventually succeeded.
+	Recovered int `json:"recovered"`
+	// Exhausted counts retried runs that still failed.
+	Exhausted int `json:"exhausted"`
+	// ExtraAttempts counts attempts beyond the first, i.e. the load retries
+	// added.
+	ExtraAttempts int           `json:"extra_attempts"`
+	TotalBackoff  time.Duration `json:"total_backoff"`
+	// Errors counts attempt errors by message.
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+// RecoveryRate is the share of retried runs that recovered, or zero when no
+// run was retried.
+func (s RetryStats) RecoveryRate() float64 {
+	if s.Retried == 0 {
+		return 0
+	}
+	return float64(s.Recovered) / float64(s.Retried)
+}
+
+// RetryStatsFor aggregates the retry behaviour of reports, e.g. those
+// returned by Runner.RecentRuns.
+func RetryStatsFor(reports []ExecutionReport) RetryStats {
+	var stats RetryStats
+	for _, report := range reports {
+		if report.Attempts == 0 {
+			continue
+		}
+		stats.Runs++
+		for _, attempt := range report.AttemptLog {
+			if attempt.Error == "" {
+				continue
+			}
+			if stats.Errors == nil {
+				stats.Errors = make(map[string]int)
+			}
+			stats.Errors[attempt.Error]++
+		}
+		if !report.Retried() {
+			continue
+		}
+		stats.Retried++
+		stats.ExtraAttempts += report.Attempts - 1
+		stats.TotalBackoff += report.TotalBackoff()
+		if report.Succeeded() {
+			stats.Recovered++
+		} else {
+			stats.Exhausted++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,107 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+)
+
+// ExitOnErrorEvent describes a job taken out of rotation because a run failed
+// with Config.ExitOnError set.
+type ExitOnErrorEvent struct {
+	JobID  string
+	RunID  string
+	Reason string
+	Err    error
+}
+
+// ExitOnErrorHandler is invoked after a failing exit_on_error job has been
+// disabled; use it for fatal escalation such as paging or shutting down.
+type ExitOnErrorHandler func(ctx context.Context, event ExitOnErrorEvent)
+
+// WithExitOnErrorHandler sets the callback invoked when ExitOnError stops a job.
+func (c *TaskCommander) WithExitOnErrorHandler(handler ExitOnErrorHandler) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.onExit = handler
+	return c
+}
+
+// exitOnError enforces Config.ExitOnError once retries are exhausted: the job
+// is disabled through the TaskToggler (so manual and scheduled runs stop), a
+// run.disabled event explains why, and the handler is notified. Runs that
+// fail because they were cancelled, e.g. on shutdown, leave the job enabled.
+func (c *TaskCommander) exitOnError(ctx context.Context, msg *ExecutionMessage, runID string, execErr error) {
+	if execErr == nil || !msg.Config.ExitOnError {
+		return
+	}
+	if ctx.Err() != nil || stderrors.Is(execErr, context.Canceled) {
+		return
+	}
+	reason := fmt.Sprintf("disabled by exit_on_error after failure: %v", execErr)
+
+	if c.toggles != nil {
+		_ = c.toggles.SetEnabled(msg.JobID, false)
+	}
+	c.recordResult(msg.JobID, Result{
+		Status:   ResultStatusDisabled,
+		Message:  reason,
+		Metadata: map[string]any{"exit_on_error": true, "run_id": runID},
+	})
+	recordAudit(ctx, c.audit, AuditEntry{
+		Action:   AuditActionJobDisabled,
+		JobID:    msg.JobID,
+		Metadata: map[string]any{"reason": reason, "run_id": runID},
+	})
+	c.events.Publish(RunEvent{Type: RunEventDisabled, RunID: runID, JobID: msg.JobID, Error: reason})
+
+	if c.onExit != nil {
+		c.onExit(ctx, ExitOnErrorEvent{JobID: msg.JobID, RunID: runID, Reason: reason, Err: execErr})
+	}
+}
+
+// WithExitOnErrorHandler sets the callback invoked after ExitOnError stops a
+// scheduled job. The job's schedules are unsubscribed before it runs.
+func (m *CronManager) WithExitOnErrorHandler(handler ExitOnErrorHandler) *CronManager {
+	m.onExit = handler
+	return m
+}
+
+// exitOnErrorHandler unsubscribes every schedule of the failing job, then
+// forwards to the configured handler.
+func (m *CronManager) exitOnErrorHandler() ExitOnErrorHandler {
+	return func(ctx context.Context, event ExitOnErrorEvent) {
+		m.unsubscribeJob(ctx, event.JobID, event.Reason)
+		if m.onExit != nil {
+			m.onExit(ctx, event)
+		}
+	}
+}
+
+func (m *CronManager) unsubscribeJob(ctx context.Context, jobID, reason string) {
+	m.mu.Lock()
+	removed := make([]*scheduledEntry, 0, 1)
+	for id, entry := range m.schedules {
+		if entry.definition.Message.JobID == jobID {
+			removed = append(removed, entry)
+			delete(m.schedules, id)
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(removed, func(i, j int) bool { return removed[i].definition.ID < removed[j].definition.ID })
+	for _, entry := range removed {
+		if entry.subscription != nil {
+			entry.subscription.Unsubscribe()
+		}
+		recordAudit(ctx, m.audit, AuditEntry{
+			Action:     AuditActionScheduleDeleted,
+			ScheduleID: entry.definition.ID,
+			JobID:      jobID,
+			Before:     auditScheduleSnapshot(entry.definition),
+			Metadata:   map[string]any{"reason": reason},
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFailTask struct {
+	*stubTask
+	calls int
+}
+
+func (t *countingFailTask) Execute(context.Context, *ExecutionMessage) error {
+	t.calls++
+	return errors.New("upstream unavailable")
+}
+
+func TestCronManagerExitOnError(t *testing.T) {
+	reg := NewMemoryRegistry()
+	task := &countingFailTask{stubTask: newStubTask("sync", Config{ExitOnError: true, Retries: 1})}
+	require.NoError(t, reg.Add(task))
+
+	scheduler := newStubScheduler()
+	var fatal []ExitOnErrorEvent
+	manager := NewCronManager(reg, scheduler).WithExitOnErrorHandler(func(_ context.Context, event ExitOnErrorEvent) {
+		fatal = append(fatal, event)
+	})
+	for _, id := range []string{"hourly", "nightly"} {
+		require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+			ID:         id,
+			Expression: "@hourly",
+			Message:    ExecutionMessage{JobID: "sync"},
+		}))
+	}
+	require.Equal(t, 2, scheduler.count())
+
+	for _, run := range scheduler.jobs {
+		assert.ErrorContains(t, run(), "upstream unavailable")
+		break
+	}
+
+	assert.Equal(t, 2, task.calls, "retries run before the job is stopped")
+	assert.Equal(t, 0, scheduler.count())
+	assert.Empty(t, manager.List())
+	assert.False(t, reg.IsEnabled("sync"))
+
+	result, ok := reg.GetResult("sync")
+	require.True(t, ok)
+	assert.Equal(t, ResultStatusDisabled, result.Status)
+	assert.Contains(t, result.Message, "exit_on_error")
+
+	require.Len(t, fatal, 1)
+	assert.Equal(t, "sync", fatal[0].JobID)
+	assert.EqualError(t, fatal[0].Err, "upstream unavailable")
+}
+
+func TestTaskCommanderExitOnErrorEvent(t *testing.T) {
+	broker := NewRunEventBroker(0, 0)
+	task := &countingFailTask{stubTask: newStubTask("sync", Config{ExitOnError: true})}
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(task))
+
+	cmd := NewTaskCommander(task).WithTaskToggler(reg).WithRunEvents(broker)
+	ctx := ContextWithRunID(context.Background(), "run-1")
+	require.Error(t, cmd.Execute(ctx, &ExecutionMessage{JobID: "sync"}))
+
+	events, cancel := broker.Subscribe("run-1")
+	defer cancel()
+	var types []RunEventType
+	for event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []RunEventType{RunEventStarted, RunEventAttempt, RunEventDisabled, RunEventFailed}, types)
+
+	assert.ErrorIs(t, cmd.Execute(context.Background(), &ExecutionMessage{JobID: "sync"}), ErrTaskDisabled)
+	assert.Equal(t, 1, task.calls)
+
+	plain := &countingFailTask{stubTask: newStubTask("plain", Config{})}
+	require.NoError(t, reg.Add(plain))
+	require.Error(t, NewTaskCommander(plain).WithTaskToggler(reg).Execute(context.Background(), &ExecutionMessage{JobID: "plain"}))
+	assert.True(t, reg.IsEnabled("plain"))
+}
+
+type cancelledTask struct {
+	*stubTask
+	cancel context.CancelFunc
+}
+
+func (t *cancelledTask) Execute(ctx context.Context, _ *ExecutionMessage) error {
+	if t.cancel != nil {
+		t.cancel()
+		return ctx.Err()
+	}
+	return context.Canceled
+}
+
+func TestTaskCommanderExitOnErrorSkipsCancelledRuns(t *testing.T) {
+	reg := NewMemoryRegistry()
+	task := &cancelledTask{stubTask: newStubTask("sync", Config{ExitOnError: true})}
+	require.NoError(t, reg.Add(task))
+	var exits int
+	cmd := NewTaskCommander(task).WithTaskToggler(reg).WithExitOnErrorHandler(func(context.Context, ExitOnErrorEvent) { exits++ })
+
+	require.ErrorIs(t, cmd.Execute(context.Background(), &ExecutionMessage{JobID: "sync"}), context.Canceled)
+	assert.True(t, reg.IsEnabled("sync"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task.cancel = cancel
+	require.Error(t, cmd.Execute(ctx, &ExecutionMessage{JobID: "sync"}))
+	assert.True(t, reg.IsEnabled("sync"))
+	assert.Zero(t, exits)
+}
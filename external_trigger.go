@@ -0,0 +1,451 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// TriggerMapping routes an inbound identifier, such as a queue topic or a
+// webhook path segment, to a job ID or to every job matching a selector.
+//
+// Params values are text/template strings rendered against TriggerEvent, e.g.
+// `{{.Payload.order_id}}`. A template that is a single field reference keeps
+// the value's JSON type; any other template renders to a string. Referencing
+// a missing key is an error. When Params is empty the payload is passed
+// through as the job parameters. RateLimit caps fires per RateInterval (one
+// minute when unset); zero disables the limit.
+type TriggerMapping struct {
+	Source       string            `json:"source" yaml:"source"`
+	JobID        string            `json:"job_id,omitempty" yaml:"job_id,omitempty"`
+	Selector     Selector          `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Params       map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+	RateLimit    int               `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	RateInterval time.Duration     `json:"rate_interval,omitempty" yaml:"rate_interval,omitempty"`
+}
+
+// TriggerEvent is the data available to parameter templates.
+type TriggerEvent struct {
+	Source  string
+	Payload map[string]any
+}
+
+// TriggerResult reports the outcome of one job started by a fire. Status is
+// succeeded or failed for Fire and accepted for FireAsync.
+type TriggerResult struct {
+	JobID  string `json:"job_id"`
+	RunID  string `json:"run_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ExternalTriggerOption customises an ExternalTrigger.
+type ExternalTriggerOption func(*ExternalTrigger)
+
+// WithTriggerCommander customises the TaskCommander used for each fired job.
+// Pass Runner.Commander so fired runs honour the runner's maintenance mode,
+// hooks, run cache and history; by default only task toggles are honoured.
+func WithTriggerCommander(fn func(Task) *TaskCommander) ExternalTriggerOption {
+	return func(t *ExternalTrigger) {
+		if fn != nil {
+			t.commander = fn
+		}
+	}
+}
+
+// WithTriggerClock sets the clock used for rate limit windows.
+func WithTriggerClock(clock Clock) ExternalTriggerOption {
+	return func(t *ExternalTrigger) {
+		t.clock = clock
+	}
+}
+
+// WithTriggerMaxBytes caps the request body accepted by Handler. It defaults
+// to DefaultEnvelopeMaxBytes; zero or less removes the limit.
+func WithTriggerMaxBytes(limit int) ExternalTriggerOption {
+	return func(t *ExternalTrigger) {
+		t.maxBytes = limit
+	}
+}
+
+// WithTriggerAsyncLimit bounds how many background runs FireAsync keeps in
+// flight. It defaults to DefaultAsyncLimit.
+func WithTriggerAsyncLimit(limit int) ExternalTriggerOption {
+	return func(t *ExternalTrigger) {
+		t.asyncLimit = limit
+	}
+}
+
+// WithTriggerMappings registers mappings when the trigger is built. Invalid
+// mappings are reported by Map; use it directly when errors matter.
+func WithTriggerMappings(mappings ...TriggerMapping) ExternalTriggerOption {
+	return func(t *ExternalTrigger) {
+		for _, m := range mappings {
+			_ = t.Map(m)
+		}
+	}
+}
+
+// ExternalTrigger maps inbound identifiers to jobs so queue consumers and
+// webhook endpoints are wired by configuration instead of glue code.
+type ExternalTrigger struct {
+	mu         sync.Mutex
+	registry   Registry
+	routes     map[string]*triggerRoute
+	commander  func(Task) *TaskCommander
+	clock      Clock
+	maxBytes   int
+	asyncLimit int
+	background *asyncGroup
+}
+
+type triggerRoute struct {
+	mapping     TriggerMapping
+	params      map[string]*template.Template
+	refs        map[string][]string
+	windowStart time.Time
+	fired       int
+}
+
+// NewExternalTrigger builds a trigger resolving jobs from registry.
+func NewExternalTrigger(registry Registry, opts ...ExternalTriggerOption) *ExternalTrigger {
+	t := &ExternalTrigger{
+		registry:  registry,
+		routes:    make(map[string]*triggerRoute),
+		commander: RegistryCommander(registry),
+		maxBytes:  DefaultEnvelopeMaxBytes,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+	t.background = newAsyncGroup(t.asyncLimit)
+	return t
+}
+
+// Shutdown stops accepting background runs and waits for those in flight to
+// finish or ctx to end. Later FireAsync calls fail as busy.
+func (t *ExternalTrigger) Shutdown(ctx context.Context) error {
+	if t == nil || t.background == nil {
+		return nil
+	}
+	return t.background.Close(ctx)
+}
+
+// Map adds or replaces the mapping for m.Source.
+func (t *ExternalTrigger) Map(m TriggerMapping) error {
+	m.Source = strings.TrimSpace(m.Source)
+	if m.Source == "" {
+		return fmt.Errorf("trigger mapping requires a source")
+	}
+	if (m.JobID == "") == (len(m.Selector) == 0) {
+		return fmt.Errorf("trigger mapping %q requires exactly one of job_id or selector", m.Source)
+	}
+	if m.RateLimit < 0 {
+		return fmt.Errorf("trigger mapping %q has negative rate_limit", m.Source)
+	}
+	if m.RateLimit > 0 && m.RateInterval <= 0 {
+		m.RateInterval = time.Minute
+	}
+
+	params := make(map[string]*template.Template, len(m.Params))
+	refs := make(map[string][]string)
+	for name, text := range m.Params {
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("trigger mapping %q param %q: %w", m.Source, name, err)
+		}
+		params[name] = tmpl
+		if ref, ok := templateFieldRef(tmpl); ok {
+			refs[name] = ref
+		}
+	}
+
+	t.mu.Lock()
+	t.routes[m.Source] = &triggerRoute{mapping: m, params: params, refs: refs}
+	t.mu.Unlock()
+	return nil
+}
+
+// Unmap removes the mapping for source, reporting whether it existed.
+func (t *ExternalTrigger) Unmap(source string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.routes[source]
+	delete(t.routes, source)
+	return ok
+}
+
+// Mappings returns the registered mappings ordered by source.
+func (t *ExternalTrigger) Mappings() []TriggerMapping {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]TriggerMapping, 0, len(t.routes))
+	for _, route := range t.routes {
+		out = append(out, route.mapping)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Source < out[j].Source })
+	return out
+}
+
+// Fire runs the jobs mapped to source with parameters rendered from payload.
+// Jobs run sequentially; a failing job is reported in its TriggerResult and
+// does not stop the others.
+func (t *ExternalTrigger) Fire(ctx context.Context, source string, payload map[string]any) ([]TriggerResult, error) {
+	return t.fire(ctx, source, payload, false)
+}
+
+// FireAsync renders parameters and admits the fire like Fire, then starts the
+// jobs in the background and returns their run IDs without waiting. Mapping,
+// rate limit and template errors are still returned. Jobs beyond the async
+// limit are reported as failed; when none can start FireAsync returns a busy
+// error.
+func (t *ExternalTrigger) FireAsync(ctx context.Context, source string, payload map[string]any) ([]TriggerResult, error) {
+	return t.fire(ctx, source, payload, true)
+}
+
+func (t *ExternalTrigger) fire(ctx context.Context, source string, payload map[string]any, async bool) ([]TriggerResult, error) {
+	t.mu.Lock()
+	route, ok := t.routes[source]
+	t.mu.Unlock()
+	if !ok {
+		return nil, triggerNotMapped(source, "no mapping")
+	}
+
+	event := TriggerEvent{Source: source, Payload: payload}
+	params, err := route.render(event)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := t.resolve(route.mapping)
+	if len(tasks) == 0 {
+		return nil, triggerNotMapped(source, "no jobs matched")
+	}
+
+	if err := t.admit(source, route); err != nil {
+		return nil, err
+	}
+
+	results := make([]TriggerResult, 0, len(tasks))
+	started := 0
+	for _, task := range tasks {
+		runID := NewRunID()
+		msg := &ExecutionMessage{
+			JobID:      task.GetID(),
+			Parameters: copyParams(params),
+		}
+		cmd := t.commander(task)
+		runCtx := ContextWithRunID(ctx, runID)
+		result := TriggerResult{JobID: task.GetID(), RunID: runID, Status: ResultStatusSucceeded}
+		if async {
+			bgCtx := context.WithoutCancel(runCtx)
+			if t.background.Go(func() { _ = cmd.Execute(bgCtx, msg) }) {
+				result.Status = ResultStatusAccepted
+				started++
+			} else {
+				result.Status = ResultStatusFailed
+				result.Error = "too many background runs"
+			}
+		} else if err := cmd.Execute(runCtx, msg); err != nil {
+			result.Status = ResultStatusFailed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	if async && started == 0 {
+		return results, errors.New(fmt.Sprintf("external trigger %q has too many background runs", source), errors.CategoryOperation).
+			WithCode(http.StatusServiceUnavailable).
+			WithTextCode("TRIGGER_BUSY").
+			WithMetadata(map[string]any{"source": source})
+	}
+	return results, nil
+}
+
+// admit consumes one slot of the route's rate limit. It runs once the fire
+// has rendered and resolved, so rejected requests do not use up the limit.
+func (t *ExternalTrigger) admit(source string, route *triggerRoute) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := route.mapping
+	if m.RateLimit > 0 {
+		now := clockOrSystem(t.clock).Now()
+		if route.windowStart.IsZero() || now.Sub(route.windowStart) >= m.RateInterval {
+			route.windowStart = now
+			route.fired = 0
+		}
+		if route.fired >= m.RateLimit {
+			return errors.New(fmt.Sprintf("external trigger %q rate limited", source), errors.CategoryRateLimit).
+				WithCode(errors.CodeTooManyRequests).
+				WithTextCode("TRIGGER_RATE_LIMITED").
+				WithMetadata(map[string]any{
+					"source":      source,
+					"limit":       m.RateLimit,
+					"interval":    m.RateInterval.String(),
+					"retry_after": route.windowStart.Add(m.RateInterval).Sub(now).String(),
+				})
+		}
+		route.fired++
+	}
+	return nil
+}
+
+// isTriggerBusy reports whether err is the error FireAsync returns when no
+// job could start in the background.
+func isTriggerBusy(err error) bool {
+	var target *errors.Error
+	return stderrors.As(err, &target) && target.TextCode == "TRIGGER_BUSY"
+}
+
+func triggerNotMapped(source, reason string) error {
+	return errors.New(fmt.Sprintf("external trigger %q not mapped", source), errors.CategoryNotFound).
+		WithTextCode("TRIGGER_NOT_MAPPED").
+		WithMetadata(map[string]any{"source": source, "reason": reason})
+}
+
+func (t *ExternalTrigger) resolve(m TriggerMapping) []Task {
+	if t.registry == nil {
+		return nil
+	}
+	if m.JobID != "" {
+		task, ok := t.registry.Get(m.JobID)
+		if !ok || task == nil {
+			return nil
+		}
+		return []Task{task}
+	}
+	return SelectTasks(t.registry, m.Selector)
+}
+
+func (r *triggerRoute) render(event TriggerEvent) (map[string]any, error) {
+	if len(r.params) == 0 {
+		return event.Payload, nil
+	}
+	params := make(map[string]any, len(r.params))
+	for name, tmpl := range r.params {
+		if ref, ok := r.refs[name]; ok {
+			value, err := lookupTriggerField(event, ref)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("trigger %q param %q: %v", event.Source, name, err), errors.CategoryBadInput).
+					WithTextCode("TRIGGER_PARAM_INVALID")
+			}
+			params[name] = value
+			continue
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, event); err != nil {
+			return nil, errors.New(fmt.Sprintf("trigger %q param %q: %v", event.Source, name, err), errors.CategoryBadInput).
+				WithTextCode("TRIGGER_PARAM_INVALID")
+		}
+		params[name] = buf.String()
+	}
+	return params, nil
+}
+
+// templateFieldRef returns the field chain of a template made of a single
+// reference such as `{{.Payload.order_id}}`.
+func templateFieldRef(tmpl *template.Template) ([]string, bool) {
+	if tmpl.Tree == nil || tmpl.Tree.Root == nil || len(tmpl.Tree.Root.Nodes) != 1 {
+		return nil, false
+	}
+	action, ok := tmpl.Tree.Root.Nodes[0].(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 || len(action.Pipe.Cmds[0].Args) != 1 {
+		return nil, false
+	}
+	field, ok := action.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return nil, false
+	}
+	return field.Ident, true
+}
+
+// lookupTriggerField resolves a field chain against event, keeping the type of
+// the value it finds.
+func lookupTriggerField(event TriggerEvent, path []string) (any, error) {
+	var value any = map[string]any{"Source": event.Source, "Payload": event.Payload}
+	for _, key := range path {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot read key %q of a non-object value", key)
+		}
+		if value, ok = fields[key]; !ok {
+			return nil, fmt.Errorf("map has no entry for key %q", key)
+		}
+	}
+	return value, nil
+}
+
+// Handler returns an http.Handler firing the mapping named by
+// the `source` path value (e.g. "POST /hooks/{source}") with the JSON body
+// as payload. Jobs are started with FireAsync and the handler answers
+// 202 Accepted with their run IDs, or 503 Service Unavailable when the async
+// limit leaves no room.
+func (t *ExternalTrigger) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeTriggerResponse(w, http.StatusMethodNotAllowed, nil, "method not allowed")
+			return
+		}
+
+		var payload map[string]any
+		body := r.Body
+		if t.maxBytes > 0 {
+			body = http.MaxBytesReader(w, r.Body, int64(t.maxBytes))
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			status := http.StatusBadRequest
+			var tooLarge *http.MaxBytesError
+			if stderrors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			writeTriggerResponse(w, status, nil, err.Error())
+			return
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &payload); err != nil {
+				writeTriggerResponse(w, http.StatusBadRequest, nil, err.Error())
+				return
+			}
+		}
+
+		results, err := t.FireAsync(r.Context(), r.PathValue("source"), payload)
+		switch {
+		case err == nil:
+			writeTriggerResponse(w, http.StatusAccepted, results, "")
+		case isTriggerBusy(err):
+			w.Header().Set("Retry-After", "1")
+			writeTriggerResponse(w, http.StatusServiceUnavailable, nil, err.Error())
+		case errors.IsCategory(err, errors.CategoryRateLimit):
+			writeTriggerResponse(w, http.StatusTooManyRequests, nil, err.Error())
+		case errors.IsNotFound(err):
+			writeTriggerResponse(w, http.StatusNotFound, nil, err.Error())
+		default:
+			writeTriggerResponse(w, http.StatusBadRequest, nil, err.Error())
+		}
+	})
+}
+
+func writeTriggerResponse(w http.ResponseWriter, status int, results []TriggerResult, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Results []TriggerResult `json:"results,omitempty"`
+		Error   string          `json:"error,omitempty"`
+	}{results, msg})
+}
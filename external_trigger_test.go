@@ -0,0 +1,225 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalTriggerRendersParamsForMappedJob(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	engine := &recordingEngine{}
+	require.NoError(t, registry.Add(job.NewBaseTask("orders", "jobs/orders.js", "js", job.Config{}, "", engine)))
+
+	trigger := job.NewExternalTrigger(registry)
+	require.NoError(t, trigger.Map(job.TriggerMapping{
+		Source: "sqs:orders",
+		JobID:  "orders",
+		Params: map[string]string{
+			"order": "{{.Payload.id}}",
+			"queue": "{{.Source}}",
+			"count": "{{.Payload.items.count}}",
+			"label": "order {{.Payload.id}}",
+		},
+	}))
+
+	payload := map[string]any{"id": "o-42", "items": map[string]any{"count": float64(3)}}
+	results, err := trigger.Fire(context.Background(), "sqs:orders", payload)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "orders", results[0].JobID)
+	assert.NotEmpty(t, results[0].RunID)
+	assert.Equal(t, job.ResultStatusSucceeded, results[0].Status)
+	assert.Empty(t, results[0].Error)
+
+	require.NotNil(t, engine.lastMsg)
+	assert.Equal(t, "o-42", engine.lastMsg.Parameters["order"])
+	assert.Equal(t, "sqs:orders", engine.lastMsg.Parameters["queue"])
+	assert.Equal(t, float64(3), engine.lastMsg.Parameters["count"], "single references keep their type")
+	assert.Equal(t, "order o-42", engine.lastMsg.Parameters["label"])
+	assert.Equal(t, results[0].RunID, job.RunIDFromContext(engine.lastCtx))
+
+	_, err = trigger.Fire(context.Background(), "sqs:orders", map[string]any{"id": "o-43"})
+	require.Error(t, err, "missing keys are rejected")
+	assert.True(t, errors.IsCategory(err, errors.CategoryBadInput))
+
+	require.NoError(t, trigger.Map(job.TriggerMapping{
+		Source: "sqs:notes",
+		JobID:  "orders",
+		Params: map[string]string{"note": "note: {{.Payload.note}}"},
+	}))
+	_, err = trigger.Fire(context.Background(), "sqs:notes", map[string]any{})
+	assert.ErrorContains(t, err, `no entry for key "note"`)
+}
+
+func TestExternalTriggerSelectorFansOut(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	tagged := job.Config{Metadata: map[string]any{"tags": []any{"nightly"}}}
+	require.NoError(t, registry.Add(job.NewBaseTask("b", "jobs/b.js", "js", tagged, "", noopEngine{})))
+	require.NoError(t, registry.Add(job.NewBaseTask("a", "jobs/a.js", "js", tagged, "", noopEngine{})))
+	require.NoError(t, registry.Add(job.NewBaseTask("c", "jobs/c.js", "js", job.Config{}, "", noopEngine{})))
+
+	trigger := job.NewExternalTrigger(registry, job.WithTriggerMappings(job.TriggerMapping{
+		Source:   "deploy",
+		Selector: job.Selector{"tag": "nightly"},
+	}))
+
+	results, err := trigger.Fire(context.Background(), "deploy", nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].JobID)
+	assert.Equal(t, "b", results[1].JobID)
+}
+
+func TestExternalTriggerRateLimit(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("sync", "jobs/sync.js", "js", job.Config{}, "", noopEngine{})))
+	clock := jobtest.NewFakeClock(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	trigger := job.NewExternalTrigger(registry, job.WithTriggerClock(clock))
+	require.NoError(t, trigger.Map(job.TriggerMapping{
+		Source:       "hook",
+		JobID:        "sync",
+		RateLimit:    2,
+		RateInterval: time.Minute,
+	}))
+
+	ctx := context.Background()
+	_, err := trigger.Fire(ctx, "hook", nil)
+	require.NoError(t, err)
+	_, err = trigger.Fire(ctx, "hook", nil)
+	require.NoError(t, err)
+	_, err = trigger.Fire(ctx, "hook", nil)
+	require.Error(t, err)
+	assert.True(t, errors.IsCategory(err, errors.CategoryRateLimit))
+
+	clock.Advance(time.Minute)
+	_, err = trigger.Fire(ctx, "hook", nil)
+	assert.NoError(t, err)
+}
+
+func TestExternalTriggerRateLimitSkipsRejectedFires(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("sync", "jobs/sync.js", "js", job.Config{}, "", noopEngine{})))
+
+	trigger := job.NewExternalTrigger(registry, job.WithTriggerMappings(
+		job.TriggerMapping{Source: "hook", JobID: "sync", RateLimit: 1, Params: map[string]string{"id": "{{.Payload.id}}"}},
+		job.TriggerMapping{Source: "gone", JobID: "missing", RateLimit: 1},
+	))
+
+	ctx := context.Background()
+	for range 3 {
+		_, err := trigger.Fire(ctx, "hook", map[string]any{})
+		require.True(t, errors.IsCategory(err, errors.CategoryBadInput), "%v", err)
+		_, err = trigger.Fire(ctx, "gone", nil)
+		require.True(t, errors.IsNotFound(err), "%v", err)
+	}
+
+	_, err := trigger.Fire(ctx, "hook", map[string]any{"id": "42"})
+	require.NoError(t, err, "invalid fires do not use up the limit")
+	_, err = trigger.Fire(ctx, "hook", map[string]any{"id": "43"})
+	assert.True(t, errors.IsCategory(err, errors.CategoryRateLimit))
+}
+
+type blockingEngine struct {
+	noopEngine
+	release chan struct{}
+}
+
+func (e *blockingEngine) Execute(context.Context, *job.ExecutionMessage) error {
+	<-e.release
+	return nil
+}
+
+func TestExternalTriggerBoundsAsyncFires(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	engine := &blockingEngine{release: make(chan struct{})}
+	require.NoError(t, registry.Add(job.NewBaseTask("build", "jobs/build.js", "js", job.Config{}, "", engine)))
+
+	trigger := job.NewExternalTrigger(registry,
+		job.WithTriggerAsyncLimit(1),
+		job.WithTriggerMappings(job.TriggerMapping{Source: "github", JobID: "build"}),
+	)
+
+	results, err := trigger.FireAsync(context.Background(), "github", nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, job.ResultStatusAccepted, results[0].Status)
+
+	results, err = trigger.FireAsync(context.Background(), "github", nil)
+	require.Error(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, job.ResultStatusFailed, results[0].Status)
+	assert.Equal(t, "too many background runs", results[0].Error)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.SetPathValue("source", "github")
+	rec := httptest.NewRecorder()
+	trigger.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, trigger.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(engine.release)
+	require.NoError(t, trigger.Shutdown(context.Background()))
+	_, err = trigger.FireAsync(context.Background(), "github", nil)
+	assert.Error(t, err, "no background runs start after shutdown")
+}
+
+func TestExternalTriggerMapValidation(t *testing.T) {
+	trigger := job.NewExternalTrigger(job.NewMemoryRegistry())
+
+	assert.Error(t, trigger.Map(job.TriggerMapping{JobID: "x"}))
+	assert.Error(t, trigger.Map(job.TriggerMapping{Source: "s"}))
+	assert.Error(t, trigger.Map(job.TriggerMapping{Source: "s", JobID: "x", Selector: job.Selector{"tag": "y"}}))
+	assert.Error(t, trigger.Map(job.TriggerMapping{Source: "s", JobID: "x", Params: map[string]string{"p": "{{"}}))
+
+	_, err := trigger.Fire(context.Background(), "unknown", nil)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestExternalTriggerHandler(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	engine := &countingEngine{}
+	require.NoError(t, registry.Add(job.NewBaseTask("build", "jobs/build.js", "js", job.Config{}, "", engine)))
+
+	trigger := job.NewExternalTrigger(registry, job.WithTriggerMappings(job.TriggerMapping{
+		Source: "github",
+		JobID:  "build",
+		Params: map[string]string{"ref": "{{.Payload.ref}}"},
+	}))
+	mux := http.NewServeMux()
+	mux.Handle("POST /hooks/{source}", trigger.Handler())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hooks/github", strings.NewReader(`{"ref":"main"}`)))
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"job_id":"build"`)
+	assert.Contains(t, rec.Body.String(), `"status":"accepted"`)
+	require.Eventually(t, func() bool { return engine.runs.Load() == 1 }, time.Second, time.Millisecond)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hooks/gitlab", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hooks/github", strings.NewReader(`{"sha":"abc"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "missing template keys are rejected")
+
+	small := job.NewExternalTrigger(registry, job.WithTriggerMaxBytes(8), job.WithTriggerMappings(job.TriggerMapping{Source: "github", JobID: "build"}))
+	rec = httptest.NewRecorder()
+	small.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ref":"main-branch"}`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
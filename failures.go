@@ -0,0 +1,251 @@
+package job
+
+import (
+	stderrors "errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	defaultFailureFingerprints = 50
+	defaultFailureWindow       = 20
+	failureMessageLimit        = 120
+)
+
+var (
+	fingerprintUUID   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	fingerprintHex    = regexp.MustCompile(`(?i)\b(0x)?[0-9a-f]{8,}\b`)
+	fingerprintQuoted = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	fingerprintNumber = regexp.MustCompile(`\d+`)
+)
+
+// FailureFingerprint groups errors that differ only in volatile details. It
+// combines the go-errors text code with the message truncated to 120 chars,
+// with UUIDs, hex IDs, quoted values and numbers replaced by placeholders.
+func FailureFingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	code, message := "", err.Error()
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		// skip the "[category:code]" prefix and metadata counts of Error()
+		code, message = typed.TextCode, typed.Message
+		if typed.Source != nil {
+			message += ": " + typed.Source.Error()
+		}
+	}
+
+	message = strings.ToLower(strings.TrimSpace(message))
+	message = fingerprintUUID.ReplaceAllString(message, "<uuid>")
+	message = fingerprintQuoted.ReplaceAllString(message, "<str>")
+	message = fingerprintHex.ReplaceAllString(message, "<hex>")
+	message = fingerprintNumber.ReplaceAllString(message, "<n>")
+	message = strings.Join(strings.Fields(message), " ")
+	if len(message) > failureMessageLimit {
+		message = message[:failureMessageLimit]
+	}
+	if code == "" {
+		return message
+	}
+	return code + ": " + message
+}
+
+// FailureGroup counts failures of a job sharing a fingerprint.
+type FailureGroup struct {
+	Fingerprint string    `json:"fingerprint"`
+	TextCode    string    `json:"text_code,omitempty"`
+	Sample      string    `json:"sample"`
+	Count       int       `json:"count"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	LastRunID   string    `json:"last_run_id,omitempty"`
+}
+
+// FailureReport summarizes a job's failures. Flaky is set when the recent
+// outcome window flips between success and failure more than once.
+type FailureReport struct {
+	JobID     string         `json:"job_id"`
+	Successes int            `json:"successes"`
+	Failures  int            `json:"failures"`
+	Groups    []FailureGroup `json:"groups"`
+	Flaky     bool           `json:"flaky"`
+}
+
+type jobFailures struct {
+	successes int
+	failures  int
+	groups    map[string]*FailureGroup
+	outcomes  []bool
+}
+
+// FailureStore groups run failures by fingerprint per job, so "same error 40
+// times" and "40 different errors" are told apart at a glance.
+type FailureStore struct {
+	mu              sync.Mutex
+	maxFingerprints int
+	window          int
+	jobs            map[string]*jobFailures
+}
+
+// NewFailureStore keeps up to maxFingerprints groups per job, evicting the
+// least recently seen. Non-positive values fall back to package defaults.
+func NewFailureStore(maxFingerprints int) *FailureStore {
+	if maxFingerprints <= 0 {
+		maxFingerprints = defaultFailureFingerprints
+	}
+	return &FailureStore{
+		maxFingerprints: maxFingerprints,
+		window:          defaultFailureWindow,
+		jobs:            make(map[string]*jobFailures),
+	}
+}
+
+// Record stores the outcome of a run; a nil err counts as a success.
+func (s *FailureStore) Record(jobID, runID string, err error) {
+	if s == nil || jobID == "" {
+		return
+	}
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[jobID]
+	if job == nil {
+		job = &jobFailures{groups: make(map[string]*FailureGroup)}
+		s.jobs[jobID] = job
+	}
+	job.outcomes = append(job.outcomes, err == nil)
+	if len(job.outcomes) > s.window {
+		job.outcomes = job.outcomes[len(job.outcomes)-s.window:]
+	}
+	if err == nil {
+		job.successes++
+		return
+	}
+	job.failures++
+
+	fingerprint := FailureFingerprint(err)
+	group := job.groups[fingerprint]
+	if group == nil {
+		if len(job.groups) >= s.maxFingerprints {
+			job.evictOldest()
+		}
+		group = &FailureGroup{Fingerprint: fingerprint, FirstSeen: now}
+		var typed *errors.Error
+		if stderrors.As(err, &typed) {
+			group.TextCode = typed.TextCode
+		}
+		job.groups[fingerprint] = group
+	}
+	group.Count++
+	group.Sample = err.Error()
+	group.LastSeen = now
+	group.LastRunID = runID
+}
+
+// Report returns the job's failure groups, most frequent first.
+func (s *FailureStore) Report(jobID string) (FailureReport, bool) {
+	if s == nil {
+		return FailureReport{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[jobID]
+	if job == nil {
+		return FailureReport{}, false
+	}
+	report := FailureReport{
+		JobID:     jobID,
+		Successes: job.successes,
+		Failures:  job.failures,
+		Groups:    make([]FailureGroup, 0, len(job.groups)),
+		Flaky:     outcomeFlips(job.outcomes) > 1,
+	}
+	for _, group := range job.groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Count != report.Groups[j].Count {
+			return report.Groups[i].Count > report.Groups[j].Count
+		}
+		return report.Groups[i].Fingerprint < report.Groups[j].Fingerprint
+	})
+	return report, true
+}
+
+// Reports returns a report for every job seen, ordered by job ID.
+func (s *FailureStore) Reports() []FailureReport {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.jobs))
+	for id := range s.jobs {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	sort.Strings(ids)
+	reports := make([]FailureReport, 0, len(ids))
+	for _, id := range ids {
+		if report, ok := s.Report(id); ok {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+// Reset forgets the job's history, e.g. after a fix is deployed.
+func (s *FailureStore) Reset(jobID string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.jobs, jobID)
+	s.mu.Unlock()
+}
+
+func (j *jobFailures) evictOldest() {
+	var oldest *FailureGroup
+	for _, group := range j.groups {
+		if oldest == nil || group.LastSeen.Before(oldest.LastSeen) {
+			oldest = group
+		}
+	}
+	if oldest != nil {
+		delete(j.groups, oldest.Fingerprint)
+	}
+}
+
+func outcomeFlips(outcomes []bool) int {
+	flips := 0
+	for i := 1; i < len(outcomes); i++ {
+		if outcomes[i] != outcomes[i-1] {
+			flips++
+		}
+	}
+	return flips
+}
+
+// WithFailureStore records run outcomes for failure fingerprinting.
+func (c *TaskCommander) WithFailureStore(store *FailureStore) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.failures = store
+	return c
+}
+
+// WithFailureStore records scheduled run outcomes for failure fingerprinting.
+func (m *CronManager) WithFailureStore(store *FailureStore) *CronManager {
+	m.failures = store
+	return m
+}
@@ -0,0 +1,71 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	goerrors "github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureFingerprint(t *testing.T) {
+	a := goerrors.New(`row 42 of "orders" failed for 3f2b8c1e-7d4a-4f0e-9a2b-1c3d5e7f9a0b`, goerrors.CategoryExternal).WithTextCode("SQL_EXECUTION_ERROR")
+	b := goerrors.New(`row 7 of "users" failed for 0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d`, goerrors.CategoryExternal).WithTextCode("SQL_EXECUTION_ERROR")
+
+	assert.Equal(t, job.FailureFingerprint(a), job.FailureFingerprint(b))
+	assert.Equal(t, "SQL_EXECUTION_ERROR: row <n> of <str> failed for <uuid>", job.FailureFingerprint(a))
+	assert.NotEqual(t, job.FailureFingerprint(a), job.FailureFingerprint(errors.New("connection refused")))
+	assert.Empty(t, job.FailureFingerprint(nil))
+}
+
+func TestFailureStoreGroupsAndFlakes(t *testing.T) {
+	store := job.NewFailureStore(2)
+	for i := 0; i < 40; i++ {
+		store.Record("import", fmt.Sprintf("run-%d", i), fmt.Errorf("timeout after %dms", 1000+i))
+	}
+	store.Record("import", "run-x", errors.New("connection refused"))
+
+	report, ok := store.Report("import")
+	require.True(t, ok)
+	assert.Equal(t, 41, report.Failures)
+	require.Len(t, report.Groups, 2)
+	assert.Equal(t, 40, report.Groups[0].Count)
+	assert.Equal(t, "timeout after <n>ms", report.Groups[0].Fingerprint)
+	assert.Equal(t, "run-39", report.Groups[0].LastRunID)
+	assert.False(t, report.Flaky)
+
+	store.Record("import", "run-y", errors.New("disk full"))
+	report, _ = store.Report("import")
+	assert.Len(t, report.Groups, 2, "least recently seen group is evicted")
+
+	for i, err := range []error{nil, assert.AnError, nil, assert.AnError} {
+		store.Record("sync", fmt.Sprint(i), err)
+	}
+	report, _ = store.Report("sync")
+	assert.True(t, report.Flaky)
+	assert.Equal(t, 2, report.Successes)
+
+	assert.Len(t, store.Reports(), 2)
+	store.Reset("sync")
+	_, ok = store.Report("sync")
+	assert.False(t, ok)
+}
+
+func TestTaskCommanderRecordsFailures(t *testing.T) {
+	store := job.NewFailureStore(0)
+	task := &flakyRetryTask{}
+	cmd := job.NewTaskCommander(task).WithFailureStore(store)
+
+	msg := &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"}
+	assert.Error(t, cmd.Execute(context.Background(), msg))
+	require.NoError(t, cmd.Execute(context.Background(), msg))
+
+	report, ok := store.Report("retry-task")
+	require.True(t, ok)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Successes)
+}
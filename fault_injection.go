@@ -0,0 +1,161 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// FaultKind selects what a FaultRule injects.
+type FaultKind string
+
+const (
+	FaultError FaultKind = "error"
+	FaultDelay FaultKind = "delay"
+	FaultPanic FaultKind = "panic"
+)
+
+// ErrInjectedFault is returned by FaultError rules without a custom error.
+var ErrInjectedFault = errors.New("injected fault", errors.CategoryExternal).
+	WithTextCode("JOB_FAULT_INJECTED")
+
+// FaultRule injects a fault into matching executions with the given probability.
+type FaultRule struct {
+	// JobID matches task IDs with path.Match wildcards; empty matches all.
+	JobID string
+	// Engine matches the task's engine name ("engine:sql"); empty matches all.
+	Engine      string
+	Kind        FaultKind
+	Probability float64
+	// Delay is the pause added by FaultDelay rules.
+	Delay time.Duration
+	// Err replaces ErrInjectedFault for FaultError rules.
+	Err error
+}
+
+func (r FaultRule) matches(task Task, jobID string) bool {
+	if r.JobID != "" {
+		if ok, _ := path.Match(r.JobID, jobID); !ok {
+			return false
+		}
+	}
+	if r.Engine != "" {
+		engine := task.GetEngine()
+		if engine == nil || engine.Name() != r.Engine {
+			return false
+		}
+	}
+	return true
+}
+
+// FaultInjector adds errors, delays and panics to executions so staging can
+// exercise retries, alerting and dead-lettering. Rules are evaluated in order
+// before every attempt: delays accumulate, the first error or panic wins.
+type FaultInjector struct {
+	mu       sync.Mutex
+	rules    []FaultRule
+	rand     *rand.Rand
+	disabled bool
+	injected map[FaultKind]int
+}
+
+// NewFaultInjector creates an enabled injector with rules.
+func NewFaultInjector(rules ...FaultRule) *FaultInjector {
+	return &FaultInjector{
+		rules:    append([]FaultRule(nil), rules...),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		injected: make(map[FaultKind]int),
+	}
+}
+
+// WithRand sets the random source, e.g. a seeded one for reproducible runs.
+func (f *FaultInjector) WithRand(r *rand.Rand) *FaultInjector {
+	if r != nil {
+		f.rand = r
+	}
+	return f
+}
+
+// SetEnabled switches injection on or off at runtime.
+func (f *FaultInjector) SetEnabled(enabled bool) {
+	f.mu.Lock()
+	f.disabled = !enabled
+	f.mu.Unlock()
+}
+
+// Injected reports how many faults of each kind were injected.
+func (f *FaultInjector) Injected() map[FaultKind]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[FaultKind]int, len(f.injected))
+	for kind, count := range f.injected {
+		out[kind] = count
+	}
+	return out
+}
+
+// Inject applies the matching rules for an attempt of task.
+func (f *FaultInjector) Inject(ctx context.Context, task Task, msg *ExecutionMessage) error {
+	if f == nil || task == nil || msg == nil {
+		return nil
+	}
+	var delay time.Duration
+	var fault *FaultRule
+
+	f.mu.Lock()
+	if !f.disabled {
+		for i := range f.rules {
+			rule := f.rules[i]
+			if !rule.matches(task, msg.JobID) || f.rand.Float64() >= rule.Probability {
+				continue
+			}
+			f.injected[rule.Kind]++
+			if rule.Kind == FaultDelay {
+				delay += rule.Delay
+				continue
+			}
+			fault = &rule
+			break
+		}
+	}
+	f.mu.Unlock()
+
+	if err := sleepWithContext(ctx, delay); err != nil {
+		return err
+	}
+	if fault == nil {
+		return nil
+	}
+	switch fault.Kind {
+	case FaultPanic:
+		panic(fmt.Sprintf("injected panic for %s", msg.JobID))
+	case FaultError:
+		if fault.Err != nil {
+			return fault.Err
+		}
+		return ErrInjectedFault
+	default:
+		return nil
+	}
+}
+
+// WithFaultInjector injects faults before every attempt. Leave unset outside
+// test and staging environments.
+func (c *TaskCommander) WithFaultInjector(injector *FaultInjector) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.faults = injector
+	return c
+}
+
+// WithFaultInjector injects faults into scheduled and bulk-triggered runs.
+func (m *CronManager) WithFaultInjector(injector *FaultInjector) *CronManager {
+	m.faults = injector
+	return m
+}
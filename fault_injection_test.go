@@ -0,0 +1,55 @@
+package job_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjectorErrorsExerciseRetries(t *testing.T) {
+	restoreSleep := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restoreSleep()
+
+	engine := &recordingEngine{}
+	task := job.NewBaseTask("import-orders", "/tmp/import.sh", "shell", job.Config{Retries: 2}, "noop", engine)
+	faults := job.NewFaultInjector(job.FaultRule{JobID: "import-*", Kind: job.FaultError, Probability: 1})
+
+	err := job.NewTaskCommander(task).WithFaultInjector(faults).Execute(context.Background(), &job.ExecutionMessage{})
+	assert.ErrorIs(t, err, job.ErrInjectedFault)
+	assert.Nil(t, engine.lastMsg, "the engine never runs")
+	assert.Equal(t, 3, faults.Injected()[job.FaultError])
+
+	faults.SetEnabled(false)
+	require.NoError(t, job.NewTaskCommander(task).WithFaultInjector(faults).Execute(context.Background(), &job.ExecutionMessage{}))
+	assert.NotNil(t, engine.lastMsg)
+}
+
+func TestFaultInjectorMatchesEngineAndProbability(t *testing.T) {
+	task := job.NewBaseTask("report", "/tmp/report.sh", "shell", job.Config{}, "noop", noopEngine{})
+	faults := job.NewFaultInjector(
+		job.FaultRule{Engine: "engine:sql", Kind: job.FaultError, Probability: 1},
+		job.FaultRule{Engine: "noop", Kind: job.FaultDelay, Delay: time.Millisecond, Probability: 1},
+		job.FaultRule{Kind: job.FaultPanic, Probability: 0.5},
+	).WithRand(rand.New(rand.NewSource(7)))
+	cmd := job.NewTaskCommander(task).WithFaultInjector(faults)
+
+	panics := 0
+	for i := 0; i < 20; i++ {
+		if err := cmd.Execute(context.Background(), &job.ExecutionMessage{}); err != nil {
+			require.True(t, job.IsPanic(err))
+			panics++
+		}
+	}
+
+	injected := faults.Injected()
+	assert.Zero(t, injected[job.FaultError], "sql rule does not match a noop task")
+	assert.Equal(t, 20, injected[job.FaultDelay])
+	assert.Equal(t, panics, injected[job.FaultPanic])
+	assert.Greater(t, panics, 0)
+	assert.Less(t, panics, 20)
+}
@@ -0,0 +1,52 @@
+package job
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// slashPath normalizes a script path to forward slashes regardless of the
+// OS it was produced on, so "jobs\reports\daily.js" and
+// "jobs/reports/daily.js" derive the same task ID.
+func slashPath(p string) string {
+	return strings.ReplaceAll(filepath.ToSlash(p), `\`, "/")
+}
+
+// matchGlob reports whether the slash separated name matches pattern.
+// Segments use path.Match semantics and a "**" segment matches any number
+// of directories, including none: "**/node_modules/**" matches
+// "node_modules/x.js" and "a/b/node_modules/c/x.js".
+func matchGlob(pattern, name string) bool {
+	pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+	name = strings.Trim(slashPath(name), "/")
+	if pattern == "" {
+		return name == ""
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,44 @@
+package job
+
+// GlobalEnvAware engines and task creators accept site-wide environment
+// variables, such as the region, cluster name or proxy, merged beneath the
+// env of every job.
+type GlobalEnvAware interface {
+	SetGlobalEnv(map[string]string)
+}
+
+// SetGlobalEnv sets env merged beneath the connection profile and job env.
+// Values may contain `${secret:NAME}` references.
+func (e *BaseEngine) SetGlobalEnv(env map[string]string) {
+	e.globalEnv = copyStringMap(env)
+}
+
+// WithGlobalEnv sets the site-wide env engines merge beneath every job's env.
+func (f *taskCreator) WithGlobalEnv(env map[string]string) *taskCreator {
+	f.SetGlobalEnv(env)
+	return f
+}
+
+// SetGlobalEnv satisfies GlobalEnvAware.
+func (f *taskCreator) SetGlobalEnv(env map[string]string) {
+	f.globalEnv = copyStringMap(env)
+}
+
+// WithGlobalEnv sets site-wide env available to every job, merged beneath
+// connection profile env and each script's `env:` metadata. Unlike
+// WithConfigDefaults, the values are applied at execution time and are not
+// copied into task config. Repeated calls merge, later keys winning.
+func WithGlobalEnv(env map[string]string) Option {
+	return func(r *Runner) {
+		r.globalEnv = mergeStringMaps(copyStringMap(r.globalEnv), copyStringMap(env))
+		r.propagateGlobalEnv()
+	}
+}
+
+func (r *Runner) propagateGlobalEnv() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(GlobalEnvAware); ok {
+			aware.SetGlobalEnv(r.globalEnv)
+		}
+	}
+}
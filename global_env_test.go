@@ -0,0 +1,59 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerGlobalEnvMergesBeneathJobEnv(t *testing.T) {
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/site.sh", Content: []byte("# config\n# env:\n#   REGION: us\n\necho \"$REGION $CLUSTER $HTTPS_PROXY\"")},
+		},
+	}
+	secrets := job.SecretsProviderFunc(func(_ context.Context, ref string) (job.Secret, error) {
+		return job.Secret{Value: "http://proxy.internal:3128"}, nil
+	})
+
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner()})),
+		job.WithSecretsProvider(secrets),
+		job.WithGlobalEnv(map[string]string{"REGION": "eu", "CLUSTER": "blue"}),
+		job.WithGlobalEnv(map[string]string{"HTTPS_PROXY": "${secret:proxy}"}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	tasks := runner.RegisteredTasks()
+	require.Len(t, tasks, 1)
+	task := tasks[0]
+	assert.Equal(t, map[string]string{"REGION": "us"}, task.GetConfig().Env, "global env stays out of task config")
+
+	store := job.NewRunLogStore(32, 4)
+	ctx := job.ContextWithRunID(context.Background(), "run-site")
+	require.NoError(t, job.NewTaskCommander(task).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{}))
+	entries, _ := store.Logs("run-site")
+	assert.Equal(t, []string{"us blue http://proxy.internal:3128"}, scriptLines(entries, "shell output"))
+}
+
+func TestGlobalEnvSitsBeneathProfileEnv(t *testing.T) {
+	engine := job.NewShellRunner()
+	engine.SetGlobalEnv(map[string]string{"DB_ROLE": "reader", "REGION": "eu"})
+	engine.SetProfileStore(job.NewMemoryProfileStore(job.ConnectionProfile{
+		Name: "billing-rw",
+		Env:  map[string]string{"DB_ROLE": "writer"},
+	}))
+	task := job.NewBaseTask("sh-task", "out.sh", "shell",
+		job.Config{Metadata: map[string]any{job.ProfileMetadataKey: "billing-rw"}}, "", engine)
+
+	store := job.NewRunLogStore(32, 4)
+	ctx := job.ContextWithRunID(context.Background(), "run-sh")
+	require.NoError(t, job.NewTaskCommander(task).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": `echo "$DB_ROLE $REGION"`},
+	}))
+	entries, _ := store.Logs("run-sh")
+	assert.Equal(t, []string{"writer eu"}, scriptLines(entries, "shell output"))
+}
@@ -0,0 +1,218 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HealthStatus summarises the overall state reported by Runner.Health.
+type HealthStatus string
+
+const (
+	HealthStatusOK          HealthStatus = "ok"
+	HealthStatusDegraded    HealthStatus = "degraded"
+	HealthStatusUnavailable HealthStatus = "unavailable"
+)
+
+// HealthCheck probes a dependency (store, database, broker) for connectivity.
+type HealthCheck func(ctx context.Context) error
+
+// HealthCheckResult captures the outcome of a single named health check.
+type HealthCheckResult struct {
+	Status   HealthStatus  `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// DiscoveryError records a task discovery or registration failure seen during Start.
+type DiscoveryError struct {
+	TaskID     string `json:"task_id,omitempty"`
+	ScriptPath string `json:"script_path,omitempty"`
+	Error      string `json:"error"`
+}
+
+// ReconcileReport captures the last reconcile outcome observed by a CronManager.
+type ReconcileReport struct {
+	Result    ReconcileResult `json:"result"`
+	Error     string          `json:"error,omitempty"`
+	StartedAt time.Time       `json:"started_at"`
+	Duration  time.Duration   `json:"duration"`
+}
+
+// HealthReport is a point-in-time snapshot of runner health suitable for
+// serialising from HTTP health or readiness endpoints.
+type HealthReport struct {
+	Status            HealthStatus                 `json:"status"`
+	CheckedAt         time.Time                    `json:"checked_at"`
+	RegisteredTasks   int                          `json:"registered_tasks"`
+	DiscoveryErrors   []DiscoveryError             `json:"discovery_errors,omitempty"`
+	SkippedScripts    map[SkipReason]int           `json:"skipped_scripts,omitempty"`
+	SchedulerAttached bool                         `json:"scheduler_attached"`
+	Checks            map[string]HealthCheckResult `json:"checks,omitempty"`
+	LastReconcile     *ReconcileReport             `json:"last_reconcile,omitempty"`
+	// RecentRuns lists the latest runs from the runner's RunCache, newest first.
+	RecentRuns []ExecutionReport `json:"recent_runs,omitempty"`
+}
+
+// DefaultHealthCheckTimeout bounds each health check unless
+// WithHealthCheckTimeout overrides it.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// healthRecentRuns caps the runs included in a HealthReport.
+const healthRecentRuns = 10
+
+// Healthy reports whether the runner can serve traffic (ok or degraded).
+func (h HealthReport) Healthy() bool {
+	return h.Status != HealthStatusUnavailable
+}
+
+// Health returns a structured snapshot of discovery state, registry size,
+// scheduler attachment, dependency and engine checks, the last reconcile
+// outcome and the most recent runs. Checks run concurrently, each bounded by
+// the health check timeout.
+func (r *Runner) Health(ctx context.Context) HealthReport {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	report := HealthReport{
+		Status:    HealthStatusOK,
+		CheckedAt: time.Now().UTC(),
+	}
+	if r == nil {
+		report.Status = HealthStatusUnavailable
+		return report
+	}
+
+	if r.registry != nil {
+		report.RegisteredTasks = len(r.registry.List())
+	}
+	report.RecentRuns = r.RecentRuns(healthRecentRuns)
+
+	r.mx.RLock()
+	report.DiscoveryErrors = append([]DiscoveryError(nil), r.discoveryErrors...)
+	if len(r.skipCounts) > 0 {
+		report.SkippedScripts = make(map[SkipReason]int, len(r.skipCounts))
+		for reason, n := range r.skipCounts {
+			report.SkippedScripts[reason] = n
+		}
+	}
+	manager := r.cronManager
+	checks := r.engineHealthChecks()
+	custom := make([]string, 0, len(r.healthChecks))
+	for name := range r.healthChecks {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+	for _, name := range custom {
+		addHealthCheck(checks, name, r.healthChecks[name])
+	}
+	r.mx.RUnlock()
+
+	report.SchedulerAttached = manager != nil && manager.scheduler != nil
+	if manager != nil {
+		if last, ok := manager.LastReconcile(); ok {
+			report.LastReconcile = &last
+		}
+	}
+
+	if len(checks) > 0 {
+		report.Checks = make(map[string]HealthCheckResult, len(checks))
+		names := make([]string, 0, len(checks))
+		for name := range checks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		results := make([]HealthCheckResult, len(names))
+		var wg sync.WaitGroup
+		for i, name := range names {
+			wg.Add(1)
+			go func(i int, check HealthCheck) {
+				defer wg.Done()
+				start := time.Now()
+				err := runHealthCheck(ctx, check, r.healthTimeout)
+				results[i] = HealthCheckResult{Status: HealthStatusOK, Duration: time.Since(start)}
+				if err != nil {
+					results[i].Status = HealthStatusUnavailable
+					results[i].Error = err.Error()
+				}
+			}(i, checks[name])
+		}
+		wg.Wait()
+		for i, name := range names {
+			if results[i].Status != HealthStatusOK {
+				report.Status = HealthStatusUnavailable
+			}
+			report.Checks[name] = results[i]
+		}
+	}
+
+	if report.Status == HealthStatusOK {
+		if len(report.DiscoveryErrors) > 0 {
+			report.Status = HealthStatusDegraded
+		}
+		if report.LastReconcile != nil && report.LastReconcile.Error != "" {
+			report.Status = HealthStatusDegraded
+		}
+	}
+
+	return report
+}
+
+// addHealthCheck stores check under name, or under name#2, name#3... when
+// the name is taken, so no check replaces another.
+func addHealthCheck(checks map[string]HealthCheck, name string, check HealthCheck) {
+	key := name
+	for n := 2; ; n++ {
+		if _, taken := checks[key]; !taken {
+			break
+		}
+		key = fmt.Sprintf("%s#%d", name, n)
+	}
+	checks[key] = check
+}
+
+// runHealthCheck runs check with a deadline of timeout, returning once the
+// deadline passes even if the check ignores its context.
+func runHealthCheck(ctx context.Context, check HealthCheck, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("health check timed out after %s", timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+func (r *Runner) recordDiscoveryEvent(event TaskEvent) {
+	if event.Type != TaskEventRegistrationFailed || event.Err == nil {
+		return
+	}
+	r.mx.Lock()
+	r.discoveryErrors = append(r.discoveryErrors, DiscoveryError{
+		TaskID:     event.TaskID,
+		ScriptPath: event.ScriptPath,
+		Error:      event.Err.Error(),
+	})
+	r.mx.Unlock()
+}
+
+func (r *Runner) resetDiscoveryErrors() {
+	r.mx.Lock()
+	r.discoveryErrors = nil
+	r.mx.Unlock()
+}
@@ -0,0 +1,94 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerHealthReportsDiscoveryAndChecks(t *testing.T) {
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/ok.js", Content: []byte("console.log('ok')")},
+			{Path: "jobs/unsupported.txt", Content: []byte("noop")},
+			{Path: "jobs/broken.js", Content: []byte("// config\n// schedule: [\n\nconsole.log('x')")},
+		},
+	}
+	creator := job.NewTaskCreator(provider, []job.Engine{job.NewJSRunner()})
+
+	storeUp := true
+	runner := job.NewRunner(
+		job.WithTaskCreator(creator),
+		job.WithHealthCheck("store", func(context.Context) error {
+			if storeUp {
+				return nil
+			}
+			return errors.New("connection refused")
+		}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	report := runner.Health(context.Background())
+	assert.Equal(t, job.HealthStatusDegraded, report.Status)
+	assert.True(t, report.Healthy())
+	assert.Equal(t, 1, report.RegisteredTasks)
+	require.Len(t, report.DiscoveryErrors, 1)
+	assert.Equal(t, "jobs/broken.js", report.DiscoveryErrors[0].ScriptPath)
+	assert.Equal(t, map[job.SkipReason]int{job.SkipReasonNoEngine: 1}, report.SkippedScripts)
+	assert.False(t, report.SchedulerAttached)
+	assert.Nil(t, report.LastReconcile)
+	assert.Equal(t, job.HealthStatusOK, report.Checks["store"].Status)
+
+	storeUp = false
+	report = runner.Health(context.Background())
+	assert.Equal(t, job.HealthStatusUnavailable, report.Status)
+	assert.False(t, report.Healthy())
+	assert.Equal(t, "connection refused", report.Checks["store"].Error)
+}
+
+func TestRunnerHealthIncludesLastReconcile(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	manager := job.NewCronManager(registry, nil)
+	runner := job.NewRunner(
+		job.WithRegistry(registry),
+		job.WithCronManager(manager),
+	)
+
+	_, err := manager.Reconcile(context.Background(), []job.ScheduleDefinition{
+		{ID: "missing", Expression: "@hourly", Message: job.ExecutionMessage{JobID: "missing"}},
+	})
+	require.Error(t, err)
+
+	report := runner.Health(context.Background())
+	assert.False(t, report.SchedulerAttached)
+	require.NotNil(t, report.LastReconcile)
+	assert.NotEmpty(t, report.LastReconcile.Error)
+	assert.Equal(t, job.HealthStatusDegraded, report.Status)
+}
+
+func TestRunnerHealthBoundsHungChecks(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	runner := job.NewRunner(
+		job.WithHealthCheckTimeout(20*time.Millisecond),
+		job.WithHealthCheck("hung", func(context.Context) error {
+			<-release
+			return nil
+		}),
+		job.WithHealthCheck("store", func(context.Context) error { return nil }),
+	)
+
+	start := time.Now()
+	report := runner.Health(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, job.HealthStatusUnavailable, report.Status)
+	assert.Equal(t, job.HealthStatusUnavailable, report.Checks["hung"].Status)
+	assert.Contains(t, report.Checks["hung"].Error, "timed out")
+	assert.Equal(t, job.HealthStatusOK, report.Checks["store"].Status)
+}
@@ -0,0 +1,306 @@
+package job
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// HeartbeatFileEnvVar exposes a touch file to shell scripts; updating its mtime counts as a heartbeat.
+	HeartbeatFileEnvVar = "JOB_HEARTBEAT_FILE"
+
+	defaultHeartbeatCheckInterval = 5 * time.Second
+)
+
+var heartbeatFilePollInterval = time.Second
+
+// HeartbeatStatus distinguishes runs that are progressing from runs that stopped reporting.
+type HeartbeatStatus string
+
+const (
+	// HeartbeatAlive means the run reported liveness within the heartbeat timeout.
+	HeartbeatAlive HeartbeatStatus = "alive"
+	// HeartbeatSlow means the run is still heartbeating but has exceeded the slow threshold.
+	HeartbeatSlow HeartbeatStatus = "slow"
+	// HeartbeatHung means the run stopped heartbeating before finishing.
+	HeartbeatHung HeartbeatStatus = "hung"
+)
+
+// HeartbeatRun is a snapshot of a tracked execution.
+type HeartbeatRun struct {
+	JobID     string          `json:"job_id"`
+	TraceID   string          `json:"trace_id,omitempty"`
+	StartedAt time.Time       `json:"started_at"`
+	LastBeat  time.Time       `json:"last_beat"`
+	Beats     int             `json:"beats"`
+	Status    HeartbeatStatus `json:"status"`
+}
+
+// HeartbeatOption customises a HeartbeatMonitor.
+type HeartbeatOption func(*HeartbeatMonitor)
+
+// WithHeartbeatCheckInterval sets how often the watchdog evaluates tracked runs.
+func WithHeartbeatCheckInterval(interval time.Duration) HeartbeatOption {
+	return func(m *HeartbeatMonitor) {
+		if interval > 0 {
+			m.interval = interval
+		}
+	}
+}
+
+// WithHeartbeatSlowAfter marks runs that keep heartbeating past the threshold as slow.
+func WithHeartbeatSlowAfter(threshold time.Duration) HeartbeatOption {
+	return func(m *HeartbeatMonitor) {
+		m.slowAfter = threshold
+	}
+}
+
+// WithHeartbeatHungHandler is invoked once when a run transitions to hung.
+func WithHeartbeatHungHandler(fn func(HeartbeatRun)) HeartbeatOption {
+	return func(m *HeartbeatMonitor) {
+		m.onHung = fn
+	}
+}
+
+// HeartbeatMonitor tracks liveness reports from running executions and flags
+// runs that stop heartbeating before they finish.
+type HeartbeatMonitor struct {
+	mu        sync.Mutex
+	runs      map[uint64]*heartbeatRun
+	nextID    uint64
+	timeout   time.Duration
+	interval  time.Duration
+	slowAfter time.Duration
+	onHung    func(HeartbeatRun)
+	now       func() time.Time
+}
+
+type heartbeatRun struct {
+	snapshot HeartbeatRun
+	flagged  bool
+}
+
+// NewHeartbeatMonitor builds a monitor that flags runs without a heartbeat for longer than timeout.
+func NewHeartbeatMonitor(timeout time.Duration, opts ...HeartbeatOption) *HeartbeatMonitor {
+	m := &HeartbeatMonitor{
+		runs:     make(map[uint64]*heartbeatRun),
+		timeout:  timeout,
+		interval: defaultHeartbeatCheckInterval,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	return m
+}
+
+// Track registers a run and returns a context carrying its heartbeat plus a done func.
+func (m *HeartbeatMonitor) Track(ctx context.Context, msg *ExecutionMessage) (context.Context, func()) {
+	if m == nil {
+		return ctx, func() {}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := m.now()
+	run := &heartbeatRun{snapshot: HeartbeatRun{StartedAt: now, LastBeat: now, Status: HeartbeatAlive}}
+	if msg != nil {
+		run.snapshot.JobID = msg.JobID
+		run.snapshot.TraceID = msg.TraceID
+	}
+
+	m.mu.Lock()
+	m.nextID++
+	id := m.nextID
+	m.runs[id] = run
+	m.mu.Unlock()
+
+	beat := func() { m.beat(id) }
+	done := func() {
+		m.mu.Lock()
+		delete(m.runs, id)
+		m.mu.Unlock()
+	}
+	return context.WithValue(ctx, heartbeatContextKey{}, beat), done
+}
+
+// Check evaluates tracked runs, invoking the hung handler for newly hung runs.
+func (m *HeartbeatMonitor) Check() []HeartbeatRun {
+	if m == nil {
+		return nil
+	}
+	now := m.now()
+
+	var hung []HeartbeatRun
+	m.mu.Lock()
+	for _, run := range m.runs {
+		run.snapshot.Status = m.statusFor(run.snapshot, now)
+		if run.snapshot.Status == HeartbeatHung && !run.flagged {
+			run.flagged = true
+			hung = append(hung, run.snapshot)
+		}
+	}
+	m.mu.Unlock()
+
+	sortHeartbeatRuns(hung)
+	if m.onHung != nil {
+		for _, run := range hung {
+			m.onHung(run)
+		}
+	}
+	return hung
+}
+
+// Runs returns a snapshot of tracked runs ordered by start time.
+func (m *HeartbeatMonitor) Runs() []HeartbeatRun {
+	if m == nil {
+		return nil
+	}
+	now := m.now()
+
+	m.mu.Lock()
+	out := make([]HeartbeatRun, 0, len(m.runs))
+	for _, run := range m.runs {
+		snapshot := run.snapshot
+		snapshot.Status = m.statusFor(snapshot, now)
+		out = append(out, snapshot)
+	}
+	m.mu.Unlock()
+
+	sortHeartbeatRuns(out)
+	return out
+}
+
+// Run executes the watchdog loop until ctx is cancelled.
+func (m *HeartbeatMonitor) Run(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
+
+func (m *HeartbeatMonitor) statusFor(run HeartbeatRun, now time.Time) HeartbeatStatus {
+	if m.timeout > 0 && now.Sub(run.LastBeat) > m.timeout {
+		return HeartbeatHung
+	}
+	if m.slowAfter > 0 && now.Sub(run.StartedAt) > m.slowAfter {
+		return HeartbeatSlow
+	}
+	return HeartbeatAlive
+}
+
+func (m *HeartbeatMonitor) beat(id uint64) {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	run, ok := m.runs[id]
+	if !ok {
+		return
+	}
+	run.snapshot.LastBeat = now
+	run.snapshot.Beats++
+	run.flagged = false
+}
+
+type heartbeatContextKey struct{}
+
+// Heartbeat reports liveness for the run carried by ctx. It is a no-op when the
+// run is not tracked by a HeartbeatMonitor.
+func Heartbeat(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if beat, ok := ctx.Value(heartbeatContextKey{}).(func()); ok && beat != nil {
+		beat()
+	}
+}
+
+func hasHeartbeat(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	_, ok := ctx.Value(heartbeatContextKey{}).(func())
+	return ok
+}
+
+// watchHeartbeatFile creates a touch file and converts mtime changes into heartbeats.
+// Returns the file path (empty when the run is not tracked) and a cleanup func.
+func watchHeartbeatFile(ctx context.Context) (string, func()) {
+	if !hasHeartbeat(ctx) {
+		return "", func() {}
+	}
+
+	file, err := os.CreateTemp("", "job-heartbeat-*")
+	if err != nil {
+		return "", func() {}
+	}
+	path := file.Name()
+	_ = file.Close()
+
+	var lastMod time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastMod = info.ModTime()
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(heartbeatFilePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil {
+					continue
+				}
+				if info.ModTime().After(lastMod) {
+					lastMod = info.ModTime()
+					Heartbeat(ctx)
+				}
+			}
+		}
+	}()
+
+	return path, func() {
+		close(stop)
+		wg.Wait()
+		_ = os.Remove(path)
+	}
+}
+
+// sortHeartbeatRuns orders runs by start time, then job and trace ID, so
+// runs started in the same instant list deterministically.
+func sortHeartbeatRuns(runs []HeartbeatRun) {
+	sort.Slice(runs, func(i, j int) bool {
+		a, b := runs[i], runs[j]
+		if !a.StartedAt.Equal(b.StartedAt) {
+			return a.StartedAt.Before(b.StartedAt)
+		}
+		if a.JobID != b.JobID {
+			return a.JobID < b.JobID
+		}
+		return a.TraceID < b.TraceID
+	})
+}
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeatMonitorDistinguishesHungFromSlow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var hung []HeartbeatRun
+	monitor := NewHeartbeatMonitor(time.Minute,
+		WithHeartbeatSlowAfter(2*time.Minute),
+		WithHeartbeatHungHandler(func(run HeartbeatRun) { hung = append(hung, run) }),
+	)
+	monitor.now = func() time.Time { return now }
+
+	slowCtx, doneSlow := monitor.Track(context.Background(), &ExecutionMessage{JobID: "slow"})
+	defer doneSlow()
+	_, doneHung := monitor.Track(context.Background(), &ExecutionMessage{JobID: "hung"})
+	defer doneHung()
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(45 * time.Second)
+		Heartbeat(slowCtx)
+		monitor.Check()
+	}
+
+	require.Len(t, hung, 1)
+	assert.Equal(t, "hung", hung[0].JobID)
+
+	statuses := map[string]HeartbeatStatus{}
+	for _, run := range monitor.Runs() {
+		statuses[run.JobID] = run.Status
+	}
+	assert.Equal(t, HeartbeatSlow, statuses["slow"])
+	assert.Equal(t, HeartbeatHung, statuses["hung"])
+
+	// handler fires once per transition
+	monitor.Check()
+	assert.Len(t, hung, 1)
+
+	doneHung()
+	assert.Len(t, monitor.Runs(), 1)
+}
+
+func TestHeartbeatBindingsForScripts(t *testing.T) {
+	restore := heartbeatFilePollInterval
+	heartbeatFilePollInterval = 10 * time.Millisecond
+	defer func() { heartbeatFilePollInterval = restore }()
+
+	monitor := NewHeartbeatMonitor(time.Minute)
+
+	ctx, done := monitor.Track(context.Background(), &ExecutionMessage{JobID: "js"})
+	err := NewJSRunner().Execute(ctx, &ExecutionMessage{
+		JobID:      "js",
+		ScriptPath: "beat.js",
+		Parameters: map[string]any{"script": "job.heartbeat(); job.heartbeat();"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, monitor.Runs()[0].Beats)
+	done()
+
+	ctx, done = monitor.Track(context.Background(), &ExecutionMessage{JobID: "sh"})
+	defer done()
+	err = NewShellRunner().Execute(ctx, &ExecutionMessage{
+		JobID:      "sh",
+		ScriptPath: "beat.sh",
+		Parameters: map[string]any{"script": `sleep 0.05; touch "$JOB_HEARTBEAT_FILE"; sleep 0.1`},
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, monitor.Runs()[0].Beats, 1)
+}
@@ -0,0 +1,80 @@
+package job
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type HTTPOption func(*HTTPEngine)
+
+// WithHTTPFS sets the filesystem jobs are read from
+func WithHTTPFS(dirfs fs.FS) HTTPOption {
+	return func(e *HTTPEngine) {
+		if dirfs != nil {
+			e.FS = dirfs
+		}
+	}
+}
+
+// WithHTTPExtension adds file extensions that this engine can handle
+func WithHTTPExtension(ext string) HTTPOption {
+	return func(e *HTTPEngine) {
+		if ext == "" {
+			return
+		}
+
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		e.FileExtensions = append(e.FileExtensions, ext)
+	}
+}
+
+// WithHTTPTimeout sets the default execution timeout
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(e *HTTPEngine) {
+		if timeout > 0 {
+			e.Timeout = timeout
+		}
+	}
+}
+
+// WithHTTPClient sets the client sending requests, e.g. one with a custom
+// transport or TLS config. The execution context bounds each request, so
+// the client needs no timeout of its own.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(e *HTTPEngine) {
+		if client != nil {
+			e.client = client
+		}
+	}
+}
+
+// WithHTTPResponseLimit caps the response body kept for logs and error
+// metadata; the default is 64KiB.
+func WithHTTPResponseLimit(limit int64) HTTPOption {
+	return func(e *HTTPEngine) {
+		if limit > 0 {
+			e.responseLimit = limit
+		}
+	}
+}
+
+// WithHTTPMetadataParser sets a custom metadata parser
+func WithHTTPMetadataParser(parser MetadataParser) HTTPOption {
+	return func(e *HTTPEngine) {
+		if parser != nil {
+			e.MetadataParser = parser
+		}
+	}
+}
+
+func WithHTTPLogger(logger Logger) HTTPOption {
+	return func(e *HTTPEngine) {
+		if logger != nil {
+			e.SetLogger(logger)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultHTTPResponseLimit caps the response body read for logging and error
+// metadata.
+const defaultHTTPResponseLimit = 64 * 1024
+
+// HTTPRequestSpec is the declarative request in the body of an HTTP job,
+// below its metadata header:
+//
+//	---
+//	schedule: "*/5 * * * *"
+//	retries: 3
+//	timeout: 10s
+//	---
+//	method: POST
+//	url: https://hooks.example.com/ping
+//	headers:
+//	  Authorization: Bearer ${secret:HOOK_TOKEN}
+//	body:
+//	  status: ok
+//	expect_status: [200, 204]
+type HTTPRequestSpec struct {
+	// Method defaults to GET, or POST when Body is set.
+	Method  string            `yaml:"method"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	// Body is sent as is when a string; other values are sent as JSON
+	// with a default Content-Type of application/json.
+	Body any `yaml:"body"`
+	// ExpectStatus lists the accepted response codes; empty accepts any 2xx.
+	ExpectStatus HTTPStatusList `yaml:"expect_status"`
+}
+
+// HTTPStatusList accepts a single status code or a list of codes in YAML.
+type HTTPStatusList []int
+
+// UnmarshalYAML decodes either `expect_status: 204` or
+// `expect_status: [200, 204]`.
+func (l *HTTPStatusList) UnmarshalYAML(unmarshal func(any) error) error {
+	var single int
+	if err := unmarshal(&single); err == nil {
+		*l = HTTPStatusList{single}
+		return nil
+	}
+	var list []int
+	if err := unmarshal(&list); err != nil {
+		return fmt.Errorf("expect_status must be a status code or a list of status codes")
+	}
+	*l = list
+	return nil
+}
+
+// Accepts reports whether status is expected.
+func (l HTTPStatusList) Accepts(status int) bool {
+	if len(l) == 0 {
+		return status >= 200 && status < 300
+	}
+	return slices.Contains(l, status)
+}
+
+// ParseHTTPRequestSpec decodes and validates the request of an HTTP job.
+func ParseHTTPRequestSpec(content string) (HTTPRequestSpec, error) {
+	var spec HTTPRequestSpec
+	if err := yaml.UnmarshalStrict([]byte(content), &spec); err != nil {
+		return HTTPRequestSpec{}, err
+	}
+	spec.Method = strings.ToUpper(strings.TrimSpace(spec.Method))
+	if spec.Method == "" {
+		spec.Method = http.MethodGet
+		if spec.Body != nil {
+			spec.Method = http.MethodPost
+		}
+	}
+	if strings.TrimSpace(spec.URL) == "" {
+		return HTTPRequestSpec{}, fmt.Errorf("url is required")
+	}
+	for _, status := range spec.ExpectStatus {
+		if status < 100 || status > 599 {
+			return HTTPRequestSpec{}, fmt.Errorf("invalid expect_status %d", status)
+		}
+	}
+	return spec, nil
+}
+
+// HTTPEngine runs `.http` and `.yaml` jobs describing a single HTTP call, so
+// webhook pings can be scheduled without writing a script. Timeouts and
+// retries come from the job Config like any other engine.
+type HTTPEngine struct {
+	*BaseEngine
+	client        *http.Client
+	responseLimit int64
+}
+
+// NewHTTPRunner handles `.http`, `.yaml` and `.yml` jobs.
+func NewHTTPRunner(opts ...HTTPOption) *HTTPEngine {
+	e := &HTTPEngine{
+		client:        &http.Client{},
+		responseLimit: defaultHTTPResponseLimit,
+	}
+	e.BaseEngine = NewBaseEngine(e, "http", ".http", ".yaml", ".yml")
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+
+	return e
+}
+
+// SetTaskIDProvider overrides the ID derivation strategy for tasks parsed by the http engine.
+func (e *HTTPEngine) SetTaskIDProvider(provider TaskIDProvider) {
+	if e.BaseEngine != nil {
+		e.BaseEngine.SetTaskIDProvider(provider)
+	}
+}
+
+// ParseJob parses the job like BaseEngine and validates its request, so a
+// malformed spec fails registration rather than the first run.
+func (e *HTTPEngine) ParseJob(path string, content []byte) (Task, error) {
+	task, err := e.BaseEngine.ParseJob(path, content)
+	if err != nil {
+		return nil, err
+	}
+	body, err := parseScriptContent(e.MetadataParser, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	if _, err := ParseHTTPRequestSpec(body); err != nil {
+		return nil, fmt.Errorf("invalid http request: %w", err)
+	}
+	return task, nil
+}
+
+// Execute sends the job's request, resolving `${secret:...}` references in
+// the URL, headers and string body. A response outside ExpectStatus fails
+// the run; the status code is reported in the run Result metadata.
+func (e *HTTPEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	content, err := e.GetScriptContent(msg)
+	if err != nil {
+		return err
+	}
+	spec, err := ParseHTTPRequestSpec(content)
+	if err != nil {
+		return errors.Wrap(err, errors.CategoryBadInput, "invalid http request").
+			WithTextCode("HTTP_REQUEST_ERROR").
+			WithMetadata(map[string]any{
+				"operation":   "parse_request",
+				"script_path": msg.ScriptPath,
+			})
+	}
+
+	execCtx, cancel := e.GetMessageExecutionContext(ctx, msg)
+	defer cancel()
+
+	logger := e.executionLogger(ctx, msg)
+
+	req, err := e.newRequest(execCtx, msg, spec)
+	if err != nil {
+		logger.Error("http request build failed", "script_path", msg.ScriptPath, "error", err)
+		return errors.Wrap(err, errors.CategoryBadInput, "failed to create request").
+			WithTextCode("HTTP_REQUEST_ERROR").
+			WithMetadata(map[string]any{
+				"operation":   "create_request",
+				"script_path": msg.ScriptPath,
+				"method":      spec.Method,
+			})
+	}
+
+	logger.Debug("http request starting", "method", spec.Method, "url", spec.URL)
+	start := time.Now()
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		duration := time.Since(start)
+		logger.Error("http request failed", "method", spec.Method, "url", spec.URL, "duration", duration, "error", err)
+		return errors.Wrap(err, errors.CategoryExternal, "request failed").
+			WithTextCode("HTTP_EXECUTION_ERROR").
+			WithMetadata(map[string]any{
+				"operation": "execute_request",
+				"method":    spec.Method,
+				"url":       spec.URL,
+				"duration":  duration,
+			})
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, e.responseLimit))
+	duration := time.Since(start)
+
+	ReportResult(ctx, Result{
+		Message: fmt.Sprintf("%s %s: %s", spec.Method, spec.URL, resp.Status),
+		Metadata: map[string]any{
+			"status_code": resp.StatusCode,
+		},
+	})
+
+	if !spec.ExpectStatus.Accepts(resp.StatusCode) {
+		logger.Error("http request returned unexpected status", "method", spec.Method, "url", spec.URL, "duration", duration, "status", resp.StatusCode, "response", summarizeOutput(string(body)))
+		return errors.New(fmt.Sprintf("unexpected response status %s", resp.Status), errors.CategoryExternal).
+			WithTextCode("HTTP_UNEXPECTED_STATUS").
+			WithMetadata(map[string]any{
+				"operation":     "execute_request",
+				"method":        spec.Method,
+				"url":           spec.URL,
+				"status_code":   resp.StatusCode,
+				"expect_status": []int(spec.ExpectStatus),
+				"response":      string(body),
+				"duration":      duration,
+			})
+	}
+
+	logger.Info("http request completed", "method", spec.Method, "url", spec.URL, "duration", duration, "status", resp.StatusCode)
+	return nil
+}
+
+func (e *HTTPEngine) newRequest(ctx context.Context, msg *ExecutionMessage, spec HTTPRequestSpec) (*http.Request, error) {
+	url, err := ResolveSecretRefs(ctx, e.secrets, spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch value := spec.Body.(type) {
+	case nil:
+	case string:
+		resolved, err := ResolveSecretRefs(ctx, e.secrets, value)
+		if err != nil {
+			return nil, err
+		}
+		body = strings.NewReader(resolved)
+	default:
+		data, err := json.Marshal(jsonCompatible(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, spec.Method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range spec.Headers {
+		resolved, err := ResolveSecretRefs(ctx, e.secrets, value)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", key, err)
+		}
+		req.Header.Set(key, resolved)
+	}
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if msg.TraceID != "" && req.Header.Get(DefaultTraceIDHeader) == "" {
+		req.Header.Set(DefaultTraceIDHeader, msg.TraceID)
+	}
+	return req, nil
+}
+
+// jsonCompatible converts the map[any]any values decoded by yaml.v2 into
+// map[string]any so they can be encoded as JSON.
+func jsonCompatible(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			out[fmt.Sprint(key)] = jsonCompatible(val)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			out[key] = jsonCompatible(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = jsonCompatible(val)
+		}
+		return out
+	default:
+		return value
+	}
+}
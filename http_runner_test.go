@@ -0,0 +1,111 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRunnerSendsRequestWithRetries(t *testing.T) {
+	var calls atomic.Int32
+	var got struct {
+		method, auth, contentType, body string
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		got.method, got.auth, got.contentType, got.body = r.Method, r.Header.Get("Authorization"), r.Header.Get("Content-Type"), string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	engine := job.NewHTTPRunner()
+	engine.SetSecretsProvider(job.SecretsProviderFunc(func(_ context.Context, ref string) (job.Secret, error) {
+		return job.Secret{Value: "token-" + ref}, nil
+	}))
+	assert.Equal(t, "engine:http", engine.Name())
+	assert.True(t, engine.CanHandle("jobs/ping.http"))
+	assert.True(t, engine.CanHandle("jobs/ping.yaml"))
+	assert.False(t, engine.CanHandle("jobs/ping.sh"))
+
+	task, err := engine.ParseJob("jobs/ping.http", []byte(`---
+schedule: "@every 5m"
+retries: 2
+backoff:
+  strategy: none
+---
+url: `+server.URL+`/ping
+headers:
+  Authorization: Bearer ${secret:hook}
+body:
+  status: ok
+  checks: [db, cache]
+expect_status: 204
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "@every 5m", task.GetConfig().Schedule)
+
+	report, err := job.NewTaskCommander(task).ExecuteWithReport(context.Background(), &job.ExecutionMessage{JobID: task.GetID()})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Attempts)
+	assert.Equal(t, 204, report.Result.Metadata["status_code"])
+
+	assert.Equal(t, http.MethodPost, got.method)
+	assert.Equal(t, "Bearer token-hook", got.auth)
+	assert.Equal(t, "application/json", got.contentType)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got.body), &body))
+	assert.Equal(t, map[string]any{"status": "ok", "checks": []any{"db", "cache"}}, body)
+}
+
+func TestHTTPRunnerFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		http.Error(w, "not here", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	engine := job.NewHTTPRunner()
+	execute := func(spec string, config job.Config) error {
+		return engine.Execute(context.Background(), &job.ExecutionMessage{
+			JobID:      "ping",
+			Config:     config,
+			Parameters: map[string]any{"script": spec},
+		})
+	}
+
+	err := execute("url: "+server.URL+"/missing", job.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	assert.NoError(t, execute("url: "+server.URL+"/missing\nexpect_status: [404]", job.Config{}))
+
+	err = execute("url: "+server.URL+"/slow", job.Config{Timeout: 50 * time.Millisecond})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = engine.ParseJob("jobs/bad.yaml", []byte("method: GET\n"))
+	assert.ErrorContains(t, err, "url is required")
+	_, err = engine.ParseJob("jobs/bad.yaml", []byte("url: http://example.com\nexpect_status: [999]\n"))
+	assert.ErrorContains(t, err, "invalid expect_status 999")
+	_, err = engine.ParseJob("jobs/bad.yaml", []byte("url: http://example.com\nmethd: POST\n"))
+	assert.ErrorContains(t, err, "methd")
+}
@@ -1,6 +1,8 @@
 package job
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/goliatone/go-errors"
@@ -26,10 +28,13 @@ const (
 	dedupProceed dedupDecision = iota
 	dedupDrop
 	dedupMerge
+	// dedupMergeSucceeded merges into an earlier run that already succeeded.
+	dedupMergeSucceeded
 )
 
 type dedupEntry struct {
 	lastErr error
+	done    bool
 }
 
 // IdempotencyTracker tracks idempotency keys to enforce deduplication policies.
@@ -62,6 +67,9 @@ func (t *IdempotencyTracker) BeforeExecute(key string, policy DeduplicationPolic
 	case DedupPolicyDrop:
 		return dedupDrop, entry.lastErr
 	case DedupPolicyMerge:
+		if entry.done && entry.lastErr == nil {
+			return dedupMergeSucceeded, nil
+		}
 		return dedupMerge, entry.lastErr
 	case DedupPolicyReplace:
 		t.entries[key] = &dedupEntry{}
@@ -86,6 +94,21 @@ func (t *IdempotencyTracker) AfterExecute(key string, policy DeduplicationPolicy
 	}
 
 	entry.lastErr = execErr
+	entry.done = true
+}
+
+// dedupPolicies lists the accepted policies in the order error messages show.
+var dedupPolicies = []DeduplicationPolicy{DedupPolicyIgnore, DedupPolicyDrop, DedupPolicyMerge, DedupPolicyReplace}
+
+// parseDedupPolicy normalizes the case of a declared policy and rejects
+// values outside dedupPolicies.
+func parseDedupPolicy(raw any) (DeduplicationPolicy, error) {
+	value, ok := raw.(string)
+	policy := DeduplicationPolicy(strings.ToLower(strings.TrimSpace(value)))
+	if !ok || policy == "" || !isValidDedupPolicy(policy) {
+		return "", fmt.Errorf("invalid dedup_policy %v, expected one of %s", formatEnumValue(raw), joinEnum(dedupPolicies))
+	}
+	return policy, nil
 }
 
 func isValidDedupPolicy(policy DeduplicationPolicy) bool {
@@ -57,6 +57,10 @@ func TestIdempotencyMergeReusesResult(t *testing.T) {
 	err := cmd.Execute(context.Background(), msg)
 	require.NoError(t, err, "merge should return prior result (nil)")
 	assert.Equal(t, 1, task.count, "merge should not re-execute task")
+
+	report, err := cmd.ExecuteWithReport(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, job.ResultStatusSucceeded, report.Result.Status, "merging into a succeeded run reports its status")
 }
 
 func TestIdempotencyMergePropagatesPreviousError(t *testing.T) {
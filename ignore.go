@@ -0,0 +1,122 @@
+package job
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// JobIgnoreFile is the ignore file FileSystemSourceProvider.WithIgnoreFile
+// reads from the provider root by default.
+const JobIgnoreFile = ".jobignore"
+
+// IgnoreRules is an ordered set of gitignore style patterns:
+//
+//   - blank lines and lines starting with "#" are skipped
+//   - a leading "!" re-includes paths excluded by an earlier rule
+//   - a trailing "/" only matches directories
+//   - a pattern with a leading or inner "/" is anchored to the root,
+//     otherwise it matches at any depth
+//   - "**" matches any number of directories
+//
+// The last matching rule wins. As with git, a file cannot be re-included when
+// one of its parent directories is excluded.
+type IgnoreRules struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// NewIgnoreRules parses each pattern as a line of an ignore file.
+func NewIgnoreRules(patterns ...string) *IgnoreRules {
+	r := &IgnoreRules{}
+	r.Add(patterns...)
+	return r
+}
+
+// ParseIgnoreRules reads rules from an ignore file.
+func ParseIgnoreRules(reader io.Reader) (*IgnoreRules, error) {
+	r := &IgnoreRules{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		r.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Add appends patterns after the existing rules.
+func (r *IgnoreRules) Add(patterns ...string) *IgnoreRules {
+	for _, line := range patterns {
+		if rule, ok := parseIgnoreRule(line); ok {
+			r.rules = append(r.rules, rule)
+		}
+	}
+	return r
+}
+
+// Len returns the number of rules.
+func (r *IgnoreRules) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.rules)
+}
+
+// Match reports whether the slash separated relative path is ignored.
+func (r *IgnoreRules) Match(path string, isDir bool) bool {
+	if r == nil {
+		return false
+	}
+	path = strings.Trim(slashPath(path), "/")
+	ignored := false
+	for _, rule := range r.rules {
+		if rule.negate != ignored {
+			continue
+		}
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if matchGlob(rule.pattern, path) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	switch {
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	if strings.HasPrefix(line, "/") {
+		line = strings.TrimLeft(line, "/")
+	} else if !strings.Contains(line, "/") {
+		line = "**/" + line
+	}
+	rule.pattern = line
+	return rule, true
+}
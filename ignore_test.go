@@ -0,0 +1,55 @@
+package job_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	rules, err := job.ParseIgnoreRules(strings.NewReader(`
+# dependencies
+**/node_modules/**
+*.log
+!keep.log
+build/
+/drafts
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 5, rules.Len())
+
+	assert.True(t, rules.Match("a/node_modules/x/y.js", false))
+	assert.True(t, rules.Match("debug.log", false))
+	assert.True(t, rules.Match("nested/debug.log", false))
+	assert.False(t, rules.Match("nested/keep.log", false))
+	assert.True(t, rules.Match("reports/build", true))
+	assert.False(t, rules.Match("reports/build", false))
+	assert.True(t, rules.Match("drafts", true))
+	assert.False(t, rules.Match("reports/drafts", true))
+	assert.False(t, rules.Match("reports/daily.js", false))
+}
+
+func TestFileSystemSourceProviderIgnoreFile(t *testing.T) {
+	provider := job.NewFileSystemSourceProvider(".", fstest.MapFS{
+		".jobignore":          {Data: []byte("*.sh\n!keep.sh\nvendor/\n")},
+		"a.js":                {Data: []byte("console.log('a')")},
+		"b.sh":                {Data: []byte("echo b")},
+		"keep.sh":             {Data: []byte("echo keep")},
+		"vendor/lib.js":       {Data: []byte("console.log('lib')")},
+		"reports/archive.sql": {Data: []byte("SELECT 1;")},
+	}).WithIgnoreFile("").WithIgnoreRules("reports/*.sql")
+
+	scripts, err := provider.ListScripts(context.Background())
+	require.NoError(t, err)
+
+	var paths []string
+	for _, script := range scripts {
+		paths = append(paths, script.Path)
+	}
+	assert.ElementsMatch(t, []string{"a.js", "keep.sh"}, paths)
+}
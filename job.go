@@ -19,18 +19,27 @@ type ScriptInfo struct {
 	Meta    map[string]any `json:"metadata"`
 }
 
+// ScriptMetaEngine is the ScriptInfo.Meta key a SourceProvider sets to pick
+// the engine for a script by name ("sql", "engine:sql") or extension ("js")
+// instead of matching the path's extension.
+const ScriptMetaEngine = "engine"
+
 type TaskCreator interface {
 	CreateTasks(ctx context.Context) ([]Task, error)
 }
 
 // ExecutionMessage represents a request to execute a job script.
 // Required fields: JobID and ScriptPath (either provided by the caller or by the Task metadata).
-// Optional fields: Config, Parameters, IdempotencyKey, DedupPolicy, Result, and OutputCallback.
+// Optional fields: Config, Parameters, IdempotencyKey, DedupPolicy, TraceID, Result, and OutputCallback.
 type ExecutionMessage struct {
 	// JobID identifies the task to run. Filled from Task.GetID() when using TaskCommander/CompleteExecutionMessage.
 	JobID string `json:"job_id" yaml:"job_id"`
 	// ScriptPath is the filesystem path to the script. Filled from Task.GetPath() when using TaskCommander/CompleteExecutionMessage.
 	ScriptPath string `json:"script_path" yaml:"script_path"`
+	// ScriptChecksum identifies the script version the message was built for
+	// (see ScriptChecksum). Engines serve the body from their script cache when
+	// it matches, instead of reading ScriptPath from the source provider.
+	ScriptChecksum string `json:"script_checksum,omitempty" yaml:"script_checksum,omitempty"`
 	// Canonical FSM correlation fields used by durable orchestrator execution.
 	MachineID       string `json:"machine_id,omitempty" yaml:"machine_id,omitempty"`
 	EntityID        string `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
@@ -42,12 +51,24 @@ type ExecutionMessage struct {
 	// Parameters carries runtime inputs. Defaults to an empty map to avoid nil dereferences when normalized.
 	Parameters     map[string]any `json:"parameters" yaml:"parameters"`
 	IdempotencyKey string         `json:"idempotency_key" yaml:"idempotency_key"`
+	// CallbackURL receives the run's Result once it completes; see
+	// TaskCommander.WithResultCallbacks.
+	CallbackURL string `json:"callback_url,omitempty" yaml:"callback_url,omitempty"`
 	// DedupPolicy determines how idempotency keys are handled. Defaults to ignore when left empty.
-	DedupPolicy    DeduplicationPolicy         `json:"dedup_policy" yaml:"dedup_policy"`
+	DedupPolicy DeduplicationPolicy `json:"dedup_policy" yaml:"dedup_policy"`
+	// TraceID correlates the run across logs and scripts. Derived from the context when empty.
+	TraceID string `json:"trace_id,omitempty" yaml:"trace_id,omitempty"`
+	// Attempt is the 1-based attempt number, set by TaskCommander before each
+	// try so engines and scripts can branch on retry state.
+	Attempt        int                         `json:"attempt,omitempty" yaml:"attempt,omitempty"`
 	Result         *Result                     `json:"result,omitempty" yaml:"result,omitempty"`
 	OutputCallback func(stdout, stderr string) `json:"-" yaml:"-"`
 }
 
+// AttemptEnvVar is the environment variable (shell) and global (JS) exposing
+// ExecutionMessage.Attempt to scripts.
+const AttemptEnvVar = "JOB_ATTEMPT"
+
 // Type returns the message type for the command system
 func (msg ExecutionMessage) Type() string {
 	return "job:runner:execution"
@@ -89,6 +110,16 @@ func (msg ExecutionMessage) Validate() error {
 		})
 	}
 
+	if msg.CallbackURL != "" {
+		if err := validateCallbackURL(msg.CallbackURL); err != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{
+				Field:   "callback_url",
+				Message: err.Error(),
+				Value:   msg.CallbackURL,
+			})
+		}
+	}
+
 	if msg.Result != nil {
 		if err := msg.Result.Validate(); err != nil {
 			fieldErrors = append(fieldErrors, errors.FieldError{
@@ -152,6 +183,21 @@ type Registry interface {
 	GetResult(id string) (Result, bool)
 }
 
+// MutableRegistry is implemented by registries that support in-place updates,
+// which Runner.Reload requires to apply discovery diffs.
+type MutableRegistry interface {
+	Registry
+	Replace(job Task) error
+	Remove(id string) bool
+}
+
+// TaskToggler is implemented by registries that can switch tasks off at runtime.
+// Disabled tasks stay registered and scheduled but TaskCommander refuses to run them.
+type TaskToggler interface {
+	SetEnabled(id string, enabled bool) error
+	IsEnabled(id string) bool
+}
+
 type MetadataParser interface {
 	Parse(content []byte) (Config, string, error)
 }
@@ -165,6 +211,7 @@ type Config struct {
 	Schedule       string            `yaml:"schedule" json:"schedule"`
 	Retries        int               `yaml:"retries" json:"retries"`
 	Timeout        time.Duration     `yaml:"duration" json:"duration"`
+	WarnAfter      time.Duration     `yaml:"warn_after" json:"warn_after"`
 	Deadline       time.Time         `yaml:"deadline" json:"deadline"`
 	NoTimeout      bool              `yaml:"no_timeout" json:"no_timeout"`
 	Debug          bool              `yaml:"debug" json:"debug"`
@@ -177,6 +224,9 @@ type Config struct {
 	Env            map[string]string `yaml:"env" json:"env"`
 	Backoff        BackoffConfig     `yaml:"backoff" json:"backoff"`
 	MaxConcurrency int               `yaml:"max_concurrency" json:"max_concurrency"`
+	// Affinity lists the worker labels a run requires, e.g. gpu: "true".
+	// Queue workers only run messages whose affinity their labels satisfy.
+	Affinity map[string]string `yaml:"affinity" json:"affinity,omitempty"`
 }
 
 var (
@@ -0,0 +1,273 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/dop251/goja"
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	// ControlSocketEnvVar names the unix socket shell jobs use to invoke other
+	// jobs, e.g. with `job run <id> key=value`.
+	ControlSocketEnvVar = "JOB_CONTROL_SOCKET"
+
+	// ResultStatusAccepted marks an invocation started without waiting.
+	ResultStatusAccepted = "accepted"
+
+	// MaxJobInvokeDepth bounds nested script-to-script invocations so a job
+	// that runs itself cannot recurse forever.
+	MaxJobInvokeDepth = 8
+)
+
+// JobInvocation asks a JobInvoker to run a registered job.
+type JobInvocation struct {
+	JobID  string         `json:"job_id"`
+	Params map[string]any `json:"params,omitempty"`
+	// Wait runs the job to completion and returns its Result; otherwise the
+	// job starts in the background and an accepted Result is returned.
+	Wait bool `json:"wait,omitempty"`
+}
+
+// JobInvoker runs registered jobs on behalf of scripts, backing the JS
+// `jobs.run(id, params)` binding and the shell control socket.
+type JobInvoker interface {
+	InvokeJob(ctx context.Context, inv JobInvocation) (Result, error)
+}
+
+// JobInvokerAware engines and task creators accept a JobInvoker.
+type JobInvokerAware interface {
+	SetJobInvoker(JobInvoker)
+}
+
+// RegistryInvoker runs jobs from a registry through a TaskCommander.
+type RegistryInvoker struct {
+	registry  func() Registry
+	commander func(Task) *TaskCommander
+}
+
+// NewRegistryInvoker builds an invoker resolving jobs from registry. Waited
+// results are redacted and stored in registry.
+func NewRegistryInvoker(registry Registry) *RegistryInvoker {
+	commander := RegistryCommander(registry)
+	return &RegistryInvoker{
+		registry: func() Registry { return registry },
+		commander: func(task Task) *TaskCommander {
+			return commander(task).WithResultRecorder(registry)
+		},
+	}
+}
+
+// WithCommander customises the TaskCommander used for each invocation.
+// Waited results are recorded through the commander's result recorder.
+func (i *RegistryInvoker) WithCommander(fn func(Task) *TaskCommander) *RegistryInvoker {
+	if fn != nil {
+		i.commander = fn
+	}
+	return i
+}
+
+type invokeDepthKey struct{}
+
+// InvokeJob implements JobInvoker. The invoked run gets its own run ID and
+// keeps the caller's trace ID; waited results are recorded through the
+// commander, so its redactor and recorder apply.
+func (i *RegistryInvoker) InvokeJob(ctx context.Context, inv JobInvocation) (Result, error) {
+	depth, _ := ctx.Value(invokeDepthKey{}).(int)
+	if depth >= MaxJobInvokeDepth {
+		return Result{}, errors.New(fmt.Sprintf("job invocation depth exceeds %d", MaxJobInvokeDepth), errors.CategoryBadInput).
+			WithTextCode("JOB_INVOKE_DEPTH_EXCEEDED").
+			WithMetadata(map[string]any{"job_id": inv.JobID, "depth": depth})
+	}
+
+	registry := i.registry()
+	if registry == nil {
+		return Result{}, fmt.Errorf("job invoker registry not configured")
+	}
+	task, ok := registry.Get(inv.JobID)
+	if !ok || task == nil {
+		return Result{}, errors.New(fmt.Sprintf("job %q not found", inv.JobID), errors.CategoryNotFound).
+			WithTextCode("JOB_NOT_FOUND").
+			WithMetadata(map[string]any{"job_id": inv.JobID})
+	}
+
+	runID := NewRunID()
+	ctx = context.WithValue(ContextWithRunID(ctx, runID), invokeDepthKey{}, depth+1)
+	msg := &ExecutionMessage{JobID: inv.JobID, Parameters: copyParams(inv.Params)}
+	cmd := i.commander(task)
+	meta := map[string]any{"run_id": runID}
+
+	if !inv.Wait {
+		go func(ctx context.Context) {
+			_ = cmd.Execute(ctx, msg)
+		}(context.WithoutCancel(ctx))
+		return Result{Status: ResultStatusAccepted, Metadata: meta}, nil
+	}
+
+	report, err := cmd.ExecuteWithReport(ctx, msg)
+	if !commanderRecordedResult(report, err) {
+		cmd.recordResult(inv.JobID, report.Result)
+	}
+	return report.Result, err
+}
+
+// commanderRecordedResult reports whether the commander already recorded the
+// run's result: it does so for refused, expired and panicking runs.
+func commanderRecordedResult(report ExecutionReport, err error) bool {
+	switch report.Result.Status {
+	case ResultStatusDisabled, ResultStatusMaintenance, ResultStatusExpired:
+		return true
+	}
+	return IsPanic(err)
+}
+
+// controlSocketResponse is the JSON body returned by the control socket.
+type controlSocketResponse struct {
+	Result Result `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// serveControlSocket listens on a fresh unix socket that accepts
+// `POST /run` requests carrying a JobInvocation. It returns the socket path
+// and a stop function removing it.
+func serveControlSocket(ctx context.Context, invoker JobInvoker) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "job-ctl-")
+	if err != nil {
+		return "", func() {}, err
+	}
+	path := filepath.Join(dir, "ctl.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", func() {}, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /run", func(w http.ResponseWriter, r *http.Request) {
+		var inv JobInvocation
+		if err := json.NewDecoder(r.Body).Decode(&inv); err != nil || inv.JobID == "" {
+			writeControlResponse(w, http.StatusBadRequest, controlSocketResponse{Error: "expected a job_id"})
+			return
+		}
+		result, err := invoker.InvokeJob(ctx, inv)
+		resp := controlSocketResponse{Result: result}
+		status := http.StatusOK
+		if err != nil {
+			resp.Error = err.Error()
+			status = http.StatusUnprocessableEntity
+			if errors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+		}
+		writeControlResponse(w, status, resp)
+	})
+
+	server := &http.Server{Handler: mux}
+	go func() { _ = server.Serve(listener) }()
+
+	return path, func() {
+		_ = server.Close()
+		os.RemoveAll(dir)
+	}, nil
+}
+
+func writeControlResponse(w http.ResponseWriter, status int, resp controlSocketResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// InvokeViaControlSocket sends inv to the control socket at socketPath, as
+// exported to shell jobs in JOB_CONTROL_SOCKET. It backs the `job run` helper.
+func InvokeViaControlSocket(ctx context.Context, socketPath string, inv JobInvocation) (Result, error) {
+	if socketPath == "" {
+		return Result{}, fmt.Errorf("%s is not set; job invocation is not enabled for this run", ControlSocketEnvVar)
+	}
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}}
+
+	body, err := json.Marshal(inv)
+	if err != nil {
+		return Result{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://job/run", bytes.NewReader(body))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	var out controlSocketResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Result{}, fmt.Errorf("decode control socket response: %w", err)
+	}
+	if out.Error != "" {
+		return out.Result, fmt.Errorf("%s", out.Error)
+	}
+	return out.Result, nil
+}
+
+// setupJobsBinding exposes `jobs.run(id, params, {wait})`, returning a promise
+// for the invoked job's result. wait defaults to true; a failed job rejects
+// the promise.
+func setupJobsBinding(ctx context.Context, vm *goja.Runtime, scope *jsCancelScope, invoker JobInvoker) error {
+	jobs := vm.NewObject()
+	if err := jobs.Set("run", func(id string, params map[string]any, opts map[string]any) *goja.Promise {
+		promise, resolve, reject := vm.NewPromise()
+		if scope.isCancelled() {
+			_ = reject(jsAbortError(vm))
+			return promise
+		}
+		inv := JobInvocation{JobID: id, Params: params, Wait: true}
+		if wait, ok := opts["wait"].(bool); ok {
+			inv.Wait = wait
+		}
+		pending := scope.track(func(_ *goja.Runtime, reason goja.Value) { _ = reject(reason) })
+		go func() {
+			result, err := invoker.InvokeJob(ctx, inv)
+			scope.settle(pending, func(vm *goja.Runtime) {
+				if err != nil {
+					_ = reject(vm.NewGoError(err))
+					return
+				}
+				_ = resolve(vm.ToValue(jsInvokeResult(result)))
+			})
+		}()
+		return promise
+	}); err != nil {
+		return err
+	}
+	return vm.Set("jobs", jobs)
+}
+
+func jsInvokeResult(result Result) map[string]any {
+	out := map[string]any{
+		"status":     result.Status,
+		"durationMs": result.Duration.Milliseconds(),
+	}
+	if result.Message != "" {
+		out["message"] = result.Message
+	}
+	if runID, ok := result.Metadata["run_id"]; ok {
+		out["runId"] = runID
+	}
+	if len(result.Metadata) > 0 {
+		out["metadata"] = result.Metadata
+	}
+	return out
+}
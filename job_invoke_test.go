@@ -0,0 +1,241 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goliatone/go-errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type invokedTask struct {
+	*stubTask
+	err  error
+	msgs chan *ExecutionMessage
+}
+
+func newInvokedTask(id string, err error) *invokedTask {
+	return &invokedTask{stubTask: newStubTask(id, Config{}), err: err, msgs: make(chan *ExecutionMessage, 4)}
+}
+
+func (t *invokedTask) Execute(_ context.Context, msg *ExecutionMessage) error {
+	t.msgs <- msg
+	return t.err
+}
+
+func TestRegistryInvokerRunsAndRecordsResult(t *testing.T) {
+	reg := NewMemoryRegistry()
+	child := newInvokedTask("child", nil)
+	require.NoError(t, reg.Add(child))
+	invoker := NewRegistryInvoker(reg)
+
+	result, err := invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child", Params: map[string]any{"n": 2}, Wait: true})
+	require.NoError(t, err)
+	assert.Equal(t, ResultStatusSucceeded, result.Status)
+	assert.NotEmpty(t, result.Metadata["run_id"])
+	assert.Equal(t, 2, (<-child.msgs).Parameters["n"])
+
+	stored, ok := reg.GetResult("child")
+	require.True(t, ok)
+	assert.Equal(t, ResultStatusSucceeded, stored.Status)
+
+	result, err = invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child"})
+	require.NoError(t, err)
+	assert.Equal(t, ResultStatusAccepted, result.Status)
+	<-child.msgs
+
+	_, err = invoker.InvokeJob(context.Background(), JobInvocation{JobID: "missing", Wait: true})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestRegistryInvokerBoundsDepth(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(newInvokedTask("child", nil)))
+
+	ctx := context.WithValue(context.Background(), invokeDepthKey{}, MaxJobInvokeDepth)
+	_, err := NewRegistryInvoker(reg).InvokeJob(ctx, JobInvocation{JobID: "child", Wait: true})
+	var typed *errors.Error
+	require.True(t, errors.As(err, &typed))
+	assert.Equal(t, "JOB_INVOKE_DEPTH_EXCEEDED", typed.TextCode)
+}
+
+func TestJSJobsRunBinding(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(newInvokedTask("child", nil)))
+	require.NoError(t, reg.Add(newInvokedTask("broken", fmt.Errorf("boom"))))
+
+	engine := NewJSRunner()
+	engine.SetJobInvoker(NewRegistryInvoker(reg))
+
+	events := &jsEvents{}
+	script := `
+async function main() {
+  var res = await jobs.run("child", {n: 1});
+  record(res.status + ":" + (res.runId ? "run" : "none"));
+  try {
+    await jobs.run("broken", {});
+  } catch (err) {
+    record("rejected");
+  }
+  var bg = await jobs.run("child", {}, {wait: false});
+  record(bg.status);
+}
+main();
+`
+	err := engine.Execute(context.Background(), &ExecutionMessage{
+		JobID:      "parent",
+		ScriptPath: "parent.js",
+		Parameters: map[string]any{"record": events.record, "script": script},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"succeeded:run", "rejected", "accepted"}, events.list())
+
+	err = engine.Execute(context.Background(), &ExecutionMessage{
+		JobID:      "denied",
+		ScriptPath: "denied.js",
+		Config:     Config{Metadata: map[string]any{CapabilitiesMetadataKey: []any{"fetch"}}},
+		Parameters: map[string]any{"record": events.record, "script": `record(typeof jobs);`},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "undefined", events.list()[3])
+}
+
+func TestShellControlSocket(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not available")
+	}
+	reg := NewMemoryRegistry()
+	child := newInvokedTask("child", nil)
+	require.NoError(t, reg.Add(child))
+
+	engine := NewShellRunner()
+	engine.SetJobInvoker(NewRegistryInvoker(reg))
+
+	out := filepath.Join(t.TempDir(), "out.json")
+	script := fmt.Sprintf(`curl -s --unix-socket "$%s" -d '{"job_id":"child","params":{"day":"mon"},"wait":true}' http://job/run > %s`, ControlSocketEnvVar, out)
+	err := engine.Execute(context.Background(), &ExecutionMessage{
+		JobID:      "parent",
+		ScriptPath: "parent.sh",
+		Parameters: map[string]any{"script": script},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "mon", (<-child.msgs).Parameters["day"])
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"status":"succeeded"`)
+}
+
+func TestInvokeViaControlSocket(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(newInvokedTask("child", nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	socket, stop, err := serveControlSocket(ctx, NewRegistryInvoker(reg))
+	require.NoError(t, err)
+	defer stop()
+
+	result, err := InvokeViaControlSocket(ctx, socket, JobInvocation{JobID: "child", Wait: true})
+	require.NoError(t, err)
+	assert.Equal(t, ResultStatusSucceeded, result.Status)
+
+	_, err = InvokeViaControlSocket(ctx, socket, JobInvocation{JobID: "missing", Wait: true})
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = InvokeViaControlSocket(ctx, "", JobInvocation{JobID: "child"})
+	assert.ErrorContains(t, err, ControlSocketEnvVar)
+}
+
+func TestRunnerWithJobInvocation(t *testing.T) {
+	provider := NewFileSystemSourceProvider(".", fstest.MapFS{
+		"parent.js": {Data: []byte(`jobs.run("child.js", {n: 2}).then(function (r) { if (r.status !== "succeeded") throw new Error(r.status); });`)},
+		"child.js":  {Data: []byte(`if (n !== 2) { throw new Error("missing n"); }`)},
+	})
+	runner := NewRunner(
+		WithTaskCreator(NewTaskCreator(provider, []Engine{NewJSRunner()})),
+		WithJobInvocation(),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	parent, ok := runner.registry.Get("parent.js")
+	require.True(t, ok)
+	require.NoError(t, NewTaskCommander(parent).Execute(context.Background(), &ExecutionMessage{JobID: "parent.js"}))
+
+	result, ok := runner.GetResult("child.js")
+	require.True(t, ok)
+	assert.Equal(t, ResultStatusSucceeded, result.Status)
+}
+
+func TestRegistryInvokerRefusesDisabledJobs(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(newInvokedTask("child", nil)))
+	require.NoError(t, reg.SetEnabled("child", false))
+
+	result, err := NewRegistryInvoker(reg).InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	assert.ErrorIs(t, err, ErrTaskDisabled)
+	assert.Equal(t, ResultStatusDisabled, result.Status)
+}
+
+func TestRunnerJobInvocationHonoursMaintenance(t *testing.T) {
+	reg := NewMemoryRegistry()
+	child := newInvokedTask("child", nil)
+	require.NoError(t, reg.Add(child))
+	runner := NewRunner(WithRegistry(reg), WithJobInvocation())
+	runner.Maintenance().Enable("upgrade")
+
+	result, err := runner.invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.Equal(t, ResultStatusMaintenance, result.Status)
+	assert.Empty(t, child.msgs)
+
+	runner.Maintenance().Disable()
+	require.NoError(t, reg.SetEnabled("child", false))
+	_, err = runner.invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	assert.ErrorIs(t, err, ErrTaskDisabled)
+}
+
+type reportingInvokedTask struct {
+	*stubTask
+	result Result
+}
+
+func (t *reportingInvokedTask) Execute(ctx context.Context, _ *ExecutionMessage) error {
+	ReportResult(ctx, t.result)
+	return nil
+}
+
+func TestRegistryInvokerRecordsThroughCommander(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(&reportingInvokedTask{stubTask: newStubTask("child", Config{}), result: Result{
+		Metadata: map[string]any{"api_token": "secret", "rows": 3},
+	}}))
+
+	_, err := NewRegistryInvoker(reg).InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	require.NoError(t, err)
+	stored, ok := reg.GetResult("child")
+	require.True(t, ok)
+	assert.NotEqual(t, "secret", stored.Metadata["api_token"], "results are redacted before they are stored")
+	assert.Equal(t, 3, stored.Metadata["rows"])
+
+	processed := 0
+	runner := NewRunner(WithRegistry(reg), WithJobInvocation(),
+		WithResultProcessors(ResultProcessorFunc(func(_ context.Context, _ string, result Result) (Result, error) {
+			processed++
+			return result, nil
+		})))
+	_, err = runner.invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed, "the waited result goes through the runner pipeline once")
+
+	require.NoError(t, reg.SetEnabled("child", false))
+	_, err = runner.invoker.InvokeJob(context.Background(), JobInvocation{JobID: "child", Wait: true})
+	assert.ErrorIs(t, err, ErrTaskDisabled)
+	assert.Equal(t, 2, processed, "refused runs are recorded once, by the commander")
+}
@@ -112,6 +112,47 @@ func TestBaseEngineRespectsInboundDeadline(t *testing.T) {
 	assert.Equal(t, d1, d2)
 }
 
+func TestBaseEngineMessageTimeoutResolution(t *testing.T) {
+	be := job.NewBaseEngine(noopEngine{}, "noop")
+	be.Timeout = time.Second
+
+	remaining := func(msg *job.ExecutionMessage) (time.Duration, bool) {
+		execCtx, cancel := be.GetMessageExecutionContext(context.Background(), msg)
+		defer cancel()
+		deadline, ok := execCtx.Deadline()
+		return time.Until(deadline), ok
+	}
+
+	d, ok := remaining(&job.ExecutionMessage{Config: job.Config{Timeout: time.Hour}})
+	require.True(t, ok)
+	assert.Greater(t, d, 59*time.Minute, "message timeout longer than the engine default applies")
+
+	d, ok = remaining(&job.ExecutionMessage{})
+	require.True(t, ok)
+	assert.LessOrEqual(t, d, time.Second, "engine default applies without a message timeout")
+
+	_, ok = remaining(&job.ExecutionMessage{Config: job.Config{Timeout: time.Hour, NoTimeout: true}})
+	assert.False(t, ok, "NoTimeout leaves the execution unbounded")
+
+	be.Timeout = 0
+	_, ok = remaining(nil)
+	assert.False(t, ok, "a zero engine default leaves the execution unbounded")
+}
+
+func TestBaseEngineInboundDeadlineWinsOverMessageTimeout(t *testing.T) {
+	be := job.NewBaseEngine(noopEngine{}, "noop")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	execCtx, execCancel := be.GetMessageExecutionContext(ctx, &job.ExecutionMessage{Config: job.Config{NoTimeout: true}})
+	defer execCancel()
+
+	d1, _ := ctx.Deadline()
+	d2, ok := execCtx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, d1, d2)
+}
+
 func TestHandlerOptionsMappingFromConfig(t *testing.T) {
 	deadline := time.Now().Add(time.Hour).UTC()
 	cfg := job.Config{
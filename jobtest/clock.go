@@ -0,0 +1,130 @@
+package jobtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	job "github.com/goliatone/go-job"
+)
+
+var _ job.Clock = &FakeClock{}
+
+// DefaultFakeClockStart is used by NewFakeClock when given a zero time.
+var DefaultFakeClockStart = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeClock is a job.Clock that only moves when Advance or Set is called.
+// Timers fire synchronously during Advance once their deadline is reached.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a fake clock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	if start.IsZero() {
+		start = DefaultFakeClockStart
+	}
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer firing once the clock is advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) job.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d, firing due timers in deadline order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.setLocked(c.now.Add(d))
+	c.mu.Unlock()
+}
+
+// Set moves the clock to t, firing due timers. Moving backwards fires nothing.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.setLocked(t)
+	c.mu.Unlock()
+}
+
+// Pending reports the number of timers waiting to fire.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil waits until at least n timers are pending, so a test can
+// advance the clock only once the code under test is sleeping.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) setLocked(t time.Time) {
+	c.now = t
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+
+	remaining := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(t) {
+			remaining = append(remaining, timer)
+			continue
+		}
+		timer.ch <- timer.deadline
+	}
+	for i := len(remaining); i < len(c.timers); i++ {
+		c.timers[i] = nil
+	}
+	c.timers = remaining
+}
+
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+// Stop prevents the timer from firing, reporting whether it was pending.
+func (t *fakeTimer) Stop() bool { return t.clock.remove(t) }
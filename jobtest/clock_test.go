@@ -0,0 +1,94 @@
+package jobtest_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	job "github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClockFiresTimersOnAdvance(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Time{})
+	start := clock.Now()
+
+	short := clock.NewTimer(time.Second)
+	long := clock.NewTimer(time.Minute)
+	assert.Equal(t, 2, clock.Pending())
+
+	clock.Advance(30 * time.Second)
+	select {
+	case fired := <-short.C():
+		assert.Equal(t, start.Add(time.Second), fired)
+	default:
+		t.Fatal("expected short timer to fire")
+	}
+	select {
+	case <-long.C():
+		t.Fatal("long timer fired early")
+	default:
+	}
+
+	assert.True(t, long.Stop())
+	assert.False(t, long.Stop())
+	assert.Zero(t, clock.Pending())
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+}
+
+func TestFakeClockDrivesNextRun(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 3, 10, 12, 30, 0, 0, time.UTC))
+
+	next, err := job.NextRun("0 * * * *", time.Time{}, job.WithSchedulerClock(clock), job.WithLocation(time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC), next)
+}
+
+type flakyTask struct {
+	calls atomic.Int32
+}
+
+func (t *flakyTask) GetID() string                        { return "flaky" }
+func (t *flakyTask) GetHandler() func() error             { return nil }
+func (t *flakyTask) GetHandlerConfig() job.HandlerOptions { return job.HandlerOptions{} }
+func (t *flakyTask) GetConfig() job.Config                { return job.Config{} }
+func (t *flakyTask) GetPath() string                      { return "flaky.js" }
+func (t *flakyTask) GetEngine() job.Engine                { return nil }
+func (t *flakyTask) Execute(context.Context, *job.ExecutionMessage) error {
+	if t.calls.Add(1) < 3 {
+		return errors.New("transient")
+	}
+	return nil
+}
+
+func TestFakeClockDrivesRetryBackoff(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Time{})
+	task := &flakyTask{}
+	retries := 2
+	cmd := job.NewTaskCommander(task).WithClock(clock).WithRetryOverride(retries)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Execute(context.Background(), &job.ExecutionMessage{
+			JobID:  "flaky",
+			Config: job.Config{Backoff: job.BackoffConfig{Strategy: "fixed", Interval: time.Hour}},
+		})
+	}()
+
+	for i := 0; i < retries; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+	}
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("retries did not complete after advancing the clock")
+	}
+	assert.EqualValues(t, 3, task.calls.Load())
+}
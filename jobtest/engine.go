@@ -0,0 +1,129 @@
+package jobtest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	job "github.com/goliatone/go-job"
+)
+
+var _ job.Engine = &RecordingEngine{}
+
+// RecordingEngineType is the engine type of tasks parsed by RecordingEngine.
+const RecordingEngineType = "jobtest"
+
+// RecordingEngine parses scripts with the YAML metadata parser and records
+// executions instead of running them. Outcomes can be scripted per job.
+type RecordingEngine struct {
+	recorder *RunRecorder
+	exts     []string
+	parser   job.MetadataParser
+	ids      job.TaskIDProvider
+
+	mu        sync.Mutex
+	outcomes  map[string][]error
+	onExecute func(context.Context, *job.ExecutionMessage) error
+}
+
+// NewRecordingEngine returns an engine handling exts (every file when empty)
+// and recording executions into recorder.
+func NewRecordingEngine(recorder *RunRecorder, exts ...string) *RecordingEngine {
+	if recorder == nil {
+		recorder = NewRunRecorder(nil)
+	}
+	normalized := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		ext = strings.ToLower(ext)
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized = append(normalized, ext)
+	}
+	return &RecordingEngine{
+		recorder: recorder,
+		exts:     normalized,
+		parser:   job.NewYAMLMetadataParser(),
+		ids:      job.DefaultTaskIDProvider,
+		outcomes: make(map[string][]error),
+	}
+}
+
+// Recorder returns the recorder receiving executions.
+func (e *RecordingEngine) Recorder() *RunRecorder {
+	return e.recorder
+}
+
+// FailWith queues outcomes for successive executions of jobID; nil entries
+// succeed. Once the queue is drained executions succeed.
+func (e *RecordingEngine) FailWith(jobID string, errs ...error) *RecordingEngine {
+	e.mu.Lock()
+	e.outcomes[jobID] = append(e.outcomes[jobID], errs...)
+	e.mu.Unlock()
+	return e
+}
+
+// OnExecute runs fn for every execution without a queued outcome; its error
+// becomes the execution result.
+func (e *RecordingEngine) OnExecute(fn func(context.Context, *job.ExecutionMessage) error) *RecordingEngine {
+	e.mu.Lock()
+	e.onExecute = fn
+	e.mu.Unlock()
+	return e
+}
+
+// SetTaskIDProvider overrides how task IDs are derived from script paths.
+func (e *RecordingEngine) SetTaskIDProvider(provider job.TaskIDProvider) {
+	if provider != nil {
+		e.ids = provider
+	}
+}
+
+func (e *RecordingEngine) Name() string {
+	return "engine:" + RecordingEngineType
+}
+
+func (e *RecordingEngine) CanHandle(path string) bool {
+	if len(e.exts) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, supported := range e.exts {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *RecordingEngine) ParseJob(path string, content []byte) (job.Task, error) {
+	cfg, script, err := e.parser.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	return job.NewBaseTask(e.ids(path), path, RecordingEngineType, cfg, script, e), nil
+}
+
+func (e *RecordingEngine) Execute(ctx context.Context, msg *job.ExecutionMessage) error {
+	err := e.outcome(ctx, msg)
+	e.recorder.Record(ctx, msg, err)
+	return err
+}
+
+func (e *RecordingEngine) outcome(ctx context.Context, msg *job.ExecutionMessage) error {
+	e.mu.Lock()
+	if queued := e.outcomes[msg.JobID]; len(queued) > 0 {
+		e.outcomes[msg.JobID] = queued[1:]
+		e.mu.Unlock()
+		return queued[0]
+	}
+	fn := e.onExecute
+	e.mu.Unlock()
+
+	if fn != nil {
+		return fn(ctx, msg)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package jobtest
+
+import (
+	"testing"
+
+	job "github.com/goliatone/go-job"
+)
+
+// Harness wires the jobtest doubles together: scripts served by Source are
+// discovered by Engine, scheduled on Scheduler, timed by Clock, and their
+// executions collected by Recorder.
+type Harness struct {
+	Clock     *FakeClock
+	Scheduler *Scheduler
+	Source    *SourceProvider
+	Engine    *RecordingEngine
+	Recorder  *RunRecorder
+	Registry  job.Registry
+}
+
+// NewHarness returns a harness serving scripts keyed by path.
+func NewHarness(scripts map[string]string) *Harness {
+	clock := NewFakeClock(DefaultFakeClockStart)
+	recorder := NewRunRecorder(clock)
+	return &Harness{
+		Clock:     clock,
+		Scheduler: NewScheduler(clock),
+		Source:    NewSourceProvider(scripts),
+		Engine:    NewRecordingEngine(recorder),
+		Recorder:  recorder,
+		Registry:  job.NewMemoryRegistry(),
+	}
+}
+
+// TaskCreator discovers the harness scripts with the recording engine.
+func (h *Harness) TaskCreator() job.TaskCreator {
+	return job.NewTaskCreator(h.Source, []job.Engine{h.Engine})
+}
+
+// Runner returns a runner discovering into the harness registry; opts are applied last.
+func (h *Harness) Runner(opts ...job.Option) *job.Runner {
+	base := []job.Option{
+		job.WithTaskCreator(h.TaskCreator()),
+		job.WithRegistry(h.Registry),
+	}
+	return job.NewRunner(append(base, opts...)...)
+}
+
+// CronManager returns a manager scheduling harness registry tasks on the harness scheduler and clock.
+func (h *Harness) CronManager() *job.CronManager {
+	return job.NewCronManager(h.Registry, h.Scheduler).WithClock(h.Clock)
+}
+
+// AssertExecuted checks that jobID ran exactly times times.
+func (h *Harness) AssertExecuted(t testing.TB, jobID string, times int) bool {
+	t.Helper()
+	return h.Recorder.AssertExecuted(t, jobID, times)
+}
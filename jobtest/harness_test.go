@@ -0,0 +1,152 @@
+package jobtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	job "github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarnessDiscoversSchedulesAndRecordsRuns(t *testing.T) {
+	h := jobtest.NewHarness(map[string]string{
+		"jobs/report.js":  "// config\n// schedule: \"0 * * * *\"\nreport()\n",
+		"jobs/cleanup.sh": "# config\n# schedule: \"@daily\"\nrm -rf tmp\n",
+	})
+
+	ctx := context.Background()
+	require.NoError(t, h.Runner().Start(ctx))
+	require.Len(t, h.Registry.List(), 2)
+
+	manager := h.CronManager()
+	for _, task := range h.Registry.List() {
+		require.NoError(t, manager.Register(ctx, job.ScheduleDefinition{
+			ID:         task.GetID(),
+			Expression: task.GetConfig().Schedule,
+			Message:    job.ExecutionMessage{JobID: task.GetID()},
+		}))
+	}
+	assert.Equal(t, 2, h.Scheduler.Len())
+
+	h.Clock.Advance(30 * time.Minute)
+	ran, err := h.Scheduler.RunDue()
+	require.NoError(t, err)
+	assert.Zero(t, ran)
+
+	h.Clock.Advance(30 * time.Minute)
+	ran, err = h.Scheduler.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	h.AssertExecuted(t, "report.js", 1)
+	h.Recorder.AssertNotExecuted(t, "cleanup.sh")
+	h.Recorder.AssertSucceeded(t, "report.js")
+
+	runs := h.Recorder.RunsFor("report.js")
+	require.Len(t, runs, 1)
+	assert.Equal(t, "jobs/report.js", runs[0].ScriptPath)
+	assert.Equal(t, h.Clock.Now(), runs[0].At)
+	assert.NotEmpty(t, runs[0].RunID)
+	assert.NotContains(t, runs[0].Parameters, "script")
+
+	h.Clock.Advance(23 * time.Hour)
+	ran, err = h.Scheduler.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 2, ran)
+	h.AssertExecuted(t, "report.js", 2)
+	h.AssertExecuted(t, "cleanup.sh", 1)
+}
+
+func TestRecordingEngineScriptedOutcomes(t *testing.T) {
+	h := jobtest.NewHarness(map[string]string{
+		"jobs/sync.js": "// config\n// retries: 2\nsync()\n",
+	})
+	h.Engine.FailWith("sync.js", errors.New("first"), errors.New("second"))
+
+	ctx := context.Background()
+	require.NoError(t, h.Runner().Start(ctx))
+	task, ok := h.Registry.Get("sync.js")
+	require.True(t, ok)
+
+	restore := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restore()
+
+	require.NoError(t, job.NewTaskCommander(task).Execute(ctx, &job.ExecutionMessage{JobID: "sync.js"}))
+	h.AssertExecuted(t, "sync.js", 3)
+	h.Recorder.AssertSucceeded(t, "sync.js")
+
+	runs := h.Recorder.RunsFor("sync.js")
+	assert.EqualError(t, runs[0].Err, "first")
+	assert.EqualError(t, runs[1].Err, "second")
+}
+
+func TestSourceProviderCountsReads(t *testing.T) {
+	source := jobtest.NewSourceProvider(nil).WithScript("a.sh", "echo a")
+
+	scripts, err := source.ListScripts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, scripts, 1)
+	assert.Equal(t, "a.sh", scripts[0].ID)
+
+	content, err := source.GetScript("a.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "echo a", string(content))
+	assert.Equal(t, 1, source.Reads("a.sh"))
+
+	_, err = source.GetScript("missing.sh")
+	assert.Error(t, err)
+
+	source.FailList(errors.New("offline"))
+	_, err = source.ListScripts(context.Background())
+	assert.EqualError(t, err, "offline")
+}
+
+func TestRunRecorderAssertionsReportMismatches(t *testing.T) {
+	recorder := jobtest.NewRunRecorder(nil)
+	recorder.Record(context.Background(), &job.ExecutionMessage{JobID: "a"}, errors.New("boom"))
+
+	mock := &recordingTB{TB: t}
+	assert.False(t, recorder.AssertExecuted(mock, "a", 2))
+	assert.False(t, recorder.AssertSucceeded(mock, "a"))
+	assert.Equal(t, []string{
+		`expected job "a" to execute 2 time(s), got 1`,
+		`expected last run of job "a" to succeed, got boom`,
+	}, mock.failures)
+	assert.True(t, recorder.AssertFailed(t, "a"))
+	assert.True(t, recorder.AssertNotExecuted(t, "b"))
+}
+
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertTaskSnapshotMatchesGolden(t *testing.T) {
+	h := jobtest.NewHarness(map[string]string{
+		"jobs/report.js":  "// config\n// schedule: \"0 * * * *\"\n// retries: 2\n// metadata:\n//   tags: [reports]\nreport()\n",
+		"jobs/cleanup.sh": "# config\n# schedule: \"@daily\"\n# timeout: 5m\nrm -rf tmp\n",
+	})
+	require.NoError(t, h.Runner().Start(context.Background()))
+
+	jobtest.AssertTaskSnapshot(t, h.Registry.List(), "testdata/tasks.golden.yaml", job.SnapshotOptions{})
+	if os.Getenv(jobtest.UpdateGoldenEnv) != "" {
+		return
+	}
+
+	mock := &recordingTB{TB: t}
+	assert.False(t, jobtest.AssertTaskSnapshot(mock, h.Registry.List()[:1], "testdata/tasks.golden.yaml", job.SnapshotOptions{}))
+	require.Len(t, mock.failures, 1)
+	assert.Contains(t, mock.failures[0], "task snapshot differs")
+}
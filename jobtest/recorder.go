@@ -0,0 +1,140 @@
+package jobtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	job "github.com/goliatone/go-job"
+)
+
+// Run is a single execution observed by a RunRecorder.
+type Run struct {
+	JobID      string
+	RunID      string
+	ScriptPath string
+	Parameters map[string]any
+	Err        error
+	At         time.Time
+}
+
+// RunRecorder collects executions so tests can assert on them.
+type RunRecorder struct {
+	mu    sync.RWMutex
+	clock job.Clock
+	runs  []Run
+}
+
+// NewRunRecorder returns a recorder stamping runs with clock (SystemClock when nil).
+func NewRunRecorder(clock job.Clock) *RunRecorder {
+	if clock == nil {
+		clock = job.SystemClock
+	}
+	return &RunRecorder{clock: clock}
+}
+
+// Record stores an execution of msg with its outcome. The inline script body
+// is left out of the recorded parameters.
+func (r *RunRecorder) Record(ctx context.Context, msg *job.ExecutionMessage, err error) {
+	if r == nil || msg == nil {
+		return
+	}
+	run := Run{
+		JobID:      msg.JobID,
+		RunID:      job.RunIDFromContext(ctx),
+		ScriptPath: msg.ScriptPath,
+		Err:        err,
+		At:         r.clock.Now(),
+	}
+	if len(msg.Parameters) > 0 {
+		run.Parameters = make(map[string]any, len(msg.Parameters))
+		for key, value := range msg.Parameters {
+			if key != "script" {
+				run.Parameters[key] = value
+			}
+		}
+	}
+
+	r.mu.Lock()
+	r.runs = append(r.runs, run)
+	r.mu.Unlock()
+}
+
+// Runs returns every recorded run in execution order.
+func (r *RunRecorder) Runs() []Run {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Run(nil), r.runs...)
+}
+
+// RunsFor returns the recorded runs for jobID in execution order.
+func (r *RunRecorder) RunsFor(jobID string) []Run {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []Run
+	for _, run := range r.runs {
+		if run.JobID == jobID {
+			out = append(out, run)
+		}
+	}
+	return out
+}
+
+// Count returns how many times jobID ran.
+func (r *RunRecorder) Count(jobID string) int {
+	return len(r.RunsFor(jobID))
+}
+
+// Reset drops every recorded run.
+func (r *RunRecorder) Reset() {
+	r.mu.Lock()
+	r.runs = nil
+	r.mu.Unlock()
+}
+
+// AssertExecuted checks that jobID ran exactly times times.
+func (r *RunRecorder) AssertExecuted(t testing.TB, jobID string, times int) bool {
+	t.Helper()
+	if got := r.Count(jobID); got != times {
+		t.Errorf("expected job %q to execute %d time(s), got %d", jobID, times, got)
+		return false
+	}
+	return true
+}
+
+// AssertNotExecuted checks that jobID never ran.
+func (r *RunRecorder) AssertNotExecuted(t testing.TB, jobID string) bool {
+	t.Helper()
+	return r.AssertExecuted(t, jobID, 0)
+}
+
+// AssertSucceeded checks that the last run of jobID returned no error.
+func (r *RunRecorder) AssertSucceeded(t testing.TB, jobID string) bool {
+	t.Helper()
+	runs := r.RunsFor(jobID)
+	if len(runs) == 0 {
+		t.Errorf("expected job %q to have executed", jobID)
+		return false
+	}
+	if err := runs[len(runs)-1].Err; err != nil {
+		t.Errorf("expected last run of job %q to succeed, got %v", jobID, err)
+		return false
+	}
+	return true
+}
+
+// AssertFailed checks that the last run of jobID returned an error.
+func (r *RunRecorder) AssertFailed(t testing.TB, jobID string) bool {
+	t.Helper()
+	runs := r.RunsFor(jobID)
+	if len(runs) == 0 {
+		t.Errorf("expected job %q to have executed", jobID)
+		return false
+	}
+	if runs[len(runs)-1].Err == nil {
+		t.Errorf("expected last run of job %q to fail", jobID)
+		return false
+	}
+	return true
+}
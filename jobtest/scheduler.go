@@ -0,0 +1,152 @@
+package jobtest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-command"
+	gocron "github.com/goliatone/go-command/cron"
+	job "github.com/goliatone/go-job"
+)
+
+// ScheduledEntry is a handler registered with Scheduler.
+type ScheduledEntry struct {
+	ID     int
+	Config command.HandlerConfig
+	// Next is the next due time on the scheduler clock, zero when the
+	// expression cannot be parsed (the entry then only runs via RunAll).
+	Next time.Time
+}
+
+// Scheduler is an in-memory cron scheduler for job.NewCronManager. Nothing
+// runs on its own: tests call RunDue after advancing the clock, or RunAll.
+type Scheduler struct {
+	mu      sync.Mutex
+	clock   job.Clock
+	nextID  int
+	entries map[int]*scheduledHandler
+}
+
+type scheduledHandler struct {
+	entry ScheduledEntry
+	run   func() error
+}
+
+// NewScheduler returns a scheduler evaluating due times on clock (SystemClock when nil).
+func NewScheduler(clock job.Clock) *Scheduler {
+	if clock == nil {
+		clock = job.SystemClock
+	}
+	return &Scheduler{
+		clock:   clock,
+		nextID:  1,
+		entries: make(map[int]*scheduledHandler),
+	}
+}
+
+// AddHandler registers handler, which must be a func() error or func().
+func (s *Scheduler) AddHandler(cfg command.HandlerConfig, handler any) (gocron.Subscription, error) {
+	var run func() error
+	switch fn := handler.(type) {
+	case func() error:
+		run = fn
+	case func():
+		run = func() error { fn(); return nil }
+	default:
+		return nil, fmt.Errorf("unsupported handler type %T", handler)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.entries[id] = &scheduledHandler{
+		entry: ScheduledEntry{ID: id, Config: cfg, Next: s.next(cfg.Expression, s.clock.Now())},
+		run:   run,
+	}
+	return &subscription{scheduler: s, id: id}, nil
+}
+
+// Entries returns the registered handlers ordered by ID.
+func (s *Scheduler) Entries() []ScheduledEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ScheduledEntry, 0, len(s.entries))
+	for _, h := range s.entries {
+		out = append(out, h.entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Len returns the number of registered handlers.
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// RunDue runs every handler whose next due time has passed on the clock and
+// schedules its following run. It returns how many handlers ran and their
+// joined errors.
+func (s *Scheduler) RunDue() (int, error) {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	var due []*scheduledHandler
+	for _, h := range s.entries {
+		if !h.entry.Next.IsZero() && !h.entry.Next.After(now) {
+			h.entry.Next = s.next(h.entry.Config.Expression, now)
+			due = append(due, h)
+		}
+	}
+	s.mu.Unlock()
+
+	return runHandlers(due)
+}
+
+// RunAll runs every registered handler once regardless of its schedule.
+func (s *Scheduler) RunAll() (int, error) {
+	s.mu.Lock()
+	all := make([]*scheduledHandler, 0, len(s.entries))
+	for _, h := range s.entries {
+		all = append(all, h)
+	}
+	s.mu.Unlock()
+
+	return runHandlers(all)
+}
+
+func (s *Scheduler) next(expression string, after time.Time) time.Time {
+	next, err := job.NextRun(expression, after, job.WithLocation(after.Location()))
+	if err != nil {
+		return time.Time{}
+	}
+	return next
+}
+
+func runHandlers(handlers []*scheduledHandler) (int, error) {
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].entry.ID < handlers[j].entry.ID })
+	var errs []error
+	for _, h := range handlers {
+		if err := h.run(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(handlers), errors.Join(errs...)
+}
+
+type subscription struct {
+	scheduler *Scheduler
+	id        int
+}
+
+func (s *subscription) Unsubscribe() {
+	s.scheduler.mu.Lock()
+	delete(s.scheduler.entries, s.id)
+	s.scheduler.mu.Unlock()
+}
@@ -0,0 +1,56 @@
+package jobtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	job "github.com/goliatone/go-job"
+)
+
+// UpdateGoldenEnv names the environment variable that, when set to a non-empty
+// value, makes AssertTaskSnapshot rewrite golden files instead of comparing.
+const UpdateGoldenEnv = "JOBTEST_UPDATE_GOLDEN"
+
+// AssertTaskSnapshot renders tasks with job.TaskSnapshots and compares the
+// result with the golden file, encoded as JSON for ".json" files and YAML
+// otherwise. Run with JOBTEST_UPDATE_GOLDEN=1 to record a new golden file.
+func AssertTaskSnapshot(t testing.TB, tasks []job.Task, golden string, opts job.SnapshotOptions) bool {
+	t.Helper()
+
+	format := job.SnapshotYAML
+	if strings.EqualFold(filepath.Ext(golden), ".json") {
+		format = job.SnapshotJSON
+	}
+
+	var buf bytes.Buffer
+	if err := job.WriteTaskSnapshot(&buf, job.TaskSnapshots(tasks, opts), format); err != nil {
+		t.Errorf("failed to render task snapshot: %v", err)
+		return false
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Errorf("failed to create golden directory: %v", err)
+			return false
+		}
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Errorf("failed to write golden file %s: %v", golden, err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("failed to read golden file %s (set %s=1 to create it): %v", golden, UpdateGoldenEnv, err)
+		return false
+	}
+	if !bytes.Equal(want, buf.Bytes()) {
+		t.Errorf("task snapshot differs from %s (set %s=1 to update)\n--- want\n%s\n--- got\n%s", golden, UpdateGoldenEnv, want, buf.Bytes())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package jobtest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	job "github.com/goliatone/go-job"
+)
+
+var _ job.SourceProvider = &SourceProvider{}
+
+// SourceProvider serves scripts from memory and counts reads per path.
+type SourceProvider struct {
+	mu      sync.RWMutex
+	scripts map[string][]byte
+	reads   map[string]int
+	listErr error
+}
+
+// NewSourceProvider returns a provider serving scripts keyed by path.
+func NewSourceProvider(scripts map[string]string) *SourceProvider {
+	p := &SourceProvider{
+		scripts: make(map[string][]byte, len(scripts)),
+		reads:   make(map[string]int),
+	}
+	for path, content := range scripts {
+		p.scripts[path] = []byte(content)
+	}
+	return p
+}
+
+// WithScript adds or replaces the script at path.
+func (p *SourceProvider) WithScript(path, content string) *SourceProvider {
+	p.mu.Lock()
+	p.scripts[path] = []byte(content)
+	p.mu.Unlock()
+	return p
+}
+
+// Remove deletes the script at path.
+func (p *SourceProvider) Remove(path string) {
+	p.mu.Lock()
+	delete(p.scripts, path)
+	p.mu.Unlock()
+}
+
+// FailList makes ListScripts return err; nil restores listing.
+func (p *SourceProvider) FailList(err error) {
+	p.mu.Lock()
+	p.listErr = err
+	p.mu.Unlock()
+}
+
+// Reads reports how many times GetScript was called for path.
+func (p *SourceProvider) Reads(path string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.reads[path]
+}
+
+func (p *SourceProvider) GetScript(path string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reads[path]++
+	content, ok := p.scripts[path]
+	if !ok {
+		return nil, fmt.Errorf("script %s not found", path)
+	}
+	return append([]byte(nil), content...), nil
+}
+
+// ListScripts returns every script ordered by path.
+func (p *SourceProvider) ListScripts(ctx context.Context) ([]job.ScriptInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.listErr != nil {
+		return nil, p.listErr
+	}
+
+	paths := make([]string, 0, len(p.scripts))
+	for path := range p.scripts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	scripts := make([]job.ScriptInfo, 0, len(paths))
+	for _, path := range paths {
+		scripts = append(scripts, job.ScriptInfo{
+			ID:      filepath.Base(path),
+			Path:    path,
+			Content: append([]byte(nil), p.scripts[path]...),
+		})
+	}
+	return scripts, nil
+}
@@ -0,0 +1,221 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/eventloop"
+)
+
+// DefaultJSCancelGrace is how long a cancelled JS job may run cleanup code
+// (finally blocks, job.onCancel handlers) before its event loop is terminated.
+const DefaultJSCancelGrace = 2 * time.Second
+
+// jsCancelScope tracks the work pending on one execution's event loop so it
+// can be unwound when the execution context is cancelled: job.onCancel
+// handlers run, timers are cleared, and outstanding fetches and job.sleep calls
+// are rejected with an AbortError. All methods taking a runtime run on the loop.
+type jsCancelScope struct {
+	loop *eventloop.EventLoop
+
+	done chan struct{}
+
+	mu        sync.Mutex
+	cancelled bool
+	nextID    int
+	pending   map[int]func(*goja.Runtime, goja.Value)
+	timers    []jsTimer
+	handlers  []goja.Callable
+}
+
+type jsTimer struct {
+	handle goja.Value
+	clear  goja.Callable
+}
+
+func newJSCancelScope(loop *eventloop.EventLoop) *jsCancelScope {
+	return &jsCancelScope{
+		loop:    loop,
+		done:    make(chan struct{}),
+		pending: make(map[int]func(*goja.Runtime, goja.Value)),
+	}
+}
+
+// isCancelled reports whether the scope has been cancelled.
+func (s *jsCancelScope) isCancelled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cancelled
+}
+
+// track registers reject to be called with an AbortError on cancel. The
+// returned id is passed to settle once the operation completes.
+func (s *jsCancelScope) track(reject func(*goja.Runtime, goja.Value)) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.pending[s.nextID] = reject
+	return s.nextID
+}
+
+// settle runs fn on the loop unless the operation was already rejected by a
+// cancellation. It is safe to call from any goroutine.
+func (s *jsCancelScope) settle(id int, fn func(*goja.Runtime)) {
+	s.loop.RunOnLoop(func(vm *goja.Runtime) {
+		s.mu.Lock()
+		_, ok := s.pending[id]
+		delete(s.pending, id)
+		s.mu.Unlock()
+		if ok {
+			fn(vm)
+		}
+	})
+}
+
+// wrapTimers replaces setTimeout and setInterval with versions that record
+// their handles so cancel can clear them.
+func (s *jsCancelScope) wrapTimers(vm *goja.Runtime) error {
+	for _, pair := range [][2]string{{"setTimeout", "clearTimeout"}, {"setInterval", "clearInterval"}} {
+		set, ok := goja.AssertFunction(vm.Get(pair[0]))
+		if !ok {
+			continue
+		}
+		clear, ok := goja.AssertFunction(vm.Get(pair[1]))
+		if !ok {
+			continue
+		}
+		name := pair[0]
+		if err := vm.Set(name, func(call goja.FunctionCall) goja.Value {
+			if s.isCancelled() {
+				return goja.Undefined()
+			}
+			handle, err := set(goja.Undefined(), call.Arguments...)
+			if err != nil {
+				panic(err)
+			}
+			s.mu.Lock()
+			s.timers = append(s.timers, jsTimer{handle: handle, clear: clear})
+			s.mu.Unlock()
+			return handle
+		}); err != nil {
+			return fmt.Errorf("wrap %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// bind adds the cancellation helpers to the job binding:
+// job.onCancel(fn), job.cancelled() and job.sleep(ms).
+func (s *jsCancelScope) bind(vm *goja.Runtime, binding *goja.Object) error {
+	if err := binding.Set("onCancel", func(fn goja.Callable) {
+		s.mu.Lock()
+		s.handlers = append(s.handlers, fn)
+		s.mu.Unlock()
+	}); err != nil {
+		return err
+	}
+	if err := binding.Set("cancelled", s.isCancelled); err != nil {
+		return err
+	}
+	return binding.Set("sleep", func(ms int64) *goja.Promise {
+		promise, resolve, reject := vm.NewPromise()
+		if s.isCancelled() {
+			_ = reject(jsAbortError(vm))
+			return promise
+		}
+		id := s.track(func(_ *goja.Runtime, reason goja.Value) { _ = reject(reason) })
+		go func() {
+			timer := time.NewTimer(time.Duration(ms) * time.Millisecond)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				s.settle(id, func(*goja.Runtime) { _ = resolve(goja.Undefined()) })
+			case <-s.done:
+			}
+		}()
+		return promise
+	})
+}
+
+// cancel unwinds pending work on the loop. It reports false when the loop
+// no longer accepts jobs.
+func (s *jsCancelScope) cancel() bool {
+	return s.loop.RunOnLoop(func(vm *goja.Runtime) {
+		s.mu.Lock()
+		if s.cancelled {
+			s.mu.Unlock()
+			return
+		}
+		s.cancelled = true
+		close(s.done)
+		timers := s.timers
+		pending := s.pending
+		handlers := s.handlers
+		s.timers = nil
+		s.pending = make(map[int]func(*goja.Runtime, goja.Value))
+		s.mu.Unlock()
+
+		reason := jsAbortError(vm)
+		for _, handler := range handlers {
+			_, _ = handler(goja.Undefined(), reason)
+		}
+		for _, t := range timers {
+			_, _ = t.clear(goja.Undefined(), t.handle)
+		}
+		for _, reject := range pending {
+			reject(vm, reason)
+		}
+	})
+}
+
+// jsAbortError builds the AbortError value used to reject cancelled work.
+func jsAbortError(vm *goja.Runtime) goja.Value {
+	obj := vm.NewGoError(fmt.Errorf("job cancelled"))
+	_ = obj.Set("name", "AbortError")
+	return obj
+}
+
+// awaitScriptResult reports the script outcome on done. When the script
+// evaluates to a promise, e.g. a trailing `main()` call on an async function,
+// the outcome is deferred until the promise settles.
+func awaitScriptResult(vm *goja.Runtime, value goja.Value, done chan<- error) {
+	promise, ok := value.Export().(*goja.Promise)
+	if !ok {
+		done <- nil
+		return
+	}
+	switch promise.State() {
+	case goja.PromiseStateFulfilled:
+		done <- nil
+		return
+	case goja.PromiseStateRejected:
+		done <- jsRejectionError(promise.Result())
+		return
+	}
+
+	obj := value.ToObject(vm)
+	then, ok := goja.AssertFunction(obj.Get("then"))
+	if !ok {
+		done <- nil
+		return
+	}
+	_, err := then(obj,
+		vm.ToValue(func(goja.Value) { done <- nil }),
+		vm.ToValue(func(reason goja.Value) { done <- jsRejectionError(reason) }),
+	)
+	if err != nil {
+		done <- err
+	}
+}
+
+func jsRejectionError(reason goja.Value) error {
+	if reason == nil {
+		return fmt.Errorf("promise rejected")
+	}
+	if err, ok := reason.Export().(error); ok {
+		return err
+	}
+	return fmt.Errorf("promise rejected: %s", reason.String())
+}
@@ -0,0 +1,114 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsEvents struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *jsEvents) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *jsEvents) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func TestJSEngineAwaitsReturnedPromise(t *testing.T) {
+	events := &jsEvents{}
+	err := NewJSRunner().Execute(context.Background(), &ExecutionMessage{
+		JobID:      "async",
+		ScriptPath: "async.js",
+		Parameters: map[string]any{
+			"record": events.record,
+			"script": `async function main() { await job.sleep(10); record("done"); } main();`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"done"}, events.list())
+
+	err = NewJSRunner().Execute(context.Background(), &ExecutionMessage{
+		JobID:      "async",
+		ScriptPath: "async.js",
+		Parameters: map[string]any{"script": `async function main() { throw new Error("boom"); } main();`},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script execution failed")
+}
+
+func TestJSEngineCancellationRunsFinallyBlocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	events := &jsEvents{}
+	script := `
+job.onCancel(function (reason) { record("onCancel:" + reason.name); });
+setInterval(function () { record("tick"); }, 5000);
+
+async function main() {
+  try {
+    await job.sleep(10000);
+  } catch (err) {
+    record("sleep:" + err.name);
+  }
+  try {
+    await fetch(url);
+  } catch (err) {
+    record("fetch:" + err.name);
+  } finally {
+    record("cleanup:" + job.cancelled());
+  }
+}
+main();
+`
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	err := NewJSRunner().Execute(ctx, &ExecutionMessage{
+		JobID:      "cancel",
+		ScriptPath: "cancel.js",
+		Parameters: map[string]any{"record": events.record, "url": server.URL, "script": script},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Equal(t, []string{
+		"onCancel:AbortError",
+		"sleep:AbortError",
+		"fetch:AbortError",
+		"cleanup:true",
+	}, events.list())
+}
+
+func TestJSEngineCancelGraceZeroTerminatesImmediately(t *testing.T) {
+	events := &jsEvents{}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := NewJSRunner(WithJSCancelGrace(0)).Execute(ctx, &ExecutionMessage{
+		JobID:      "cancel",
+		ScriptPath: "cancel.js",
+		Parameters: map[string]any{
+			"record": events.record,
+			"script": `async function main() { try { await job.sleep(10000); } finally { record("cleanup"); } } main();`,
+		},
+	})
+	require.Error(t, err)
+	assert.Empty(t, events.list())
+}
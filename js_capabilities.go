@@ -0,0 +1,211 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/goliatone/go-errors"
+)
+
+// CapabilitiesMetadataKey declares the runtime bindings a JS job may use,
+// e.g. `capabilities: [fetch, secrets]`. Jobs without the key keep every
+// binding; an empty list exposes none.
+const CapabilitiesMetadataKey = "capabilities"
+
+// JSCapability names a group of bindings exposed to JS jobs.
+type JSCapability string
+
+const (
+	// JSCapabilityFetch exposes the global fetch function.
+	JSCapabilityFetch JSCapability = "fetch"
+	// JSCapabilityFS allows require() of file modules and exposes job.workspace.
+	JSCapabilityFS JSCapability = "fs"
+	// JSCapabilitySQL exposes job.query and job.exec against the task's
+	// connection profile or driver/dsn metadata.
+	JSCapabilitySQL JSCapability = "sql"
+	// JSCapabilitySecrets exposes job.secret.
+	JSCapabilitySecrets JSCapability = "secrets"
+	// JSCapabilityProcess exposes the process global, including process.env.
+	JSCapabilityProcess JSCapability = "process"
+	// JSCapabilityJobs exposes jobs.run when job invocation is enabled.
+	JSCapabilityJobs JSCapability = "jobs"
+)
+
+var knownJSCapabilities = map[JSCapability]bool{
+	JSCapabilityFetch:   true,
+	JSCapabilityFS:      true,
+	JSCapabilitySQL:     true,
+	JSCapabilitySecrets: true,
+	JSCapabilityProcess: true,
+	JSCapabilityJobs:    true,
+}
+
+// jsCapabilitySet is the resolved capability declaration of one job. A nil
+// allowed map means the job declared nothing and every capability is granted.
+type jsCapabilitySet struct {
+	allowed map[JSCapability]bool
+}
+
+func (s jsCapabilitySet) allows(c JSCapability) bool {
+	return s.allowed == nil || s.allowed[c]
+}
+
+// JSCapabilitiesFromConfig returns the capabilities declared in cfg, sorted.
+// declared is false when the job has no capabilities key.
+func JSCapabilitiesFromConfig(cfg Config) (caps []JSCapability, declared bool, err error) {
+	set, err := jsCapabilitiesFromConfig(cfg)
+	if err != nil || set.allowed == nil {
+		return nil, false, err
+	}
+	for c := range set.allowed {
+		caps = append(caps, c)
+	}
+	sort.Slice(caps, func(i, j int) bool { return caps[i] < caps[j] })
+	return caps, true, nil
+}
+
+func jsCapabilitiesFromConfig(cfg Config) (jsCapabilitySet, error) {
+	raw, ok := cfg.Metadata[CapabilitiesMetadataKey]
+	if !ok || raw == nil {
+		return jsCapabilitySet{}, nil
+	}
+
+	set := jsCapabilitySet{allowed: map[JSCapability]bool{}}
+	var unknown []string
+	for _, name := range stringList(raw) {
+		c := JSCapability(strings.ToLower(strings.TrimSpace(name)))
+		if c == "" {
+			continue
+		}
+		if !knownJSCapabilities[c] {
+			unknown = append(unknown, name)
+			continue
+		}
+		set.allowed[c] = true
+	}
+	if len(unknown) > 0 {
+		return jsCapabilitySet{}, errors.New(fmt.Sprintf("unknown capabilities: %s", strings.Join(unknown, ", ")), errors.CategoryValidation).
+			WithTextCode("JS_UNKNOWN_CAPABILITY").
+			WithMetadata(map[string]any{"capabilities": unknown})
+	}
+	return set, nil
+}
+
+// jsSQLConn lazily opens the database used by job.query and job.exec and
+// closes it when the execution ends.
+type jsSQLConn struct {
+	engine *JSEngine
+	cfg    Config
+
+	mu sync.Mutex
+	db *sql.DB
+}
+
+func (c *jsSQLConn) open(ctx context.Context) (*sql.DB, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db != nil {
+		return c.db, nil
+	}
+
+	driverName, dataSourceName := "", ""
+	profile, ok, err := resolveProfile(ctx, c.engine.profiles, c.engine.secrets, c.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		driverName, dataSourceName = profile.Driver, profile.DSN
+	}
+	if driver, ok := c.cfg.Metadata["driver"].(string); ok {
+		driverName = driver
+	}
+	if dsn, ok := c.cfg.Metadata["dsn"].(string); ok {
+		dataSourceName = dsn
+	}
+	if driverName == "" || dataSourceName == "" {
+		return nil, fmt.Errorf("database connection details not provided")
+	}
+	if dataSourceName, err = ResolveSecretRefs(ctx, c.engine.secrets, dataSourceName); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	c.db = db
+	return db, nil
+}
+
+func (c *jsSQLConn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db != nil {
+		c.db.Close()
+		c.db = nil
+	}
+}
+
+// bind adds job.query(sql, ...args), returning rows as objects, and
+// job.exec(sql, ...args), returning {rowsAffected}.
+func (c *jsSQLConn) bind(ctx context.Context, binding *goja.Object) error {
+	if err := binding.Set("query", func(statement string, args ...any) ([]map[string]any, error) {
+		db, err := c.open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := db.QueryContext(ctx, statement, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		return scanJSRows(rows)
+	}); err != nil {
+		return err
+	}
+	return binding.Set("exec", func(statement string, args ...any) (map[string]any, error) {
+		db, err := c.open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res, err := db.ExecContext(ctx, statement, args...)
+		if err != nil {
+			return nil, err
+		}
+		affected, _ := res.RowsAffected()
+		return map[string]any{"rowsAffected": affected}, nil
+	})
+}
+
+func scanJSRows(rows *sql.Rows) ([]map[string]any, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	out := []map[string]any{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+				continue
+			}
+			row[column] = values[i]
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
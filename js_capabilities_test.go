@@ -0,0 +1,114 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const probeBindingsScript = `
+record("fetch:" + typeof fetch);
+record("process:" + typeof process);
+record("secret:" + typeof job.secret);
+record("query:" + typeof job.query);
+try { require("./lib.js"); record("require:ok"); } catch (e) { record("require:denied"); }
+`
+
+func runCapabilityProbe(t *testing.T, metadata map[string]any) []string {
+	t.Helper()
+	events := &jsEvents{}
+	engine := NewJSRunner(WithJSModuleLoader(func(string) ([]byte, error) {
+		return []byte("module.exports = {};"), nil
+	}))
+	err := engine.Execute(context.Background(), &ExecutionMessage{
+		JobID:      "probe",
+		ScriptPath: "probe.js",
+		Config:     Config{Metadata: metadata},
+		Parameters: map[string]any{"record": events.record, "script": probeBindingsScript},
+	})
+	require.NoError(t, err)
+	return events.list()
+}
+
+func TestJSCapabilitiesDefaultToEverything(t *testing.T) {
+	assert.Equal(t, []string{
+		"fetch:function",
+		"process:object",
+		"secret:function",
+		"query:function",
+		"require:ok",
+	}, runCapabilityProbe(t, nil))
+}
+
+func TestJSCapabilitiesLimitBindings(t *testing.T) {
+	assert.Equal(t, []string{
+		"fetch:undefined",
+		"process:undefined",
+		"secret:function",
+		"query:undefined",
+		"require:denied",
+	}, runCapabilityProbe(t, map[string]any{CapabilitiesMetadataKey: []any{"secrets"}}))
+
+	assert.Equal(t, []string{
+		"fetch:function",
+		"process:undefined",
+		"secret:undefined",
+		"query:undefined",
+		"require:ok",
+	}, runCapabilityProbe(t, map[string]any{CapabilitiesMetadataKey: "fetch, fs"}))
+}
+
+func TestJSCapabilitiesRejectUnknown(t *testing.T) {
+	cfg := Config{Metadata: map[string]any{CapabilitiesMetadataKey: []any{"fetch", "net"}}}
+
+	_, _, err := JSCapabilitiesFromConfig(cfg)
+	require.Error(t, err)
+	var typed *errors.Error
+	require.True(t, errors.As(err, &typed))
+	assert.Equal(t, "JS_UNKNOWN_CAPABILITY", typed.TextCode)
+
+	err = NewJSRunner().Execute(context.Background(), &ExecutionMessage{
+		JobID:      "bad",
+		ScriptPath: "bad.js",
+		Config:     cfg,
+		Parameters: map[string]any{"script": "1"},
+	})
+	assert.Error(t, err)
+
+	issues := (&lintConfig{}).checkConfig(cfg)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "invalid_capabilities", issues[0].Code)
+	assert.Equal(t, "unknown capabilities: net", issues[0].Message)
+
+	caps, declared, err := JSCapabilitiesFromConfig(Config{Metadata: map[string]any{CapabilitiesMetadataKey: []any{"sql", "fetch"}}})
+	require.NoError(t, err)
+	assert.True(t, declared)
+	assert.Equal(t, []JSCapability{JSCapabilityFetch, JSCapabilitySQL}, caps)
+}
+
+func TestJSCapabilitySQLBinding(t *testing.T) {
+	events := &jsEvents{}
+	script := `
+job.exec("CREATE TABLE items (id INTEGER, name TEXT)");
+var res = job.exec("INSERT INTO items VALUES (?, ?), (?, ?)", 1, "a", 2, "b");
+record("affected:" + res.rowsAffected);
+var rows = job.query("SELECT id, name FROM items WHERE id > ? ORDER BY id", 0);
+rows.forEach(function (row) { record(row.id + "=" + row.name); });
+`
+	err := NewJSRunner().Execute(context.Background(), &ExecutionMessage{
+		JobID:      "sql",
+		ScriptPath: "sql.js",
+		Config: Config{Metadata: map[string]any{
+			CapabilitiesMetadataKey: []any{"sql"},
+			"driver":                "sqlite3",
+			"dsn":                   "file:jscaps?mode=memory&cache=shared",
+		}},
+		Parameters: map[string]any{"record": events.record, "script": script},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"affected:2", "1=a", "2=b"}, events.list())
+}
@@ -82,8 +82,8 @@ func (b *jsBody) arrayBuffer() func() *goja.Promise {
 	}
 }
 
-func (e *JSEngine) setupFetch(ctx context.Context, vm *goja.Runtime) error {
-	return SetupFetchWithContext(ctx, vm)
+func (e *JSEngine) setupFetch(ctx context.Context, vm *goja.Runtime, scope *jsCancelScope) error {
+	return installFetch(ctx, vm, scope)
 }
 
 // SetupFetch preserves the previous public API and wires fetch to a background context.
@@ -94,6 +94,13 @@ func SetupFetch(vm *goja.Runtime) error {
 // SetupFetchWithContext binds a fetch implementation to the provided context so requests
 // are cancelled when the parent execution context is done.
 func SetupFetchWithContext(ctx context.Context, vm *goja.Runtime) error {
+	return installFetch(ctx, vm, nil)
+}
+
+// installFetch defines fetch on vm. With a cancel scope, responses are
+// delivered on the event loop and outstanding requests are rejected with an
+// AbortError when the execution is cancelled.
+func installFetch(ctx context.Context, vm *goja.Runtime, scope *jsCancelScope) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -189,14 +196,33 @@ func SetupFetchWithContext(ctx context.Context, vm *goja.Runtime) error {
 			}
 		}
 
+		if scope == nil {
+			go func() {
+				resp, err := executeFetch(ctx, urlStr, options)
+				if err != nil {
+					reject(vm.NewGoError(err))
+					return
+				}
+				jsResp := createJSResponse(vm, resp)
+				resolve(jsResp)
+			}()
+			return vm.ToValue(promise)
+		}
+
+		if scope.isCancelled() {
+			reject(jsAbortError(vm))
+			return vm.ToValue(promise)
+		}
+		id := scope.track(func(_ *goja.Runtime, reason goja.Value) { reject(reason) })
 		go func() {
 			resp, err := executeFetch(ctx, urlStr, options)
-			if err != nil {
-				reject(vm.NewGoError(err))
-				return
-			}
-			jsResp := createJSResponse(vm, resp)
-			resolve(jsResp)
+			scope.settle(id, func(vm *goja.Runtime) {
+				if err != nil {
+					reject(vm.NewGoError(err))
+					return
+				}
+				resolve(createJSResponse(vm, resp))
+			})
 		}()
 
 		return vm.ToValue(promise)
@@ -285,6 +311,10 @@ func executeFetch(ctx context.Context, url string, options FetchOptions) (*Fetch
 			})
 	}
 
+	if hint, ok := HTTPRetryHint(httpResp.StatusCode, httpResp.Header); ok {
+		observeRetryHint(ctx, hint)
+	}
+
 	headers := make(map[string][]string)
 	for k, v := range httpResp.Header {
 		if len(v) > 0 {
@@ -40,6 +40,16 @@ func WithJSTimeout(timeout time.Duration) JSOption {
 	}
 }
 
+// WithJSCancelGrace sets how long a cancelled script may run cleanup code
+// before its event loop is terminated. Zero terminates immediately.
+func WithJSCancelGrace(grace time.Duration) JSOption {
+	return func(e *JSEngine) {
+		if grace >= 0 {
+			e.cancelGrace = grace
+		}
+	}
+}
+
 // WithJSMetadataParser sets a custom metadata parser
 func WithJSMetadataParser(parser MetadataParser) JSOption {
 	return func(e *JSEngine) {
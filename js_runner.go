@@ -23,12 +23,14 @@ type JSEngine struct {
 	moduleLoader func(path string) ([]byte, error)
 	panicHandler func(funcName string, fields ...map[string]any)
 	pathResolver func(base, path string) string
+	cancelGrace  time.Duration
 }
 
 func NewJSRunner(opts ...JSOption) *JSEngine {
 	e := &JSEngine{
 		moduleLoader: require.DefaultSourceLoader,
 		pathResolver: require.DefaultPathResolver,
+		cancelGrace:  DefaultJSCancelGrace,
 	}
 	e.BaseEngine = NewBaseEngine(e, "javascript", ".js")
 
@@ -45,6 +47,15 @@ func NewJSRunner(opts ...JSOption) *JSEngine {
 	return e
 }
 
+// reportPanic hands a recovered panic to the configured panic handler, which
+// expects to recover it itself.
+func (e *JSEngine) reportPanic(recovered any, msg *ExecutionMessage) {
+	defer e.panicHandler("JSEngine.Execute", map[string]any{
+		"scriptPath": msg.ScriptPath,
+	})
+	panic(recovered)
+}
+
 // SetTaskIDProvider overrides the ID derivation strategy for tasks parsed by the JS engine.
 func (e *JSEngine) SetTaskIDProvider(provider TaskIDProvider) {
 	if e.BaseEngine != nil {
@@ -53,18 +64,15 @@ func (e *JSEngine) SetTaskIDProvider(provider TaskIDProvider) {
 }
 
 // Execute runs a JavaScript file in a Node-like environment using goja_nodejs' eventloop.
-func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
-	defer e.panicHandler("JSEngine.Execute", map[string]any{
-		"scriptPath": msg.ScriptPath,
-	})
+func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(msg.JobID, recovered)
+			e.reportPanic(recovered, msg)
+		}
+	}()
 
-	logger := e.logger
-	if fl, ok := logger.(FieldsLogger); ok {
-		logger = fl.WithFields(map[string]any{
-			"engine":      e.EngineType,
-			"script_path": msg.ScriptPath,
-		})
-	}
+	logger := e.executionLogger(ctx, msg)
 
 	scriptContent, err := e.GetScriptContent(msg)
 	if err != nil {
@@ -84,36 +92,85 @@ func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 		}
 	}()
 
-	execCtx, cancel := e.GetExecutionContext(ctx)
+	execCtx, cancel := e.GetMessageExecutionContext(ctx, msg)
 	defer cancel()
 
+	execCtx, hints := contextWithRetryHintRecorder(execCtx)
+	defer func() { err = hints.wrap(err) }()
+
+	env, err := e.resolveEnv(execCtx, msg)
+	if err != nil {
+		execErr = err
+		return execErr
+	}
+	scriptMsg := *msg
+	scriptMsg.Config.Env = env
+
+	caps, err := jsCapabilitiesFromConfig(msg.Config)
+	if err != nil {
+		execErr = err
+		return execErr
+	}
+	loader := e.moduleLoader
+	if !caps.allows(JSCapabilityFS) {
+		loader = func(path string) ([]byte, error) {
+			return nil, fmt.Errorf("require %q: job does not declare the %q capability", path, JSCapabilityFS)
+		}
+	}
+	sqlConn := &jsSQLConn{engine: e, cfg: msg.Config}
+	defer sqlConn.close()
+
 	// Create a custom require registry that knows how to load modules
 	registry := require.NewRegistry(
-		require.WithLoader(e.moduleLoader),
+		require.WithLoader(loader),
 		// require.WithGlobalFolders(),
 	)
+	registry.RegisterNativeModule(console.ModuleName, console.RequireWithPrinter(jsConsolePrinter{ctx: ctx, logger: logger}))
 
 	loop := eventloop.NewEventLoop(
 		eventloop.WithRegistry(registry),
 		// eventloop.EnableConsole(true),
 	)
 
+	scope := newJSCancelScope(loop)
+
 	loop.Start()
 	defer loop.StopNoWait()
 
 	configErrCh := make(chan error, 1)
 	ok := loop.RunOnLoop(func(vm *goja.Runtime) {
-		process.Enable(vm)
+		if caps.allows(JSCapabilityProcess) {
+			process.Enable(vm)
+		}
 		url.Enable(vm)
 		buffer.Enable(vm)
 		console.Enable(vm)
 
-		if ferr := e.setupFetch(execCtx, vm); ferr != nil {
+		if ferr := scope.wrapTimers(vm); ferr != nil {
 			configErrCh <- ferr
 			return
 		}
 
-		if ferr := e.configureScriptEnvironment(vm, msg); ferr != nil {
+		if caps.allows(JSCapabilityFetch) {
+			if ferr := e.setupFetch(execCtx, vm, scope); ferr != nil {
+				configErrCh <- ferr
+				return
+			}
+		}
+
+		if ferr := e.setupJobBinding(execCtx, vm, scope, caps, sqlConn); ferr != nil {
+			configErrCh <- ferr
+			return
+		}
+
+		if e.invoker != nil && caps.allows(JSCapabilityJobs) {
+			if ferr := setupJobsBinding(execCtx, vm, scope, e.invoker); ferr != nil {
+				configErrCh <- ferr
+				return
+			}
+		}
+
+		if ferr := e.configureScriptEnvironment(vm, &scriptMsg); ferr != nil {
 			configErrCh <- ferr
 			return
 		}
@@ -145,8 +202,12 @@ func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 
 	execErrCh := make(chan error, 1)
 	ok = loop.RunOnLoop(func(vm *goja.Runtime) {
-		_, runErr := vm.RunScript(msg.ScriptPath, scriptContent)
-		execErrCh <- runErr
+		value, runErr := vm.RunScript(msg.ScriptPath, scriptContent)
+		if runErr != nil {
+			execErrCh <- runErr
+			return
+		}
+		awaitScriptResult(vm, value, execErrCh)
 	})
 
 	if !ok {
@@ -175,6 +236,7 @@ func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 		execErr = nil
 		return nil
 	case <-execCtx.Done():
+		e.unwindScript(scope, execErrCh)
 		loop.Terminate()
 		execErr = errors.Wrap(execCtx.Err(), errors.CategoryExternal, "script execution timed out").
 			WithTextCode("JS_EXECUTION_TIMEOUT").
@@ -187,6 +249,84 @@ func (e *JSEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 	}
 }
 
+// unwindScript cancels pending timers, fetches and sleeps, runs job.onCancel
+// handlers, and gives the script the cancel grace period to settle so its
+// finally blocks can run before the loop is terminated.
+func (e *JSEngine) unwindScript(scope *jsCancelScope, done <-chan error) {
+	if e.cancelGrace <= 0 || !scope.cancel() {
+		return
+	}
+	timer := time.NewTimer(e.cancelGrace)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
+
+// jsConsolePrinter routes console output through the execution logger so it is
+// tagged with the run fields instead of going straight to stdout. Logged
+// ResultLinePrefix lines report the run's Result.
+type jsConsolePrinter struct {
+	ctx    context.Context
+	logger Logger
+}
+
+func (p jsConsolePrinter) Log(s string) {
+	result, ok, err := ParseResultLine(s)
+	switch {
+	case err != nil:
+		p.logger.Warn("ignoring malformed script result", "error", err)
+	case ok:
+		ReportResult(p.ctx, result)
+		return
+	}
+	p.logger.Info("js console", "line", s)
+}
+
+func (p jsConsolePrinter) Warn(s string)  { p.logger.Warn("js console", "line", s) }
+func (p jsConsolePrinter) Error(s string) { p.logger.Error("js console", "line", s) }
+
+// setupJobBinding exposes the `job` global with runtime helpers such as
+// job.heartbeat() and the cancellation helpers job.onCancel(fn),
+// job.cancelled() and job.sleep(ms). Capability-gated helpers are added when
+// the job may use them: job.secret(name), job.query/job.exec and, inside a run
+// workspace, job.workspace.
+func (e *JSEngine) setupJobBinding(ctx context.Context, vm *goja.Runtime, scope *jsCancelScope, caps jsCapabilitySet, conn *jsSQLConn) error {
+	binding := vm.NewObject()
+	if err := scope.bind(vm, binding); err != nil {
+		return err
+	}
+	if err := binding.Set("heartbeat", func() { Heartbeat(ctx) }); err != nil {
+		return err
+	}
+	if caps.allows(JSCapabilitySecrets) {
+		if err := binding.Set("secret", func(ref string) (string, error) {
+			if e.secrets == nil {
+				return "", fmt.Errorf("no secrets provider configured")
+			}
+			secret, err := e.secrets.GetSecret(ctx, ref)
+			return secret.Value, err
+		}); err != nil {
+			return err
+		}
+	}
+	if caps.allows(JSCapabilitySQL) {
+		if err := conn.bind(ctx, binding); err != nil {
+			return err
+		}
+	}
+	if dir, ok := WorkspaceFromContext(ctx); ok && caps.allows(JSCapabilityFS) {
+		if err := binding.Set("workspace", dir); err != nil {
+			return err
+		}
+		if err := vm.Set(WorkspaceEnvVar, dir); err != nil {
+			return err
+		}
+	}
+	return vm.Set("job", binding)
+}
+
 func (e *JSEngine) configureScriptEnvironment(vm *goja.Runtime, msg *ExecutionMessage) error {
 	scriptDir := filepath.Dir(msg.ScriptPath)
 	if err := vm.Set("__dirname", scriptDir); err != nil {
@@ -208,6 +348,39 @@ func (e *JSEngine) configureScriptEnvironment(vm *goja.Runtime, msg *ExecutionMe
 			})
 	}
 
+	if err := vm.Set(TraceIDEnvVar, msg.TraceID); err != nil {
+		return errors.Wrap(err, errors.CategoryInternal, "failed to set trace id").
+			WithTextCode("JS_SET_TRACE_ID_ERROR").
+			WithMetadata(map[string]any{
+				"operation":   "set_trace_id",
+				"script_path": msg.ScriptPath,
+			})
+	}
+
+	if err := vm.Set(AttemptEnvVar, msg.Attempt); err != nil {
+		return errors.Wrap(err, errors.CategoryInternal, "failed to set attempt").
+			WithTextCode("JS_SET_ATTEMPT_ERROR").
+			WithMetadata(map[string]any{
+				"operation":   "set_attempt",
+				"script_path": msg.ScriptPath,
+			})
+	}
+
+	// Set before the raw parameters so a parameter named "params" keeps
+	// shadowing the accessor, as it did before the accessor existed.
+	params, err := bindJSParams(vm, ParamsFrom(msg))
+	if err == nil {
+		err = vm.Set("params", params)
+	}
+	if err != nil {
+		return errors.Wrap(err, errors.CategoryInternal, "failed to set params accessor").
+			WithTextCode("JS_SET_PARAMETER_ERROR").
+			WithMetadata(map[string]any{
+				"operation":   "set_params_accessor",
+				"script_path": msg.ScriptPath,
+			})
+	}
+
 	if msg.Parameters != nil {
 		for k, v := range msg.Parameters {
 			if k == "script" {
@@ -0,0 +1,312 @@
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const kubeCronJobNameLimit = 52
+
+// CronJobOptions controls how tasks are rendered as Kubernetes CronJobs.
+type CronJobOptions struct {
+	Image     string
+	Namespace string
+	// Command is the container command; "{{id}}", "{{path}}" and "{{file}}"
+	// are replaced per task. When empty, shell tasks run "/bin/sh {{path}}",
+	// python tasks "python3 {{path}}", and other engines fail, since their
+	// runtime lives in-process.
+	Command            []string
+	ServiceAccountName string
+	TimeZone           string
+	// SecretName maps env values written as `${secret:KEY}` to a secretKeyRef
+	// on this Kubernetes Secret. Without it such values are rejected rather
+	// than rendered.
+	SecretName string
+	Labels     map[string]string
+	// RestartPolicy defaults to "Never"; retries map to backoffLimit.
+	RestartPolicy string
+}
+
+// CronJobManifest is a batch/v1 CronJob, trimmed to the fields the generator sets.
+type CronJobManifest struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   kubeObjectMeta  `yaml:"metadata"`
+	Spec       kubeCronJobSpec `yaml:"spec"`
+}
+
+type kubeObjectMeta struct {
+	Name        string            `yaml:"name,omitempty"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type kubeCronJobSpec struct {
+	Schedule          string          `yaml:"schedule"`
+	TimeZone          string          `yaml:"timeZone,omitempty"`
+	ConcurrencyPolicy string          `yaml:"concurrencyPolicy"`
+	JobTemplate       kubeJobTemplate `yaml:"jobTemplate"`
+}
+
+type kubeJobTemplate struct {
+	Spec kubeJobSpec `yaml:"spec"`
+}
+
+type kubeJobSpec struct {
+	BackoffLimit          int             `yaml:"backoffLimit"`
+	ActiveDeadlineSeconds int64           `yaml:"activeDeadlineSeconds,omitempty"`
+	Template              kubePodTemplate `yaml:"template"`
+}
+
+type kubePodTemplate struct {
+	Metadata kubeObjectMeta `yaml:"metadata"`
+	Spec     kubePodSpec    `yaml:"spec"`
+}
+
+type kubePodSpec struct {
+	ServiceAccountName string            `yaml:"serviceAccountName,omitempty"`
+	NodeSelector       map[string]string `yaml:"nodeSelector,omitempty"`
+	RestartPolicy      string            `yaml:"restartPolicy"`
+	Containers         []kubeContainer   `yaml:"containers"`
+}
+
+type kubeContainer struct {
+	Name    string       `yaml:"name"`
+	Image   string       `yaml:"image"`
+	Command []string     `yaml:"command"`
+	Env     []kubeEnvVar `yaml:"env,omitempty"`
+}
+
+type kubeEnvVar struct {
+	Name      string            `yaml:"name"`
+	Value     string            `yaml:"value,omitempty"`
+	ValueFrom *kubeEnvVarSource `yaml:"valueFrom,omitempty"`
+}
+
+type kubeEnvVarSource struct {
+	SecretKeyRef kubeSecretKeyRef `yaml:"secretKeyRef"`
+}
+
+type kubeSecretKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+var (
+	kubeNameUnsafe   = regexp.MustCompile(`[^a-z0-9-]+`)
+	kubeWholeSecret  = regexp.MustCompile(`^\$\{secret:([^}]+)\}$`)
+	kubeSecretPrefix = "${secret:"
+)
+
+// CronJobManifests renders one CronJob per task, sorted by task ID. Timeouts
+// become activeDeadlineSeconds, retries backoffLimit, affinity the pod's
+// nodeSelector, and max_concurrency 1 the Forbid concurrency policy.
+func CronJobManifests(tasks []Task, opts CronJobOptions) ([]CronJobManifest, error) {
+	if opts.Image == "" {
+		return nil, fmt.Errorf("cronjob image is required")
+	}
+	sorted := append([]Task(nil), tasks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetID() < sorted[j].GetID() })
+
+	manifests := make([]CronJobManifest, 0, len(sorted))
+	for _, task := range sorted {
+		manifest, err := cronJobManifest(task.GetID(), task, task.GetConfig(), opts)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// ScheduleCronJobManifests renders CronManager schedules, resolving each
+// definition's task in registry and layering its message config on top.
+func ScheduleCronJobManifests(registry Registry, defs []ScheduleDefinition, opts CronJobOptions) ([]CronJobManifest, error) {
+	if opts.Image == "" {
+		return nil, fmt.Errorf("cronjob image is required")
+	}
+	sorted := append([]ScheduleDefinition(nil), defs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	manifests := make([]CronJobManifest, 0, len(sorted))
+	for _, def := range sorted {
+		task, ok := registry.Get(def.Message.JobID)
+		if !ok || task == nil {
+			return nil, fmt.Errorf("schedule %s: task %q not found", def.ID, def.Message.JobID)
+		}
+		cfg := mergeConfigDefaults(task.GetConfig(), def.Message.Config)
+		if def.Expression != "" {
+			cfg.Schedule = def.Expression
+		}
+		manifest, err := cronJobManifest(def.ID, task, cfg, opts)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// WriteCronJobManifests writes manifests as a multi-document YAML stream.
+func WriteCronJobManifests(w io.Writer, manifests []CronJobManifest) error {
+	for i, manifest := range manifests {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		out, err := yaml.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to encode cronjob %s: %w", manifest.Metadata.Name, err)
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cronJobManifest(id string, task Task, cfg Config, opts CronJobOptions) (CronJobManifest, error) {
+	schedule := cfg.Schedule
+	if schedule == "" {
+		schedule = task.GetHandlerConfig().Expression
+	}
+	if schedule == "" {
+		schedule = DefaultSchedule
+	}
+	schedule, err := ExpandSchedule(schedule)
+	if err != nil {
+		return CronJobManifest{}, fmt.Errorf("task %s: %w", id, err)
+	}
+	if strings.HasPrefix(schedule, "@every") || (!strings.HasPrefix(schedule, "@") && len(strings.Fields(schedule)) != 5) {
+		return CronJobManifest{}, fmt.Errorf("task %s: schedule %q is not supported by Kubernetes CronJobs", id, schedule)
+	}
+
+	command, err := cronJobCommand(id, task, opts.Command)
+	if err != nil {
+		return CronJobManifest{}, err
+	}
+	env, err := cronJobEnv(id, cfg.Env, opts.SecretName)
+	if err != nil {
+		return CronJobManifest{}, err
+	}
+
+	name := kubeName(id)
+	labels := map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/managed-by": "go-job",
+	}
+	for key, value := range opts.Labels {
+		labels[key] = value
+	}
+	restart := opts.RestartPolicy
+	if restart == "" {
+		restart = "Never"
+	}
+	concurrency := "Allow"
+	if cfg.MaxConcurrency == 1 {
+		concurrency = "Forbid"
+	}
+
+	manifest := CronJobManifest{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Metadata: kubeObjectMeta{
+			Name:        name,
+			Namespace:   opts.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{"go-job/task-id": task.GetID(), "go-job/script-path": task.GetPath()},
+		},
+		Spec: kubeCronJobSpec{
+			Schedule:          schedule,
+			TimeZone:          opts.TimeZone,
+			ConcurrencyPolicy: concurrency,
+			JobTemplate: kubeJobTemplate{Spec: kubeJobSpec{
+				BackoffLimit: cfg.Retries,
+				Template: kubePodTemplate{
+					Metadata: kubeObjectMeta{Labels: labels},
+					Spec: kubePodSpec{
+						ServiceAccountName: opts.ServiceAccountName,
+						NodeSelector:       cfg.Affinity,
+						RestartPolicy:      restart,
+						Containers: []kubeContainer{{
+							Name:    "job",
+							Image:   opts.Image,
+							Command: command,
+							Env:     env,
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if !cfg.NoTimeout && cfg.Timeout > 0 {
+		manifest.Spec.JobTemplate.Spec.ActiveDeadlineSeconds = int64(cfg.Timeout.Seconds())
+	}
+	return manifest, nil
+}
+
+func cronJobCommand(id string, task Task, template []string) ([]string, error) {
+	if len(template) == 0 {
+		var name string
+		if engine := task.GetEngine(); engine != nil {
+			name = engine.Name()
+		}
+		switch name {
+		case "engine:shell":
+			template = []string{"/bin/sh", "{{path}}"}
+		case "engine:python":
+			template = []string{"python3", "{{path}}"}
+		default:
+			return nil, fmt.Errorf("task %s: set CronJobOptions.Command to run tasks outside the shell and python engines", id)
+		}
+	}
+	replacer := strings.NewReplacer("{{id}}", task.GetID(), "{{path}}", task.GetPath(), "{{file}}", filepath.Base(task.GetPath()))
+	command := make([]string, len(template))
+	for i, part := range template {
+		command[i] = replacer.Replace(part)
+	}
+	return command, nil
+}
+
+func cronJobEnv(id string, env map[string]string, secretName string) ([]kubeEnvVar, error) {
+	vars := make([]kubeEnvVar, 0, len(env))
+	for _, key := range sortedStringKeys(env) {
+		value := env[key]
+		if !strings.Contains(value, kubeSecretPrefix) {
+			vars = append(vars, kubeEnvVar{Name: key, Value: value})
+			continue
+		}
+		match := kubeWholeSecret.FindStringSubmatch(value)
+		if match == nil || secretName == "" {
+			return nil, fmt.Errorf("task %s: env %s references a secret; set CronJobOptions.SecretName and use a whole-value ${secret:KEY}", id, key)
+		}
+		vars = append(vars, kubeEnvVar{Name: key, ValueFrom: &kubeEnvVarSource{
+			SecretKeyRef: kubeSecretKeyRef{Name: secretName, Key: strings.TrimSpace(match[1])},
+		}})
+	}
+	return vars, nil
+}
+
+// kubeName converts a task ID into a DNS-1123 name within the CronJob limit,
+// hashing the ID into the suffix of truncated names to keep them distinct.
+func kubeName(id string) string {
+	name := strings.Trim(kubeNameUnsafe.ReplaceAllString(strings.ToLower(id), "-"), "-")
+	if len(name) > kubeCronJobNameLimit {
+		sum := sha256.Sum256([]byte(id))
+		name = strings.TrimRight(name[:kubeCronJobNameLimit-9], "-") + "-" + hex.EncodeToString(sum[:])[:8]
+	}
+	if name == "" {
+		name = "job"
+	}
+	return name
+}
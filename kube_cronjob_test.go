@@ -0,0 +1,106 @@
+package job_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCronJobManifests(t *testing.T) {
+	shell := job.NewShellRunner()
+	task, err := shell.ParseJob("/jobs/Nightly_Backup.sh", []byte(`# config
+# schedule: "30 2 * * *"
+# timeout: 10m
+# retries: 3
+# env:
+#   REGION: eu
+#   DB_PASSWORD: ${secret:db-password}
+
+pg_dump app`))
+	require.NoError(t, err)
+
+	manifests, err := job.CronJobManifests([]job.Task{task}, job.CronJobOptions{
+		Image:      "registry.example.com/jobs:1.2.0",
+		Namespace:  "batch",
+		SecretName: "job-secrets",
+	})
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, job.WriteCronJobManifests(&buf, manifests))
+
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "CronJob", doc["kind"])
+	out := buf.String()
+	assert.Contains(t, out, "name: nightly-backup-sh\n")
+	assert.Contains(t, out, "schedule: 30 2 * * *\n")
+	assert.Contains(t, out, "concurrencyPolicy: Allow\n")
+	assert.Contains(t, out, "backoffLimit: 3\n")
+	assert.Contains(t, out, "activeDeadlineSeconds: 600\n")
+	assert.Contains(t, out, "- /bin/sh\n")
+	assert.Contains(t, out, "- /jobs/Nightly_Backup.sh\n")
+	assert.Contains(t, out, "secretKeyRef:\n")
+	assert.Contains(t, out, "key: db-password\n")
+	assert.NotContains(t, out, "${secret:")
+}
+
+func TestCronJobManifestsRejectsUnsupported(t *testing.T) {
+	js := job.NewJSRunner()
+	task, err := js.ParseJob("/jobs/report.js", []byte("console.log('x')"))
+	require.NoError(t, err)
+
+	_, err = job.CronJobManifests([]job.Task{task}, job.CronJobOptions{Image: "img"})
+	assert.ErrorContains(t, err, "Command")
+
+	manifests, err := job.CronJobManifests([]job.Task{task}, job.CronJobOptions{Image: "img", Command: []string{"job-runner", "run", "{{id}}"}})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, job.WriteCronJobManifests(&buf, manifests))
+	assert.Contains(t, buf.String(), "- report.js\n")
+
+	every, err := js.ParseJob("/jobs/poll.js", []byte("// config\n// schedule: \"@every 5m\"\n\nconsole.log('x')"))
+	require.NoError(t, err)
+	_, err = job.CronJobManifests([]job.Task{every}, job.CronJobOptions{Image: "img", Command: []string{"run"}})
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestScheduleCronJobManifests(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	task, err := job.NewShellRunner().ParseJob("/jobs/sync.sh", []byte("echo sync"))
+	require.NoError(t, err)
+	require.NoError(t, registry.Add(task))
+
+	defs := []job.ScheduleDefinition{
+		{ID: "sync-eu", Expression: "0 * * * *", Message: job.ExecutionMessage{JobID: "sync.sh", Config: job.Config{MaxConcurrency: 1}}},
+		{ID: "sync-us", Expression: "30 * * * *", Message: job.ExecutionMessage{JobID: "sync.sh", Config: job.Config{Affinity: map[string]string{"region": "us"}}}},
+	}
+	manifests, err := job.ScheduleCronJobManifests(registry, defs, job.CronJobOptions{Image: "img"})
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+	assert.Equal(t, "sync-eu", manifests[0].Metadata.Name)
+	assert.Equal(t, "0 * * * *", manifests[0].Spec.Schedule)
+	assert.Equal(t, "Forbid", manifests[0].Spec.ConcurrencyPolicy)
+	assert.Equal(t, "30 * * * *", manifests[1].Spec.Schedule)
+	assert.Equal(t, "Allow", manifests[1].Spec.ConcurrencyPolicy)
+	assert.Empty(t, manifests[0].Spec.JobTemplate.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, map[string]string{"region": "us"}, manifests[1].Spec.JobTemplate.Spec.Template.Spec.NodeSelector)
+
+	_, err = job.ScheduleCronJobManifests(registry, []job.ScheduleDefinition{{ID: "x", Message: job.ExecutionMessage{JobID: "missing"}}}, job.CronJobOptions{Image: "img"})
+	assert.Error(t, err)
+}
+
+func TestCronJobManifestsPythonDefaultCommand(t *testing.T) {
+	task, err := job.NewPythonRunner().ParseJob("/jobs/etl.py", []byte("# config\n# schedule: \"0 3 * * *\"\n\nprint('etl')"))
+	require.NoError(t, err)
+
+	manifests, err := job.CronJobManifests([]job.Task{task}, job.CronJobOptions{Image: "img"})
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, []string{"python3", "/jobs/etl.py"}, manifests[0].Spec.JobTemplate.Spec.Template.Spec.Containers[0].Command)
+}
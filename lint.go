@@ -0,0 +1,250 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/goliatone/go-errors"
+)
+
+// LintSeverity ranks a lint finding.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a single finding produced by Lint.
+type LintIssue struct {
+	Severity   LintSeverity `json:"severity"`
+	Code       string       `json:"code"`
+	ScriptPath string       `json:"script_path"`
+	TaskID     string       `json:"task_id,omitempty"`
+	Message    string       `json:"message"`
+}
+
+// LintReport is the machine-readable outcome of Lint, suitable for CI.
+type LintReport struct {
+	Scripts  int         `json:"scripts"`
+	Errors   int         `json:"errors"`
+	Warnings int         `json:"warnings"`
+	Issues   []LintIssue `json:"issues"`
+}
+
+// OK reports whether the report has no errors. Warnings do not fail a lint.
+func (r LintReport) OK() bool {
+	return r.Errors == 0
+}
+
+func (r *LintReport) add(issue LintIssue) {
+	switch issue.Severity {
+	case LintError:
+		r.Errors++
+	case LintWarning:
+		r.Warnings++
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+// ScriptValidator is implemented by engines that can check script syntax
+// without executing it (e.g. JS compilation, SQL statement splitting).
+type ScriptValidator interface {
+	ValidateScript(path, content string) []LintIssue
+}
+
+// LintOption customises Lint.
+type LintOption func(*lintConfig)
+
+type lintConfig struct {
+	taskIDProvider TaskIDProvider
+	scheduler      []SchedulerOption
+}
+
+// WithLintTaskIDProvider lints IDs as the runner would derive them.
+func WithLintTaskIDProvider(provider TaskIDProvider) LintOption {
+	return func(c *lintConfig) {
+		c.taskIDProvider = provider
+	}
+}
+
+// WithLintSchedulerOptions configures cron parsing, e.g. WithSecondsPrecision.
+func WithLintSchedulerOptions(opts ...SchedulerOption) LintOption {
+	return func(c *lintConfig) {
+		c.scheduler = append(c.scheduler, opts...)
+	}
+}
+
+// Lint parses every script exposed by provider and validates engine matching,
+// metadata, cron expressions, timeouts, dedup policies, parameter declarations,
+// duplicate IDs and engine-specific syntax. It only returns an error when the
+// scripts cannot be listed; findings are reported in the LintReport.
+func Lint(ctx context.Context, provider SourceProvider, engines []Engine, opts ...LintOption) (LintReport, error) {
+	report := LintReport{Issues: []LintIssue{}}
+	cfg := &lintConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	scripts, err := provider.ListScripts(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list scripts: %w", err)
+	}
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Path < scripts[j].Path })
+
+	if cfg.taskIDProvider != nil {
+		for _, engine := range engines {
+			if aware, ok := engine.(TaskIDProviderAware); ok {
+				aware.SetTaskIDProvider(cfg.taskIDProvider)
+			}
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, script := range scripts {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Scripts++
+
+		engine, err := selectEngine(engines, script)
+		if err != nil {
+			report.add(LintIssue{Severity: LintWarning, Code: "no_engine", ScriptPath: script.Path,
+				Message: "no engine handles this file"})
+			continue
+		}
+
+		task, err := engine.ParseJob(script.Path, script.Content)
+		if err != nil || task == nil {
+			report.add(LintIssue{Severity: LintError, Code: "parse_error", ScriptPath: script.Path,
+				Message: fmt.Sprint(err)})
+			continue
+		}
+
+		id := task.GetID()
+		if previous, dup := seen[id]; dup {
+			report.add(LintIssue{Severity: LintError, Code: "duplicate_id", ScriptPath: script.Path, TaskID: id,
+				Message: fmt.Sprintf("task ID also used by %s", previous)})
+		} else {
+			seen[id] = script.Path
+		}
+
+		for _, issue := range cfg.checkConfig(task.GetConfig()) {
+			issue.ScriptPath, issue.TaskID = script.Path, id
+			report.add(issue)
+		}
+
+		if validator, ok := engine.(ScriptValidator); ok {
+			content := ""
+			if v, ok := task.(interface{ GetScriptContent() string }); ok {
+				content = v.GetScriptContent()
+			}
+			for _, issue := range validator.ValidateScript(script.Path, content) {
+				if issue.ScriptPath == "" {
+					issue.ScriptPath = script.Path
+				}
+				issue.TaskID = id
+				report.add(issue)
+			}
+		}
+	}
+	return report, nil
+}
+
+func (c *lintConfig) checkConfig(cfg Config) []LintIssue {
+	var issues []LintIssue
+	add := func(severity LintSeverity, code, format string, args ...any) {
+		issues = append(issues, LintIssue{Severity: severity, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if cfg.Schedule != "" {
+		if next, err := NextRun(cfg.Schedule, time.Now(), c.scheduler...); err != nil {
+			add(LintError, "invalid_schedule", "%v", err)
+		} else if after, err := NextRun(cfg.Schedule, next, c.scheduler...); err == nil && !cfg.NoTimeout && cfg.Timeout > after.Sub(next) {
+			text, _ := ExplainSchedule(cfg.Schedule, c.scheduler...)
+			add(LintWarning, "timeout_exceeds_interval", "timeout %s is longer than the schedule interval (%s), runs may overlap", cfg.Timeout, text)
+		}
+	}
+	if cfg.Timeout < 0 {
+		add(LintError, "invalid_timeout", "timeout must not be negative")
+	}
+	if cfg.NoTimeout && cfg.Timeout != 0 && cfg.Timeout != DefaultTimeout {
+		add(LintWarning, "timeout_ignored", "timeout %s is ignored because no_timeout is set", cfg.Timeout)
+	}
+	if cfg.WarnAfter != 0 && cfg.Timeout > 0 && !cfg.NoTimeout && cfg.WarnAfter >= cfg.Timeout {
+		add(LintWarning, "warn_after_exceeds_timeout", "warn_after %s is not shorter than timeout %s", cfg.WarnAfter, cfg.Timeout)
+	}
+	if cfg.Retries < 0 {
+		add(LintError, "invalid_retries", "retries must not be negative")
+	}
+	if cfg.MaxConcurrency < 0 {
+		add(LintError, "invalid_max_concurrency", "max_concurrency must not be negative")
+	}
+	if raw, ok := cfg.Metadata["dedup_policy"]; ok {
+		if _, err := parseDedupPolicy(raw); err != nil {
+			add(LintError, "invalid_dedup_policy", "%v", err)
+		}
+	}
+	if _, err := jsCapabilitiesFromConfig(cfg); err != nil {
+		add(LintError, "invalid_capabilities", "%s", lintMessage(err))
+	}
+	if raw, ok := cfg.Metadata["params"]; ok {
+		issues = append(issues, lintParamDeclarations(raw)...)
+	}
+	return issues
+}
+
+// lintParamDeclarations checks the declaration with the same rules
+// ParamSchemaFromConfig applies at run time.
+func lintParamDeclarations(raw any) []LintIssue {
+	_, err := ParamSchemaFromConfig(Config{Metadata: map[string]any{ParamsMetadataKey: raw}})
+	if err == nil {
+		return nil
+	}
+	return []LintIssue{{Severity: LintError, Code: "invalid_params", Message: lintMessage(err)}}
+}
+
+// lintMessage returns the bare message of a go-errors error, without the
+// category prefix Error() adds.
+func lintMessage(err error) string {
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		return typed.Message
+	}
+	return err.Error()
+}
+
+// ValidateScript compiles the script without running it.
+func (e *JSEngine) ValidateScript(path, content string) []LintIssue {
+	if _, err := goja.Compile(path, content, false); err != nil {
+		return []LintIssue{{Severity: LintError, Code: "js_syntax", ScriptPath: path, Message: err.Error()}}
+	}
+	return nil
+}
+
+// ValidateScript checks that the script splits into statements and flags
+// statements with unbalanced single quotes.
+func (e *SQLEngine) ValidateScript(path, content string) []LintIssue {
+	statements := splitSQLStatements(content, e.scriptBoundary)
+	if len(statements) == 0 {
+		return []LintIssue{{Severity: LintError, Code: "sql_empty", ScriptPath: path, Message: "script contains no statements"}}
+	}
+	var issues []LintIssue
+	for i, stmt := range statements {
+		if strings.Count(stmt, "'")%2 != 0 {
+			issues = append(issues, LintIssue{Severity: LintWarning, Code: "sql_unbalanced_quotes", ScriptPath: path,
+				Message: fmt.Sprintf("statement %d has an unterminated string literal", i+1)})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,49 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintReportsFindings(t *testing.T) {
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/ok.js", Content: []byte("// config\n// schedule: \"0 * * * *\"\n\nconsole.log('ok')")},
+			{Path: "jobs/broken.js", Content: []byte("function (")},
+			{Path: "jobs/bad_cron.sh", Content: []byte("# config\n# schedule: \"61 * * * *\"\n# warn_after: 2m\n# timeout: 1m\n\necho hi")},
+			{Path: "jobs/policy.sh", Content: []byte("# config\n# metadata:\n#   dedup_policy: sometimes\n\necho hi")},
+			{Path: "jobs/params.sh", Content: []byte("# config\n# metadata:\n#   params: [a, b]\n\necho hi")},
+			{Path: "jobs/quotes.sql", Content: []byte("-- config\n-- transaction: true\n\nINSERT INTO t VALUES ('a;b');\n--job\nSELECT 'oops;")},
+			{Path: "jobs/notes.txt", Content: []byte("hello")},
+			{Path: "jobs/overlap.sh", Content: []byte("# config\n# schedule: \"*/5 * * * *\"\n# timeout: 10m\n\necho hi")},
+		},
+	}
+	engines := []job.Engine{job.NewJSRunner(), job.NewShellRunner(), job.NewSQLRunner()}
+
+	report, err := job.Lint(context.Background(), provider, engines)
+	require.NoError(t, err)
+	assert.Equal(t, 8, report.Scripts)
+	assert.False(t, report.OK())
+
+	codes := map[string][]string{}
+	for _, issue := range report.Issues {
+		codes[issue.ScriptPath] = append(codes[issue.ScriptPath], issue.Code)
+	}
+	assert.NotContains(t, codes, "jobs/ok.js")
+	assert.Equal(t, []string{"js_syntax"}, codes["jobs/broken.js"])
+	assert.ElementsMatch(t, []string{"invalid_schedule", "warn_after_exceeds_timeout"}, codes["jobs/bad_cron.sh"])
+	assert.Equal(t, []string{"parse_error"}, codes["jobs/policy.sh"], "invalid enums fail metadata parsing")
+	assert.Equal(t, []string{"invalid_params"}, codes["jobs/params.sh"])
+	assert.Equal(t, []string{"sql_unbalanced_quotes"}, codes["jobs/quotes.sql"])
+	assert.Equal(t, []string{"no_engine"}, codes["jobs/notes.txt"])
+	assert.Equal(t, []string{"timeout_exceeds_interval"}, codes["jobs/overlap.sh"])
+	for _, issue := range report.Issues {
+		if issue.Code == "timeout_exceeds_interval" {
+			assert.Contains(t, issue.Message, "(Every 5 minutes)")
+		}
+	}
+}
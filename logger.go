@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -100,6 +101,33 @@ func WithStdLoggerTimestampFunc(fn func() time.Time) StdLoggerOption {
 	}
 }
 
+// WithStdLoggerClock reads log entry timestamps from clock.
+func WithStdLoggerClock(clock Clock) StdLoggerOption {
+	return func(p *stdLoggerProvider) {
+		if clock != nil {
+			p.now = clock.Now
+		}
+	}
+}
+
+// StdLoggerFormat selects how the std logger renders lines.
+type StdLoggerFormat string
+
+const (
+	// StdLoggerFormatText writes "ts LEVEL [name] msg key=value" lines.
+	StdLoggerFormatText StdLoggerFormat = "text"
+	// StdLoggerFormatJSON writes one JSON object per line with ts, level,
+	// name, msg and fields, ready for log shippers.
+	StdLoggerFormatJSON StdLoggerFormat = "json"
+)
+
+// WithStdLoggerFormat changes the line format; text is the default.
+func WithStdLoggerFormat(format StdLoggerFormat) StdLoggerOption {
+	return func(p *stdLoggerProvider) {
+		p.format = format
+	}
+}
+
 // NewStdLoggerProvider returns a lightweight logger provider that writes structured
 // log lines to the supplied writer. By default it discards output, providing a silent
 // fallback for dependants that do not configure logging explicitly.
@@ -108,10 +136,13 @@ func NewStdLoggerProvider(opts ...StdLoggerOption) LoggerProvider {
 }
 
 type stdLoggerProvider struct {
-	mu       sync.Mutex
-	writer   io.Writer
-	minLevel LogLevel
-	now      func() time.Time
+	mu        sync.Mutex
+	writer    io.Writer
+	minLevel  LogLevel
+	format    StdLoggerFormat
+	overrides LogLevelOverrides
+	sampler   *logSampler
+	now       func() time.Time
 }
 
 func newStdLoggerProvider(opts ...StdLoggerOption) *stdLoggerProvider {
@@ -184,7 +215,7 @@ func (l *stdLogger) log(level LogLevel, msg string, args ...any) {
 		return
 	}
 
-	fields := make([]string, 0, len(l.fields)+(len(args)+1)/2)
+	fields := make([]logField, 0, len(l.fields)+(len(args)+1)/2)
 
 	if len(l.fields) > 0 {
 		keys := make([]string, 0, len(l.fields))
@@ -193,7 +224,7 @@ func (l *stdLogger) log(level LogLevel, msg string, args ...any) {
 		}
 		sort.Strings(keys)
 		for _, key := range keys {
-			fields = append(fields, fmt.Sprintf("%s=%v", key, l.fields[key]))
+			fields = append(fields, logField{key: key, value: l.fields[key]})
 		}
 	}
 
@@ -207,27 +238,54 @@ func (l *stdLogger) log(level LogLevel, msg string, args ...any) {
 		if i+1 < len(args) {
 			value = args[i+1]
 		}
-		fields = append(fields, fmt.Sprintf("%s=%v", key, value))
+		fields = append(fields, logField{key: key, value: value})
 	}
 
 	if len(args)%2 == 1 {
 		// Preserve the dangling value so tooling can surface the mismatch.
 		last := args[len(args)-1]
-		fields = append(fields, fmt.Sprintf("extra_arg=%v", last))
+		fields = append(fields, logField{key: "extra_arg", value: last})
 	}
 
 	l.provider.write(level, l.name, msg, fields)
 }
 
-func (p *stdLoggerProvider) write(level LogLevel, name, msg string, fields []string) {
+type logField struct {
+	key   string
+	value any
+}
+
+func (p *stdLoggerProvider) write(level LogLevel, name, msg string, fields []logField) {
 	if p == nil || p.writer == nil {
 		return
 	}
 
-	if level < p.minLevel {
+	if level < p.minLevelFor(name) {
 		return
 	}
 
+	ok, suppressed := p.sampler.allow(level, name, msg)
+	if !ok {
+		return
+	}
+	if suppressed > 0 {
+		fields = append(fields, logField{key: "suppressed", value: suppressed})
+	}
+
+	var line string
+	if p.format == StdLoggerFormatJSON {
+		line = p.formatJSON(level, name, msg, fields)
+	} else {
+		line = p.formatText(level, name, msg, fields)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintln(p.writer, line)
+}
+
+func (p *stdLoggerProvider) formatText(level LogLevel, name, msg string, fields []logField) string {
 	timestamp := p.now().Format(time.RFC3339Nano)
 
 	var sb strings.Builder
@@ -245,15 +303,56 @@ func (p *stdLoggerProvider) write(level LogLevel, name, msg string, fields []str
 		sb.WriteByte(' ')
 		sb.WriteString(msg)
 	}
-	if len(fields) > 0 {
+	for _, field := range fields {
 		sb.WriteByte(' ')
-		sb.WriteString(strings.Join(fields, " "))
+		sb.WriteString(fmt.Sprintf("%s=%v", field.key, field.value))
 	}
+	return sb.String()
+}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
+// formatJSON renders one object per line: ts, level, name, msg and fields.
+// Values that cannot be encoded fall back to their fmt representation.
+func (p *stdLoggerProvider) formatJSON(level LogLevel, name, msg string, fields []logField) string {
+	entry := struct {
+		TS     string         `json:"ts"`
+		Level  string         `json:"level"`
+		Name   string         `json:"name,omitempty"`
+		Msg    string         `json:"msg"`
+		Fields map[string]any `json:"fields,omitempty"`
+	}{
+		TS:    p.now().Format(time.RFC3339Nano),
+		Level: strings.ToLower(level.String()),
+		Name:  name,
+		Msg:   msg,
+	}
+	if len(fields) > 0 {
+		entry.Fields = make(map[string]any, len(fields))
+		for _, field := range fields {
+			entry.Fields[field.key] = jsonLogValue(field.value)
+		}
+	}
+	out, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprintf(`{"ts":%q,"level":%q,"msg":%q,"error":%q}`, entry.TS, entry.Level, msg, err.Error())
+	}
+	return string(out)
+}
 
-	fmt.Fprintln(p.writer, sb.String())
+func jsonLogValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case error:
+		return v.Error()
+	case time.Time:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return value
 }
 
 func cloneFields(fields map[string]any) map[string]any {
@@ -8,11 +8,17 @@ import (
 )
 
 // GoLoggerProvider converts a go-logger provider into the job LoggerProvider contract.
-func GoLoggerProvider(provider glog.LoggerProvider) LoggerProvider {
+func GoLoggerProvider(provider glog.LoggerProvider, opts ...GoLoggerOption) LoggerProvider {
 	if provider == nil {
 		return nil
 	}
-	return &goLoggerProviderAdapter{provider: provider}
+	adapter := &goLoggerProviderAdapter{provider: provider}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(adapter)
+		}
+	}
+	return adapter
 }
 
 // GoLogger wraps a go-logger Logger into the job Logger contract.
@@ -24,11 +30,16 @@ func GoLogger(logger glog.Logger) Logger {
 }
 
 type goLoggerProviderAdapter struct {
-	provider glog.LoggerProvider
+	provider  glog.LoggerProvider
+	overrides LogLevelOverrides
 }
 
 func (g *goLoggerProviderAdapter) GetLogger(name string) Logger {
-	return GoLogger(g.provider.GetLogger(name))
+	logger := GoLogger(g.provider.GetLogger(name))
+	if level, ok := g.overrides.levelFor(name); ok && logger != nil {
+		return &levelFilterLogger{logger: logger, min: level}
+	}
+	return logger
 }
 
 type goLoggerAdapter struct {
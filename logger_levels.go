@@ -0,0 +1,143 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LogLevelOverrides sets the minimum level per logger name prefix, e.g.
+// {"job:engine:sql": LevelDebug, "job:runner": LevelWarn}. The longest
+// matching prefix wins.
+type LogLevelOverrides map[string]LogLevel
+
+// ParseLogLevel parses trace, debug, info, warn(ing), error or fatal.
+func ParseLogLevel(value string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q", value)
+	}
+}
+
+// ParseLogLevelOverrides parses "job:engine:sql=debug,job:runner=warn", the
+// format suited to an environment variable or flag.
+func ParseLogLevelOverrides(spec string) (LogLevelOverrides, error) {
+	overrides := LogLevelOverrides{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		prefix, level, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(prefix) == "" {
+			return nil, fmt.Errorf("invalid log level override %q, expected prefix=level", part)
+		}
+		parsed, err := ParseLogLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		overrides[strings.TrimSpace(prefix)] = parsed
+	}
+	return overrides, nil
+}
+
+// levelFor returns the level of the longest prefix matching name.
+func (o LogLevelOverrides) levelFor(name string) (LogLevel, bool) {
+	best, found := -1, false
+	var level LogLevel
+	for prefix, candidate := range o {
+		if strings.HasPrefix(name, prefix) && len(prefix) > best {
+			best, level, found = len(prefix), candidate, true
+		}
+	}
+	return level, found
+}
+
+// WithStdLoggerLevelOverrides sets per-component minimum levels; names
+// without a matching prefix use WithStdLoggerMinLevel.
+func WithStdLoggerLevelOverrides(overrides LogLevelOverrides) StdLoggerOption {
+	return func(p *stdLoggerProvider) {
+		p.overrides = overrides
+	}
+}
+
+func (p *stdLoggerProvider) minLevelFor(name string) LogLevel {
+	if level, ok := p.overrides.levelFor(name); ok {
+		return level
+	}
+	return p.minLevel
+}
+
+// GoLoggerOption customises GoLoggerProvider.
+type GoLoggerOption func(*goLoggerProviderAdapter)
+
+// WithGoLoggerLevelOverrides drops records below the level configured for the
+// logger's name prefix. It can only quiet components: records still need to
+// pass the go-logger's own level.
+func WithGoLoggerLevelOverrides(overrides LogLevelOverrides) GoLoggerOption {
+	return func(g *goLoggerProviderAdapter) {
+		g.overrides = overrides
+	}
+}
+
+// levelFilterLogger drops records below min before they reach the wrapped logger.
+type levelFilterLogger struct {
+	logger Logger
+	min    LogLevel
+}
+
+func (l *levelFilterLogger) Trace(msg string, args ...any) {
+	if l.min <= LevelTrace {
+		l.logger.Trace(msg, args...)
+	}
+}
+
+func (l *levelFilterLogger) Debug(msg string, args ...any) {
+	if l.min <= LevelDebug {
+		l.logger.Debug(msg, args...)
+	}
+}
+
+func (l *levelFilterLogger) Info(msg string, args ...any) {
+	if l.min <= LevelInfo {
+		l.logger.Info(msg, args...)
+	}
+}
+
+func (l *levelFilterLogger) Warn(msg string, args ...any) {
+	if l.min <= LevelWarn {
+		l.logger.Warn(msg, args...)
+	}
+}
+
+func (l *levelFilterLogger) Error(msg string, args ...any) {
+	if l.min <= LevelError {
+		l.logger.Error(msg, args...)
+	}
+}
+
+func (l *levelFilterLogger) Fatal(msg string, args ...any) { l.logger.Fatal(msg, args...) }
+
+func (l *levelFilterLogger) WithContext(ctx context.Context) Logger {
+	return &levelFilterLogger{logger: l.logger.WithContext(ctx), min: l.min}
+}
+
+func (l *levelFilterLogger) WithFields(fields map[string]any) Logger {
+	fieldsLogger, ok := l.logger.(FieldsLogger)
+	if !ok {
+		return l
+	}
+	return &levelFilterLogger{logger: fieldsLogger.WithFields(fields), min: l.min}
+}
@@ -0,0 +1,142 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LogSampling limits repeated log lines. Lines are keyed by logger name, level
+// and message; within each Period the first First lines of a key are logged,
+// then one in every Thereafter (none when Thereafter is 0). A sampled line
+// carries a "suppressed" field counting the lines dropped before it.
+type LogSampling struct {
+	First      int
+	Thereafter int
+	// Period defaults to one second.
+	Period time.Duration
+	// MaxLevel is the highest level sampled; defaults to LevelDebug so
+	// warnings and errors are never dropped.
+	MaxLevel LogLevel
+}
+
+type logSampleCounter struct {
+	count      int
+	suppressed int
+}
+
+type logSampler struct {
+	cfg LogSampling
+	now func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counters    map[string]*logSampleCounter
+}
+
+func newLogSampler(cfg LogSampling, now func() time.Time) *logSampler {
+	if cfg.Period <= 0 {
+		cfg.Period = time.Second
+	}
+	if cfg.MaxLevel == 0 {
+		cfg.MaxLevel = LevelDebug
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &logSampler{cfg: cfg, now: now, counters: make(map[string]*logSampleCounter)}
+}
+
+// allow reports whether the line is logged and how many lines of its key were
+// suppressed since the last one logged.
+func (s *logSampler) allow(level LogLevel, name, msg string) (bool, int) {
+	if s == nil || level > s.cfg.MaxLevel {
+		return true, 0
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= s.cfg.Period {
+		s.windowStart = now
+		s.counters = make(map[string]*logSampleCounter, len(s.counters))
+	}
+	key := level.String() + "\x00" + name + "\x00" + msg
+	counter := s.counters[key]
+	if counter == nil {
+		counter = &logSampleCounter{}
+		s.counters[key] = counter
+	}
+	counter.count++
+
+	if counter.count <= s.cfg.First ||
+		(s.cfg.Thereafter > 0 && (counter.count-s.cfg.First)%s.cfg.Thereafter == 0) {
+		suppressed := counter.suppressed
+		counter.suppressed = 0
+		return true, suppressed
+	}
+	counter.suppressed++
+	return false, 0
+}
+
+// WithStdLoggerSampling samples repeated lines, so a 10k-statement SQL job
+// does not emit 10k debug lines per run.
+func WithStdLoggerSampling(cfg LogSampling) StdLoggerOption {
+	return func(p *stdLoggerProvider) {
+		p.sampler = newLogSampler(cfg, func() time.Time { return p.now() })
+	}
+}
+
+// SamplingLoggerProvider applies LogSampling to loggers from any provider.
+func SamplingLoggerProvider(provider LoggerProvider, cfg LogSampling) LoggerProvider {
+	if provider == nil {
+		return nil
+	}
+	return &samplingProvider{provider: provider, sampler: newLogSampler(cfg, nil)}
+}
+
+type samplingProvider struct {
+	provider LoggerProvider
+	sampler  *logSampler
+}
+
+func (s *samplingProvider) GetLogger(name string) Logger {
+	return &samplingLogger{logger: s.provider.GetLogger(name), name: name, sampler: s.sampler}
+}
+
+type samplingLogger struct {
+	logger  Logger
+	name    string
+	sampler *logSampler
+}
+
+func (l *samplingLogger) Trace(msg string, args ...any) { l.log(LevelTrace, l.logger.Trace, msg, args) }
+func (l *samplingLogger) Debug(msg string, args ...any) { l.log(LevelDebug, l.logger.Debug, msg, args) }
+func (l *samplingLogger) Info(msg string, args ...any)  { l.log(LevelInfo, l.logger.Info, msg, args) }
+func (l *samplingLogger) Warn(msg string, args ...any)  { l.log(LevelWarn, l.logger.Warn, msg, args) }
+func (l *samplingLogger) Error(msg string, args ...any) { l.log(LevelError, l.logger.Error, msg, args) }
+func (l *samplingLogger) Fatal(msg string, args ...any) { l.log(LevelFatal, l.logger.Fatal, msg, args) }
+
+func (l *samplingLogger) log(level LogLevel, emit func(string, ...any), msg string, args []any) {
+	ok, suppressed := l.sampler.allow(level, l.name, msg)
+	if !ok {
+		return
+	}
+	if suppressed > 0 {
+		args = append(append([]any(nil), args...), "suppressed", suppressed)
+	}
+	emit(msg, args...)
+}
+
+func (l *samplingLogger) WithContext(ctx context.Context) Logger {
+	return &samplingLogger{logger: l.logger.WithContext(ctx), name: l.name, sampler: l.sampler}
+}
+
+func (l *samplingLogger) WithFields(fields map[string]any) Logger {
+	fieldsLogger, ok := l.logger.(FieldsLogger)
+	if !ok {
+		return l
+	}
+	return &samplingLogger{logger: fieldsLogger.WithFields(fields), name: l.name, sampler: l.sampler}
+}
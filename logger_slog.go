@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// Slog levels used for the job levels slog lacks. Fatal only logs; it does not exit.
+const (
+	SlogLevelTrace = slog.LevelDebug - 4
+	SlogLevelFatal = slog.LevelError + 4
+)
+
+// SlogLoggerProvider adapts a slog.Logger into a LoggerProvider. Named loggers
+// carry their name in the "logger" attribute.
+func SlogLoggerProvider(logger *slog.Logger) LoggerProvider {
+	if logger == nil {
+		return nil
+	}
+	return &slogProviderAdapter{logger: logger}
+}
+
+// SlogLogger wraps a slog.Logger into the job Logger contract.
+func SlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		return nil
+	}
+	return &slogAdapter{logger: logger, ctx: context.Background()}
+}
+
+type slogProviderAdapter struct {
+	logger *slog.Logger
+}
+
+func (s *slogProviderAdapter) GetLogger(name string) Logger {
+	logger := s.logger
+	if name != "" {
+		logger = logger.With("logger", name)
+	}
+	return SlogLogger(logger)
+}
+
+type slogAdapter struct {
+	logger *slog.Logger
+	ctx    context.Context
+}
+
+func (s *slogAdapter) Trace(msg string, args ...any) { s.log(SlogLevelTrace, msg, args...) }
+func (s *slogAdapter) Debug(msg string, args ...any) { s.log(slog.LevelDebug, msg, args...) }
+func (s *slogAdapter) Info(msg string, args ...any)  { s.log(slog.LevelInfo, msg, args...) }
+func (s *slogAdapter) Warn(msg string, args ...any)  { s.log(slog.LevelWarn, msg, args...) }
+func (s *slogAdapter) Error(msg string, args ...any) { s.log(slog.LevelError, msg, args...) }
+func (s *slogAdapter) Fatal(msg string, args ...any) { s.log(SlogLevelFatal, msg, args...) }
+
+func (s *slogAdapter) log(level slog.Level, msg string, args ...any) {
+	s.logger.Log(s.ctx, level, msg, args...)
+}
+
+// WithContext passes ctx to the slog handler on every record, so context-aware
+// handlers can extract trace IDs.
+func (s *slogAdapter) WithContext(ctx context.Context) Logger {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &slogAdapter{logger: s.logger, ctx: ctx}
+}
+
+func (s *slogAdapter) WithFields(fields map[string]any) Logger {
+	if len(fields) == 0 {
+		return s
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]any, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, fields[key]))
+	}
+	return &slogAdapter{logger: s.logger.With(attrs...), ctx: s.ctx}
+}
@@ -3,9 +3,13 @@ package job
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-logger/glog"
 	"github.com/stretchr/testify/assert"
@@ -129,3 +133,158 @@ func TestStdLoggerOddArgsHandled(t *testing.T) {
 	output := buf.String()
 	assert.True(t, strings.Contains(output, "extra_arg=one"))
 }
+
+type ctxKey string
+
+type ctxRecordingHandler struct {
+	slog.Handler
+	ctxs *[]context.Context
+}
+
+func (h ctxRecordingHandler) Handle(ctx context.Context, record slog.Record) error {
+	*h.ctxs = append(*h.ctxs, ctx)
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h ctxRecordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ctxRecordingHandler{Handler: h.Handler.WithAttrs(attrs), ctxs: h.ctxs}
+}
+
+func TestSlogLoggerProvider(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var ctxs []context.Context
+	handler := ctxRecordingHandler{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: SlogLevelTrace}), ctxs: &ctxs}
+	provider := SlogLoggerProvider(slog.New(handler))
+
+	logger := provider.GetLogger("job:engine:sql")
+	logger = logger.(FieldsLogger).WithFields(map[string]any{"task_id": "report"})
+	ctx := context.WithValue(context.Background(), ctxKey("trace"), "t-1")
+	logger.WithContext(ctx).Trace("statement", "index", 3)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "job:engine:sql", record["logger"])
+	assert.Equal(t, "report", record["task_id"])
+	assert.EqualValues(t, 3, record["index"])
+	assert.Equal(t, "statement", record["msg"])
+	assert.Equal(t, "DEBUG-4", record["level"])
+	require.Len(t, ctxs, 1)
+	assert.Equal(t, "t-1", ctxs[0].Value(ctxKey("trace")))
+
+	assert.Nil(t, SlogLogger(nil))
+	assert.Nil(t, SlogLoggerProvider(nil))
+}
+
+func TestStdLoggerJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	provider := NewStdLoggerProvider(
+		WithStdLoggerWriter(buf),
+		WithStdLoggerFormat(StdLoggerFormatJSON),
+		WithStdLoggerTimestampFunc(func() time.Time { return now }),
+	)
+
+	logger := provider.GetLogger("job:runner").(FieldsLogger).WithFields(map[string]any{"task_id": "report"})
+	logger.Warn("slow run", "elapsed", 2*time.Second, "error", errors.New("boom"), "rows", 3, "dangling")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "2026-10-17T09:30:00Z", entry["ts"])
+	assert.Equal(t, "warn", entry["level"])
+	assert.Equal(t, "job:runner", entry["name"])
+	assert.Equal(t, "slow run", entry["msg"])
+	assert.Equal(t, map[string]any{
+		"task_id":   "report",
+		"elapsed":   "2s",
+		"error":     "boom",
+		"rows":      float64(3),
+		"extra_arg": "dangling",
+	}, entry["fields"])
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+}
+
+func TestStdLoggerLevelOverrides(t *testing.T) {
+	overrides, err := ParseLogLevelOverrides("job:engine=warn, job:engine:sql=debug")
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	provider := NewStdLoggerProvider(WithStdLoggerWriter(buf), WithStdLoggerLevelOverrides(overrides))
+
+	provider.GetLogger("job:engine:sql").Debug("sql detail")
+	provider.GetLogger("job:engine:shell").Info("shell chatter")
+	provider.GetLogger("job:engine:shell").Warn("shell warning")
+	provider.GetLogger("job:runner").Debug("runner detail")
+	provider.GetLogger("job:runner").Info("runner info")
+
+	output := buf.String()
+	assert.Contains(t, output, "sql detail")
+	assert.NotContains(t, output, "shell chatter")
+	assert.Contains(t, output, "shell warning")
+	assert.NotContains(t, output, "runner detail")
+	assert.Contains(t, output, "runner info")
+
+	_, err = ParseLogLevelOverrides("job:runner")
+	assert.Error(t, err)
+	_, err = ParseLogLevelOverrides("job:runner=loud")
+	assert.Error(t, err)
+}
+
+func TestGoLoggerProviderLevelOverrides(t *testing.T) {
+	stub := &stubGoLogger{}
+	provider := GoLoggerProvider(fixedGoLoggerProvider{logger: stub}, WithGoLoggerLevelOverrides(LogLevelOverrides{"job:engine": LevelWarn}))
+
+	logger := provider.GetLogger("job:engine:sql").(FieldsLogger).WithFields(map[string]any{"task_id": "t"})
+	logger.Info("dropped")
+	assert.Empty(t, stub.lastMsg)
+	logger.Error("kept")
+	assert.Equal(t, "kept", stub.lastMsg)
+
+	provider.GetLogger("job:runner").Debug("passes through")
+	assert.Equal(t, "passes through", stub.lastMsg)
+}
+
+type fixedGoLoggerProvider struct{ logger glog.Logger }
+
+func (f fixedGoLoggerProvider) GetLogger(string) glog.Logger { return f.logger }
+
+func TestStdLoggerSampling(t *testing.T) {
+	buf := &bytes.Buffer{}
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	provider := NewStdLoggerProvider(
+		WithStdLoggerWriter(buf),
+		WithStdLoggerMinLevel(LevelDebug),
+		WithStdLoggerTimestampFunc(func() time.Time { return now }),
+		WithStdLoggerSampling(LogSampling{First: 2, Thereafter: 100}),
+	)
+	logger := provider.GetLogger("job:engine:sql")
+
+	for i := 0; i < 10000; i++ {
+		logger.Debug("sql statement", "statement_index", i+1)
+	}
+	logger.Warn("sql statement")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2+99+1)
+	assert.Contains(t, lines[2], "statement_index=102 suppressed=99")
+	assert.Contains(t, lines[len(lines)-1], "WARN")
+
+	buf.Reset()
+	now = now.Add(time.Second)
+	logger.Debug("sql statement")
+	assert.NotContains(t, buf.String(), "suppressed", "a new period starts a fresh burst")
+}
+
+func TestSamplingLoggerProvider(t *testing.T) {
+	stub := &stubGoLogger{}
+	provider := SamplingLoggerProvider(GoLoggerProvider(fixedGoLoggerProvider{logger: stub}), LogSampling{First: 1})
+
+	logger := provider.GetLogger("job:engine:sql").(FieldsLogger).WithFields(map[string]any{"run_id": "r"})
+	logger.Debug("row")
+	stub.lastMsg = ""
+	logger.Debug("row")
+	logger.Debug("row")
+	assert.Empty(t, stub.lastMsg, "rate limited after the burst")
+
+	logger.Info("row")
+	assert.Equal(t, "row", stub.lastMsg, "info is above the default MaxLevel")
+}
@@ -0,0 +1,171 @@
+package job
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ResultStatusMaintenance is the Result status recorded when a run is
+// refused because maintenance mode is on.
+const ResultStatusMaintenance = "maintenance"
+
+// ErrMaintenanceMode is returned for executions refused in maintenance mode.
+var ErrMaintenanceMode = errors.New("maintenance mode: executions are paused", errors.CategoryOperation).
+	WithTextCode("JOB_MAINTENANCE_MODE")
+
+// MaintenanceState describes the maintenance switch.
+type MaintenanceState struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"`
+	// Since is when maintenance mode was turned on; zero while off.
+	Since time.Time `json:"since"`
+}
+
+// MaintenanceMode is a runner-wide switch that refuses every execution,
+// manual and scheduled, while discovery, listing and scheduling keep
+// working, e.g. during a database migration or failover. A nil
+// *MaintenanceMode is always off.
+type MaintenanceMode struct {
+	mu    sync.RWMutex
+	state MaintenanceState
+}
+
+// NewMaintenanceMode returns a switch that starts off.
+func NewMaintenanceMode() *MaintenanceMode {
+	return &MaintenanceMode{}
+}
+
+// Enable refuses executions until Disable, recording reason in the refused
+// runs' Result.
+func (m *MaintenanceMode) Enable(reason string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.state.Enabled {
+		m.state.Since = time.Now()
+	}
+	m.state.Enabled = true
+	m.state.Reason = reason
+}
+
+// Disable lets executions run again.
+func (m *MaintenanceMode) Disable() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = MaintenanceState{}
+}
+
+// Enabled reports whether executions are refused.
+func (m *MaintenanceMode) Enabled() bool {
+	return m.State().Enabled
+}
+
+// State returns the current state of the switch.
+func (m *MaintenanceMode) State() MaintenanceState {
+	if m == nil {
+		return MaintenanceState{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// result is the Result of a run refused in maintenance mode.
+func (s MaintenanceState) result() Result {
+	message := "maintenance mode"
+	if s.Reason != "" {
+		message += ": " + s.Reason
+	}
+	return Result{Status: ResultStatusMaintenance, Message: message}
+}
+
+// WithMaintenanceMode makes the commander refuse executions while mode is on.
+func (c *TaskCommander) WithMaintenanceMode(mode *MaintenanceMode) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.maintenance = mode
+	return c
+}
+
+// WithMaintenanceMode refuses scheduled runs while mode is on. Schedules
+// keep firing; each refused run is reported with the maintenance status.
+func (m *CronManager) WithMaintenanceMode(mode *MaintenanceMode) *CronManager {
+	m.maintenance = mode
+	return m
+}
+
+// WithMaintenanceMode sets the switch behind Runner.Maintenance, e.g. one
+// shared by several runners. By default every runner has its own.
+func WithMaintenanceMode(mode *MaintenanceMode) Option {
+	return func(r *Runner) {
+		if mode != nil {
+			r.maintenance = mode
+		}
+	}
+}
+
+// Maintenance returns the runner's maintenance switch, shared with the
+// commanders the runner builds and its attached CronManager:
+//
+//	runner.Maintenance().Enable("database migration")
+//	defer runner.Maintenance().Disable()
+func (r *Runner) Maintenance() *MaintenanceMode {
+	if r == nil {
+		return nil
+	}
+	return r.maintenance
+}
+
+// MaintenanceHandler exposes a maintenance switch over HTTP. GET returns the
+// MaintenanceState as JSON; PUT or POST with a body like
+// {"enabled": true, "reason": "failover"} toggles it and returns the new
+// state. Guard it with authentication, e.g. AuthzMiddleware.
+type MaintenanceHandler struct {
+	mode *MaintenanceMode
+}
+
+// NewMaintenanceHandler builds a handler toggling mode.
+func NewMaintenanceHandler(mode *MaintenanceMode) *MaintenanceHandler {
+	return &MaintenanceHandler{mode: mode}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *MaintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.mode == nil {
+		http.Error(w, "maintenance mode not configured", http.StatusNotImplemented)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		var req struct {
+			Enabled *bool  `json:"enabled"`
+			Reason  string `json:"reason"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+			http.Error(w, `body must be {"enabled": bool, "reason": string}`, http.StatusBadRequest)
+			return
+		}
+		if *req.Enabled {
+			h.mode.Enable(req.Reason)
+		} else {
+			h.mode.Disable()
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.mode.State())
+}
@@ -0,0 +1,73 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerMaintenanceMode(t *testing.T) {
+	history := job.NewMemoryHistory(10)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("maintenance-export", "maintenance-export.sh", "shell", job.Config{}, "", job.NewShellRunner())))
+	runner := job.NewRunner(job.WithRegistry(registry), job.WithExecutionHistory(history),
+		job.WithBackfillOptions(job.BackfillOptions{Tracker: job.NewIdempotencyTracker()}))
+
+	runner.Maintenance().Enable("database migration")
+	state := runner.Maintenance().State()
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "database migration", state.Reason)
+	assert.False(t, state.Since.IsZero())
+	assert.Len(t, runner.RegisteredTasks(), 1, "listing keeps working")
+
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	_, err := runner.Backfill(context.Background(), "maintenance-export", from, from.Add(time.Hour), time.Hour, map[string]any{"script": "true"})
+	require.Error(t, err)
+
+	records, err := history.List(context.Background(), job.HistoryFilter{JobID: "maintenance-export"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, job.ResultStatusMaintenance, records[0].Status)
+	assert.Equal(t, job.ResultStatusMaintenance, runner.RecentRuns(1)[0].Result.Status)
+	assert.Equal(t, "maintenance mode: database migration", runner.RecentRuns(1)[0].Result.Message)
+
+	runner.Maintenance().Disable()
+	assert.False(t, runner.Maintenance().Enabled())
+	_, err = runner.Backfill(context.Background(), "maintenance-export", from.Add(time.Hour), from.Add(2*time.Hour), time.Hour, map[string]any{"script": "true"})
+	require.NoError(t, err)
+}
+
+func TestMaintenanceHandler(t *testing.T) {
+	mode := job.NewMaintenanceMode()
+	handler := job.NewMaintenanceHandler(mode)
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/maintenance", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled": false, "since": "0001-01-01T00:00:00Z"}`, rec.Body.String())
+
+	rec = serve(http.MethodPut, `{"enabled": true, "reason": "failover"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"reason":"failover"`)
+	assert.True(t, mode.Enabled())
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"reason": "x"}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "").Code)
+	assert.True(t, mode.Enabled())
+
+	serve(http.MethodPost, `{"enabled": false}`)
+	assert.False(t, mode.Enabled())
+	assert.Equal(t, job.MaintenanceState{}, mode.State())
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -27,6 +28,17 @@ type Processor interface {
 type yamlMetadataParser struct {
 	patterns   []MatchPattern
 	processors []Processor
+
+	compileOnce sync.Once
+	compiled    []compiledPattern
+}
+
+// compiledPattern holds the regular expressions for a MatchPattern so they
+// are built once per parser rather than once per scanned line.
+type compiledPattern struct {
+	MatchPattern
+	start *regexp.Regexp
+	end   *regexp.Regexp
 }
 
 var DefaultMatchPatterns = []MatchPattern{
@@ -78,6 +90,28 @@ func NewYAMLMetadataParser(patterns ...MatchPattern) *yamlMetadataParser {
 	}
 }
 
+// compile builds the regular expressions for every pattern. End patterns
+// are only compiled for block and YAML styles; single line comment styles
+// end on the first line without the comment prefix (their EndPattern uses
+// a lookahead RE2 cannot compile and is kept for documentation only).
+func (p *yamlMetadataParser) compile() []compiledPattern {
+	p.compileOnce.Do(func() {
+		compiled := make([]compiledPattern, 0, len(p.patterns))
+		for _, pattern := range p.patterns {
+			cp := compiledPattern{
+				MatchPattern: pattern,
+				start:        regexp.MustCompile(pattern.StartPattern),
+			}
+			if pattern.IsBlock || pattern.CommentPrefix == "" {
+				cp.end = regexp.MustCompile(pattern.EndPattern)
+			}
+			compiled = append(compiled, cp)
+		}
+		p.compiled = compiled
+	})
+	return p.compiled
+}
+
 func (p *yamlMetadataParser) applyProcesors(data []byte) ([]byte, error) {
 	var err error
 	for _, processor := range p.processors {
@@ -97,112 +131,140 @@ func (p *yamlMetadataParser) applyProcesors(data []byte) ([]byte, error) {
 // It returns a Config, the remaining script minus the config content
 // and any errors collected during parsing.
 func (p *yamlMetadataParser) Parse(content []byte) (Config, string, error) {
-	processedContent, err := p.applyProcesors(content)
+	metadata, scriptContent, found, err := p.split(content)
 	if err != nil {
 		return Config{}, "", err
 	}
+	if !found {
+		return Config{
+			Schedule: DefaultSchedule,
+			Timeout:  DefaultTimeout,
+			// TODO: should we return processed content or raw?
+		}, scriptContent, nil
+	}
+	cfg, err := parseRawConfig(metadata)
+	return cfg, scriptContent, err
+}
 
-	// Split the file into lines.
-	lines := bytes.Split(processedContent, []byte("\n"))
+// ParseScript returns the script content without its metadata header. It
+// skips decoding the metadata, which callers that already hold the Config
+// (e.g. at execution time) do not need.
+func (p *yamlMetadataParser) ParseScript(content []byte) (string, error) {
+	_, scriptContent, _, err := p.split(content)
+	return scriptContent, err
+}
 
-	for i, origLine := range lines {
-		line := bytes.TrimSpace(origLine)
-		for _, pattern := range p.patterns {
-			re := regexp.MustCompile(pattern.StartPattern)
-			if re.Match(line) {
-				if pattern.IsBlock {
-					var metadataLines [][]byte
-					// capture any text after "config" on the first line
-					submatches := re.FindSubmatch(line)
-					if len(submatches) > 1 && len(submatches[1]) > 0 {
-						metadataLines = append(metadataLines, bytes.TrimSpace(submatches[1]))
-					}
+// split locates the metadata header and returns its raw YAML alongside the
+// remaining script. Lines are scanned in place by offset, so the script is
+// a single slice of the processed content rather than a re-joined copy.
+func (p *yamlMetadataParser) split(content []byte) ([]byte, string, bool, error) {
+	processedContent, err := p.applyProcesors(content)
+	if err != nil {
+		return nil, "", false, err
+	}
 
-					endRegex := regexp.MustCompile(pattern.EndPattern)
-					j := i + 1
-					for ; j < len(lines); j++ {
-						trimmed := bytes.TrimSpace(lines[j])
-						if endRegex.Match(trimmed) {
-							break
-						}
-						// remove the comment prefix from the trimmed line
-						metadataLines = append(metadataLines, stripCommentPrefix(trimmed, pattern.CommentPrefix))
-					}
+	patterns := p.compile()
 
-					scriptContent := ""
-					if j+1 < len(lines) {
-						// preserve the original script lines (with their spacing)
-						scriptContent = string(bytes.Join(lines[j+1:], []byte("\n")))
-					}
+	for pos := 0; pos <= len(processedContent); {
+		origLine, next := nextLine(processedContent, pos)
+		pos = next
 
-					metadataContent := bytes.Join(metadataLines, []byte("\n"))
-					cfg, err := parseRawConfig(metadataContent)
-					return cfg, scriptContent, err
-				}
+		line := bytes.TrimSpace(origLine)
+		for i := range patterns {
+			pattern := &patterns[i]
+			if !pattern.start.Match(line) {
+				continue
+			}
 
-				// YAML style with no comment prefix
-				if pattern.CommentPrefix == "" {
-					endRegex := regexp.MustCompile(pattern.EndPattern)
-					end := len(lines)
-					for j := i + 1; j < len(lines); j++ {
-						trimmed := bytes.TrimSpace(lines[j])
-						if endRegex.Match(trimmed) {
-							end = j
-							break
-						}
-					}
+			metadata := make([]byte, 0, 256)
 
-					// trimmed metadata lines
-					var metadataLines [][]byte
-					for j := i + 1; j < end; j++ {
-						metadataLines = append(metadataLines, bytes.TrimSpace(lines[j]))
-					}
+			if pattern.IsBlock {
+				// capture any text after "config" on the first line
+				submatches := pattern.start.FindSubmatch(line)
+				if len(submatches) > 1 && len(submatches[1]) > 0 {
+					metadata = appendMetadataLine(metadata, bytes.TrimSpace(submatches[1]))
+				}
 
-					scriptContent := ""
-					if end+1 < len(lines) {
-						scriptContent = string(bytes.Join(lines[end+1:], []byte("\n")))
+				for pos <= len(processedContent) {
+					current, next := nextLine(processedContent, pos)
+					pos = next
+					trimmed := bytes.TrimSpace(current)
+					if pattern.end.Match(trimmed) {
+						break
 					}
-
-					metadataContent := bytes.Join(metadataLines, []byte("\n"))
-					cfg, err := parseRawConfig(metadataContent)
-					return cfg, scriptContent, err
+					// remove the comment prefix from the trimmed line
+					metadata = appendMetadataLine(metadata, stripCommentPrefix(trimmed, pattern.CommentPrefix))
 				}
 
-				// single line comment branch
-				commentRegex := commentRegexFor(pattern.CommentPrefix)
-				end := len(lines)
-				for j := i + 1; j < len(lines); j++ {
-					trimmed := bytes.TrimSpace(lines[j])
-					if !commentRegex.Match(trimmed) {
-						end = j
+				// preserve the original script lines (with their spacing)
+				return metadata, remainder(processedContent, pos), true, nil
+			}
+
+			// YAML style with no comment prefix
+			if pattern.CommentPrefix == "" {
+				for pos <= len(processedContent) {
+					current, next := nextLine(processedContent, pos)
+					pos = next
+					trimmed := bytes.TrimSpace(current)
+					if pattern.end.Match(trimmed) {
 						break
 					}
+					metadata = appendMetadataLine(metadata, trimmed)
 				}
 
-				var metadataLines [][]byte
-				for j := i + 1; j < end; j++ {
-					// use the trimmed version of the line
-					metadataLines = append(metadataLines, stripCommentPrefix(bytes.TrimSpace(lines[j]), pattern.CommentPrefix))
+				return metadata, remainder(processedContent, pos), true, nil
+			}
+
+			// single line comment branch
+			for pos <= len(processedContent) {
+				current, next := nextLine(processedContent, pos)
+				trimmed := bytes.TrimSpace(current)
+				if !hasCommentPrefix(trimmed, pattern.CommentPrefix) {
+					break
 				}
-				scriptContent := string(bytes.Join(lines[end:], []byte("\n")))
-				metadataContent := bytes.Join(metadataLines, []byte("\n"))
-				cfg, err := parseRawConfig(metadataContent)
-				return cfg, scriptContent, err
+				pos = next
+				metadata = appendMetadataLine(metadata, stripCommentPrefix(trimmed, pattern.CommentPrefix))
 			}
+
+			return metadata, remainder(processedContent, pos), true, nil
 		}
 	}
 
-	return Config{
-		Schedule: DefaultSchedule,
-		Timeout:  DefaultTimeout,
-		// TODO: should we return processed content or raw?
-	}, string(content), nil
+	return nil, string(content), false, nil
+}
+
+// nextLine returns the line starting at pos (without its newline) and the
+// offset of the following line. The returned offset is len(data)+1 once
+// the final line has been consumed, matching bytes.Split semantics where a
+// trailing newline yields a last empty line.
+func nextLine(data []byte, pos int) ([]byte, int) {
+	if i := bytes.IndexByte(data[pos:], '\n'); i >= 0 {
+		return data[pos : pos+i], pos + i + 1
+	}
+	return data[pos:], len(data) + 1
+}
+
+// remainder returns the content from offset pos, or an empty string once
+// every line has been consumed.
+func remainder(data []byte, pos int) string {
+	if pos > len(data) {
+		return ""
+	}
+	return string(data[pos:])
+}
+
+func appendMetadataLine(metadata, line []byte) []byte {
+	if len(metadata) > 0 {
+		metadata = append(metadata, '\n')
+	}
+	return append(metadata, line...)
 }
 
 type rawConfig struct {
 	Schedule    string            `yaml:"schedule"`
 	Retries     int               `yaml:"retries"`
 	Timeout     string            `yaml:"timeout"`
+	WarnAfter   string            `yaml:"warn_after"`
 	Deadline    string            `yaml:"deadline"`
 	NoTimeout   bool              `yaml:"no_timeout"`
 	Debug       bool              `yaml:"debug"`
@@ -213,6 +275,16 @@ type rawConfig struct {
 	ScriptType  string            `yaml:"script_type"`
 	Transaction bool              `yaml:"transaction"`
 	Metadata    map[string]any    `yaml:"metadata"`
+	Affinity    map[string]string `yaml:"affinity"`
+	Backoff     rawBackoff        `yaml:"backoff"`
+}
+
+type rawBackoff struct {
+	Strategy    string                `yaml:"strategy"`
+	Interval    string                `yaml:"interval"`
+	MaxInterval string                `yaml:"max_interval"`
+	Jitter      bool                  `yaml:"jitter"`
+	OnError     map[string]rawBackoff `yaml:"on_error"`
 }
 
 func parseRawConfig(data []byte) (Config, error) {
@@ -233,22 +305,16 @@ func parseRawConfig(data []byte) (Config, error) {
 		Transaction: raw.Transaction,
 		Metadata:    raw.Metadata,
 		Env:         raw.Env,
+		Affinity:    raw.Affinity,
 		Timeout:     DefaultTimeout,
 	}
 
 	var errs error
 
 	if raw.Timeout != "" {
-		// try first for 300s
-		d, err := time.ParseDuration(raw.Timeout)
+		d, err := parseConfigDuration(raw.Timeout)
 		if err != nil {
-			// assume int, but support 30_000
-			cleaned := strings.ReplaceAll(raw.Timeout, "_", "")
-			if seconds, err2 := strconv.Atoi(cleaned); err2 == nil {
-				d = time.Duration(seconds) * time.Second
-			} else {
-				errs = errors.Join(errs, errors.New(fmt.Sprintf("invalid timeout duration: %s", raw.Timeout)))
-			}
+			errs = errors.Join(errs, errors.New(fmt.Sprintf("invalid timeout duration: %s", raw.Timeout)))
 		}
 		// success, set it
 		if d > 0 {
@@ -256,10 +322,39 @@ func parseRawConfig(data []byte) (Config, error) {
 		}
 	}
 
+	if raw.WarnAfter != "" {
+		d, err := parseConfigDuration(raw.WarnAfter)
+		if err != nil {
+			errs = errors.Join(errs, errors.New(fmt.Sprintf("invalid warn_after duration: %s", raw.WarnAfter)))
+		}
+		if d > 0 {
+			cfg.WarnAfter = d
+		}
+	}
+
 	if cfg.Schedule == "" {
 		cfg.Schedule = DefaultSchedule
 	}
 
+	if expanded, err := ExpandSchedule(cfg.Schedule); err != nil {
+		errs = errors.Join(errs, err)
+	} else {
+		cfg.Schedule = expanded
+	}
+
+	if policy, ok := cfg.Metadata["dedup_policy"]; ok {
+		normalized, err := parseDedupPolicy(policy)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		} else {
+			cfg.Metadata["dedup_policy"] = string(normalized)
+		}
+	}
+
+	backoff, err := parseRawBackoff(raw.Backoff, "backoff")
+	errs = errors.Join(errs, err)
+	cfg.Backoff = backoff
+
 	if raw.Deadline != "" {
 		d, err := time.Parse(time.RFC3339, raw.Deadline)
 		if err != nil {
@@ -272,52 +367,123 @@ func parseRawConfig(data []byte) (Config, error) {
 	return cfg, errs
 }
 
-// commentRegexFor returns a regex that will match a comment prefix
-// repeated at least as many times as in the configured prefix
-func commentRegexFor(prefix string) *regexp.Regexp {
+// parseRawBackoff converts a backoff block, normalizing the strategy case.
+// Errors name the block path, e.g. backoff.on_error.SQL_DEADLOCK.
+func parseRawBackoff(raw rawBackoff, path string) (BackoffConfig, error) {
+	var errs error
+	strategy, err := parseBackoffStrategy(raw.Strategy)
+	if err != nil {
+		errs = errors.Join(errs, fmt.Errorf("%s: %w", path, err))
+	}
+	cfg := BackoffConfig{Strategy: strategy, Jitter: raw.Jitter}
+
+	if raw.Interval != "" {
+		if cfg.Interval, err = parseConfigDuration(raw.Interval); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: invalid interval duration: %s", path, raw.Interval))
+		}
+	}
+	if raw.MaxInterval != "" {
+		if cfg.MaxInterval, err = parseConfigDuration(raw.MaxInterval); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: invalid max_interval duration: %s", path, raw.MaxInterval))
+		}
+	}
+	for key, override := range raw.OnError {
+		parsed, err := parseRawBackoff(override, path+".on_error."+key)
+		errs = errors.Join(errs, err)
+		if cfg.OnError == nil {
+			cfg.OnError = make(map[string]BackoffConfig, len(raw.OnError))
+		}
+		cfg.OnError[key] = parsed
+	}
+	return cfg, errs
+}
+
+// parseConfigDuration accepts Go durations (300s) or plain seconds (30, 30_000).
+func parseConfigDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil {
+		return d, nil
+	}
+	cleaned := strings.ReplaceAll(value, "_", "")
+	seconds, err2 := strconv.Atoi(cleaned)
+	if err2 != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// hasCommentPrefix reports whether the trimmed line starts with the comment
+// prefix. Prefixes made of a single repeated character (e.g. "//", "--")
+// match that character repeated at least as many times as in the prefix.
+func hasCommentPrefix(line []byte, prefix string) bool {
+	return commentPrefixLen(line, prefix) > 0
+}
+
+// commentPrefixLen returns the length of the comment marker at the start of
+// line, or 0 when the line does not start with it.
+func commentPrefixLen(line []byte, prefix string) int {
+	if prefix == "" || len(line) < len(prefix) {
+		return 0
+	}
+
 	allSame := true
-	for _, c := range prefix {
-		if c != rune(prefix[0]) {
+	for i := 1; i < len(prefix); i++ {
+		if prefix[i] != prefix[0] {
 			allSame = false
 			break
 		}
 	}
 
-	if allSame {
-		minCount := len(prefix)
-		// prefix "//" -> regex becomes ^/{2,}
-		return regexp.MustCompile("^" + regexp.QuoteMeta(strings.Repeat(string(prefix[0]), minCount)) + "+")
+	if !allSame {
+		if bytes.HasPrefix(line, []byte(prefix)) {
+			return len(prefix)
+		}
+		return 0
+	}
+
+	n := 0
+	for n < len(line) && line[n] == prefix[0] {
+		n++
+	}
+	if n < len(prefix) {
+		return 0
 	}
-	// require exactly the configured prefix
-	return regexp.MustCompile("^" + regexp.QuoteMeta(prefix))
+	return n
 }
 
 // stripCommentPrefix removes the repeated comment marker (and an optional space) from the beginning of the trimmed line.
 func stripCommentPrefix(line []byte, prefix string) []byte {
-	allSame := true
-	for _, c := range prefix {
-		if c != rune(prefix[0]) {
-			allSame = false
-			break
-		}
+	n := commentPrefixLen(line, prefix)
+	if n == 0 {
+		return line
 	}
-	var re *regexp.Regexp
-	if allSame {
-		minCount := len(prefix)
-		re = regexp.MustCompile("^" + regexp.QuoteMeta(strings.Repeat(string(prefix[0]), minCount)) + `+\s?`)
-	} else {
-		re = regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `\s?`)
+	line = line[n:]
+	if len(line) > 0 && isSpace(line[0]) {
+		line = line[1:]
+	}
+	return line
+}
+
+// isSpace matches the RE2 \s class.
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\f', '\r':
+		return true
 	}
-	return re.ReplaceAll(line, []byte(""))
+	return false
 }
 
 // ScheduleQuotesProcessor ensures that schedule values
-// like @every are properly quoted so the parser does
-// not barf an error
+// like @every or macros like @daily_at(03:30) are properly
+// quoted so the parser does not barf an error
 type ScheduleQuotesProcessor struct{}
 
+var scheduleQuotesRegex = regexp.MustCompile(`(?m)^((?:(?:-+|#+|//)\s*)?)(schedule:\s*)(@(?:(?:(?:every(?:\s+\S+)?)|yearly|annually|monthly|weekly|daily|midnight|hourly|reboot)\b|\w+\().*)$`)
+
 func (s *ScheduleQuotesProcessor) Process(data []byte) ([]byte, error) {
-	re := regexp.MustCompile(`(?m)^((?:-+\s*)?)(schedule:\s*)(@(?:(?:every(?:\s+\S+)?)|yearly|annually|monthly|weekly|daily|midnight|hourly|reboot)\b.*)$`)
-	result := re.ReplaceAll(data, []byte(`${1}${2}"${3}"`))
+	if !bytes.Contains(data, []byte("schedule:")) {
+		return data, nil
+	}
+	result := scheduleQuotesRegex.ReplaceAll(data, []byte(`${1}${2}"${3}"`))
 	return result, nil
 }
@@ -2,6 +2,7 @@ package job_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-job"
 	"github.com/stretchr/testify/assert"
@@ -430,3 +431,109 @@ echo "Timeout with underscores"`)
 	assert.Equal(t, 30000, int(config.Timeout.Seconds()))
 	assert.Equal(t, "echo \"Timeout with underscores\"", script)
 }
+
+func TestYAMLMetadataParser_Parse_WarnAfter(t *testing.T) {
+	parser := job.NewYAMLMetadataParser()
+	content := []byte(`---
+timeout: 600s
+warn_after: 2m
+---
+echo "slow"`)
+
+	config, _, err := parser.Parse(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, config.WarnAfter)
+
+	_, _, err = parser.Parse([]byte(`---
+warn_after: "soon"
+---
+echo "broken"`))
+	assert.Error(t, err)
+}
+
+func TestYAMLMetadataParser_ParseScriptMatchesParse(t *testing.T) {
+	parser := job.NewYAMLMetadataParser()
+	sp, ok := any(parser).(interface {
+		ParseScript([]byte) (string, error)
+	})
+	if !ok {
+		t.Fatal("expected parser to implement ParseScript")
+	}
+
+	inputs := map[string]string{
+		"line_comments":       "// config\n// schedule: \"@daily\"\nconsole.log('a')\n",
+		"only_metadata":       "# config\n# retries: 2",
+		"block_trailing":      "/** config\n * retries: 2\n */",
+		"block_body":          "/** config schedule: \"@hourly\"\n * retries: 2\n */\nconsole.log('b')",
+		"yaml_unterminated":   "---\nretries: 3\n",
+		"yaml_body":           "---\nretries: 3\n---\n\n  indented\nbody",
+		"no_metadata":         "echo hi\n",
+		"repeated_sql_prefix": "---- config\n---- transaction: true\nSELECT 1;",
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			_, want, err := parser.Parse([]byte(input))
+			assert.NoError(t, err)
+			got, err := sp.ParseScript([]byte(input))
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestYAMLMetadataParser_Parse_RepeatedCommentPrefix(t *testing.T) {
+	parser := job.NewYAMLMetadataParser()
+	config, script, err := parser.Parse([]byte("---- config\n---- retries: 4\n--- timeout: 10s\nSELECT 1;"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, config.Retries)
+	assert.Equal(t, 10*time.Second, config.Timeout)
+	assert.Equal(t, "SELECT 1;", script)
+}
+
+func TestYAMLMetadataParser_NormalizesEnums(t *testing.T) {
+	content := []byte(`
+# config
+# retries: 2
+# backoff:
+#   strategy: Exponential
+#   interval: 200ms
+#   max_interval: 2s
+#   on_error:
+#     rate_limit:
+#       strategy: FIXED
+#       interval: 30s
+# metadata:
+#   dedup_policy: " Drop "
+
+echo hi
+`)
+	cfg, _, err := job.NewYAMLMetadataParser().Parse(content)
+	assert.NoError(t, err)
+	assert.Equal(t, job.BackoffExponential, cfg.Backoff.Strategy)
+	assert.Equal(t, 200*time.Millisecond, cfg.Backoff.Interval)
+	assert.Equal(t, 2*time.Second, cfg.Backoff.MaxInterval)
+	assert.Equal(t, job.BackoffConfig{Strategy: job.BackoffFixed, Interval: 30 * time.Second}, cfg.Backoff.OnError["rate_limit"])
+	assert.Equal(t, "drop", cfg.Metadata["dedup_policy"])
+}
+
+func TestYAMLMetadataParser_RejectsInvalidEnums(t *testing.T) {
+	content := []byte(`
+# config
+# backoff:
+#   strategy: linear
+#   on_error:
+#     SQL_DEADLOCK:
+#       strategy: random
+# metadata:
+#   dedup_policy: sometimes
+
+echo hi
+`)
+	_, _, err := job.NewYAMLMetadataParser().Parse(content)
+	assert.Error(t, err)
+	assert.ErrorContains(t, err, `backoff: invalid backoff strategy "linear", expected one of none|fixed|exponential`)
+	assert.ErrorContains(t, err, `backoff.on_error.SQL_DEADLOCK: invalid backoff strategy "random"`)
+	assert.ErrorContains(t, err, `invalid dedup_policy "sometimes", expected one of ignore|drop|merge|replace`)
+}
@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+	"strings"
+)
+
+// NamespaceAware task creators can implement this to prefix the IDs of the
+// tasks they discover.
+type NamespaceAware interface {
+	SetNamespace(prefix string)
+}
+
+// NamespaceTaskID prefixes id with namespace, leaving IDs that already carry
+// it untouched.
+func NamespaceTaskID(namespace, id string) string {
+	if namespace == "" || id == "" || strings.HasPrefix(id, namespace) {
+		return id
+	}
+	return namespace + id
+}
+
+// WithNamespacedTaskCreator registers creator with its task IDs prefixed by
+// namespace (e.g. "db:"), so several creators can feed one Runner without ID
+// collisions. Creators that do not implement NamespaceAware are registered
+// without a namespace and a warning is logged.
+func WithNamespacedTaskCreator(namespace string, creator TaskCreator) Option {
+	return func(r *Runner) {
+		if creator == nil {
+			return
+		}
+		if aware, ok := creator.(NamespaceAware); ok {
+			aware.SetNamespace(namespace)
+		} else if namespace != "" {
+			r.logger.Warn("task creator namespace ignored: creator does not support namespaces", "namespace", namespace)
+		}
+		WithTaskCreator(creator)(r)
+	}
+}
+
+// NamespacedScheduleLoader prefixes the schedule IDs and job references loaded
+// by loader with namespace, matching tasks discovered by a creator using the
+// same namespace.
+func NamespacedScheduleLoader(namespace string, loader ScheduleLoader) ScheduleLoader {
+	if namespace == "" || loader == nil {
+		return loader
+	}
+	return func(ctx context.Context) ([]ScheduleDefinition, error) {
+		defs, err := loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]ScheduleDefinition, len(defs))
+		for i, def := range defs {
+			def.ID = NamespaceTaskID(namespace, def.ID)
+			def.Message.JobID = NamespaceTaskID(namespace, def.Message.JobID)
+			out[i] = def
+		}
+		return out, nil
+	}
+}
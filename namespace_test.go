@@ -0,0 +1,57 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedTaskCreatorsAvoidCollisions(t *testing.T) {
+	fs := &staticSourceProvider{scripts: []job.ScriptInfo{{Path: "jobs/report.sh", Content: []byte("echo fs")}}}
+	db := &staticSourceProvider{scripts: []job.ScriptInfo{
+		{Path: "report.sh", Content: []byte("echo db")},
+		{Path: "notes.txt", Content: []byte("skip")},
+	}}
+	engine := job.NewShellRunner()
+
+	var skipped []string
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(fs, []job.Engine{engine})),
+		job.WithNamespacedTaskCreator("db:", job.NewTaskCreator(db, []job.Engine{engine})),
+		job.WithTaskEventHandler(func(event job.TaskEvent) {
+			if event.Type == job.TaskEventSkipped {
+				skipped = append(skipped, event.TaskID)
+			}
+		}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	var ids []string
+	for _, task := range runner.RegisteredTasks() {
+		ids = append(ids, task.GetID())
+	}
+	assert.Equal(t, []string{"db:report.sh", "report.sh"}, ids)
+	assert.Equal(t, []string{"db:notes.txt"}, skipped)
+}
+
+func TestNamespacedScheduleLoader(t *testing.T) {
+	loader := job.NamespacedScheduleLoader("db:", func(context.Context) ([]job.ScheduleDefinition, error) {
+		return []job.ScheduleDefinition{
+			{ID: "nightly", Expression: "@daily", Message: job.ExecutionMessage{JobID: "report.sh"}},
+			{ID: "db:hourly", Expression: "@hourly", Message: job.ExecutionMessage{JobID: "db:report.sh"}},
+		}, nil
+	})
+
+	defs, err := loader(context.Background())
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	assert.Equal(t, "db:nightly", defs[0].ID)
+	assert.Equal(t, "db:report.sh", defs[0].Message.JobID)
+	assert.Equal(t, "db:hourly", defs[1].ID)
+	assert.Equal(t, "db:report.sh", defs[1].Message.JobID)
+
+	assert.Equal(t, "report.sh", job.NamespaceTaskID("", "report.sh"))
+}
@@ -0,0 +1,213 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// NotificationStatus identifies the lifecycle event a notification reports.
+type NotificationStatus string
+
+const (
+	NotifySuccess NotificationStatus = "success"
+	NotifyFailure NotificationStatus = "failure"
+	NotifySlow    NotificationStatus = "slow"
+)
+
+// DefaultNotificationTemplate renders a one line summary of a Notification.
+const DefaultNotificationTemplate = `[{{.Status}}] job {{.JobID}}{{if .Duration}} after {{.Duration}}{{end}}{{if .Error}}: {{.Error}}{{end}}`
+
+// Notification describes a job lifecycle event delivered to Notifiers.
+type Notification struct {
+	Status     NotificationStatus `json:"status"`
+	JobID      string             `json:"job_id"`
+	RunID      string             `json:"run_id,omitempty"`
+	TraceID    string             `json:"trace_id,omitempty"`
+	ScriptPath string             `json:"script_path,omitempty"`
+	// ScriptChecksum identifies the script version that ran.
+	ScriptChecksum string        `json:"script_checksum,omitempty"`
+	Attempts       int           `json:"attempts,omitempty"`
+	Duration       time.Duration `json:"duration"`
+	Error          string        `json:"error,omitempty"`
+	Timestamp      time.Time     `json:"timestamp"`
+	// Owner and Team come from the job's ownership metadata and select the
+	// owner- and team-routed channels.
+	Owner string `json:"owner,omitempty"`
+	Team  string `json:"team,omitempty"`
+}
+
+// Notifier delivers job lifecycle notifications to an external channel.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, n Notification) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(ctx context.Context, n Notification) error {
+	if f == nil {
+		return nil
+	}
+	return f(ctx, n)
+}
+
+// NotifyPolicy selects which events are delivered and to which named notifiers.
+// It is read from the `notify` metadata key, which accepts a status list
+// ("failure", "failure,success", [failure, slow]) or a map with `on` and
+// `channels` keys. Without a policy only failures are notified, to every channel
+// (see OwnerChannel for owner-routed channels).
+type NotifyPolicy struct {
+	On       []NotificationStatus `json:"on"`
+	Channels []string             `json:"channels,omitempty"`
+}
+
+// NotifyPolicyFromConfig extracts the notify policy from task metadata.
+func NotifyPolicyFromConfig(cfg Config) NotifyPolicy {
+	policy := NotifyPolicy{On: []NotificationStatus{NotifyFailure}}
+	raw, ok := cfg.Metadata["notify"]
+	if !ok || raw == nil {
+		return policy
+	}
+
+	switch v := raw.(type) {
+	case bool:
+		if !v {
+			policy.On = nil
+		}
+	case string, []any, []string:
+		policy.On = toStatuses(stringList(v))
+	default:
+		fields, ok := toStringMap(v)
+		if !ok {
+			return policy
+		}
+		if on, ok := fields["on"]; ok {
+			policy.On = toStatuses(stringList(on))
+		}
+		if channels, ok := fields["channels"]; ok {
+			policy.Channels = stringList(channels)
+		}
+	}
+	return policy
+}
+
+// Wants reports whether status should be delivered to the named channel.
+func (p NotifyPolicy) Wants(status NotificationStatus, channel string) bool {
+	matched := false
+	for _, s := range p.On {
+		if s == status {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	if len(p.Channels) == 0 {
+		return true
+	}
+	for _, c := range p.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderNotification executes tmpl (DefaultNotificationTemplate when empty) against n.
+func RenderNotification(tmpl string, n Notification) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultNotificationTemplate
+	}
+	t, err := template.New("notification").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, n); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// dispatchNotification delivers n to the notifiers selected by policy and the
+// notification's owner and team. Delivery is best-effort: notifier errors
+// never fail the execution.
+func dispatchNotification(ctx context.Context, notifiers map[string]Notifier, policy NotifyPolicy, n Notification) {
+	if len(notifiers) == 0 {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithoutCancel(ctx)
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+
+	names := make([]string, 0, len(notifiers))
+	for name := range notifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range notificationChannels(names, policy, n) {
+		if notifier := notifiers[name]; notifier != nil {
+			_ = notifier.Notify(ctx, n)
+		}
+	}
+}
+
+func toStatuses(values []string) []NotificationStatus {
+	out := make([]NotificationStatus, 0, len(values))
+	for _, v := range values {
+		out = append(out, NotificationStatus(strings.ToLower(v)))
+	}
+	return out
+}
+
+func stringList(raw any) []string {
+	var items []string
+	switch v := raw.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []any:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	default:
+		return nil
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+// toStringMap normalises maps decoded from YAML (map[any]any) or JSON.
+func toStringMap(raw any) (map[string]any, bool) {
+	switch v := raw.(type) {
+	case map[string]any:
+		return v, true
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[fmt.Sprint(k)] = val
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
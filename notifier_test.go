@@ -0,0 +1,169 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingTask struct {
+	*stubTask
+	err error
+}
+
+func (t *failingTask) Execute(context.Context, *ExecutionMessage) error { return t.err }
+
+func TestNotifyPolicyFromConfig(t *testing.T) {
+	policy := NotifyPolicyFromConfig(Config{})
+	assert.True(t, policy.Wants(NotifyFailure, "slack"))
+	assert.False(t, policy.Wants(NotifySuccess, "slack"))
+
+	policy = NotifyPolicyFromConfig(Config{Metadata: map[string]any{"notify": "success, failure"}})
+	assert.True(t, policy.Wants(NotifySuccess, "any"))
+
+	policy = NotifyPolicyFromConfig(Config{Metadata: map[string]any{"notify": map[any]any{
+		"on":       []any{"slow"},
+		"channels": []any{"email"},
+	}}})
+	assert.True(t, policy.Wants(NotifySlow, "email"))
+	assert.False(t, policy.Wants(NotifySlow, "slack"))
+	assert.False(t, policy.Wants(NotifyFailure, "email"))
+
+	policy = NotifyPolicyFromConfig(Config{Metadata: map[string]any{"notify": false}})
+	assert.False(t, policy.Wants(NotifyFailure, "slack"))
+}
+
+func TestTaskCommanderNotifiesPerPolicy(t *testing.T) {
+	var got []Notification
+	record := NotifierFunc(func(_ context.Context, n Notification) error {
+		got = append(got, n)
+		return errors.New("delivery failures are ignored")
+	})
+
+	ok := newStubTask("ok-job", Config{})
+	require.NoError(t, NewTaskCommander(ok).WithNotifier("ops", record).Execute(context.Background(), &ExecutionMessage{}))
+	assert.Empty(t, got)
+
+	failing := &failingTask{stubTask: newStubTask("bad-job", Config{Retries: 1}), err: errors.New("boom")}
+	cmd := NewTaskCommander(failing).WithNotifier("ops", record)
+	require.Error(t, cmd.Execute(ContextWithRunID(context.Background(), "run-1"), &ExecutionMessage{
+		Config: Config{Backoff: BackoffConfig{Strategy: BackoffNone}},
+	}))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, NotifyFailure, got[0].Status)
+	assert.Equal(t, "bad-job", got[0].JobID)
+	assert.Equal(t, "run-1", got[0].RunID)
+	assert.Equal(t, 2, got[0].Attempts)
+	assert.Equal(t, "boom", got[0].Error)
+}
+
+func TestSlackAndWebhookNotifiers(t *testing.T) {
+	var bodies []map[string]any
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	n := Notification{Status: NotifyFailure, JobID: "nightly", Duration: 2 * time.Second, Error: "exit 1"}
+
+	require.NoError(t, NewSlackNotifier(server.URL).Notify(context.Background(), n))
+	require.NoError(t, NewWebhookNotifier(server.URL,
+		WithNotifierHeader("Authorization", "Bearer t"),
+		WithNotifierTemplate("{{.JobID}} {{.Status}}"),
+	).Notify(context.Background(), n))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "[failure] job nightly after 2s: exit 1", bodies[0]["text"])
+	assert.Equal(t, "nightly failure", bodies[1]["message"])
+	assert.Equal(t, "nightly", bodies[1]["job_id"])
+	assert.Equal(t, "Bearer t", auth)
+}
+
+func TestSMTPNotifierRendersMessage(t *testing.T) {
+	notifier := NewSMTPNotifier(SMTPConfig{Addr: "mail:25", From: "jobs@example.com", To: []string{"ops@example.com"}})
+	var sent string
+	notifier.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		sent = string(msg)
+		return nil
+	}
+
+	require.NoError(t, notifier.Notify(context.Background(), Notification{Status: NotifySuccess, JobID: "report"}))
+	assert.Contains(t, sent, "Subject: [success] job report\r\n")
+	assert.True(t, strings.HasSuffix(sent, "[success] job report\r\n"))
+}
+
+func TestNotificationsRouteByOwnership(t *testing.T) {
+	got := map[string][]string{}
+	record := func(channel string) Notifier {
+		return NotifierFunc(func(_ context.Context, n Notification) error {
+			got[channel] = append(got[channel], n.JobID)
+			return nil
+		})
+	}
+	run := func(task Task) {
+		cmd := NewTaskCommander(task).
+			WithNotifier("default", record("default")).
+			WithNotifier(TeamChannel("payments"), record("payments")).
+			WithNotifier(OwnerChannel("ana"), record("ana"))
+		require.Error(t, cmd.Execute(context.Background(), &ExecutionMessage{}))
+	}
+
+	boom := errors.New("boom")
+	run(&failingTask{stubTask: newStubTask("billing", Config{Metadata: map[string]any{"team": "payments", "owner": "ana"}}), err: boom})
+	run(&failingTask{stubTask: newStubTask("search", Config{Metadata: map[string]any{"team": "search"}}), err: boom})
+	run(&failingTask{stubTask: newStubTask("misc", Config{}), err: boom})
+
+	assert.Equal(t, map[string][]string{
+		"payments": {"billing"},
+		"ana":      {"billing"},
+		"default":  {"search", "misc"},
+	}, got)
+}
+
+func TestScheduleOwnershipOverridesTask(t *testing.T) {
+	reg := NewMemoryRegistry()
+	require.NoError(t, reg.Add(&failingTask{
+		stubTask: newStubTask("billing", Config{Metadata: map[string]any{"team": "payments"}}),
+		err:      errors.New("boom"),
+	}))
+
+	var got []Notification
+	scheduler := newStubScheduler()
+	manager := NewCronManager(reg, scheduler).
+		WithNotifier("default", NotifierFunc(func(context.Context, Notification) error {
+			t.Fatal("routed notification reached the default channel")
+			return nil
+		})).
+		WithNotifier(TeamChannel("ledger"), NotifierFunc(func(_ context.Context, n Notification) error {
+			got = append(got, n)
+			return nil
+		}))
+	require.NoError(t, manager.Register(context.Background(), ScheduleDefinition{
+		ID:         "nightly",
+		Expression: "@daily",
+		Team:       "ledger",
+		Message:    ExecutionMessage{JobID: "billing", Config: Config{Backoff: BackoffConfig{Strategy: BackoffNone}}},
+	}))
+	assert.Equal(t, "ledger", manager.List()[0].Team)
+
+	for _, run := range scheduler.jobs {
+		require.Error(t, run())
+	}
+	require.Len(t, got, 1)
+	assert.Equal(t, "ledger", got[0].Team)
+	assert.Equal(t, "billing", got[0].JobID)
+}
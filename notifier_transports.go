@@ -0,0 +1,190 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const defaultNotifierTimeout = 10 * time.Second
+
+// NotifierOption customises the built-in notifiers.
+type NotifierOption func(*notifierBase)
+
+// WithNotifierTemplate overrides the message template (text/template syntax over Notification).
+func WithNotifierTemplate(tmpl string) NotifierOption {
+	return func(b *notifierBase) {
+		if tmpl != "" {
+			b.template = tmpl
+		}
+	}
+}
+
+// WithNotifierHTTPClient sets the client used by HTTP based notifiers.
+func WithNotifierHTTPClient(client *http.Client) NotifierOption {
+	return func(b *notifierBase) {
+		if client != nil {
+			b.client = client
+		}
+	}
+}
+
+// WithNotifierHeader adds a header to webhook requests.
+func WithNotifierHeader(key, value string) NotifierOption {
+	return func(b *notifierBase) {
+		if b.headers == nil {
+			b.headers = make(http.Header)
+		}
+		b.headers.Add(key, value)
+	}
+}
+
+type notifierBase struct {
+	template string
+	client   *http.Client
+	headers  http.Header
+}
+
+func newNotifierBase(opts []NotifierOption) notifierBase {
+	b := notifierBase{
+		template: DefaultNotificationTemplate,
+		client:   &http.Client{Timeout: defaultNotifierTimeout},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&b)
+		}
+	}
+	return b
+}
+
+func (b notifierBase) postJSON(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range b.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackNotifier posts notifications to a Slack incoming webhook.
+type SlackNotifier struct {
+	notifierBase
+	webhookURL string
+}
+
+// NewSlackNotifier creates a notifier for the given Slack webhook URL.
+func NewSlackNotifier(webhookURL string, opts ...NotifierOption) *SlackNotifier {
+	return &SlackNotifier{notifierBase: newNotifierBase(opts), webhookURL: webhookURL}
+}
+
+// Notify implements Notifier.
+func (s *SlackNotifier) Notify(ctx context.Context, n Notification) error {
+	text, err := RenderNotification(s.template, n)
+	if err != nil {
+		return err
+	}
+	return s.postJSON(ctx, s.webhookURL, map[string]string{"text": text})
+}
+
+// WebhookNotifier posts the notification as JSON, plus the rendered message, to a URL.
+type WebhookNotifier struct {
+	notifierBase
+	url string
+}
+
+// NewWebhookNotifier creates a generic HTTP webhook notifier.
+func NewWebhookNotifier(url string, opts ...NotifierOption) *WebhookNotifier {
+	return &WebhookNotifier{notifierBase: newNotifierBase(opts), url: url}
+}
+
+type webhookPayload struct {
+	Notification
+	Message string `json:"message"`
+}
+
+// Notify implements Notifier.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	message, err := RenderNotification(w.template, n)
+	if err != nil {
+		return err
+	}
+	return w.postJSON(ctx, w.url, webhookPayload{Notification: n, Message: message})
+}
+
+// SMTPConfig holds the settings for SMTPNotifier.
+type SMTPConfig struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+	// Subject is a template rendered against the Notification.
+	Subject string
+}
+
+// SMTPNotifier sends notifications by email.
+type SMTPNotifier struct {
+	notifierBase
+	config   SMTPConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier creates an email notifier.
+func NewSMTPNotifier(config SMTPConfig, opts ...NotifierOption) *SMTPNotifier {
+	if config.Subject == "" {
+		config.Subject = "[{{.Status}}] job {{.JobID}}"
+	}
+	return &SMTPNotifier{notifierBase: newNotifierBase(opts), config: config, sendMail: smtp.SendMail}
+}
+
+// Notify implements Notifier.
+func (s *SMTPNotifier) Notify(_ context.Context, n Notification) error {
+	if len(s.config.To) == 0 {
+		return fmt.Errorf("smtp notifier has no recipients")
+	}
+	subject, err := RenderNotification(s.config.Subject, n)
+	if err != nil {
+		return err
+	}
+	body, err := RenderNotification(s.template, n)
+	if err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	msg.WriteString(body)
+	msg.WriteString("\r\n")
+
+	return s.sendMail(s.config.Addr, s.config.Auth, s.config.From, s.config.To, []byte(msg.String()))
+}
@@ -0,0 +1,86 @@
+package job
+
+import (
+	"maps"
+	"strings"
+)
+
+// Ownership metadata keys. Tasks declare them in their config metadata and
+// schedules through ScheduleDefinition.Owner and Team.
+const (
+	OwnerMetadataKey = "owner"
+	TeamMetadataKey  = "team"
+)
+
+// Channel prefixes for owner-routed notifiers. A notifier registered as
+// "team:payments" only receives notifications for jobs owned by the payments
+// team; jobs whose owner and team have no routed notifier fall back to the
+// unprefixed channels.
+const (
+	OwnerChannelPrefix = "owner:"
+	TeamChannelPrefix  = "team:"
+)
+
+// OwnerChannel names the notifier channel routed to owner.
+func OwnerChannel(owner string) string { return OwnerChannelPrefix + owner }
+
+// TeamChannel names the notifier channel routed to team.
+func TeamChannel(team string) string { return TeamChannelPrefix + team }
+
+// OwnershipFromConfig reads the owner and team metadata from cfg.
+func OwnershipFromConfig(cfg Config) (owner, team string) {
+	owner, _ = cfg.Metadata[OwnerMetadataKey].(string)
+	team, _ = cfg.Metadata[TeamMetadataKey].(string)
+	return strings.TrimSpace(owner), strings.TrimSpace(team)
+}
+
+// withOwnership returns cfg with owner and team metadata set, leaving cfg's
+// metadata map untouched. Empty values keep the existing metadata.
+func withOwnership(cfg Config, owner, team string) Config {
+	if owner == "" && team == "" {
+		return cfg
+	}
+	metadata := maps.Clone(cfg.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]any, 2)
+	}
+	if owner != "" {
+		metadata[OwnerMetadataKey] = owner
+	}
+	if team != "" {
+		metadata[TeamMetadataKey] = team
+	}
+	cfg.Metadata = metadata
+	return cfg
+}
+
+func isRoutedChannel(name string) bool {
+	return strings.HasPrefix(name, OwnerChannelPrefix) || strings.HasPrefix(name, TeamChannelPrefix)
+}
+
+// routesTo reports whether the routed channel name belongs to n's owner or team.
+func (n Notification) routesTo(name string) bool {
+	return (n.Owner != "" && name == OwnerChannel(n.Owner)) ||
+		(n.Team != "" && name == TeamChannel(n.Team))
+}
+
+// notificationChannels selects the channels, from sorted names, that receive
+// n: the owner and team channels when any wants it, else the unrouted ones.
+func notificationChannels(names []string, policy NotifyPolicy, n Notification) []string {
+	var routed, fallback []string
+	for _, name := range names {
+		if !policy.Wants(n.Status, name) {
+			continue
+		}
+		switch {
+		case !isRoutedChannel(name):
+			fallback = append(fallback, name)
+		case n.routesTo(name):
+			routed = append(routed, name)
+		}
+	}
+	if len(routed) > 0 {
+		return routed
+	}
+	return fallback
+}
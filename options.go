@@ -1,5 +1,7 @@
 package job
 
+import "time"
+
 type Option func(*Runner)
 
 func WithLoggerProvider(provider LoggerProvider) Option {
@@ -61,3 +63,129 @@ func WithTaskEventHandler(handler TaskEventHandler) Option {
 		r.propagateTaskEventHandler(handler)
 	}
 }
+
+// WithDuplicateIDStrategy sets how discovery handles scripts resolving to an
+// already registered task ID. The default is DuplicateIDError.
+func WithDuplicateIDStrategy(strategy DuplicateIDStrategy) Option {
+	return func(r *Runner) {
+		r.duplicateIDs = strategy
+	}
+}
+
+// WithStrictStartup makes Start return an aggregated error when any script fails
+// to parse or register, so boots can refuse to run with a broken jobs directory.
+// Tasks that did register remain in the registry.
+func WithStrictStartup() Option {
+	return func(r *Runner) {
+		r.strictStartup = true
+	}
+}
+
+// WithConfigDefaults sets site-wide config applied to every discovered script
+// beneath its own metadata, replacing defaults configured on the task creators.
+func WithConfigDefaults(cfg Config) Option {
+	return func(r *Runner) {
+		r.configDefaults.Global = cfg
+		r.propagateConfigDefaults()
+	}
+}
+
+// WithEngineConfigDefaults sets a per-engine profile, e.g. a 5m timeout for
+// "sql" and 1m for "shell", layered over WithConfigDefaults.
+func WithEngineConfigDefaults(engineType string, cfg Config) Option {
+	return func(r *Runner) {
+		r.configDefaults = r.configDefaults.withEngine(engineType, cfg)
+		r.propagateConfigDefaults()
+	}
+}
+
+// WithSecretsProvider sets the provider engines use to resolve `${secret:NAME}`
+// references in env values, SQL DSNs and the JS `job.secret()` binding.
+func WithSecretsProvider(provider SecretsProvider) Option {
+	return func(r *Runner) {
+		r.secrets = provider
+		r.propagateSecretsProvider()
+	}
+}
+
+// WithProfileStore sets the connection profiles scripts select with the
+// `profile` metadata key, e.g. "analytics-ro" or "billing-rw".
+func WithProfileStore(store ProfileStore) Option {
+	return func(r *Runner) {
+		r.profiles = store
+		r.propagateProfileStore()
+	}
+}
+
+// WithJobInvocation lets scripts run other registered jobs: JS jobs through
+// `jobs.run(id, params)` and shell jobs through the control socket in
+// JOB_CONTROL_SOCKET. Jobs are resolved from the runner's registry, run
+// through Runner.Commander, so disabled jobs and maintenance mode are
+// honoured, and waited results are stored with SetResult once, after
+// redaction and result processors.
+func WithJobInvocation() Option {
+	return func(r *Runner) {
+		r.invoker = &RegistryInvoker{
+			registry:  func() Registry { return r.registry },
+			commander: r.Commander,
+		}
+		r.propagateJobInvoker()
+	}
+}
+
+// WithJobInvoker sets a custom invoker for script-to-script invocation.
+func WithJobInvoker(invoker JobInvoker) Option {
+	return func(r *Runner) {
+		r.invoker = invoker
+		r.propagateJobInvoker()
+	}
+}
+
+// WithOverrideStore merges operator overrides (schedule, timeout, retries) on
+// top of script metadata at discovery time, keyed by task ID.
+func WithOverrideStore(store OverrideStore) Option {
+	return func(r *Runner) {
+		r.overrides = store
+		r.propagateOverrideStore()
+	}
+}
+
+// WithResultRedactor sets the sanitizer applied to Result metadata stored via
+// Runner.SetResult, e.g. RedactingSanitizer("*token*", "db.password").
+// Defaults to RedactingSanitizer with DefaultRedactKeys; nil disables it.
+func WithResultRedactor(sanitizer EnvelopeSanitizer) Option {
+	return func(r *Runner) {
+		r.resultRedactor = sanitizer
+	}
+}
+
+// WithCronManager attaches the CronManager driving schedules so Health can report
+// scheduler attachment and the last reconcile outcome.
+func WithCronManager(manager *CronManager) Option {
+	return func(r *Runner) {
+		r.cronManager = manager
+	}
+}
+
+// WithHealthCheck registers a named dependency probe (e.g. store connectivity)
+// evaluated on every Runner.Health call. Engine checks use the "engine:"
+// namespace; a check whose name is already taken is reported as name#2.
+func WithHealthCheck(name string, check HealthCheck) Option {
+	return func(r *Runner) {
+		if name == "" || check == nil {
+			return
+		}
+		if r.healthChecks == nil {
+			r.healthChecks = make(map[string]HealthCheck)
+		}
+		r.healthChecks[name] = check
+	}
+}
+
+// WithHealthCheckTimeout bounds each health check run by Health and at
+// startup; defaults to DefaultHealthCheckTimeout.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(r *Runner) {
+		r.healthTimeout = timeout
+	}
+}
@@ -0,0 +1,93 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ResultStatusFailed is the Result status recorded when an execution fails.
+const ResultStatusFailed = "failed"
+
+// ResultRecorder stores the latest Result per job; Runner satisfies it.
+type ResultRecorder interface {
+	SetResult(jobID string, result Result) error
+}
+
+// IsPanic reports whether err was produced by recovering a panicking task.
+func IsPanic(err error) bool {
+	var target *errors.Error
+	return stderrors.As(err, &target) && target.TextCode == "JOB_PANIC"
+}
+
+// recoverExecution runs fn, converting a panic into a JOB_PANIC error that
+// carries the recovered value and the stack trace of the panicking goroutine.
+func recoverExecution(jobID string, fn func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(jobID, recovered)
+		}
+	}()
+	return fn()
+}
+
+func panicError(jobID string, recovered any) error {
+	if err, ok := recovered.(error); ok && IsPanic(err) {
+		return err
+	}
+	return errors.New(fmt.Sprintf("task panicked: %v", recovered), errors.CategoryInternal).
+		WithTextCode("JOB_PANIC").
+		WithSeverity(errors.SeverityCritical).
+		WithStackTrace().
+		WithMetadata(map[string]any{
+			"job_id": jobID,
+			"panic":  fmt.Sprint(recovered),
+		})
+}
+
+// WithResultRecorder sets where the commander records failed and skipped
+// executions. Without one, a TaskToggler that also records results is used.
+func (c *TaskCommander) WithResultRecorder(results ResultRecorder) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.results = results
+	return c
+}
+
+// WithResultRedactor sets the sanitizer applied to the metadata of results
+// the commander records. Defaults to RedactingSanitizer with
+// DefaultRedactKeys; nil disables it.
+func (c *TaskCommander) WithResultRedactor(sanitizer EnvelopeSanitizer) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.redactor = sanitizer
+	return c
+}
+
+func (c *TaskCommander) recordResult(jobID string, result Result) {
+	result = RedactResult(result, c.redactor)
+	results := c.results
+	if results == nil {
+		results, _ = c.toggles.(ResultRecorder)
+	}
+	if results != nil {
+		_ = results.SetResult(jobID, result)
+	}
+}
+
+func (c *TaskCommander) recordPanic(_ context.Context, msg *ExecutionMessage, err error) {
+	metadata := map[string]any{"error_code": "JOB_PANIC"}
+	var target *errors.Error
+	if stderrors.As(err, &target) {
+		metadata["panic"] = target.Metadata["panic"]
+	}
+	c.recordResult(msg.JobID, Result{
+		Status:   ResultStatusFailed,
+		Message:  err.Error(),
+		Metadata: metadata,
+	})
+}
@@ -0,0 +1,69 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	goerrors "github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panickingEngine struct {
+	noopEngine
+	calls *int
+}
+
+func (e panickingEngine) Execute(context.Context, *job.ExecutionMessage) error {
+	*e.calls++
+	var m map[string]int
+	m["boom"] = 1
+	return nil
+}
+
+type panickingTask struct {
+	builderTask
+}
+
+func (panickingTask) Execute(context.Context, *job.ExecutionMessage) error {
+	panic("custom task exploded")
+}
+
+func TestBaseTaskRecoversEnginePanic(t *testing.T) {
+	calls := 0
+	task := job.NewBaseTask("nil-map", "/tmp/nil-map.sh", "shell",
+		job.Config{Retries: 2}, "noop", panickingEngine{calls: &calls})
+	runner := job.NewRunner()
+	cmd := job.NewTaskCommander(task).WithResultRecorder(runner)
+
+	err := cmd.Execute(context.Background(), &job.ExecutionMessage{})
+	require.Error(t, err)
+	assert.True(t, job.IsPanic(err))
+	assert.Contains(t, err.Error(), "assignment to entry in nil map")
+	assert.Equal(t, 1, calls, "panics are not retried")
+
+	var typed *goerrors.Error
+	require.ErrorAs(t, err, &typed)
+	assert.Equal(t, goerrors.CategoryInternal, typed.Category)
+	assert.NotEmpty(t, typed.StackTrace)
+	assert.Equal(t, "nil-map", typed.Metadata["job_id"])
+
+	result, ok := runner.GetResult("nil-map")
+	require.True(t, ok)
+	assert.Equal(t, job.ResultStatusFailed, result.Status)
+	assert.Equal(t, "JOB_PANIC", result.Metadata["error_code"])
+}
+
+func TestTaskCommanderRecoversTaskPanic(t *testing.T) {
+	task := panickingTask{builderTask{id: "custom", path: "/tmp/custom.js"}}
+	cmd := job.NewTaskCommander(task)
+
+	var err error
+	require.NotPanics(t, func() {
+		err = cmd.Execute(context.Background(), &job.ExecutionMessage{JobID: "custom", ScriptPath: "/tmp/custom.js"})
+	})
+	assert.True(t, job.IsPanic(err))
+	assert.Contains(t, err.Error(), "custom task exploded")
+	assert.False(t, job.IsPanic(context.Canceled))
+}
@@ -0,0 +1,278 @@
+package job
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ParamsMetadataKey is the metadata key declaring a task's parameters.
+const ParamsMetadataKey = "params"
+
+// Parameter types understood by ParamSchema.
+const (
+	ParamTypeString   = "string"
+	ParamTypeInt      = "int"
+	ParamTypeFloat    = "float"
+	ParamTypeBool     = "bool"
+	ParamTypeDuration = "duration"
+	ParamTypeList     = "list"
+	ParamTypeAny      = "any"
+)
+
+// ParamSpec declares one task parameter.
+type ParamSpec struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Default     any    `json:"default,omitempty" yaml:"default,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ParamSchema is the parameter declaration of a task, ordered by name.
+type ParamSchema []ParamSpec
+
+// ParamSchemaFromConfig reads the `params` metadata. Parameters can be
+// declared as a map keyed by name, whose values are either a spec or just a
+// type name, or as a list of specs carrying a `name`:
+//
+//	metadata:
+//	  params:
+//	    tenant: {type: string, required: true}
+//	    days: {type: int, default: 7}
+//	    dry_run: bool
+func ParamSchemaFromConfig(cfg Config) (ParamSchema, error) {
+	raw, ok := cfg.Metadata[ParamsMetadataKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	var schema ParamSchema
+	if entries, ok := toStringMap(raw); ok {
+		for name, value := range entries {
+			spec, err := parseParamSpec(name, value)
+			if err != nil {
+				return nil, err
+			}
+			schema = append(schema, spec)
+		}
+	} else if items, ok := raw.([]any); ok {
+		for _, item := range items {
+			fields, ok := toStringMap(item)
+			if !ok {
+				return nil, paramSchemaError(fmt.Sprintf("invalid parameter declaration %v", item))
+			}
+			name, _ := fields["name"].(string)
+			spec, err := parseParamSpec(name, fields)
+			if err != nil {
+				return nil, err
+			}
+			schema = append(schema, spec)
+		}
+	} else {
+		return nil, paramSchemaError(fmt.Sprintf("params must be a map or a list, got %T", raw))
+	}
+
+	sort.Slice(schema, func(i, j int) bool { return schema[i].Name < schema[j].Name })
+	return schema, nil
+}
+
+// TaskParamSchema returns the parameter schema declared by task.
+func TaskParamSchema(task Task) (ParamSchema, error) {
+	if task == nil {
+		return nil, nil
+	}
+	return ParamSchemaFromConfig(task.GetConfig())
+}
+
+func parseParamSpec(name string, value any) (ParamSpec, error) {
+	if name == "" {
+		return ParamSpec{}, paramSchemaError("parameter declaration without a name")
+	}
+	spec := ParamSpec{Name: name, Type: ParamTypeAny}
+	switch v := value.(type) {
+	case nil:
+	case string:
+		spec.Type = v
+	default:
+		fields, ok := toStringMap(v)
+		if !ok {
+			return ParamSpec{}, paramSchemaError(fmt.Sprintf("invalid declaration for parameter %q", name))
+		}
+		if typ, ok := fields["type"].(string); ok && typ != "" {
+			spec.Type = typ
+		}
+		spec.Required, _ = fields["required"].(bool)
+		spec.Default = fields["default"]
+		spec.Description, _ = fields["description"].(string)
+	}
+	spec.Type = strings.ToLower(spec.Type)
+	if _, err := coerceParam(spec.Type, ""); err == errUnknownParamType {
+		return ParamSpec{}, paramSchemaError(fmt.Sprintf("parameter %q has unknown type %q", name, spec.Type))
+	}
+	return spec, nil
+}
+
+func paramSchemaError(msg string) error {
+	return errors.New("invalid params metadata: "+msg, errors.CategoryBadInput).
+		WithTextCode("JOB_PARAMS_SCHEMA_INVALID")
+}
+
+// Apply validates params against the schema: values are coerced to their
+// declared type (so "7" becomes 7 for an int), defaults fill absent
+// parameters and every missing required parameter is listed in a single
+// JOB_PARAMS_INVALID validation error. Undeclared parameters pass through
+// unchanged. The input map is not modified.
+func (s ParamSchema) Apply(params map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(params)+len(s))
+	for key, value := range params {
+		out[key] = value
+	}
+
+	var (
+		fieldErrors []errors.FieldError
+		missing     []string
+	)
+	for _, spec := range s {
+		value, ok := out[spec.Name]
+		if !ok || value == nil {
+			switch {
+			case spec.Default != nil:
+				value = spec.Default
+			case spec.Required:
+				missing = append(missing, spec.Name)
+				fieldErrors = append(fieldErrors, errors.FieldError{Field: spec.Name, Message: "is required"})
+				continue
+			default:
+				continue
+			}
+		}
+		coerced, err := coerceParam(spec.Type, value)
+		if err != nil {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: spec.Name, Message: err.Error(), Value: value})
+			continue
+		}
+		out[spec.Name] = coerced
+	}
+
+	if len(fieldErrors) == 0 {
+		return out, nil
+	}
+	msg := "invalid job parameters"
+	if len(missing) > 0 {
+		msg = "missing required parameters: " + strings.Join(missing, ", ")
+	}
+	return nil, errors.NewValidation(msg, fieldErrors...).
+		WithTextCode("JOB_PARAMS_INVALID").
+		WithMetadata(map[string]any{"missing": missing})
+}
+
+// Missing lists the required parameters without a value or default in params.
+func (s ParamSchema) Missing(params map[string]any) []string {
+	var missing []string
+	for _, spec := range s {
+		if _, ok := params[spec.Name]; !ok && spec.Required && spec.Default == nil {
+			missing = append(missing, spec.Name)
+		}
+	}
+	return missing
+}
+
+// ValidateTaskParams applies the task's parameter schema to params.
+func ValidateTaskParams(task Task, params map[string]any) (map[string]any, error) {
+	schema, err := TaskParamSchema(task)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Apply(params)
+}
+
+// ParseParamFlags turns repeated `key=value` flags into a parameter map.
+// Values stay strings; ParamSchema.Apply coerces them.
+func ParseParamFlags(flags []string) (map[string]any, error) {
+	params := make(map[string]any, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errors.New(fmt.Sprintf("invalid parameter %q, expected key=value", flag), errors.CategoryBadInput).
+				WithTextCode("JOB_PARAMS_INVALID")
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+var errUnknownParamType = fmt.Errorf("unknown parameter type")
+
+func coerceParam(typ string, value any) (any, error) {
+	switch typ {
+	case "", ParamTypeAny:
+		return value, nil
+	case ParamTypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(value), nil
+	case ParamTypeInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return n, nil
+			}
+		}
+		return nil, fmt.Errorf("expected an integer")
+	case ParamTypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a number")
+	case ParamTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("expected true or false")
+	case ParamTypeDuration:
+		switch v := value.(type) {
+		case time.Duration:
+			return v, nil
+		case string:
+			if d, err := time.ParseDuration(strings.TrimSpace(v)); err == nil {
+				return d, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a duration such as 90s")
+	case ParamTypeList:
+		if list := stringList(value); list != nil {
+			return list, nil
+		}
+		return nil, fmt.Errorf("expected a list")
+	default:
+		return nil, errUnknownParamType
+	}
+}
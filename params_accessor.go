@@ -0,0 +1,199 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/goliatone/go-errors"
+)
+
+// Params gives typed access to execution parameters. Getters return the
+// default when the parameter is absent or nil, and the default with a
+// JOB_PARAMS_INVALID error when it cannot be coerced. Values are coerced like
+// ParamSchema.Apply, so "10" reads as an int.
+type Params map[string]any
+
+// ParamsFrom returns the parameters of msg.
+func ParamsFrom(msg *ExecutionMessage) Params {
+	if msg == nil {
+		return nil
+	}
+	return Params(msg.Parameters)
+}
+
+// Has reports whether key is set to a non-nil value.
+func (p Params) Has(key string) bool {
+	return p[key] != nil
+}
+
+// Get returns the raw value of key, or def.
+func (p Params) Get(key string, def any) any {
+	if value := p[key]; value != nil {
+		return value
+	}
+	return def
+}
+
+// GetString returns key as a string; non-string values are formatted.
+func (p Params) GetString(key, def string) (string, error) {
+	return getParam(p, key, ParamTypeString, def)
+}
+
+// GetInt returns key as an int.
+func (p Params) GetInt(key string, def int) (int, error) {
+	return getParam(p, key, ParamTypeInt, def)
+}
+
+// GetFloat returns key as a float64.
+func (p Params) GetFloat(key string, def float64) (float64, error) {
+	return getParam(p, key, ParamTypeFloat, def)
+}
+
+// GetBool returns key as a bool; strings are parsed with strconv.ParseBool.
+func (p Params) GetBool(key string, def bool) (bool, error) {
+	return getParam(p, key, ParamTypeBool, def)
+}
+
+// GetDuration returns key as a duration such as "90s".
+func (p Params) GetDuration(key string, def time.Duration) (time.Duration, error) {
+	return getParam(p, key, ParamTypeDuration, def)
+}
+
+// GetList returns key as a list of strings; a string is split on commas.
+func (p Params) GetList(key string, def []string) ([]string, error) {
+	return getParam(p, key, ParamTypeList, def)
+}
+
+// GetTime returns key as a time. It accepts RFC 3339 timestamps, dates
+// (2006-01-02) and Unix seconds.
+func (p Params) GetTime(key string, def time.Time) (time.Time, error) {
+	value := p[key]
+	if value == nil {
+		return def, nil
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case int:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case float64:
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+	case string:
+		s := strings.TrimSpace(v)
+		for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return def, paramValueError(key, value, "expected an RFC 3339 time, a date or Unix seconds")
+}
+
+// GetJSON decodes key into target, which keeps its current value when the
+// parameter is absent. Strings are decoded as JSON documents; other values
+// are converted through their JSON encoding.
+func (p Params) GetJSON(key string, target any) error {
+	value := p[key]
+	if value == nil {
+		return nil
+	}
+	var raw []byte
+	if s, ok := value.(string); ok {
+		raw = []byte(s)
+	} else {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return paramValueError(key, value, err.Error())
+		}
+		raw = encoded
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return paramValueError(key, value, fmt.Sprintf("invalid JSON: %v", err))
+	}
+	return nil
+}
+
+func getParam[T any](p Params, key, typ string, def T) (T, error) {
+	value := p[key]
+	if value == nil {
+		return def, nil
+	}
+	coerced, err := coerceParam(typ, value)
+	if err != nil {
+		return def, paramValueError(key, value, err.Error())
+	}
+	return coerced.(T), nil
+}
+
+func paramValueError(key string, value any, msg string) error {
+	return errors.NewValidation(fmt.Sprintf("invalid parameter %s: %s", key, msg),
+		errors.FieldError{Field: key, Message: msg, Value: value},
+	).WithTextCode("JOB_PARAMS_INVALID")
+}
+
+// bindJSParams exposes p to scripts as the `params` object: params.string,
+// params.int, params.float, params.bool, params.list and params.json take a
+// name and an optional default; params.duration returns milliseconds and
+// params.time a Date. Coercion errors are thrown.
+func bindJSParams(vm *goja.Runtime, p Params) (*goja.Object, error) {
+	obj := vm.NewObject()
+	bindings := map[string]any{
+		"has": p.Has,
+		"get": p.Get,
+		"string": func(key string, def string) (string, error) {
+			return p.GetString(key, def)
+		},
+		"int": func(key string, def int) (int, error) {
+			return p.GetInt(key, def)
+		},
+		"float": func(key string, def float64) (float64, error) {
+			return p.GetFloat(key, def)
+		},
+		"bool": func(key string, def bool) (bool, error) {
+			return p.GetBool(key, def)
+		},
+		"list": func(key string, def []string) ([]string, error) {
+			return p.GetList(key, def)
+		},
+		"duration": func(key string, defMillis int64) (int64, error) {
+			d, err := p.GetDuration(key, time.Duration(defMillis)*time.Millisecond)
+			return d.Milliseconds(), err
+		},
+		"time": func(call goja.FunctionCall) goja.Value {
+			key := call.Argument(0).String()
+			if !p.Has(key) {
+				return call.Argument(1)
+			}
+			t, err := p.GetTime(key, time.Time{})
+			if err != nil {
+				panic(vm.NewGoError(err))
+			}
+			date, err := vm.New(vm.Get("Date"), vm.ToValue(t.UnixMilli()))
+			if err != nil {
+				panic(err)
+			}
+			return date
+		},
+		"json": func(key string, def any) (any, error) {
+			if !p.Has(key) {
+				return def, nil
+			}
+			var out any
+			err := p.GetJSON(key, &out)
+			return out, err
+		},
+	}
+	for name, fn := range bindings {
+		if err := obj.Set(name, fn); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsTypedGetters(t *testing.T) {
+	p := Params{
+		"limit":   "25",
+		"ratio":   0.5,
+		"dry_run": "true",
+		"window":  "90s",
+		"tags":    "a, b",
+		"since":   "2026-01-02",
+		"at":      int64(1767225600),
+		"filter":  `{"status":"active"}`,
+		"bad":     "many",
+	}
+
+	limit, err := p.GetInt("limit", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 25, limit)
+
+	missing, err := p.GetInt("missing", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, missing)
+
+	bad, err := p.GetInt("bad", 10)
+	assert.Equal(t, 10, bad)
+	assert.ErrorContains(t, err, "invalid parameter bad")
+
+	ratio, _ := p.GetFloat("ratio", 0)
+	dryRun, _ := p.GetBool("dry_run", false)
+	window, _ := p.GetDuration("window", 0)
+	tags, _ := p.GetList("tags", nil)
+	name, _ := p.GetString("limit", "")
+	assert.Equal(t, 0.5, ratio)
+	assert.True(t, dryRun)
+	assert.Equal(t, 90*time.Second, window)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Equal(t, "25", name)
+
+	since, err := p.GetTime("since", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), since)
+	at, err := p.GetTime("at", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), at)
+	_, err = p.GetTime("bad", time.Time{})
+	assert.Error(t, err)
+
+	filter := map[string]string{"status": "all", "region": "eu"}
+	require.NoError(t, p.GetJSON("filter", &filter))
+	assert.Equal(t, map[string]string{"status": "active", "region": "eu"}, filter)
+	assert.Error(t, p.GetJSON("bad", &filter))
+}
+
+func TestJSParamsAccessor(t *testing.T) {
+	events := &jsEvents{}
+	script := `
+record("limit:" + params.int("limit", 10));
+record("page:" + params.int("page", 1));
+record("dry:" + params.bool("dry_run", false));
+record("window:" + params.duration("window", 0));
+record("since:" + params.time("since").toISOString());
+record("filter:" + params.json("filter").status);
+try { params.int("bad", 0); } catch (e) { record("threw"); }
+`
+	err := NewJSRunner().Execute(context.Background(), &ExecutionMessage{
+		JobID:      "params",
+		ScriptPath: "params.js",
+		Parameters: map[string]any{
+			"record":  events.record,
+			"script":  script,
+			"limit":   "25",
+			"dry_run": true,
+			"window":  "2s",
+			"since":   "2026-01-02T03:04:05Z",
+			"filter":  map[string]any{"status": "active"},
+			"bad":     "many",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"limit:25",
+		"page:1",
+		"dry:true",
+		"window:2000",
+		"since:2026-01-02T03:04:05.000Z",
+		"filter:active",
+		"threw",
+	}, events.list())
+}
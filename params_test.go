@@ -0,0 +1,90 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamSchemaFromMetadata(t *testing.T) {
+	content := []byte(`// config
+// metadata:
+//   params:
+//     tenant: {type: string, required: true, description: Tenant slug}
+//     days: {type: int, default: 7}
+//     window: duration
+//     dry_run: bool
+console.log("ok")
+`)
+	task, err := job.NewJSRunner().ParseJob("jobs/backfill.js", content)
+	require.NoError(t, err)
+
+	schema, err := job.TaskParamSchema(task)
+	require.NoError(t, err)
+	require.Len(t, schema, 4)
+	assert.Equal(t, job.ParamSpec{Name: "days", Type: job.ParamTypeInt, Default: 7}, schema[0])
+	assert.Equal(t, "tenant", schema[2].Name)
+	assert.True(t, schema[2].Required)
+
+	params, err := schema.Apply(map[string]any{"tenant": "acme", "dry_run": "true", "window": "90s", "extra": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"tenant":  "acme",
+		"days":    7,
+		"dry_run": true,
+		"window":  90 * time.Second,
+		"extra":   "x",
+	}, params)
+}
+
+func TestParamSchemaApplyReportsErrors(t *testing.T) {
+	schema, err := job.ParamSchemaFromConfig(job.Config{Metadata: map[string]any{
+		"params": []any{
+			map[string]any{"name": "tenant", "required": true},
+			map[string]any{"name": "region", "type": "string", "required": true},
+			map[string]any{"name": "days", "type": "int"},
+		},
+	}})
+	require.NoError(t, err)
+
+	_, err = schema.Apply(map[string]any{"days": "seven"})
+	var target *errors.Error
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_PARAMS_INVALID", target.TextCode)
+	assert.Equal(t, "missing required parameters: region, tenant", target.Message)
+	assert.Len(t, target.ValidationErrors, 3)
+
+	_, err = job.ParamSchemaFromConfig(job.Config{Metadata: map[string]any{"params": map[string]any{"x": "uuid"}}})
+	require.Error(t, err)
+}
+
+func TestParseParamFlags(t *testing.T) {
+	params, err := job.ParseParamFlags([]string{"tenant=acme", "query=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"tenant": "acme", "query": "a=b"}, params)
+
+	_, err = job.ParseParamFlags([]string{"tenant"})
+	require.Error(t, err)
+}
+
+func TestRunTaskCommandValidatesParams(t *testing.T) {
+	engine := &recordingEngine{}
+	registry := job.NewMemoryRegistry()
+	cfg := job.Config{Metadata: map[string]any{"params": map[string]any{
+		"days": map[string]any{"type": "int", "required": true},
+	}}}
+	require.NoError(t, registry.Add(job.NewBaseTask("backfill", "jobs/backfill.js", "js", cfg, "", engine)))
+	cmd := job.NewRunTaskCommand(registry)
+
+	require.Error(t, cmd.Run(context.Background(), "backfill", map[string]any{}))
+	assert.Nil(t, engine.lastMsg)
+
+	require.NoError(t, cmd.Run(context.Background(), "backfill", map[string]any{"days": "3"}))
+	assert.Equal(t, 3, engine.lastMsg.Parameters["days"])
+}
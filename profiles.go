@@ -0,0 +1,127 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-errors"
+)
+
+// ProfileMetadataKey names the script metadata key selecting a connection
+// profile, e.g. `profile: analytics-ro`.
+const ProfileMetadataKey = "profile"
+
+// ConnectionProfile is a named set of connection details supplied at runtime,
+// so scripts reference "billing-rw" instead of embedding a DSN. Driver and DSN
+// are used by the SQL engine; Env is merged beneath the script's own env for
+// every engine. DSN and Env values may contain `${secret:NAME}` references.
+type ConnectionProfile struct {
+	Name   string            `json:"name" yaml:"name"`
+	Driver string            `json:"driver,omitempty" yaml:"driver,omitempty"`
+	DSN    string            `json:"dsn,omitempty" yaml:"dsn,omitempty"`
+	Env    map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// ProfileStore resolves connection profiles by name.
+type ProfileStore interface {
+	GetProfile(ctx context.Context, name string) (ConnectionProfile, error)
+}
+
+// ProfileStoreAware engines and task creators accept a ProfileStore.
+type ProfileStoreAware interface {
+	SetProfileStore(ProfileStore)
+}
+
+// MemoryProfileStore keeps profiles in memory; safe for concurrent use, so
+// profiles can be added once credentials become available.
+type MemoryProfileStore struct {
+	mu       sync.RWMutex
+	profiles map[string]ConnectionProfile
+}
+
+// NewMemoryProfileStore returns a store holding profiles.
+func NewMemoryProfileStore(profiles ...ConnectionProfile) *MemoryProfileStore {
+	s := &MemoryProfileStore{profiles: make(map[string]ConnectionProfile)}
+	for _, profile := range profiles {
+		s.Set(profile)
+	}
+	return s
+}
+
+// Set stores profile under its name, replacing any previous one.
+func (s *MemoryProfileStore) Set(profile ConnectionProfile) {
+	profile.Env = copyStringMap(profile.Env)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[profile.Name] = profile
+}
+
+// Delete removes the profile called name.
+func (s *MemoryProfileStore) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.profiles, name)
+}
+
+// Names returns the stored profile names, sorted.
+func (s *MemoryProfileStore) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.profiles))
+	for name := range s.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetProfile implements ProfileStore.
+func (s *MemoryProfileStore) GetProfile(_ context.Context, name string) (ConnectionProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	profile, ok := s.profiles[name]
+	if !ok {
+		return ConnectionProfile{}, profileNotFound(name)
+	}
+	profile.Env = copyStringMap(profile.Env)
+	return profile, nil
+}
+
+// TaskProfile returns the profile name declared in cfg metadata.
+func TaskProfile(cfg Config) string {
+	name, _ := cfg.Metadata[ProfileMetadataKey].(string)
+	return strings.TrimSpace(name)
+}
+
+// resolveProfile looks up the profile named in cfg and resolves secret
+// references in its env; the SQL engine resolves the DSN when it connects.
+// ok is false when cfg names no profile.
+func resolveProfile(ctx context.Context, store ProfileStore, secrets SecretsProvider, cfg Config) (profile ConnectionProfile, ok bool, err error) {
+	name := TaskProfile(cfg)
+	if name == "" {
+		return ConnectionProfile{}, false, nil
+	}
+	if store == nil {
+		return ConnectionProfile{}, false, errors.New(fmt.Sprintf("profile %q requested but no profile store configured", name), errors.CategoryBadInput).
+			WithTextCode("JOB_PROFILE_STORE_MISSING").
+			WithMetadata(map[string]any{"profile": name})
+	}
+
+	profile, err = store.GetProfile(ctx, name)
+	if err != nil {
+		return ConnectionProfile{}, false, err
+	}
+	if profile.Env, err = resolveSecretEnv(ctx, secrets, profile.Env); err != nil {
+		return ConnectionProfile{}, false, fmt.Errorf("profile %s: %w", name, err)
+	}
+	return profile, true, nil
+}
+
+func profileNotFound(name string) error {
+	return errors.New(fmt.Sprintf("connection profile %q not found", name), errors.CategoryNotFound).
+		WithTextCode("JOB_PROFILE_NOT_FOUND").
+		WithMetadata(map[string]any{"profile": name})
+}
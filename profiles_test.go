@@ -0,0 +1,73 @@
+package job_test
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnginesUseConnectionProfiles(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "billing.db")
+	profiles := job.NewMemoryProfileStore(job.ConnectionProfile{
+		Name:   "billing-rw",
+		Driver: "sqlite3",
+		DSN:    "${secret:billing_path}",
+		Env:    map[string]string{"DB_ROLE": "writer", "REGION": "eu"},
+	})
+	secrets := job.SecretsProviderFunc(func(_ context.Context, ref string) (job.Secret, error) {
+		return job.Secret{Value: dbPath}, nil
+	})
+	meta := map[string]any{job.ProfileMetadataKey: "billing-rw"}
+	store := job.NewRunLogStore(32, 4)
+
+	shellEngine := job.NewShellRunner()
+	shellEngine.SetProfileStore(profiles)
+	shell := job.NewBaseTask("sh-task", "out.sh", "shell",
+		job.Config{Metadata: meta, Env: map[string]string{"REGION": "us"}}, "", shellEngine)
+	ctx := job.ContextWithRunID(context.Background(), "run-sh")
+	require.NoError(t, job.NewTaskCommander(shell).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": `echo "$DB_ROLE $REGION"`},
+	}))
+	entries, _ := store.Logs("run-sh")
+	assert.Equal(t, []string{"writer us"}, scriptLines(entries, "shell output"))
+
+	fallback, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer fallback.Close()
+
+	sqlEngine := job.NewSQLRunner(job.WithSQLClient(fallback))
+	sqlEngine.SetProfileStore(profiles)
+	sqlEngine.SetSecretsProvider(secrets)
+	sqlTask := job.NewBaseTask("sql-task", "seed.sql", "sql", job.Config{Metadata: meta}, "", sqlEngine)
+	require.NoError(t, job.NewTaskCommander(sqlTask).Execute(context.Background(), &job.ExecutionMessage{
+		Parameters: map[string]any{"script": "CREATE TABLE invoices (id INTEGER); INSERT INTO invoices VALUES (1);"},
+	}))
+
+	billing, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	defer billing.Close()
+	var count int
+	require.NoError(t, billing.QueryRow("SELECT COUNT(*) FROM invoices").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestConnectionProfileNotFound(t *testing.T) {
+	engine := job.NewShellRunner()
+	engine.SetProfileStore(job.NewMemoryProfileStore())
+	task := job.NewBaseTask("sh-task", "out.sh", "shell",
+		job.Config{Metadata: map[string]any{job.ProfileMetadataKey: "analytics-ro"}}, "", engine)
+
+	err := job.NewTaskCommander(task).Execute(context.Background(), &job.ExecutionMessage{
+		Parameters: map[string]any{"script": "true"},
+	})
+	var typed *errors.Error
+	require.True(t, stderrors.As(err, &typed), "got %v", err)
+	assert.Equal(t, "JOB_PROFILE_NOT_FOUND", typed.TextCode)
+}
@@ -0,0 +1,88 @@
+package job
+
+import (
+	"io/fs"
+	"strings"
+	"time"
+)
+
+type PythonOption func(*PythonEngine)
+
+// WithPythonFS sets the filesystem scripts are read from
+func WithPythonFS(dirfs fs.FS) PythonOption {
+	return func(e *PythonEngine) {
+		if dirfs != nil {
+			e.FS = dirfs
+		}
+	}
+}
+
+// WithPythonExtension adds file extensions that this engine can handle
+func WithPythonExtension(ext string) PythonOption {
+	return func(e *PythonEngine) {
+		if ext == "" {
+			return
+		}
+
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		e.FileExtensions = append(e.FileExtensions, ext)
+	}
+}
+
+// WithPythonTimeout sets the default execution timeout
+func WithPythonTimeout(timeout time.Duration) PythonOption {
+	return func(e *PythonEngine) {
+		if timeout > 0 {
+			e.Timeout = timeout
+		}
+	}
+}
+
+// WithPythonInterpreter sets the interpreter executable and the arguments
+// placed before "-c <script>", e.g. a virtualenv's bin/python. Pass "-u" to
+// keep output unbuffered.
+func WithPythonInterpreter(interpreter string, args ...string) PythonOption {
+	return func(e *PythonEngine) {
+		if interpreter != "" {
+			e.interpreter = interpreter
+			e.interpreterArgs = args
+		}
+	}
+}
+
+// WithPythonWorkingDirectory sets the working directory for script execution
+func WithPythonWorkingDirectory(dir string) PythonOption {
+	return func(e *PythonEngine) {
+		if dir != "" {
+			e.workDir = dir
+		}
+	}
+}
+
+// WithPythonEnvironment sets additional environment variables
+func WithPythonEnvironment(env []string) PythonOption {
+	return func(e *PythonEngine) {
+		if env != nil {
+			e.environment = env
+		}
+	}
+}
+
+// WithPythonMetadataParser sets a custom metadata parser
+func WithPythonMetadataParser(parser MetadataParser) PythonOption {
+	return func(e *PythonEngine) {
+		if parser != nil {
+			e.MetadataParser = parser
+		}
+	}
+}
+
+func WithPythonLogger(logger Logger) PythonOption {
+	return func(e *PythonEngine) {
+		if logger != nil {
+			e.SetLogger(logger)
+		}
+	}
+}
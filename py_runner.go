@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+)
+
+// PythonEngine runs `.py` scripts with a Python interpreter, parsing the
+// same `# config` metadata header as shell scripts.
+type PythonEngine struct {
+	*BaseEngine
+	interpreter     string
+	interpreterArgs []string
+	workDir         string
+	environment     []string
+}
+
+// NewPythonRunner runs scripts with "python3 -u", unbuffered so output is
+// logged line by line as the script prints it.
+func NewPythonRunner(opts ...PythonOption) *PythonEngine {
+	e := &PythonEngine{
+		interpreter:     "python3",
+		interpreterArgs: []string{"-u"},
+	}
+	e.BaseEngine = NewBaseEngine(e, "python", ".py")
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+
+	return e
+}
+
+// SetTaskIDProvider overrides the ID derivation strategy for tasks parsed by the python engine.
+func (e *PythonEngine) SetTaskIDProvider(provider TaskIDProvider) {
+	if e.BaseEngine != nil {
+		e.BaseEngine.SetTaskIDProvider(provider)
+	}
+}
+
+// Execute passes the script to the interpreter with -c, after the
+// interpreter arguments.
+func (e *PythonEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	scriptContent, err := e.GetScriptContent(msg)
+	if err != nil {
+		return err
+	}
+	return e.runProcess(ctx, msg, scriptProcess{
+		kind:        "python",
+		textCode:    "PYTHON_EXECUTION_ERROR",
+		command:     e.interpreter,
+		args:        append(append([]string(nil), e.interpreterArgs...), "-c", scriptContent),
+		workDir:     e.workDir,
+		environment: e.environment,
+	})
+}
+
+// HealthCheck verifies the interpreter and working directory exist.
+func (e *PythonEngine) HealthCheck(context.Context) error {
+	return checkProcess("python", e.interpreter, e.workDir)
+}
@@ -0,0 +1,99 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requirePython(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+}
+
+func TestPythonRunnerParsesAndExecutesScript(t *testing.T) {
+	requirePython(t)
+
+	engine := job.NewPythonRunner()
+	assert.Equal(t, "engine:python", engine.Name())
+	assert.True(t, engine.CanHandle("jobs/etl.py"))
+	assert.False(t, engine.CanHandle("jobs/etl.sh"))
+
+	task, err := engine.ParseJob("jobs/etl.py", []byte(`#!/usr/bin/env python3
+# config
+# schedule: "@daily"
+# timeout: 30s
+# env:
+#   GREETING: hello
+
+import os
+import sys
+
+print(os.environ["GREETING"], os.environ["JOB_ATTEMPT"])
+print("warning", file=sys.stderr)
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", task.GetConfig().Schedule)
+	assert.Equal(t, 30*time.Second, task.GetConfig().Timeout)
+
+	store := job.NewRunLogStore(32, 4)
+	cmd := job.NewTaskCommander(task).WithRunLogStore(store)
+	ctx := job.ContextWithRunID(context.Background(), "run-py")
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{JobID: task.GetID()}))
+
+	entries, ok := store.Logs("run-py")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"hello 1", "warning"}, scriptLines(entries, "python output"))
+}
+
+func TestPythonRunnerFailures(t *testing.T) {
+	requirePython(t)
+
+	engine := job.NewPythonRunner(job.WithPythonTimeout(200 * time.Millisecond))
+
+	err := engine.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "broken",
+		ScriptPath: "broken.py",
+		Parameters: map[string]any{"script": "import sys\nprint('partial')\nsys.exit(3)"},
+	})
+	require.Error(t, err)
+	var typed *errors.Error
+	require.True(t, stderrors.As(err, &typed))
+	assert.Equal(t, "PYTHON_EXECUTION_ERROR", typed.TextCode)
+	assert.Equal(t, 3, typed.Metadata["exit_code"])
+	assert.Equal(t, "python3", typed.Metadata["python"])
+	assert.Equal(t, "partial\n", typed.Metadata["stdout"])
+
+	start := time.Now()
+	err = engine.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "slow",
+		ScriptPath: "slow.py",
+		Parameters: map[string]any{"script": "import time\ntime.sleep(5)"},
+	})
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 4*time.Second, "the engine timeout stops the interpreter")
+}
+
+func TestPythonRunnerInterpreter(t *testing.T) {
+	requirePython(t)
+
+	engine := job.NewPythonRunner(job.WithPythonInterpreter("python3", "-I", "-u"))
+	require.NoError(t, engine.HealthCheck(context.Background()))
+	require.NoError(t, engine.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "isolated",
+		ScriptPath: "isolated.py",
+		Parameters: map[string]any{"script": "import sys\nassert sys.flags.isolated"},
+	}))
+
+	missing := job.NewPythonRunner(job.WithPythonInterpreter("/nonexistent/python"))
+	assert.ErrorContains(t, missing.HealthCheck(context.Background()), "/nonexistent/python")
+}
@@ -33,6 +33,26 @@ func TestStorageEnqueueDequeueAck(t *testing.T) {
 	assert.Equal(t, 0, countRows(t, storage.db, storage.table))
 }
 
+func TestStorageMessageCodec(t *testing.T) {
+	storage, cleanup := setupStorage(t)
+	defer cleanup()
+	WithMessageCodec(queue.ProtobufCodec{})(storage.Storage)
+
+	msg := &job.ExecutionMessage{
+		JobID:      "export",
+		ScriptPath: "/tmp/export",
+		Parameters: map[string]any{"payload": []byte{0x00, 0xff}, "region": "eu"},
+	}
+	_, err := storage.Enqueue(context.Background(), msg)
+	require.NoError(t, err)
+
+	out, _, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "export", out.JobID)
+	assert.Equal(t, []byte{0x00, 0xff}, out.Parameters["payload"])
+	assert.Equal(t, "eu", out.Parameters["region"])
+}
+
 func TestAdapterEnqueueReceiptsAndDispatchStatusLifecycle(t *testing.T) {
 	storage, cleanup := setupStorage(t)
 	defer cleanup()
@@ -224,6 +244,58 @@ func TestStorageNackDelayedRetry(t *testing.T) {
 	assert.Equal(t, storage.clock.Now(), receipt.AvailableAt)
 }
 
+func TestStorageNackRequeueKeepsAttempts(t *testing.T) {
+	storage, cleanup := setupStorage(t)
+	defer cleanup()
+
+	_, err := storage.Enqueue(context.Background(), &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train"})
+	require.NoError(t, err)
+	_, receipt, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, receipt.Requeues)
+
+	require.NoError(t, storage.Nack(context.Background(), receipt, queue.NackOptions{
+		Disposition: queue.NackDispositionRequeue,
+		Reason:      "affinity mismatch",
+	}))
+
+	out, receipt, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	assert.Equal(t, 1, receipt.Attempts)
+	assert.Equal(t, 1, receipt.Requeues)
+}
+
+func TestStorageMigrateAddsRequeuesColumn(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE job_queue (
+id TEXT PRIMARY KEY,
+payload TEXT NOT NULL,
+attempts INTEGER NOT NULL DEFAULT 0,
+available_at BIGINT NOT NULL,
+leased_until BIGINT NOT NULL DEFAULT 0,
+token TEXT,
+last_error TEXT,
+created_at BIGINT NOT NULL,
+updated_at BIGINT NOT NULL
+)`)
+	require.NoError(t, err)
+
+	storage := NewStorage(db, WithDialect(DialectSQLite), WithUseSkipLocked(false), WithTableName("job_queue"))
+	require.NoError(t, storage.Migrate(context.Background()))
+	require.NoError(t, storage.Migrate(context.Background()), "migrate is idempotent")
+
+	_, err = storage.Enqueue(context.Background(), &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train"})
+	require.NoError(t, err)
+	_, receipt, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, receipt.Requeues)
+}
+
 func TestStorageVisibilityTimeoutRequeues(t *testing.T) {
 	storage, cleanup := setupStorage(t)
 	defer cleanup()
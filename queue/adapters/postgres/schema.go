@@ -8,6 +8,7 @@ func schemaStatements(table, dlqTable, statusTable string) []string {
 id TEXT PRIMARY KEY,
 payload TEXT NOT NULL,
 attempts INTEGER NOT NULL DEFAULT 0,
+requeues INTEGER NOT NULL DEFAULT 0,
 available_at BIGINT NOT NULL,
 leased_until BIGINT NOT NULL DEFAULT 0,
 token TEXT,
@@ -40,6 +41,20 @@ expires_at BIGINT NOT NULL
 	}
 }
 
+// schemaColumns lists columns added after the first release, which
+// CREATE TABLE IF NOT EXISTS does not add to existing tables.
+func schemaColumns(table string) []schemaColumn {
+	return []schemaColumn{
+		{table: table, name: "requeues", definition: "INTEGER NOT NULL DEFAULT 0"},
+	}
+}
+
+type schemaColumn struct {
+	table      string
+	name       string
+	definition string
+}
+
 func dropStatements(table, dlqTable, statusTable string) []string {
 	return []string{
 		fmt.Sprintf("DROP TABLE IF EXISTS %s", table),
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -52,6 +53,16 @@ func WithStatusTableName(name string) Option {
 	}
 }
 
+// WithMessageCodec sets the codec used to encode enqueued messages, e.g.
+// queue.ProtobufCodec{}. Binary codec output is stored base64 encoded in the
+// TEXT payload column. Payloads written by any registered codec are decoded,
+// so the codec can change while messages are in flight. Defaults to JSON.
+func WithMessageCodec(codec queue.MessageCodec) Option {
+	return func(s *Storage) {
+		s.codec = codec
+	}
+}
+
 // WithVisibilityTimeout sets the lease timeout.
 func WithVisibilityTimeout(timeout time.Duration) Option {
 	return func(s *Storage) {
@@ -120,6 +131,7 @@ type Storage struct {
 	table             string
 	dlqTable          string
 	statusTable       string
+	codec             queue.MessageCodec
 	visibilityTimeout time.Duration
 	statusTTL         time.Duration
 	now               func() time.Time
@@ -174,6 +186,26 @@ func (s *Storage) Migrate(ctx context.Context) error {
 			return err
 		}
 	}
+	for _, column := range schemaColumns(s.table) {
+		if err := s.addColumnIfMissing(ctx, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adds column to tables created before it existed. The
+// probe query works on every supported dialect, unlike ADD COLUMN IF NOT EXISTS.
+func (s *Storage) addColumnIfMissing(ctx context.Context, column schemaColumn) error {
+	probe := fmt.Sprintf(`SELECT %s FROM %s WHERE 1 = 0`, column.name, column.table)
+	rows, err := s.db.QueryContext(ctx, probe)
+	if err == nil {
+		return rows.Close()
+	}
+	stmt := fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, column.table, column.name, column.definition)
+	if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("add column %s.%s: %w", column.table, column.name, err)
+	}
 	return nil
 }
 
@@ -210,7 +242,7 @@ func (s *Storage) EnqueueAt(ctx context.Context, msg *job.ExecutionMessage, at t
 		return queue.EnqueueReceipt{}, err
 	}
 
-	payload, err := queue.EncodeExecutionMessage(msg)
+	payload, err := s.encodeMessage(msg)
 	if err != nil {
 		return queue.EnqueueReceipt{}, err
 	}
@@ -381,17 +413,23 @@ func (s *Storage) Nack(ctx context.Context, receipt queue.Receipt, opts queue.Na
 		return tx.Commit()
 	}
 
-	if opts.Disposition == queue.NackDispositionRetry {
+	if opts.Disposition == queue.NackDispositionRetry || opts.Disposition == queue.NackDispositionRequeue {
 		availableAt := now
 		if opts.Delay > 0 {
 			availableAt = now.Add(opts.Delay)
 		}
-		if err := s.updateForRetry(ctx, tx, receipt, availableAt, opts.Reason, now); err != nil {
+		requeue := opts.Disposition == queue.NackDispositionRequeue
+		if err := s.updateForRetry(ctx, tx, receipt, availableAt, opts.Reason, now, requeue); err != nil {
 			return err
 		}
+		state := queue.DispatchStateRetrying
+		if requeue {
+			attempts--
+			state = queue.DispatchStateAccepted
+		}
 		if err := s.upsertStatus(ctx, tx, statusRecord{
 			DispatchID:     receipt.ID,
-			State:          queue.DispatchStateRetrying,
+			State:          state,
 			Attempt:        attempts,
 			EnqueuedAt:     enqueuedAt,
 			UpdatedAt:      now,
@@ -524,7 +562,7 @@ func (s *Storage) dequeueSkipLocked(ctx context.Context) (*job.ExecutionMessage,
 	now := s.now()
 	nowUnix := now.UnixNano()
 	p := s.placeholder
-	query := fmt.Sprintf(`SELECT id, payload, attempts, available_at, created_at, last_error
+	query := fmt.Sprintf(`SELECT id, payload, attempts, requeues, available_at, created_at, last_error
 FROM %s
 WHERE available_at <= %s AND (leased_until = 0 OR leased_until <= %s)
 ORDER BY available_at ASC, created_at ASC
@@ -535,10 +573,11 @@ LIMIT 1`, s.table, p(1), p(2))
 	var id string
 	var payload string
 	var attempts int
+	var requeues int
 	var availableAt int64
 	var createdAt int64
 	var lastError sql.NullString
-	if err := row.Scan(&id, &payload, &attempts, &availableAt, &createdAt, &lastError); err != nil {
+	if err := row.Scan(&id, &payload, &attempts, &requeues, &availableAt, &createdAt, &lastError); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, queue.Receipt{}, tx.Commit()
 		}
@@ -578,6 +617,7 @@ LIMIT 1`, s.table, p(1), p(2))
 		ID:          id,
 		Token:       token,
 		Attempts:    attempts,
+		Requeues:    requeues,
 		LeasedAt:    now,
 		AvailableAt: timeutil.UnixNanoTime(availableAt),
 		CreatedAt:   timeutil.UnixNanoTime(createdAt),
@@ -595,7 +635,7 @@ func (s *Storage) dequeueCompatible(ctx context.Context) (*job.ExecutionMessage,
 	now := s.now()
 	nowUnix := now.UnixNano()
 	p := s.placeholder
-	query := fmt.Sprintf(`SELECT id, payload, attempts, available_at, created_at, last_error
+	query := fmt.Sprintf(`SELECT id, payload, attempts, requeues, available_at, created_at, last_error
 FROM %s
 WHERE available_at <= %s AND (leased_until = 0 OR leased_until <= %s)
 ORDER BY available_at ASC, created_at ASC
@@ -605,10 +645,11 @@ LIMIT 1`, s.table, p(1), p(2))
 	var id string
 	var payload string
 	var attempts int
+	var requeues int
 	var availableAt int64
 	var createdAt int64
 	var lastError sql.NullString
-	if err := row.Scan(&id, &payload, &attempts, &availableAt, &createdAt, &lastError); err != nil {
+	if err := row.Scan(&id, &payload, &attempts, &requeues, &availableAt, &createdAt, &lastError); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, queue.Receipt{}, tx.Commit()
 		}
@@ -654,6 +695,7 @@ WHERE id = %s AND (leased_until = 0 OR leased_until <= %s)`, s.table, p(1), p(2)
 		ID:          id,
 		Token:       token,
 		Attempts:    attempts,
+		Requeues:    requeues,
 		LeasedAt:    now,
 		AvailableAt: timeutil.UnixNanoTime(availableAt),
 		CreatedAt:   timeutil.UnixNanoTime(createdAt),
@@ -687,13 +729,19 @@ VALUES (%s, %s, %s, %s, %s, %s, %s)`, s.dlqTable, p(1), p(2), p(3), p(4), p(5),
 	return err
 }
 
-func (s *Storage) updateForRetry(ctx context.Context, tx *sql.Tx, receipt queue.Receipt, availableAt time.Time, reason string, now time.Time) error {
+// updateForRetry releases the lease so the message is redelivered at
+// availableAt. A requeue gives back the attempt counted by the dequeue.
+func (s *Storage) updateForRetry(ctx context.Context, tx *sql.Tx, receipt queue.Receipt, availableAt time.Time, reason string, now time.Time, requeue bool) error {
 	nowUnix := now.UnixNano()
 	availableUnix := availableAt.UnixNano()
 	p := s.placeholder
+	counters := ""
+	if requeue {
+		counters = "attempts = attempts - 1, requeues = requeues + 1, "
+	}
 	query := fmt.Sprintf(`UPDATE %s
-SET token = '', leased_until = 0, available_at = %s, updated_at = %s, last_error = %s
-WHERE id = %s AND token = %s`, s.table, p(1), p(2), p(3), p(4), p(5))
+SET %stoken = '', leased_until = 0, available_at = %s, updated_at = %s, last_error = %s
+WHERE id = %s AND token = %s`, s.table, counters, p(1), p(2), p(3), p(4), p(5))
 	res, err := tx.ExecContext(ctx, query, availableUnix, nowUnix, reason, receipt.ID, receipt.Token)
 	if err != nil {
 		return err
@@ -785,8 +833,28 @@ expires_at = excluded.expires_at`, s.statusTable, p(1), p(2), p(3), p(4), p(5),
 	return err
 }
 
+func (s *Storage) encodeMessage(msg *job.ExecutionMessage) (string, error) {
+	payload, err := queue.EncodeExecutionMessageWith(s.codec, msg)
+	if err != nil {
+		return "", err
+	}
+	if s.codec == nil || s.codec.Name() == queue.JSONCodecName {
+		return string(payload), nil
+	}
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// decodeMessage reads JSON payloads as stored and base64 payloads written by
+// binary codecs.
 func decodeMessage(payload string) (*job.ExecutionMessage, error) {
-	return queue.DecodeExecutionMessage([]byte(payload))
+	if strings.HasPrefix(strings.TrimSpace(payload), "{") {
+		return queue.DecodeExecutionMessage([]byte(payload))
+	}
+	raw, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode queue payload: %w", err)
+	}
+	return queue.DecodeExecutionMessage(raw)
 }
 
 func (s *Storage) validateIdentifiers() error {
@@ -108,6 +108,15 @@ func (d *delivery) Attempts() int {
 	return d.receipt.Attempts
 }
 
+// Requeues reports how often the message was returned with
+// NackDispositionRequeue.
+func (d *delivery) Requeues() int {
+	if d == nil {
+		return 0
+	}
+	return d.receipt.Requeues
+}
+
 // GetDispatchStatus probes the underlying storage for dispatch lifecycle state.
 func (a *Adapter) GetDispatchStatus(ctx context.Context, dispatchID string) (queue.DispatchStatus, error) {
 	if a == nil || a.storage == nil {
@@ -39,6 +39,28 @@ func TestStorageEnqueueDequeueAck(t *testing.T) {
 	assert.False(t, client.HasKey(storage.keys.message(receipt.ID)))
 }
 
+func TestStorageMessageCodec(t *testing.T) {
+	client := newFakeClient()
+	storage := NewStorage(client,
+		WithMessageCodec(queue.MsgpackCodec{}),
+		WithIDFunc(sequence("msg-1")),
+	)
+
+	msg := &job.ExecutionMessage{
+		JobID:      "export",
+		ScriptPath: "/tmp/export",
+		Parameters: map[string]any{"payload": []byte(`{"id":1}`), "region": "eu"},
+	}
+	_, err := storage.Enqueue(context.Background(), msg)
+	require.NoError(t, err)
+
+	out, _, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "export", out.JobID)
+	assert.Equal(t, []byte(`{"id":1}`), out.Parameters["payload"])
+	assert.Equal(t, "eu", out.Parameters["region"])
+}
+
 func TestAdapterEnqueueReceiptsAndDispatchStatusLifecycle(t *testing.T) {
 	client := newFakeClient()
 	clock := newManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
@@ -266,6 +288,33 @@ func TestStorageNackDelayedRetry(t *testing.T) {
 	assert.Equal(t, clock.Now(), receipt.AvailableAt)
 }
 
+func TestStorageNackRequeueKeepsAttempts(t *testing.T) {
+	client := newFakeClient()
+	clock := newManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	storage := NewStorage(client,
+		WithClock(clock.Now),
+		WithIDFunc(sequence("msg-1")),
+		WithTokenFunc(sequence("token-1", "token-2")),
+	)
+
+	_, err := storage.Enqueue(context.Background(), &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train"})
+	require.NoError(t, err)
+	_, receipt, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, receipt.Requeues)
+
+	require.NoError(t, storage.Nack(context.Background(), receipt, queue.NackOptions{
+		Disposition: queue.NackDispositionRequeue,
+		Reason:      "affinity mismatch",
+	}))
+
+	out, receipt, err := storage.Dequeue(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	assert.Equal(t, 1, receipt.Attempts)
+	assert.Equal(t, 1, receipt.Requeues)
+}
+
 func TestStorageVisibilityTimeoutRequeues(t *testing.T) {
 	client := newFakeClient()
 	clock := newManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
@@ -617,6 +666,7 @@ func (c *fakeClient) evalDequeue(keys []string, args ...any) (any, error) {
 		c.hashes[msgKey][fieldAvailable],
 		c.hashes[msgKey][fieldCreatedAt],
 		c.hashes[msgKey][fieldLastError],
+		strconv.Itoa(parseInt(c.hashes[msgKey][fieldRequeues])),
 	}, nil
 }
 
@@ -685,7 +735,14 @@ func (c *fakeClient) evalNack(keys []string, args ...any) (any, error) {
 		})
 		c.lpushNoLock(keys[4], id)
 		return []any{attempts, createdAt, "0"}, nil
-	case string(queue.NackDispositionRetry):
+	case string(queue.NackDispositionRetry), string(queue.NackDispositionRequeue):
+		if disposition == string(queue.NackDispositionRequeue) {
+			attempts = strconv.Itoa(parseInt(attempts) - 1)
+			c.hsetNoLock(msgKey, map[string]string{
+				fieldAttempts: attempts,
+				fieldRequeues: strconv.Itoa(parseInt(c.hashes[msgKey][fieldRequeues]) + 1),
+			})
+		}
 		availableAt := now + delay
 		c.hsetNoLock(msgKey, map[string]string{
 			fieldToken:     "",
@@ -20,6 +20,7 @@ const (
 
 	fieldPayload   = "payload"
 	fieldAttempts  = "attempts"
+	fieldRequeues  = "requeues"
 	fieldToken     = "token"
 	fieldLeasedAt  = "leased_at"
 	fieldAvailable = "available_at"
@@ -82,13 +83,14 @@ local attempts = redis.call('HINCRBY', msgKey, 'attempts', 1)
 local availableAt = redis.call('HGET', msgKey, 'available_at') or '0'
 local createdAt = redis.call('HGET', msgKey, 'created_at') or '0'
 local lastError = redis.call('HGET', msgKey, 'last_error') or ''
+local requeues = redis.call('HGET', msgKey, 'requeues') or '0'
 local seq = redis.call('INCR', leaseSeq)
 local token = redis.sha1hex(id .. ':' .. tostring(now) .. ':' .. tostring(seq))
 
 redis.call('HSET', msgKey, 'token', token, 'leased_at', tostring(now), 'updated_at', tostring(now))
 redis.call('ZADD', inflight, leaseUntil, id)
 
-return { id, payload, tostring(attempts), token, availableAt, createdAt, lastError }
+return { id, payload, tostring(attempts), token, availableAt, createdAt, lastError, requeues }
 `
 
 	// AckScript atomically validates token and removes an in-flight entry.
@@ -153,7 +155,12 @@ if disposition == 'dead_letter' then
 	return { attempts, createdAt, '0' }
 end
 
-if disposition == 'retry' then
+if disposition == 'requeue' then
+	attempts = tostring(redis.call('HINCRBY', msgKey, 'attempts', -1))
+	redis.call('HINCRBY', msgKey, 'requeues', 1)
+end
+
+if disposition == 'retry' or disposition == 'requeue' then
 	local availableAt = now + delay
 	redis.call('HSET', msgKey, 'token', '', 'leased_at', '0', 'updated_at', tostring(now), 'last_error', reason, 'available_at', tostring(availableAt))
 	if delay > 0 then
@@ -208,6 +215,15 @@ func WithQueueName(queue string) Option {
 	}
 }
 
+// WithMessageCodec sets the codec used to encode enqueued messages, e.g.
+// queue.MsgpackCodec{}. Payloads written by any registered codec are decoded,
+// so the codec can change while messages are in flight. Defaults to JSON.
+func WithMessageCodec(codec queue.MessageCodec) Option {
+	return func(s *Storage) {
+		s.codec = codec
+	}
+}
+
 // WithVisibilityTimeout sets the default lease timeout.
 func WithVisibilityTimeout(timeout time.Duration) Option {
 	return func(s *Storage) {
@@ -272,6 +288,7 @@ type Storage struct {
 	now               func() time.Time
 	idFunc            func() string
 	tokenFunc         func() string
+	codec             queue.MessageCodec
 }
 
 // NewStorage builds a redis storage adapter.
@@ -317,7 +334,7 @@ func (s *Storage) EnqueueAt(ctx context.Context, msg *job.ExecutionMessage, at t
 		return queue.EnqueueReceipt{}, err
 	}
 
-	payload, err := queue.EncodeExecutionMessage(msg)
+	payload, err := queue.EncodeExecutionMessageWith(s.codec, msg)
 	if err != nil {
 		return queue.EnqueueReceipt{}, err
 	}
@@ -448,6 +465,12 @@ func (s *Storage) Dequeue(ctx context.Context) (*job.ExecutionMessage, queue.Rec
 	if err != nil {
 		return nil, queue.Receipt{}, err
 	}
+	requeues := 0
+	if len(values) > 7 {
+		if requeues, err = evalInt(values[7]); err != nil {
+			return nil, queue.Receipt{}, err
+		}
+	}
 
 	availableAt := timeutil.UnixNanoTime(availableAtUnix)
 	createdAt := timeutil.UnixNanoTime(createdAtUnix)
@@ -468,6 +491,7 @@ func (s *Storage) Dequeue(ctx context.Context) (*job.ExecutionMessage, queue.Rec
 		ID:          id,
 		Token:       token,
 		Attempts:    attempts,
+		Requeues:    requeues,
 		LeasedAt:    now,
 		AvailableAt: availableAt,
 		CreatedAt:   createdAt,
@@ -613,7 +637,7 @@ func (s *Storage) Nack(ctx context.Context, receipt queue.Receipt, opts queue.Na
 		})
 	}
 
-	if opts.Disposition == queue.NackDispositionRetry {
+	if opts.Disposition == queue.NackDispositionRetry || opts.Disposition == queue.NackDispositionRequeue {
 		availableAt := timeutil.UnixNanoTime(availableAtUnix)
 		state := queue.DispatchStateAccepted
 		nextRunAt := time.Time{}
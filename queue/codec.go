@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	job "github.com/goliatone/go-job"
+)
+
+// MessageCodec serializes execution messages for storage and transport.
+// Implementations encode the versioned envelope so every codec carries the
+// same fields.
+type MessageCodec interface {
+	// Name identifies the codec in framed payloads, e.g. "msgpack".
+	Name() string
+	Encode(msg *job.ExecutionMessage) ([]byte, error)
+	Decode(payload []byte) (*job.ExecutionMessage, error)
+}
+
+// JSONCodecName names the canonical JSON codec.
+const JSONCodecName = "json"
+
+// codecFrameMagic prefixes payloads written by non-JSON codecs:
+// magic, one byte name length, the codec name, then the codec body.
+var codecFrameMagic = []byte{0x00, 'J', 'M'}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]MessageCodec{
+		JSONCodecName:     JSONCodec{},
+		MsgpackCodecName:  MsgpackCodec{},
+		ProtobufCodecName: ProtobufCodec{},
+	}
+)
+
+// RegisterMessageCodec makes codec available to DecodeExecutionMessage. It
+// replaces a codec with the same name.
+func RegisterMessageCodec(codec MessageCodec) error {
+	if codec == nil {
+		return fmt.Errorf("message codec required")
+	}
+	name := codec.Name()
+	if name == "" || len(name) > 255 {
+		return fmt.Errorf("message codec name must be 1-255 bytes")
+	}
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = codec
+	return nil
+}
+
+// LookupMessageCodec returns the codec registered under name.
+func LookupMessageCodec(name string) (MessageCodec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[name]
+	return codec, ok
+}
+
+// MessageCodecNames lists the registered codecs, sorted.
+func MessageCodecNames() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EncodeExecutionMessageWith encodes msg with codec. JSON output is left
+// unframed so it stays readable by older decoders; other codecs are framed
+// with their name so DecodeExecutionMessage can pick the right decoder. A nil
+// codec uses JSON.
+func EncodeExecutionMessageWith(codec MessageCodec, msg *job.ExecutionMessage) ([]byte, error) {
+	if codec == nil || codec.Name() == JSONCodecName {
+		return EncodeExecutionMessage(msg)
+	}
+	body, err := codec.Encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	name := codec.Name()
+	if name == "" || len(name) > 255 {
+		return nil, fmt.Errorf("message codec name must be 1-255 bytes")
+	}
+	out := make([]byte, 0, len(codecFrameMagic)+1+len(name)+len(body))
+	out = append(out, codecFrameMagic...)
+	out = append(out, byte(len(name)))
+	out = append(out, name...)
+	return append(out, body...), nil
+}
+
+func splitCodecFrame(payload []byte) (string, []byte, bool) {
+	if !bytes.HasPrefix(payload, codecFrameMagic) || len(payload) < len(codecFrameMagic)+1 {
+		return "", nil, false
+	}
+	rest := payload[len(codecFrameMagic):]
+	n := int(rest[0])
+	if n == 0 || len(rest) < 1+n {
+		return "", nil, false
+	}
+	return string(rest[1 : 1+n]), rest[1+n:], true
+}
+
+// ErrUnsupportedMessageVersion is returned for envelopes newer than
+// MessageFormatVersion.
+var ErrUnsupportedMessageVersion = fmt.Errorf("unsupported message format version")
+
+func checkMessageVersion(version int) error {
+	if version > MessageFormatVersion {
+		return fmt.Errorf("%w %d (max %d)", ErrUnsupportedMessageVersion, version, MessageFormatVersion)
+	}
+	return nil
+}
+
+// JSONCodec is the canonical JSON envelope codec.
+type JSONCodec struct{}
+
+// Name implements MessageCodec.
+func (JSONCodec) Name() string { return JSONCodecName }
+
+// Encode implements MessageCodec.
+func (JSONCodec) Encode(msg *job.ExecutionMessage) ([]byte, error) {
+	return EncodeExecutionMessage(msg)
+}
+
+// Decode implements MessageCodec.
+func (JSONCodec) Decode(payload []byte) (*job.ExecutionMessage, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("payload empty")
+	}
+	return decodeJSONEnvelope(payload)
+}
@@ -28,6 +28,11 @@ type EnqueueOptions struct {
 	DedupPolicy    job.DeduplicationPolicy
 	CorrelationID  string
 	Metadata       map[string]any
+	// Affinity adds worker label requirements for this dispatch on top of
+	// the task's own Config.Affinity.
+	Affinity map[string]string
+	// CallbackURL receives the run's Result once a worker completes it.
+	CallbackURL string
 
 	// IdempotencyStore enables durable deduplication for drop|merge|replace policies.
 	IdempotencyStore qidempotency.Store
@@ -297,6 +302,13 @@ func buildExecutionMessage(id string, params map[string]any, opts EnqueueOptions
 		IdempotencyKey: strings.TrimSpace(opts.IdempotencyKey),
 		DedupPolicy:    normalizeDedupPolicy(opts.DedupPolicy),
 		ExecutionID:    strings.TrimSpace(opts.CorrelationID),
+		CallbackURL:    strings.TrimSpace(opts.CallbackURL),
+	}
+	if len(opts.Affinity) > 0 {
+		msg.Config.Affinity = make(map[string]string, len(opts.Affinity))
+		for key, value := range opts.Affinity {
+			msg.Config.Affinity[key] = value
+		}
 	}
 	if msg.DedupPolicy == "" {
 		msg.DedupPolicy = job.DedupPolicyIgnore
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	job "github.com/goliatone/go-job"
@@ -61,7 +62,7 @@ func (r *Registry) Get(id string) (Entry, bool) {
 	return entry, ok
 }
 
-// List returns all registered entries.
+// List returns all registered entries ordered by ID.
 func (r *Registry) List() []Entry {
 	if r == nil {
 		return nil
@@ -72,5 +73,6 @@ func (r *Registry) List() []Entry {
 	for _, entry := range r.entries {
 		entries = append(entries, entry)
 	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
 	return entries
 }
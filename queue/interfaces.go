@@ -69,6 +69,10 @@ const (
 	NackDispositionDeadLetter NackDisposition = "dead_letter"
 	NackDispositionFailed     NackDisposition = "failed"
 	NackDispositionCanceled   NackDisposition = "canceled"
+	// NackDispositionRequeue returns the message after Delay without counting
+	// the delivery as an attempt, for deliveries the worker could not serve
+	// (e.g. unmet affinity). Adapters count requeues separately.
+	NackDispositionRequeue NackDisposition = "requeue"
 )
 
 // DispatchState defines queue dispatch lifecycle states exposed by status readers.
@@ -7,9 +7,16 @@ import (
 	job "github.com/goliatone/go-job"
 )
 
+// MessageFormatVersion is the envelope version written by the codecs.
+// Decoders accept envelopes up to this version, treat unversioned payloads as
+// version 1, and ignore fields they do not know.
+const MessageFormatVersion = 1
+
 type messageEnvelope struct {
+	Version         int                     `json:"v,omitempty"`
 	JobID           string                  `json:"job_id"`
 	ScriptPath      string                  `json:"script_path"`
+	ScriptChecksum  string                  `json:"script_checksum,omitempty"`
 	MachineID       string                  `json:"machine_id,omitempty"`
 	EntityID        string                  `json:"entity_id,omitempty"`
 	ExecutionID     string                  `json:"execution_id,omitempty"`
@@ -20,13 +27,24 @@ type messageEnvelope struct {
 	Parameters      map[string]any          `json:"parameters,omitempty"`
 	IdempotencyKey  string                  `json:"idempotency_key,omitempty"`
 	DedupPolicy     job.DeduplicationPolicy `json:"dedup_policy,omitempty"`
+	TraceID         string                  `json:"trace_id,omitempty"`
+	Attempt         int                     `json:"attempt,omitempty"`
 	Result          *job.Result             `json:"result,omitempty"`
 }
 
-// EncodeExecutionMessage marshals a message while preserving raw payload bytes.
+// EncodeExecutionMessage marshals a message to the canonical JSON envelope
+// while preserving raw payload bytes.
 func EncodeExecutionMessage(msg *job.ExecutionMessage) ([]byte, error) {
+	envelope, err := newMessageEnvelope(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
+
+func newMessageEnvelope(msg *job.ExecutionMessage) (messageEnvelope, error) {
 	if msg == nil {
-		return nil, fmt.Errorf("execution message required")
+		return messageEnvelope{}, fmt.Errorf("execution message required")
 	}
 
 	var params map[string]any
@@ -42,9 +60,11 @@ func EncodeExecutionMessage(msg *job.ExecutionMessage) ([]byte, error) {
 		}
 	}
 
-	envelope := messageEnvelope{
+	return messageEnvelope{
+		Version:         MessageFormatVersion,
 		JobID:           msg.JobID,
 		ScriptPath:      msg.ScriptPath,
+		ScriptChecksum:  msg.ScriptChecksum,
 		MachineID:       msg.MachineID,
 		EntityID:        msg.EntityID,
 		ExecutionID:     msg.ExecutionID,
@@ -55,15 +75,17 @@ func EncodeExecutionMessage(msg *job.ExecutionMessage) ([]byte, error) {
 		Parameters:      params,
 		IdempotencyKey:  msg.IdempotencyKey,
 		DedupPolicy:     msg.DedupPolicy,
+		TraceID:         msg.TraceID,
+		Attempt:         msg.Attempt,
 		Result:          msg.Result,
-	}
-
-	return json.Marshal(envelope)
+	}, nil
 }
 
 type messageEnvelopeRaw struct {
+	Version         int                        `json:"v,omitempty"`
 	JobID           string                     `json:"job_id"`
 	ScriptPath      string                     `json:"script_path"`
+	ScriptChecksum  string                     `json:"script_checksum,omitempty"`
 	MachineID       string                     `json:"machine_id,omitempty"`
 	EntityID        string                     `json:"entity_id,omitempty"`
 	ExecutionID     string                     `json:"execution_id,omitempty"`
@@ -74,23 +96,41 @@ type messageEnvelopeRaw struct {
 	Parameters      map[string]json.RawMessage `json:"parameters,omitempty"`
 	IdempotencyKey  string                     `json:"idempotency_key,omitempty"`
 	DedupPolicy     job.DeduplicationPolicy    `json:"dedup_policy,omitempty"`
+	TraceID         string                     `json:"trace_id,omitempty"`
+	Attempt         int                        `json:"attempt,omitempty"`
 	Result          *job.Result                `json:"result,omitempty"`
 }
 
-// DecodeExecutionMessage unmarshals a message and restores raw payload bytes.
+// DecodeExecutionMessage unmarshals a message written by any registered
+// codec: framed payloads go to the codec named in their header, anything
+// else is read as the JSON envelope. Raw payload bytes are restored.
 func DecodeExecutionMessage(payload []byte) (*job.ExecutionMessage, error) {
 	if len(payload) == 0 {
 		return nil, fmt.Errorf("payload empty")
 	}
+	if name, body, ok := splitCodecFrame(payload); ok {
+		codec, found := LookupMessageCodec(name)
+		if !found {
+			return nil, fmt.Errorf("unknown message codec %q", name)
+		}
+		return codec.Decode(body)
+	}
+	return decodeJSONEnvelope(payload)
+}
 
+func decodeJSONEnvelope(payload []byte) (*job.ExecutionMessage, error) {
 	var raw messageEnvelopeRaw
 	if err := json.Unmarshal(payload, &raw); err != nil {
 		return nil, err
 	}
+	if err := checkMessageVersion(raw.Version); err != nil {
+		return nil, err
+	}
 
 	msg := &job.ExecutionMessage{
 		JobID:           raw.JobID,
 		ScriptPath:      raw.ScriptPath,
+		ScriptChecksum:  raw.ScriptChecksum,
 		MachineID:       raw.MachineID,
 		EntityID:        raw.EntityID,
 		ExecutionID:     raw.ExecutionID,
@@ -100,6 +140,8 @@ func DecodeExecutionMessage(payload []byte) (*job.ExecutionMessage, error) {
 		Config:          raw.Config,
 		IdempotencyKey:  raw.IdempotencyKey,
 		DedupPolicy:     raw.DedupPolicy,
+		TraceID:         raw.TraceID,
+		Attempt:         raw.Attempt,
 		Result:          raw.Result,
 	}
 
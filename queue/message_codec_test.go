@@ -1,7 +1,10 @@
 package queue
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	job "github.com/goliatone/go-job"
 	"github.com/stretchr/testify/require"
@@ -23,6 +26,7 @@ func TestExecutionMessageCodecRoundTripWithFSMFields(t *testing.T) {
 		},
 		IdempotencyKey: "idem-1",
 		DedupPolicy:    job.DedupPolicyDrop,
+		TraceID:        "trace-1",
 	}
 
 	payload, err := EncodeExecutionMessage(msg)
@@ -42,6 +46,104 @@ func TestExecutionMessageCodecRoundTripWithFSMFields(t *testing.T) {
 	require.Equal(t, msg.ResumeEvent, decoded.ResumeEvent)
 	require.Equal(t, msg.IdempotencyKey, decoded.IdempotencyKey)
 	require.Equal(t, msg.DedupPolicy, decoded.DedupPolicy)
+	require.Equal(t, msg.TraceID, decoded.TraceID)
 	require.Equal(t, []byte(`{"hello":"world"}`), decoded.Parameters["payload"])
 	require.EqualValues(t, 2, decoded.Parameters["count"])
 }
+
+func codecTestMessage() *job.ExecutionMessage {
+	return &job.ExecutionMessage{
+		JobID:          "job-1",
+		ScriptPath:     "/tmp/job-1",
+		ExecutionID:    "exec-1",
+		Config:         job.Config{Retries: 2, Timeout: time.Minute, Metadata: map[string]any{"tier": "gold"}},
+		IdempotencyKey: "idem-1",
+		DedupPolicy:    job.DedupPolicyDrop,
+		TraceID:        "trace-1",
+		Attempt:        3,
+		Parameters: map[string]any{
+			"payload": []byte(`{"hello":"world"}`),
+			"count":   2,
+			"ratio":   0.5,
+			"tags":    []any{"a", "b"},
+			"nested":  map[string]any{"ok": true, "none": nil},
+			"long":    strings.Repeat("x", 300),
+		},
+		Result: &job.Result{Status: "succeeded"},
+	}
+}
+
+func TestMessageCodecsRoundTrip(t *testing.T) {
+	for _, name := range MessageCodecNames() {
+		t.Run(name, func(t *testing.T) {
+			codec, ok := LookupMessageCodec(name)
+			require.True(t, ok)
+			msg := codecTestMessage()
+
+			payload, err := EncodeExecutionMessageWith(codec, msg)
+			require.NoError(t, err)
+			decoded, err := DecodeExecutionMessage(payload)
+			require.NoError(t, err)
+
+			require.Equal(t, msg.JobID, decoded.JobID)
+			require.Equal(t, msg.ExecutionID, decoded.ExecutionID)
+			require.Equal(t, msg.Config.Retries, decoded.Config.Retries)
+			require.Equal(t, msg.Config.Timeout, decoded.Config.Timeout)
+			require.Equal(t, "gold", decoded.Config.Metadata["tier"])
+			require.Equal(t, msg.DedupPolicy, decoded.DedupPolicy)
+			require.Equal(t, msg.Attempt, decoded.Attempt)
+			require.Equal(t, msg.Result, decoded.Result)
+			require.Equal(t, []byte(`{"hello":"world"}`), decoded.Parameters["payload"])
+			require.EqualValues(t, 2, decoded.Parameters["count"])
+			require.Equal(t, 0.5, decoded.Parameters["ratio"])
+			require.Equal(t, []any{"a", "b"}, decoded.Parameters["tags"])
+			require.Equal(t, map[string]any{"ok": true, "none": nil}, decoded.Parameters["nested"])
+			require.Equal(t, msg.Parameters["long"], decoded.Parameters["long"])
+		})
+	}
+}
+
+func TestMsgpackCodecCarriesBinaryPayload(t *testing.T) {
+	msg := &job.ExecutionMessage{JobID: "job-1", ScriptPath: "/tmp/job-1", Parameters: map[string]any{"payload": []byte{0x00, 0xff}}}
+	payload, err := EncodeExecutionMessageWith(MsgpackCodec{}, msg)
+	require.NoError(t, err)
+	decoded, err := DecodeExecutionMessage(payload)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0xff}, decoded.Parameters["payload"])
+}
+
+func TestMsgpackCodecRejectsDeepNesting(t *testing.T) {
+	payload := bytes.Repeat([]byte{0x91}, maxMsgpackDepth+2)
+	payload = append(payload, 0xc0)
+	_, err := MsgpackCodec{}.Decode(payload)
+	require.ErrorContains(t, err, "nesting exceeds")
+}
+
+func TestMessageCodecVersioning(t *testing.T) {
+	payload, err := EncodeExecutionMessage(&job.ExecutionMessage{JobID: "job-1", ScriptPath: "/tmp/job-1"})
+	require.NoError(t, err)
+	require.Contains(t, string(payload), `"v":1`)
+
+	legacy, err := DecodeExecutionMessage([]byte(`{"job_id":"job-1","script_path":"/tmp/job-1","future_field":true}`))
+	require.NoError(t, err)
+	require.Equal(t, "job-1", legacy.JobID)
+
+	_, err = DecodeExecutionMessage([]byte(`{"v":2,"job_id":"job-1"}`))
+	require.ErrorIs(t, err, ErrUnsupportedMessageVersion)
+}
+
+type upperCodec struct{ JSONCodec }
+
+func (upperCodec) Name() string { return "test-json" }
+
+func TestRegisterMessageCodec(t *testing.T) {
+	require.NoError(t, RegisterMessageCodec(upperCodec{}))
+	payload, err := EncodeExecutionMessageWith(upperCodec{}, &job.ExecutionMessage{JobID: "job-1", ScriptPath: "/tmp/job-1"})
+	require.NoError(t, err)
+	decoded, err := DecodeExecutionMessage(payload)
+	require.NoError(t, err)
+	require.Equal(t, "job-1", decoded.JobID)
+
+	_, err = DecodeExecutionMessage(append([]byte{0x00, 'J', 'M', 3}, "bad{}"...))
+	require.ErrorContains(t, err, `unknown message codec "bad"`)
+}
@@ -0,0 +1,358 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	job "github.com/goliatone/go-job"
+)
+
+// MsgpackCodecName names the MessagePack codec.
+const MsgpackCodecName = "msgpack"
+
+// maxMsgpackDepth bounds array and map nesting so crafted payloads cannot
+// exhaust the stack while decoding.
+const maxMsgpackDepth = 64
+
+// MsgpackCodec encodes the envelope as MessagePack. The raw "payload"
+// parameter travels as a binary value; other parameters keep their JSON
+// shape, with integral numbers decoded as int64.
+type MsgpackCodec struct{}
+
+// Name implements MessageCodec.
+func (MsgpackCodec) Name() string { return MsgpackCodecName }
+
+// Encode implements MessageCodec.
+func (MsgpackCodec) Encode(msg *job.ExecutionMessage) ([]byte, error) {
+	envelope, err := newMessageEnvelope(msg)
+	if err != nil {
+		return nil, err
+	}
+	payload, hasPayload := envelope.Parameters["payload"].(json.RawMessage)
+	if hasPayload {
+		delete(envelope.Parameters, "payload")
+	}
+
+	generic, err := toGeneric(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if hasPayload {
+		root := generic.(map[string]any)
+		params, _ := root["parameters"].(map[string]any)
+		if params == nil {
+			params = make(map[string]any)
+			root["parameters"] = params
+		}
+		params["payload"] = []byte(payload)
+	}
+
+	var buf bytes.Buffer
+	if err := writeMsgpack(&buf, generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode implements MessageCodec.
+func (MsgpackCodec) Decode(payload []byte) (*job.ExecutionMessage, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("payload empty")
+	}
+	r := bytes.NewReader(payload)
+	value, err := readMsgpack(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("decode msgpack message: %w", err)
+	}
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("decode msgpack message: expected a map, got %T", value)
+	}
+
+	params, _ := root["parameters"].(map[string]any)
+	delete(root, "parameters")
+	typed, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := decodeJSONEnvelope(typed)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) > 0 {
+		msg.Parameters = params
+	}
+	return msg, nil
+}
+
+// toGeneric converts v to maps, slices and scalars through its JSON form.
+func toGeneric(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func writeMsgpack(buf *bytes.Buffer, v any) error {
+	switch value := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if value {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			writeMsgpackInt(buf, i)
+			return nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return err
+		}
+		writeMsgpackFloat(buf, f)
+	case int:
+		writeMsgpackInt(buf, int64(value))
+	case int64:
+		writeMsgpackInt(buf, value)
+	case float64:
+		writeMsgpackFloat(buf, value)
+	case string:
+		writeMsgpackHeader(buf, len(value), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		buf.WriteString(value)
+	case []byte:
+		writeMsgpackHeader(buf, len(value), 0, 0, 0xc4, 0xc5, 0xc6)
+		buf.Write(value)
+	case []any:
+		writeMsgpackHeader(buf, len(value), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, item := range value {
+			if err := writeMsgpack(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		writeMsgpackHeader(buf, len(value), 0x80, 16, 0, 0xde, 0xdf)
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := writeMsgpack(buf, k); err != nil {
+				return err
+			}
+			if err := writeMsgpack(buf, value[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		generic, err := toGeneric(value)
+		if err != nil {
+			return fmt.Errorf("msgpack: unsupported value %T: %w", v, err)
+		}
+		return writeMsgpack(buf, generic)
+	}
+	return nil
+}
+
+// writeMsgpackHeader writes a length header: the fix form when n < fixMax
+// (fixMax 0 disables it), then the 8, 16 or 32 bit forms (code 0 disables).
+func writeMsgpackHeader(buf *bytes.Buffer, n int, fix byte, fixMax int, c8, c16, c32 byte) {
+	switch {
+	case fixMax > 0 && n < fixMax:
+		buf.WriteByte(fix | byte(n))
+	case c8 != 0 && n <= math.MaxUint8:
+		buf.WriteByte(c8)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(c16)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(c32)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func writeMsgpackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 127:
+		buf.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		buf.WriteByte(byte(int8(i)))
+	default:
+		buf.WriteByte(0xd3)
+		_ = binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+func writeMsgpackFloat(buf *bytes.Buffer, f float64) {
+	buf.WriteByte(0xcb)
+	_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+}
+
+func readMsgpack(r *bytes.Reader, depth int) (any, error) {
+	if depth > maxMsgpackDepth {
+		return nil, fmt.Errorf("msgpack: nesting exceeds %d levels", maxMsgpackDepth)
+	}
+	code, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xe0 == 0xa0:
+		return readMsgpackString(r, int(code&0x1f))
+	case code&0xf0 == 0x90:
+		return readMsgpackArray(r, int(code&0x0f), depth)
+	case code&0xf0 == 0x80:
+		return readMsgpackMap(r, int(code&0x0f), depth)
+	}
+
+	switch code {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readMsgpackLength(r, code-0xc4)
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackBytes(r, n)
+	case 0xca:
+		var bits uint32
+		if err := binary.Read(r, binary.BigEndian, &bits); err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(bits)), nil
+	case 0xcb:
+		var bits uint64
+		if err := binary.Read(r, binary.BigEndian, &bits); err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(bits), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := readMsgpackUint(r, 1<<(code-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("msgpack: uint64 %d overflows int64", n)
+		}
+		return int64(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (code - 0xd0)
+		n, err := readMsgpackUint(r, size)
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := readMsgpackLength(r, code-0xd9)
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackString(r, n)
+	case 0xdc, 0xdd:
+		n, err := readMsgpackLength(r, code-0xdc+1)
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackArray(r, n, depth)
+	case 0xde, 0xdf:
+		n, err := readMsgpackLength(r, code-0xde+1)
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackMap(r, n, depth)
+	}
+	return nil, fmt.Errorf("msgpack: unsupported type code 0x%x", code)
+}
+
+// readMsgpackLength reads an 8, 16 or 32 bit length for width 0, 1 or 2.
+func readMsgpackLength(r *bytes.Reader, width byte) (int, error) {
+	n, err := readMsgpackUint(r, 1<<width)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(r.Len()) {
+		return 0, fmt.Errorf("msgpack: length %d exceeds remaining %d bytes", n, r.Len())
+	}
+	return int(n), nil
+}
+
+func readMsgpackUint(r *bytes.Reader, size int) (uint64, error) {
+	var raw [8]byte
+	if _, err := io.ReadFull(r, raw[8-size:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(raw[:]), nil
+}
+
+func readMsgpackBytes(r *bytes.Reader, n int) ([]byte, error) {
+	if n > r.Len() {
+		return nil, fmt.Errorf("msgpack: length %d exceeds remaining %d bytes", n, r.Len())
+	}
+	out := make([]byte, n)
+	if _, err := io.ReadFull(r, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func readMsgpackString(r *bytes.Reader, n int) (string, error) {
+	b, err := readMsgpackBytes(r, n)
+	return string(b), err
+}
+
+func readMsgpackArray(r *bytes.Reader, n, depth int) ([]any, error) {
+	out := make([]any, 0, min(n, r.Len()))
+	for i := 0; i < n; i++ {
+		v, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func readMsgpackMap(r *bytes.Reader, n, depth int) (map[string]any, error) {
+	out := make(map[string]any, min(n, r.Len()))
+	for i := 0; i < n; i++ {
+		k, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack: map key %T is not a string", k)
+		}
+		v, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+
+	job "github.com/goliatone/go-job"
+	adminv1 "github.com/goliatone/go-job/admin/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ProtobufCodecName names the protobuf codec.
+const ProtobufCodecName = "protobuf"
+
+// ProtobufCodec encodes the envelope as the ExecutionMessage type from
+// admin/v1/admin.proto, the same shape the gRPC service uses. The raw
+// "payload" parameter travels in the bytes field; config, result and the
+// other parameters travel as google.protobuf.Struct, so their numbers decode
+// as float64 like JSON.
+type ProtobufCodec struct{}
+
+// Name implements MessageCodec.
+func (ProtobufCodec) Name() string { return ProtobufCodecName }
+
+// Encode implements MessageCodec.
+func (ProtobufCodec) Encode(msg *job.ExecutionMessage) ([]byte, error) {
+	envelope, err := newMessageEnvelope(msg)
+	if err != nil {
+		return nil, err
+	}
+	out := &adminv1.ExecutionMessage{
+		V:               int32(envelope.Version),
+		JobId:           envelope.JobID,
+		ScriptPath:      envelope.ScriptPath,
+		ScriptChecksum:  envelope.ScriptChecksum,
+		MachineId:       envelope.MachineID,
+		EntityId:        envelope.EntityID,
+		ExecutionId:     envelope.ExecutionID,
+		ExpectedState:   envelope.ExpectedState,
+		ExpectedVersion: envelope.ExpectedVersion,
+		ResumeEvent:     envelope.ResumeEvent,
+		IdempotencyKey:  envelope.IdempotencyKey,
+		DedupPolicy:     string(envelope.DedupPolicy),
+		TraceId:         envelope.TraceID,
+		Attempt:         int32(envelope.Attempt),
+	}
+	if payload, ok := envelope.Parameters["payload"].(json.RawMessage); ok {
+		out.Payload = []byte(payload)
+		delete(envelope.Parameters, "payload")
+	}
+	if out.Config, err = toStruct(envelope.Config); err != nil {
+		return nil, fmt.Errorf("encode protobuf config: %w", err)
+	}
+	if len(envelope.Parameters) > 0 {
+		if out.Parameters, err = toStruct(envelope.Parameters); err != nil {
+			return nil, fmt.Errorf("encode protobuf parameters: %w", err)
+		}
+	}
+	if envelope.Result != nil {
+		if out.Result, err = toStruct(envelope.Result); err != nil {
+			return nil, fmt.Errorf("encode protobuf result: %w", err)
+		}
+	}
+	return proto.Marshal(out)
+}
+
+// Decode implements MessageCodec.
+func (ProtobufCodec) Decode(payload []byte) (*job.ExecutionMessage, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("payload empty")
+	}
+	var in adminv1.ExecutionMessage
+	if err := proto.Unmarshal(payload, &in); err != nil {
+		return nil, fmt.Errorf("decode protobuf message: %w", err)
+	}
+
+	root := map[string]any{
+		"v":                in.GetV(),
+		"job_id":           in.GetJobId(),
+		"script_path":      in.GetScriptPath(),
+		"script_checksum":  in.GetScriptChecksum(),
+		"machine_id":       in.GetMachineId(),
+		"entity_id":        in.GetEntityId(),
+		"execution_id":     in.GetExecutionId(),
+		"expected_state":   in.GetExpectedState(),
+		"expected_version": in.GetExpectedVersion(),
+		"resume_event":     in.GetResumeEvent(),
+		"idempotency_key":  in.GetIdempotencyKey(),
+		"dedup_policy":     in.GetDedupPolicy(),
+		"trace_id":         in.GetTraceId(),
+		"attempt":          in.GetAttempt(),
+	}
+	if in.GetConfig() != nil {
+		root["config"] = in.GetConfig().AsMap()
+	}
+	if in.GetResult() != nil {
+		root["result"] = in.GetResult().AsMap()
+	}
+	typed, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := decodeJSONEnvelope(typed)
+	if err != nil {
+		return nil, err
+	}
+
+	params := in.GetParameters().AsMap()
+	if in.GetPayload() != nil {
+		params["payload"] = cloneBytes(in.GetPayload())
+	}
+	if len(params) > 0 {
+		msg.Parameters = params
+	}
+	return msg, nil
+}
+
+// toStruct converts v to a protobuf Struct through its JSON form.
+func toStruct(v any) (*structpb.Struct, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(fields)
+}
@@ -12,6 +12,7 @@ type Receipt struct {
 	ID          string
 	Token       string
 	Attempts    int
+	Requeues    int
 	LeasedAt    time.Time
 	AvailableAt time.Time
 	CreatedAt   time.Time
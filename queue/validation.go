@@ -37,7 +37,7 @@ func ValidateRequiredMessage(msg *job.ExecutionMessage) error {
 // ValidateNackOptions enforces explicit nack disposition semantics.
 func ValidateNackOptions(opts NackOptions) error {
 	switch opts.Disposition {
-	case NackDispositionRetry:
+	case NackDispositionRetry, NackDispositionRequeue:
 		if opts.Delay < 0 {
 			return fmt.Errorf("retry delay must be >= 0")
 		}
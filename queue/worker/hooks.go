@@ -29,6 +29,9 @@ type Event struct {
 	Err         error
 	StartedAt   time.Time
 	Duration    time.Duration
+	// Report describes the commander run once the task has executed; nil for
+	// deliveries that never reached the commander.
+	Report *job.ExecutionReport
 }
 
 // Hook exposes lifecycle callbacks for worker execution.
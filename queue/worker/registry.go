@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	job "github.com/goliatone/go-job"
@@ -73,7 +74,7 @@ func (r *Registry) Get(id string) (Entry, bool) {
 	return entry, ok
 }
 
-// List returns registered tasks.
+// List returns registered tasks ordered by ID.
 func (r *Registry) List() []job.Task {
 	if r == nil {
 		return nil
@@ -84,5 +85,6 @@ func (r *Registry) List() []job.Task {
 	for _, entry := range r.tasks {
 		tasks = append(tasks, entry.Task)
 	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].GetID() < tasks[j].GetID() })
 	return tasks
 }
@@ -19,6 +19,8 @@ const (
 	defaultCancelPoll             = 250 * time.Millisecond
 	defaultLeaseHeartbeatInterval = 15 * time.Second
 	defaultLeaseExtensionTTL      = 60 * time.Second
+	defaultAffinityRetryDelay     = time.Second
+	defaultMaxAffinityRequeues    = 100
 )
 
 // ShutdownHook runs during worker shutdown.
@@ -102,7 +104,10 @@ func WithTaskCommanderRetries(enabled bool) Option {
 	}
 }
 
-// WithCommanderFactory overrides the TaskCommander construction.
+// WithCommanderFactory overrides the TaskCommander construction, e.g. with
+// Runner.Commander so queued runs honour the runner's maintenance mode,
+// toggles, hooks and history. The worker's idempotency store and retry policy
+// are applied on top.
 func WithCommanderFactory(factory CommanderFactory) Option {
 	return func(w *Worker) {
 		if factory != nil {
@@ -164,6 +169,42 @@ func WithIdempotencyStore(store qidempotency.Store, ttl time.Duration) Option {
 	}
 }
 
+// WithLabels sets the labels matched against a message's affinity, e.g.
+// gpu: "true". Messages whose affinity the labels do not satisfy are
+// requeued for another worker without spending a retry attempt.
+func WithLabels(labels map[string]string) Option {
+	return func(w *Worker) {
+		w.labels = make(map[string]string, len(labels))
+		for key, value := range labels {
+			w.labels[key] = value
+		}
+	}
+}
+
+// WithAffinityRetryDelay sets how long a message rejected for affinity waits
+// before it is redelivered.
+func WithAffinityRetryDelay(delay time.Duration) Option {
+	return func(w *Worker) {
+		if delay >= 0 {
+			w.affinityDelay = delay
+		}
+	}
+}
+
+// WithMaxAffinityRequeues dead-letters messages requeued for unmet affinity
+// this many times, so messages no worker can serve do not circulate forever.
+// It defaults to 100; zero or less removes the cap. The count is kept by
+// adapters exposing Requeues on their deliveries.
+func WithMaxAffinityRequeues(limit int) Option {
+	return func(w *Worker) {
+		w.maxAffinityRequeues = limit
+	}
+}
+
+// ErrAffinityMismatch is reported for deliveries whose affinity the worker's
+// labels do not satisfy.
+var ErrAffinityMismatch = fmt.Errorf("affinity not satisfied by worker labels")
+
 // Worker consumes queue deliveries and dispatches tasks.
 type Worker struct {
 	dequeuer               queue.Dequeuer
@@ -183,6 +224,9 @@ type Worker struct {
 	leaseExtensionTTL      time.Duration
 	idempotencyStore       qidempotency.Store
 	idempotencyTTL         time.Duration
+	labels                 map[string]string
+	affinityDelay          time.Duration
+	maxAffinityRequeues    int
 	mu                     sync.Mutex
 	wg                     sync.WaitGroup
 	running                bool
@@ -204,6 +248,8 @@ func NewWorker(dequeuer queue.Dequeuer, opts ...Option) *Worker {
 		cancelPoll:             defaultCancelPoll,
 		leaseHeartbeatInterval: defaultLeaseHeartbeatInterval,
 		leaseExtensionTTL:      defaultLeaseExtensionTTL,
+		affinityDelay:          defaultAffinityRetryDelay,
+		maxAffinityRequeues:    defaultMaxAffinityRequeues,
 		idempotencyTTL:         24 * time.Hour,
 		logger:                 loggerProvider.GetLogger("queue:worker"),
 		retryPolicy:            DefaultRetryPolicy{MaxAttempts: 1},
@@ -415,6 +461,23 @@ func (w *Worker) handleDelivery(ctx context.Context, delivery queue.Delivery) {
 		return
 	}
 
+	if unmet := job.UnmetAffinity(job.AffinityFor(entry.Task, msg), w.labels); len(unmet) > 0 {
+		err := fmt.Errorf("%w: %v", ErrAffinityMismatch, unmet)
+		if requeues := deliveryRequeues(delivery); w.maxAffinityRequeues > 0 && requeues >= w.maxAffinityRequeues {
+			w.failDelivery(ctx, event, err, queue.NackOptions{
+				Disposition: queue.NackDispositionDeadLetter,
+				Reason:      fmt.Sprintf("affinity not satisfied after %d requeues", requeues),
+			})
+			return
+		}
+		w.failDelivery(ctx, event, err, queue.NackOptions{
+			Disposition: queue.NackDispositionRequeue,
+			Delay:       w.affinityDelay,
+			Reason:      "affinity mismatch",
+		})
+		return
+	}
+
 	cancelState := &cancelState{}
 	cancelKey := w.cancellationKey(msg)
 	if cancelKey != "" && w.checkCancellation(ctx, cancelKey, cancelState) {
@@ -439,8 +502,9 @@ func (w *Worker) handleDelivery(ctx context.Context, delivery queue.Delivery) {
 		defer stopHeartbeat()
 	}
 
-	execErr := commander.Execute(execCtx, msg)
+	report, execErr := commander.ExecuteWithReport(execCtx, msg)
 	event.Duration = time.Since(started)
+	event.Report = &report
 	if execErr == nil {
 		if err := delivery.Ack(ctx); err != nil {
 			w.logAckError(event, err)
@@ -488,7 +552,7 @@ func (w *Worker) failDelivery(ctx context.Context, event Event, err error, opts
 	event.Duration = time.Since(event.StartedAt)
 	event.Delay = opts.Delay
 
-	if opts.Disposition == queue.NackDispositionRetry {
+	if opts.Disposition == queue.NackDispositionRetry || opts.Disposition == queue.NackDispositionRequeue {
 		w.logRetry(event)
 		w.emitRetry(ctx, event)
 	} else {
@@ -520,11 +584,16 @@ func (w *Worker) runShutdownHooks(ctx context.Context) error {
 	return errors.Join(errs...)
 }
 
+// buildCommander applies the worker's idempotency store and retry policy on
+// top of the commander built by the factory, if any.
 func (w *Worker) buildCommander(task job.Task) *job.TaskCommander {
+	var commander *job.TaskCommander
 	if w.commanderFactory != nil {
-		return w.commanderFactory(task)
+		commander = w.commanderFactory(task)
+	}
+	if commander == nil {
+		commander = job.NewTaskCommander(task)
 	}
-	commander := job.NewTaskCommander(task)
 	if w.idempotencyStore != nil {
 		commander.WithSharedIdempotencyStore(w.idempotencyStore, w.idempotencyTTL)
 	}
@@ -743,6 +812,17 @@ type deliveryAttemptsReader interface {
 	Attempts() int
 }
 
+type deliveryRequeuesReader interface {
+	Requeues() int
+}
+
+func deliveryRequeues(delivery queue.Delivery) int {
+	if reader, ok := delivery.(deliveryRequeuesReader); ok {
+		return reader.Requeues()
+	}
+	return 0
+}
+
 func deliveryAttempts(delivery queue.Delivery) int {
 	if delivery == nil {
 		return 1
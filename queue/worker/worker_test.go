@@ -629,3 +629,87 @@ func (t *testTask) Execute(ctx context.Context, msg *job.ExecutionMessage) error
 	}
 	return t.err
 }
+
+func TestWorkerRequeuesOnAffinityMismatch(t *testing.T) {
+	dequeuer := &fakeDequeuer{deliveries: make(chan queue.Delivery, 2)}
+	nackCh := make(chan queue.NackOptions, 1)
+	ackCh := make(chan struct{}, 1)
+	gpuTask := &testTask{id: "train", path: "/tmp/train", cfg: job.Config{Affinity: map[string]string{"gpu": "true"}}}
+	var runs int32
+	gpuTask.exec = func(context.Context, *job.ExecutionMessage) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}
+
+	dequeuer.deliveries <- &fakeDelivery{
+		msg: &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train", Config: job.Config{
+			Affinity: map[string]string{"region": "eu"},
+		}},
+		attempts: 1,
+		nackCh:   nackCh,
+	}
+	dequeuer.deliveries <- &fakeDelivery{
+		msg:      &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train"},
+		attempts: 1,
+		ackCh:    ackCh,
+	}
+
+	worker := NewWorker(dequeuer, WithConcurrency(1), WithIdleDelay(0),
+		WithLabels(map[string]string{"gpu": "true", "region": "us"}),
+		WithAffinityRetryDelay(5*time.Second))
+	require.NoError(t, worker.Register(gpuTask))
+	require.NoError(t, worker.Start(context.Background()))
+
+	var opts queue.NackOptions
+	select {
+	case opts = <-nackCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for nack")
+	}
+	select {
+	case <-ackCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for ack")
+	}
+	require.NoError(t, worker.Stop(context.Background()))
+
+	assert.Equal(t, queue.NackDispositionRequeue, opts.Disposition)
+	assert.Equal(t, 5*time.Second, opts.Delay)
+	assert.Equal(t, "affinity mismatch", opts.Reason)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
+
+type requeuedDelivery struct {
+	*fakeDelivery
+	requeues int
+}
+
+func (d *requeuedDelivery) Requeues() int { return d.requeues }
+
+func TestWorkerDeadLettersAfterMaxAffinityRequeues(t *testing.T) {
+	dequeuer := &fakeDequeuer{deliveries: make(chan queue.Delivery, 1)}
+	nackCh := make(chan queue.NackOptions, 1)
+	dequeuer.deliveries <- &requeuedDelivery{
+		fakeDelivery: &fakeDelivery{
+			msg:      &job.ExecutionMessage{JobID: "train", ScriptPath: "/tmp/train"},
+			attempts: 1,
+			nackCh:   nackCh,
+		},
+		requeues: 3,
+	}
+
+	worker := NewWorker(dequeuer, WithConcurrency(1), WithIdleDelay(0), WithMaxAffinityRequeues(3))
+	require.NoError(t, worker.Register(&testTask{id: "train", path: "/tmp/train", cfg: job.Config{Affinity: map[string]string{"gpu": "true"}}}))
+	require.NoError(t, worker.Start(context.Background()))
+
+	var opts queue.NackOptions
+	select {
+	case opts = <-nackCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for nack")
+	}
+	require.NoError(t, worker.Stop(context.Background()))
+
+	assert.Equal(t, queue.NackDispositionDeadLetter, opts.Disposition)
+	assert.Equal(t, "affinity not satisfied after 3 requeues", opts.Reason)
+}
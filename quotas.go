@@ -2,20 +2,85 @@ package job
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/goliatone/go-errors"
 )
 
 var (
+	// ErrQuotaExceeded is wrapped by every quota denial; match it with
+	// errors.Is and read the details with QuotaDenialFrom.
 	ErrQuotaExceeded = errors.New("quota exceeded", errors.CategoryRateLimit).
 		WithCode(errors.CodeTooManyRequests)
 )
 
+// Quota names used by the built-in checkers.
+const (
+	QuotaPayloadSize = "payload_size"
+	QuotaRetries     = "retries"
+	QuotaTenantRuns  = "tenant_runs"
+	QuotaGlobalRate  = "global_rate"
+)
+
+// QuotaDenial describes why a quota refused a message.
+type QuotaDenial struct {
+	Quota string `json:"quota"`
+	// Scope is the key the quota was counted under, e.g. the tenant ID.
+	Scope string `json:"scope,omitempty"`
+	Usage int64  `json:"usage"`
+	Limit int64  `json:"limit"`
+	// ResetAt is when the quota window resets; zero for static limits.
+	ResetAt time.Time `json:"reset_at,omitempty"`
+}
+
+// NewQuotaError builds the error returned for a denial. It wraps
+// ErrQuotaExceeded and carries the denial in its metadata.
+func NewQuotaError(textCode string, d QuotaDenial) *errors.Error {
+	meta := map[string]any{"quota": d.Quota, "usage": d.Usage, "limit": d.Limit}
+	if d.Scope != "" {
+		meta["scope"] = d.Scope
+	}
+	if !d.ResetAt.IsZero() {
+		meta["reset_at"] = d.ResetAt
+	}
+	err := errors.New(fmt.Sprintf("quota exceeded: %s (usage %d, limit %d)", d.Quota, d.Usage, d.Limit), errors.CategoryRateLimit).
+		WithCode(errors.CodeTooManyRequests).
+		WithTextCode(textCode).
+		WithMetadata(meta)
+	err.Source = ErrQuotaExceeded
+	return err
+}
+
+// QuotaDenialFrom extracts the denial carried by an error from NewQuotaError.
+func QuotaDenialFrom(err error) (QuotaDenial, bool) {
+	var typed *errors.Error
+	if err == nil || !stderrors.Is(err, ErrQuotaExceeded) || !errors.As(err, &typed) {
+		return QuotaDenial{}, false
+	}
+	d := QuotaDenial{}
+	d.Quota, _ = typed.Metadata["quota"].(string)
+	d.Scope, _ = typed.Metadata["scope"].(string)
+	d.Usage, _ = typed.Metadata["usage"].(int64)
+	d.Limit, _ = typed.Metadata["limit"].(int64)
+	d.ResetAt, _ = typed.Metadata["reset_at"].(time.Time)
+	return d, d.Quota != ""
+}
+
 type QuotaChecker interface {
 	Check(*ExecutionMessage) error
 }
 
+// QuotaReleaser is implemented by checkers whose Check consumes capacity,
+// such as RunRateQuota. MultiQuotaChecker calls Release on the checkers that
+// admitted a message when a later checker denies it, so the denied run does
+// not use up their slots.
+type QuotaReleaser interface {
+	Release(*ExecutionMessage)
+}
+
 type quotaCheckerFunc func(*ExecutionMessage) error
 
 func (f quotaCheckerFunc) Check(msg *ExecutionMessage) error {
@@ -42,17 +107,200 @@ func (q BasicQuotaChecker) Check(msg *ExecutionMessage) error {
 			return fmt.Errorf("quota marshal parameters: %w", err)
 		}
 		if len(payload) > q.PayloadSizeLimit {
-			return ErrQuotaExceeded.WithTextCode("PAYLOAD_TOO_LARGE").
-				WithMetadata(map[string]any{"size": len(payload), "limit": q.PayloadSizeLimit})
+			return NewQuotaError("PAYLOAD_TOO_LARGE", QuotaDenial{
+				Quota: QuotaPayloadSize,
+				Usage: int64(len(payload)),
+				Limit: int64(q.PayloadSizeLimit),
+			})
 		}
 	}
 
 	if q.MaxRetries > 0 && msg.Config.Retries > q.MaxRetries {
-		return ErrQuotaExceeded.WithTextCode("RETRY_LIMIT_EXCEEDED").
-			WithMetadata(map[string]any{"retries": msg.Config.Retries, "limit": q.MaxRetries})
+		return NewQuotaError("RETRY_LIMIT_EXCEEDED", QuotaDenial{
+			Quota: QuotaRetries,
+			Usage: int64(msg.Config.Retries),
+			Limit: int64(q.MaxRetries),
+		})
 	}
 
 	return nil
 }
 
 var defaultQuotaChecker QuotaChecker = quotaCheckerFunc(func(*ExecutionMessage) error { return nil })
+
+// RunRateQuota admits at most Limit runs per fixed Window, counted per scope.
+// Without a scope extractor it is a global rate; with TenantScopeExtractor it
+// caps runs per tenant. It is safe for concurrent use.
+type RunRateQuota struct {
+	name   string
+	limit  int
+	window time.Duration
+	scope  func(*ExecutionMessage) string
+	clock  Clock
+
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+}
+
+type quotaWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRunRateQuota builds a rate quota reported under name, e.g. QuotaGlobalRate.
+func NewRunRateQuota(name string, limit int, window time.Duration) *RunRateQuota {
+	return &RunRateQuota{
+		name:    name,
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*quotaWindow),
+	}
+}
+
+// NewTenantRunQuota caps runs per tenant, keyed by TenantScopeExtractor.
+// Messages without a tenant are not counted.
+func NewTenantRunQuota(limit int, window time.Duration) *RunRateQuota {
+	return NewRunRateQuota(QuotaTenantRuns, limit, window).WithScope(TenantScopeExtractor)
+}
+
+// WithScope counts runs per key returned by fn; an empty key skips the check.
+func (q *RunRateQuota) WithScope(fn func(*ExecutionMessage) string) *RunRateQuota {
+	q.scope = fn
+	return q
+}
+
+// WithClock sets the time source for windows. Defaults to SystemClock.
+func (q *RunRateQuota) WithClock(clock Clock) *RunRateQuota {
+	q.clock = clock
+	return q
+}
+
+// Check counts msg against its window, denying it once the limit is reached.
+func (q *RunRateQuota) Check(msg *ExecutionMessage) error {
+	if q == nil || msg == nil || q.limit <= 0 || q.window <= 0 {
+		return nil
+	}
+	key := ""
+	if q.scope != nil {
+		if key = q.scope(msg); key == "" {
+			return nil
+		}
+	}
+
+	now := clockOrSystem(q.clock).Now()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	w, ok := q.windows[key]
+	if !ok || !now.Before(w.start.Add(q.window)) {
+		w = &quotaWindow{start: now}
+		q.windows[key] = w
+	}
+	if w.count >= q.limit {
+		return NewQuotaError("RUN_RATE_EXCEEDED", QuotaDenial{
+			Quota:   q.name,
+			Scope:   key,
+			Usage:   int64(w.count),
+			Limit:   int64(q.limit),
+			ResetAt: w.start.Add(q.window),
+		})
+	}
+	w.count++
+	return nil
+}
+
+// Release returns the slot Check took for msg in the current window.
+func (q *RunRateQuota) Release(msg *ExecutionMessage) {
+	if q == nil || msg == nil || q.limit <= 0 || q.window <= 0 {
+		return
+	}
+	key := ""
+	if q.scope != nil {
+		if key = q.scope(msg); key == "" {
+			return
+		}
+	}
+
+	now := clockOrSystem(q.clock).Now()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if w, ok := q.windows[key]; ok && w.count > 0 && now.Before(w.start.Add(q.window)) {
+		w.count--
+	}
+}
+
+// MultiQuotaChecker runs several checkers in order and returns the first
+// denial, releasing the slots the earlier checkers took (see QuotaReleaser).
+// It counts denials per quota name for metrics.
+type MultiQuotaChecker struct {
+	checkers []QuotaChecker
+
+	mu      sync.Mutex
+	denials map[string]int64
+}
+
+// NewMultiQuotaChecker composes checkers; nil entries are ignored.
+func NewMultiQuotaChecker(checkers ...QuotaChecker) *MultiQuotaChecker {
+	m := &MultiQuotaChecker{denials: make(map[string]int64)}
+	for _, c := range checkers {
+		m.Add(c)
+	}
+	return m
+}
+
+// Add appends a checker.
+func (m *MultiQuotaChecker) Add(checker QuotaChecker) *MultiQuotaChecker {
+	if checker != nil {
+		m.checkers = append(m.checkers, checker)
+	}
+	return m
+}
+
+// Check implements QuotaChecker.
+func (m *MultiQuotaChecker) Check(msg *ExecutionMessage) error {
+	if m == nil {
+		return nil
+	}
+	for i, checker := range m.checkers {
+		err := checker.Check(msg)
+		if err == nil {
+			continue
+		}
+		releaseQuotas(m.checkers[:i], msg)
+		quota := "unknown"
+		if d, ok := QuotaDenialFrom(err); ok {
+			quota = d.Quota
+		}
+		m.mu.Lock()
+		m.denials[quota]++
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Release implements QuotaReleaser for nested checkers.
+func (m *MultiQuotaChecker) Release(msg *ExecutionMessage) {
+	if m == nil {
+		return
+	}
+	releaseQuotas(m.checkers, msg)
+}
+
+func releaseQuotas(checkers []QuotaChecker, msg *ExecutionMessage) {
+	for i := len(checkers) - 1; i >= 0; i-- {
+		if releaser, ok := checkers[i].(QuotaReleaser); ok {
+			releaser.Release(msg)
+		}
+	}
+}
+
+// Denials returns the number of denials per quota name.
+func (m *MultiQuotaChecker) Denials() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]int64, len(m.denials))
+	for k, v := range m.denials {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// RedactedValue replaces values removed by RedactingSanitizer.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeys are the patterns RedactingSanitizer uses when none are given.
+var DefaultRedactKeys = []string{"*password*", "*passwd*", "*secret*", "*token*", "authorization", "*api_key*", "*apikey*", "cookie"}
+
+// RedactingSanitizer returns an EnvelopeSanitizer replacing sensitive values
+// with RedactedValue. Keys match case-insensitively and support path.Match
+// wildcards ("*token*"). A key without dots matches at any depth; a dotted
+// key ("db.password", "credentials.*") matches that path from the root.
+// Nested maps and slices are walked and copied, so inputs are not mutated.
+// Without keys, DefaultRedactKeys are used.
+func RedactingSanitizer(keys ...string) EnvelopeSanitizer {
+	if len(keys) == 0 {
+		keys = DefaultRedactKeys
+	}
+	patterns := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			patterns = append(patterns, strings.Split(key, "."))
+		}
+	}
+	return func(params map[string]any) map[string]any {
+		if params == nil {
+			return nil
+		}
+		return redactMap(params, nil, patterns)
+	}
+}
+
+// RedactResult returns res with its Metadata passed through sanitizer.
+func RedactResult(res Result, sanitizer EnvelopeSanitizer) Result {
+	if sanitizer != nil && res.Metadata != nil {
+		res.Metadata = sanitizer(res.Metadata)
+	}
+	return res
+}
+
+var defaultRedactor = RedactingSanitizer()
+
+// redactForLog drops the script body and redacts sensitive parameters.
+func redactForLog(params map[string]any) map[string]any {
+	if len(params) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(params))
+	for key, value := range params {
+		if key != "script" {
+			out[key] = value
+		}
+	}
+	return defaultRedactor(out)
+}
+
+// ConfigRedactKeys extends DefaultRedactKeys with connection strings, which
+// embed credentials under keys the default patterns do not match. Use it for
+// task config metadata and env.
+var ConfigRedactKeys = append([]string{"dsn", "*connection_string*"}, DefaultRedactKeys...)
+
+var configLogRedactor = RedactingSanitizer(ConfigRedactKeys...)
+
+// redactConfigForLog returns cfg in its JSON form with credentials in its
+// metadata and env redacted.
+func redactConfigForLog(cfg Config) map[string]any {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return configLogRedactor(out)
+}
+
+func redactMap(in map[string]any, parent []string, patterns [][]string) map[string]any {
+	out := make(map[string]any, len(in))
+	for key, value := range in {
+		keyPath := append(append([]string(nil), parent...), strings.ToLower(key))
+		if redactMatches(keyPath, patterns) {
+			out[key] = RedactedValue
+			continue
+		}
+		out[key] = redactValue(value, keyPath, patterns)
+	}
+	return out
+}
+
+func redactValue(value any, keyPath []string, patterns [][]string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactMap(v, keyPath, patterns)
+	case map[string]string:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			converted[key] = item
+		}
+		return redactMap(converted, keyPath, patterns)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue(item, keyPath, patterns)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func redactMatches(keyPath []string, patterns [][]string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) == 1 {
+			if ok, _ := path.Match(pattern[0], keyPath[len(keyPath)-1]); ok {
+				return true
+			}
+			continue
+		}
+		if len(pattern) != len(keyPath) {
+			continue
+		}
+		matched := true
+		for i := range pattern {
+			if ok, _ := path.Match(pattern[i], keyPath[i]); !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
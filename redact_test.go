@@ -0,0 +1,160 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingSanitizer(t *testing.T) {
+	params := map[string]any{
+		"user":          "ana",
+		"Password":      "hunter2",
+		"Authorization": "Bearer abc",
+		"db": map[string]any{
+			"host":     "db.internal",
+			"password": "pg-secret",
+		},
+		"hooks": []any{
+			map[string]any{"url": "https://example.com", "access_token": "t-1"},
+		},
+		"headers": map[string]string{"X-Api-Key": "k"},
+	}
+
+	redacted := job.RedactingSanitizer()(params)
+
+	assert.Equal(t, "ana", redacted["user"])
+	assert.Equal(t, job.RedactedValue, redacted["Password"])
+	assert.Equal(t, job.RedactedValue, redacted["Authorization"])
+	db := redacted["db"].(map[string]any)
+	assert.Equal(t, "db.internal", db["host"])
+	assert.Equal(t, job.RedactedValue, db["password"])
+	hook := redacted["hooks"].([]any)[0].(map[string]any)
+	assert.Equal(t, job.RedactedValue, hook["access_token"])
+	assert.Equal(t, "https://example.com", hook["url"])
+	assert.Equal(t, "k", redacted["headers"].(map[string]any)["X-Api-Key"], "x-api-key is not a default pattern")
+
+	assert.Equal(t, "hunter2", params["Password"], "input is not mutated")
+	assert.Equal(t, "pg-secret", params["db"].(map[string]any)["password"])
+}
+
+func TestRedactingSanitizerPaths(t *testing.T) {
+	sanitize := job.RedactingSanitizer("db.password", "credentials.*")
+	redacted := sanitize(map[string]any{
+		"password":    "top-level stays",
+		"db":          map[string]any{"password": "x", "user": "app"},
+		"credentials": map[string]any{"a": 1, "b": 2},
+	})
+
+	assert.Equal(t, "top-level stays", redacted["password"])
+	assert.Equal(t, job.RedactedValue, redacted["db"].(map[string]any)["password"])
+	assert.Equal(t, "app", redacted["db"].(map[string]any)["user"])
+	assert.Equal(t, map[string]any{"a": job.RedactedValue, "b": job.RedactedValue}, redacted["credentials"])
+}
+
+func TestRedactingSanitizerWithEnvelope(t *testing.T) {
+	payload, err := job.EncodeEnvelope(job.Envelope{Params: map[string]any{"token": "abc", "n": 1}},
+		job.WithEnvelopeSanitizer(job.RedactingSanitizer()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "abc")
+	assert.Contains(t, string(payload), job.RedactedValue)
+}
+
+func TestRunnerSetResultRedactsMetadata(t *testing.T) {
+	runner := job.NewRunner()
+	require.NoError(t, runner.SetResult("job", job.Result{Status: "success", Metadata: map[string]any{"api_key": "k", "rows": 3}}))
+
+	stored, ok := runner.GetResult("job")
+	require.True(t, ok)
+	assert.Equal(t, job.RedactedValue, stored.Metadata["api_key"])
+	assert.Equal(t, 3, stored.Metadata["rows"])
+
+	raw := job.NewRunner(job.WithResultRedactor(nil))
+	require.NoError(t, raw.SetResult("job", job.Result{Metadata: map[string]any{"api_key": "k"}}))
+	stored, _ = raw.GetResult("job")
+	assert.Equal(t, "k", stored.Metadata["api_key"])
+}
+
+type resultRecorderFunc func(jobID string, result job.Result) error
+
+func (f resultRecorderFunc) SetResult(jobID string, result job.Result) error { return f(jobID, result) }
+
+func TestTaskCommanderRedactsRecordedResults(t *testing.T) {
+	var recorded job.Result
+	recorder := resultRecorderFunc(func(_ string, result job.Result) error {
+		recorded = result
+		return nil
+	})
+	marker := func(metadata map[string]any) map[string]any {
+		out := map[string]any{"sanitized": true}
+		for key, value := range metadata {
+			out[key] = value
+		}
+		return out
+	}
+	task := job.NewBaseTask("crash", "jobs/crash.js", "js", job.Config{}, "", panickingEngine{calls: new(int)})
+
+	err := job.NewTaskCommander(task).
+		WithResultRecorder(recorder).
+		WithResultRedactor(marker).
+		Execute(context.Background(), &job.ExecutionMessage{JobID: "crash"})
+	require.True(t, job.IsPanic(err))
+	assert.Equal(t, job.ResultStatusFailed, recorded.Status)
+	assert.Equal(t, true, recorded.Metadata["sanitized"])
+}
+
+type resultReportingEngine struct {
+	noopEngine
+	result job.Result
+}
+
+func (e resultReportingEngine) Execute(ctx context.Context, _ *job.ExecutionMessage) error {
+	job.ReportResult(ctx, e.result)
+	return nil
+}
+
+func TestTaskCommanderRedactsReportedResults(t *testing.T) {
+	engine := resultReportingEngine{result: job.Result{Metadata: map[string]any{"api_token": "secret", "rows": 3}}}
+	task := job.NewBaseTask("export", "jobs/export.js", "js", job.Config{}, "", engine)
+	runs := job.NewRunCache(10)
+
+	report, err := job.NewTaskCommander(task).
+		WithRunCache(runs).
+		ExecuteWithReport(context.Background(), &job.ExecutionMessage{JobID: "export"})
+	require.NoError(t, err)
+	assert.Equal(t, job.RedactedValue, report.Result.Metadata["api_token"])
+	assert.Equal(t, 3, report.Result.Metadata["rows"])
+
+	recent := runs.Recent(1)
+	require.Len(t, recent, 1)
+	assert.Equal(t, job.RedactedValue, recent[0].Result.Metadata["api_token"])
+}
+
+func TestRunArchiveRedactsViewsButReplaysRawValues(t *testing.T) {
+	engine := &sequenceEngine{}
+	task := job.NewBaseTask("import.js", "jobs/import.js", "js", job.Config{Env: map[string]string{"DB_PASSWORD": "hunter2"}}, "", engine)
+	archive := job.NewRunArchive(10)
+	cmd := job.NewTaskCommander(task).WithRunArchive(archive)
+
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{
+		JobID:      "import.js",
+		Parameters: map[string]any{"api_token": "secret", "batch": 42},
+	}))
+
+	archived, ok := archive.Get("run-1")
+	require.True(t, ok)
+	assert.Equal(t, job.RedactedValue, archived.Message.Parameters["api_token"])
+	assert.Equal(t, 42, archived.Message.Parameters["batch"])
+	assert.Equal(t, job.RedactedValue, archived.Message.Config.Env["DB_PASSWORD"])
+	assert.Equal(t, job.RedactedValue, archive.Runs("import.js")[0].Message.Parameters["api_token"])
+
+	_, err := cmd.Replay(context.Background(), "run-1")
+	require.NoError(t, err)
+	require.Len(t, engine.msgs, 2)
+	assert.Equal(t, "secret", engine.msgs[1].Parameters["api_token"])
+	assert.Equal(t, "hunter2", engine.msgs[1].Config.Env["DB_PASSWORD"])
+}
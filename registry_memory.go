@@ -1,20 +1,25 @@
 package job
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 )
 
 type memoryRegistry struct {
-	mx      sync.RWMutex
-	jobs    map[string]Task
-	results map[string]Result
+	mx       sync.RWMutex
+	jobs     map[string]Task
+	results  map[string]Result
+	disabled map[string]struct{}
+	watchers registryWatchers
 }
 
 func NewMemoryRegistry() *memoryRegistry {
 	return &memoryRegistry{
-		jobs:    make(map[string]Task),
-		results: make(map[string]Result),
+		jobs:     make(map[string]Task),
+		results:  make(map[string]Result),
+		disabled: make(map[string]struct{}),
 	}
 }
 
@@ -28,9 +33,76 @@ func (r *memoryRegistry) Add(job Task) error {
 	}
 
 	r.jobs[id] = job
+	r.watchers.publish(RegistryEvent{Type: RegistryEventAdded, TaskID: id, Task: job})
 	return nil
 }
 
+// Replace stores job, overwriting any task registered under the same ID.
+func (r *memoryRegistry) Replace(job Task) error {
+	if job == nil {
+		return fmt.Errorf("job is nil")
+	}
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	_, exists := r.jobs[job.GetID()]
+	r.jobs[job.GetID()] = job
+	eventType := RegistryEventAdded
+	if exists {
+		eventType = RegistryEventUpdated
+	}
+	r.watchers.publish(RegistryEvent{Type: eventType, TaskID: job.GetID(), Task: job})
+	return nil
+}
+
+// Remove deletes the task and its stored result, reporting whether it existed.
+func (r *memoryRegistry) Remove(id string) bool {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	task, ok := r.jobs[id]
+	delete(r.jobs, id)
+	delete(r.results, id)
+	delete(r.disabled, id)
+	if ok {
+		r.watchers.publish(RegistryEvent{Type: RegistryEventRemoved, TaskID: id, Task: task})
+	}
+	return ok
+}
+
+// SetEnabled enables or disables a registered task.
+func (r *memoryRegistry) SetEnabled(id string, enabled bool) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.jobs[id]; !ok {
+		return fmt.Errorf("job with ID %s not found", id)
+	}
+	_, wasDisabled := r.disabled[id]
+	if enabled {
+		delete(r.disabled, id)
+	} else {
+		r.disabled[id] = struct{}{}
+	}
+	if wasDisabled == enabled {
+		eventType := RegistryEventDisabled
+		if enabled {
+			eventType = RegistryEventEnabled
+		}
+		r.watchers.publish(RegistryEvent{Type: eventType, TaskID: id, Task: r.jobs[id]})
+	}
+	return nil
+}
+
+// IsEnabled reports whether id may run. Unknown IDs are considered enabled.
+func (r *memoryRegistry) IsEnabled(id string) bool {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	_, disabled := r.disabled[id]
+	return !disabled
+}
+
 func (r *memoryRegistry) Get(id string) (Task, bool) {
 	r.mx.Lock()
 	defer r.mx.Unlock()
@@ -39,17 +111,32 @@ func (r *memoryRegistry) Get(id string) (Task, bool) {
 	return job, ok
 }
 
+// List returns the registered tasks ordered by ID.
 func (r *memoryRegistry) List() []Task {
-	r.mx.Lock()
-	defer r.mx.Unlock()
+	return r.ListFunc(nil)
+}
 
+// ListFunc returns the registered tasks ordered by less, or by ID when less
+// is nil. Ties keep ID order.
+func (r *memoryRegistry) ListFunc(less func(a, b Task) bool) []Task {
+	r.mx.Lock()
 	jobs := make([]Task, 0, len(r.jobs))
 	for _, job := range r.jobs {
 		jobs = append(jobs, job)
 	}
+	r.mx.Unlock()
+
+	sortTasksByID(jobs)
+	if less != nil {
+		sort.SliceStable(jobs, func(i, j int) bool { return less(jobs[i], jobs[j]) })
+	}
 	return jobs
 }
 
+func sortTasksByID(tasks []Task) {
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].GetID() < tasks[j].GetID() })
+}
+
 func (r *memoryRegistry) SetResult(id string, result Result) error {
 	r.mx.Lock()
 	defer r.mx.Unlock()
@@ -57,6 +144,7 @@ func (r *memoryRegistry) SetResult(id string, result Result) error {
 		return fmt.Errorf("job id required")
 	}
 	r.results[id] = result
+	r.watchers.publish(RegistryEvent{Type: RegistryEventResultSet, TaskID: id, Task: r.jobs[id], Result: &result})
 	return nil
 }
 
@@ -66,3 +154,8 @@ func (r *memoryRegistry) GetResult(id string) (Result, bool) {
 	result, ok := r.results[id]
 	return result, ok
 }
+
+// Watch streams registry changes until ctx is done.
+func (r *memoryRegistry) Watch(ctx context.Context) <-chan RegistryEvent {
+	return r.watchers.watch(ctx, &r.mx)
+}
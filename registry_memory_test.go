@@ -1,8 +1,10 @@
 package job_test
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-job"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +51,29 @@ func TestMemoryRegistry_List(t *testing.T) {
 	assert.Len(t, jobs, 2)
 }
 
+func TestMemoryRegistry_ListOrder(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	for _, id := range []string{"c", "a", "d", "b"} {
+		require.NoError(t, registry.Add(&stubTask{id: id}))
+	}
+
+	ids := func(tasks []job.Task) []string {
+		out := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			out = append(out, task.GetID())
+		}
+		return out
+	}
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, []string{"a", "b", "c", "d"}, ids(registry.List()))
+	}
+
+	vowelsFirst := func(a, b job.Task) bool { return a.GetID() == "a" && b.GetID() != "a" }
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids(registry.ListFunc(vowelsFirst)))
+	reversed := func(a, b job.Task) bool { return a.GetID() > b.GetID() }
+	assert.Equal(t, []string{"d", "c", "b", "a"}, ids(registry.ListFunc(reversed)))
+}
+
 func TestMemoryRegistry_Concurrency(t *testing.T) {
 	registry := job.NewMemoryRegistry()
 	var wg sync.WaitGroup
@@ -67,3 +92,64 @@ func TestMemoryRegistry_Concurrency(t *testing.T) {
 	jobs := registry.List()
 	assert.GreaterOrEqual(t, len(jobs), 1)
 }
+
+func TestMemoryRegistry_SetEnabled(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(stubTask{id: "task-1"}))
+
+	assert.True(t, registry.IsEnabled("task-1"))
+	require.NoError(t, registry.SetEnabled("task-1", false))
+	assert.False(t, registry.IsEnabled("task-1"))
+
+	cmd := job.NewTaskCommander(stubTask{id: "task-1"}).WithTaskToggler(registry)
+	err := cmd.Execute(context.Background(), &job.ExecutionMessage{JobID: "task-1", ScriptPath: "task-1"})
+	require.ErrorIs(t, err, job.ErrTaskDisabled)
+
+	result, ok := registry.GetResult("task-1")
+	require.True(t, ok)
+	assert.Equal(t, job.ResultStatusDisabled, result.Status)
+
+	require.NoError(t, registry.SetEnabled("task-1", true))
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{JobID: "task-1", ScriptPath: "task-1"}))
+
+	require.Error(t, registry.SetEnabled("missing", false))
+}
+
+func TestMemoryRegistry_Watch(t *testing.T) {
+	registry := job.NewMemoryRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := registry.Watch(ctx)
+
+	require.NoError(t, registry.Add(stubTask{id: "task-1"}))
+	require.NoError(t, registry.Replace(stubTask{id: "task-1"}))
+	require.NoError(t, registry.SetEnabled("task-1", false))
+	require.NoError(t, registry.SetResult("task-1", job.Result{Status: "ok"}))
+	assert.True(t, registry.Remove("task-1"))
+
+	want := []job.RegistryEventType{
+		job.RegistryEventAdded,
+		job.RegistryEventUpdated,
+		job.RegistryEventDisabled,
+		job.RegistryEventResultSet,
+		job.RegistryEventRemoved,
+	}
+	for _, eventType := range want {
+		select {
+		case event := <-events:
+			assert.Equal(t, eventType, event.Type)
+			assert.Equal(t, "task-1", event.TaskID)
+			if eventType == job.RegistryEventResultSet {
+				require.NotNil(t, event.Result)
+				assert.Equal(t, "ok", event.Result.Status)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", eventType)
+		}
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-events
+		return !open
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,87 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultRegistryWatchBuffer = 64
+
+// RegistryEventType identifies a registry change.
+type RegistryEventType string
+
+const (
+	RegistryEventAdded     RegistryEventType = "added"
+	RegistryEventUpdated   RegistryEventType = "updated"
+	RegistryEventRemoved   RegistryEventType = "removed"
+	RegistryEventEnabled   RegistryEventType = "enabled"
+	RegistryEventDisabled  RegistryEventType = "disabled"
+	RegistryEventResultSet RegistryEventType = "result_set"
+)
+
+// RegistryEvent describes a single registry change. Result is set for
+// RegistryEventResultSet only.
+type RegistryEvent struct {
+	Type   RegistryEventType
+	TaskID string
+	Task   Task
+	Result *Result
+	Time   time.Time
+}
+
+// WatchableRegistry is implemented by registries that publish their changes,
+// letting components react instead of polling List.
+type WatchableRegistry interface {
+	Registry
+	Watch(ctx context.Context) <-chan RegistryEvent
+}
+
+// registryWatchers fans registry events out to subscribers. publish is called
+// with the owning registry's lock held so events arrive in mutation order; slow
+// watchers drop events rather than block writers.
+type registryWatchers struct {
+	nextID uint64
+	subs   map[uint64]chan RegistryEvent
+}
+
+func (w *registryWatchers) publish(event RegistryEvent) {
+	if len(w.subs) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	for _, ch := range w.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// watch registers a subscriber guarded by mu; the channel closes once ctx is done.
+func (w *registryWatchers) watch(ctx context.Context, mu sync.Locker) <-chan RegistryEvent {
+	ch := make(chan RegistryEvent, defaultRegistryWatchBuffer)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	mu.Lock()
+	if w.subs == nil {
+		w.subs = make(map[uint64]chan RegistryEvent)
+	}
+	w.nextID++
+	id := w.nextID
+	w.subs[id] = ch
+	mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		mu.Lock()
+		delete(w.subs, id)
+		close(ch)
+		mu.Unlock()
+	}()
+	return ch
+}
@@ -0,0 +1,219 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"unicode/utf8"
+)
+
+// ResultProcessor transforms a Result before it is persisted, e.g. to redact,
+// summarise, offload or enrich it. An error stops the result from being
+// stored.
+type ResultProcessor interface {
+	ProcessResult(ctx context.Context, jobID string, result Result) (Result, error)
+}
+
+// ResultProcessorFunc adapts a function to the ResultProcessor interface.
+type ResultProcessorFunc func(ctx context.Context, jobID string, result Result) (Result, error)
+
+// ProcessResult implements ResultProcessor.
+func (f ResultProcessorFunc) ProcessResult(ctx context.Context, jobID string, result Result) (Result, error) {
+	if f == nil {
+		return result, nil
+	}
+	return f(ctx, jobID, result)
+}
+
+// ResultProcessorsMetadataKey lists, in task metadata, the named processors
+// applied to the job's results, e.g. `result_processors: [upload, git]`.
+const ResultProcessorsMetadataKey = "result_processors"
+
+// ResultPipeline applies processors to results before they are persisted:
+// the global processors for every job, then the named processors a job
+// selects through its `result_processors` metadata, in the listed order.
+type ResultPipeline struct {
+	global []ResultProcessor
+	named  map[string]ResultProcessor
+}
+
+// NewResultPipeline returns a pipeline running processors for every job.
+func NewResultPipeline(processors ...ResultProcessor) *ResultPipeline {
+	return (&ResultPipeline{}).Use(processors...)
+}
+
+// Use appends processors run for every job.
+func (p *ResultPipeline) Use(processors ...ResultProcessor) *ResultPipeline {
+	for _, processor := range processors {
+		if processor != nil {
+			p.global = append(p.global, processor)
+		}
+	}
+	return p
+}
+
+// Register makes processor selectable by name from task metadata.
+func (p *ResultPipeline) Register(name string, processor ResultProcessor) *ResultPipeline {
+	if name == "" || processor == nil {
+		return p
+	}
+	if p.named == nil {
+		p.named = make(map[string]ResultProcessor)
+	}
+	p.named[name] = processor
+	return p
+}
+
+// Validate reports an error when cfg names a processor that is not
+// registered. The runner calls it before registering a task, so typos fail at
+// discovery instead of when a result is stored.
+func (p *ResultPipeline) Validate(jobID string, cfg Config) error {
+	for _, name := range stringList(cfg.Metadata[ResultProcessorsMetadataKey]) {
+		if p == nil || p.named[name] == nil {
+			return fmt.Errorf("job %s: unknown result processor %q", jobID, name)
+		}
+	}
+	return nil
+}
+
+// Process runs result through the global processors and those cfg selects.
+// Naming an unregistered processor is an error.
+func (p *ResultPipeline) Process(ctx context.Context, jobID string, cfg Config, result Result) (Result, error) {
+	if p == nil {
+		return result, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	processors := append([]ResultProcessor(nil), p.global...)
+	for _, name := range stringList(cfg.Metadata[ResultProcessorsMetadataKey]) {
+		processor, ok := p.named[name]
+		if !ok {
+			return result, fmt.Errorf("job %s: unknown result processor %q", jobID, name)
+		}
+		processors = append(processors, processor)
+	}
+
+	var err error
+	for _, processor := range processors {
+		if result, err = processor.ProcessResult(ctx, jobID, result); err != nil {
+			return result, fmt.Errorf("job %s: process result: %w", jobID, err)
+		}
+	}
+	return result, nil
+}
+
+// RedactResultProcessor passes Result metadata through sanitizer.
+func RedactResultProcessor(sanitizer EnvelopeSanitizer) ResultProcessor {
+	return ResultProcessorFunc(func(_ context.Context, _ string, result Result) (Result, error) {
+		return RedactResult(result, sanitizer), nil
+	})
+}
+
+// SummarizeResultProcessor truncates Message to maxRunes runes, recording the
+// original length in the message_length metadata.
+func SummarizeResultProcessor(maxRunes int) ResultProcessor {
+	return ResultProcessorFunc(func(_ context.Context, _ string, result Result) (Result, error) {
+		length := utf8.RuneCountInString(result.Message)
+		if maxRunes <= 0 || length <= maxRunes {
+			return result, nil
+		}
+		result.Message = string([]rune(result.Message)[:maxRunes]) + "…"
+		result.Metadata = withResultMetadata(result.Metadata, map[string]any{"message_length": length})
+		return result, nil
+	})
+}
+
+// ResultArtifactName is the artifact ArtifactResultProcessor uploads.
+const ResultArtifactName = "result.json"
+
+// ArtifactResultProcessor uploads Result metadata larger than maxBytes,
+// encoded as JSON, to store as ResultArtifactName. The metadata is replaced by
+// the artifact name and run ID, Size is set to the uploaded size and
+// OutputURL to urlFor(artifact); without urlFor it is
+// "artifact://<run_id>/result.json". The run ID is read from ctx, then from
+// the run_id metadata.
+func ArtifactResultProcessor(store ArtifactStore, maxBytes int, urlFor func(Artifact) string) ResultProcessor {
+	return ResultProcessorFunc(func(ctx context.Context, jobID string, result Result) (Result, error) {
+		if store == nil || len(result.Metadata) == 0 {
+			return result, nil
+		}
+		payload, err := json.Marshal(result.Metadata)
+		if err != nil {
+			return result, err
+		}
+		if len(payload) <= maxBytes {
+			return result, nil
+		}
+
+		runID := RunIDFromContext(ctx)
+		if runID == "" {
+			runID, _ = result.Metadata["run_id"].(string)
+		}
+		if runID == "" {
+			runID = NewRunID()
+		}
+		artifact := Artifact{RunID: runID, JobID: jobID, Name: ResultArtifactName, Size: int64(len(payload))}
+		if err := store.PutArtifact(ctx, artifact, bytes.NewReader(payload)); err != nil {
+			return result, fmt.Errorf("upload result artifact: %w", err)
+		}
+
+		result.Metadata = map[string]any{"artifact": artifact.Name, "run_id": runID}
+		result.Size = artifact.Size
+		if urlFor != nil {
+			result.OutputURL = urlFor(artifact)
+		} else {
+			result.OutputURL = fmt.Sprintf("artifact://%s/%s", runID, artifact.Name)
+		}
+		return result, nil
+	})
+}
+
+// EnrichResultProcessor adds fields to Result metadata, replacing existing keys.
+func EnrichResultProcessor(fields map[string]any) ResultProcessor {
+	fields = maps.Clone(fields)
+	return ResultProcessorFunc(func(_ context.Context, _ string, result Result) (Result, error) {
+		result.Metadata = withResultMetadata(result.Metadata, fields)
+		return result, nil
+	})
+}
+
+// GitSHAResultProcessor records the deployed revision as git_sha metadata.
+func GitSHAResultProcessor(sha string) ResultProcessor {
+	return EnrichResultProcessor(map[string]any{"git_sha": sha})
+}
+
+// withResultMetadata returns a copy of metadata with fields set.
+func withResultMetadata(metadata, fields map[string]any) map[string]any {
+	out := make(map[string]any, len(metadata)+len(fields))
+	maps.Copy(out, metadata)
+	maps.Copy(out, fields)
+	return out
+}
+
+// WithResultProcessors runs processors on every result stored through
+// Runner.SetResult, after redaction. Runner commanders record failed,
+// skipped and panicked runs through SetResult too.
+func WithResultProcessors(processors ...ResultProcessor) Option {
+	return func(r *Runner) {
+		r.resultPipeline().Use(processors...)
+	}
+}
+
+// WithNamedResultProcessor registers a processor jobs opt into by listing
+// name in their `result_processors` metadata. Tasks naming a processor that
+// is not registered fail registration.
+func WithNamedResultProcessor(name string, processor ResultProcessor) Option {
+	return func(r *Runner) {
+		r.resultPipeline().Register(name, processor)
+	}
+}
+
+func (r *Runner) resultPipeline() *ResultPipeline {
+	if r.results == nil {
+		r.results = NewResultPipeline()
+	}
+	return r.results
+}
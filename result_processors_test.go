@@ -0,0 +1,139 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerSetResultAppliesProcessorsAfterRedaction(t *testing.T) {
+	runner := job.NewRunner(job.WithResultProcessors(
+		job.SummarizeResultProcessor(5),
+		job.GitSHAResultProcessor("abc123"),
+	))
+	require.NoError(t, runner.SetResult("job", job.Result{
+		Status:   "success",
+		Message:  "processed 42 rows",
+		Metadata: map[string]any{"api_key": "k"},
+	}))
+
+	stored, ok := runner.GetResult("job")
+	require.True(t, ok)
+	assert.Equal(t, "proce…", stored.Message)
+	assert.Equal(t, 17, stored.Metadata["message_length"])
+	assert.Equal(t, "abc123", stored.Metadata["git_sha"])
+	assert.Equal(t, job.RedactedValue, stored.Metadata["api_key"])
+}
+
+func TestRunnerSetResultSkipsStoreOnProcessorError(t *testing.T) {
+	failing := job.ResultProcessorFunc(func(context.Context, string, job.Result) (job.Result, error) {
+		return job.Result{}, errors.New("boom")
+	})
+	runner := job.NewRunner(job.WithResultProcessors(failing))
+	err := runner.SetResult("job", job.Result{Status: "failed"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	_, ok := runner.GetResult("job")
+	assert.False(t, ok)
+}
+
+func TestResultPipelineAppliesNamedProcessorsPerJob(t *testing.T) {
+	pipeline := job.NewResultPipeline(job.GitSHAResultProcessor("abc123")).
+		Register("team", job.EnrichResultProcessor(map[string]any{"team": "payments"}))
+
+	cfg := job.Config{Metadata: map[string]any{job.ResultProcessorsMetadataKey: []any{"team"}}}
+	result, err := pipeline.Process(context.Background(), "job", cfg, job.Result{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"git_sha": "abc123", "team": "payments"}, result.Metadata)
+
+	result, err = pipeline.Process(context.Background(), "other", job.Config{}, job.Result{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"git_sha": "abc123"}, result.Metadata)
+
+	cfg.Metadata[job.ResultProcessorsMetadataKey] = "missing"
+	_, err = pipeline.Process(context.Background(), "job", cfg, job.Result{})
+	assert.ErrorContains(t, err, `unknown result processor "missing"`)
+}
+
+func TestArtifactResultProcessorUploadsLargeMetadata(t *testing.T) {
+	store := job.NewMemoryArtifactStore()
+	processor := job.ArtifactResultProcessor(store, 32, func(a job.Artifact) string {
+		return "https://artifacts.example.com/" + a.RunID + "/" + a.Name
+	})
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+
+	small := job.Result{Metadata: map[string]any{"rows": 3}}
+	out, err := processor.ProcessResult(ctx, "job", small)
+	require.NoError(t, err)
+	assert.Equal(t, small, out)
+
+	large := job.Result{Status: "success", Metadata: map[string]any{"rows": strings.Repeat("x", 64)}}
+	out, err = processor.ProcessResult(ctx, "job", large)
+	require.NoError(t, err)
+	assert.Equal(t, "https://artifacts.example.com/run-1/result.json", out.OutputURL)
+	assert.Equal(t, map[string]any{"artifact": job.ResultArtifactName, "run_id": "run-1"}, out.Metadata)
+
+	reader, ok := store.Open("run-1", job.ResultArtifactName)
+	require.True(t, ok)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), out.Size)
+
+	var uploaded map[string]any
+	require.NoError(t, json.Unmarshal(content, &uploaded))
+	assert.Equal(t, large.Metadata, uploaded)
+}
+
+func TestRunnerCommanderRecordsResultsThroughProcessors(t *testing.T) {
+	task := job.NewBaseTask("crash", "jobs/crash.js", "js", job.Config{}, "", panickingEngine{calls: new(int)})
+	runner := job.NewRunner(
+		job.WithTaskCreator(&stubTaskCreator{tasks: []job.Task{task}}),
+		job.WithResultProcessors(job.GitSHAResultProcessor("abc123")),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+	defer runner.Stop(context.Background())
+
+	err := runner.Commander(task).Execute(context.Background(), &job.ExecutionMessage{JobID: "crash"})
+	require.True(t, job.IsPanic(err))
+
+	stored, ok := runner.GetResult("crash")
+	require.True(t, ok)
+	assert.Equal(t, job.ResultStatusFailed, stored.Status)
+	assert.Equal(t, "abc123", stored.Metadata["git_sha"])
+}
+
+func TestRunnerRejectsUnknownResultProcessors(t *testing.T) {
+	named := job.NewBaseTask("named", "jobs/named.js", "js", job.Config{
+		Metadata: map[string]any{job.ResultProcessorsMetadataKey: []any{"team"}},
+	}, "", noopEngine{})
+	typo := job.NewBaseTask("typo", "jobs/typo.js", "js", job.Config{
+		Metadata: map[string]any{job.ResultProcessorsMetadataKey: []any{"teem"}},
+	}, "", noopEngine{})
+
+	var failed []job.TaskEvent
+	runner := job.NewRunner(
+		job.WithTaskCreator(&stubTaskCreator{tasks: []job.Task{named, typo}}),
+		job.WithNamedResultProcessor("team", job.EnrichResultProcessor(map[string]any{"team": "payments"})),
+		job.WithTaskEventHandler(func(event job.TaskEvent) {
+			if event.Type == job.TaskEventRegistrationFailed {
+				failed = append(failed, event)
+			}
+		}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+	defer runner.Stop(context.Background())
+
+	tasks := runner.RegisteredTasks()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "named", tasks[0].GetID())
+	require.Len(t, failed, 1)
+	assert.Equal(t, "typo", failed[0].TaskID)
+	assert.ErrorContains(t, failed[0].Err, `unknown result processor "teem"`)
+}
@@ -0,0 +1,177 @@
+package job
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	// ResultLinePrefix marks an output line carrying a JSON Result, e.g.
+	// `::job-result::{"status":"failed","metadata":{"rows":0}}`.
+	ResultLinePrefix = "::job-result::"
+	// ResultFileEnvVar names a file shell scripts can write a JSON Result to.
+	ResultFileEnvVar = "JOB_RESULT_FILE"
+)
+
+// ParseResultLine decodes a single ResultLinePrefix line; ok is false for
+// ordinary output.
+func ParseResultLine(line string) (result Result, ok bool, err error) {
+	payload, found := strings.CutPrefix(strings.TrimSpace(line), ResultLinePrefix)
+	if !found {
+		return Result{}, false, nil
+	}
+	result, err = DecodeResult([]byte(payload))
+	if err != nil {
+		return Result{}, true, fmt.Errorf("invalid %s line: %w", ResultLinePrefix, err)
+	}
+	return result, true, nil
+}
+
+// ParseResultOutput collects the results reported on ResultLinePrefix lines
+// of output, later lines overriding earlier ones field by field.
+func ParseResultOutput(output string) (result Result, ok bool, err error) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), DefaultResultMaxBytes+len(ResultLinePrefix))
+	for scanner.Scan() {
+		reported, found, lineErr := ParseResultLine(scanner.Text())
+		if lineErr != nil {
+			return result, ok, lineErr
+		}
+		if found {
+			result, ok = mergeReportedResult(result, reported), true
+		}
+	}
+	return result, ok, scanner.Err()
+}
+
+// ReadResultFile decodes the Result a script wrote to path; an empty or
+// missing file reports nothing.
+func ReadResultFile(path string) (Result, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(strings.TrimSpace(string(data))) == 0) {
+		return Result{}, false, nil
+	}
+	if err != nil {
+		return Result{}, false, err
+	}
+	result, err := DecodeResult(data)
+	if err != nil {
+		return Result{}, true, fmt.Errorf("invalid %s: %w", ResultFileEnvVar, err)
+	}
+	return result, true, nil
+}
+
+// ReportResult hands a script-reported result to the TaskCommander running
+// ctx. Reports merge, later ones overriding earlier fields; the commander
+// uses them as the run's Result, and a "failed" status fails the attempt.
+func ReportResult(ctx context.Context, result Result) {
+	if scope, ok := runScopeFromContext(ctx); ok && scope.results != nil {
+		scope.results.report(result)
+	}
+}
+
+// reportedResult collects the results reported during one attempt.
+type reportedResult struct {
+	mu     sync.Mutex
+	result *Result
+}
+
+func (r *reportedResult) report(result Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var base Result
+	if r.result != nil {
+		base = *r.result
+	}
+	merged := mergeReportedResult(base, result)
+	r.result = &merged
+}
+
+func (r *reportedResult) get() (Result, bool) {
+	if r == nil {
+		return Result{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result == nil {
+		return Result{}, false
+	}
+	result := *r.result
+	result.Metadata = maps.Clone(result.Metadata)
+	return result, true
+}
+
+// err fails an attempt whose script reported a failed status.
+func (r *reportedResult) err() error {
+	result, ok := r.get()
+	if !ok || result.Status != ResultStatusFailed {
+		return nil
+	}
+	msg := result.Message
+	if msg == "" {
+		msg = "script reported failure"
+	}
+	return errors.New(msg, errors.CategoryExternal).
+		WithTextCode("JOB_RESULT_FAILED").
+		WithMetadata(map[string]any{"result": result})
+}
+
+func mergeReportedResult(base, reported Result) Result {
+	if reported.Status != "" {
+		base.Status = reported.Status
+	}
+	if reported.Message != "" {
+		base.Message = reported.Message
+	}
+	if reported.OutputURL != "" {
+		base.OutputURL = reported.OutputURL
+	}
+	if reported.Size != 0 {
+		base.Size = reported.Size
+	}
+	if len(reported.Metadata) > 0 {
+		base.Metadata = withResultMetadata(base.Metadata, reported.Metadata)
+	}
+	return base
+}
+
+// resultFile creates the ResultFileEnvVar file for a run reporting to a
+// TaskCommander. Returns the path (empty when nothing collects results) and a
+// cleanup func.
+func resultFile(ctx context.Context) (string, func()) {
+	if scope, ok := runScopeFromContext(ctx); !ok || scope.results == nil {
+		return "", func() {}
+	}
+	file, err := os.CreateTemp("", "job-result-*.json")
+	if err != nil {
+		return "", func() {}
+	}
+	path := file.Name()
+	_ = file.Close()
+	return path, func() { _ = os.Remove(path) }
+}
+
+// reportScriptResult forwards the results a script printed to stdout and
+// wrote to resultFile; malformed reports are logged and ignored.
+func reportScriptResult(ctx context.Context, logger Logger, stdout, resultFile string) {
+	if result, ok, err := ParseResultOutput(stdout); err != nil {
+		logger.Warn("ignoring malformed script result", "error", err)
+	} else if ok {
+		ReportResult(ctx, result)
+	}
+	if resultFile == "" {
+		return
+	}
+	if result, ok, err := ReadResultFile(resultFile); err != nil {
+		logger.Warn("ignoring malformed script result", "error", err, "path", resultFile)
+	} else if ok {
+		ReportResult(ctx, result)
+	}
+}
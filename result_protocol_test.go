@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResultOutputMergesLines(t *testing.T) {
+	output := "starting\n" +
+		`::job-result::{"status":"succeeded","metadata":{"rows":3}}` + "\n" +
+		"more output\n" +
+		`  ::job-result::{"message":"done","metadata":{"skipped":1}}` + "\n"
+
+	result, ok, err := ParseResultOutput(output)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "succeeded", result.Status)
+	assert.Equal(t, "done", result.Message)
+	assert.Equal(t, map[string]any{"rows": float64(3), "skipped": float64(1)}, result.Metadata)
+
+	_, ok, err = ParseResultOutput("plain output\n")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = ParseResultOutput("::job-result::{not json")
+	assert.Error(t, err)
+}
+
+func TestShellScriptReportsResult(t *testing.T) {
+	script := `echo '::job-result::{"metadata":{"rows":3}}'
+echo '{"message":"exported","output_url":"s3://bucket/out.csv"}' > "$JOB_RESULT_FILE"`
+	task := NewBaseTask("export", "export.sh", "shell", Config{}, script, NewShellRunner())
+
+	report, err := NewTaskCommander(task).ExecuteWithReport(context.Background(), &ExecutionMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, ResultStatusSucceeded, report.Result.Status)
+	assert.Equal(t, "exported", report.Result.Message)
+	assert.Equal(t, "s3://bucket/out.csv", report.Result.OutputURL)
+	assert.Equal(t, float64(3), report.Result.Metadata["rows"])
+	assert.Equal(t, report.RunID, report.Result.Metadata["run_id"])
+}
+
+func TestScriptReportedFailureFailsRun(t *testing.T) {
+	script := `echo '::job-result::{"status":"failed","message":"no rows to export"}'`
+	task := NewBaseTask("export", "export.sh", "shell", Config{}, script, NewShellRunner())
+
+	report, err := NewTaskCommander(task).ExecuteWithReport(context.Background(), &ExecutionMessage{})
+	require.Error(t, err)
+	var jobErr *errors.Error
+	require.True(t, errors.As(err, &jobErr))
+	assert.Equal(t, "JOB_RESULT_FAILED", jobErr.TextCode)
+	assert.Equal(t, ResultStatusFailed, report.Result.Status)
+	assert.Equal(t, "no rows to export", report.Result.Message)
+}
+
+func TestJSConsoleReportsResult(t *testing.T) {
+	script := `console.log("working");
+console.log("::job-result::" + JSON.stringify({status: "partial", metadata: {rows: 2}}));`
+	task := NewBaseTask("sync", "sync.js", "javascript", Config{}, script, NewJSRunner())
+
+	report, err := NewTaskCommander(task).ExecuteWithReport(context.Background(), &ExecutionMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "partial", report.Result.Status)
+	assert.Equal(t, float64(2), report.Result.Metadata["rows"])
+}
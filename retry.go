@@ -2,8 +2,15 @@ package job
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"math/rand"
+	"net"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/goliatone/go-errors"
 )
 
 type BackoffStrategy string
@@ -14,12 +21,77 @@ const (
 	BackoffExponential BackoffStrategy = "exponential"
 )
 
+// backoffStrategies lists the accepted strategies in the order error
+// messages show.
+var backoffStrategies = []BackoffStrategy{BackoffNone, BackoffFixed, BackoffExponential}
+
+// parseBackoffStrategy normalizes the case of a declared strategy and
+// rejects values outside backoffStrategies. Empty keeps the default.
+func parseBackoffStrategy(raw string) (BackoffStrategy, error) {
+	strategy := BackoffStrategy(strings.ToLower(strings.TrimSpace(raw)))
+	if strategy == "" {
+		return "", nil
+	}
+	for _, known := range backoffStrategies {
+		if strategy == known {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid backoff strategy %q, expected one of %s", raw, joinEnum(backoffStrategies))
+}
+
+// joinEnum formats the allowed values of an enum for error messages.
+func joinEnum[T ~string](values []T) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = string(value)
+	}
+	return strings.Join(parts, "|")
+}
+
+// formatEnumValue quotes string values and prints anything else as is.
+func formatEnumValue(raw any) string {
+	if value, ok := raw.(string); ok {
+		return strconv.Quote(value)
+	}
+	return fmt.Sprint(raw)
+}
+
 // BackoffConfig configures retry timing.
 type BackoffConfig struct {
 	Strategy    BackoffStrategy `json:"strategy" yaml:"strategy"`
 	Interval    time.Duration   `json:"interval" yaml:"interval"`
 	MaxInterval time.Duration   `json:"max_interval" yaml:"max_interval"`
 	Jitter      bool            `json:"jitter" yaml:"jitter"`
+	// OnError overrides the backoff for matching failures, keyed by go-errors
+	// text code ("SQL_DEADLOCK") or category ("rate_limit"); text codes win.
+	// Plain network errors match the "network" key.
+	OnError map[string]BackoffConfig `json:"on_error,omitempty" yaml:"on_error,omitempty"`
+}
+
+// BackoffCategoryNetwork is the OnError key matched by net.Error failures.
+const BackoffCategoryNetwork = "network"
+
+// BackoffResolver picks the backoff for a failed attempt. Returning false
+// defers to the next resolver and finally to the configured backoff.
+type BackoffResolver func(err error, cfg BackoffConfig) (BackoffConfig, bool)
+
+// ErrorBackoffResolver selects cfg.OnError entries by text code, then by
+// category. It is always consulted after custom resolvers.
+func ErrorBackoffResolver(err error, cfg BackoffConfig) (BackoffConfig, bool) {
+	if err == nil || len(cfg.OnError) == 0 {
+		return BackoffConfig{}, false
+	}
+	for _, key := range backoffErrorKeys(err) {
+		if override, ok := cfg.OnError[key]; ok {
+			return mergeBackoffDefaults(cfg, override), true
+		}
+	}
+	return BackoffConfig{}, false
+}
+
+func (b BackoffConfig) isZero() bool {
+	return b.Strategy == "" && b.Interval == 0 && b.MaxInterval == 0 && !b.Jitter && len(b.OnError) == 0
 }
 
 const (
@@ -69,6 +141,41 @@ func computeBackoffDelay(attempt int, cfg BackoffConfig) time.Duration {
 	}
 }
 
+// resolveBackoff runs resolvers in order, then ErrorBackoffResolver. The
+// resolved config never carries OnError, so overrides do not chain.
+func resolveBackoff(err error, cfg BackoffConfig, resolvers []BackoffResolver) BackoffConfig {
+	for _, resolver := range append(append([]BackoffResolver(nil), resolvers...), ErrorBackoffResolver) {
+		if resolver == nil {
+			continue
+		}
+		if resolved, ok := resolver(err, cfg); ok {
+			resolved.OnError = nil
+			return resolved
+		}
+	}
+	cfg.OnError = nil
+	return cfg
+}
+
+// backoffErrorKeys lists the OnError keys err can match, most specific first.
+func backoffErrorKeys(err error) []string {
+	var keys []string
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		if typed.TextCode != "" {
+			keys = append(keys, typed.TextCode)
+		}
+		if typed.Category != "" {
+			keys = append(keys, string(typed.Category))
+		}
+	}
+	var netErr net.Error
+	if stderrors.As(err, &netErr) {
+		keys = append(keys, BackoffCategoryNetwork)
+	}
+	return keys
+}
+
 func applyJitter(delay time.Duration, jitter bool) time.Duration {
 	if !jitter || delay <= 0 {
 		return delay
@@ -85,17 +192,5 @@ func applyJitter(delay time.Duration, jitter bool) time.Duration {
 }
 
 func sleepWithContext(ctx context.Context, delay time.Duration) error {
-	if delay <= 0 {
-		return nil
-	}
-
-	timer := time.NewTimer(delay)
-	defer timer.Stop()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-timer.C:
-		return nil
-	}
+	return sleepWithClock(ctx, SystemClock, delay)
 }
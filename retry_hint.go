@@ -0,0 +1,174 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRetryHintMaxWait caps the wait a RetryHint can impose on the retry
+// loop, so a hostile or misconfigured Retry-After cannot stall a run for days.
+const DefaultRetryHintMaxWait = 5 * time.Minute
+
+// RetryHint tells the retry loop how to treat a failure instead of applying
+// blind backoff.
+type RetryHint struct {
+	// After is the minimum wait before the next attempt, e.g. from Retry-After.
+	After time.Duration
+	// NoRetry stops retrying; the failure is not transient.
+	NoRetry bool
+	Reason  string
+}
+
+type retryHintError struct {
+	err  error
+	hint RetryHint
+}
+
+func (e *retryHintError) Error() string        { return e.err.Error() }
+func (e *retryHintError) Unwrap() error        { return e.err }
+func (e *retryHintError) RetryHint() RetryHint { return e.hint }
+
+// WithRetryHint attaches hint to err; errors.Is/As still see err.
+func WithRetryHint(err error, hint RetryHint) error {
+	if err == nil {
+		return nil
+	}
+	return &retryHintError{err: err, hint: hint}
+}
+
+// RetryHintFrom returns the hint carried by err or any error it wraps.
+func RetryHintFrom(err error) (RetryHint, bool) {
+	var carrier interface{ RetryHint() RetryHint }
+	if stderrors.As(err, &carrier) {
+		return carrier.RetryHint(), true
+	}
+	return RetryHint{}, false
+}
+
+// ParseRetryAfter reads an HTTP Retry-After value in seconds or as an HTTP date.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := when.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// HTTPRetryHint derives a hint from a 429 or 503 response carrying Retry-After.
+func HTTPRetryHint(status int, header http.Header) (RetryHint, bool) {
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return RetryHint{}, false
+	}
+	after, ok := ParseRetryAfter(header.Get("Retry-After"), time.Now())
+	if !ok {
+		return RetryHint{}, false
+	}
+	return RetryHint{After: after, Reason: "retry-after " + strconv.Itoa(status)}, true
+}
+
+// RetryableSQLStates are SQLSTATE codes treated as transient.
+var RetryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"55P03": true, // lock_not_available
+	"57P01": true, // admin_shutdown
+	"08000": true, // connection_exception
+	"08003": true, // connection_does_not_exist
+	"08006": true, // connection_failure
+}
+
+// permanentSQLStateClasses never succeed on retry: data exceptions, integrity
+// violations and syntax or access errors.
+var permanentSQLStateClasses = map[string]bool{"22": true, "23": true, "42": true}
+
+// SQLStateRetryHint inspects drivers exposing SQLState() (pgx, lib/pq):
+// transient codes keep retrying, permanent classes stop it.
+func SQLStateRetryHint(err error) (RetryHint, bool) {
+	var stateErr interface{ SQLState() string }
+	if !stderrors.As(err, &stateErr) {
+		return RetryHint{}, false
+	}
+	state := stateErr.SQLState()
+	switch {
+	case RetryableSQLStates[state]:
+		return RetryHint{Reason: "sqlstate " + state}, true
+	case len(state) == 5 && permanentSQLStateClasses[state[:2]]:
+		return RetryHint{NoRetry: true, Reason: "sqlstate " + state}, true
+	default:
+		return RetryHint{}, false
+	}
+}
+
+// retryHintDelay applies hint on top of the computed backoff delay, capping
+// the hinted wait at maxWait (DefaultRetryHintMaxWait when unset).
+func retryHintDelay(delay time.Duration, hint RetryHint, maxWait time.Duration) time.Duration {
+	if maxWait <= 0 {
+		maxWait = DefaultRetryHintMaxWait
+	}
+	after := min(hint.After, maxWait)
+	if after > delay {
+		return after
+	}
+	return delay
+}
+
+type retryHintRecorderKey struct{}
+
+// retryHintRecorder collects hints observed during a run, such as 429
+// responses a script saw before failing.
+type retryHintRecorder struct {
+	mu   sync.Mutex
+	hint *RetryHint
+}
+
+func contextWithRetryHintRecorder(ctx context.Context) (context.Context, *retryHintRecorder) {
+	recorder := &retryHintRecorder{}
+	return context.WithValue(ctx, retryHintRecorderKey{}, recorder), recorder
+}
+
+// observeRetryHint records hint on the run's recorder, keeping the longest wait.
+func observeRetryHint(ctx context.Context, hint RetryHint) {
+	recorder, _ := ctx.Value(retryHintRecorderKey{}).(*retryHintRecorder)
+	if recorder == nil {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.hint == nil || hint.After > recorder.hint.After {
+		recorder.hint = &hint
+	}
+}
+
+// wrap attaches the recorded hint to err unless err already carries one.
+func (r *retryHintRecorder) wrap(err error) error {
+	if r == nil || err == nil {
+		return err
+	}
+	if _, ok := RetryHintFrom(err); ok {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hint == nil {
+		return err
+	}
+	return WithRetryHint(err, *r.hint)
+}
@@ -0,0 +1,106 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sqlStateError struct{ state string }
+
+func (e sqlStateError) Error() string    { return "pq: " + e.state }
+func (e sqlStateError) SQLState() string { return e.state }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := ParseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = ParseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	_, ok = ParseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestSQLStateRetryHint(t *testing.T) {
+	hint, ok := SQLStateRetryHint(sqlStateError{"40001"})
+	assert.True(t, ok)
+	assert.False(t, hint.NoRetry)
+
+	hint, ok = SQLStateRetryHint(sqlStateError{"42P01"})
+	assert.True(t, ok)
+	assert.True(t, hint.NoRetry)
+
+	_, ok = SQLStateRetryHint(sqlStateError{"XX000"})
+	assert.False(t, ok)
+
+	wrapped := withSQLRetryHint(sqlStateError{"23505"}, errors.New("failed to execute statement 1"))
+	hint, ok = RetryHintFrom(wrapped)
+	assert.True(t, ok)
+	assert.True(t, hint.NoRetry)
+}
+
+func TestFetchRecordsRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, hints := contextWithRetryHintRecorder(context.Background())
+	resp, err := executeFetch(ctx, server.URL, FetchOptions{Method: http.MethodGet, Timeout: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Status)
+
+	hint, ok := RetryHintFrom(hints.wrap(errors.New("script threw")))
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Second, hint.After)
+}
+
+func TestTaskCommanderHonorsRetryHints(t *testing.T) {
+	var delays []time.Duration
+	restore := TestSetBackoffSleep(func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	})
+	defer restore()
+
+	task := &countingFailTask{stubTask: newStubTask("api", Config{
+		Retries: 3,
+		Backoff: BackoffConfig{Strategy: BackoffFixed, Interval: 10 * time.Millisecond},
+	})}
+	hinted := &hintedTask{countingFailTask: task, hints: []RetryHint{{After: 3 * time.Second}, {}, {NoRetry: true}}}
+
+	err := NewTaskCommander(hinted).Execute(context.Background(), &ExecutionMessage{JobID: "api"})
+	require.Error(t, err)
+	assert.Equal(t, 3, task.calls, "NoRetry stops before the retry budget is spent")
+	assert.Equal(t, []time.Duration{3 * time.Second, 10 * time.Millisecond}, delays)
+
+	delays = nil
+	task.calls = 0
+	hinted.hints = []RetryHint{{After: 48 * time.Hour}, {After: time.Hour}, {}}
+	require.Error(t, NewTaskCommander(hinted).WithRetryHintMaxWait(time.Minute).Execute(context.Background(), &ExecutionMessage{JobID: "api"}))
+	assert.Equal(t, []time.Duration{time.Minute, time.Minute, 10 * time.Millisecond}, delays)
+
+	assert.Equal(t, DefaultRetryHintMaxWait, retryHintDelay(time.Second, RetryHint{After: 24 * time.Hour}, 0))
+}
+
+type hintedTask struct {
+	*countingFailTask
+	hints []RetryHint
+}
+
+func (t *hintedTask) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	err := t.countingFailTask.Execute(ctx, msg)
+	return WithRetryHint(err, t.hints[t.calls-1])
+}
@@ -2,10 +2,14 @@ package job_test
 
 import (
 	"context"
+	"errors"
 	"math/rand"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/goliatone/go-command"
+	goerrors "github.com/goliatone/go-errors"
 	"github.com/goliatone/go-job"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -74,3 +78,210 @@ func (f *flakyRetryTask) Execute(_ context.Context, _ *job.ExecutionMessage) err
 	}
 	return nil
 }
+
+type scriptedErrorTask struct {
+	flakyRetryTask
+	errs []error
+}
+
+func (s *scriptedErrorTask) Execute(context.Context, *job.ExecutionMessage) error {
+	s.count++
+	if s.count > len(s.errs) {
+		return nil
+	}
+	return s.errs[s.count-1]
+}
+
+func TestRetryBackoffPerErrorCategory(t *testing.T) {
+	task := &scriptedErrorTask{
+		flakyRetryTask: flakyRetryTask{cfg: job.Config{
+			Retries: 4,
+			Backoff: job.BackoffConfig{
+				Strategy: job.BackoffFixed,
+				Interval: 10 * time.Millisecond,
+				OnError: map[string]job.BackoffConfig{
+					"rate_limit":               {Interval: time.Second},
+					"SQL_DEADLOCK":             {Interval: 5 * time.Millisecond},
+					job.BackoffCategoryNetwork: {Strategy: job.BackoffExponential, Interval: 20 * time.Millisecond},
+				},
+			},
+		}},
+		errs: []error{
+			goerrors.New("slow down", goerrors.CategoryRateLimit),
+			goerrors.New("deadlock", goerrors.CategoryOperation).WithTextCode("SQL_DEADLOCK"),
+			&net.OpError{Op: "dial", Err: assert.AnError},
+			assert.AnError,
+		},
+	}
+
+	var delays []time.Duration
+	restoreSleep := job.TestSetBackoffSleep(func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	})
+	defer restoreSleep()
+
+	require.NoError(t, job.NewTaskCommander(task).Execute(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"}))
+	assert.Equal(t, []time.Duration{time.Second, 5 * time.Millisecond, 80 * time.Millisecond, 10 * time.Millisecond}, delays)
+}
+
+func TestRetryBackoffResolver(t *testing.T) {
+	task := &scriptedErrorTask{
+		flakyRetryTask: flakyRetryTask{cfg: job.Config{Retries: 1, Backoff: job.BackoffConfig{Strategy: job.BackoffFixed}}},
+		errs:           []error{assert.AnError},
+	}
+
+	var delays []time.Duration
+	restoreSleep := job.TestSetBackoffSleep(func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	})
+	defer restoreSleep()
+
+	cmd := job.NewTaskCommander(task).WithBackoffResolver(func(err error, cfg job.BackoffConfig) (job.BackoffConfig, bool) {
+		cfg.Interval = 42 * time.Millisecond
+		return cfg, err == assert.AnError
+	})
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"}))
+	assert.Equal(t, []time.Duration{42 * time.Millisecond}, delays)
+}
+
+type attemptRecordingTask struct {
+	flakyRetryTask
+	handler  job.HandlerOptions
+	failN    int
+	attempts *[]int
+	timeouts *[]time.Duration
+}
+
+func (a *attemptRecordingTask) GetHandlerConfig() job.HandlerOptions { return a.handler }
+
+func (a *attemptRecordingTask) Execute(_ context.Context, msg *job.ExecutionMessage) error {
+	*a.attempts = append(*a.attempts, msg.Attempt)
+	*a.timeouts = append(*a.timeouts, msg.Config.Timeout)
+	if len(*a.attempts) <= a.failN {
+		return assert.AnError
+	}
+	return nil
+}
+
+func TestRetryExposesAttemptAndRefreshesConfig(t *testing.T) {
+	restoreSleep := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restoreSleep()
+
+	var attempts []int
+	var timeouts []time.Duration
+	current := &attemptRecordingTask{
+		flakyRetryTask: flakyRetryTask{cfg: job.Config{Retries: 1, Timeout: time.Second}},
+		failN:          2,
+		attempts:       &attempts,
+		timeouts:       &timeouts,
+	}
+	original := current
+
+	cmd := job.NewTaskCommander(original).WithConfigRefresh(func(id string) (job.Task, bool) {
+		assert.Equal(t, "retry-task", id)
+		return current, true
+	})
+
+	// The operator raises the timeout and retry budget after the first attempt.
+	current = &attemptRecordingTask{
+		flakyRetryTask: flakyRetryTask{cfg: job.Config{Retries: 3, Timeout: time.Minute}},
+		failN:          2,
+		attempts:       &attempts,
+		timeouts:       &timeouts,
+	}
+
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"}))
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.Equal(t, []time.Duration{time.Second, time.Minute, time.Minute}, timeouts)
+}
+
+func TestRetryFallsBackToHandlerOptions(t *testing.T) {
+	restoreSleep := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restoreSleep()
+
+	var attempts []int
+	var timeouts []time.Duration
+	task := &attemptRecordingTask{
+		handler:  job.HandlerOptions{HandlerConfig: command.HandlerConfig{MaxRetries: 2}},
+		failN:    5,
+		attempts: &attempts,
+		timeouts: &timeouts,
+	}
+
+	require.Error(t, job.NewTaskCommander(task).Execute(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"}))
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+}
+
+func TestExecuteWithReportDescribesRun(t *testing.T) {
+	restoreSleep := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restoreSleep()
+
+	task := &flakyRetryTask{cfg: job.Config{
+		Retries: 2,
+		Backoff: job.BackoffConfig{Strategy: job.BackoffFixed, Interval: 25 * time.Millisecond},
+	}}
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	report, err := job.NewTaskCommander(task).ExecuteWithReport(ctx, &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "run-1", report.RunID)
+	assert.Equal(t, "retry-task", report.JobID)
+	assert.Equal(t, 2, report.Attempts)
+	assert.Equal(t, []time.Duration{25 * time.Millisecond}, report.Backoff)
+	assert.Equal(t, 25*time.Millisecond, report.TotalBackoff())
+	assert.Equal(t, job.ResultStatusSucceeded, report.Result.Status)
+	assert.Equal(t, "run-1", report.Result.Metadata["run_id"])
+	assert.Equal(t, 2, report.Result.Metadata["attempts"])
+
+	task = &flakyRetryTask{}
+	report, err = job.NewTaskCommander(task).ExecuteWithReport(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"})
+	require.Error(t, err)
+	assert.Equal(t, job.ResultStatusFailed, report.Result.Status)
+	assert.Equal(t, err.Error(), report.Result.Message)
+	assert.Equal(t, 1, report.Attempts)
+	assert.Empty(t, report.Backoff)
+}
+
+func TestExecuteWithReportRecordsEachAttempt(t *testing.T) {
+	restoreSleep := job.TestSetBackoffSleep(func(context.Context, time.Duration) error { return nil })
+	defer restoreSleep()
+
+	task := &scriptedErrorTask{
+		flakyRetryTask: flakyRetryTask{cfg: job.Config{
+			Retries: 3,
+			Backoff: job.BackoffConfig{Strategy: job.BackoffFixed, Interval: 10 * time.Millisecond},
+		}},
+		errs: []error{errors.New("connection refused"), errors.New("connection refused")},
+	}
+	report, err := job.NewTaskCommander(task).ExecuteWithReport(context.Background(), &job.ExecutionMessage{JobID: "retry-task", ScriptPath: "/tmp/retry"})
+	require.NoError(t, err)
+
+	require.Len(t, report.AttemptLog, 3)
+	for i, attempt := range report.AttemptLog {
+		assert.Equal(t, i+1, attempt.Attempt)
+		assert.False(t, attempt.StartedAt.IsZero())
+	}
+	assert.Equal(t, "connection refused", report.AttemptLog[0].Error)
+	assert.Equal(t, 10*time.Millisecond, report.AttemptLog[0].Backoff)
+	assert.Empty(t, report.AttemptLog[2].Error)
+	assert.Zero(t, report.AttemptLog[2].Backoff)
+
+	exhausted := job.ExecutionReport{
+		Attempts:   2,
+		Backoff:    []time.Duration{time.Second},
+		AttemptLog: []job.AttemptRecord{{Attempt: 1, Error: "timeout"}, {Attempt: 2, Error: "timeout"}},
+		Result:     job.Result{Status: job.ResultStatusFailed},
+	}
+	firstTry := job.ExecutionReport{Attempts: 1, Result: job.Result{Status: job.ResultStatusSucceeded}}
+	stats := job.RetryStatsFor([]job.ExecutionReport{report, exhausted, firstTry, {}})
+	assert.Equal(t, 3, stats.Runs)
+	assert.Equal(t, 2, stats.Retried)
+	assert.Equal(t, 1, stats.Recovered)
+	assert.Equal(t, 1, stats.Exhausted)
+	assert.Equal(t, 3, stats.ExtraAttempts)
+	assert.Equal(t, time.Second+20*time.Millisecond, stats.TotalBackoff)
+	assert.Equal(t, map[string]int{"connection refused": 2, "timeout": 2}, stats.Errors)
+	assert.Equal(t, 0.5, stats.RecoveryRate())
+}
@@ -0,0 +1,228 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const defaultRunArchiveMaxRuns = 500
+
+// ArchivedRun is the execution message recorded for a run, as handed to the
+// task after defaults, tenant overlays and cached script content were applied.
+type ArchivedRun struct {
+	RunID      string           `json:"run_id"`
+	JobID      string           `json:"job_id"`
+	ReplayOf   string           `json:"replay_of,omitempty"`
+	RecordedAt time.Time        `json:"recorded_at"`
+	Message    ExecutionMessage `json:"message"`
+}
+
+// Script returns the script content captured with the run, if the task
+// passed it inline (as tasks built by the bundled engines do).
+func (r ArchivedRun) Script() (string, bool) {
+	script, ok := r.Message.Parameters["script"].(string)
+	return script, ok
+}
+
+// RunArchive keeps the execution messages of the most recent runs so a run
+// can be replayed with the exact inputs that produced its outcome. Replays
+// use the recorded values; Get and Runs return copies passed through the
+// archive's sanitizer.
+type RunArchive struct {
+	mu        sync.RWMutex
+	maxRuns   int
+	clock     Clock
+	sanitizer EnvelopeSanitizer
+	runs      map[string]ArchivedRun
+	order     []string
+}
+
+// NewRunArchive retains up to maxRuns runs; non-positive values fall back to 500.
+func NewRunArchive(maxRuns int) *RunArchive {
+	if maxRuns <= 0 {
+		maxRuns = defaultRunArchiveMaxRuns
+	}
+	return &RunArchive{
+		maxRuns:   maxRuns,
+		clock:     SystemClock,
+		sanitizer: defaultRedactor,
+		runs:      make(map[string]ArchivedRun),
+	}
+}
+
+// WithSanitizer sets the sanitizer applied to the parameters, env and
+// metadata of runs returned by Get and Runs. Defaults to RedactingSanitizer
+// with DefaultRedactKeys; nil disables it.
+func (a *RunArchive) WithSanitizer(sanitizer EnvelopeSanitizer) *RunArchive {
+	a.sanitizer = sanitizer
+	return a
+}
+
+// WithClock sets the clock stamping RecordedAt.
+func (a *RunArchive) WithClock(clock Clock) *RunArchive {
+	if clock != nil {
+		a.clock = clock
+	}
+	return a
+}
+
+// Record stores a copy of msg under runID. Parameters are copied shallowly;
+// the callback and previous result are dropped.
+func (a *RunArchive) Record(ctx context.Context, runID string, msg *ExecutionMessage) {
+	if a == nil || msg == nil || runID == "" {
+		return
+	}
+	stored := *msg
+	stored.Parameters = cloneParams(msg.Parameters)
+	stored.Config.Env = copyStringMap(msg.Config.Env)
+	stored.OutputCallback = nil
+	stored.Result = nil
+
+	run := ArchivedRun{
+		RunID:      runID,
+		JobID:      msg.JobID,
+		ReplayOf:   replayOfFromContext(ctx),
+		RecordedAt: a.clock.Now().UTC(),
+		Message:    stored,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, exists := a.runs[runID]; !exists {
+		a.order = append(a.order, runID)
+	}
+	a.runs[runID] = run
+	for len(a.order) > a.maxRuns {
+		delete(a.runs, a.order[0])
+		a.order = a.order[1:]
+	}
+}
+
+// Get returns the archived run for runID, sanitized.
+func (a *RunArchive) Get(runID string) (ArchivedRun, bool) {
+	run, ok := a.lookup(runID)
+	if ok {
+		run = a.sanitize(run)
+	}
+	return run, ok
+}
+
+// lookup returns the run as recorded, for replays.
+func (a *RunArchive) lookup(runID string) (ArchivedRun, bool) {
+	if a == nil {
+		return ArchivedRun{}, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	run, ok := a.runs[runID]
+	if ok {
+		run.Message.Parameters = cloneParams(run.Message.Parameters)
+	}
+	return run, ok
+}
+
+func (a *RunArchive) sanitize(run ArchivedRun) ArchivedRun {
+	if a.sanitizer == nil {
+		return run
+	}
+	run.Message.Parameters = a.sanitizer(run.Message.Parameters)
+	run.Message.Config.Metadata = a.sanitizer(run.Message.Config.Metadata)
+	if len(run.Message.Config.Env) > 0 {
+		env := make(map[string]any, len(run.Message.Config.Env))
+		for key, value := range run.Message.Config.Env {
+			env[key] = value
+		}
+		redacted := make(map[string]string, len(env))
+		for key, value := range a.sanitizer(env) {
+			redacted[key], _ = value.(string)
+		}
+		run.Message.Config.Env = redacted
+	}
+	return run
+}
+
+// Runs returns the archived runs for jobID (every job when empty), newest
+// first, sanitized.
+func (a *RunArchive) Runs(jobID string) []ArchivedRun {
+	if a == nil {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	out := make([]ArchivedRun, 0, len(a.order))
+	for i := len(a.order) - 1; i >= 0; i-- {
+		run := a.runs[a.order[i]]
+		if jobID == "" || run.JobID == jobID {
+			out = append(out, a.sanitize(run))
+		}
+	}
+	return out
+}
+
+// ErrRunNotArchived is returned when replaying a run missing from the archive.
+var ErrRunNotArchived = errors.New("run not found in archive", errors.CategoryNotFound).
+	WithTextCode("JOB_RUN_NOT_ARCHIVED")
+
+// WithRunArchive records the execution message of every run so it can be replayed.
+func (c *TaskCommander) WithRunArchive(archive *RunArchive) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.archive = archive
+	return c
+}
+
+// Replay re-executes an archived run of this commander's task with the
+// recorded message: parameters, config and, when captured, the script
+// content at that time. The replay gets a new run ID (returned) and is
+// archived with ReplayOf set; a run ID already on ctx is used as is. Its
+// idempotency key is cleared so it is not deduplicated against the original.
+func (c *TaskCommander) Replay(ctx context.Context, runID string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c == nil || c.Task == nil {
+		return "", errors.New("task not configured", errors.CategoryInternal).
+			WithTextCode("JOB_TASK_MISSING")
+	}
+
+	run, ok := c.archive.lookup(runID)
+	if !ok {
+		return "", errors.New("run not found in archive", errors.CategoryNotFound).
+			WithTextCode(ErrRunNotArchived.TextCode).
+			WithMetadata(map[string]any{"run_id": runID})
+	}
+	if run.JobID != c.Task.GetID() {
+		return "", errors.New("archived run belongs to a different job", errors.CategoryBadInput).
+			WithTextCode("JOB_REPLAY_MISMATCH").
+			WithMetadata(map[string]any{"run_id": runID, "job_id": run.JobID, "task_id": c.Task.GetID()})
+	}
+
+	msg := run.Message
+	msg.IdempotencyKey = ""
+	msg.DedupPolicy = ""
+
+	replayID := RunIDFromContext(ctx)
+	if replayID == "" || replayID == runID {
+		replayID = NewRunID()
+	}
+	ctx = ContextWithRunID(contextWithReplayOf(ctx, runID), replayID)
+	return replayID, c.Execute(ctx, &msg)
+}
+
+type replayOfKey struct{}
+
+func contextWithReplayOf(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, replayOfKey{}, runID)
+}
+
+func replayOfFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	runID, _ := ctx.Value(replayOfKey{}).(string)
+	return runID
+}
@@ -0,0 +1,95 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceEngine struct {
+	msgs []*job.ExecutionMessage
+	errs []error
+}
+
+func (e *sequenceEngine) Name() string                              { return "sequence" }
+func (e *sequenceEngine) ParseJob(string, []byte) (job.Task, error) { return nil, nil }
+func (e *sequenceEngine) CanHandle(string) bool                     { return true }
+func (e *sequenceEngine) Execute(_ context.Context, msg *job.ExecutionMessage) error {
+	copied := *msg
+	e.msgs = append(e.msgs, &copied)
+	if len(e.errs) == 0 {
+		return nil
+	}
+	err := e.errs[0]
+	e.errs = e.errs[1:]
+	return err
+}
+
+func TestReplayReExecutesArchivedMessage(t *testing.T) {
+	engine := &sequenceEngine{errs: []error{stderrors.New("bad input")}}
+	task := job.NewBaseTask("import.js", "jobs/import.js", "js", job.Config{Retries: 0}, "import(v1)", engine)
+	archive := job.NewRunArchive(10)
+	cmd := job.NewTaskCommander(task).WithRunArchive(archive).WithIdempotencyTracker(job.NewIdempotencyTracker())
+
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	err := cmd.Execute(ctx, &job.ExecutionMessage{
+		JobID:          "import.js",
+		Parameters:     map[string]any{"batch": 42},
+		IdempotencyKey: "import-42",
+		DedupPolicy:    job.DedupPolicyDrop,
+	})
+	require.EqualError(t, err, "bad input")
+
+	archived, ok := archive.Get("run-1")
+	require.True(t, ok)
+	assert.Equal(t, "import.js", archived.JobID)
+	script, ok := archived.Script()
+	require.True(t, ok)
+	assert.Equal(t, "import(v1)", script)
+
+	replayID, err := cmd.Replay(context.Background(), "run-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, "run-1", replayID)
+
+	require.Len(t, engine.msgs, 2)
+	replayed := engine.msgs[1]
+	assert.Equal(t, 42, replayed.Parameters["batch"])
+	assert.Equal(t, "import(v1)", replayed.Parameters["script"])
+	assert.Empty(t, replayed.IdempotencyKey)
+
+	replay, ok := archive.Get(replayID)
+	require.True(t, ok)
+	assert.Equal(t, "run-1", replay.ReplayOf)
+
+	runs := archive.Runs("import.js")
+	require.Len(t, runs, 2)
+	assert.Equal(t, replayID, runs[0].RunID)
+}
+
+func TestReplayErrors(t *testing.T) {
+	archive := job.NewRunArchive(1)
+	other := job.NewTaskCommander(job.NewBaseTask("other", "jobs/other.js", "js", job.Config{}, "", noopEngine{})).
+		WithRunArchive(archive)
+	require.NoError(t, other.Execute(job.ContextWithRunID(context.Background(), "run-other"), &job.ExecutionMessage{}))
+
+	cmd := job.NewTaskCommander(job.NewBaseTask("mine", "jobs/mine.js", "js", job.Config{}, "", noopEngine{})).
+		WithRunArchive(archive)
+
+	_, err := cmd.Replay(context.Background(), "missing")
+	var target *errors.Error
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_RUN_NOT_ARCHIVED", target.TextCode)
+
+	_, err = cmd.Replay(context.Background(), "run-other")
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_REPLAY_MISMATCH", target.TextCode)
+
+	require.NoError(t, cmd.Execute(job.ContextWithRunID(context.Background(), "run-mine"), &job.ExecutionMessage{}))
+	_, ok := archive.Get("run-other")
+	assert.False(t, ok, "archive should evict beyond maxRuns")
+}
@@ -0,0 +1,126 @@
+package job
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+const defaultRunCacheSize = 100
+
+// RunCache keeps the reports of the most recent runs in a fixed-size ring so
+// deployments without a persistent run store still get last-N visibility.
+type RunCache struct {
+	mu   sync.RWMutex
+	runs []ExecutionReport
+	next int
+	full bool
+}
+
+// NewRunCache retains up to size runs; non-positive values fall back to 100.
+func NewRunCache(size int) *RunCache {
+	if size <= 0 {
+		size = defaultRunCacheSize
+	}
+	return &RunCache{runs: make([]ExecutionReport, size)}
+}
+
+// Record stores a copy of report, evicting the oldest run once the cache is
+// full.
+func (c *RunCache) Record(report ExecutionReport) {
+	if c == nil {
+		return
+	}
+	report = cloneExecutionReport(report)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs[c.next] = report
+	c.next = (c.next + 1) % len(c.runs)
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// Recent returns up to n runs, newest first; n <= 0 returns every cached run.
+func (c *RunCache) Recent(n int) []ExecutionReport {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	size := c.next
+	if c.full {
+		size = len(c.runs)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+	out := make([]ExecutionReport, 0, n)
+	for i := 1; i <= n; i++ {
+		idx := (c.next - i + len(c.runs)) % len(c.runs)
+		out = append(out, cloneExecutionReport(c.runs[idx]))
+	}
+	return out
+}
+
+// Len reports how many runs are cached.
+func (c *RunCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.full {
+		return len(c.runs)
+	}
+	return c.next
+}
+
+func cloneExecutionReport(report ExecutionReport) ExecutionReport {
+	report.Backoff = append([]time.Duration(nil), report.Backoff...)
+	report.AttemptLog = append([]AttemptRecord(nil), report.AttemptLog...)
+	report.Result.Metadata = maps.Clone(report.Result.Metadata)
+	return report
+}
+
+// WithRunCache records the report of every run in cache.
+func (c *TaskCommander) WithRunCache(cache *RunCache) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.runs = cache
+	return c
+}
+
+// WithRunCache records the report of every scheduled run in cache.
+func (m *CronManager) WithRunCache(cache *RunCache) *CronManager {
+	m.runs = cache
+	return m
+}
+
+// WithRunCache sets the cache behind Runner.RecentRuns and shares it with the
+// commanders the runner builds and its attached CronManager. By default the
+// runner keeps the last 100 runs.
+func WithRunCache(cache *RunCache) Option {
+	return func(r *Runner) {
+		if cache != nil {
+			r.runs = cache
+		}
+	}
+}
+
+// RecentRuns returns up to n of the most recent runs, newest first.
+func (r *Runner) RecentRuns(n int) []ExecutionReport {
+	if r == nil {
+		return nil
+	}
+	return r.runs.Recent(n)
+}
+
+// RetryStats aggregates the retry behaviour of up to n recent runs; see
+// RetryStatsFor.
+func (r *Runner) RetryStats(n int) RetryStats {
+	return RetryStatsFor(r.RecentRuns(n))
+}
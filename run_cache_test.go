@@ -0,0 +1,62 @@
+package job_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCacheKeepsMostRecentRuns(t *testing.T) {
+	cache := job.NewRunCache(3)
+	assert.Empty(t, cache.Recent(0))
+
+	for i := 1; i <= 5; i++ {
+		cache.Record(job.ExecutionReport{RunID: fmt.Sprintf("run-%d", i), Backoff: []time.Duration{time.Second}})
+	}
+	assert.Equal(t, 3, cache.Len())
+
+	ids := func(reports []job.ExecutionReport) []string {
+		var out []string
+		for _, r := range reports {
+			out = append(out, r.RunID)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"run-5", "run-4", "run-3"}, ids(cache.Recent(0)))
+	assert.Equal(t, []string{"run-5", "run-4"}, ids(cache.Recent(2)))
+
+	recent := cache.Recent(1)
+	recent[0].Backoff[0] = time.Hour
+	assert.Equal(t, time.Second, cache.Recent(1)[0].Backoff[0])
+}
+
+func TestRunnerRecentRunsFromScheduledRuns(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	scheduler := jobtest.NewScheduler(clock)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("report", "jobs/report.js", "js", job.Config{}, "", &countingEngine{})))
+
+	manager := job.NewCronManager(registry, scheduler).WithClock(clock)
+	runner := job.NewRunner(job.WithRegistry(registry), job.WithCronManager(manager))
+	require.NoError(t, manager.Register(context.Background(), job.ScheduleDefinition{
+		ID: "hourly", Expression: "@hourly", Message: job.ExecutionMessage{JobID: "report"},
+	}))
+
+	for i := 0; i < 2; i++ {
+		_, err := scheduler.RunAll()
+		require.NoError(t, err)
+	}
+
+	runs := runner.RecentRuns(5)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "report", runs[0].JobID)
+	assert.True(t, runs[0].Succeeded())
+	assert.NotEqual(t, runs[0].RunID, runs[1].RunID)
+	assert.Len(t, runner.Health(context.Background()).RecentRuns, 2)
+}
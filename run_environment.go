@@ -0,0 +1,168 @@
+package job
+
+import (
+	"database/sql"
+	"maps"
+	"os"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// modulePath is the import path of this package's module, used to look up
+// its version in the binary's build info.
+const modulePath = "github.com/goliatone/go-job"
+
+// RunEnvironment fingerprints the process that executed a run so
+// discrepancies between hosts (a different driver release, an older build)
+// can be diagnosed from the run record alone.
+type RunEnvironment struct {
+	Host string `json:"host,omitempty"`
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// GoVersion is the Go release the binary was built with.
+	GoVersion string `json:"go_version"`
+	// Version is the go-job module version linked into the binary,
+	// "(devel)" when built from a checkout.
+	Version string `json:"version,omitempty"`
+	Engine  string `json:"engine,omitempty"`
+	// EngineVersions holds the versions the engine reports through
+	// EngineVersioner, e.g. {"goja": "v0.0.0-..."}.
+	EngineVersions map[string]string `json:"engine_versions,omitempty"`
+}
+
+// EngineVersioner engines can implement this to report the versions of the
+// runtime or drivers executing msg.
+type EngineVersioner interface {
+	EngineVersions(msg *ExecutionMessage) map[string]string
+}
+
+var (
+	hostEnvironment     RunEnvironment
+	hostEnvironmentOnce sync.Once
+	buildModules        map[string]string
+)
+
+// loadHostEnvironment collects the parts of the fingerprint that do not
+// change for the life of the process.
+func loadHostEnvironment() {
+	hostEnvironment = RunEnvironment{
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+	}
+	hostEnvironment.Host, _ = os.Hostname()
+
+	buildModules = make(map[string]string)
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	buildModules[info.Main.Path] = info.Main.Version
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		buildModules[dep.Path] = dep.Version
+	}
+	hostEnvironment.Version = buildModules[modulePath]
+}
+
+// moduleVersion returns the version of module linked into the binary, empty
+// when unknown.
+func moduleVersion(module string) string {
+	hostEnvironmentOnce.Do(loadHostEnvironment)
+	return buildModules[module]
+}
+
+// packageModule returns the module providing pkg, matching the longest
+// module path in the build info.
+func packageModule(pkg string) (string, string) {
+	hostEnvironmentOnce.Do(loadHostEnvironment)
+	var module string
+	for path := range buildModules {
+		if (pkg == path || strings.HasPrefix(pkg, path+"/")) && len(path) > len(module) {
+			module = path
+		}
+	}
+	if module == "" {
+		return "", ""
+	}
+	return module, buildModules[module]
+}
+
+// runEnvironmentFor fingerprints the current process for a run of msg on
+// engine.
+func runEnvironmentFor(engine Engine, msg *ExecutionMessage) *RunEnvironment {
+	hostEnvironmentOnce.Do(loadHostEnvironment)
+	env := hostEnvironment
+	if engine == nil {
+		return &env
+	}
+	env.Engine = engine.Name()
+	if versioner, ok := engine.(EngineVersioner); ok {
+		env.EngineVersions = maps.Clone(versioner.EngineVersions(msg))
+	}
+	return &env
+}
+
+// EngineVersions reports the goja module version.
+func (e *JSEngine) EngineVersions(*ExecutionMessage) map[string]string {
+	version := moduleVersion("github.com/dop251/goja")
+	if version == "" {
+		return nil
+	}
+	return map[string]string{"goja": version}
+}
+
+// sqlDriverModules caches the module of each driver name, since resolving
+// it opens a throwaway handle.
+var sqlDriverModules sync.Map
+
+// EngineVersions reports the database driver used for msg and the module
+// version providing it, e.g. {"driver": "sqlite3",
+// "github.com/mattn/go-sqlite3": "v1.14.28"}.
+func (e *SQLEngine) EngineVersions(msg *ExecutionMessage) map[string]string {
+	driverName := e.driverName
+	if msg != nil {
+		if driver, ok := msg.Config.Metadata["driver"].(string); ok {
+			driverName = driver
+		}
+	}
+
+	var pkg string
+	switch {
+	case e.db != nil && driverName == e.driverName:
+		pkg = driverPackage(e.db)
+	case driverName != "":
+		if cached, ok := sqlDriverModules.Load(driverName); ok {
+			pkg = cached.(string)
+			break
+		}
+		// sql.Open only validates the driver name; it does not connect.
+		if db, err := sql.Open(driverName, ""); err == nil {
+			pkg = driverPackage(db)
+			db.Close()
+		}
+		sqlDriverModules.Store(driverName, pkg)
+	}
+
+	versions := make(map[string]string)
+	if driverName != "" {
+		versions["driver"] = driverName
+	}
+	if module, version := packageModule(pkg); module != "" {
+		versions[module] = version
+	}
+	return versions
+}
+
+func driverPackage(db *sql.DB) string {
+	t := reflect.TypeOf(db.Driver())
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath()
+}
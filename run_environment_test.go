@@ -0,0 +1,51 @@
+package job_test
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteWithReportRecordsEnvironment(t *testing.T) {
+	task := job.NewBaseTask("env-shell", "env-shell.sh", "shell", job.Config{}, "", job.NewShellRunner())
+	report, err := job.NewTaskCommander(task).ExecuteWithReport(context.Background(), &job.ExecutionMessage{
+		JobID:      "env-shell",
+		Parameters: map[string]any{"script": "true"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, report.Environment)
+
+	host, _ := os.Hostname()
+	env := report.Environment
+	assert.Equal(t, host, env.Host)
+	assert.Equal(t, runtime.GOOS, env.OS)
+	assert.Equal(t, runtime.GOARCH, env.Arch)
+	assert.Equal(t, runtime.Version(), env.GoVersion)
+	assert.Equal(t, "engine:shell", env.Engine)
+	assert.Empty(t, env.EngineVersions)
+}
+
+func TestEngineVersions(t *testing.T) {
+	goja := job.NewJSRunner().EngineVersions(nil)
+	assert.NotEmpty(t, goja["goja"])
+
+	sqlite := job.NewSQLRunner(job.WithSQLDatabase("sqlite3", ":memory:")).EngineVersions(&job.ExecutionMessage{})
+	assert.Equal(t, "sqlite3", sqlite["driver"])
+	assert.NotEmpty(t, sqlite["github.com/mattn/go-sqlite3"])
+
+	override := job.NewSQLRunner().EngineVersions(&job.ExecutionMessage{
+		Config: job.Config{Metadata: map[string]any{"driver": "sqlite3"}},
+	})
+	assert.Equal(t, sqlite, override)
+
+	unknown := job.NewSQLRunner().EngineVersions(&job.ExecutionMessage{
+		Config: job.Config{Metadata: map[string]any{"driver": "missing-driver"}},
+	})
+	assert.Equal(t, map[string]string{"driver": "missing-driver"}, unknown)
+}
@@ -0,0 +1,210 @@
+package job
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultRunEventHistory    = 200
+	defaultRunEventMaxRuns    = 100
+	defaultRunEventSubscriber = 64
+)
+
+// RunEventType identifies a run lifecycle event.
+type RunEventType string
+
+const (
+	RunEventStarted   RunEventType = "run.started"
+	RunEventAttempt   RunEventType = "run.attempt"
+	RunEventLog       RunEventType = "run.log"
+	RunEventSlow      RunEventType = "run.slow"
+	RunEventSucceeded RunEventType = "run.succeeded"
+	RunEventFailed    RunEventType = "run.failed"
+	// RunEventDisabled precedes run.failed when exit_on_error disables the job.
+	RunEventDisabled RunEventType = "run.disabled"
+)
+
+// Terminal reports whether no further events follow for the run.
+func (t RunEventType) Terminal() bool {
+	return t == RunEventSucceeded || t == RunEventFailed
+}
+
+// RunEvent is a lifecycle or output event published for a run.
+type RunEvent struct {
+	Type  RunEventType `json:"type"`
+	RunID string       `json:"run_id"`
+	JobID string       `json:"job_id"`
+	// ScriptChecksum is set on run.started and terminal events and
+	// identifies the script version that ran.
+	ScriptChecksum string        `json:"script_checksum,omitempty"`
+	Attempt        int           `json:"attempt,omitempty"`
+	Time           time.Time     `json:"time"`
+	Duration       time.Duration `json:"duration,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	Log            *RunLogEntry  `json:"log,omitempty"`
+}
+
+// RunEventBroker fans out run events to subscribers and keeps a short history
+// per run so late subscribers can catch up.
+type RunEventBroker struct {
+	mu         sync.Mutex
+	history    int
+	maxRuns    int
+	runs       map[string]*runEventStream
+	order      []string
+	nextSubID  uint64
+	subscriber int
+}
+
+type runEventStream struct {
+	events []RunEvent
+	done   bool
+	subs   map[uint64]chan RunEvent
+}
+
+// NewRunEventBroker keeps up to history events for each of the last maxRuns runs.
+// Non-positive values fall back to package defaults.
+func NewRunEventBroker(history, maxRuns int) *RunEventBroker {
+	if history <= 0 {
+		history = defaultRunEventHistory
+	}
+	if maxRuns <= 0 {
+		maxRuns = defaultRunEventMaxRuns
+	}
+	return &RunEventBroker{
+		history:    history,
+		maxRuns:    maxRuns,
+		runs:       make(map[string]*runEventStream),
+		subscriber: defaultRunEventSubscriber,
+	}
+}
+
+// Publish records the event and delivers it to the run's subscribers. Slow
+// subscribers drop events rather than block execution.
+func (b *RunEventBroker) Publish(event RunEvent) {
+	if b == nil || event.RunID == "" {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream := b.stream(event.RunID)
+	if stream.done {
+		return
+	}
+	stream.events = append(stream.events, event)
+	if len(stream.events) > b.history {
+		stream.events = stream.events[len(stream.events)-b.history:]
+	}
+	for _, ch := range stream.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	if event.Type.Terminal() {
+		stream.done = true
+		for id, ch := range stream.subs {
+			close(ch)
+			delete(stream.subs, id)
+		}
+	}
+}
+
+// Track creates the run's stream ahead of its first event, so in-process
+// callers that assign the run id can Subscribe before the run starts.
+func (b *RunEventBroker) Track(runID string) {
+	if b == nil || runID == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stream(runID)
+}
+
+// Subscribe returns a channel replaying the run's history followed by live
+// events. The channel closes after a terminal event or when cancel is called.
+// Unknown runs, never published nor tracked, get a closed channel.
+func (b *RunEventBroker) Subscribe(runID string) (<-chan RunEvent, func()) {
+	ch, cancel, _ := b.subscribe(runID)
+	return ch, cancel
+}
+
+func (b *RunEventBroker) subscribe(runID string) (<-chan RunEvent, func(), bool) {
+	if b == nil {
+		ch := make(chan RunEvent)
+		close(ch)
+		return ch, func() {}, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream, ok := b.runs[runID]
+	if !ok {
+		ch := make(chan RunEvent)
+		close(ch)
+		return ch, func() {}, false
+	}
+	ch := make(chan RunEvent, len(stream.events)+b.subscriber)
+	for _, event := range stream.events {
+		ch <- event
+	}
+	if stream.done {
+		close(ch)
+		return ch, func() {}, true
+	}
+
+	b.nextSubID++
+	id := b.nextSubID
+	stream.subs[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if sub, ok := stream.subs[id]; ok {
+				close(sub)
+				delete(stream.subs, id)
+			}
+		})
+	}, true
+}
+
+// stream returns the run's stream, creating it and evicting the oldest
+// finished runs as needed. Runs still in progress are never evicted, so the
+// broker may briefly hold more than maxRuns streams. Callers hold b.mu.
+func (b *RunEventBroker) stream(runID string) *runEventStream {
+	if stream, ok := b.runs[runID]; ok {
+		return stream
+	}
+	stream := &runEventStream{subs: make(map[uint64]chan RunEvent)}
+	b.runs[runID] = stream
+	b.order = append(b.order, runID)
+
+	excess := len(b.order) - b.maxRuns
+	if excess <= 0 {
+		return stream
+	}
+	kept := b.order[:0]
+	for _, id := range b.order {
+		if old := b.runs[id]; excess > 0 && old.done {
+			for subID, ch := range old.subs {
+				close(ch)
+				delete(old.subs, subID)
+			}
+			delete(b.runs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	b.order = kept
+	return stream
+}
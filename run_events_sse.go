@@ -0,0 +1,115 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultSSEKeepAlive = 15 * time.Second
+
+// RunEventStreamOption customises a RunEventStreamHandler.
+type RunEventStreamOption func(*RunEventStreamHandler)
+
+// WithRunEventStreamResolver overrides how the run ID is read from the request.
+func WithRunEventStreamResolver(fn func(*http.Request) string) RunEventStreamOption {
+	return func(h *RunEventStreamHandler) {
+		if fn != nil {
+			h.resolveRun = fn
+		}
+	}
+}
+
+// WithRunEventStreamKeepAlive sets the interval between keep-alive comments.
+func WithRunEventStreamKeepAlive(interval time.Duration) RunEventStreamOption {
+	return func(h *RunEventStreamHandler) {
+		if interval > 0 {
+			h.keepAlive = interval
+		}
+	}
+}
+
+// RunEventStreamHandler streams a run's lifecycle events and output lines as
+// server-sent events. The stream ends after the run's terminal event.
+type RunEventStreamHandler struct {
+	broker     *RunEventBroker
+	resolveRun func(*http.Request) string
+	keepAlive  time.Duration
+}
+
+// NewRunEventStreamHandler builds an SSE handler. By default the run ID is read
+// from the `run` path value (e.g. "GET /runs/{run}/events") or query parameter.
+func NewRunEventStreamHandler(broker *RunEventBroker, opts ...RunEventStreamOption) *RunEventStreamHandler {
+	h := &RunEventStreamHandler{
+		broker:     broker,
+		resolveRun: defaultRunEventStreamResolver,
+		keepAlive:  defaultSSEKeepAlive,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	return h
+}
+
+func defaultRunEventStreamResolver(r *http.Request) string {
+	if id := r.PathValue("run"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("run")
+}
+
+// ServeHTTP implements http.Handler.
+func (h *RunEventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	runID := h.resolveRun(r)
+	if runID == "" {
+		http.Error(w, "run id required", http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel, ok := h.broker.subscribe(runID)
+	defer cancel()
+	if !ok {
+		http.Error(w, "run not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			flusher.Flush()
+			if event.Type.Terminal() {
+				return
+			}
+		}
+	}
+}
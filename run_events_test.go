@@ -0,0 +1,109 @@
+package job_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEventBrokerReplaysAndCloses(t *testing.T) {
+	broker := job.NewRunEventBroker(0, 0)
+	task := job.NewBaseTask("sh-task", "out.sh", "shell", job.Config{}, "", job.NewShellRunner())
+	cmd := job.NewTaskCommander(task).WithRunEvents(broker)
+
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	broker.Track("run-1")
+	live, cancel := broker.Subscribe("run-1")
+	defer cancel()
+
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": "echo hello"},
+	}))
+
+	var types []job.RunEventType
+	var lines []string
+	for event := range live {
+		types = append(types, event.Type)
+		if event.Type == job.RunEventLog && event.Log.Message == "shell output" {
+			lines = append(lines, event.Log.Fields["line"].(string))
+		}
+	}
+	assert.Equal(t, job.RunEventStarted, types[0])
+	assert.Equal(t, job.RunEventAttempt, types[1])
+	assert.Equal(t, job.RunEventSucceeded, types[len(types)-1])
+	assert.Equal(t, []string{"hello"}, lines)
+
+	// late subscribers receive the history of a finished run
+	replay, _ := broker.Subscribe("run-1")
+	count := 0
+	for range replay {
+		count++
+	}
+	assert.Equal(t, len(types), count)
+}
+
+func TestRunEventBrokerEvictsOnlyFinishedRuns(t *testing.T) {
+	broker := job.NewRunEventBroker(10, 1)
+
+	unknown, cancelUnknown := broker.Subscribe("nope")
+	_, open := <-unknown
+	assert.False(t, open)
+	cancelUnknown()
+
+	broker.Track("a")
+	live, cancel := broker.Subscribe("a")
+	broker.Publish(job.RunEvent{Type: job.RunEventStarted, RunID: "b"})
+	broker.Publish(job.RunEvent{Type: job.RunEventSucceeded, RunID: "b"})
+	cancel()
+	_, open = <-live
+	assert.False(t, open)
+
+	// "a" is still running, so the finished "b" is evicted instead.
+	broker.Publish(job.RunEvent{Type: job.RunEventStarted, RunID: "c"})
+	replay, _ := broker.Subscribe("b")
+	_, open = <-replay
+	assert.False(t, open)
+
+	broker.Publish(job.RunEvent{Type: job.RunEventStarted, RunID: "a"})
+	replay, cancel = broker.Subscribe("a")
+	defer cancel()
+	event := <-replay
+	assert.Equal(t, job.RunEventStarted, event.Type)
+}
+
+func TestRunEventStreamHandlerWritesSSE(t *testing.T) {
+	broker := job.NewRunEventBroker(0, 0)
+	broker.Publish(job.RunEvent{Type: job.RunEventStarted, RunID: "run-2", JobID: "report"})
+	broker.Publish(job.RunEvent{Type: job.RunEventFailed, RunID: "run-2", JobID: "report", Error: "boom"})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /runs/{run}/events", job.NewRunEventStreamHandler(broker))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/runs/run-2/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+			events = append(events, name)
+		}
+	}
+	assert.Equal(t, []string{"run.started", "run.failed"}, events)
+
+	resp, err = http.Get(server.URL + "/runs/unknown/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
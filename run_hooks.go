@@ -0,0 +1,115 @@
+package job
+
+import (
+	"context"
+
+	"github.com/goliatone/go-errors"
+)
+
+// BeforeRunHook prepares a run before its first attempt, e.g. by opening a
+// VPN tunnel, warming a cache or minting temporary credentials. The returned
+// context, when non-nil, replaces ctx for the attempts and the remaining
+// hooks. An error fails the run without executing the task.
+type BeforeRunHook func(ctx context.Context, msg *ExecutionMessage) (context.Context, error)
+
+// AfterRunHook tears down what a BeforeRunHook set up. It runs once the run
+// finishes, after the last attempt, and receives the run error. An error
+// fails a run that otherwise succeeded.
+type AfterRunHook func(ctx context.Context, msg *ExecutionMessage, runErr error) error
+
+// WithBeforeRun appends hooks run, in order, before the first attempt of every
+// execution. The context they receive carries the run ID.
+func (c *TaskCommander) WithBeforeRun(hooks ...BeforeRunHook) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	for _, hook := range hooks {
+		if hook != nil {
+			c.beforeRun = append(c.beforeRun, hook)
+		}
+	}
+	return c
+}
+
+// WithAfterRun appends hooks run, in order, once every execution finishes.
+// They run whenever the before-run hooks were reached, including when one of
+// them failed, so teardown must tolerate partial setup.
+func (c *TaskCommander) WithAfterRun(hooks ...AfterRunHook) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	for _, hook := range hooks {
+		if hook != nil {
+			c.afterRun = append(c.afterRun, hook)
+		}
+	}
+	return c
+}
+
+// runBeforeHooks runs the before-run hooks, stopping at the first failure.
+func (c *TaskCommander) runBeforeHooks(ctx context.Context, msg *ExecutionMessage) (context.Context, error) {
+	for _, hook := range c.beforeRun {
+		next := ctx
+		err := recoverExecution(msg.JobID, func() error {
+			hooked, err := hook(ctx, msg)
+			if hooked != nil {
+				next = hooked
+			}
+			return err
+		})
+		if err != nil {
+			return ctx, runHookError("before", msg.JobID, err)
+		}
+		ctx = next
+	}
+	return ctx, nil
+}
+
+// runAfterHooks runs every after-run hook and returns the run error, or the
+// first hook failure when the run succeeded.
+func (c *TaskCommander) runAfterHooks(ctx context.Context, msg *ExecutionMessage, runErr error) error {
+	ctx = context.WithoutCancel(ctx)
+	for _, hook := range c.afterRun {
+		err := recoverExecution(msg.JobID, func() error {
+			return hook(ctx, msg, runErr)
+		})
+		if err != nil && runErr == nil {
+			runErr = runHookError("after", msg.JobID, err)
+		}
+	}
+	return runErr
+}
+
+func runHookError(stage, jobID string, err error) error {
+	return errors.Wrap(err, errors.CategoryExternal, stage+"-run hook failed").
+		WithTextCode("JOB_RUN_HOOK_FAILED").
+		WithMetadata(map[string]any{"job_id": jobID, "stage": stage})
+}
+
+// WithBeforeRun registers hooks run before every execution of the commanders
+// the runner builds and of its attached CronManager.
+func WithBeforeRun(hooks ...BeforeRunHook) Option {
+	return func(r *Runner) {
+		r.beforeRun = append(r.beforeRun, hooks...)
+	}
+}
+
+// WithAfterRun registers hooks run after every execution of the commanders
+// the runner builds and of its attached CronManager.
+func WithAfterRun(hooks ...AfterRunHook) Option {
+	return func(r *Runner) {
+		r.afterRun = append(r.afterRun, hooks...)
+	}
+}
+
+// WithBeforeRun appends hooks run before every scheduled execution.
+func (m *CronManager) WithBeforeRun(hooks ...BeforeRunHook) *CronManager {
+	m.beforeRun = append(m.beforeRun, hooks...)
+	return m
+}
+
+// WithAfterRun appends hooks run after every scheduled execution.
+func (m *CronManager) WithAfterRun(hooks ...AfterRunHook) *CronManager {
+	m.afterRun = append(m.afterRun, hooks...)
+	return m
+}
@@ -0,0 +1,106 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/goliatone/go-errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tunnelKey struct{}
+
+type hookedTask struct {
+	*stubTask
+	calls int
+	seen  any
+	err   error
+}
+
+func (t *hookedTask) Execute(ctx context.Context, _ *ExecutionMessage) error {
+	t.calls++
+	t.seen = ctx.Value(tunnelKey{})
+	return t.err
+}
+
+func TestTaskCommanderRunsHooksAroundExecution(t *testing.T) {
+	task := &hookedTask{stubTask: newStubTask("sync", Config{})}
+	var order []string
+	var hookRunID string
+	var afterErr error = stderrors.New("unset")
+
+	cmd := NewTaskCommander(task).
+		WithBeforeRun(func(ctx context.Context, msg *ExecutionMessage) (context.Context, error) {
+			order = append(order, "before:"+msg.JobID)
+			hookRunID = RunIDFromContext(ctx)
+			return context.WithValue(ctx, tunnelKey{}, "tunnel-1"), nil
+		}).
+		WithAfterRun(func(ctx context.Context, _ *ExecutionMessage, runErr error) error {
+			order = append(order, "after")
+			afterErr = runErr
+			assert.Equal(t, "tunnel-1", ctx.Value(tunnelKey{}))
+			return nil
+		})
+
+	report, err := cmd.ExecuteWithReport(context.Background(), &ExecutionMessage{JobID: "sync", ScriptPath: "/tmp/sync"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"before:sync", "after"}, order)
+	assert.Equal(t, "tunnel-1", task.seen)
+	assert.NoError(t, afterErr)
+	assert.Equal(t, report.RunID, hookRunID)
+}
+
+func TestTaskCommanderBeforeRunFailureFailsRun(t *testing.T) {
+	task := &hookedTask{stubTask: newStubTask("sync", Config{})}
+	var afterErr error
+
+	cmd := NewTaskCommander(task).
+		WithBeforeRun(func(ctx context.Context, _ *ExecutionMessage) (context.Context, error) {
+			return nil, stderrors.New("vpn unavailable")
+		}).
+		WithAfterRun(func(_ context.Context, _ *ExecutionMessage, runErr error) error {
+			afterErr = runErr
+			return nil
+		})
+
+	report, err := cmd.ExecuteWithReport(context.Background(), &ExecutionMessage{JobID: "sync", ScriptPath: "/tmp/sync"})
+	require.Error(t, err)
+	var target *errors.Error
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_RUN_HOOK_FAILED", target.TextCode)
+	assert.Contains(t, err.Error(), "vpn unavailable")
+	assert.Zero(t, task.calls)
+	assert.Equal(t, err, afterErr)
+	assert.Equal(t, ResultStatusFailed, report.Result.Status)
+}
+
+func TestTaskCommanderAfterRunFailureFailsSuccessfulRun(t *testing.T) {
+	task := &hookedTask{stubTask: newStubTask("sync", Config{})}
+	cleanup := func(context.Context, *ExecutionMessage, error) error {
+		return stderrors.New("revoke credentials")
+	}
+
+	err := NewTaskCommander(task).WithAfterRun(cleanup).
+		Execute(context.Background(), &ExecutionMessage{JobID: "sync", ScriptPath: "/tmp/sync"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoke credentials")
+
+	task.err = stderrors.New("boom")
+	err = NewTaskCommander(task).WithAfterRun(cleanup).
+		Execute(context.Background(), &ExecutionMessage{JobID: "sync", ScriptPath: "/tmp/sync"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom", "the run error wins over teardown failures")
+}
+
+func TestRunnerHooksApplyToBuiltCommanders(t *testing.T) {
+	task := &hookedTask{stubTask: newStubTask("sync", Config{})}
+	before := func(ctx context.Context, _ *ExecutionMessage) (context.Context, error) {
+		return context.WithValue(ctx, tunnelKey{}, "runner"), nil
+	}
+	runner := NewRunner(WithBeforeRun(before))
+
+	require.NoError(t, runner.commander(task).Execute(context.Background(), &ExecutionMessage{JobID: "sync", ScriptPath: "/tmp/sync"}))
+	assert.Equal(t, "runner", task.seen)
+}
@@ -0,0 +1,492 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	defaultRunLogCapacity = 256
+	defaultRunLogMaxRuns  = 100
+)
+
+// RunLogEntry is a single log line captured for a run.
+type RunLogEntry struct {
+	Time    time.Time      `json:"time"`
+	Level   LogLevel       `json:"level"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// RunLogBuffer is a fixed size ring buffer of log entries; once full the
+// oldest entries are overwritten.
+type RunLogBuffer struct {
+	mu      sync.Mutex
+	entries []RunLogEntry
+	next    int
+	full    bool
+	dropped int
+}
+
+// NewRunLogBuffer creates a ring buffer that keeps the last capacity entries.
+func NewRunLogBuffer(capacity int) *RunLogBuffer {
+	if capacity <= 0 {
+		capacity = defaultRunLogCapacity
+	}
+	return &RunLogBuffer{entries: make([]RunLogEntry, capacity)}
+}
+
+// Append stores an entry, evicting the oldest one when the buffer is full.
+func (b *RunLogBuffer) Append(entry RunLogEntry) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.full {
+		b.dropped++
+	}
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Entries returns the buffered entries, oldest first.
+func (b *RunLogBuffer) Entries() []RunLogEntry {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		return append([]RunLogEntry(nil), b.entries[:b.next]...)
+	}
+	out := make([]RunLogEntry, 0, len(b.entries))
+	out = append(out, b.entries[b.next:]...)
+	return append(out, b.entries[:b.next]...)
+}
+
+// Dropped reports how many entries were overwritten.
+func (b *RunLogBuffer) Dropped() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// RunLogRetentionMetadataKey overrides, in task metadata, how long the job's
+// run logs are kept, e.g. `log_retention: 168h`.
+const RunLogRetentionMetadataKey = "log_retention"
+
+// RunLogs is the captured log of one run.
+type RunLogs struct {
+	RunID     string        `json:"run_id"`
+	JobID     string        `json:"job_id,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+	Entries   []RunLogEntry `json:"entries"`
+	// Dropped counts the oldest entries overwritten once the run's buffer
+	// filled up.
+	Dropped int `json:"dropped,omitempty"`
+}
+
+// RunLogStore keeps per-run log buffers for the most recent runs so logs can be
+// retrieved after an execution finishes. Retention is bounded by the number
+// of runs, overall and per job, and optionally by age.
+type RunLogStore struct {
+	mu       sync.Mutex
+	capacity int
+	maxRuns  int
+	perJob   int
+	maxAge   time.Duration
+	clock    Clock
+	runs     map[string]*runLogRecord
+	order    []string
+}
+
+type runLogRecord struct {
+	buffer  *RunLogBuffer
+	jobID   string
+	started time.Time
+	maxAge  time.Duration
+}
+
+// NewRunLogStore retains up to maxRuns runs with capacity entries each.
+// Non-positive values fall back to package defaults.
+func NewRunLogStore(capacity, maxRuns int) *RunLogStore {
+	if capacity <= 0 {
+		capacity = defaultRunLogCapacity
+	}
+	if maxRuns <= 0 {
+		maxRuns = defaultRunLogMaxRuns
+	}
+	return &RunLogStore{
+		capacity: capacity,
+		maxRuns:  maxRuns,
+		runs:     make(map[string]*runLogRecord),
+	}
+}
+
+// WithMaxAge drops run logs older than maxAge, measured from the start of the
+// run. Jobs override it with the `log_retention` metadata key. Zero keeps logs
+// until they are evicted by count.
+func (s *RunLogStore) WithMaxAge(maxAge time.Duration) *RunLogStore {
+	s.maxAge = maxAge
+	return s
+}
+
+// WithMaxRunsPerJob keeps at most n runs per job, so a frequent job cannot
+// evict the logs of every other job.
+func (s *RunLogStore) WithMaxRunsPerJob(n int) *RunLogStore {
+	s.perJob = n
+	return s
+}
+
+// WithClock sets the time source for run start times and age based expiry.
+func (s *RunLogStore) WithClock(clock Clock) *RunLogStore {
+	s.clock = clock
+	return s
+}
+
+// Logs returns the captured entries for runID.
+func (s *RunLogStore) Logs(runID string) ([]RunLogEntry, bool) {
+	logs, err := s.GetRunLogs(runID)
+	if err != nil {
+		return nil, false
+	}
+	return logs.Entries, true
+}
+
+// GetRunLogs returns the captured log of runID, or a JOB_RUN_LOGS_NOT_FOUND
+// error when the run was never captured or its logs have expired.
+func (s *RunLogStore) GetRunLogs(runID string) (RunLogs, error) {
+	var record *runLogRecord
+	if s != nil {
+		s.mu.Lock()
+		s.pruneLocked()
+		record = s.runs[runID]
+		s.mu.Unlock()
+	}
+	if record == nil {
+		return RunLogs{}, errors.New(fmt.Sprintf("logs for run %q not found", runID), errors.CategoryNotFound).
+			WithTextCode("JOB_RUN_LOGS_NOT_FOUND").
+			WithMetadata(map[string]any{"run_id": runID})
+	}
+	return RunLogs{
+		RunID:     runID,
+		JobID:     record.jobID,
+		StartedAt: record.started,
+		Entries:   record.buffer.Entries(),
+		Dropped:   record.buffer.Dropped(),
+	}, nil
+}
+
+// RunIDs lists retained runs, oldest first.
+func (s *RunLogStore) RunIDs() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	return append([]string(nil), s.order...)
+}
+
+// Prune drops expired run logs and returns how many were removed. Expired
+// logs are also dropped lazily on access; Prune frees their memory sooner.
+func (s *RunLogStore) Prune() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pruneLocked()
+}
+
+// buffer opens the log buffer of runID for jobID; maxAge overrides the
+// store's age limit when positive.
+func (s *RunLogStore) buffer(runID, jobID string, maxAge time.Duration) *RunLogBuffer {
+	if s == nil || runID == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.runs[runID]; ok {
+		return record.buffer
+	}
+	s.pruneLocked()
+	record := &runLogRecord{
+		buffer:  NewRunLogBuffer(s.capacity),
+		jobID:   jobID,
+		started: clockOrSystem(s.clock).Now(),
+		maxAge:  maxAge,
+	}
+	s.runs[runID] = record
+	s.order = append(s.order, runID)
+
+	if s.perJob > 0 && jobID != "" {
+		var jobRuns []string
+		for _, id := range s.order {
+			if s.runs[id].jobID == jobID {
+				jobRuns = append(jobRuns, id)
+			}
+		}
+		for _, id := range jobRuns[:max(0, len(jobRuns)-s.perJob)] {
+			s.removeLocked(id)
+		}
+	}
+	for len(s.order) > s.maxRuns {
+		s.removeLocked(s.order[0])
+	}
+	return record.buffer
+}
+
+func (s *RunLogStore) pruneLocked() int {
+	now := clockOrSystem(s.clock).Now()
+	var expired []string
+	for _, id := range s.order {
+		record := s.runs[id]
+		maxAge := s.maxAge
+		if record.maxAge > 0 {
+			maxAge = record.maxAge
+		}
+		if maxAge > 0 && now.Sub(record.started) > maxAge {
+			expired = append(expired, id)
+		}
+	}
+	for _, id := range expired {
+		s.removeLocked(id)
+	}
+	return len(expired)
+}
+
+func (s *RunLogStore) removeLocked(runID string) {
+	delete(s.runs, runID)
+	for i, id := range s.order {
+		if id == runID {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// runLogRetention reads the `log_retention` override from cfg.
+func runLogRetention(cfg Config) time.Duration {
+	value, ok := cfg.Metadata[RunLogRetentionMetadataKey]
+	if !ok {
+		return 0
+	}
+	retention, err := coerceParam(ParamTypeDuration, value)
+	if err != nil {
+		return 0
+	}
+	return retention.(time.Duration)
+}
+
+// WithRunLogStore captures the logs of the runs the runner executes: those of
+// the commanders it builds and, unless it has its own store, of its attached
+// CronManager. Runner.GetRunLogs reads them back.
+func WithRunLogStore(store *RunLogStore) Option {
+	return func(r *Runner) {
+		r.runLogs = store
+	}
+}
+
+// GetRunLogs returns the captured log of runID; see RunLogStore.GetRunLogs.
+func (r *Runner) GetRunLogs(runID string) (RunLogs, error) {
+	var store *RunLogStore
+	if r != nil {
+		store = r.runLogs
+	}
+	return store.GetRunLogs(runID)
+}
+
+// runScope identifies the run an engine is executing on behalf of.
+type runScope struct {
+	RunID   string
+	JobID   string
+	Attempt int
+	capture func(RunLogEntry)
+	results *reportedResult
+}
+
+type runScopeContextKey struct{}
+
+func contextWithRunScope(ctx context.Context, scope runScope) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, runScopeContextKey{}, scope)
+}
+
+func runScopeFromContext(ctx context.Context) (runScope, bool) {
+	if ctx == nil {
+		return runScope{}, false
+	}
+	scope, ok := ctx.Value(runScopeContextKey{}).(runScope)
+	return scope, ok
+}
+
+// ContextWithRunID pins the run ID TaskCommander assigns to the next execution,
+// letting callers look up captured logs afterwards.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	if runID == "" {
+		return ctx
+	}
+	return contextWithRunScope(ctx, runScope{RunID: runID})
+}
+
+// RunIDFromContext returns the run ID assigned by TaskCommander, if any.
+func RunIDFromContext(ctx context.Context) string {
+	scope, _ := runScopeFromContext(ctx)
+	return scope.RunID
+}
+
+// NewRunID returns a random identifier suitable for ContextWithRunID.
+func NewRunID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// withRunScope decorates logger with the run fields carried by ctx and tees
+// entries into the run's capture sinks when any are attached.
+func withRunScope(ctx context.Context, logger Logger) Logger {
+	scope, ok := runScopeFromContext(ctx)
+	if !ok {
+		return logger
+	}
+	fields := map[string]any{
+		"run_id":  scope.RunID,
+		"job_id":  scope.JobID,
+		"attempt": scope.Attempt,
+	}
+	if fl, ok := logger.(FieldsLogger); ok {
+		logger = fl.WithFields(fields)
+	}
+	if scope.capture == nil {
+		return logger
+	}
+	return &runLogger{next: logger, capture: scope.capture, fields: fields}
+}
+
+// runLogger forwards to the wrapped logger while capturing entries.
+type runLogger struct {
+	next    Logger
+	capture func(RunLogEntry)
+	fields  map[string]any
+}
+
+func (l *runLogger) Trace(msg string, args ...any) {
+	l.record(LevelTrace, msg, args)
+	l.next.Trace(msg, args...)
+}
+
+func (l *runLogger) Debug(msg string, args ...any) {
+	l.record(LevelDebug, msg, args)
+	l.next.Debug(msg, args...)
+}
+
+func (l *runLogger) Info(msg string, args ...any) {
+	l.record(LevelInfo, msg, args)
+	l.next.Info(msg, args...)
+}
+
+func (l *runLogger) Warn(msg string, args ...any) {
+	l.record(LevelWarn, msg, args)
+	l.next.Warn(msg, args...)
+}
+
+func (l *runLogger) Error(msg string, args ...any) {
+	l.record(LevelError, msg, args)
+	l.next.Error(msg, args...)
+}
+
+func (l *runLogger) Fatal(msg string, args ...any) {
+	l.record(LevelFatal, msg, args)
+	l.next.Fatal(msg, args...)
+}
+
+func (l *runLogger) WithContext(ctx context.Context) Logger {
+	return &runLogger{next: l.next.WithContext(ctx), capture: l.capture, fields: l.fields}
+}
+
+func (l *runLogger) WithFields(fields map[string]any) Logger {
+	next := l.next
+	if fl, ok := next.(FieldsLogger); ok {
+		next = fl.WithFields(fields)
+	}
+	merged := cloneFields(l.fields)
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &runLogger{next: next, capture: l.capture, fields: merged}
+}
+
+func (l *runLogger) record(level LogLevel, msg string, args []any) {
+	fields := cloneFields(l.fields)
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.capture(RunLogEntry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Fields:  fields,
+	})
+}
+
+// lineLogger is an io.Writer that emits one log entry per output line.
+type lineLogger struct {
+	mu      sync.Mutex
+	log     func(msg string, args ...any)
+	message string
+	stream  string
+	pending []byte
+}
+
+func newLineLogger(log func(msg string, args ...any), message, stream string) *lineLogger {
+	return &lineLogger{log: log, message: message, stream: stream}
+}
+
+func (w *lineLogger) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		w.emit(string(w.pending[:idx]))
+		w.pending = w.pending[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing partial line.
+func (w *lineLogger) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		w.emit(string(w.pending))
+		w.pending = nil
+	}
+}
+
+func (w *lineLogger) emit(line string) {
+	w.log(w.message, "stream", w.stream, "line", line)
+}
@@ -0,0 +1,137 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLogStoreCapturesScriptOutputPerRun(t *testing.T) {
+	store := job.NewRunLogStore(32, 4)
+
+	shell := job.NewBaseTask("sh-task", "out.sh", "shell", job.Config{}, "", job.NewShellRunner())
+	cmd := job.NewTaskCommander(shell).WithRunLogStore(store)
+	ctx := job.ContextWithRunID(context.Background(), "run-sh")
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": "echo first; echo second 1>&2; printf third"},
+	}))
+
+	entries, ok := store.Logs("run-sh")
+	require.True(t, ok)
+	lines := scriptLines(entries, "shell output")
+	assert.ElementsMatch(t, []string{"first", "second", "third"}, lines)
+	for _, entry := range entries {
+		assert.Equal(t, "run-sh", entry.Fields["run_id"])
+		assert.Equal(t, "sh-task", entry.Fields["job_id"])
+		assert.Equal(t, 1, entry.Fields["attempt"])
+	}
+
+	js := job.NewBaseTask("js-task", "out.js", "js", job.Config{}, "", job.NewJSRunner())
+	cmd = job.NewTaskCommander(js).WithRunLogStore(store)
+	ctx = job.ContextWithRunID(context.Background(), "run-js")
+	require.NoError(t, cmd.Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": `console.log("hello", 42); console.error("oops")`},
+	}))
+
+	entries, ok = store.Logs("run-js")
+	require.True(t, ok)
+	assert.Equal(t, []string{"hello 42", "oops"}, scriptLines(entries, "js console"))
+
+	_, ok = store.Logs("run-other")
+	assert.False(t, ok)
+}
+
+func TestRunLogBufferKeepsMostRecentEntries(t *testing.T) {
+	buffer := job.NewRunLogBuffer(2)
+	buffer.Append(job.RunLogEntry{Message: "a"})
+	buffer.Append(job.RunLogEntry{Message: "b"})
+	buffer.Append(job.RunLogEntry{Message: "c"})
+
+	entries := buffer.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b", entries[0].Message)
+	assert.Equal(t, "c", entries[1].Message)
+	assert.Equal(t, 1, buffer.Dropped())
+}
+
+func TestRunLogStoreEvictsOldestRuns(t *testing.T) {
+	store := job.NewRunLogStore(4, 1)
+	task := job.NewBaseTask("task", "/tmp/task.js", "js", job.Config{}, "noop", noopEngine{})
+	cmd := job.NewTaskCommander(task).WithRunLogStore(store)
+
+	require.NoError(t, cmd.Execute(job.ContextWithRunID(context.Background(), "first"), &job.ExecutionMessage{}))
+	require.NoError(t, cmd.Execute(job.ContextWithRunID(context.Background(), "second"), &job.ExecutionMessage{}))
+
+	assert.Equal(t, []string{"second"}, store.RunIDs())
+}
+
+func scriptLines(entries []job.RunLogEntry, message string) []string {
+	var lines []string
+	for _, entry := range entries {
+		if entry.Message == message {
+			lines = append(lines, entry.Fields["line"].(string))
+		}
+	}
+	return lines
+}
+
+func TestRunLogStoreRetention(t *testing.T) {
+	clock := jobtest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := job.NewRunLogStore(4, 10).WithMaxRunsPerJob(2).WithMaxAge(24 * time.Hour).WithClock(clock)
+
+	chatty := job.NewBaseTask("chatty", "/tmp/chatty.js", "js", job.Config{}, "noop", noopEngine{})
+	audit := job.NewBaseTask("audit", "/tmp/audit.js", "js",
+		job.Config{Metadata: map[string]any{job.RunLogRetentionMetadataKey: "168h"}}, "noop", noopEngine{})
+	run := func(task job.Task, runID string) {
+		require.NoError(t, job.NewTaskCommander(task).WithRunLogStore(store).
+			Execute(job.ContextWithRunID(context.Background(), runID), &job.ExecutionMessage{}))
+	}
+
+	run(audit, "audit-1")
+	for _, id := range []string{"chatty-1", "chatty-2", "chatty-3"} {
+		run(chatty, id)
+	}
+	assert.Equal(t, []string{"audit-1", "chatty-2", "chatty-3"}, store.RunIDs(), "per-job cap keeps other jobs' logs")
+
+	logs, err := store.GetRunLogs("audit-1")
+	require.NoError(t, err)
+	assert.Equal(t, "audit", logs.JobID)
+	assert.Equal(t, clock.Now(), logs.StartedAt)
+
+	clock.Advance(25 * time.Hour)
+	assert.Equal(t, 2, store.Prune())
+	assert.Equal(t, []string{"audit-1"}, store.RunIDs(), "log_retention overrides the store max age")
+
+	clock.Advance(7 * 24 * time.Hour)
+	_, err = store.GetRunLogs("audit-1")
+	var typed *errors.Error
+	require.True(t, stderrors.As(err, &typed), "got %v", err)
+	assert.Equal(t, "JOB_RUN_LOGS_NOT_FOUND", typed.TextCode)
+}
+
+func TestRunnerGetRunLogs(t *testing.T) {
+	store := job.NewRunLogStore(32, 4)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("report", "report.sh", "shell", job.Config{}, "", job.NewShellRunner())))
+	runner := job.NewRunner(job.WithRegistry(registry), job.WithRunLogStore(store),
+		job.WithBackfillOptions(job.BackfillOptions{Tracker: job.NewIdempotencyTracker()}))
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := runner.Backfill(context.Background(), "report", from, from.Add(time.Hour), time.Hour,
+		map[string]any{"script": "echo backfilled"})
+	require.NoError(t, err)
+
+	recent := runner.RecentRuns(1)
+	require.Len(t, recent, 1)
+	logs, err := runner.GetRunLogs(recent[0].RunID)
+	require.NoError(t, err)
+	assert.Equal(t, "report", logs.JobID)
+	assert.Equal(t, []string{"backfilled"}, scriptLines(logs.Entries, "shell output"))
+}
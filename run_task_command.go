@@ -0,0 +1,207 @@
+package job
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/goliatone/go-command"
+)
+
+// RunTaskCommand runs a registered task from the CLI, validating `--param`
+// flags against the task's declared parameters.
+type RunTaskCommand struct {
+	registry Registry
+	cliName  string
+	cliGroup string
+	in       io.Reader
+	out      io.Writer
+	build    func(Task) *TaskCommander
+}
+
+// RunTaskOption customizes the run command.
+type RunTaskOption func(*RunTaskCommand)
+
+// WithRunTaskCLIName overrides the CLI command name ("run-job" by default).
+func WithRunTaskCLIName(name string) RunTaskOption {
+	return func(cmd *RunTaskCommand) {
+		if name != "" {
+			cmd.cliName = name
+		}
+	}
+}
+
+// WithRunTaskCLIGroup sets the CLI group for the command.
+func WithRunTaskCLIGroup(group string) RunTaskOption {
+	return func(cmd *RunTaskCommand) {
+		cmd.cliGroup = group
+	}
+}
+
+// WithRunTaskIO replaces stdin (read when prompting) and stdout.
+func WithRunTaskIO(in io.Reader, out io.Writer) RunTaskOption {
+	return func(cmd *RunTaskCommand) {
+		if in != nil {
+			cmd.in = in
+		}
+		if out != nil {
+			cmd.out = out
+		}
+	}
+}
+
+// WithRunTaskCommander builds the commander used for each run, e.g. to add
+// notifiers or a run archive.
+func WithRunTaskCommander(build func(Task) *TaskCommander) RunTaskOption {
+	return func(cmd *RunTaskCommand) {
+		if build != nil {
+			cmd.build = build
+		}
+	}
+}
+
+// NewRunTaskCommand wires a CLI command running tasks from registry.
+func NewRunTaskCommand(registry Registry, opts ...RunTaskOption) *RunTaskCommand {
+	cmd := &RunTaskCommand{
+		registry: registry,
+		cliName:  "run-job",
+		in:       os.Stdin,
+		out:      os.Stdout,
+		build:    NewTaskCommander,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cmd)
+		}
+	}
+	return cmd
+}
+
+// CLIHandler satisfies command.CLICommand.
+func (c *RunTaskCommand) CLIHandler() any {
+	return &runTaskCLI{cmd: c}
+}
+
+// CLIOptions returns CLI metadata for registration.
+func (c *RunTaskCommand) CLIOptions() command.CLIConfig {
+	return command.CLIConfig{
+		Path:        []string{c.cliName},
+		Description: "Run a job once with validated parameters",
+		Group:       c.cliGroup,
+	}
+}
+
+// Run validates params against the task schema and executes it.
+func (c *RunTaskCommand) Run(ctx context.Context, jobID string, params map[string]any) error {
+	if c.registry == nil {
+		return fmt.Errorf("registry is not configured")
+	}
+	task, ok := c.registry.Get(jobID)
+	if !ok || task == nil {
+		return fmt.Errorf("task %q not found", jobID)
+	}
+	validated, err := ValidateTaskParams(task, params)
+	if err != nil {
+		return err
+	}
+	return c.build(task).Execute(ctx, &ExecutionMessage{Parameters: validated})
+}
+
+// prompt asks for each missing required parameter, one line per value.
+func (c *RunTaskCommand) prompt(task Task, params map[string]any) error {
+	schema, err := TaskParamSchema(task)
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(c.in)
+	for _, name := range schema.Missing(params) {
+		spec := schema[indexOfParam(schema, name)]
+		label := name
+		if spec.Description != "" {
+			label = fmt.Sprintf("%s (%s)", name, spec.Description)
+		}
+		fmt.Fprintf(c.out, "%s [%s]: ", label, spec.Type)
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			params[name] = line
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RunTaskCommand) printParams(task Task) error {
+	schema, err := TaskParamSchema(task)
+	if err != nil {
+		return err
+	}
+	if len(schema) == 0 {
+		fmt.Fprintf(c.out, "%s declares no parameters\n", task.GetID())
+		return nil
+	}
+	for _, spec := range schema {
+		line := fmt.Sprintf("%s\t%s", spec.Name, spec.Type)
+		if spec.Required {
+			line += "\trequired"
+		}
+		if spec.Default != nil {
+			line += fmt.Sprintf("\tdefault=%v", spec.Default)
+		}
+		if spec.Description != "" {
+			line += "\t" + spec.Description
+		}
+		fmt.Fprintln(c.out, line)
+	}
+	return nil
+}
+
+func indexOfParam(schema ParamSchema, name string) int {
+	for i, spec := range schema {
+		if spec.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+type runTaskCLI struct {
+	cmd *RunTaskCommand
+
+	JobID      string   `kong:"arg,name='job',help='ID of the job to run'"`
+	Params     []string `kong:"name='param',short='p',help='Job parameter as key=value; repeatable'"`
+	Prompt     bool     `kong:"name='prompt',help='Ask for missing required parameters on stdin'"`
+	ListParams bool     `kong:"name='list-params',help='Print the declared parameters and exit'"`
+}
+
+// Run executes the job from the CLI.
+func (c *runTaskCLI) Run() error {
+	if c.cmd == nil || c.cmd.registry == nil {
+		return fmt.Errorf("run command not configured")
+	}
+	task, ok := c.cmd.registry.Get(c.JobID)
+	if !ok || task == nil {
+		return fmt.Errorf("task %q not found", c.JobID)
+	}
+	if c.ListParams {
+		return c.cmd.printParams(task)
+	}
+
+	params, err := ParseParamFlags(c.Params)
+	if err != nil {
+		return err
+	}
+	if c.Prompt {
+		if err := c.cmd.prompt(task, params); err != nil {
+			return err
+		}
+	}
+	return c.cmd.Run(context.Background(), c.JobID, params)
+}
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTaskCLIPromptsForMissingParams(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("backfill", Config{Metadata: map[string]any{
+		"params": map[string]any{
+			"tenant": map[string]any{"required": true, "description": "Tenant slug"},
+			"days":   map[string]any{"type": "int", "required": true},
+		},
+	}})}
+	registry := NewMemoryRegistry()
+	require.NoError(t, registry.Add(task))
+
+	var out bytes.Buffer
+	cmd := NewRunTaskCommand(registry, WithRunTaskIO(strings.NewReader("5\nacme\n"), &out))
+	cli := cmd.CLIHandler().(*runTaskCLI)
+	cli.JobID = "backfill"
+	cli.Prompt = true
+	require.NoError(t, cli.Run())
+
+	assert.Equal(t, "days [int]: tenant (Tenant slug) [any]: ", out.String())
+	require.Len(t, task.msgs, 1)
+	assert.Equal(t, 5, task.msgs[0].Parameters["days"])
+	assert.Equal(t, "acme", task.msgs[0].Parameters["tenant"])
+
+	out.Reset()
+	cli.ListParams = true
+	require.NoError(t, cli.Run())
+	assert.Equal(t, "days\tint\trequired\ntenant\tany\trequired\tTenant slug\n", out.String())
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/goliatone/go-command/router"
+	"github.com/goliatone/go-errors"
 )
 
 type Runner struct {
@@ -17,6 +21,33 @@ type Runner struct {
 	loggerProvider    LoggerProvider
 	taskIDProvider    TaskIDProvider
 	taskEventHandlers []TaskEventHandler
+	duplicateIDs      DuplicateIDStrategy
+	strictStartup     bool
+	configDefaults    ConfigDefaults
+	secrets           SecretsProvider
+	profiles          ProfileStore
+	invoker           JobInvoker
+	overrides         OverrideStore
+	resultRedactor    EnvelopeSanitizer
+	results           *ResultPipeline
+	backfill          BackfillOptions
+	backfillTracker   *IdempotencyTracker
+	beforeRun         []BeforeRunHook
+	afterRun          []AfterRunHook
+	globalEnv         map[string]string
+
+	cronManager     *CronManager
+	healthChecks    map[string]HealthCheck
+	healthTimeout   time.Duration
+	discoveryErrors []DiscoveryError
+	skipCounts      map[SkipReason]int
+	runs            *RunCache
+	runLogs         *RunLogStore
+	history         ExecutionHistory
+	maintenance     *MaintenanceMode
+
+	mux     *router.Mux
+	muxSubs map[string]router.Subscription
 }
 
 func NewRunner(opts ...Option) *Runner {
@@ -24,8 +55,14 @@ func NewRunner(opts ...Option) *Runner {
 	rn := &Runner{
 		registry:       NewMemoryRegistry(),
 		parser:         NewYAMLMetadataParser(),
+		resultRedactor: defaultRedactor,
 		loggerProvider: loggerProvider,
 		logger:         loggerProvider.GetLogger("job:runner"),
+		runs:           NewRunCache(defaultRunCacheSize),
+		maintenance:    NewMaintenanceMode(),
+		// backfillTracker lets repeated backfills merge completed windows
+		// without sharing the process-wide tracker.
+		backfillTracker: NewIdempotencyTracker(),
 	}
 
 	for _, opt := range opts {
@@ -34,6 +71,22 @@ func NewRunner(opts ...Option) *Runner {
 		}
 	}
 
+	if rn.cronManager != nil && rn.cronManager.runs == nil {
+		rn.cronManager.WithRunCache(rn.runs)
+	}
+	if rn.cronManager != nil && rn.cronManager.runLogs == nil && rn.runLogs != nil {
+		rn.cronManager.WithRunLogStore(rn.runLogs)
+	}
+	if rn.cronManager != nil && rn.cronManager.runHistory == nil && rn.history != nil {
+		rn.cronManager.WithExecutionHistory(rn.history)
+	}
+	if rn.cronManager != nil && rn.cronManager.maintenance == nil {
+		rn.cronManager.WithMaintenanceMode(rn.maintenance)
+	}
+	if rn.cronManager != nil {
+		rn.cronManager.WithBeforeRun(rn.beforeRun...).WithAfterRun(rn.afterRun...)
+	}
+
 	if rn.errorHandler == nil {
 		rn.errorHandler = func(task Task, err error) {
 			if task != nil {
@@ -48,6 +101,8 @@ func NewRunner(opts ...Option) *Runner {
 }
 
 func (r *Runner) Start(ctx context.Context) error {
+	r.resetDiscoveryErrors()
+
 	for _, make := range r.taskCreators {
 		if err := ctx.Err(); err != nil {
 			r.handleContextCancellation(err)
@@ -75,7 +130,23 @@ func (r *Runner) Start(ctx context.Context) error {
 				return err
 			}
 
+			if err := r.results.Validate(task.GetID(), task.GetConfig()); err != nil {
+				r.errorHandler(task, err)
+				r.emitTaskEvent(TaskEvent{
+					Type:       TaskEventRegistrationFailed,
+					TaskID:     task.GetID(),
+					ScriptPath: taskScriptPath(task),
+					Task:       task,
+					Err:        err,
+				})
+				continue
+			}
+
 			if err := r.registry.Add(task); err != nil {
+				if existing, dup := r.registry.Get(task.GetID()); dup && existing != nil {
+					r.registerDuplicate(existing, task, err)
+					continue
+				}
 				r.errorHandler(task, err)
 				r.emitTaskEvent(TaskEvent{
 					Type:       TaskEventRegistrationFailed,
@@ -101,22 +172,88 @@ func (r *Runner) Start(ctx context.Context) error {
 		return err
 	}
 
+	engineFailures := r.checkEngines(ctx)
+	if r.strictStartup {
+		return r.startupError(engineFailures)
+	}
 	return nil
 }
 
+// startupError aggregates the discovery failures recorded during Start and
+// the failed engine health checks.
+func (r *Runner) startupError(engineFailures []DiscoveryError) error {
+	r.mx.RLock()
+	failures := append([]DiscoveryError(nil), r.discoveryErrors...)
+	r.mx.RUnlock()
+	discovered := len(failures)
+	failures = append(failures, engineFailures...)
+
+	if len(failures) == 0 {
+		return nil
+	}
+	fieldErrors := make([]errors.FieldError, 0, len(failures))
+	for _, failure := range failures {
+		field := failure.ScriptPath
+		if field == "" {
+			field = failure.TaskID
+		}
+		if field == "" {
+			field = "task_creator"
+		}
+		fieldErrors = append(fieldErrors, errors.FieldError{
+			Field:   field,
+			Message: failure.Error,
+		})
+	}
+	msg := fmt.Sprintf("strict startup: %d task(s) failed discovery", discovered)
+	if len(engineFailures) > 0 {
+		msg += fmt.Sprintf(", %d engine(s) failed health checks", len(engineFailures))
+	}
+	return errors.NewValidation(msg, fieldErrors...).WithTextCode("JOB_STARTUP_FAILED")
+}
+
 func (r *Runner) Stop(_ context.Context) error {
+	r.unsubscribeCommandMux()
+	if r.cronManager != nil {
+		r.cronManager.Stop()
+	}
 	return nil
 }
 
+// RegisteredTasks returns the registered tasks ordered by ID, whatever order
+// the registry lists them in.
 func (r *Runner) RegisteredTasks() []Task {
-	return r.registry.List()
+	tasks := append([]Task(nil), r.registry.List()...)
+	sortTasksByID(tasks)
+	return tasks
 }
 
-// SetResult stores result metadata for a given job ID.
+// SetResult stores result metadata for a given job ID. Metadata is redacted
+// first; see WithResultRedactor.
 func (r *Runner) SetResult(jobID string, result Result) error {
+	return r.SetResultContext(context.Background(), jobID, result)
+}
+
+// SetResultContext is SetResult with a context for the result processors:
+// the result is redacted, passed through the processors configured with
+// WithResultProcessors and those the job selects, then stored. A processor
+// error leaves the stored result unchanged.
+func (r *Runner) SetResultContext(ctx context.Context, jobID string, result Result) error {
 	if r == nil || r.registry == nil {
 		return fmt.Errorf("runner registry not configured")
 	}
+	result = RedactResult(result, r.resultRedactor)
+	if r.results != nil {
+		var cfg Config
+		if task, ok := r.registry.Get(jobID); ok && task != nil {
+			cfg = task.GetConfig()
+		}
+		processed, err := r.results.Process(ctx, jobID, cfg, result)
+		if err != nil {
+			return err
+		}
+		result = processed
+	}
 	return r.registry.SetResult(jobID, result)
 }
 
@@ -133,22 +270,50 @@ func (r *Runner) emitTaskEvent(event TaskEvent) {
 		event.Type = TaskEventRegistrationFailed
 	}
 
+	event = describeTaskEvent(event)
+	r.recordDiscoveryEvent(event)
+	r.countSkip(event)
+	r.syncCommandMux(event)
+
 	switch event.Type {
 	case TaskEventRegistered:
 		args := []any{
 			"task_id", event.TaskID,
 			"script_path", event.ScriptPath,
 		}
+		if event.ExistingPath != "" {
+			args = append(args, "existing_path", event.ExistingPath)
+		}
+		if event.Engine != "" {
+			args = append(args, "engine", event.Engine, "checksum", event.Checksum)
+		}
 		r.logger.Info("task registered", args...)
 	case TaskEventRegistrationFailed:
 		args := []any{
 			"task_id", event.TaskID,
 			"script_path", event.ScriptPath,
 		}
+		if event.ExistingPath != "" {
+			args = append(args, "existing_path", event.ExistingPath)
+		}
 		if event.Err != nil {
 			args = append(args, "error", event.Err)
 		}
 		r.logger.Warn("task registration failed", args...)
+	case TaskEventUpdated:
+		r.logger.Info("task updated", "task_id", event.TaskID, "script_path", event.ScriptPath)
+	case TaskEventSkipped:
+		args := []any{
+			"task_id", event.TaskID,
+			"script_path", event.ScriptPath,
+			"reason", event.Reason,
+		}
+		if event.ExistingPath != "" {
+			args = append(args, "existing_path", event.ExistingPath)
+		}
+		r.logger.Info("task skipped", args...)
+	case TaskEventRemoved:
+		r.logger.Info("task removed", "task_id", event.TaskID, "script_path", event.ScriptPath)
 	}
 
 	for _, handler := range r.taskEventHandlers {
@@ -168,6 +333,38 @@ func (r *Runner) handleContextCancellation(err error) {
 	})
 }
 
+// Commander returns a TaskCommander for task wired like the runner's own
+// runs: run cache, run logs, execution history, maintenance mode, hooks and,
+// when the registry implements TaskToggler, task toggles. Hand it to trigger
+// surfaces built outside the runner so they honour the same switches:
+//
+//	job.NewWebhookTriggerHandler(registry, job.WithWebhookCommander(runner.Commander))
+//	admin.NewService(registry, admin.WithCommander(runner.Commander))
+//	worker.New(storage, worker.WithCommanderFactory(runner.Commander))
+func (r *Runner) Commander(task Task) *TaskCommander {
+	cmd := r.commander(task)
+	if toggles, ok := r.registry.(TaskToggler); ok {
+		cmd = cmd.WithTaskToggler(toggles)
+	}
+	return cmd
+}
+
+// commander builds a TaskCommander sharing the runner's run cache, run log
+// store, execution history, maintenance switch and hooks. Results it records
+// go through SetResult, so redaction and result processors apply.
+func (r *Runner) commander(task Task) *TaskCommander {
+	return NewTaskCommander(task).
+		WithResultRecorder(r).
+		WithResultRedactor(r.resultRedactor).
+		WithLogger(r.logger).
+		WithRunCache(r.runs).
+		WithRunLogStore(r.runLogs).
+		WithExecutionHistory(r.history).
+		WithMaintenanceMode(r.maintenance).
+		WithBeforeRun(r.beforeRun...).
+		WithAfterRun(r.afterRun...)
+}
+
 func (r *Runner) attachTaskCreatorOptions(creator TaskCreator) {
 	if creator == nil {
 		return
@@ -188,7 +385,45 @@ func (r *Runner) attachTaskCreatorOptions(creator TaskCreator) {
 		}
 	}
 
+	if !r.configDefaults.isZero() {
+		if aware, ok := creator.(ConfigDefaultsAware); ok {
+			aware.SetConfigDefaults(r.configDefaults)
+		}
+	}
+
+	if r.secrets != nil {
+		if aware, ok := creator.(SecretsAware); ok {
+			aware.SetSecretsProvider(r.secrets)
+		}
+	}
+
+	if r.profiles != nil {
+		if aware, ok := creator.(ProfileStoreAware); ok {
+			aware.SetProfileStore(r.profiles)
+		}
+	}
+
+	if r.invoker != nil {
+		if aware, ok := creator.(JobInvokerAware); ok {
+			aware.SetJobInvoker(r.invoker)
+		}
+	}
+
+	if r.overrides != nil {
+		if aware, ok := creator.(OverrideStoreAware); ok {
+			aware.SetOverrideStore(r.overrides)
+		}
+	}
+
+	if len(r.globalEnv) > 0 {
+		if aware, ok := creator.(GlobalEnvAware); ok {
+			aware.SetGlobalEnv(r.globalEnv)
+		}
+	}
+
 	if emitter, ok := creator.(TaskEventEmitter); ok {
+		emitter.AddTaskEventHandler(r.recordDiscoveryEvent)
+		emitter.AddTaskEventHandler(r.countSkip)
 		for _, handler := range r.taskEventHandlers {
 			emitter.AddTaskEventHandler(handler)
 		}
@@ -219,6 +454,46 @@ func (r *Runner) propagateTaskIDProvider() {
 	}
 }
 
+func (r *Runner) propagateConfigDefaults() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(ConfigDefaultsAware); ok {
+			aware.SetConfigDefaults(r.configDefaults)
+		}
+	}
+}
+
+func (r *Runner) propagateSecretsProvider() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(SecretsAware); ok {
+			aware.SetSecretsProvider(r.secrets)
+		}
+	}
+}
+
+func (r *Runner) propagateProfileStore() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(ProfileStoreAware); ok {
+			aware.SetProfileStore(r.profiles)
+		}
+	}
+}
+
+func (r *Runner) propagateJobInvoker() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(JobInvokerAware); ok {
+			aware.SetJobInvoker(r.invoker)
+		}
+	}
+}
+
+func (r *Runner) propagateOverrideStore() {
+	for _, creator := range r.taskCreators {
+		if aware, ok := creator.(OverrideStoreAware); ok {
+			aware.SetOverrideStore(r.overrides)
+		}
+	}
+}
+
 func (r *Runner) propagateLoggerProvider() {
 	if r.loggerProvider == nil {
 		return
@@ -0,0 +1,164 @@
+package job
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// DuplicateIDStrategy decides what happens when two scripts resolve to the same task ID.
+type DuplicateIDStrategy string
+
+const (
+	// DuplicateIDError keeps the first task and reports the second as a registration failure.
+	DuplicateIDError DuplicateIDStrategy = "error"
+	// DuplicateIDReplace registers the later task in place of the earlier one.
+	DuplicateIDReplace DuplicateIDStrategy = "replace"
+	// DuplicateIDSuffix registers the later task under "<id>-<hash>", hashing its script path.
+	DuplicateIDSuffix DuplicateIDStrategy = "suffix"
+	// DuplicateIDKeepFirst keeps the first task and skips the second without an
+	// error, emitting TaskEventSkipped with reason "duplicate_id".
+	DuplicateIDKeepFirst DuplicateIDStrategy = "keep_first"
+)
+
+// registerDuplicate applies the runner's strategy after registry.Add rejected
+// task because existing already holds its ID.
+func (r *Runner) registerDuplicate(existing, task Task, addErr error) {
+	event := TaskEvent{
+		TaskID:       task.GetID(),
+		ScriptPath:   taskScriptPath(task),
+		ExistingPath: taskScriptPath(existing),
+		Task:         task,
+	}
+
+	switch r.duplicateIDs {
+	case DuplicateIDKeepFirst:
+		event.Type = TaskEventSkipped
+		event.Reason = SkipReasonDuplicateID
+		r.emitTaskEvent(event)
+		return
+	case DuplicateIDReplace:
+		registry, ok := r.registry.(MutableRegistry)
+		if !ok {
+			addErr = fmt.Errorf("registry %T cannot replace duplicate task %s", r.registry, event.TaskID)
+			break
+		}
+		if addErr = registry.Replace(task); addErr == nil {
+			event.Type = TaskEventRegistered
+			r.emitTaskEvent(event)
+			return
+		}
+	case DuplicateIDSuffix:
+		renamed := suffixTaskID(task)
+		if addErr = r.registry.Add(renamed); addErr == nil {
+			event.Type = TaskEventRegistered
+			event.TaskID = renamed.GetID()
+			event.Task = renamed
+			r.emitTaskEvent(event)
+			return
+		}
+	}
+
+	r.errorHandler(task, addErr)
+	event.Type = TaskEventRegistrationFailed
+	event.Err = addErr
+	r.emitTaskEvent(event)
+}
+
+// collectDuplicate applies the strategy to tasks discovered during Reload,
+// where nothing has been written to the registry yet.
+func (r *Runner) collectDuplicate(discovered map[string]Task, task Task) {
+	existing := discovered[task.GetID()]
+	event := TaskEvent{
+		TaskID:       task.GetID(),
+		ScriptPath:   taskScriptPath(task),
+		ExistingPath: taskScriptPath(existing),
+		Task:         task,
+	}
+
+	switch r.duplicateIDs {
+	case DuplicateIDKeepFirst:
+		event.Type = TaskEventSkipped
+		event.Reason = SkipReasonDuplicateID
+		r.emitTaskEvent(event)
+		return
+	case DuplicateIDReplace:
+		discovered[task.GetID()] = task
+		return
+	case DuplicateIDSuffix:
+		renamed := suffixTaskID(task)
+		if _, taken := discovered[renamed.GetID()]; !taken {
+			discovered[renamed.GetID()] = renamed
+			return
+		}
+	}
+
+	err := fmt.Errorf("job with ID %s already exists", task.GetID())
+	r.errorHandler(task, err)
+	event.Type = TaskEventRegistrationFailed
+	event.Err = err
+	r.emitTaskEvent(event)
+}
+
+// suffixTaskID wraps task so it reports a path-derived unique ID.
+func suffixTaskID(task Task) Task {
+	sum := sha256.Sum256([]byte(taskScriptPath(task)))
+	return &renamedTask{
+		Task: task,
+		id:   task.GetID() + "-" + hex.EncodeToString(sum[:])[:8],
+	}
+}
+
+type renamedTask struct {
+	Task
+	id string
+}
+
+func (t *renamedTask) GetID() string {
+	return t.id
+}
+
+func (t *renamedTask) GetHandler() func() error {
+	return func() error {
+		return t.Execute(context.Background(), nil)
+	}
+}
+
+func (t *renamedTask) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	if msg == nil {
+		msg = &ExecutionMessage{}
+	}
+	if msg.JobID == "" {
+		msg.JobID = t.id
+	}
+	return t.Task.Execute(ctx, msg)
+}
+
+func (t *renamedTask) GetScriptContent() string {
+	if v, ok := t.Task.(interface{ GetScriptContent() string }); ok {
+		return v.GetScriptContent()
+	}
+	return ""
+}
+
+func (t *renamedTask) GetParseDuration() time.Duration {
+	if v, ok := t.Task.(interface{ GetParseDuration() time.Duration }); ok {
+		return v.GetParseDuration()
+	}
+	return 0
+}
+
+func (t *renamedTask) buildExecutionMessage(msg *ExecutionMessage) (*ExecutionMessage, error) {
+	if msg == nil {
+		msg = &ExecutionMessage{}
+	}
+	if msg.JobID == "" {
+		msg.JobID = t.id
+	}
+	if composer, ok := t.Task.(messageComposer); ok {
+		return composer.buildExecutionMessage(msg)
+	}
+	return CompleteExecutionMessage(t.Task, msg)
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"fmt"
+)
+
+// EngineManager task creators can implement this to accept engines after
+// construction.
+type EngineManager interface {
+	AddEngine(engine Engine)
+	RemoveEngine(name string) bool
+}
+
+// AddEngine registers engine on every task creator that implements
+// EngineManager and reloads, so scripts the engine can handle are registered
+// without rebuilding the runner. Reload semantics apply: when a creator
+// fails, nothing is removed.
+func (r *Runner) AddEngine(ctx context.Context, engine Engine) (ReloadResult, error) {
+	if engine == nil {
+		return ReloadResult{}, fmt.Errorf("engine is required")
+	}
+	if r.engineManagers() == 0 {
+		return ReloadResult{}, fmt.Errorf("no task creator accepts engines")
+	}
+	for _, creator := range r.taskCreators {
+		if manager, ok := creator.(EngineManager); ok {
+			manager.AddEngine(engine)
+		}
+	}
+	return r.Reload(ctx)
+}
+
+// RemoveEngine drops the engine called name from every EngineManager task
+// creator and reloads, unregistering the tasks it handled.
+func (r *Runner) RemoveEngine(ctx context.Context, name string) (ReloadResult, error) {
+	removed := false
+	for _, creator := range r.taskCreators {
+		if manager, ok := creator.(EngineManager); ok && manager.RemoveEngine(name) {
+			removed = true
+		}
+	}
+	if !removed {
+		return ReloadResult{}, fmt.Errorf("engine %q is not registered", name)
+	}
+	return r.Reload(ctx)
+}
+
+func (r *Runner) engineManagers() int {
+	count := 0
+	for _, creator := range r.taskCreators {
+		if _, ok := creator.(EngineManager); ok {
+			count++
+		}
+	}
+	return count
+}
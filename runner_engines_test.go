@@ -0,0 +1,39 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerAddAndRemoveEngine(t *testing.T) {
+	provider := job.NewFileSystemSourceProvider(".", fstest.MapFS{
+		"notify.js":  {Data: []byte("console.log('hi')")},
+		"report.sql": {Data: []byte("SELECT 1;")},
+	})
+	creator := job.NewTaskCreator(provider, []job.Engine{job.NewJSRunner()})
+	runner := job.NewRunner(job.WithTaskCreator(creator))
+	require.NoError(t, runner.Start(context.Background()))
+	require.Len(t, runner.RegisteredTasks(), 1)
+
+	result, err := runner.AddEngine(context.Background(), job.NewSQLRunner())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report.sql"}, result.Added)
+	assert.Len(t, creator.Engines(), 2)
+
+	// re-adding an engine with the same name replaces it
+	creator.AddEngine(job.NewSQLRunner())
+	assert.Len(t, creator.Engines(), 2)
+
+	result, err = runner.RemoveEngine(context.Background(), "engine:sql")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report.sql"}, result.Removed)
+	assert.Len(t, runner.RegisteredTasks(), 1)
+
+	_, err = runner.RemoveEngine(context.Background(), "engine:sql")
+	assert.Error(t, err)
+}
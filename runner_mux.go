@@ -0,0 +1,60 @@
+package job
+
+import (
+	"github.com/goliatone/go-command/router"
+)
+
+// WithCommandMux keeps mux in sync with the registry: every task registered
+// by Start or Reload gets a TaskCommander subscribed under
+// TaskCommandPattern, updated tasks are re-subscribed and removed tasks
+// unsubscribed. Stop unsubscribes everything. When the registry implements
+// TaskToggler, disabled tasks are refused by their commander.
+func WithCommandMux(mux *router.Mux) Option {
+	return func(r *Runner) {
+		r.mux = mux
+	}
+}
+
+// syncCommandMux applies a registration event to the command mux.
+func (r *Runner) syncCommandMux(event TaskEvent) {
+	if r.mux == nil || event.TaskID == "" {
+		return
+	}
+	switch event.Type {
+	case TaskEventRegistered, TaskEventUpdated:
+		if event.Task == nil {
+			return
+		}
+		entry := r.mux.Add(TaskCommandPattern(event.Task), r.Commander(event.Task))
+
+		r.mx.Lock()
+		previous := r.muxSubs[event.TaskID]
+		if r.muxSubs == nil {
+			r.muxSubs = make(map[string]router.Subscription)
+		}
+		r.muxSubs[event.TaskID] = entry
+		r.mx.Unlock()
+		if previous != nil {
+			previous.Unsubscribe()
+		}
+	case TaskEventRemoved:
+		r.mx.Lock()
+		previous := r.muxSubs[event.TaskID]
+		delete(r.muxSubs, event.TaskID)
+		r.mx.Unlock()
+		if previous != nil {
+			previous.Unsubscribe()
+		}
+	}
+}
+
+// unsubscribeCommandMux drops every subscription created by WithCommandMux.
+func (r *Runner) unsubscribeCommandMux() {
+	r.mx.Lock()
+	subs := r.muxSubs
+	r.muxSubs = nil
+	r.mx.Unlock()
+	for _, sub := range subs {
+		sub.Unsubscribe()
+	}
+}
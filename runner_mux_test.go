@@ -0,0 +1,45 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-command/router"
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerCommandMuxFollowsRegistry(t *testing.T) {
+	creator := &stubTaskCreator{tasks: []job.Task{
+		configTask{stubTask: stubTask{id: "keep"}},
+		configTask{stubTask: stubTask{id: "change"}, config: job.Config{Retries: 1}},
+		configTask{stubTask: stubTask{id: "drop"}},
+	}}
+	mux := router.NewMux()
+	runner := job.NewRunner(job.WithTaskCreator(creator), job.WithCommandMux(mux))
+	require.NoError(t, runner.Start(context.Background()))
+
+	pattern := func(id string) string { return job.TaskCommandPattern(stubTask{id: id}) }
+	for _, id := range []string{"keep", "change", "drop"} {
+		assert.Len(t, mux.Get(pattern(id)), 1, id)
+	}
+
+	creator.tasks = []job.Task{
+		configTask{stubTask: stubTask{id: "keep"}},
+		configTask{stubTask: stubTask{id: "change"}, config: job.Config{Retries: 2}},
+		configTask{stubTask: stubTask{id: "new"}},
+	}
+	_, err := runner.Reload(context.Background())
+	require.NoError(t, err)
+
+	assert.Len(t, mux.Get(pattern("keep")), 1)
+	assert.Len(t, mux.Get(pattern("change")), 1)
+	assert.Len(t, mux.Get(pattern("new")), 1)
+	assert.Empty(t, mux.Get(pattern("drop")))
+
+	require.NoError(t, runner.Stop(context.Background()))
+	for _, id := range []string{"keep", "change", "new"} {
+		assert.Empty(t, mux.Get(pattern(id)), id)
+	}
+}
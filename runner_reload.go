@@ -0,0 +1,182 @@
+package job
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ReloadResult summarises the changes applied by Runner.Reload. IDs are sorted.
+type ReloadResult struct {
+	Added     []string
+	Updated   []string
+	Removed   []string
+	Unchanged []string
+	Errors    []DiscoveryError
+}
+
+// Changed reports whether the reload modified the registry.
+func (r ReloadResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// Reload re-runs the task creators and diffs the discovered tasks against the
+// registry by content hash: new IDs are added, changed tasks replaced and
+// missing tasks removed, emitting the matching TaskEvents. When any creator
+// fails, removals are skipped so a transient source error does not unregister
+// jobs. The registry must implement MutableRegistry.
+func (r *Runner) Reload(ctx context.Context) (ReloadResult, error) {
+	var result ReloadResult
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	registry, ok := r.registry.(MutableRegistry)
+	if !ok {
+		return result, fmt.Errorf("registry %T does not support reload", r.registry)
+	}
+
+	r.resetDiscoveryErrors()
+
+	discovered := make(map[string]Task)
+	complete := true
+	for _, creator := range r.taskCreators {
+		if err := ctx.Err(); err != nil {
+			r.handleContextCancellation(err)
+			return result, err
+		}
+
+		tasks, err := creator.CreateTasks(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				r.handleContextCancellation(ctxErr)
+				return result, ctxErr
+			}
+			complete = false
+			r.errorHandler(nil, err)
+			r.emitTaskEvent(TaskEvent{Type: TaskEventRegistrationFailed, Err: err})
+			continue
+		}
+
+		for _, task := range tasks {
+			if task == nil {
+				continue
+			}
+			id := task.GetID()
+			if _, dup := discovered[id]; dup {
+				r.collectDuplicate(discovered, task)
+				continue
+			}
+			discovered[id] = task
+		}
+	}
+
+	current := make(map[string]Task)
+	for _, task := range registry.List() {
+		current[task.GetID()] = task
+	}
+
+	for _, id := range sortedTaskIDs(discovered) {
+		task := discovered[id]
+		if err := r.results.Validate(id, task.GetConfig()); err != nil {
+			r.reloadFailed(task, err)
+			continue
+		}
+		existing, exists := current[id]
+		switch {
+		case !exists:
+			if err := registry.Add(task); err != nil {
+				r.reloadFailed(task, err)
+				continue
+			}
+			result.Added = append(result.Added, id)
+			r.emitTaskEvent(TaskEvent{Type: TaskEventRegistered, TaskID: id, ScriptPath: taskScriptPath(task), Task: task})
+		case TaskContentHash(existing) == TaskContentHash(task):
+			result.Unchanged = append(result.Unchanged, id)
+		default:
+			if err := registry.Replace(task); err != nil {
+				r.reloadFailed(task, err)
+				continue
+			}
+			result.Updated = append(result.Updated, id)
+			r.emitTaskEvent(TaskEvent{
+				Type:             TaskEventUpdated,
+				TaskID:           id,
+				ScriptPath:       taskScriptPath(task),
+				PreviousChecksum: taskContentChecksum(existing),
+				DiffSize:         scriptDiffSize(taskScriptContent(existing), taskScriptContent(task)),
+				Task:             task,
+			})
+		}
+	}
+
+	if complete {
+		for _, id := range sortedTaskIDs(current) {
+			if _, ok := discovered[id]; ok {
+				continue
+			}
+			task := current[id]
+			if registry.Remove(id) {
+				result.Removed = append(result.Removed, id)
+				r.emitTaskEvent(TaskEvent{Type: TaskEventRemoved, TaskID: id, ScriptPath: taskScriptPath(task), Task: task})
+			}
+		}
+	}
+
+	r.mx.RLock()
+	result.Errors = append([]DiscoveryError(nil), r.discoveryErrors...)
+	r.mx.RUnlock()
+	return result, nil
+}
+
+func (r *Runner) reloadFailed(task Task, err error) {
+	r.errorHandler(task, err)
+	r.emitTaskEvent(TaskEvent{
+		Type:       TaskEventRegistrationFailed,
+		TaskID:     task.GetID(),
+		ScriptPath: taskScriptPath(task),
+		Task:       task,
+		Err:        err,
+	})
+}
+
+func taskScriptContent(task Task) string {
+	if v, ok := task.(interface{ GetScriptContent() string }); ok {
+		return v.GetScriptContent()
+	}
+	return ""
+}
+
+// TaskContentHash fingerprints a task's script path, content and config so
+// reloads can tell whether a rediscovered task actually changed.
+func TaskContentHash(task Task) string {
+	if task == nil {
+		return ""
+	}
+	type contentAware interface {
+		GetScriptContent() string
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", task.GetID(), taskScriptPath(task))
+	if v, ok := task.(contentAware); ok {
+		h.Write([]byte(v.GetScriptContent()))
+	}
+	h.Write([]byte{0})
+	if cfg, err := json.Marshal(task.GetConfig()); err == nil {
+		h.Write(cfg)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedTaskIDs(tasks map[string]Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
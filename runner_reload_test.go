@@ -0,0 +1,127 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configTask struct {
+	stubTask
+	config job.Config
+}
+
+func (t configTask) GetConfig() job.Config { return t.config }
+
+func TestRunnerReloadAppliesDiff(t *testing.T) {
+	creator := &stubTaskCreator{tasks: []job.Task{
+		configTask{stubTask: stubTask{id: "keep"}, config: job.Config{Retries: 1}},
+		configTask{stubTask: stubTask{id: "change"}, config: job.Config{Retries: 1}},
+		configTask{stubTask: stubTask{id: "drop"}},
+	}}
+
+	var events []job.TaskEvent
+	runner := job.NewRunner(
+		job.WithTaskCreator(creator),
+		job.WithTaskEventHandler(func(event job.TaskEvent) { events = append(events, event) }),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	creator.tasks = []job.Task{
+		configTask{stubTask: stubTask{id: "keep"}, config: job.Config{Retries: 1}},
+		configTask{stubTask: stubTask{id: "change"}, config: job.Config{Retries: 3}},
+		configTask{stubTask: stubTask{id: "new"}},
+	}
+	events = nil
+
+	result, err := runner.Reload(context.Background())
+	require.NoError(t, err)
+	assert.True(t, result.Changed())
+	assert.Equal(t, []string{"new"}, result.Added)
+	assert.Equal(t, []string{"change"}, result.Updated)
+	assert.Equal(t, []string{"drop"}, result.Removed)
+	assert.Equal(t, []string{"keep"}, result.Unchanged)
+	assert.Empty(t, result.Errors)
+
+	types := map[string]job.TaskEventType{}
+	for _, event := range events {
+		types[event.TaskID] = event.Type
+	}
+	assert.Equal(t, map[string]job.TaskEventType{
+		"change": job.TaskEventUpdated,
+		"new":    job.TaskEventRegistered,
+		"drop":   job.TaskEventRemoved,
+	}, types)
+
+	ids := []string{}
+	for _, task := range runner.RegisteredTasks() {
+		ids = append(ids, task.GetID())
+	}
+	assert.ElementsMatch(t, []string{"keep", "change", "new"}, ids)
+
+	result, err = runner.Reload(context.Background())
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+}
+
+func TestRunnerReloadKeepsTasksWhenCreatorFails(t *testing.T) {
+	healthy := &stubTaskCreator{tasks: []job.Task{stubTask{id: "a"}}}
+	flaky := &stubTaskCreator{tasks: []job.Task{stubTask{id: "b"}}}
+	runner := job.NewRunner(
+		job.WithTaskCreator(healthy),
+		job.WithTaskCreator(flaky),
+		job.WithErrorHandler(func(job.Task, error) {}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	flaky.tasks, flaky.err = nil, errors.New("source unavailable")
+	result, err := runner.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+	assert.Len(t, result.Errors, 1)
+	assert.Len(t, runner.RegisteredTasks(), 2)
+}
+
+func TestRunnerReloadReportsChecksumChange(t *testing.T) {
+	engine := noopEngine{}
+	creator := &stubTaskCreator{tasks: []job.Task{
+		job.NewBaseTask("report", "jobs/report.js", "js", job.Config{}, "a();\nb();", engine),
+	}}
+	var events []job.TaskEvent
+	runner := job.NewRunner(
+		job.WithTaskCreator(creator),
+		job.WithTaskEventHandler(func(event job.TaskEvent) { events = append(events, event) }),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+	require.Len(t, events, 1)
+	registered := events[0]
+
+	updated := job.NewBaseTask("report", "jobs/report.js", "js", job.Config{}, "a();\nc();\nd();", engine)
+	creator.tasks = []job.Task{updated}
+	events = nil
+	_, err := runner.Reload(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, job.TaskEventUpdated, event.Type)
+	assert.Equal(t, registered.Checksum, event.PreviousChecksum)
+	assert.NotEqual(t, event.PreviousChecksum, event.Checksum)
+	assert.Equal(t, 3, event.DiffSize)
+
+	broker := job.NewRunEventBroker(10, 10)
+	broker.Track("run-1")
+	runEvents, cancel := broker.Subscribe("run-1")
+	defer cancel()
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	require.NoError(t, job.NewTaskCommander(updated).WithRunEvents(broker).Execute(ctx, &job.ExecutionMessage{}))
+
+	started := <-runEvents
+	assert.Equal(t, job.RunEventStarted, started.Type)
+	assert.Equal(t, event.ScriptChecksum, started.ScriptChecksum)
+	assert.NotEmpty(t, started.ScriptChecksum)
+}
@@ -117,9 +117,63 @@ func TestRunnerRejectsDuplicateTaskIDsFromCustomProvider(t *testing.T) {
 	assert.Equal(t, job.TaskEventRegistrationFailed, events[1].Type)
 	assert.Equal(t, "welcome", events[1].TaskID)
 	assert.Equal(t, "jobs/notifications/welcome.sh", events[1].ScriptPath)
+	assert.Equal(t, "jobs/email/welcome.sh", events[1].ExistingPath)
 	assert.Error(t, events[1].Err)
 }
 
+func TestRunnerDuplicateIDStrategies(t *testing.T) {
+	start := func(t *testing.T, strategy job.DuplicateIDStrategy) (*job.Runner, []job.TaskEvent) {
+		t.Helper()
+		provider := &staticSourceProvider{
+			scripts: []job.ScriptInfo{
+				{Path: "jobs/email/welcome.sh", Content: []byte("echo hello")},
+				{Path: "jobs/notifications/welcome.sh", Content: []byte("echo hello again")},
+			},
+		}
+		var events []job.TaskEvent
+		runner := job.NewRunner(
+			job.WithTaskIDProvider(func(string) string { return "welcome" }),
+			job.WithDuplicateIDStrategy(strategy),
+			job.WithTaskEventHandler(func(event job.TaskEvent) { events = append(events, event) }),
+			job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner()})),
+		)
+		require.NoError(t, runner.Start(context.Background()))
+		return runner, events
+	}
+
+	t.Run("keep first", func(t *testing.T) {
+		runner, events := start(t, job.DuplicateIDKeepFirst)
+		tasks := runner.RegisteredTasks()
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "jobs/email/welcome.sh", tasks[0].GetPath())
+		require.Len(t, events, 2)
+		assert.Equal(t, job.TaskEventSkipped, events[1].Type)
+		assert.Equal(t, job.SkipReasonDuplicateID, events[1].Reason)
+		assert.Equal(t, map[job.SkipReason]int{job.SkipReasonDuplicateID: 1}, runner.SkipCounts())
+		assert.NoError(t, events[1].Err)
+		assert.Empty(t, runner.Health(context.Background()).DiscoveryErrors)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		runner, events := start(t, job.DuplicateIDReplace)
+		tasks := runner.RegisteredTasks()
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "jobs/notifications/welcome.sh", tasks[0].GetPath())
+		require.Len(t, events, 2)
+		assert.Equal(t, job.TaskEventRegistered, events[1].Type)
+		assert.Equal(t, "jobs/email/welcome.sh", events[1].ExistingPath)
+	})
+
+	t.Run("suffix", func(t *testing.T) {
+		runner, events := start(t, job.DuplicateIDSuffix)
+		require.Len(t, runner.RegisteredTasks(), 2)
+		require.Len(t, events, 2)
+		assert.Equal(t, job.TaskEventRegistered, events[1].Type)
+		assert.Regexp(t, `^welcome-[0-9a-f]{8}$`, events[1].TaskID)
+		assert.Equal(t, "jobs/notifications/welcome.sh", events[1].ScriptPath)
+	})
+}
+
 func TestRunnerHonoursContextCancellationBetweenTaskCreators(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -184,26 +238,54 @@ func TestRunnerTaskEventHookProvidesAccuratePayloads(t *testing.T) {
 
 	require.Len(t, events, 2)
 
-	var successEvent, failureEvent *job.TaskEvent
+	var successEvent, skippedEvent *job.TaskEvent
 	for i := range events {
 		switch events[i].Type {
 		case job.TaskEventRegistered:
 			successEvent = &events[i]
-		case job.TaskEventRegistrationFailed:
-			failureEvent = &events[i]
+		case job.TaskEventSkipped:
+			skippedEvent = &events[i]
 		}
 	}
 
 	require.NotNil(t, successEvent)
-	require.NotNil(t, failureEvent)
+	require.NotNil(t, skippedEvent)
 
 	assert.Equal(t, "jobs/js/send_email.js", successEvent.TaskID)
 	assert.Equal(t, "jobs/js/send_email.js", successEvent.ScriptPath)
 	assert.NotNil(t, successEvent.Task)
 	assert.NoError(t, successEvent.Err)
+	assert.Equal(t, "engine:javascript", successEvent.Engine)
+	assert.Equal(t, job.DefaultSchedule, successEvent.Schedule)
+	assert.Len(t, successEvent.Checksum, 64)
+	assert.NotNil(t, successEvent.ConfigSummary)
+	assert.Positive(t, successEvent.ParseDuration)
+
+	assert.Equal(t, "jobs/unsupported/cleanup.txt", skippedEvent.TaskID)
+	assert.Equal(t, "jobs/unsupported/cleanup.txt", skippedEvent.ScriptPath)
+	assert.Nil(t, skippedEvent.Task)
+	assert.Error(t, skippedEvent.Err)
+	assert.Equal(t, job.SkipReasonNoEngine, skippedEvent.Reason)
+}
 
-	assert.Equal(t, "jobs/unsupported/cleanup.txt", failureEvent.TaskID)
-	assert.Equal(t, "jobs/unsupported/cleanup.txt", failureEvent.ScriptPath)
-	assert.Nil(t, failureEvent.Task)
-	assert.Error(t, failureEvent.Err)
+func TestRunnerStrictStartupAggregatesFailures(t *testing.T) {
+	creator := &stubTaskCreator{tasks: []job.Task{stubTask{id: "a"}, stubTask{id: "a"}}}
+	failing := &stubTaskCreator{err: errors.New("parse failed")}
+
+	runner := job.NewRunner(
+		job.WithStrictStartup(),
+		job.WithErrorHandler(func(job.Task, error) {}),
+		job.WithTaskCreator(creator),
+		job.WithTaskCreator(failing),
+	)
+	err := runner.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 task(s) failed discovery")
+	assert.Len(t, runner.RegisteredTasks(), 1)
+
+	lenient := job.NewRunner(
+		job.WithErrorHandler(func(job.Task, error) {}),
+		job.WithTaskCreator(failing),
+	)
+	require.NoError(t, lenient.Start(context.Background()))
 }
@@ -8,7 +8,8 @@ import (
 )
 
 // NextRun returns the next execution time for the provided cron expression using the
-// same parser configuration as the embedded scheduler utilities.
+// same parser configuration as the embedded scheduler utilities. Schedule macros
+// are expanded first (see ExpandSchedule).
 func NextRun(expression string, after time.Time, opts ...SchedulerOption) (time.Time, error) {
 	if expression == "" {
 		return time.Time{}, fmt.Errorf("cron expression cannot be empty")
@@ -21,6 +22,10 @@ func NextRun(expression string, after time.Time, opts ...SchedulerOption) (time.
 		}
 	}
 
+	expression, err := ExpandSchedule(expression)
+	if err != nil {
+		return time.Time{}, err
+	}
 	cronParser := schedulerCfg.parser()
 	schedule, err := cronParser.Parse(expression)
 	if err != nil {
@@ -29,7 +34,7 @@ func NextRun(expression string, after time.Time, opts ...SchedulerOption) (time.
 
 	base := after
 	if base.IsZero() {
-		base = time.Now().In(schedulerCfg.location())
+		base = clockOrSystem(schedulerCfg.clock).Now().In(schedulerCfg.location())
 	}
 
 	next := schedule.Next(base)
@@ -53,9 +58,17 @@ func WithLocation(loc *time.Location) SchedulerOption {
 	}
 }
 
+// WithSchedulerClock sets the clock NextRun reads when no base time is supplied.
+func WithSchedulerClock(clock Clock) SchedulerOption {
+	return func(c *schedulerConfig) {
+		c.clock = clock
+	}
+}
+
 type schedulerConfig struct {
 	useSeconds       bool
 	locationOverride *time.Location
+	clock            Clock
 }
 
 func (c *schedulerConfig) location() *time.Location {
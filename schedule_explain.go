@@ -0,0 +1,378 @@
+package job
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExplainSchedule describes a cron expression in words, for example
+// "0 7 * * 1-5" becomes "At 07:00 on weekdays". The time zone from a
+// CRON_TZ=/TZ= prefix or WithLocation is appended in parentheses. Invalid
+// expressions return the parser error.
+func ExplainSchedule(expression string, opts ...SchedulerOption) (string, error) {
+	cfg := &schedulerConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return "", fmt.Errorf("cron expression cannot be empty")
+	}
+	expression, err := ExpandSchedule(expression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := cfg.parser().Parse(expression); err != nil {
+		return "", fmt.Errorf("failed to parse cron expression %q: %w", expression, err)
+	}
+
+	zone := ""
+	if cfg.locationOverride != nil {
+		zone = cfg.locationOverride.String()
+	}
+	if strings.HasPrefix(expression, "CRON_TZ=") || strings.HasPrefix(expression, "TZ=") {
+		prefix, rest, _ := strings.Cut(expression, " ")
+		_, zone, _ = strings.Cut(prefix, "=")
+		expression = strings.TrimSpace(rest)
+	}
+
+	text := explainCron(expression, cfg.useSeconds)
+	if zone != "" && zone != "Local" {
+		text += " (" + zone + ")"
+	}
+	return text, nil
+}
+
+func explainCron(expression string, withSeconds bool) string {
+	switch expression {
+	case "@yearly", "@annually":
+		return "At 00:00 on day 1 of the month in January"
+	case "@monthly":
+		return "At 00:00 on day 1 of the month"
+	case "@weekly":
+		return "At 00:00 on Sunday"
+	case "@daily", "@midnight":
+		return "At 00:00 every day"
+	case "@hourly":
+		return "Every hour"
+	}
+	if every, ok := strings.CutPrefix(expression, "@every "); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(every)); err == nil {
+			return "Every " + d.String()
+		}
+		return "Every " + strings.TrimSpace(every)
+	}
+
+	fields := strings.Fields(expression)
+	seconds := "0"
+	if withSeconds {
+		seconds, fields = fields[0], fields[1:]
+	}
+	minute := parseCronField(fields[0], nil)
+	hour := parseCronField(fields[1], nil)
+	dom := parseCronField(fields[2], nil)
+	month := parseCronField(fields[3], cronMonthNames)
+	dow := parseCronField(fields[4], cronDayNames)
+
+	timeText, specific := explainCronTime(minute, hour)
+	if sec := parseCronField(seconds, nil); !sec.isSingle(0) {
+		secText := explainCronSeconds(sec)
+		if timeText == "Every minute" {
+			timeText = secText
+		} else {
+			timeText = secText + ", " + lowerFirst(timeText)
+		}
+		specific = false
+	}
+
+	parts := []string{timeText}
+	switch {
+	case !dom.star && !dow.star:
+		parts = append(parts, explainDayOfMonth(dom)+" or "+explainDayOfWeek(dow))
+	case !dom.star:
+		parts = append(parts, explainDayOfMonth(dom))
+	case !dow.star:
+		parts = append(parts, explainDayOfWeek(dow))
+	case specific && month.star:
+		parts = append(parts, "every day")
+	}
+	if !month.star {
+		parts = append(parts, explainMonth(month))
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronDayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+)
+
+// cronField is one parsed cron field. Each range has lo == hi for a single
+// value; a star range covers the whole field.
+type cronField struct {
+	star   bool
+	ranges []cronRange
+}
+
+type cronRange struct {
+	lo, hi, step int
+	star         bool
+}
+
+func parseCronField(raw string, names map[string]int) cronField {
+	if raw == "*" || raw == "?" {
+		return cronField{star: true}
+	}
+	var field cronField
+	for _, part := range strings.Split(raw, ",") {
+		r := cronRange{step: 1}
+		span, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			r.step, _ = strconv.Atoi(step)
+		}
+		if span == "*" || span == "?" {
+			r.star = true
+		} else {
+			lo, hi, isRange := strings.Cut(span, "-")
+			r.lo = cronValue(lo, names)
+			r.hi = r.lo
+			if isRange {
+				r.hi = cronValue(hi, names)
+			} else if hasStep {
+				r.hi = -1 // "a/n" runs from a to the end of the field
+			}
+		}
+		field.ranges = append(field.ranges, r)
+	}
+	return field
+}
+
+func cronValue(raw string, names map[string]int) int {
+	if v, ok := names[strings.ToUpper(raw)]; ok {
+		return v
+	}
+	v, _ := strconv.Atoi(raw)
+	return v
+}
+
+func (f cronField) isSingle(v int) bool {
+	n, ok := f.single()
+	return ok && n == v
+}
+
+func (f cronField) single() (int, bool) {
+	if f.star || len(f.ranges) != 1 {
+		return 0, false
+	}
+	r := f.ranges[0]
+	return r.lo, !r.star && r.lo == r.hi
+}
+
+// singles returns the values of a field made only of single values.
+func (f cronField) singles() ([]int, bool) {
+	if f.star {
+		return nil, false
+	}
+	values := make([]int, 0, len(f.ranges))
+	for _, r := range f.ranges {
+		if r.star || r.lo != r.hi {
+			return nil, false
+		}
+		values = append(values, r.lo)
+	}
+	return values, true
+}
+
+// everyStep returns n for a "*/n" field.
+func (f cronField) everyStep() (int, bool) {
+	if f.star || len(f.ranges) != 1 || !f.ranges[0].star {
+		return 0, false
+	}
+	return f.ranges[0].step, true
+}
+
+func (f cronField) describe(name func(int) string) string {
+	items := make([]string, 0, len(f.ranges))
+	for _, r := range f.ranges {
+		var item string
+		switch {
+		case r.star:
+			item = "every " + strconv.Itoa(r.step)
+		case r.hi == -1:
+			item = fmt.Sprintf("every %d from %s", r.step, name(r.lo))
+		case r.lo == r.hi:
+			item = name(r.lo)
+		default:
+			item = name(r.lo) + " through " + name(r.hi)
+			if r.step > 1 {
+				item = fmt.Sprintf("every %d from %s", r.step, item)
+			}
+		}
+		items = append(items, item)
+	}
+	return joinWords(items)
+}
+
+func explainCronTime(minute, hour cronField) (string, bool) {
+	m, minuteSingle := minute.single()
+	hours, hourSingles := hour.singles()
+
+	switch {
+	case minuteSingle && hourSingles:
+		times := make([]string, 0, len(hours))
+		for _, h := range hours {
+			times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+		}
+		return "At " + joinWords(times), true
+	case minute.star && hour.star:
+		return "Every minute", false
+	case minuteSingle && hour.star:
+		if m == 0 {
+			return "Every hour", false
+		}
+		return fmt.Sprintf("At minute %d past every hour", m), false
+	}
+
+	if n, ok := hour.everyStep(); ok && minuteSingle {
+		if m == 0 {
+			return fmt.Sprintf("Every %d hours", n), false
+		}
+		return fmt.Sprintf("At minute %d past every %d hours", m, n), false
+	}
+
+	var minuteText string
+	switch n, ok := minute.everyStep(); {
+	case minute.star:
+		minuteText = "Every minute"
+	case ok:
+		minuteText = fmt.Sprintf("Every %d minutes", n)
+	case minuteSingle && m == 0:
+		minuteText = "Every hour"
+	case minuteSingle:
+		minuteText = fmt.Sprintf("At minute %d past every hour", m)
+	case len(minute.ranges) == 1 && minute.ranges[0].step > 1 && minute.ranges[0].hi > minute.ranges[0].lo:
+		r := minute.ranges[0]
+		minuteText = fmt.Sprintf("Every %d minutes from minute %d through %d", r.step, r.lo, r.hi)
+	default:
+		minuteText = "At minutes " + minute.describe(strconv.Itoa)
+	}
+	if hour.star {
+		return minuteText, false
+	}
+	if len(hour.ranges) == 1 && !hour.ranges[0].star && hour.ranges[0].hi > hour.ranges[0].lo && hour.ranges[0].step == 1 {
+		last := 59
+		if minuteSingle {
+			last = m
+		}
+		r := hour.ranges[0]
+		return fmt.Sprintf("%s between %02d:00 and %02d:%02d", minuteText, r.lo, r.hi, last), false
+	}
+	return minuteText + " past hour " + hour.describe(strconv.Itoa), false
+}
+
+func explainCronSeconds(sec cronField) string {
+	if sec.star {
+		return "Every second"
+	}
+	if n, ok := sec.everyStep(); ok {
+		return fmt.Sprintf("Every %d seconds", n)
+	}
+	return "At second " + sec.describe(strconv.Itoa)
+}
+
+func explainDayOfMonth(dom cronField) string {
+	if n, ok := dom.everyStep(); ok {
+		return fmt.Sprintf("every %d days", n)
+	}
+	if values, ok := dom.singles(); ok && len(values) == 1 {
+		return fmt.Sprintf("on day %d of the month", values[0])
+	}
+	return "on days " + dom.describe(strconv.Itoa) + " of the month"
+}
+
+func explainDayOfWeek(dow cronField) string {
+	days := expandCronField(dow, 0, 6, func(v int) int { return v % 7 })
+	switch {
+	case equalInts(days, []int{1, 2, 3, 4, 5}):
+		return "on weekdays"
+	case equalInts(days, []int{0, 6}):
+		return "on weekends"
+	}
+	return "on " + dow.describe(func(v int) string { return time.Weekday(v % 7).String() })
+}
+
+func explainMonth(month cronField) string {
+	if n, ok := month.everyStep(); ok {
+		return fmt.Sprintf("every %d months", n)
+	}
+	name := func(v int) string { return time.Month(v).String() }
+	if len(month.ranges) == 1 && month.ranges[0].lo != month.ranges[0].hi && month.ranges[0].step == 1 && !month.ranges[0].star {
+		return "from " + month.describe(name)
+	}
+	return "in " + month.describe(name)
+}
+
+// expandCronField lists the distinct values a field matches, sorted.
+func expandCronField(f cronField, min, max int, normalize func(int) int) []int {
+	seen := map[int]struct{}{}
+	for _, r := range f.ranges {
+		lo, hi := r.lo, r.hi
+		if r.star {
+			lo, hi = min, max
+		}
+		if hi == -1 {
+			hi = max
+		}
+		step := r.step
+		if step <= 0 {
+			step = 1
+		}
+		for v := lo; v <= hi; v += step {
+			seen[normalize(v)] = struct{}{}
+		}
+	}
+	out := make([]int, 0, len(seen))
+	for v := range seen {
+		out = append(out, v)
+	}
+	sort.Ints(out)
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinWords(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,53 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainSchedule(t *testing.T) {
+	cases := map[string]string{
+		"0 7 * * 1-5":                        "At 07:00 on weekdays",
+		"30 9 * * MON,WED,FRI":               "At 09:30 on Monday, Wednesday and Friday",
+		"0 0 * * 0,6":                        "At 00:00 on weekends",
+		"* * * * *":                          "Every minute",
+		"*/15 9-17 * * 1-5":                  "Every 15 minutes between 09:00 and 17:59 on weekdays",
+		"0 */2 * * *":                        "Every 2 hours",
+		"5 * * * *":                          "At minute 5 past every hour",
+		"0 6,12,18 * * *":                    "At 06:00, 12:00 and 18:00 every day",
+		"0 0 1,15 * *":                       "At 00:00 on days 1 and 15 of the month",
+		"0 0 1 1-3 *":                        "At 00:00 on day 1 of the month from January through March",
+		"0 8 1 */3 *":                        "At 08:00 on day 1 of the month every 3 months",
+		"0 0 1 * 1":                          "At 00:00 on day 1 of the month or on Monday",
+		"@daily":                             "At 00:00 every day",
+		"@every 90s":                         "Every 1m30s",
+		"CRON_TZ=America/New_York 0 9 * * *": "At 09:00 every day (America/New_York)",
+	}
+	for expr, want := range cases {
+		got, err := ExplainSchedule(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, want, got, expr)
+	}
+}
+
+func TestExplainScheduleOptions(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	got, err := ExplainSchedule("0 7 * * 1-5", WithLocation(loc))
+	require.NoError(t, err)
+	assert.Equal(t, "At 07:00 on weekdays (Europe/Madrid)", got)
+
+	got, err = ExplainSchedule("*/10 * * * * *", WithSecondsPrecision())
+	require.NoError(t, err)
+	assert.Equal(t, "Every 10 seconds", got)
+}
+
+func TestExplainScheduleInvalid(t *testing.T) {
+	_, err := ExplainSchedule("61 * * * *")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "61 * * * *")
+}
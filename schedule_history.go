@@ -0,0 +1,255 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const defaultScheduleHistoryVersions = 20
+
+// ScheduleChange identifies what produced a ScheduleVersion.
+type ScheduleChange string
+
+const (
+	ScheduleChangeRegistered ScheduleChange = "registered"
+	ScheduleChangeUpdated    ScheduleChange = "updated"
+	ScheduleChangeDeleted    ScheduleChange = "deleted"
+	ScheduleChangeRolledBack ScheduleChange = "rolled_back"
+)
+
+// ScheduleVersion is one recorded revision of a schedule definition.
+type ScheduleVersion struct {
+	ScheduleID string         `json:"schedule_id"`
+	Version    int            `json:"version"`
+	Change     ScheduleChange `json:"change"`
+	// Definition is nil for deletions.
+	Definition *ScheduleDefinition `json:"definition,omitempty"`
+	Actor      *Actor              `json:"actor,omitempty"`
+	Scope      Scope               `json:"scope,omitempty"`
+	ChangedAt  time.Time           `json:"changed_at"`
+	// ChangedFields lists the top-level fields that differ from the previous
+	// version, e.g. "expression" or "message.parameters".
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	// RollbackOf is the version restored by a rollback.
+	RollbackOf int `json:"rollback_of,omitempty"`
+}
+
+// ScheduleHistory keeps a bounded list of versions per schedule so a bad
+// change (for example a faulty settings sync) can be rolled back.
+type ScheduleHistory struct {
+	mu          sync.RWMutex
+	maxVersions int
+	clock       Clock
+	versions    map[string][]ScheduleVersion
+	next        map[string]int
+}
+
+// NewScheduleHistory retains up to maxVersions versions per schedule;
+// non-positive values fall back to 20.
+func NewScheduleHistory(maxVersions int) *ScheduleHistory {
+	if maxVersions <= 0 {
+		maxVersions = defaultScheduleHistoryVersions
+	}
+	return &ScheduleHistory{
+		maxVersions: maxVersions,
+		clock:       SystemClock,
+		versions:    make(map[string][]ScheduleVersion),
+		next:        make(map[string]int),
+	}
+}
+
+// WithClock sets the clock stamping ChangedAt.
+func (h *ScheduleHistory) WithClock(clock Clock) *ScheduleHistory {
+	if clock != nil {
+		h.clock = clock
+	}
+	return h
+}
+
+// Versions returns the retained versions of a schedule, oldest first.
+func (h *ScheduleHistory) Versions(id string) []ScheduleVersion {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make([]ScheduleVersion, 0, len(h.versions[id]))
+	for _, version := range h.versions[id] {
+		out = append(out, cloneScheduleVersion(version))
+	}
+	return out
+}
+
+// Version returns a single retained version of a schedule.
+func (h *ScheduleHistory) Version(id string, version int) (ScheduleVersion, bool) {
+	if h == nil {
+		return ScheduleVersion{}, false
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, v := range h.versions[id] {
+		if v.Version == version {
+			return cloneScheduleVersion(v), true
+		}
+	}
+	return ScheduleVersion{}, false
+}
+
+func (h *ScheduleHistory) record(ctx context.Context, id string, change ScheduleChange, def *ScheduleDefinition) {
+	if h == nil {
+		return
+	}
+	entry := ScheduleVersion{
+		ScheduleID: id,
+		Change:     change,
+		ChangedAt:  h.clock.Now().UTC(),
+	}
+	if def != nil {
+		entry.Definition = auditScheduleSnapshot(*def)
+	}
+	if actor, scope, ok := ActorFromContext(ctx); ok {
+		entry.Actor = actor
+		entry.Scope = scope
+	}
+	if version, ok := rollbackFromContext(ctx); ok && change != ScheduleChangeDeleted {
+		entry.Change = ScheduleChangeRolledBack
+		entry.RollbackOf = version
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	versions := h.versions[id]
+	var prev *ScheduleDefinition
+	if len(versions) > 0 {
+		prev = versions[len(versions)-1].Definition
+	}
+	entry.ChangedFields = scheduleChangedFields(prev, entry.Definition)
+	h.next[id]++
+	entry.Version = h.next[id]
+
+	versions = append(versions, entry)
+	if len(versions) > h.maxVersions {
+		versions = versions[len(versions)-h.maxVersions:]
+	}
+	h.versions[id] = versions
+}
+
+// scheduleChangedFields compares two definitions field by field, descending
+// one level into the message.
+func scheduleChangedFields(prev, next *ScheduleDefinition) []string {
+	if prev == nil || next == nil {
+		return nil
+	}
+	var fields []string
+	if prev.Expression != next.Expression {
+		fields = append(fields, "expression")
+	}
+	if prev.Calendar != next.Calendar {
+		fields = append(fields, "calendar")
+	}
+	if prev.Owner != next.Owner {
+		fields = append(fields, "owner")
+	}
+	if prev.Team != next.Team {
+		fields = append(fields, "team")
+	}
+
+	before, after := messageFields(prev.Message), messageFields(next.Message)
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for key := range before {
+		keys[key] = struct{}{}
+	}
+	for key := range after {
+		keys[key] = struct{}{}
+	}
+	var changed []string
+	for key := range keys {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			changed = append(changed, "message."+key)
+		}
+	}
+	sort.Strings(changed)
+	return append(fields, changed...)
+}
+
+func messageFields(msg ExecutionMessage) map[string]any {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	fields := map[string]any{}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func cloneScheduleVersion(v ScheduleVersion) ScheduleVersion {
+	if v.Definition != nil {
+		v.Definition = auditScheduleSnapshot(*v.Definition)
+	}
+	v.Actor = v.Actor.clone()
+	v.Scope = v.Scope.clone()
+	v.ChangedFields = append([]string(nil), v.ChangedFields...)
+	return v
+}
+
+type rollbackKey struct{}
+
+func contextWithRollback(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, rollbackKey{}, version)
+}
+
+func rollbackFromContext(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	version, ok := ctx.Value(rollbackKey{}).(int)
+	return version, ok
+}
+
+// ErrScheduleVersionNotFound is returned when rolling back to a version the
+// history does not hold.
+var ErrScheduleVersionNotFound = errors.New("schedule version not found", errors.CategoryNotFound).
+	WithTextCode("JOB_SCHEDULE_VERSION_NOT_FOUND")
+
+// WithScheduleHistory records every registration, update and deletion so
+// schedules can be rolled back.
+func (m *CronManager) WithScheduleHistory(history *ScheduleHistory) *CronManager {
+	m.history = history
+	return m
+}
+
+// ScheduleHistory returns the recorded versions of a schedule, oldest first.
+func (m *CronManager) ScheduleHistory(id string) []ScheduleVersion {
+	return m.history.Versions(id)
+}
+
+// Rollback restores the definition recorded as version of schedule id,
+// re-registering the schedule if it was deleted since. The restore is
+// recorded as a new version with RollbackOf set.
+func (m *CronManager) Rollback(ctx context.Context, id string, version int) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	target, ok := m.history.Version(id, version)
+	if !ok || target.Definition == nil {
+		return errors.New(fmt.Sprintf("schedule %q has no restorable version %d", id, version), errors.CategoryNotFound).
+			WithTextCode(ErrScheduleVersionNotFound.TextCode).
+			WithMetadata(map[string]any{"schedule_id": id, "version": version})
+	}
+
+	ctx = contextWithRollback(ctx, version)
+	m.mu.RLock()
+	_, exists := m.schedules[id]
+	m.mu.RUnlock()
+	if exists {
+		return m.Update(ctx, *target.Definition)
+	}
+	return m.Register(ctx, *target.Definition)
+}
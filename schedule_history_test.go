@@ -0,0 +1,59 @@
+package job_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-errors"
+	"github.com/goliatone/go-job"
+	"github.com/goliatone/go-job/jobtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronManagerScheduleHistoryRollback(t *testing.T) {
+	clock := jobtest.NewFakeClock(jobtest.DefaultFakeClockStart)
+	registry := job.NewMemoryRegistry()
+	require.NoError(t, registry.Add(job.NewBaseTask("report", "jobs/report.js", "js", job.Config{}, "", noopEngine{})))
+	scheduler := jobtest.NewScheduler(clock)
+	manager := job.NewCronManager(registry, scheduler).
+		WithScheduleHistory(job.NewScheduleHistory(3).WithClock(clock))
+
+	ctx := job.ContextWithActor(context.Background(), &job.Actor{ID: "settings-sync"}, job.Scope{})
+	def := job.ScheduleDefinition{ID: "daily", Expression: "0 7 * * *", Message: job.ExecutionMessage{JobID: "report"}}
+	require.NoError(t, manager.Register(ctx, def))
+
+	clock.Advance(time.Hour)
+	def.Expression = "0 * * * *"
+	def.Message.Parameters = map[string]any{"region": "eu"}
+	require.NoError(t, manager.Update(ctx, def))
+
+	versions := manager.ScheduleHistory("daily")
+	require.Len(t, versions, 2)
+	assert.Equal(t, job.ScheduleChangeUpdated, versions[1].Change)
+	assert.Equal(t, "settings-sync", versions[1].Actor.ID)
+	assert.Equal(t, jobtest.DefaultFakeClockStart.Add(time.Hour), versions[1].ChangedAt)
+	assert.Equal(t, []string{"expression", "message.config", "message.parameters"}, versions[1].ChangedFields)
+
+	require.NoError(t, manager.Delete(ctx, "daily"))
+	require.NoError(t, manager.Rollback(context.Background(), "daily", 1))
+
+	schedules := manager.List()
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "0 7 * * *", schedules[0].Expression)
+	assert.Empty(t, schedules[0].Message.Parameters["region"])
+
+	versions = manager.ScheduleHistory("daily")
+	require.Len(t, versions, 3, "history is bounded")
+	last := versions[2]
+	assert.Equal(t, 4, last.Version)
+	assert.Equal(t, job.ScheduleChangeRolledBack, last.Change)
+	assert.Equal(t, 1, last.RollbackOf)
+
+	err := manager.Rollback(context.Background(), "daily", 1)
+	var target *errors.Error
+	require.True(t, stderrors.As(err, &target))
+	assert.Equal(t, "JOB_SCHEDULE_VERSION_NOT_FOUND", target.TextCode)
+}
@@ -0,0 +1,192 @@
+package job
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	defaultRemoteScheduleTimeout   = 30 * time.Second
+	defaultRemoteScheduleMaxBytes  = 4 << 20
+	defaultRemoteScheduleSignature = "X-Signature"
+)
+
+// SignatureVerifier checks a payload against the signature published with it.
+type SignatureVerifier func(payload []byte, signature string) error
+
+// HMACSignatureVerifier verifies hex or base64 HMAC-SHA256 signatures,
+// optionally prefixed with "sha256=".
+func HMACSignatureVerifier(key []byte) SignatureVerifier {
+	return func(payload []byte, signature string) error {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(payload)
+		if !hmac.Equal(mac.Sum(nil), decodeSignature(strings.TrimPrefix(signature, "sha256="))) {
+			return fmt.Errorf("hmac signature mismatch")
+		}
+		return nil
+	}
+}
+
+// Ed25519SignatureVerifier verifies hex or base64 Ed25519 signatures.
+func Ed25519SignatureVerifier(publicKey ed25519.PublicKey) SignatureVerifier {
+	return func(payload []byte, signature string) error {
+		if !ed25519.Verify(publicKey, payload, decodeSignature(signature)) {
+			return fmt.Errorf("ed25519 signature mismatch")
+		}
+		return nil
+	}
+}
+
+func decodeSignature(signature string) []byte {
+	signature = strings.TrimSpace(signature)
+	if raw, err := hex.DecodeString(signature); err == nil {
+		return raw
+	}
+	if raw, err := base64.StdEncoding.DecodeString(signature); err == nil {
+		return raw
+	}
+	return nil
+}
+
+// RemoteScheduleConfig configures a RemoteScheduleLoader.
+type RemoteScheduleConfig struct {
+	URL string
+	// Headers are sent with every request, e.g. Authorization.
+	Headers map[string]string
+	// Verifier, when set, rejects payloads whose SignatureHeader does not verify.
+	Verifier        SignatureVerifier
+	SignatureHeader string
+	// AllowInsecure permits plain http URLs, for local development only.
+	AllowInsecure bool
+	MaxBytes      int64
+	Client        *http.Client
+}
+
+// RemoteScheduleLoader fetches schedule definitions (JSON or YAML) from an
+// HTTPS endpoint so a fleet of runners converges on centrally published
+// schedules. Responses are cached by ETag: a 304 reuses the last payload.
+type RemoteScheduleLoader struct {
+	cfg RemoteScheduleConfig
+
+	mu   sync.Mutex
+	etag string
+	defs []ScheduleDefinition
+}
+
+// NewRemoteScheduleLoader validates cfg and returns a loader.
+func NewRemoteScheduleLoader(cfg RemoteScheduleConfig) (*RemoteScheduleLoader, error) {
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil || parsed.Host == "" {
+		return nil, errors.New("remote schedule URL is invalid", errors.CategoryBadInput).
+			WithTextCode("SCHEDULE_REMOTE_URL_INVALID").
+			WithMetadata(map[string]any{"url": cfg.URL})
+	}
+	if parsed.Scheme != "https" && !(cfg.AllowInsecure && parsed.Scheme == "http") {
+		return nil, errors.New("remote schedule URL must use https", errors.CategoryBadInput).
+			WithTextCode("SCHEDULE_REMOTE_URL_INVALID").
+			WithMetadata(map[string]any{"url": cfg.URL})
+	}
+	if cfg.SignatureHeader == "" {
+		cfg.SignatureHeader = defaultRemoteScheduleSignature
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultRemoteScheduleMaxBytes
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultRemoteScheduleTimeout}
+	}
+	return &RemoteScheduleLoader{cfg: cfg}, nil
+}
+
+// Loader adapts the remote loader to ScheduleLoader for NewScheduleSyncCommand.
+func (l *RemoteScheduleLoader) Loader() ScheduleLoader {
+	return l.Load
+}
+
+// Load fetches the definitions, returning the cached copy when the server
+// answers 304 Not Modified.
+func (l *RemoteScheduleLoader) Load(ctx context.Context) ([]ScheduleDefinition, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.cfg.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml;q=0.9")
+	for key, value := range l.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	l.mu.Lock()
+	etag, cached := l.etag, l.defs
+	l.mu.Unlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := l.cfg.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CategoryExternal, "failed to fetch remote schedules").
+			WithTextCode("SCHEDULE_REMOTE_UNAVAILABLE").
+			WithMetadata(map[string]any{"url": l.cfg.URL})
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return cloneScheduleDefinitions(cached), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("remote schedules returned status %d", resp.StatusCode), errors.CategoryExternal).
+			WithTextCode("SCHEDULE_REMOTE_STATUS").
+			WithMetadata(map[string]any{"url": l.cfg.URL, "status": resp.StatusCode})
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, l.cfg.MaxBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CategoryExternal, "failed to read remote schedules").
+			WithTextCode("SCHEDULE_REMOTE_UNAVAILABLE").
+			WithMetadata(map[string]any{"url": l.cfg.URL})
+	}
+	if int64(len(body)) > l.cfg.MaxBytes {
+		return nil, errors.New("remote schedules exceed size limit", errors.CategoryExternal).
+			WithTextCode("SCHEDULE_REMOTE_TOO_LARGE").
+			WithMetadata(map[string]any{"url": l.cfg.URL, "max_bytes": l.cfg.MaxBytes})
+	}
+
+	if l.cfg.Verifier != nil {
+		if err := l.cfg.Verifier(body, resp.Header.Get(l.cfg.SignatureHeader)); err != nil {
+			return nil, errors.Wrap(err, errors.CategoryAuth, "remote schedules signature verification failed").
+				WithTextCode("SCHEDULE_SIGNATURE_INVALID").
+				WithMetadata(map[string]any{"url": l.cfg.URL})
+		}
+	}
+
+	defs, err := parseScheduleDefinitions(body, l.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.etag = resp.Header.Get("ETag")
+	l.defs = defs
+	l.mu.Unlock()
+	return cloneScheduleDefinitions(defs), nil
+}
+
+func cloneScheduleDefinitions(defs []ScheduleDefinition) []ScheduleDefinition {
+	return append([]ScheduleDefinition(nil), defs...)
+}
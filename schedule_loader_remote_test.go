@@ -0,0 +1,86 @@
+package job_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteScheduleLoaderETagAndSignature(t *testing.T) {
+	key := []byte("fleet-key")
+	payload := []byte("- id: nightly\n  expression: \"0 2 * * *\"\n  message:\n    job_id: nightly\n")
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	var fetches, notModified atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Signature", signature)
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(payload)
+	}))
+	defer server.Close()
+
+	loader, err := job.NewRemoteScheduleLoader(job.RemoteScheduleConfig{
+		URL:      server.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Verifier: job.HMACSignatureVerifier(key),
+		Client:   server.Client(),
+	})
+	require.NoError(t, err)
+
+	defs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, "nightly", defs[0].ID)
+	assert.Equal(t, "0 2 * * *", defs[0].Expression)
+
+	defs, err = loader.Loader()(context.Background())
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, int32(2), fetches.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestRemoteScheduleLoaderRejectsBadSignature(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Signature", "deadbeef")
+		_, _ = w.Write([]byte(`[{"id":"a","expression":"@hourly"}]`))
+	}))
+	defer server.Close()
+
+	loader, err := job.NewRemoteScheduleLoader(job.RemoteScheduleConfig{
+		URL:      server.URL,
+		Verifier: job.HMACSignatureVerifier([]byte("key")),
+		Client:   server.Client(),
+	})
+	require.NoError(t, err)
+
+	_, err = loader.Load(context.Background())
+	assert.ErrorContains(t, err, "signature")
+}
+
+func TestRemoteScheduleLoaderRequiresHTTPS(t *testing.T) {
+	_, err := job.NewRemoteScheduleLoader(job.RemoteScheduleConfig{URL: "http://schedules.internal/jobs.json"})
+	assert.Error(t, err)
+
+	_, err = job.NewRemoteScheduleLoader(job.RemoteScheduleConfig{URL: "http://localhost/jobs.json", AllowInsecure: true})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,190 @@
+package job
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleMacro expands the arguments of a parameterized schedule macro into
+// a five-field cron expression.
+type scheduleMacro struct {
+	usage  string
+	args   int
+	expand func(args []string) (string, error)
+}
+
+var scheduleMacros = map[string]scheduleMacro{
+	"every_n_minutes": {"@every_n_minutes(N), N in 1-59", 1, func(args []string) (string, error) {
+		n, err := macroInt(args[0], "N", 1, 59)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s * * * *", stepField(n)), nil
+	}},
+	"every_n_hours": {"@every_n_hours(N), N in 1-23", 1, func(args []string) (string, error) {
+		n, err := macroInt(args[0], "N", 1, 23)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("0 %s * * *", stepField(n)), nil
+	}},
+	"hourly_at": {"@hourly_at(MM)", 1, func(args []string) (string, error) {
+		minute, err := macroInt(args[0], "minute", 0, 59)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d * * * *", minute), nil
+	}},
+	"daily_at": {"@daily_at(HH:MM)", 1, func(args []string) (string, error) {
+		hour, minute, err := macroClock(args[0])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d * * *", minute, hour), nil
+	}},
+	"weekdays_at": {"@weekdays_at(HH:MM)", 1, func(args []string) (string, error) {
+		hour, minute, err := macroClock(args[0])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d * * 1-5", minute, hour), nil
+	}},
+	"weekly_at": {"@weekly_at(DAY, HH:MM), DAY as mon-sun or 0-6", 2, func(args []string) (string, error) {
+		day, err := macroWeekday(args[0])
+		if err != nil {
+			return "", err
+		}
+		hour, minute, err := macroClock(args[1])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d * * %d", minute, hour, day), nil
+	}},
+	"monthly_at": {"@monthly_at(DAY, HH:MM), DAY in 1-31", 2, func(args []string) (string, error) {
+		day, err := macroInt(args[0], "day", 1, 31)
+		if err != nil {
+			return "", err
+		}
+		hour, minute, err := macroClock(args[1])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d %d * *", minute, hour, day), nil
+	}},
+}
+
+// ExpandSchedule expands a parameterized schedule macro into the cron
+// expression it stands for, keeping any CRON_TZ=/TZ= prefix:
+//
+//	@every_n_minutes(7)        */7 * * * *
+//	@every_n_hours(6)          0 */6 * * *
+//	@hourly_at(15)             15 * * * *
+//	@daily_at(03:30)           30 3 * * *
+//	@weekdays_at(07:00)        0 7 * * 1-5
+//	@weekly_at(mon, 09:00)     0 9 * * 1
+//	@monthly_at(1, 00:15)      15 0 1 * *
+//
+// Steps follow cron semantics, so @every_n_minutes(7) restarts at the top of
+// each hour; use "@every 7m" for a fixed interval. Expressions that are not
+// macros, including descriptors such as @daily, are returned unchanged.
+func ExpandSchedule(expression string) (string, error) {
+	trimmed := strings.TrimSpace(expression)
+	prefix := ""
+	if strings.HasPrefix(trimmed, "CRON_TZ=") || strings.HasPrefix(trimmed, "TZ=") {
+		zone, rest, _ := strings.Cut(trimmed, " ")
+		prefix, trimmed = zone+" ", strings.TrimSpace(rest)
+	}
+	if !strings.HasPrefix(trimmed, "@") || !strings.Contains(trimmed, "(") {
+		return expression, nil
+	}
+
+	name, rest, _ := strings.Cut(trimmed[1:], "(")
+	name = strings.TrimSpace(name)
+	rawArgs, ok := strings.CutSuffix(strings.TrimSpace(rest), ")")
+	if !ok {
+		return "", fmt.Errorf("invalid schedule macro %q: missing closing parenthesis", trimmed)
+	}
+	macro, ok := scheduleMacros[name]
+	if !ok {
+		return "", fmt.Errorf("unknown schedule macro @%s, expected one of %s", name, strings.Join(scheduleMacroNames(), ", "))
+	}
+
+	var args []string
+	for _, arg := range strings.Split(rawArgs, ",") {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	if len(args) != macro.args {
+		return "", fmt.Errorf("invalid schedule macro %q: expected %s", trimmed, macro.usage)
+	}
+	expanded, err := macro.expand(args)
+	if err != nil {
+		return "", fmt.Errorf("invalid schedule macro %q: %w (expected %s)", trimmed, err, macro.usage)
+	}
+	return prefix + expanded, nil
+}
+
+// expandScheduleOrKeep expands expression, leaving invalid macros for the
+// cron parser to reject.
+func expandScheduleOrKeep(expression string) string {
+	if expanded, err := ExpandSchedule(expression); err == nil {
+		return expanded
+	}
+	return expression
+}
+
+func scheduleMacroNames() []string {
+	names := make([]string, 0, len(scheduleMacros))
+	for name := range scheduleMacros {
+		names = append(names, "@"+name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stepField(n int) string {
+	if n == 1 {
+		return "*"
+	}
+	return "*/" + strconv.Itoa(n)
+}
+
+func macroInt(raw, name string, min, max int) (int, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s must be a number from %d to %d, got %q", name, min, max, raw)
+	}
+	return n, nil
+}
+
+func macroClock(raw string) (hour, minute int, err error) {
+	h, m, ok := strings.Cut(raw, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("time must be HH:MM, got %q", raw)
+	}
+	if hour, err = macroInt(h, "hour", 0, 23); err != nil {
+		return 0, 0, err
+	}
+	if minute, err = macroInt(m, "minute", 0, 59); err != nil {
+		return 0, 0, err
+	}
+	return hour, minute, nil
+}
+
+func macroWeekday(raw string) (int, error) {
+	if _, err := strconv.Atoi(raw); err == nil {
+		return macroInt(raw, "day", 0, 6)
+	}
+	name := strings.ToLower(raw)
+	for i := time.Sunday; i <= time.Saturday; i++ {
+		full := strings.ToLower(i.String())
+		if name == full || name == full[:3] {
+			return int(i), nil
+		}
+	}
+	return 0, fmt.Errorf("day must be mon-sun or 0-6, got %q", raw)
+}
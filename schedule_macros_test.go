@@ -0,0 +1,73 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSchedule(t *testing.T) {
+	cases := map[string]string{
+		"@every_n_minutes(7)":                    "*/7 * * * *",
+		"@every_n_minutes(1)":                    "* * * * *",
+		"@every_n_hours( 6 )":                    "0 */6 * * *",
+		"@hourly_at(15)":                         "15 * * * *",
+		"@daily_at(03:30)":                       "30 3 * * *",
+		"@daily_at(3:05)":                        "5 3 * * *",
+		"@weekdays_at(07:00)":                    "0 7 * * 1-5",
+		"@weekly_at(mon, 09:00)":                 "0 9 * * 1",
+		"@weekly_at(Sunday, 22:45)":              "45 22 * * 0",
+		"@monthly_at(1, 00:15)":                  "15 0 1 * *",
+		"CRON_TZ=Europe/Madrid @daily_at(06:00)": "CRON_TZ=Europe/Madrid 0 6 * * *",
+		"@daily":                                 "@daily",
+		"@every 7m":                              "@every 7m",
+		"*/5 * * * *":                            "*/5 * * * *",
+	}
+	for expr, want := range cases {
+		got, err := ExpandSchedule(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, want, got, expr)
+	}
+}
+
+func TestExpandScheduleInvalid(t *testing.T) {
+	cases := map[string]string{
+		"@every_n_minutes(0)":      "N must be a number from 1 to 59",
+		"@every_n_minutes(7":       "missing closing parenthesis",
+		"@daily_at(25:00)":         "hour must be a number from 0 to 23",
+		"@daily_at(0330)":          "time must be HH:MM",
+		"@weekly_at(09:00)":        "expected @weekly_at(DAY, HH:MM)",
+		"@weekly_at(someday, 9:0)": "day must be mon-sun or 0-6",
+		"@every_n_days(2)":         "unknown schedule macro @every_n_days",
+	}
+	for expr, want := range cases {
+		_, err := ExpandSchedule(expr)
+		require.Error(t, err, expr)
+		assert.Contains(t, err.Error(), want, expr)
+	}
+}
+
+func TestScheduleMacrosAcrossValidationLayers(t *testing.T) {
+	cfg, _, err := NewYAMLMetadataParser().Parse([]byte("# config\n# schedule: @daily_at(03:30)\n\necho hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "30 3 * * *", cfg.Schedule)
+
+	_, _, err = NewYAMLMetadataParser().Parse([]byte("# config\n# schedule: @daily_at(27:30)\n\necho hi"))
+	require.Error(t, err)
+
+	next, err := NextRun("@every_n_minutes(7)", time.Date(2026, 1, 1, 10, 57, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), next, "steps restart at the top of the hour")
+
+	text, err := ExplainSchedule("@weekdays_at(07:00)")
+	require.NoError(t, err)
+	assert.Equal(t, "At 07:00 on weekdays", text)
+
+	def := ScheduleDefinition{ID: "nightly", Expression: "@daily_at(3:70)", Message: ExecutionMessage{JobID: "job"}}
+	assert.ErrorContains(t, def.Validate(), "schedule validation failed")
+
+	task := NewBaseTask("job", "/tmp/job.sh", "shell", Config{Schedule: "@hourly_at(5)"}, "", nil)
+	assert.Equal(t, "5 * * * *", task.GetHandlerConfig().Expression)
+}
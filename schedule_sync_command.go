@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/goliatone/go-command"
 	"gopkg.in/yaml.v2"
@@ -23,9 +27,29 @@ type ScheduleSyncCommand struct {
 	cliGroup string
 	cliDesc  string
 	cronExpr string
+	logger   Logger
+
+	statsMu sync.Mutex
+	stats   ScheduleSyncStats
+}
+
+// ScheduleSyncStats counts reconcile cycles run by the sync command. The
+// Added, Updated and Removed totals accumulate across cycles.
+type ScheduleSyncStats struct {
+	Cycles     int64
+	Failures   int64
+	Added      int64
+	Updated    int64
+	Removed    int64
+	LastSyncAt time.Time
+	LastResult ReconcileResult
+	LastError  string
 }
 
-const defaultScheduleSyncCron = "*/5 * * * *"
+const (
+	defaultScheduleSyncCron     = "*/5 * * * *"
+	defaultScheduleSyncInterval = time.Minute
+)
 
 // NewScheduleSyncCommand wires a sync command implementing both CLICommand and CronCommand.
 func NewScheduleSyncCommand(manager *CronManager, loader ScheduleLoader, opts ...ScheduleSyncOption) *ScheduleSyncCommand {
@@ -35,6 +59,7 @@ func NewScheduleSyncCommand(manager *CronManager, loader ScheduleLoader, opts ..
 		cliName:  "sync-schedules",
 		cliDesc:  "Reconcile cron schedules from settings",
 		cronExpr: defaultScheduleSyncCron,
+		logger:   newStdLoggerProvider().GetLogger("job:schedule_sync"),
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -83,6 +108,15 @@ func WithScheduleSyncCLIDescription(desc string) ScheduleSyncOption {
 	}
 }
 
+// WithScheduleSyncLogger sets the logger used to report each sync cycle.
+func WithScheduleSyncLogger(logger Logger) ScheduleSyncOption {
+	return func(cmd *ScheduleSyncCommand) {
+		if logger != nil {
+			cmd.logger = logger
+		}
+	}
+}
+
 // CronHandler satisfies command.CronCommand to run periodic reconciliation.
 func (c *ScheduleSyncCommand) CronHandler() func() error {
 	return func() error {
@@ -110,6 +144,47 @@ func (c *ScheduleSyncCommand) CLIOptions() command.CLIConfig {
 	}
 }
 
+// Watch loads and reconciles immediately and then every interval until ctx
+// is done, logging the diff of each cycle. Failed cycles are logged and
+// counted in Stats without stopping the loop. It returns nil once ctx is
+// cancelled.
+func (c *ScheduleSyncCommand) Watch(ctx context.Context, interval time.Duration) error {
+	return c.watch(ctx, interval, c.loader)
+}
+
+// Stats returns a snapshot of the sync cycle counters.
+func (c *ScheduleSyncCommand) Stats() ScheduleSyncStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	return c.stats
+}
+
+func (c *ScheduleSyncCommand) watch(ctx context.Context, interval time.Duration, loader ScheduleLoader) error {
+	if c.manager == nil {
+		return fmt.Errorf("schedule manager not configured")
+	}
+	if loader == nil {
+		return fmt.Errorf("schedule loader not configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if interval <= 0 {
+		interval = defaultScheduleSyncInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = c.reconcile(ctx, loader)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 func (c *ScheduleSyncCommand) sync(ctx context.Context) (ReconcileResult, error) {
 	if c.manager == nil {
 		return ReconcileResult{}, fmt.Errorf("schedule manager not configured")
@@ -117,18 +192,50 @@ func (c *ScheduleSyncCommand) sync(ctx context.Context) (ReconcileResult, error)
 	if c.loader == nil {
 		return ReconcileResult{}, fmt.Errorf("schedule loader not configured")
 	}
+	return c.reconcile(ctx, c.loader)
+}
+
+func (c *ScheduleSyncCommand) reconcile(ctx context.Context, loader ScheduleLoader) (ReconcileResult, error) {
+	defs, err := loader(ctx)
+	var result ReconcileResult
+	if err == nil {
+		result, err = c.manager.Reconcile(ctx, defs)
+	}
+	c.record(result, err)
+	return result, err
+}
 
-	defs, err := c.loader(ctx)
+func (c *ScheduleSyncCommand) record(result ReconcileResult, err error) {
+	c.statsMu.Lock()
+	c.stats.Cycles++
+	c.stats.Added += int64(len(result.Added))
+	c.stats.Updated += int64(len(result.Updated))
+	c.stats.Removed += int64(len(result.Removed))
+	c.stats.LastSyncAt = time.Now()
+	c.stats.LastResult = result
+	c.stats.LastError = ""
 	if err != nil {
-		return ReconcileResult{}, err
+		c.stats.Failures++
+		c.stats.LastError = err.Error()
+	}
+	c.statsMu.Unlock()
+
+	switch {
+	case err != nil:
+		c.logger.Error("schedule sync failed", "error", err)
+	case len(result.Added)+len(result.Updated)+len(result.Removed) > 0:
+		c.logger.Info("schedule sync applied changes", "added", result.Added, "updated", result.Updated, "removed", result.Removed)
+	default:
+		c.logger.Debug("schedule sync: no changes")
 	}
-	return c.manager.Reconcile(ctx, defs)
 }
 
 type scheduleSyncCLI struct {
 	cmd *ScheduleSyncCommand
 
-	From string `kong:"name='from',help='Path to JSON or YAML schedule definitions from settings'"`
+	From     string        `kong:"name='from',help='Path or https URL of JSON or YAML schedule definitions'"`
+	Watch    bool          `kong:"name='watch',help='Keep reconciling every interval until interrupted'"`
+	Interval time.Duration `kong:"name='interval',default='1m',help='Reconcile interval in watch mode'"`
 }
 
 // Run executes the reconciliation from CLI.
@@ -138,33 +245,56 @@ func (c *scheduleSyncCLI) Run() error {
 	}
 
 	ctx := context.Background()
+	loader := c.cmd.loader
 	if strings.TrimSpace(c.From) != "" {
-		defs, err := loadSchedulesFromFile(c.From)
-		if err != nil {
-			return err
-		}
-		_, err = c.cmd.manager.Reconcile(ctx, defs)
-		return err
+		loader = c.load
 	}
 
-	_, err := c.cmd.sync(ctx)
+	if c.Watch {
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.cmd.watch(ctx, c.Interval, loader)
+	}
+
+	if loader == nil {
+		return fmt.Errorf("schedule loader not configured")
+	}
+	if c.cmd.manager == nil {
+		return fmt.Errorf("schedule manager not configured")
+	}
+	_, err := c.cmd.reconcile(ctx, loader)
 	return err
 }
 
+func (c *scheduleSyncCLI) load(ctx context.Context) ([]ScheduleDefinition, error) {
+	if strings.HasPrefix(c.From, "https://") {
+		loader, err := NewRemoteScheduleLoader(RemoteScheduleConfig{URL: c.From})
+		if err != nil {
+			return nil, err
+		}
+		return loader.Load(ctx)
+	}
+	return loadSchedulesFromFile(c.From)
+}
+
 func loadSchedulesFromFile(path string) ([]ScheduleDefinition, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read schedules file: %w", err)
 	}
+	return parseScheduleDefinitions(content, path)
+}
 
+func parseScheduleDefinitions(content []byte, source string) ([]ScheduleDefinition, error) {
 	var defs []ScheduleDefinition
 	if jsonErr := json.Unmarshal(content, &defs); jsonErr == nil {
 		return defs, nil
 	}
 
+	defs = nil
 	if yamlErr := yaml.Unmarshal(content, &defs); yamlErr == nil {
 		return defs, nil
 	}
 
-	return nil, fmt.Errorf("failed to parse schedules file %s as JSON or YAML", path)
+	return nil, fmt.Errorf("failed to parse schedules %s as JSON or YAML", source)
 }
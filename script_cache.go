@@ -0,0 +1,77 @@
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// ScriptCache keeps parsed script bodies keyed by path and the checksum of
+// the raw file content, so executions that do not carry the script inline
+// skip re-parsing and, when the message carries the checksum, re-fetching.
+// Only the latest checksum per path is retained.
+type ScriptCache struct {
+	mu      sync.RWMutex
+	entries map[string]scriptCacheEntry
+}
+
+type scriptCacheEntry struct {
+	checksum string
+	content  string
+}
+
+// NewScriptCache returns an empty cache.
+func NewScriptCache() *ScriptCache {
+	return &ScriptCache{entries: make(map[string]scriptCacheEntry)}
+}
+
+// ScriptChecksum returns the hex sha256 of raw script content, the key used
+// by ScriptCache and ExecutionMessage.ScriptChecksum.
+func ScriptChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached script body for path when it was stored under checksum.
+func (c *ScriptCache) Get(path, checksum string) (string, bool) {
+	if c == nil || checksum == "" {
+		return "", false
+	}
+	c.mu.RLock()
+	entry, ok := c.entries[path]
+	c.mu.RUnlock()
+	if !ok || entry.checksum != checksum {
+		return "", false
+	}
+	return entry.content, true
+}
+
+// Put stores the script body for path, replacing any previous checksum.
+func (c *ScriptCache) Put(path, checksum, content string) {
+	if c == nil || checksum == "" {
+		return
+	}
+	c.mu.Lock()
+	c.entries[path] = scriptCacheEntry{checksum: checksum, content: content}
+	c.mu.Unlock()
+}
+
+// Invalidate drops the cached entry for path.
+func (c *ScriptCache) Invalidate(path string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, path)
+	c.mu.Unlock()
+}
+
+// Len reports the number of cached scripts.
+func (c *ScriptCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
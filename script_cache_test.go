@@ -0,0 +1,89 @@
+package job_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingSourceProvider struct {
+	content []byte
+	reads   atomic.Int32
+}
+
+func (p *countingSourceProvider) GetScript(string) ([]byte, error) {
+	p.reads.Add(1)
+	return p.content, nil
+}
+
+func (p *countingSourceProvider) ListScripts(context.Context) ([]job.ScriptInfo, error) {
+	return nil, nil
+}
+
+func TestGetScriptContentServesCachedScriptByChecksum(t *testing.T) {
+	raw := []byte("# config\n# retries: 1\necho cached\n")
+	provider := &countingSourceProvider{content: raw}
+
+	engine := job.NewShellRunner()
+	engine.SourceProvider = provider
+
+	task, err := engine.ParseJob("jobs/cached.sh", raw)
+	require.NoError(t, err)
+
+	msg, err := job.CompleteExecutionMessage(task, nil)
+	require.NoError(t, err)
+	assert.Equal(t, job.ScriptChecksum(raw), msg.ScriptChecksum)
+
+	delete(msg.Parameters, "script")
+	content, err := engine.GetScriptContent(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "echo cached\n", content)
+	assert.Zero(t, provider.reads.Load(), "checksum hit should not read the provider")
+}
+
+func TestGetScriptContentRefetchesOnChecksumMismatch(t *testing.T) {
+	raw := []byte("# config\n# retries: 1\necho v1\n")
+	provider := &countingSourceProvider{content: []byte("# config\n# retries: 1\necho v2\n")}
+
+	engine := job.NewShellRunner()
+	engine.SourceProvider = provider
+
+	_, err := engine.ParseJob("jobs/versioned.sh", raw)
+	require.NoError(t, err)
+
+	msg := &job.ExecutionMessage{JobID: "versioned", ScriptPath: "jobs/versioned.sh", ScriptChecksum: "stale"}
+	content, err := engine.GetScriptContent(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "echo v2\n", content)
+	assert.EqualValues(t, 1, provider.reads.Load())
+
+	msg.ScriptChecksum = job.ScriptChecksum(provider.content)
+	content, err = engine.GetScriptContent(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "echo v2\n", content)
+	assert.EqualValues(t, 1, provider.reads.Load(), "refetched content should be cached under its checksum")
+}
+
+func TestGetScriptContentWithoutCache(t *testing.T) {
+	raw := []byte("echo uncached\n")
+	provider := &countingSourceProvider{content: raw}
+
+	engine := job.NewShellRunner()
+	engine.SourceProvider = provider
+	engine.SetScriptCache(nil)
+
+	_, err := engine.ParseJob("jobs/uncached.sh", raw)
+	require.NoError(t, err)
+
+	msg := &job.ExecutionMessage{JobID: "uncached", ScriptPath: "jobs/uncached.sh", ScriptChecksum: job.ScriptChecksum(raw)}
+	for i := 0; i < 2; i++ {
+		content, err := engine.GetScriptContent(msg)
+		require.NoError(t, err)
+		assert.Equal(t, "echo uncached\n", content)
+	}
+	assert.EqualValues(t, 2, provider.reads.Load())
+}
@@ -0,0 +1,143 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// scriptProcess describes how an engine runs a script in a child process.
+type scriptProcess struct {
+	// kind names the engine in log messages, output labels and error
+	// metadata, e.g. "shell" or "python".
+	kind        string
+	textCode    string
+	command     string
+	args        []string
+	workDir     string
+	environment []string
+}
+
+// runProcess runs the script of msg as described by p, injecting the job
+// environment and logging its output line by line.
+func (e *BaseEngine) runProcess(ctx context.Context, msg *ExecutionMessage, p scriptProcess) error {
+	execCtx, cancel := e.GetMessageExecutionContext(ctx, msg)
+	defer cancel()
+
+	logger := e.executionLogger(ctx, msg)
+
+	env, err := e.resolveEnv(execCtx, msg)
+	if err != nil {
+		logger.Error(p.kind+" secrets resolution failed", "script_path", msg.ScriptPath, "error", err)
+		return err
+	}
+
+	cmd := exec.CommandContext(execCtx, p.command, p.args...)
+
+	if p.workDir != "" {
+		cmd.Dir = p.workDir
+	}
+
+	// NOTE: Use this if you know what you are doing :)
+	if use, ok := msg.Config.Metadata["use_env"].(bool); ok && use {
+		cmd.Env = os.Environ()
+	}
+
+	if p.environment != nil {
+		cmd.Env = append(cmd.Env, p.environment...)
+	}
+
+	if env != nil {
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	if msg.TraceID != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", TraceIDEnvVar, msg.TraceID))
+	}
+
+	if msg.Attempt > 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d", AttemptEnvVar, msg.Attempt))
+	}
+
+	if dir, ok := WorkspaceFromContext(ctx); ok {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", WorkspaceEnvVar, dir))
+	}
+
+	if e.invoker != nil {
+		socket, stopSocket, err := serveControlSocket(execCtx, e.invoker)
+		if err != nil {
+			logger.Error(p.kind+" control socket failed", "script_path", msg.ScriptPath, "error", err)
+			return err
+		}
+		defer stopSocket()
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", ControlSocketEnvVar, socket))
+	}
+
+	heartbeatFile, stopHeartbeat := watchHeartbeatFile(execCtx)
+	defer stopHeartbeat()
+	if heartbeatFile != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", HeartbeatFileEnvVar, heartbeatFile))
+	}
+
+	resultPath, removeResultFile := resultFile(ctx)
+	defer removeResultFile()
+	if resultPath != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", ResultFileEnvVar, resultPath))
+	}
+
+	var stdout, stderr bytes.Buffer
+	stdoutLines := newLineLogger(logger.Info, p.kind+" output", "stdout")
+	stderrLines := newLineLogger(logger.Warn, p.kind+" output", "stderr")
+	cmd.Stdout = io.MultiWriter(&stdout, stdoutLines)
+	cmd.Stderr = io.MultiWriter(&stderr, stderrLines)
+
+	logger.Debug(p.kind+" command starting", "script_path", msg.ScriptPath)
+	start := time.Now()
+
+	err = cmd.Run()
+	stdoutLines.Flush()
+	stderrLines.Flush()
+	reportScriptResult(ctx, logger, stdout.String(), resultPath)
+	if err != nil {
+		duration := time.Since(start)
+		logger.Error(p.kind+" command failed", "script_path", msg.ScriptPath, "duration", duration, "exit_code", getExitCode(err), "stderr", summarizeOutput(stderr.String()))
+		return errors.Wrap(err, errors.CategoryExternal, "script execution failed").
+			WithTextCode(p.textCode).
+			WithMetadata(p.errorMetadata(msg, stdout.String(), stderr.String(), duration, getExitCode(err)))
+	}
+
+	duration := time.Since(start)
+	stdoutSummary := summarizeOutput(stdout.String())
+	stderrSummary := summarizeOutput(stderr.String())
+
+	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 0 {
+		logger.Warn(p.kind+" command exited with non-zero status", "script_path", msg.ScriptPath, "duration", duration, "exit_code", exitCode, "stdout", stdoutSummary, "stderr", stderrSummary)
+		return errors.New("script exited with non-zero status", errors.CategoryExternal).
+			WithTextCode(p.textCode).
+			WithMetadata(p.errorMetadata(msg, stdout.String(), stderr.String(), duration, exitCode))
+	}
+
+	logger.Info(p.kind+" command completed", "script_path", msg.ScriptPath, "duration", duration, "stdout", stdoutSummary, "stderr", stderrSummary)
+	return nil
+}
+
+func (p scriptProcess) errorMetadata(msg *ExecutionMessage, stdout, stderr string, duration time.Duration, exitCode int) map[string]any {
+	return map[string]any{
+		"operation":   "execute_command",
+		"script_path": msg.ScriptPath,
+		p.kind:        p.command,
+		"working_dir": p.workDir,
+		"stdout":      stdout,
+		"stderr":      stderr,
+		"duration":    duration,
+		"exit_code":   exitCode,
+	}
+}
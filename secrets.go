@@ -0,0 +1,241 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// Secret is a value returned by a SecretsProvider. Lease fields are set by
+// providers issuing expiring credentials (e.g. Vault dynamic secrets).
+type Secret struct {
+	Value         string
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+// SecretsProvider resolves secret references such as the NAME in `${secret:NAME}`.
+type SecretsProvider interface {
+	GetSecret(ctx context.Context, ref string) (Secret, error)
+}
+
+// SecretRenewer is implemented by providers able to extend a secret's lease.
+type SecretRenewer interface {
+	RenewSecret(ctx context.Context, secret Secret) (Secret, error)
+}
+
+// SecretsAware engines and task creators accept a SecretsProvider.
+type SecretsAware interface {
+	SetSecretsProvider(SecretsProvider)
+}
+
+// SecretsProviderFunc adapts a function to SecretsProvider.
+type SecretsProviderFunc func(ctx context.Context, ref string) (Secret, error)
+
+// GetSecret implements SecretsProvider.
+func (f SecretsProviderFunc) GetSecret(ctx context.Context, ref string) (Secret, error) {
+	return f(ctx, ref)
+}
+
+var secretRefPattern = regexp.MustCompile(`\$\{secret:([^}]+)\}`)
+
+func secretNotFound(ref, source string) error {
+	return errors.New(fmt.Sprintf("secret %q not found", ref), errors.CategoryNotFound).
+		WithTextCode("SECRET_NOT_FOUND").
+		WithMetadata(map[string]any{"ref": ref, "source": source})
+}
+
+// IsSecretNotFound reports whether err signals a missing secret.
+func IsSecretNotFound(err error) bool {
+	var target *errors.Error
+	return stderrors.As(err, &target) && target.TextCode == "SECRET_NOT_FOUND"
+}
+
+// ResolveSecretRefs replaces every `${secret:NAME}` in value using provider.
+// Values without references are returned unchanged, even when provider is nil.
+func ResolveSecretRefs(ctx context.Context, provider SecretsProvider, value string) (string, error) {
+	if !strings.Contains(value, "${secret:") {
+		return value, nil
+	}
+	if provider == nil {
+		return "", errors.New("secret reference found but no secrets provider configured", errors.CategoryBadInput).
+			WithTextCode("SECRETS_PROVIDER_MISSING")
+	}
+
+	var resolveErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		ref := strings.TrimSpace(secretRefPattern.FindStringSubmatch(match)[1])
+		secret, err := provider.GetSecret(ctx, ref)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return secret.Value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// resolveSecretEnv returns a copy of env with secret references resolved.
+func resolveSecretEnv(ctx context.Context, provider SecretsProvider, env map[string]string) (map[string]string, error) {
+	if len(env) == 0 {
+		return env, nil
+	}
+	out := make(map[string]string, len(env))
+	for key, value := range env {
+		resolved, err := ResolveSecretRefs(ctx, provider, value)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		out[key] = resolved
+	}
+	return out, nil
+}
+
+// EnvSecretsProvider reads secrets from environment variables, optionally
+// prefixed. The name is tried as-is and then upper-cased, so prefix
+// "JOB_SECRET_" maps `${secret:db_password}` to JOB_SECRET_DB_PASSWORD.
+type EnvSecretsProvider struct {
+	Prefix string
+	lookup func(string) (string, bool)
+}
+
+// NewEnvSecretsProvider returns an environment backed provider.
+func NewEnvSecretsProvider(prefix string) *EnvSecretsProvider {
+	return &EnvSecretsProvider{Prefix: prefix, lookup: os.LookupEnv}
+}
+
+// GetSecret implements SecretsProvider.
+func (p *EnvSecretsProvider) GetSecret(_ context.Context, ref string) (Secret, error) {
+	name := p.Prefix + ref
+	value, ok := p.lookup(name)
+	if !ok {
+		value, ok = p.lookup(strings.ToUpper(name))
+	}
+	if !ok {
+		return Secret{}, secretNotFound(ref, "env")
+	}
+	return Secret{Value: value}, nil
+}
+
+// FileSecretsProvider reads one secret per file under Dir, as mounted by
+// Docker and Kubernetes (e.g. /run/secrets). Trailing newlines are trimmed.
+type FileSecretsProvider struct {
+	Dir string
+}
+
+// NewFileSecretsProvider returns a provider reading secrets from dir.
+func NewFileSecretsProvider(dir string) *FileSecretsProvider {
+	return &FileSecretsProvider{Dir: dir}
+}
+
+// GetSecret implements SecretsProvider.
+func (p *FileSecretsProvider) GetSecret(_ context.Context, ref string) (Secret, error) {
+	clean := filepath.Clean("/" + ref)
+	data, err := os.ReadFile(filepath.Join(p.Dir, clean))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Secret{}, secretNotFound(ref, "file")
+		}
+		return Secret{}, errors.Wrap(err, errors.CategoryExternal, "failed to read secret file").
+			WithTextCode("SECRET_READ_FAILED").
+			WithMetadata(map[string]any{"ref": ref})
+	}
+	return Secret{Value: strings.TrimRight(string(data), "\r\n")}, nil
+}
+
+// CachedSecretsProvider caches secrets for a TTL. Leased secrets are refreshed
+// after two thirds of their lease; renewable leases are renewed through the
+// wrapped provider when it implements SecretRenewer, otherwise re-fetched.
+type CachedSecretsProvider struct {
+	provider SecretsProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	secret    Secret
+	refreshAt time.Time
+}
+
+// NewCachedSecretsProvider wraps provider with a cache holding values for ttl.
+func NewCachedSecretsProvider(provider SecretsProvider, ttl time.Duration) *CachedSecretsProvider {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &CachedSecretsProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]cachedSecret),
+	}
+}
+
+// GetSecret implements SecretsProvider.
+func (c *CachedSecretsProvider) GetSecret(ctx context.Context, ref string) (Secret, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[ref]
+	c.mu.Unlock()
+	if ok && now.Before(entry.refreshAt) {
+		return entry.secret, nil
+	}
+
+	var (
+		secret Secret
+		err    error
+	)
+	renewer, canRenew := c.provider.(SecretRenewer)
+	if ok && entry.secret.Renewable && canRenew {
+		secret, err = renewer.RenewSecret(ctx, entry.secret)
+	}
+	if !ok || !entry.secret.Renewable || !canRenew || err != nil {
+		secret, err = c.provider.GetSecret(ctx, ref)
+	}
+	if err != nil {
+		return Secret{}, err
+	}
+
+	c.mu.Lock()
+	c.entries[ref] = cachedSecret{secret: secret, refreshAt: now.Add(c.lifetime(secret))}
+	c.mu.Unlock()
+	return secret, nil
+}
+
+// Invalidate drops ref from the cache, or every entry when ref is empty.
+func (c *CachedSecretsProvider) Invalidate(ref string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ref == "" {
+		c.entries = make(map[string]cachedSecret)
+		return
+	}
+	delete(c.entries, ref)
+}
+
+func (c *CachedSecretsProvider) lifetime(secret Secret) time.Duration {
+	if secret.LeaseDuration > 0 {
+		if lease := secret.LeaseDuration * 2 / 3; lease < c.ttl {
+			return lease
+		}
+	}
+	return c.ttl
+}
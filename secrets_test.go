@@ -0,0 +1,151 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecretRefs(t *testing.T) {
+	t.Setenv("JOB_SECRET_DB_PASSWORD", "s3cret")
+	provider := job.NewEnvSecretsProvider("JOB_SECRET_")
+
+	out, err := job.ResolveSecretRefs(context.Background(), provider, "postgres://app:${secret:db_password}@db/app")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://app:s3cret@db/app", out)
+
+	_, err = job.ResolveSecretRefs(context.Background(), provider, "${secret:missing}")
+	require.Error(t, err)
+	assert.True(t, job.IsSecretNotFound(err))
+
+	plain, err := job.ResolveSecretRefs(context.Background(), nil, "no refs")
+	require.NoError(t, err)
+	assert.Equal(t, "no refs", plain)
+}
+
+func TestFileSecretsProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api_token"), []byte("tok\n"), 0o600))
+
+	provider := job.NewFileSecretsProvider(dir)
+	secret, err := provider.GetSecret(context.Background(), "api_token")
+	require.NoError(t, err)
+	assert.Equal(t, "tok", secret.Value)
+
+	_, err = provider.GetSecret(context.Background(), "../../etc/passwd")
+	assert.True(t, job.IsSecretNotFound(err))
+}
+
+func TestCachedSecretsProviderRenewsLeases(t *testing.T) {
+	var fetches, renewals int32
+	inner := &renewingProvider{
+		get: func(ref string) job.Secret {
+			atomic.AddInt32(&fetches, 1)
+			return job.Secret{Value: "v", LeaseID: "lease-1", LeaseDuration: 30 * time.Millisecond, Renewable: true}
+		},
+		renew: func(secret job.Secret) job.Secret {
+			atomic.AddInt32(&renewals, 1)
+			return secret
+		},
+	}
+	cache := job.NewCachedSecretsProvider(inner, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.GetSecret(context.Background(), "db")
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+
+	time.Sleep(25 * time.Millisecond)
+	_, err := cache.GetSecret(context.Background(), "db")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&renewals))
+}
+
+type renewingProvider struct {
+	get   func(string) job.Secret
+	renew func(job.Secret) job.Secret
+}
+
+func (p *renewingProvider) GetSecret(_ context.Context, ref string) (job.Secret, error) {
+	return p.get(ref), nil
+}
+
+func (p *renewingProvider) RenewSecret(_ context.Context, secret job.Secret) (job.Secret, error) {
+	return p.renew(secret), nil
+}
+
+func TestVaultSecretsProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "root-token", r.Header.Get("X-Vault-Token"))
+		switch r.URL.Path {
+		case "/v1/secret/data/app/db":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{"data": map[string]any{"password": "pw", "value": "default"}},
+			})
+		case "/v1/sys/leases/renew":
+			_ = json.NewEncoder(w).Encode(map[string]any{"lease_id": "l1", "lease_duration": 60, "renewable": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := job.NewVaultSecretsProvider(job.VaultConfig{Address: server.URL, Token: "root-token"})
+
+	secret, err := provider.GetSecret(context.Background(), "app/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "pw", secret.Value)
+
+	secret, err = provider.GetSecret(context.Background(), "app/db")
+	require.NoError(t, err)
+	assert.Equal(t, "default", secret.Value)
+
+	_, err = provider.GetSecret(context.Background(), "app/missing")
+	assert.True(t, job.IsSecretNotFound(err))
+
+	renewed, err := provider.RenewSecret(context.Background(), job.Secret{LeaseID: "l1", LeaseDuration: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, renewed.LeaseDuration)
+	assert.True(t, renewed.Renewable)
+}
+
+func TestEnginesResolveSecrets(t *testing.T) {
+	secrets := job.SecretsProviderFunc(func(_ context.Context, ref string) (job.Secret, error) {
+		return job.Secret{Value: "value-of-" + ref}, nil
+	})
+	store := job.NewRunLogStore(32, 4)
+
+	shellEngine := job.NewShellRunner()
+	shellEngine.SetSecretsProvider(secrets)
+	shell := job.NewBaseTask("sh-task", "out.sh", "shell",
+		job.Config{Env: map[string]string{"TOKEN": "${secret:api}"}}, "", shellEngine)
+	ctx := job.ContextWithRunID(context.Background(), "run-sh")
+	require.NoError(t, job.NewTaskCommander(shell).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": `echo "$TOKEN"`},
+	}))
+	entries, _ := store.Logs("run-sh")
+	assert.Equal(t, []string{"value-of-api"}, scriptLines(entries, "shell output"))
+
+	jsEngine := job.NewJSRunner()
+	jsEngine.SetSecretsProvider(secrets)
+	js := job.NewBaseTask("js-task", "out.js", "js", job.Config{}, "", jsEngine)
+	ctx = job.ContextWithRunID(context.Background(), "run-js")
+	require.NoError(t, job.NewTaskCommander(js).WithRunLogStore(store).Execute(ctx, &job.ExecutionMessage{
+		Parameters: map[string]any{"script": `console.log(job.secret("api"))`},
+	}))
+	entries, _ = store.Logs("run-js")
+	assert.Equal(t, []string{"value-of-api"}, scriptLines(entries, "js console"))
+}
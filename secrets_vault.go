@@ -0,0 +1,180 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+const (
+	defaultVaultTimeout = 10 * time.Second
+	defaultVaultMount   = "secret"
+	defaultVaultField   = "value"
+)
+
+// VaultConfig configures a VaultSecretsProvider.
+type VaultConfig struct {
+	Address   string
+	Token     string
+	Namespace string
+	// Mount is the secrets engine mount, "secret" by default.
+	Mount string
+	// KVVersion selects the KV engine layout: 2 (default) reads
+	// /v1/<mount>/data/<path>; 1 reads /v1/<mount>/<path>, which also suits
+	// dynamic engines such as database/creds.
+	KVVersion int
+	Client    *http.Client
+}
+
+// VaultSecretsProvider reads secrets from HashiCorp Vault over its HTTP API.
+// References take the form "path#field"; the field defaults to "value".
+type VaultSecretsProvider struct {
+	cfg VaultConfig
+}
+
+// NewVaultSecretsProvider returns a Vault backed provider.
+func NewVaultSecretsProvider(cfg VaultConfig) *VaultSecretsProvider {
+	cfg.Address = strings.TrimRight(cfg.Address, "/")
+	if cfg.Mount == "" {
+		cfg.Mount = defaultVaultMount
+	}
+	cfg.Mount = strings.Trim(cfg.Mount, "/")
+	if cfg.KVVersion == 0 {
+		cfg.KVVersion = 2
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultVaultTimeout}
+	}
+	return &VaultSecretsProvider{cfg: cfg}
+}
+
+type vaultResponse struct {
+	LeaseID       string          `json:"lease_id"`
+	LeaseDuration int             `json:"lease_duration"`
+	Renewable     bool            `json:"renewable"`
+	Data          json.RawMessage `json:"data"`
+	Errors        []string        `json:"errors"`
+}
+
+// GetSecret implements SecretsProvider.
+func (p *VaultSecretsProvider) GetSecret(ctx context.Context, ref string) (Secret, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || field == "" {
+		field = defaultVaultField
+	}
+	path = strings.Trim(path, "/")
+
+	url := fmt.Sprintf("%s/v1/%s/%s", p.cfg.Address, p.cfg.Mount, path)
+	if p.cfg.KVVersion == 2 {
+		url = fmt.Sprintf("%s/v1/%s/data/%s", p.cfg.Address, p.cfg.Mount, path)
+	}
+
+	resp, status, err := p.do(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Secret{}, err
+	}
+	if status == http.StatusNotFound {
+		return Secret{}, secretNotFound(ref, "vault")
+	}
+	if status >= 300 {
+		return Secret{}, vaultError(ref, status, resp.Errors)
+	}
+
+	data := map[string]any{}
+	if p.cfg.KVVersion == 2 {
+		var kv struct {
+			Data map[string]any `json:"data"`
+		}
+		if err := json.Unmarshal(resp.Data, &kv); err != nil {
+			return Secret{}, vaultDecodeError(ref, err)
+		}
+		data = kv.Data
+	} else if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return Secret{}, vaultDecodeError(ref, err)
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return Secret{}, secretNotFound(ref, "vault")
+	}
+	return Secret{
+		Value:         fmt.Sprint(value),
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+// RenewSecret implements SecretRenewer by renewing the secret's lease.
+func (p *VaultSecretsProvider) RenewSecret(ctx context.Context, secret Secret) (Secret, error) {
+	if secret.LeaseID == "" {
+		return secret, fmt.Errorf("secret has no lease to renew")
+	}
+	body, _ := json.Marshal(map[string]any{
+		"lease_id":  secret.LeaseID,
+		"increment": int(secret.LeaseDuration / time.Second),
+	})
+	resp, status, err := p.do(ctx, http.MethodPut, p.cfg.Address+"/v1/sys/leases/renew", body)
+	if err != nil {
+		return secret, err
+	}
+	if status >= 300 {
+		return secret, vaultError(secret.LeaseID, status, resp.Errors)
+	}
+	secret.LeaseID = resp.LeaseID
+	secret.LeaseDuration = time.Duration(resp.LeaseDuration) * time.Second
+	secret.Renewable = resp.Renewable
+	return secret, nil
+}
+
+func (p *VaultSecretsProvider) do(ctx context.Context, method, url string, body []byte) (vaultResponse, int, error) {
+	var out vaultResponse
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return out, 0, err
+	}
+	req.Header.Set("X-Vault-Token", p.cfg.Token)
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.cfg.Client.Do(req)
+	if err != nil {
+		return out, 0, errors.Wrap(err, errors.CategoryExternal, "vault request failed").
+			WithTextCode("SECRET_VAULT_UNAVAILABLE")
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil && err != io.EOF {
+		if resp.StatusCode < 300 {
+			return out, resp.StatusCode, vaultDecodeError(url, err)
+		}
+	}
+	return out, resp.StatusCode, nil
+}
+
+func vaultError(ref string, status int, messages []string) error {
+	return errors.New(fmt.Sprintf("vault returned status %d", status), errors.CategoryExternal).
+		WithTextCode("SECRET_VAULT_ERROR").
+		WithMetadata(map[string]any{"ref": ref, "status": status, "errors": messages})
+}
+
+func vaultDecodeError(ref string, err error) error {
+	return errors.Wrap(err, errors.CategoryExternal, "failed to decode vault response").
+		WithTextCode("SECRET_VAULT_ERROR").
+		WithMetadata(map[string]any{"ref": ref})
+}
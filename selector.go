@@ -0,0 +1,88 @@
+package job
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Selector matches tasks by metadata labels. The `tag` key matches when the
+// value appears in the task's `tags` metadata (a list or comma separated
+// string); any other key matches the metadata value of the same name.
+type Selector map[string]string
+
+// ParseSelector parses "key=value" pairs separated by spaces or commas,
+// e.g. "tag=backfill tenant=acme".
+func ParseSelector(raw string) (Selector, error) {
+	selector := Selector{}
+	fields := strings.FieldsFunc(raw, func(r rune) bool { return r == ' ' || r == ',' })
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid selector term %q: expected key=value", field)
+		}
+		selector[key] = strings.TrimSpace(value)
+	}
+	return selector, nil
+}
+
+// String renders the selector in its parseable form with sorted keys.
+func (s Selector) String() string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+s[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Matches reports whether task satisfies every selector term. An empty
+// selector matches nothing, so sweeps cannot accidentally target every job.
+func (s Selector) Matches(task Task) bool {
+	if len(s) == 0 || task == nil {
+		return false
+	}
+	metadata := task.GetConfig().Metadata
+	for key, want := range s {
+		if key == "tag" {
+			if !containsString(stringList(metadata["tags"]), want) {
+				return false
+			}
+			continue
+		}
+		got, ok := metadata[key]
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectTasks returns the registry tasks matching selector, ordered by ID.
+func SelectTasks(registry Registry, selector Selector) []Task {
+	if registry == nil {
+		return nil
+	}
+	var out []Task
+	for _, task := range registry.List() {
+		if selector.Matches(task) {
+			out = append(out, task)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GetID() < out[j].GetID() })
+	return out
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
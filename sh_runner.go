@@ -1,15 +1,9 @@
 package job
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"os"
 	"os/exec"
 	"strings"
-	"time"
-
-	"github.com/goliatone/go-errors"
 )
 
 type ShellEngine struct {
@@ -48,84 +42,14 @@ func (e *ShellEngine) Execute(ctx context.Context, msg *ExecutionMessage) error
 	if err != nil {
 		return err
 	}
-	execCtx, cancel := e.GetExecutionContext(ctx)
-	defer cancel()
-
-	logger := e.logger
-	if fl, ok := logger.(FieldsLogger); ok {
-		logger = fl.WithFields(map[string]any{
-			"engine":      e.EngineType,
-			"script_path": msg.ScriptPath,
-		})
-	}
-
-	cmd := exec.CommandContext(execCtx, e.shell, append(e.shellArgs, scriptContent)...)
-
-	if e.workDir != "" {
-		cmd.Dir = e.workDir
-	}
-
-	// NOTE: Use this if you know what you are doing :)
-	if use, ok := msg.Config.Metadata["use_env"].(bool); ok && use {
-		cmd.Env = os.Environ()
-	}
-
-	if e.environment != nil {
-		cmd.Env = append(cmd.Env, e.environment...)
-	}
-
-	if msg.Config.Env != nil {
-		for k, v := range msg.Config.Env {
-			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
-		}
-	}
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	logger.Debug("shell command starting", "script_path", msg.ScriptPath)
-	start := time.Now()
-
-	if err := cmd.Run(); err != nil {
-		duration := time.Since(start)
-		logger.Error("shell command failed", "script_path", msg.ScriptPath, "duration", duration, "exit_code", getExitCode(err), "stderr", summarizeOutput(stderr.String()))
-		return errors.Wrap(err, errors.CategoryExternal, "script execution failed").
-			WithTextCode("SHELL_EXECUTION_ERROR").
-			WithMetadata(map[string]any{
-				"operation":   "execute_command",
-				"script_path": msg.ScriptPath,
-				"shell":       e.shell,
-				"working_dir": e.workDir,
-				"stdout":      stdout.String(),
-				"stderr":      stderr.String(),
-				"duration":    duration,
-				"exit_code":   getExitCode(err),
-			})
-	}
-
-	duration := time.Since(start)
-	stdoutSummary := summarizeOutput(stdout.String())
-	stderrSummary := summarizeOutput(stderr.String())
-
-	if exitCode := cmd.ProcessState.ExitCode(); exitCode != 0 {
-		logger.Warn("shell command exited with non-zero status", "script_path", msg.ScriptPath, "duration", duration, "exit_code", exitCode, "stdout", stdoutSummary, "stderr", stderrSummary)
-		return errors.New("script exited with non-zero status", errors.CategoryExternal).
-			WithTextCode("SHELL_EXECUTION_ERROR").
-			WithMetadata(map[string]any{
-				"operation":   "execute_command",
-				"script_path": msg.ScriptPath,
-				"shell":       e.shell,
-				"working_dir": e.workDir,
-				"stdout":      stdout.String(),
-				"stderr":      stderr.String(),
-				"duration":    duration,
-				"exit_code":   exitCode,
-			})
-	}
-
-	logger.Info("shell command completed", "script_path", msg.ScriptPath, "duration", duration, "stdout", stdoutSummary, "stderr", stderrSummary)
-	return nil
+	return e.runProcess(ctx, msg, scriptProcess{
+		kind:        "shell",
+		textCode:    "SHELL_EXECUTION_ERROR",
+		command:     e.shell,
+		args:        append(append([]string(nil), e.shellArgs...), scriptContent),
+		workDir:     e.workDir,
+		environment: e.environment,
+	})
 }
 
 func getExitCode(err error) int {
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"sync"
+	"time"
+)
+
+// SlowExecutionEvent is emitted when an attempt runs past its configured warn_after threshold.
+// The execution is not interrupted; timeouts remain the only hard limit.
+type SlowExecutionEvent struct {
+	JobID      string        `json:"job_id"`
+	ScriptPath string        `json:"script_path,omitempty"`
+	TraceID    string        `json:"trace_id,omitempty"`
+	Attempt    int           `json:"attempt"`
+	WarnAfter  time.Duration `json:"warn_after"`
+	Elapsed    time.Duration `json:"elapsed"`
+	StartedAt  time.Time     `json:"started_at"`
+}
+
+// SlowExecutionHandler receives slow execution events, e.g. to emit a metric or alert.
+type SlowExecutionHandler func(SlowExecutionEvent)
+
+// watchSlowExecution arms a timer that invokes handler once when msg exceeds
+// Config.WarnAfter. The returned stop func must be called when the attempt ends.
+func watchSlowExecution(msg *ExecutionMessage, attempt int, handler SlowExecutionHandler) func() {
+	if msg == nil || handler == nil || msg.Config.WarnAfter <= 0 {
+		return func() {}
+	}
+
+	started := time.Now()
+	event := SlowExecutionEvent{
+		JobID:      msg.JobID,
+		ScriptPath: msg.ScriptPath,
+		TraceID:    msg.TraceID,
+		Attempt:    attempt,
+		WarnAfter:  msg.Config.WarnAfter,
+		StartedAt:  started,
+	}
+
+	timer := time.AfterFunc(msg.Config.WarnAfter, func() {
+		event.Elapsed = time.Since(started)
+		handler(event)
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { timer.Stop() })
+	}
+}
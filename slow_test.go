@@ -0,0 +1,61 @@
+package job_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sleepingEngine struct {
+	noopEngine
+	delay time.Duration
+}
+
+func (e sleepingEngine) Execute(ctx context.Context, _ *job.ExecutionMessage) error {
+	select {
+	case <-time.After(e.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestTaskCommanderWarnsOnSlowExecution(t *testing.T) {
+	var mu sync.Mutex
+	var events []job.SlowExecutionEvent
+
+	task := job.NewBaseTask("slow-task", "/tmp/slow.sh", "shell",
+		job.Config{WarnAfter: 10 * time.Millisecond}, "noop", sleepingEngine{delay: 60 * time.Millisecond})
+	cmd := job.NewTaskCommander(task).WithSlowExecutionHandler(func(event job.SlowExecutionEvent) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	})
+
+	// the run is not interrupted by the warning
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{TraceID: "t-1"}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 1)
+	assert.Equal(t, "slow-task", events[0].JobID)
+	assert.Equal(t, "t-1", events[0].TraceID)
+	assert.Equal(t, 1, events[0].Attempt)
+	assert.Equal(t, 10*time.Millisecond, events[0].WarnAfter)
+	assert.GreaterOrEqual(t, events[0].Elapsed, 10*time.Millisecond)
+}
+
+func TestTaskCommanderSkipsFastExecution(t *testing.T) {
+	called := false
+	task := job.NewBaseTask("fast-task", "/tmp/fast.sh", "shell",
+		job.Config{WarnAfter: time.Second}, "noop", noopEngine{})
+	cmd := job.NewTaskCommander(task).WithSlowExecutionHandler(func(job.SlowExecutionEvent) { called = true })
+
+	require.NoError(t, cmd.Execute(context.Background(), &job.ExecutionMessage{}))
+	assert.False(t, called)
+}
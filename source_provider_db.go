@@ -1,6 +1,7 @@
 package job
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -14,6 +15,23 @@ type DBSourceProvider struct {
 	Table       string
 	DB          *sql.DB
 	placeholder func(int) string
+	// EngineColumn, when set, names a column (e.g. "engine" or "script_type")
+	// whose value selects the engine through ScriptInfo.Meta, so paths do not
+	// need a file extension.
+	EngineColumn string
+	// EncodingColumn, when set, names a column (e.g. "content_encoding")
+	// holding the encoding of the content column: empty or "identity" for
+	// plain text, "gzip", or any encoding registered with WithContentDecoder.
+	EncodingColumn string
+	// ChunkColumn, when set, names an integer column ordering the chunks of
+	// scripts split across several rows with the same path. Chunks are
+	// concatenated before decoding, so a compressed stream can span rows.
+	ChunkColumn string
+	// MaxScriptSize, when positive, rejects scripts whose decoded content is
+	// larger with ErrScriptTooLarge, bounding the memory a single script,
+	// or a compression bomb, can take.
+	MaxScriptSize int64
+	decoders      map[string]ContentDecoder
 }
 
 func NewDBSourceProvider(db *sql.DB, table string) *DBSourceProvider {
@@ -31,18 +49,33 @@ func (p *DBSourceProvider) GetScript(path string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	columns, err := p.contentColumns()
+	if err != nil {
+		return nil, err
+	}
 
-	query := fmt.Sprintf("SELECT content FROM %s WHERE path = %s LIMIT 1", table, p.placeholderFor(1))
-	var content []byte
-	err = p.DB.QueryRow(query, path).Scan(&content)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE path = %s", columns, table, p.placeholderFor(1))
+	if p.ChunkColumn != "" {
+		query += " ORDER BY " + p.ChunkColumn
+	} else {
+		query += " LIMIT 1"
+	}
+	rows, err := p.DB.Query(query, path)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("script not found at path %s", path)
-		}
 		return nil, fmt.Errorf("failed to get script %s: %w", path, err)
 	}
+	defer rows.Close()
 
-	return content, nil
+	// chunks streams one row at a time into the decoder, so only the current
+	// chunk and the decoded script are held in memory.
+	chunks := &dbChunkReader{path: path, rows: rows, encoded: p.EncodingColumn != ""}
+	if !chunks.next() {
+		if chunks.err != nil {
+			return nil, fmt.Errorf("failed to get script %s: %w", path, chunks.err)
+		}
+		return nil, fmt.Errorf("script not found at path %s", path)
+	}
+	return p.decodeContent(path, chunks.encoding, chunks)
 }
 
 func (p *DBSourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error) {
@@ -53,7 +86,29 @@ func (p *DBSourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error
 		return nil, err
 	}
 
-	query := fmt.Sprintf("SELECT path, content FROM %s", table)
+	columns := "path, content"
+	if p.EngineColumn != "" {
+		column, err := safeIdentifier(p.EngineColumn)
+		if err != nil {
+			return nil, err
+		}
+		columns += ", " + column
+	}
+	if p.EncodingColumn != "" {
+		column, err := safeIdentifier(p.EncodingColumn)
+		if err != nil {
+			return nil, err
+		}
+		columns += ", " + column
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", columns, table)
+	if p.ChunkColumn != "" {
+		column, err := safeIdentifier(p.ChunkColumn)
+		if err != nil {
+			return nil, err
+		}
+		query += " ORDER BY path, " + column
+	}
 
 	rows, err := p.DB.QueryContext(ctx, query)
 	if err != nil {
@@ -61,6 +116,22 @@ func (p *DBSourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error
 	}
 	defer rows.Close()
 
+	var current *ScriptInfo
+	var encoding string
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		content, err := p.decodeContent(current.Path, encoding, bytes.NewReader(current.Content))
+		if err != nil {
+			return err
+		}
+		current.Content = content
+		scripts = append(scripts, *current)
+		current = nil
+		return nil
+	}
+
 	for rows.Next() {
 		select {
 		case <-ctx.Done():
@@ -70,21 +141,47 @@ func (p *DBSourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error
 
 		var path string
 		var content []byte
+		var engine, rowEncoding sql.NullString
 
-		if err := rows.Scan(&path, &content); err != nil {
+		dest := []any{&path, &content}
+		if p.EngineColumn != "" {
+			dest = append(dest, &engine)
+		}
+		if p.EncodingColumn != "" {
+			dest = append(dest, &rowEncoding)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		scripts = append(scripts, ScriptInfo{
+		if p.ChunkColumn != "" && current != nil && current.Path == path {
+			if rowEncoding.String != encoding {
+				return nil, mixedEncodingError(path, encoding, rowEncoding.String)
+			}
+			current.Content = append(current.Content, content...)
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+
+		current = &ScriptInfo{
 			ID:      filepath.Base(path),
 			Path:    path,
 			Content: content,
-		})
+		}
+		encoding = rowEncoding.String
+		if engine.Valid && engine.String != "" {
+			current.Meta = map[string]any{ScriptMetaEngine: engine.String}
+		}
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
 
 	return scripts, nil
 }
@@ -99,6 +196,13 @@ func (p *DBSourceProvider) WithPlaceholder(fn func(int) string) *DBSourceProvide
 	return p
 }
 
+// WithEngineColumn selects engines from column instead of the path
+// extension; see EngineColumn.
+func (p *DBSourceProvider) WithEngineColumn(column string) *DBSourceProvider {
+	p.EngineColumn = column
+	return p
+}
+
 func (p *DBSourceProvider) placeholderFor(index int) string {
 	if p.placeholder == nil {
 		return defaultPostgresPlaceholder(index)
@@ -112,14 +216,22 @@ func (p *DBSourceProvider) safeTable() (string, error) {
 		return "", fmt.Errorf("table name must be provided")
 	}
 
-	re := regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
-	if !re.MatchString(table) {
+	if !sqlIdentifierPattern.MatchString(table) {
 		return "", fmt.Errorf("invalid table name %q", table)
 	}
 
 	return table, nil
 }
 
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+func safeIdentifier(name string) (string, error) {
+	if !sqlIdentifierPattern.MatchString(name) {
+		return "", fmt.Errorf("invalid column name %q", name)
+	}
+	return name, nil
+}
+
 func defaultPostgresPlaceholder(index int) string {
 	return fmt.Sprintf("$%d", index)
 }
@@ -0,0 +1,175 @@
+package job
+
+import (
+	"compress/gzip"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ContentDecoder wraps the raw content of a script stored with an encoding,
+// returning a reader of the decoded script. Returned readers implementing
+// io.Closer are closed once read.
+type ContentDecoder func(r io.Reader) (io.Reader, error)
+
+// ContentEncodingGzip marks gzip-compressed script content.
+const ContentEncodingGzip = "gzip"
+
+var builtinContentDecoders = map[string]ContentDecoder{
+	"":         identityDecoder,
+	"identity": identityDecoder,
+	ContentEncodingGzip: func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	},
+}
+
+func identityDecoder(r io.Reader) (io.Reader, error) {
+	return r, nil
+}
+
+// WithEncodingColumn decodes content according to column; see EncodingColumn.
+func (p *DBSourceProvider) WithEncodingColumn(column string) *DBSourceProvider {
+	p.EncodingColumn = column
+	return p
+}
+
+// WithChunkColumn reassembles scripts split across rows ordered by column;
+// see ChunkColumn.
+func (p *DBSourceProvider) WithChunkColumn(column string) *DBSourceProvider {
+	p.ChunkColumn = column
+	return p
+}
+
+// WithMaxScriptSize fails reads of scripts larger than size once decoded
+// with ErrScriptTooLarge.
+func (p *DBSourceProvider) WithMaxScriptSize(size int64) *DBSourceProvider {
+	p.MaxScriptSize = size
+	return p
+}
+
+// WithContentDecoder registers decoder for content whose encoding column
+// holds encoding (matched case-insensitively), e.g. "zstd". It can also
+// replace the built-in gzip decoder.
+func (p *DBSourceProvider) WithContentDecoder(encoding string, decoder ContentDecoder) *DBSourceProvider {
+	if decoder == nil {
+		return p
+	}
+	if p.decoders == nil {
+		p.decoders = make(map[string]ContentDecoder)
+	}
+	p.decoders[normalizeContentEncoding(encoding)] = decoder
+	return p
+}
+
+func normalizeContentEncoding(encoding string) string {
+	return strings.ToLower(strings.TrimSpace(encoding))
+}
+
+// contentColumns lists the columns GetScript selects, validating the
+// optional column names.
+func (p *DBSourceProvider) contentColumns() (string, error) {
+	columns := "content"
+	if p.EncodingColumn != "" {
+		column, err := safeIdentifier(p.EncodingColumn)
+		if err != nil {
+			return "", err
+		}
+		columns += ", " + column
+	}
+	if p.ChunkColumn != "" {
+		if _, err := safeIdentifier(p.ChunkColumn); err != nil {
+			return "", err
+		}
+	}
+	return columns, nil
+}
+
+// decodeContent decodes the content of path from r, enforcing MaxScriptSize.
+func (p *DBSourceProvider) decodeContent(path, encoding string, r io.Reader) ([]byte, error) {
+	key := normalizeContentEncoding(encoding)
+	decoder, ok := p.decoders[key]
+	if !ok {
+		decoder, ok = builtinContentDecoders[key]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported content encoding %q for script %s", encoding, path)
+	}
+
+	decoded, err := decoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s content of script %s: %w", key, path, err)
+	}
+	if closer, ok := decoded.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if p.MaxScriptSize > 0 {
+		decoded = io.LimitReader(decoded, p.MaxScriptSize+1)
+	}
+
+	content, err := io.ReadAll(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s content of script %s: %w", key, path, err)
+	}
+	if p.MaxScriptSize > 0 && int64(len(content)) > p.MaxScriptSize {
+		return nil, fmt.Errorf("%w: script %s exceeded limit %d bytes", ErrScriptTooLarge, path, p.MaxScriptSize)
+	}
+	return content, nil
+}
+
+func mixedEncodingError(path, first, other string) error {
+	return fmt.Errorf("script %s mixes content encodings %q and %q across chunks", path, first, other)
+}
+
+// dbChunkReader reads the content column of consecutive rows as one stream,
+// scanning the next row only once the current chunk is consumed.
+type dbChunkReader struct {
+	path     string
+	rows     *sql.Rows
+	encoded  bool
+	encoding string
+	started  bool
+	chunk    []byte
+	err      error
+}
+
+// next scans the following row into chunk, reporting false at the end of
+// the rows or on error.
+func (r *dbChunkReader) next() bool {
+	if r.err != nil || !r.rows.Next() {
+		if r.err == nil {
+			r.err = r.rows.Err()
+		}
+		return false
+	}
+	var encoding sql.NullString
+	dest := []any{&r.chunk}
+	if r.encoded {
+		dest = append(dest, &encoding)
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		r.err = err
+		return false
+	}
+	if !r.started {
+		r.started, r.encoding = true, encoding.String
+	} else if encoding.String != r.encoding {
+		r.err = mixedEncodingError(r.path, r.encoding, encoding.String)
+		return false
+	}
+	return true
+}
+
+func (r *dbChunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if !r.next() {
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
@@ -1,10 +1,14 @@
 package job_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -344,3 +348,231 @@ func TestDBSourceProvider_SQLInjection(t *testing.T) {
 		t.Errorf("Expected 1 script in table, got %d", count)
 	}
 }
+
+func TestDBSourceProvider_EngineColumn(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if _, err := db.Exec("ALTER TABLE scripts ADD COLUMN script_type TEXT"); err != nil {
+		t.Fatalf("Failed to add engine column: %v", err)
+	}
+	for _, row := range []struct{ path, content, engine any }{
+		{"reports/daily", "SELECT 1;", "sql"},
+		{"notify", "console.log('hi')", "js"},
+		{"legacy.sh", "echo hi", nil},
+		{"unknown", "???", "cobol"},
+	} {
+		if _, err := db.Exec("INSERT INTO scripts (path, content, script_type) VALUES (?, ?, ?)", row.path, row.content, row.engine); err != nil {
+			t.Fatalf("Failed to insert script: %v", err)
+		}
+	}
+
+	provider := job.NewDBSourceProvider(db, "scripts").WithEngineColumn("script_type")
+	creator := job.NewTaskCreator(provider, []job.Engine{job.NewJSRunner(), job.NewShellRunner(), job.NewSQLRunner()})
+
+	var skipped []string
+	creator.AddTaskEventHandler(func(event job.TaskEvent) {
+		if event.Type == job.TaskEventSkipped && event.Reason == job.SkipReasonNoEngine {
+			skipped = append(skipped, event.ScriptPath)
+		}
+	})
+
+	tasks, err := creator.CreateTasks(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	engines := map[string]string{}
+	for _, task := range tasks {
+		engines[task.GetPath()] = task.GetEngine().Name()
+	}
+	want := map[string]string{
+		"reports/daily": "engine:sql",
+		"notify":        "engine:javascript",
+		"legacy.sh":     "engine:shell",
+	}
+	for path, name := range want {
+		if engines[path] != name {
+			t.Errorf("Expected %s to use %s, got %q", path, name, engines[path])
+		}
+	}
+	if len(skipped) != 1 || skipped[0] != "unknown" {
+		t.Errorf("Expected only unknown to be skipped, got %v", skipped)
+	}
+
+	if _, err := job.NewDBSourceProvider(db, "scripts").WithEngineColumn("type; DROP").ListScripts(context.Background()); err == nil {
+		t.Error("Expected invalid engine column to fail")
+	}
+}
+
+func gzipContent(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to compress content: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to compress content: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDBSourceProvider_ContentEncoding(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if _, err := db.Exec("ALTER TABLE scripts ADD COLUMN content_encoding TEXT"); err != nil {
+		t.Fatalf("Failed to add encoding column: %v", err)
+	}
+	large := strings.Repeat("echo compressed\n", 1000)
+	for _, row := range []struct {
+		path     string
+		content  []byte
+		encoding any
+	}{
+		{"plain.sh", []byte("echo plain"), nil},
+		{"identity.sh", []byte("echo identity"), "identity"},
+		{"large.sh", gzipContent(t, large), "GZIP"},
+	} {
+		if _, err := db.Exec("INSERT INTO scripts (path, content, content_encoding) VALUES (?, ?, ?)", row.path, row.content, row.encoding); err != nil {
+			t.Fatalf("Failed to insert script: %v", err)
+		}
+	}
+
+	provider := job.NewDBSourceProvider(db, "scripts").
+		WithPlaceholder(job.SQLQuestionPlaceholder).
+		WithEncodingColumn("content_encoding")
+
+	content, err := provider.GetScript("large.sh")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(content) != large {
+		t.Errorf("Expected gzip content to be decompressed, got %d bytes", len(content))
+	}
+
+	scripts, err := provider.ListScripts(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, script := range scripts {
+		got[script.Path] = string(script.Content)
+	}
+	want := map[string]string{"plain.sh": "echo plain", "identity.sh": "echo identity", "large.sh": large}
+	for path, content := range want {
+		if got[path] != content {
+			t.Errorf("Expected %s to list decoded content, got %q", path, got[path])
+		}
+	}
+
+	if _, err := provider.WithMaxScriptSize(1024).GetScript("large.sh"); !errors.Is(err, job.ErrScriptTooLarge) {
+		t.Errorf("Expected ErrScriptTooLarge, got %v", err)
+	}
+	if _, err := provider.ListScripts(context.Background()); !errors.Is(err, job.ErrScriptTooLarge) {
+		t.Errorf("Expected ListScripts to enforce the size limit, got %v", err)
+	}
+	provider.WithMaxScriptSize(0)
+
+	if _, err := db.Exec("INSERT INTO scripts (path, content, content_encoding) VALUES (?, ?, ?)", "rot13.sh", []byte("rpub ebg13"), "rot13"); err != nil {
+		t.Fatalf("Failed to insert script: %v", err)
+	}
+	if _, err := provider.GetScript("rot13.sh"); err == nil || !strings.Contains(err.Error(), `unsupported content encoding "rot13"`) {
+		t.Errorf("Expected unsupported encoding error, got %v", err)
+	}
+	provider.WithContentDecoder("ROT13", func(r io.Reader) (io.Reader, error) {
+		raw, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range raw {
+			switch {
+			case c >= 'a' && c <= 'z':
+				raw[i] = 'a' + (c-'a'+13)%26
+			case c >= 'A' && c <= 'Z':
+				raw[i] = 'A' + (c-'A'+13)%26
+			}
+		}
+		return bytes.NewReader(raw), nil
+	})
+	if content, err := provider.GetScript("rot13.sh"); err != nil || string(content) != "echo rot13" {
+		t.Errorf("Expected custom decoder output, got %q (%v)", content, err)
+	}
+}
+
+func TestDBSourceProvider_ChunkedContent(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`CREATE TABLE script_chunks (
+		path TEXT NOT NULL,
+		chunk INTEGER NOT NULL,
+		content BLOB,
+		content_encoding TEXT,
+		PRIMARY KEY (path, chunk)
+	)`); err != nil {
+		t.Fatalf("Failed to create chunk table: %v", err)
+	}
+
+	script := strings.Repeat("echo chunked\n", 500)
+	compressed := gzipContent(t, script)
+	insert := func(path string, chunk int, content []byte, encoding string) {
+		t.Helper()
+		if _, err := db.Exec("INSERT INTO script_chunks (path, chunk, content, content_encoding) VALUES (?, ?, ?, ?)", path, chunk, content, encoding); err != nil {
+			t.Fatalf("Failed to insert chunk: %v", err)
+		}
+	}
+	// Insert out of order so reassembly depends on the chunk column.
+	third := len(compressed) / 3
+	insert("big.sh", 2, compressed[2*third:], "gzip")
+	insert("big.sh", 0, compressed[:third], "gzip")
+	insert("big.sh", 1, compressed[third:2*third], "gzip")
+	insert("small.sh", 0, []byte("echo "), "")
+	insert("small.sh", 1, []byte("small"), "")
+	insert("mixed.sh", 0, []byte("echo "), "")
+	insert("mixed.sh", 1, compressed, "gzip")
+
+	provider := job.NewDBSourceProvider(db, "script_chunks").
+		WithPlaceholder(job.SQLQuestionPlaceholder).
+		WithEncodingColumn("content_encoding").
+		WithChunkColumn("chunk")
+
+	for path, want := range map[string]string{"big.sh": script, "small.sh": "echo small"} {
+		content, err := provider.GetScript(path)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", path, err)
+		}
+		if string(content) != want {
+			t.Errorf("Expected %s to be reassembled, got %d bytes", path, len(content))
+		}
+	}
+
+	if _, err := provider.GetScript("mixed.sh"); err == nil || !strings.Contains(err.Error(), "mixes content encodings") {
+		t.Errorf("Expected mixed encoding error, got %v", err)
+	}
+	if _, err := provider.GetScript("missing.sh"); err == nil || !strings.Contains(err.Error(), "script not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := provider.ListScripts(context.Background()); err == nil || !strings.Contains(err.Error(), "mixes content encodings") {
+		t.Errorf("Expected ListScripts to reject mixed encodings, got %v", err)
+	}
+
+	if _, err := db.Exec("DELETE FROM script_chunks WHERE path = 'mixed.sh'"); err != nil {
+		t.Fatalf("Failed to delete chunks: %v", err)
+	}
+	scripts, err := provider.ListScripts(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(scripts) != 2 || scripts[0].Path != "big.sh" || string(scripts[0].Content) != script || string(scripts[1].Content) != "echo small" {
+		t.Errorf("Expected one reassembled script per path, got %d scripts", len(scripts))
+	}
+
+	if _, err := job.NewDBSourceProvider(db, "script_chunks").WithChunkColumn("chunk; DROP").GetScript("big.sh"); err == nil {
+		t.Error("Expected invalid chunk column to fail")
+	}
+}
@@ -1,15 +1,16 @@
 package job
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	pathpkg "path"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 var _ SourceProvider = &FileSystemSourceProvider{}
@@ -19,8 +20,30 @@ type FileSystemSourceProvider struct {
 	fs             fs.FS
 	maxFileSize    int64
 	ignoreMatchers []func(string, fs.DirEntry) bool
+	ignoreRules    *IgnoreRules
+	ignoreFile     string
+	symlinks       SymlinkPolicy
+	maxDepth       int
+	maxScripts     int
+	maxTotalBytes  int64
+	skipOversized  bool
+	onSkip         ScriptSkipHandler
 }
 
+// SymlinkPolicy controls how FileSystemSourceProvider treats symbolic links.
+type SymlinkPolicy int
+
+const (
+	// SymlinkFiles reads symlinks to files and skips symlinks to directories.
+	SymlinkFiles SymlinkPolicy = iota
+	// SymlinkSkip ignores every symlink.
+	SymlinkSkip
+	// SymlinkFollow follows symlinks to files and directories. A directory
+	// link that points back to one of its ancestors is skipped, so loops
+	// cannot hang discovery.
+	SymlinkFollow
+)
+
 func NewFileSystemSourceProvider(rootDir string, fss ...fs.FS) *FileSystemSourceProvider {
 	fsys := os.DirFS(rootDir)
 	if len(fss) > 0 {
@@ -34,35 +57,112 @@ func NewFileSystemSourceProvider(rootDir string, fss ...fs.FS) *FileSystemSource
 
 var ErrScriptTooLarge = errors.New("script exceeds maximum size limit")
 
+// ErrTooManyScripts is returned when discovery finds more scripts than the
+// limit set with WithMaxScripts.
+var ErrTooManyScripts = errors.New("discovery exceeds maximum script count")
+
+// ErrTotalSizeExceeded is returned when the scripts loaded by ListScripts add
+// up to more than the limit set with WithMaxTotalBytes.
+var ErrTotalSizeExceeded = errors.New("discovery exceeds maximum total size")
+
+// ErrMaxDepthExceeded is returned when discovery reaches a path nested deeper
+// than the limit set with WithMaxDepth.
+var ErrMaxDepthExceeded = errors.New("discovery exceeds maximum depth")
+
 func (p *FileSystemSourceProvider) WithMaxFileSize(limit int64) *FileSystemSourceProvider {
 	p.maxFileSize = limit
 	return p
 }
 
-// WithIgnoreGlobs skips files or directories matching any glob pattern (filepath.Match semantics).
-// Patterns are matched against paths relative to rootDir, using "/" separators.
+// WithSkipOversized skips scripts larger than WithMaxFileSize, reporting them
+// with SkipReasonTooLarge, instead of failing ListScripts.
+func (p *FileSystemSourceProvider) WithSkipOversized() *FileSystemSourceProvider {
+	p.skipOversized = true
+	return p
+}
+
+// SetScriptSkipHandler satisfies ScriptSkipReporter. Ignored paths, excluded
+// symlinks and skipped oversized scripts are reported to handler.
+func (p *FileSystemSourceProvider) SetScriptSkipHandler(handler ScriptSkipHandler) {
+	p.onSkip = handler
+}
+
+// WithMaxScripts fails ListScripts with ErrTooManyScripts when more than
+// limit scripts are found. Zero disables the guard.
+func (p *FileSystemSourceProvider) WithMaxScripts(limit int) *FileSystemSourceProvider {
+	p.maxScripts = limit
+	return p
+}
+
+// WithMaxTotalBytes fails ListScripts with ErrTotalSizeExceeded once the
+// loaded scripts add up to more than limit bytes. Zero disables the guard.
+func (p *FileSystemSourceProvider) WithMaxTotalBytes(limit int64) *FileSystemSourceProvider {
+	p.maxTotalBytes = limit
+	return p
+}
+
+// WithSymlinkPolicy sets how symlinks are handled during discovery. The
+// default is SymlinkFiles.
+func (p *FileSystemSourceProvider) WithSymlinkPolicy(policy SymlinkPolicy) *FileSystemSourceProvider {
+	p.symlinks = policy
+	return p
+}
+
+// WithMaxDepth fails discovery with ErrMaxDepthExceeded when a path has more
+// than limit segments; 1 allows only files in the root. Zero disables the guard.
+func (p *FileSystemSourceProvider) WithMaxDepth(limit int) *FileSystemSourceProvider {
+	p.maxDepth = limit
+	return p
+}
+
+// WithIgnoreGlobs skips files or directories matching any glob pattern.
+// Patterns are matched against paths relative to rootDir using "/" separators
+// on every OS; each segment follows path.Match and "**" matches any number of
+// directories, so "**/node_modules" skips node_modules at any depth.
 func (p *FileSystemSourceProvider) WithIgnoreGlobs(patterns ...string) *FileSystemSourceProvider {
 	for _, pat := range patterns {
 		if pat == "" {
 			continue
 		}
 		p.ignoreMatchers = append(p.ignoreMatchers, func(path string, d fs.DirEntry) bool {
-			matched, _ := filepath.Match(pat, path)
-			return matched
+			return matchGlob(pat, path)
 		})
 	}
 	return p
 }
 
-// WithIgnorePaths skips exact relative paths (files or directories) during discovery.
+// WithIgnoreRules adds gitignore style rules, including "!" negation and
+// directory-only patterns; see IgnoreRules.
+func (p *FileSystemSourceProvider) WithIgnoreRules(patterns ...string) *FileSystemSourceProvider {
+	if p.ignoreRules == nil {
+		p.ignoreRules = NewIgnoreRules()
+	}
+	p.ignoreRules.Add(patterns...)
+	return p
+}
+
+// WithIgnoreFile reads gitignore style rules from name, relative to the
+// provider root, on every ListScripts call. It defaults to JobIgnoreFile
+// when name is empty. A missing file is not an error, and the file itself is
+// never listed as a script.
+func (p *FileSystemSourceProvider) WithIgnoreFile(name string) *FileSystemSourceProvider {
+	if name == "" {
+		name = JobIgnoreFile
+	}
+	p.ignoreFile = pathpkg.Clean(slashPath(name))
+	return p
+}
+
+// WithIgnorePaths skips exact relative paths (files or directories) during
+// discovery. Either separator is accepted.
 func (p *FileSystemSourceProvider) WithIgnorePaths(paths ...string) *FileSystemSourceProvider {
 	for _, path := range paths {
 		if path == "" {
 			continue
 		}
-		clean := filepath.Clean(path)
+		clean := pathpkg.Clean(slashPath(path))
 		p.ignoreMatchers = append(p.ignoreMatchers, func(pth string, _ fs.DirEntry) bool {
-			return filepath.Clean(pth) == clean
+			return pathpkg.Clean(slashPath(pth)) == clean
 		})
 	}
 	return p
@@ -70,7 +170,7 @@ func (p *FileSystemSourceProvider) WithIgnorePaths(paths ...string) *FileSystemS
 
 func (p *FileSystemSourceProvider) GetScript(path string) ([]byte, error) {
 
-	path = filepath.Clean(path)
+	path = pathpkg.Clean(slashPath(path))
 
 	file, err := p.fs.Open(path)
 	if err != nil {
@@ -89,9 +189,18 @@ func (p *FileSystemSourceProvider) GetScript(path string) ([]byte, error) {
 }
 
 func (p *FileSystemSourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error) {
-	var scripts []ScriptInfo
+	var (
+		scripts    []ScriptInfo
+		totalBytes int64
+	)
 
-	err := fs.WalkDir(p.fs, ".", func(path string, d fs.DirEntry, err error) error {
+	rules, err := p.loadIgnoreRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var walk fs.WalkDirFunc
+	walk = func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -106,29 +215,59 @@ func (p *FileSystemSourceProvider) ListScripts(ctx context.Context) ([]ScriptInf
 		default:
 		}
 
-		if p.shouldIgnore(path, d) {
+		if p.shouldIgnore(path, d, rules) {
+			if path != p.ignoreFile {
+				p.reportSkip(path, SkipReasonIgnored, nil)
+			}
 			if d.IsDir() {
 				return fs.SkipDir
 			}
 			return nil
 		}
 
+		if p.maxDepth > 0 && path != "." && strings.Count(path, "/")+1 > p.maxDepth {
+			return fmt.Errorf("%w: %s is nested deeper than %d", ErrMaxDepthExceeded, path, p.maxDepth)
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			follow, isDir, err := p.resolveSymlink(path)
+			if err != nil {
+				return err
+			}
+			if !follow {
+				p.reportSkip(path, SkipReasonSymlink, nil)
+				return nil
+			}
+			if isDir {
+				return fs.WalkDir(p.fs, path, walk)
+			}
+		}
+
 		if d.IsDir() {
 			return nil
 		}
 
+		if p.maxScripts > 0 && len(scripts) >= p.maxScripts {
+			return fmt.Errorf("%w: found more than %d scripts (at %s)", ErrTooManyScripts, p.maxScripts, path)
+		}
+
 		content, err := p.loadScriptContent(ctx, path)
 		if err != nil {
+			if p.skipOversized && errors.Is(err, ErrScriptTooLarge) {
+				p.reportSkip(path, SkipReasonTooLarge, err)
+				return nil
+			}
 			return err
 		}
 
-		absPath := path
-		if p.rootDir != "" {
-			absPath = filepath.Join(p.rootDir, path)
+		totalBytes += int64(len(content))
+		if p.maxTotalBytes > 0 && totalBytes > p.maxTotalBytes {
+			return fmt.Errorf("%w: loaded %d bytes by %s (limit %d)", ErrTotalSizeExceeded, totalBytes, path, p.maxTotalBytes)
 		}
+
 		scripts = append(scripts, ScriptInfo{
 			ID:      filepath.Base(path),
-			Path:    absPath,
+			Path:    p.scriptPath(path),
 			Content: content,
 		})
 
@@ -139,9 +278,9 @@ func (p *FileSystemSourceProvider) ListScripts(ctx context.Context) ([]ScriptInf
 		}
 
 		return nil
-	})
+	}
 
-	if err != nil {
+	if err := fs.WalkDir(p.fs, ".", walk); err != nil {
 		return nil, err
 	}
 
@@ -201,8 +340,12 @@ func (p *FileSystemSourceProvider) readFile(ctx context.Context, path string, fi
 		}
 	}
 
-	buf := bytes.NewBuffer(make([]byte, 0, initialSize))
-	chunk := make([]byte, 32*1024)
+	// read straight into a buffer sized from Stat (plus one byte so EOF is
+	// observed without growing), avoiding an intermediate copy per chunk
+	buf := make([]byte, 0, initialSize+1)
+	if initialSize == 0 {
+		buf = make([]byte, 0, 512)
+	}
 	var total int64
 
 	for {
@@ -210,15 +353,17 @@ func (p *FileSystemSourceProvider) readFile(ctx context.Context, path string, fi
 			return nil, err
 		}
 
-		n, err := file.Read(chunk)
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+
+		n, err := file.Read(buf[len(buf):cap(buf)])
 		if n > 0 {
 			total += int64(n)
 			if p.maxFileSize > 0 && total > p.maxFileSize {
 				return nil, fmt.Errorf("%w: script %s exceeded limit %d bytes", ErrScriptTooLarge, path, p.maxFileSize)
 			}
-			if _, werr := buf.Write(chunk[:n]); werr != nil {
-				return nil, fmt.Errorf("failed to buffer file %s: %w", path, werr)
-			}
+			buf = buf[:len(buf)+n]
 		}
 
 		if err != nil {
@@ -229,14 +374,95 @@ func (p *FileSystemSourceProvider) readFile(ctx context.Context, path string, fi
 		}
 	}
 
-	return buf.Bytes(), nil
+	return buf, nil
 }
 
-func (p *FileSystemSourceProvider) shouldIgnore(path string, d fs.DirEntry) bool {
+func (p *FileSystemSourceProvider) shouldIgnore(path string, d fs.DirEntry, rules *IgnoreRules) bool {
+	if path == "." {
+		return false
+	}
+	if p.ignoreFile != "" && path == p.ignoreFile {
+		return true
+	}
 	for _, matcher := range p.ignoreMatchers {
 		if matcher != nil && matcher(path, d) {
 			return true
 		}
 	}
-	return false
+	return rules.Match(path, d.IsDir())
+}
+
+// scriptPath returns the path ListScripts reports for the relative path rel.
+func (p *FileSystemSourceProvider) scriptPath(rel string) string {
+	if p.rootDir == "" {
+		return rel
+	}
+	return filepath.Join(p.rootDir, rel)
+}
+
+func (p *FileSystemSourceProvider) reportSkip(rel string, reason SkipReason, err error) {
+	if p.onSkip != nil {
+		p.onSkip(p.scriptPath(rel), reason, err)
+	}
+}
+
+// resolveSymlink decides whether to descend into or read the link at path.
+// Directory links are followed only under SymlinkFollow and only when the
+// target is not an ancestor of path.
+func (p *FileSystemSourceProvider) resolveSymlink(path string) (follow, isDir bool, err error) {
+	if p.symlinks == SymlinkSkip {
+		return false, false, nil
+	}
+	info, err := fs.Stat(p.fs, path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, false, nil // dangling link
+		}
+		return false, false, fmt.Errorf("failed to resolve symlink %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return true, false, nil
+	}
+	if p.symlinks != SymlinkFollow {
+		return false, true, nil
+	}
+
+	for dir := pathpkg.Dir(path); ; dir = pathpkg.Dir(dir) {
+		ancestor, err := fs.Stat(p.fs, dir)
+		if err == nil && os.SameFile(info, ancestor) {
+			return false, true, nil
+		}
+		if dir == "." {
+			break
+		}
+	}
+	return true, true, nil
+}
+
+// loadIgnoreRules merges the configured rules with the ignore file, which is
+// read fresh so edits apply on the next reload.
+func (p *FileSystemSourceProvider) loadIgnoreRules() (*IgnoreRules, error) {
+	rules := NewIgnoreRules()
+	if p.ignoreRules != nil {
+		rules.rules = append(rules.rules, p.ignoreRules.rules...)
+	}
+	if p.ignoreFile == "" {
+		return rules, nil
+	}
+
+	file, err := p.fs.Open(p.ignoreFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return rules, nil
+		}
+		return nil, fmt.Errorf("failed to read ignore file %s: %w", p.ignoreFile, err)
+	}
+	defer file.Close()
+
+	fromFile, err := ParseIgnoreRules(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file %s: %w", p.ignoreFile, err)
+	}
+	rules.rules = append(rules.rules, fromFile.rules...)
+	return rules, nil
 }
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/goliatone/go-job"
 	"github.com/stretchr/testify/assert"
@@ -106,6 +109,83 @@ func TestFileSystemSourceProviderIgnoreGlobsAndPaths(t *testing.T) {
 	assert.Equal(t, "keep/a.js", scripts[0].Path)
 }
 
+func TestFileSystemSourceProviderDoublestarIgnore(t *testing.T) {
+	provider := job.NewFileSystemSourceProvider(".", fstest.MapFS{
+		"jobs/a.js":                     {Data: []byte("console.log('a')")},
+		"node_modules/dep/index.js":     {Data: []byte("module.exports = {}")},
+		"jobs/lib/node_modules/x/y.js":  {Data: []byte("module.exports = {}")},
+		"jobs/nightly/reports/daily.js": {Data: []byte("console.log('d')")},
+		"jobs/b.tmp":                    {Data: []byte("tmp")},
+	})
+
+	provider.WithIgnoreGlobs("**/node_modules", "**/*.tmp")
+	provider.WithIgnorePaths(`jobs\nightly`)
+
+	scripts, err := provider.ListScripts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, scripts, 1)
+	assert.Equal(t, "jobs/a.js", scripts[0].Path)
+}
+
+func TestFileSystemSourceProviderSymlinkPolicies(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.js"), []byte("console.log('a')"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.js"), []byte("console.log('b')"), 0o644))
+	require.NoError(t, os.Symlink(root, filepath.Join(root, "sub", "loop")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "linked")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "a.js"), filepath.Join(root, "alias.js")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "missing.js"), filepath.Join(root, "dangling.js")))
+
+	list := func(policy job.SymlinkPolicy) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		scripts, err := job.NewFileSystemSourceProvider("", os.DirFS(root)).WithSymlinkPolicy(policy).ListScripts(ctx)
+		require.NoError(t, err)
+		var paths []string
+		for _, script := range scripts {
+			paths = append(paths, script.Path)
+		}
+		return paths
+	}
+
+	assert.ElementsMatch(t, []string{"a.js", "alias.js", "sub/b.js"}, list(job.SymlinkFiles))
+	assert.ElementsMatch(t, []string{"a.js", "sub/b.js"}, list(job.SymlinkSkip))
+	assert.ElementsMatch(t, []string{"a.js", "alias.js", "linked/b.js", "sub/b.js"}, list(job.SymlinkFollow))
+}
+
+func TestFileSystemSourceProviderMaxDepth(t *testing.T) {
+	provider := job.NewFileSystemSourceProvider(".", fstest.MapFS{
+		"a.js":         {Data: []byte("console.log('a')")},
+		"one/two/b.js": {Data: []byte("console.log('b')")},
+	}).WithMaxDepth(2)
+
+	_, err := provider.ListScripts(context.Background())
+	require.ErrorIs(t, err, job.ErrMaxDepthExceeded)
+
+	scripts, err := provider.WithMaxDepth(3).ListScripts(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, scripts, 2)
+}
+
+func TestFileSystemSourceProviderDiscoveryLimits(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.sh": {Data: bytes.Repeat([]byte("x"), 100)},
+		"b.sh": {Data: bytes.Repeat([]byte("x"), 100)},
+		"c.sh": {Data: bytes.Repeat([]byte("x"), 100)},
+	}
+
+	_, err := job.NewFileSystemSourceProvider(".", fsys).WithMaxScripts(2).ListScripts(context.Background())
+	assert.ErrorIs(t, err, job.ErrTooManyScripts)
+
+	_, err = job.NewFileSystemSourceProvider(".", fsys).WithMaxTotalBytes(250).ListScripts(context.Background())
+	assert.ErrorIs(t, err, job.ErrTotalSizeExceeded)
+
+	scripts, err := job.NewFileSystemSourceProvider(".", fsys).WithMaxScripts(3).WithMaxTotalBytes(300).ListScripts(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, scripts, 3)
+}
+
 type instrumentedFS struct {
 	data   fstest.MapFS
 	onOpen func(string)
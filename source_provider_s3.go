@@ -0,0 +1,241 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	pathpkg "path"
+	"strings"
+	"sync"
+)
+
+var _ SourceProvider = &S3SourceProvider{}
+
+// S3SourceProvider discovers scripts stored as objects under a prefix of an
+// S3 compatible bucket (AWS S3, GCS through its interoperability API,
+// MinIO). Script paths are the object keys.
+type S3SourceProvider struct {
+	bucket         string
+	prefix         string
+	client         ObjectStore
+	maxFileSize    int64
+	ignoreMatchers []func(string) bool
+	ignoreRules    *IgnoreRules
+	onSkip         ScriptSkipHandler
+
+	mu    sync.Mutex
+	cache map[string]s3CachedObject
+}
+
+type s3CachedObject struct {
+	etag    string
+	content []byte
+}
+
+// NewS3SourceProvider lists the objects of bucket under prefix, e.g.
+// "jobs/". It reads through an S3Client configured by S3ConfigFromEnv
+// unless WithObjectStore sets another client.
+func NewS3SourceProvider(bucket, prefix string) *S3SourceProvider {
+	return &S3SourceProvider{
+		bucket: bucket,
+		prefix: strings.TrimLeft(prefix, "/"),
+		client: NewS3Client(S3ConfigFromEnv()),
+	}
+}
+
+// WithObjectStore sets the client objects are read through, e.g. an
+// S3Client for a MinIO endpoint or an adapter over a cloud SDK.
+func (p *S3SourceProvider) WithObjectStore(client ObjectStore) *S3SourceProvider {
+	if client != nil {
+		p.client = client
+	}
+	return p
+}
+
+// WithETagCache keeps the content of every script read along with its ETag.
+// ListScripts then downloads only objects whose listed ETag changed, and
+// GetScript revalidates with a conditional request.
+func (p *S3SourceProvider) WithETagCache() *S3SourceProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cache == nil {
+		p.cache = make(map[string]s3CachedObject)
+	}
+	return p
+}
+
+func (p *S3SourceProvider) WithMaxFileSize(limit int64) *S3SourceProvider {
+	p.maxFileSize = limit
+	return p
+}
+
+// WithIgnoreGlobs skips objects matching any glob pattern, with the syntax
+// of FileSystemSourceProvider.WithIgnoreGlobs. Patterns are matched against
+// keys relative to the prefix; a pattern matching a "directory" skips every
+// key below it.
+func (p *S3SourceProvider) WithIgnoreGlobs(patterns ...string) *S3SourceProvider {
+	for _, pat := range patterns {
+		if pat == "" {
+			continue
+		}
+		p.ignoreMatchers = append(p.ignoreMatchers, func(path string) bool {
+			return matchGlob(pat, path)
+		})
+	}
+	return p
+}
+
+// WithIgnoreRules adds gitignore style rules matched against keys relative
+// to the prefix; see IgnoreRules.
+func (p *S3SourceProvider) WithIgnoreRules(patterns ...string) *S3SourceProvider {
+	if p.ignoreRules == nil {
+		p.ignoreRules = NewIgnoreRules()
+	}
+	p.ignoreRules.Add(patterns...)
+	return p
+}
+
+// SetScriptSkipHandler satisfies ScriptSkipReporter. Ignored objects are
+// reported to handler.
+func (p *S3SourceProvider) SetScriptSkipHandler(handler ScriptSkipHandler) {
+	p.onSkip = handler
+}
+
+// GetScript reads the object at key path.
+func (p *S3SourceProvider) GetScript(path string) ([]byte, error) {
+	key := strings.TrimLeft(path, "/")
+	etag, cached, ok := p.cached(key)
+	if !ok {
+		etag = ""
+	}
+
+	body, info, err := p.client.GetObject(context.Background(), p.bucket, key, etag)
+	if ok && errors.Is(err, ErrObjectNotModified) {
+		return cached, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return p.readObject(key, body, info.ETag)
+}
+
+func (p *S3SourceProvider) ListScripts(ctx context.Context) ([]ScriptInfo, error) {
+	objects, err := p.client.ListObjects(ctx, p.bucket, p.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in %s/%s: %w", p.bucket, p.prefix, err)
+	}
+
+	var scripts []ScriptInfo
+	listed := make(map[string]struct{}, len(objects))
+	for _, obj := range objects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(obj.Key, "/") {
+			continue // folder marker
+		}
+		if p.shouldIgnore(strings.TrimPrefix(obj.Key, p.prefix)) {
+			p.reportSkip(obj.Key, SkipReasonIgnored, nil)
+			continue
+		}
+		if p.maxFileSize > 0 && obj.Size > p.maxFileSize {
+			return nil, fmt.Errorf("%w: script %s has size %d bytes (limit %d)", ErrScriptTooLarge, obj.Key, obj.Size, p.maxFileSize)
+		}
+		listed[obj.Key] = struct{}{}
+
+		content, err := p.loadObject(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, ScriptInfo{
+			ID:      pathpkg.Base(obj.Key),
+			Path:    obj.Key,
+			Content: content,
+		})
+	}
+	p.pruneCache(listed)
+	return scripts, nil
+}
+
+// loadObject returns the content of obj, skipping the download when the
+// cached ETag matches the listing.
+func (p *S3SourceProvider) loadObject(ctx context.Context, obj ObjectInfo) ([]byte, error) {
+	if etag, content, ok := p.cached(obj.Key); ok && etag != "" && etag == obj.ETag {
+		return content, nil
+	}
+	body, info, err := p.client.GetObject(ctx, p.bucket, obj.Key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", obj.Key, err)
+	}
+	etag := info.ETag
+	if etag == "" {
+		etag = obj.ETag
+	}
+	return p.readObject(obj.Key, body, etag)
+}
+
+// readObject reads and closes body, enforcing the size limit and caching
+// the content under etag.
+func (p *S3SourceProvider) readObject(key string, body io.ReadCloser, etag string) ([]byte, error) {
+	defer body.Close()
+	reader := io.Reader(body)
+	if p.maxFileSize > 0 {
+		reader = io.LimitReader(body, p.maxFileSize+1)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	if p.maxFileSize > 0 && int64(len(content)) > p.maxFileSize {
+		return nil, fmt.Errorf("%w: script %s exceeded limit %d bytes", ErrScriptTooLarge, key, p.maxFileSize)
+	}
+
+	p.mu.Lock()
+	if p.cache != nil && etag != "" {
+		p.cache[key] = s3CachedObject{etag: etag, content: content}
+	}
+	p.mu.Unlock()
+	return content, nil
+}
+
+func (p *S3SourceProvider) cached(key string) (string, []byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	obj, ok := p.cache[key]
+	return obj.etag, obj.content, ok
+}
+
+// pruneCache drops cached objects that are no longer listed.
+func (p *S3SourceProvider) pruneCache(listed map[string]struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.cache {
+		if _, ok := listed[key]; !ok {
+			delete(p.cache, key)
+		}
+	}
+}
+
+// shouldIgnore matches rel, and every directory above it, against the
+// ignore globs and rules.
+func (p *S3SourceProvider) shouldIgnore(rel string) bool {
+	rel = strings.TrimLeft(rel, "/")
+	for dir, isDir := rel, false; dir != "." && dir != ""; dir, isDir = pathpkg.Dir(dir), true {
+		for _, matcher := range p.ignoreMatchers {
+			if matcher(dir) {
+				return true
+			}
+		}
+		if p.ignoreRules.Match(dir, isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *S3SourceProvider) reportSkip(key string, reason SkipReason, err error) {
+	if p.onSkip != nil {
+		p.onSkip(key, reason, err)
+	}
+}
@@ -0,0 +1,320 @@
+package job
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Timeout = 30 * time.Second
+	defaultS3Region  = "us-east-1"
+	s3EmptyHash      = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// ErrObjectNotModified is returned by ObjectStore.GetObject when the object
+// still matches the ETag passed as ifNoneMatch.
+var ErrObjectNotModified = errors.New("object not modified")
+
+// ObjectInfo describes an object in a bucket.
+type ObjectInfo struct {
+	Key          string
+	ETag         string
+	Size         int64
+	LastModified time.Time
+}
+
+// ObjectStore is the object storage API S3SourceProvider reads from. S3Client
+// implements it for S3 compatible endpoints (AWS, MinIO, GCS interoperability);
+// wrap an SDK client to use other credentials or transports.
+type ObjectStore interface {
+	// ListObjects returns every object whose key starts with prefix.
+	ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+	// GetObject reads key. A non-empty ifNoneMatch makes the read
+	// conditional, returning ErrObjectNotModified when the ETag still matches.
+	GetObject(ctx context.Context, bucket, key, ifNoneMatch string) (io.ReadCloser, ObjectInfo, error)
+}
+
+// S3Config configures an S3Client.
+type S3Config struct {
+	// Endpoint is the service URL, e.g. "http://localhost:9000" for MinIO or
+	// "https://storage.googleapis.com" for GCS. Empty uses AWS for Region.
+	Endpoint string
+	// Region defaults to us-east-1; GCS and MinIO accept "auto" or any value.
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// PathStyle addresses buckets as <endpoint>/<bucket> instead of
+	// <bucket>.<endpoint>; most MinIO deployments need it.
+	PathStyle bool
+	Client    *http.Client
+}
+
+// S3ConfigFromEnv reads the standard AWS variables: AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION (or
+// AWS_DEFAULT_REGION) and AWS_ENDPOINT_URL_S3 (or AWS_ENDPOINT_URL). A custom
+// endpoint turns on PathStyle.
+func S3ConfigFromEnv() S3Config {
+	cfg := S3Config{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+		Endpoint:        firstNonEmpty(os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL")),
+	}
+	cfg.PathStyle = cfg.Endpoint != ""
+	return cfg
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var _ ObjectStore = &S3Client{}
+
+// S3Client is a minimal S3 REST client signing requests with AWS Signature
+// Version 4. Without credentials requests are sent unsigned, which suits
+// public buckets.
+type S3Client struct {
+	cfg S3Config
+	now func() time.Time
+}
+
+// NewS3Client returns a client for cfg.
+func NewS3Client(cfg S3Config) *S3Client {
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultS3Timeout}
+	}
+	return &S3Client{cfg: cfg, now: time.Now}
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects implements ObjectStore with ListObjectsV2, following
+// continuation tokens.
+func (c *S3Client) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		resp, err := c.do(ctx, bucket, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode s3 listing of %s: %w", bucket, err)
+		}
+
+		for _, obj := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          obj.Key,
+				ETag:         obj.ETag,
+				Size:         obj.Size,
+				LastModified: obj.LastModified,
+			})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// GetObject implements ObjectStore.
+func (c *S3Client) GetObject(ctx context.Context, bucket, key, ifNoneMatch string) (io.ReadCloser, ObjectInfo, error) {
+	header := http.Header{}
+	if ifNoneMatch != "" {
+		header.Set("If-None-Match", ifNoneMatch)
+	}
+	resp, err := c.do(ctx, bucket, key, nil, header)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	info := ObjectInfo{Key: key, ETag: resp.Header.Get("ETag"), Size: resp.ContentLength}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+	return resp.Body, info, nil
+}
+
+// do sends a signed GET and returns the response when it succeeded.
+func (c *S3Client) do(ctx context.Context, bucket, key string, query url.Values, header http.Header) (*http.Response, error) {
+	endpoint, err := url.Parse(c.cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: %w", c.cfg.Endpoint, err)
+	}
+	target := &url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host, Path: endpoint.Path + "/" + key}
+	if c.cfg.PathStyle {
+		target.Path = endpoint.Path + "/" + bucket + "/" + key
+	} else {
+		target.Host = bucket + "." + endpoint.Host
+	}
+	// S3 signs the path escaped with its own, stricter, rules.
+	path := s3EscapePath(target.Path)
+	target.RawPath = path
+	target.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	c.sign(req, path)
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request for %s/%s failed: %w", bucket, key, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		resp.Body.Close()
+		return nil, ErrObjectNotModified
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 object %s/%s: %w", bucket, key, os.ErrNotExist)
+	case resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 request for %s/%s failed with status %d: %s", bucket, key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 headers for an empty-bodied request
+// to path, leaving the request unsigned without credentials.
+func (c *S3Client) sign(req *http.Request, path string) {
+	if c.cfg.AccessKeyID == "" || c.cfg.SecretAccessKey == "" {
+		return
+	}
+	now := c.now().UTC()
+	stamp := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", s3EmptyHash)
+	if c.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.cfg.SessionToken)
+	}
+
+	names := []string{"host"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n"
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names[1:])
+	for _, name := range names[1:] {
+		canonicalHeaders += name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders, signedHeaders, s3EmptyHash,
+	}, "\n")
+	scope := day + "/" + c.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretAccessKey), day)
+	for _, part := range []string{c.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// s3EscapePath escapes each segment of key with the URI encoding SigV4
+// expects, keeping the "/" separators.
+func s3EscapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3CanonicalQuery encodes query sorted by key with SigV4 escaping.
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes every byte except the unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,177 @@
+package job_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 serves path-style ListObjectsV2 and GetObject requests for one
+// bucket, one key per listing page.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]string
+	gets    map[string]int
+	auth    []string
+}
+
+func newFakeS3(bucket string, objects map[string]string) *fakeS3 {
+	return &fakeS3{bucket: bucket, objects: objects, gets: map[string]int{}}
+}
+
+func (s *fakeS3) put(key, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = content
+}
+
+func (s *fakeS3) getCount(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets[key]
+}
+
+func etagOf(content string) string {
+	sum := md5.Sum([]byte(content))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.bucket {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	if key == "" {
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range s.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		start := 0
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			start = sort.SearchStrings(keys, token)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult>`)
+		if start < len(keys) {
+			k := keys[start]
+			fmt.Fprintf(w, `<Contents><Key>%s</Key><ETag>%s</ETag><Size>%d</Size><LastModified>2026-01-02T03:04:05.000Z</LastModified></Contents>`,
+				k, strings.ReplaceAll(etagOf(s.objects[k]), `"`, "&quot;"), len(s.objects[k]))
+		}
+		if start+1 < len(keys) {
+			fmt.Fprintf(w, `<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>`, keys[start+1])
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+		return
+	}
+
+	content, ok := s.objects[key]
+	if !ok {
+		http.Error(w, "NoSuchKey", http.StatusNotFound)
+		return
+	}
+	s.gets[key]++
+	w.Header().Set("ETag", etagOf(content))
+	if r.Header.Get("If-None-Match") == etagOf(content) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	fmt.Fprint(w, content)
+}
+
+func TestS3SourceProviderListsAndCachesByETag(t *testing.T) {
+	store := newFakeS3("scripts", map[string]string{
+		"jobs/backup.sh":               "echo backup",
+		"jobs/reports/daily.js":        "console.log('daily')",
+		"jobs/node_modules/dep/x.js":   "module.exports = 1",
+		"jobs/notes.md":                "# notes",
+		"other/outside.sh":             "echo nope",
+		"jobs/reports/tmp/scratch.sql": "select 1",
+	})
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	var skipped []string
+	provider := job.NewS3SourceProvider("scripts", "jobs/").
+		WithObjectStore(job.NewS3Client(job.S3Config{
+			Endpoint:        server.URL,
+			PathStyle:       true,
+			AccessKeyID:     "minio",
+			SecretAccessKey: "minio-secret",
+		})).
+		WithETagCache().
+		WithIgnoreGlobs("**/node_modules", "*.md").
+		WithIgnoreRules("tmp/")
+	provider.SetScriptSkipHandler(func(path string, reason job.SkipReason, _ error) {
+		assert.Equal(t, job.SkipReasonIgnored, reason)
+		skipped = append(skipped, path)
+	})
+
+	scripts, err := provider.ListScripts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, scripts, 2)
+	assert.Equal(t, job.ScriptInfo{ID: "backup.sh", Path: "jobs/backup.sh", Content: []byte("echo backup")}, scripts[0])
+	assert.Equal(t, "jobs/reports/daily.js", scripts[1].Path)
+	assert.ElementsMatch(t, []string{"jobs/node_modules/dep/x.js", "jobs/notes.md", "jobs/reports/tmp/scratch.sql"}, skipped)
+	assert.True(t, strings.HasPrefix(store.auth[0], "AWS4-HMAC-SHA256 Credential=minio/"), store.auth[0])
+
+	// Unchanged objects are served from the cache; changed ones re-read.
+	store.put("jobs/backup.sh", "echo backup v2")
+	scripts, err = provider.ListScripts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, scripts, 2)
+	assert.Equal(t, "echo backup v2", string(scripts[0].Content))
+	assert.Equal(t, 2, store.getCount("jobs/backup.sh"))
+	assert.Equal(t, 1, store.getCount("jobs/reports/daily.js"))
+
+	// GetScript revalidates with If-None-Match.
+	content, err := provider.GetScript("jobs/reports/daily.js")
+	require.NoError(t, err)
+	assert.Equal(t, "console.log('daily')", string(content))
+	assert.Equal(t, 2, store.getCount("jobs/reports/daily.js"))
+
+	_, err = provider.GetScript("jobs/missing.sh")
+	assert.Error(t, err)
+}
+
+func TestS3SourceProviderMaxFileSize(t *testing.T) {
+	server := httptest.NewServer(newFakeS3("scripts", map[string]string{
+		"big.sh":   strings.Repeat("x", 64),
+		"small.sh": "echo ok",
+	}))
+	defer server.Close()
+
+	provider := job.NewS3SourceProvider("scripts", "").
+		WithObjectStore(job.NewS3Client(job.S3Config{Endpoint: server.URL, PathStyle: true})).
+		WithMaxFileSize(16)
+
+	_, err := provider.ListScripts(context.Background())
+	assert.ErrorIs(t, err, job.ErrScriptTooLarge)
+
+	_, err = provider.GetScript("big.sh")
+	assert.ErrorIs(t, err, job.ErrScriptTooLarge)
+
+	content, err := provider.GetScript("small.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "echo ok", string(content))
+}
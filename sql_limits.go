@@ -0,0 +1,123 @@
+package job
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/goliatone/go-errors"
+)
+
+// Metadata keys setting per-script SQL guardrails, e.g.
+//
+//	-- config
+//	-- transaction: true
+//	-- metadata:
+//	--   max_statements: 5
+//	--   max_rows_affected: 10000
+const (
+	SQLMaxStatementsMetadataKey   = "max_statements"
+	SQLMaxRowsAffectedMetadataKey = "max_rows_affected"
+)
+
+// ErrSQLLimitExceeded is the source of errors returned when a SQL script
+// exceeds its statement or row limits.
+var ErrSQLLimitExceeded = errors.New("sql limit exceeded", errors.CategoryOperation).
+	WithTextCode("SQL_LIMIT_EXCEEDED")
+
+// SQLLimits guard SQL jobs against runaway scripts, such as a DELETE missing
+// its WHERE clause. Zero disables a limit.
+type SQLLimits struct {
+	// MaxStatements rejects scripts with more statements before any runs.
+	MaxStatements int
+	// MaxRowsAffected aborts the script once the rows affected by its
+	// statements add up to more than the limit. Transactional scripts are
+	// rolled back; otherwise the statements already executed stay applied.
+	MaxRowsAffected int64
+}
+
+// WithSQLLimits sets the default limits for every script; the
+// max_statements and max_rows_affected metadata keys override them.
+func WithSQLLimits(limits SQLLimits) SQLOption {
+	return func(e *SQLEngine) {
+		e.limits = limits
+	}
+}
+
+// limitsFor resolves the limits of a script from the engine defaults and
+// its metadata.
+func (e *SQLEngine) limitsFor(cfg Config) (SQLLimits, error) {
+	limits := e.limits
+	if value, ok := cfg.Metadata[SQLMaxStatementsMetadataKey]; ok {
+		n, err := coerceParam(ParamTypeInt, value)
+		if err != nil || n.(int) < 0 {
+			return limits, sqlLimitsInvalid(SQLMaxStatementsMetadataKey, value)
+		}
+		limits.MaxStatements = n.(int)
+	}
+	if value, ok := cfg.Metadata[SQLMaxRowsAffectedMetadataKey]; ok {
+		n, err := coerceParam(ParamTypeInt, value)
+		if err != nil || n.(int) < 0 {
+			return limits, sqlLimitsInvalid(SQLMaxRowsAffectedMetadataKey, value)
+		}
+		limits.MaxRowsAffected = int64(n.(int))
+	}
+	return limits, nil
+}
+
+func sqlLimitsInvalid(key string, value any) error {
+	return errors.NewValidation("invalid sql limits",
+		errors.FieldError{Field: key, Message: "must be a non-negative integer", Value: value},
+	).WithTextCode("SQL_LIMITS_INVALID")
+}
+
+// sqlLimitGuard tracks a single script run against its limits.
+type sqlLimitGuard struct {
+	limits SQLLimits
+	rows   int64
+}
+
+func (g *sqlLimitGuard) checkStatements(statements []string) error {
+	if g.limits.MaxStatements <= 0 || len(statements) <= g.limits.MaxStatements {
+		return nil
+	}
+	return sqlLimitExceeded(fmt.Sprintf("script has %d statements, more than max_statements %d", len(statements), g.limits.MaxStatements),
+		map[string]any{
+			"limit":            SQLMaxStatementsMetadataKey,
+			"max":              g.limits.MaxStatements,
+			"total_statements": len(statements),
+		})
+}
+
+// addRows counts the rows affected by statement index i. Drivers that cannot
+// report affected rows are not counted.
+func (g *sqlLimitGuard) addRows(i int, stmt string, res sql.Result) error {
+	if g.limits.MaxRowsAffected <= 0 || res == nil {
+		return nil
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows <= 0 {
+		return nil
+	}
+	g.rows += rows
+	if g.rows <= g.limits.MaxRowsAffected {
+		return nil
+	}
+	return sqlLimitExceeded(fmt.Sprintf("statement %d brought rows affected to %d, more than max_rows_affected %d", i+1, g.rows, g.limits.MaxRowsAffected),
+		map[string]any{
+			"limit":           SQLMaxRowsAffectedMetadataKey,
+			"max":             g.limits.MaxRowsAffected,
+			"rows_affected":   g.rows,
+			"statement_index": i + 1,
+			"statement":       stmt,
+		})
+}
+
+// sqlLimitExceeded fails the run without retries: rerunning the script would
+// hit the same limit.
+func sqlLimitExceeded(msg string, metadata map[string]any) error {
+	failure := errors.New(msg, errors.CategoryOperation).
+		WithTextCode("SQL_LIMIT_EXCEEDED").
+		WithMetadata(metadata)
+	failure.Source = ErrSQLLimitExceeded
+	return WithRetryHint(failure, RetryHint{NoRetry: true, Reason: "sql limit exceeded"})
+}
@@ -0,0 +1,83 @@
+package job_test
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sqlLimitsDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "limits.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER); INSERT INTO orders VALUES (1), (2), (3), (4), (5);")
+	require.NoError(t, err)
+	return db
+}
+
+func countOrders(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM orders").Scan(&count))
+	return count
+}
+
+func TestSQLMaxRowsAffectedRollsBackTransaction(t *testing.T) {
+	db := sqlLimitsDB(t)
+	engine := job.NewSQLRunner(job.WithSQLClient(db))
+
+	err := engine.Execute(context.Background(), &job.ExecutionMessage{
+		JobID: "cleanup",
+		Config: job.Config{Transaction: true, Metadata: map[string]any{
+			job.SQLMaxRowsAffectedMetadataKey: 2,
+		}},
+		Parameters: map[string]any{"script": "DELETE FROM orders WHERE id = 1;\n--job\nDELETE FROM orders;"},
+	})
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, job.ErrSQLLimitExceeded))
+	hint, ok := job.RetryHintFrom(err)
+	require.True(t, ok)
+	assert.True(t, hint.NoRetry)
+	assert.Equal(t, 5, countOrders(t, db), "the transaction is rolled back")
+}
+
+func TestSQLMaxRowsAffectedStopsDirectExecution(t *testing.T) {
+	db := sqlLimitsDB(t)
+	engine := job.NewSQLRunner(job.WithSQLClient(db), job.WithSQLLimits(job.SQLLimits{MaxRowsAffected: 1}))
+
+	err := engine.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "cleanup",
+		Parameters: map[string]any{"script": "DELETE FROM orders WHERE id = 1;\n--job\nDELETE FROM orders WHERE id = 2;\n--job\nDELETE FROM orders;"},
+	})
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, job.ErrSQLLimitExceeded))
+	assert.Contains(t, err.Error(), "statement 2")
+	assert.Equal(t, 3, countOrders(t, db), "statements before the limit stay applied, later ones are skipped")
+}
+
+func TestSQLMaxStatementsRejectsScript(t *testing.T) {
+	db := sqlLimitsDB(t)
+	engine := job.NewSQLRunner(job.WithSQLClient(db), job.WithSQLLimits(job.SQLLimits{MaxStatements: 5}))
+
+	msg := &job.ExecutionMessage{
+		JobID:      "cleanup",
+		Config:     job.Config{Metadata: map[string]any{job.SQLMaxStatementsMetadataKey: "1"}},
+		Parameters: map[string]any{"script": "DELETE FROM orders WHERE id = 1;\n--job\nDELETE FROM orders WHERE id = 2;"},
+	}
+	err := engine.Execute(context.Background(), msg)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, job.ErrSQLLimitExceeded))
+	assert.Equal(t, 5, countOrders(t, db), "no statement runs")
+
+	msg.Config.Metadata[job.SQLMaxStatementsMetadataKey] = "lots"
+	err = engine.Execute(context.Background(), msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid sql limits")
+}
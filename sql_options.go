@@ -68,6 +68,15 @@ func WithSQLFS(dirfs fs.FS) SQLOption {
 	}
 }
 
+// WithSQLTraceIDStatement sets a parameterised statement (one placeholder) executed at the
+// start of transactional runs to expose the trace ID as a session variable,
+// e.g. PostgresTraceIDStatement.
+func WithSQLTraceIDStatement(statement string) SQLOption {
+	return func(e *SQLEngine) {
+		e.traceStatement = statement
+	}
+}
+
 func WithSQLLogger(logger Logger) SQLOption {
 	return func(se *SQLEngine) {
 		if logger != nil {
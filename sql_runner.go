@@ -16,7 +16,9 @@ type SQLEngine struct {
 	driverName     string
 	dataSourceName string
 	scriptBoundary string
+	traceStatement string
 	execCallback   func(e *SQLEngine, db *sql.DB, statement string, res sql.Result, err error) error
+	limits         SQLLimits
 }
 
 func NewSQLRunner(opts ...SQLOption) *SQLEngine {
@@ -48,18 +50,22 @@ func (e *SQLEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 		return err
 	}
 
-	logger := e.logger
-	if fl, ok := logger.(FieldsLogger); ok {
-		logger = fl.WithFields(map[string]any{
-			"engine":      e.EngineType,
-			"script_path": msg.ScriptPath,
-		})
+	logger := e.executionLogger(ctx, msg)
+
+	limits, err := e.limitsFor(msg.Config)
+	if err != nil {
+		return err
+	}
+	guard := &sqlLimitGuard{limits: limits}
+	if err := guard.checkStatements(splitSQLStatements(scriptContent, e.scriptBoundary)); err != nil {
+		logger.Error("sql script rejected", "script_path", msg.ScriptPath, "error", err)
+		return err
 	}
 
 	logger.Debug("sql script starting", "script_path", msg.ScriptPath)
 	start := time.Now()
 
-	execCtx, cancel := e.GetExecutionContext(ctx)
+	execCtx, cancel := e.GetMessageExecutionContext(ctx, msg)
 	defer cancel()
 
 	db, err := e.getDBConnection(execCtx, msg)
@@ -69,13 +75,13 @@ func (e *SQLEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 			WithMetadata(map[string]any{
 				"operation":   "establish_connection",
 				"script_path": msg.ScriptPath,
-				"config":      msg.Config,
+				"config":      redactConfigForLog(msg.Config),
 				"message_id":  msg.JobID,
-				"parameters":  msg.Parameters,
+				"parameters":  redactForLog(msg.Parameters),
 			})
 	}
 
-	if e.db == nil {
+	if db != e.db {
 		defer db.Close()
 	}
 
@@ -88,9 +94,9 @@ func (e *SQLEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 
 	var execErr error
 	if useTransaction {
-		execErr = e.executeInTransaction(execCtx, db, scriptContent)
+		execErr = e.executeInTransaction(execCtx, db, scriptContent, msg.TraceID, guard, logger)
 	} else {
-		execErr = e.executeDirectly(execCtx, db, scriptContent)
+		execErr = e.executeDirectly(execCtx, db, scriptContent, guard, logger)
 	}
 
 	duration := time.Since(start)
@@ -103,29 +109,47 @@ func (e *SQLEngine) Execute(ctx context.Context, msg *ExecutionMessage) error {
 	return nil
 }
 
+// getDBConnection picks the connection for msg. A connection profile opens
+// its own DSN, even when the engine has a client; explicit `driver`/`dsn`
+// metadata still wins over the profile's values.
 func (e *SQLEngine) getDBConnection(ctx context.Context, msg *ExecutionMessage) (*sql.DB, error) {
-	if e.db != nil {
+	profile, hasProfile, err := resolveProfile(ctx, e.profiles, e.secrets, msg.Config)
+	if err != nil {
+		return nil, err
+	}
+	if hasProfile && profile.DSN == "" {
+		hasProfile = false
+	}
+
+	if e.db != nil && !hasProfile {
 		return e.db, nil
 	}
 
-	var driverName, dataSourceName string
+	driverName, dataSourceName := e.driverName, e.dataSourceName
+	if hasProfile {
+		dataSourceName = profile.DSN
+		if profile.Driver != "" {
+			driverName = profile.Driver
+		}
+	}
 
 	if driver, ok := msg.Config.Metadata["driver"].(string); ok {
 		driverName = driver
-	} else {
-		driverName = e.driverName
 	}
 
 	if dsn, ok := msg.Config.Metadata["dsn"].(string); ok {
 		dataSourceName = dsn
-	} else {
-		dataSourceName = e.dataSourceName
 	}
 
 	if driverName == "" || dataSourceName == "" {
 		return nil, fmt.Errorf("database connection details not provided")
 	}
 
+	dataSourceName, err = ResolveSecretRefs(ctx, e.secrets, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
 	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
 		return nil, err
@@ -139,7 +163,7 @@ func (e *SQLEngine) getDBConnection(ctx context.Context, msg *ExecutionMessage)
 	return db, nil
 }
 
-func (e *SQLEngine) executeInTransaction(ctx context.Context, db *sql.DB, script string) error {
+func (e *SQLEngine) executeInTransaction(ctx context.Context, db *sql.DB, script, traceID string, guard *sqlLimitGuard, logger Logger) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, errors.CategoryExternal, "failed to start transaction").
@@ -149,12 +173,26 @@ func (e *SQLEngine) executeInTransaction(ctx context.Context, db *sql.DB, script
 			})
 	}
 
+	if e.traceStatement != "" && traceID != "" {
+		if _, err := tx.ExecContext(ctx, e.traceStatement, traceID); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, errors.CategoryExternal, "failed to set trace id session variable").
+				WithTextCode("SQL_TRACE_ID_ERROR").
+				WithMetadata(map[string]any{
+					"operation": "set_trace_id",
+					"statement": e.traceStatement,
+				})
+		}
+	}
+
 	statements := splitSQLStatements(script, e.scriptBoundary)
 
 	for i, stmt := range statements {
-		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		logger.Debug("sql statement", "statement_index", i+1, "sql", stmt)
+		res, err := tx.ExecContext(ctx, stmt, sqlStatementArgs(ctx, stmt)...)
+		if err != nil {
 			tx.Rollback()
-			return errors.Wrap(
+			return withSQLRetryHint(err, errors.Wrap(
 				err,
 				errors.CategoryExternal,
 				fmt.Sprintf("failed to execute statement %d in transaction", i+1),
@@ -165,8 +203,13 @@ func (e *SQLEngine) executeInTransaction(ctx context.Context, db *sql.DB, script
 					"statement_index":  i + 1,
 					"total_statements": len(statements),
 					"statement":        stmt,
-				})
+				}))
+		}
+		if err := guard.addRows(i, stmt, res); err != nil {
+			tx.Rollback()
+			return err
 		}
+		Heartbeat(ctx)
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -180,15 +223,16 @@ func (e *SQLEngine) executeInTransaction(ctx context.Context, db *sql.DB, script
 	return nil
 }
 
-func (e *SQLEngine) executeDirectly(ctx context.Context, db *sql.DB, script string) error {
+func (e *SQLEngine) executeDirectly(ctx context.Context, db *sql.DB, script string, guard *sqlLimitGuard, logger Logger) error {
 	// Split script into individual statements
 	statements := splitSQLStatements(script, e.scriptBoundary)
 
 	for i, stmt := range statements {
-		res, err := db.ExecContext(ctx, stmt)
+		logger.Debug("sql statement", "statement_index", i+1, "sql", stmt)
+		res, err := db.ExecContext(ctx, stmt, sqlStatementArgs(ctx, stmt)...)
 		var wrappedErr error
 		if err != nil {
-			wrappedErr = errors.Wrap(
+			wrappedErr = withSQLRetryHint(err, errors.Wrap(
 				err,
 				errors.CategoryExternal,
 				fmt.Sprintf("failed to execute statement %d", i+1),
@@ -199,7 +243,7 @@ func (e *SQLEngine) executeDirectly(ctx context.Context, db *sql.DB, script stri
 					"statement_index":  i + 1,
 					"total_statements": len(statements),
 					"statement":        stmt,
-				})
+				}))
 		}
 
 		if callbackErr := e.execCallback(e, db, stmt, res, wrappedErr); callbackErr != nil {
@@ -208,11 +252,25 @@ func (e *SQLEngine) executeDirectly(ctx context.Context, db *sql.DB, script stri
 		if wrappedErr != nil {
 			return wrappedErr
 		}
+		if err := guard.addRows(i, stmt, res); err != nil {
+			return err
+		}
+		Heartbeat(ctx)
 	}
 
 	return nil
 }
 
+// sqlStatementArgs binds the run workspace to statements referencing the
+// `:workspace` named parameter.
+func sqlStatementArgs(ctx context.Context, stmt string) []any {
+	dir, ok := WorkspaceFromContext(ctx)
+	if !ok || !strings.Contains(stmt, ":workspace") {
+		return nil
+	}
+	return []any{sql.Named("workspace", dir)}
+}
+
 func defaultExecuteCallback(e *SQLEngine, db *sql.DB, statement string, res sql.Result, err error) error {
 	e.logger.Debug("execute statement", "sql", statement)
 	if err != nil {
@@ -253,3 +311,11 @@ func splitSQLStatements(script, boundary string) []string {
 
 	return statements
 }
+
+// withSQLRetryHint attaches the SQLSTATE-derived retry hint of driverErr to wrapped.
+func withSQLRetryHint(driverErr, wrapped error) error {
+	if hint, ok := SQLStateRetryHint(driverErr); ok {
+		return WithRetryHint(wrapped, hint)
+	}
+	return wrapped
+}
@@ -73,6 +73,9 @@ func CompleteExecutionMessage(task Task, msg *ExecutionMessage) (*ExecutionMessa
 	if msg.ScriptPath != "" {
 		base.ScriptPath = msg.ScriptPath
 	}
+	if msg.ScriptChecksum != "" {
+		base.ScriptChecksum = msg.ScriptChecksum
+	}
 	base.MachineID = msg.MachineID
 	base.EntityID = msg.EntityID
 	base.ExecutionID = msg.ExecutionID
@@ -85,6 +88,10 @@ func CompleteExecutionMessage(task Task, msg *ExecutionMessage) (*ExecutionMessa
 	if msg.DedupPolicy != "" {
 		base.DedupPolicy = msg.DedupPolicy
 	}
+	if msg.TraceID != "" {
+		base.TraceID = msg.TraceID
+	}
+	base.Attempt = msg.Attempt
 	if msg.OutputCallback != nil {
 		base.OutputCallback = msg.OutputCallback
 	}
@@ -108,16 +115,52 @@ func CompleteExecutionMessage(task Task, msg *ExecutionMessage) (*ExecutionMessa
 
 // TaskCommander adapts a Task to the command.Commander interface.
 type TaskCommander struct {
-	Task     Task
-	tracker  *IdempotencyTracker
-	store    qidempotency.Store
-	storeTTL time.Duration
-	limiter  *ConcurrencyLimiter
-	quotas   QuotaChecker
-	scope    func(*ExecutionMessage) string
-	retries  *int
+	Task        Task
+	tracker     *IdempotencyTracker
+	store       qidempotency.Store
+	storeTTL    time.Duration
+	limiter     *ConcurrencyLimiter
+	quotas      QuotaChecker
+	scope       func(*ExecutionMessage) string
+	retries     *int
+	audit       AuditSink
+	beats       *HeartbeatMonitor
+	onSlow      SlowExecutionHandler
+	runLogs     *RunLogStore
+	notify      map[string]Notifier
+	events      *RunEventBroker
+	toggles     TaskToggler
+	auth        *GoAuthAdapter
+	tenants     *TenantConfigs
+	authorizer  ExecutionAuthorizer
+	results     ResultRecorder
+	redactor    EnvelopeSanitizer
+	onExit      ExitOnErrorHandler
+	backoffs    []BackoffResolver
+	hintMax     time.Duration
+	failures    *FailureStore
+	faults      *FaultInjector
+	clock       Clock
+	archive     *RunArchive
+	workspaces  *WorkspaceManager
+	refresh     func(id string) (Task, bool)
+	skew        *time.Duration
+	runs        *RunCache
+	history     ExecutionHistory
+	callbacks   *ResultCallbackSender
+	maintenance *MaintenanceMode
+	beforeRun   []BeforeRunHook
+	afterRun    []AfterRunHook
+	logger      Logger
 }
 
+// ErrTaskDisabled is returned when a disabled task is executed.
+var ErrTaskDisabled = errors.New("task disabled", errors.CategoryOperation).
+	WithTextCode("JOB_DISABLED")
+
+// ResultStatusDisabled is the Result status recorded when a disabled task is skipped.
+const ResultStatusDisabled = "disabled"
+
 func NewTaskCommander(task Task) *TaskCommander {
 	return &TaskCommander{
 		Task:     task,
@@ -125,7 +168,48 @@ func NewTaskCommander(task Task) *TaskCommander {
 		storeTTL: 24 * time.Hour,
 		limiter:  defaultConcurrencyLimiter,
 		quotas:   defaultQuotaChecker,
+		redactor: defaultRedactor,
+	}
+}
+
+// WithClock sets the time source for retry backoff sleeps, run durations and
+// idempotency record expiry. Defaults to SystemClock.
+func (c *TaskCommander) WithClock(clock Clock) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.clock = clock
+	return c
+}
+
+// WithLogger sets the logger reporting failures that do not fail the run,
+// such as execution history writes.
+func (c *TaskCommander) WithLogger(logger Logger) *TaskCommander {
+	if c == nil {
+		return nil
 	}
+	c.logger = logger
+	return c
+}
+
+func (c *TaskCommander) log() Logger {
+	if c.logger == nil {
+		return newStdLoggerProvider().GetLogger("job:commander")
+	}
+	return c.logger
+}
+
+func (c *TaskCommander) now() time.Time {
+	return clockOrSystem(c.clock).Now()
+}
+
+// sleep waits between retry attempts. Without a clock it uses the package
+// backoff sleeper, which TestSetBackoffSleep can replace.
+func (c *TaskCommander) sleep(ctx context.Context, delay time.Duration) error {
+	if c.clock == nil {
+		return backoffSleep(ctx, delay)
+	}
+	return sleepWithClock(ctx, c.clock, delay)
 }
 
 // WithIdempotencyTracker overrides the tracker used for deduplication checks.
@@ -190,7 +274,150 @@ func (c *TaskCommander) WithRetryOverride(maxRetries int) *TaskCommander {
 	return c
 }
 
+// WithAuditSink records an audit entry for every execution triggered through this commander.
+func (c *TaskCommander) WithAuditSink(sink AuditSink) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.audit = sink
+	return c
+}
+
+// WithHeartbeatMonitor tracks executions so runs that stop heartbeating are flagged as hung.
+func (c *TaskCommander) WithHeartbeatMonitor(monitor *HeartbeatMonitor) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.beats = monitor
+	return c
+}
+
+// WithSlowExecutionHandler is notified when an attempt exceeds Config.WarnAfter.
+func (c *TaskCommander) WithSlowExecutionHandler(handler SlowExecutionHandler) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.onSlow = handler
+	return c
+}
+
+// WithRunLogStore captures each run's engine and script logs into a ring buffer
+// retrievable by run ID.
+func (c *TaskCommander) WithRunLogStore(store *RunLogStore) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.runLogs = store
+	return c
+}
+
+// WithNotifier registers a named notifier; the task's `notify` metadata policy
+// selects which events reach it.
+func (c *TaskCommander) WithNotifier(name string, notifier Notifier) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	if notifier == nil {
+		return c
+	}
+	if c.notify == nil {
+		c.notify = make(map[string]Notifier)
+	}
+	c.notify[name] = notifier
+	return c
+}
+
+// WithRunEvents publishes lifecycle and log events for each run to broker.
+func (c *TaskCommander) WithRunEvents(broker *RunEventBroker) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.events = broker
+	return c
+}
+
+// WithBackoffResolver adds a resolver choosing the retry backoff from the
+// failure; resolvers run in order before Config.Backoff.OnError is consulted.
+func (c *TaskCommander) WithBackoffResolver(resolver BackoffResolver) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	if resolver != nil {
+		c.backoffs = append(c.backoffs, resolver)
+	}
+	return c
+}
+
+// WithRetryHintMaxWait caps the wait a RetryHint, such as a Retry-After
+// header, can add before the next attempt. It defaults to
+// DefaultRetryHintMaxWait.
+func (c *TaskCommander) WithRetryHintMaxWait(maxWait time.Duration) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.hintMax = maxWait
+	return c
+}
+
+// WithConfigRefresh re-resolves the task through lookup, typically
+// Registry.Get, before every retry so changes made between attempts (a raised
+// timeout, more retries, a fixed script) apply to the next attempt. The
+// previous attempt's task and message are kept when lookup misses or the
+// refreshed message cannot be built.
+func (c *TaskCommander) WithConfigRefresh(lookup func(id string) (Task, bool)) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.refresh = lookup
+	return c
+}
+
+// WithTaskToggler makes the commander refuse tasks the toggler reports as disabled.
+func (c *TaskCommander) WithTaskToggler(toggles TaskToggler) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.toggles = toggles
+	return c
+}
+
+// RegistryCommander returns a factory of plain commanders that refuse the
+// tasks registry reports as disabled, when it implements TaskToggler. Trigger
+// surfaces built from a bare registry default to it; prefer Runner.Commander
+// when a runner is available, which also honours maintenance mode, hooks and
+// history.
+func RegistryCommander(registry Registry) func(Task) *TaskCommander {
+	toggles, ok := registry.(TaskToggler)
+	if !ok {
+		return NewTaskCommander
+	}
+	return func(task Task) *TaskCommander {
+		return NewTaskCommander(task).WithTaskToggler(toggles)
+	}
+}
+
 func (c *TaskCommander) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	_, err := c.ExecuteWithReport(ctx, msg)
+	return err
+}
+
+// ExecuteWithReport runs msg like Execute and returns an ExecutionReport
+// describing the run alongside its error. The report's result metadata is
+// redacted before it reaches the run cache, history and result callbacks.
+func (c *TaskCommander) ExecuteWithReport(ctx context.Context, msg *ExecutionMessage) (ExecutionReport, error) {
+	var report ExecutionReport
+	err := c.execute(ctx, msg, &report)
+	report.finish(err)
+	if c != nil {
+		report.Result = RedactResult(report.Result, c.redactor)
+		c.runs.Record(report)
+		c.recordHistory(ctx, msg, report, err)
+		c.sendResultCallback(msg, report)
+	}
+	return report, err
+}
+
+func (c *TaskCommander) execute(ctx context.Context, msg *ExecutionMessage, report *ExecutionReport) (err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -209,28 +436,85 @@ func (c *TaskCommander) Execute(ctx context.Context, msg *ExecutionMessage) erro
 			WithTextCode("JOB_TASK_MISSING")
 	}
 
-	finalMsg, err := CompleteExecutionMessage(c.Task, msg)
+	var pristine *ExecutionMessage
+	if c.refresh != nil {
+		pristine = cloneExecutionMessage(msg)
+	}
+
+	task := c.Task
+	finalMsg, err := c.completeMessage(ctx, task, msg)
 	if err != nil {
 		return err
 	}
+	ctx = resolveTraceID(ctx, finalMsg)
+	report.JobID = finalMsg.JobID
+	report.TraceID = finalMsg.TraceID
 
 	if err := finalMsg.Validate(); err != nil {
 		return errors.Wrap(err, errors.CategoryBadInput, "invalid execution message").
 			WithTextCode("JOB_EXEC_MSG_INVALID")
 	}
 
+	if c.toggles != nil && !c.toggles.IsEnabled(finalMsg.JobID) {
+		c.recordResult(finalMsg.JobID, Result{
+			Status:  ResultStatusDisabled,
+			Message: "task is disabled",
+		})
+		report.Result = Result{Status: ResultStatusDisabled, Message: "task is disabled"}
+		return ErrTaskDisabled
+	}
+
+	if state := c.maintenance.State(); state.Enabled {
+		c.recordResult(finalMsg.JobID, state.result())
+		report.Result = state.result()
+		return ErrMaintenanceMode
+	}
+
+	ctx, cancelDeadline, err := c.checkDeadline(ctx, finalMsg)
+	if err != nil {
+		c.recordResult(finalMsg.JobID, Result{Status: ResultStatusExpired, Message: err.Error()})
+		report.Result = Result{Status: ResultStatusExpired}
+		return err
+	}
+	defer cancelDeadline()
+
+	if err := c.authorizeExecution(ctx, finalMsg); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, c.audit, AuditEntry{
+		Action: AuditActionJobTriggered,
+		JobID:  finalMsg.JobID,
+		Metadata: map[string]any{
+			"script_path":     finalMsg.ScriptPath,
+			"script_checksum": finalMsg.ScriptChecksum,
+			"idempotency_key": finalMsg.IdempotencyKey,
+		},
+	})
+
 	decision, prevErr, dedupErr := c.dedupBeforeExecute(ctx, finalMsg)
 	if dedupErr != nil {
 		return dedupErr
 	}
 	switch decision {
 	case dedupDrop:
+		report.Result = Result{Status: ResultStatusSkipped, Message: "duplicate execution dropped"}
 		return ErrIdempotentDrop
 	case dedupMerge:
+		report.Result = Result{Status: ResultStatusSkipped, Message: "merged into previous execution"}
+		if prevErr != nil {
+			report.Result.Status = ResultStatusFailed
+		}
 		return prevErr
+	case dedupMergeSucceeded:
+		report.Result = Result{Status: ResultStatusSucceeded, Message: "merged into previous execution"}
+		return nil
 	}
 
 	if err := c.quotas.Check(finalMsg); err != nil {
+		if denial, ok := QuotaDenialFrom(err); ok {
+			report.Result = Result{Status: ResultStatusFailed, Metadata: map[string]any{"quota": denial}}
+		}
 		return err
 	}
 
@@ -240,31 +524,248 @@ func (c *TaskCommander) Execute(ctx context.Context, msg *ExecutionMessage) erro
 	}
 	defer release()
 
+	if c.beats != nil {
+		var done func()
+		ctx, done = c.beats.Track(ctx, finalMsg)
+		defer done()
+	}
+
 	defer c.dedupAfterExecute(ctx, finalMsg, &err)
 
-	maxRetries := finalMsg.Config.Retries
-	if c.retries != nil {
-		maxRetries = *c.retries
-	}
+	maxRetries := c.maxRetries(task, finalMsg)
 	backoffCfg := finalMsg.Config.Backoff
 
+	runID := RunIDFromContext(ctx)
+	if runID == "" {
+		runID = NewRunID()
+	}
+	c.archive.Record(ctx, runID, finalMsg)
+	if c.workspaces != nil {
+		ws, wsErr := c.workspaces.Open(finalMsg.JobID, runID)
+		if wsErr != nil {
+			return wsErr
+		}
+		ctx = ContextWithWorkspace(ctx, ws.Dir)
+		defer c.workspaces.Finish(context.WithoutCancel(ctx), ws, artifactPatterns(finalMsg.Config))
+	}
+	capture := c.runCapture(runID, finalMsg)
+	onSlow := c.slowExecutionHandler(ctx, finalMsg, runID)
+
+	started := c.now()
+	attempts := 0
+	var reported *reportedResult
+	report.RunID = runID
+	report.StartedAt = started
+	report.Environment = runEnvironmentFor(task.GetEngine(), finalMsg)
+	c.events.Publish(RunEvent{Type: RunEventStarted, RunID: runID, JobID: finalMsg.JobID, ScriptChecksum: finalMsg.ScriptChecksum})
+	defer func() {
+		report.Attempts = attempts
+		report.Duration = c.now().Sub(started)
+		if result, ok := reported.get(); ok {
+			if err != nil {
+				// The run's outcome decides the status; keep what the script said.
+				result.Status = ""
+			}
+			report.Result = result
+		}
+		if c.expiredDuringRun(finalMsg, err) {
+			report.Result.Status = ResultStatusExpired
+		}
+		c.finishRun(ctx, finalMsg, runID, started, attempts, err)
+	}()
+
+	if len(c.beforeRun) > 0 || len(c.afterRun) > 0 {
+		ctx = contextWithRunScope(ctx, runScope{RunID: runID, JobID: finalMsg.JobID})
+		defer func() {
+			err = c.runAfterHooks(ctx, finalMsg, err)
+		}()
+		if ctx, err = c.runBeforeHooks(ctx, finalMsg); err != nil {
+			return err
+		}
+	}
+
 	for attempt := 0; ; attempt++ {
-		err = c.Task.Execute(ctx, finalMsg)
+		attempts = attempt + 1
+		if attempt > 0 && c.refresh != nil {
+			if refreshed, refreshedMsg, ok := c.refreshAttempt(ctx, pristine, finalMsg); ok {
+				task, finalMsg = refreshed, refreshedMsg
+				maxRetries = c.maxRetries(task, finalMsg)
+				backoffCfg = finalMsg.Config.Backoff
+			}
+		}
+		finalMsg.Attempt = attempts
+		c.events.Publish(RunEvent{Type: RunEventAttempt, RunID: runID, JobID: finalMsg.JobID, Attempt: attempts})
+		reported = &reportedResult{}
+		attemptCtx := contextWithRunScope(ctx, runScope{
+			RunID:   runID,
+			JobID:   finalMsg.JobID,
+			Attempt: attempts,
+			capture: capture,
+			results: reported,
+		})
+		stopWatch := watchSlowExecution(finalMsg, attempt+1, onSlow)
+		attemptStarted := c.now()
+		err = recoverExecution(finalMsg.JobID, func() error {
+			if err := c.faults.Inject(attemptCtx, task, finalMsg); err != nil {
+				return err
+			}
+			if err := task.Execute(attemptCtx, finalMsg); err != nil {
+				return err
+			}
+			return reported.err()
+		})
+		stopWatch()
+		report.recordAttempt(attempts, attemptStarted, c.now(), err)
 		if err == nil {
 			return nil
 		}
+		if IsPanic(err) {
+			c.recordPanic(ctx, finalMsg, err)
+			c.exitOnError(ctx, finalMsg, runID, err)
+			return err
+		}
 
-		if attempt >= maxRetries {
+		hint, _ := RetryHintFrom(err)
+		if attempt >= maxRetries || hint.NoRetry {
+			c.exitOnError(ctx, finalMsg, runID, err)
 			return err
 		}
 
-		delay := computeBackoffDelay(attempt+1, backoffCfg)
-		if sleepErr := backoffSleep(ctx, delay); sleepErr != nil {
+		delay := retryHintDelay(computeBackoffDelay(attempt+1, resolveBackoff(err, backoffCfg, c.backoffs)), hint, c.hintMax)
+		report.Backoff = append(report.Backoff, delay)
+		report.AttemptLog[len(report.AttemptLog)-1].Backoff = delay
+		if sleepErr := c.sleep(ctx, delay); sleepErr != nil {
 			return sleepErr
 		}
 	}
 }
 
+// completeMessage merges msg with task defaults and applies the caller's
+// tenant overlay.
+func (c *TaskCommander) completeMessage(ctx context.Context, task Task, msg *ExecutionMessage) (*ExecutionMessage, error) {
+	finalMsg, err := CompleteExecutionMessage(task, msg)
+	if err != nil {
+		return nil, err
+	}
+	if c.tenants != nil {
+		if _, scope, ok := ActorFromContext(ctx); ok {
+			if err := c.tenants.Apply(finalMsg, scope); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return finalMsg, nil
+}
+
+// refreshAttempt rebuilds the message for the next attempt from the task
+// currently registered under current.JobID, keeping the run's trace ID.
+func (c *TaskCommander) refreshAttempt(ctx context.Context, pristine, current *ExecutionMessage) (Task, *ExecutionMessage, bool) {
+	task, ok := c.refresh(current.JobID)
+	if !ok || task == nil {
+		return nil, nil, false
+	}
+	msg := cloneExecutionMessage(pristine)
+	msg.JobID = current.JobID
+	msg.TraceID = current.TraceID
+	refreshed, err := c.completeMessage(ctx, task, msg)
+	if err != nil || refreshed.Validate() != nil {
+		return nil, nil, false
+	}
+	return task, refreshed, true
+}
+
+// maxRetries resolves the retry budget: the commander override, then the
+// message config, then the task's handler options.
+func (c *TaskCommander) maxRetries(task Task, msg *ExecutionMessage) int {
+	if c.retries != nil {
+		return *c.retries
+	}
+	if msg.Config.Retries != 0 {
+		return msg.Config.Retries
+	}
+	return task.GetHandlerConfig().MaxRetries
+}
+
+// runCapture builds the sink receiving the run's engine and script log entries.
+func (c *TaskCommander) runCapture(runID string, msg *ExecutionMessage) func(RunLogEntry) {
+	buffer := c.runLogs.buffer(runID, msg.JobID, runLogRetention(msg.Config))
+	if buffer == nil && c.events == nil {
+		return nil
+	}
+	return func(entry RunLogEntry) {
+		buffer.Append(entry)
+		if c.events != nil {
+			jobID, _ := entry.Fields["job_id"].(string)
+			attempt, _ := entry.Fields["attempt"].(int)
+			c.events.Publish(RunEvent{Type: RunEventLog, RunID: runID, JobID: jobID, Attempt: attempt, Time: entry.Time, Log: &entry})
+		}
+	}
+}
+
+func (c *TaskCommander) slowExecutionHandler(ctx context.Context, msg *ExecutionMessage, runID string) SlowExecutionHandler {
+	if len(c.notify) == 0 && c.events == nil {
+		return c.onSlow
+	}
+	policy := NotifyPolicyFromConfig(msg.Config)
+	owner, team := OwnershipFromConfig(msg.Config)
+	return func(event SlowExecutionEvent) {
+		if c.onSlow != nil {
+			c.onSlow(event)
+		}
+		c.events.Publish(RunEvent{
+			Type:     RunEventSlow,
+			RunID:    runID,
+			JobID:    event.JobID,
+			Attempt:  event.Attempt,
+			Duration: event.Elapsed,
+		})
+		dispatchNotification(ctx, c.notify, policy, Notification{
+			Status:     NotifySlow,
+			JobID:      event.JobID,
+			RunID:      runID,
+			TraceID:    event.TraceID,
+			ScriptPath: event.ScriptPath,
+			Attempts:   event.Attempt,
+			Duration:   event.Elapsed,
+			Owner:      owner,
+			Team:       team,
+		})
+	}
+}
+
+// finishRun publishes the terminal run event and delivers completion notifications.
+func (c *TaskCommander) finishRun(ctx context.Context, msg *ExecutionMessage, runID string, started time.Time, attempts int, execErr error) {
+	duration := c.now().Sub(started)
+	event := RunEvent{
+		Type:           RunEventSucceeded,
+		RunID:          runID,
+		JobID:          msg.JobID,
+		ScriptChecksum: msg.ScriptChecksum,
+		Attempt:        attempts,
+		Duration:       duration,
+	}
+	n := Notification{
+		Status:         NotifySuccess,
+		JobID:          msg.JobID,
+		RunID:          runID,
+		TraceID:        msg.TraceID,
+		ScriptPath:     msg.ScriptPath,
+		ScriptChecksum: msg.ScriptChecksum,
+		Attempts:       attempts,
+		Duration:       duration,
+	}
+	n.Owner, n.Team = OwnershipFromConfig(msg.Config)
+	if execErr != nil {
+		event.Type = RunEventFailed
+		event.Error = execErr.Error()
+		n.Status = NotifyFailure
+		n.Error = execErr.Error()
+	}
+	c.events.Publish(event)
+	c.failures.Record(msg.JobID, runID, execErr)
+	dispatchNotification(ctx, c.notify, NotifyPolicyFromConfig(msg.Config), n)
+}
+
 func (c *TaskCommander) dedupBeforeExecute(ctx context.Context, msg *ExecutionMessage) (dedupDecision, error, error) {
 	if c == nil || c.store == nil {
 		decision, prevErr := dedupBeforeExecute(c.tracker, msg)
@@ -289,11 +790,14 @@ func (c *TaskCommander) dedupBeforeExecute(ctx context.Context, msg *ExecutionMe
 		if record.Status == qidempotency.StatusFailed && len(record.Payload) > 0 {
 			return dedupMerge, fmt.Errorf("%s", string(record.Payload)), nil
 		}
+		if record.Status == qidempotency.StatusCompleted {
+			return dedupMergeSucceeded, nil, nil
+		}
 		return dedupMerge, nil, nil
 	case DedupPolicyReplace:
 		status := qidempotency.StatusPending
 		emptyPayload := []byte(nil)
-		expiresAt := time.Now().UTC().Add(c.idempotencyTTL())
+		expiresAt := c.now().UTC().Add(c.idempotencyTTL())
 		if err := c.store.Update(ctx, msg.IdempotencyKey, qidempotency.Update{
 			Status:    &status,
 			Payload:   &emptyPayload,
@@ -322,7 +826,7 @@ func (c *TaskCommander) dedupAfterExecute(ctx context.Context, msg *ExecutionMes
 		status = qidempotency.StatusFailed
 		payload = []byte((*execErr).Error())
 	}
-	expiresAt := time.Now().UTC().Add(c.idempotencyTTL())
+	expiresAt := c.now().UTC().Add(c.idempotencyTTL())
 	_ = c.store.Update(ctx, msg.IdempotencyKey, qidempotency.Update{
 		Status:    &status,
 		Payload:   &payload,
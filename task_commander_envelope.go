@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+
+	"github.com/goliatone/go-errors"
+)
+
+// WithAuthAdapter lets ExecuteEnvelope fill missing actor/scope from the
+// caller's auth context and inject the envelope actor back into it.
+func (c *TaskCommander) WithAuthAdapter(adapter GoAuthAdapter) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.auth = &adapter
+	return c
+}
+
+// ExecuteEnvelope validates env and runs the task with its Params as
+// Parameters and its IdempotencyKey, attaching the actor/scope to ctx (via
+// the auth adapter when configured) so audit and authorization see the
+// caller. jobID may be empty; otherwise it must match the commander's task.
+func (c *TaskCommander) ExecuteEnvelope(ctx context.Context, jobID string, env Envelope) error {
+	if c == nil || c.Task == nil {
+		return errors.New("task not configured", errors.CategoryInternal).
+			WithTextCode("JOB_TASK_MISSING")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	taskID := c.Task.GetID()
+	if jobID == "" {
+		jobID = taskID
+	}
+	if jobID != taskID {
+		return errors.NewValidation("envelope job does not match task", errors.FieldError{
+			Field:   "job_id",
+			Message: "must match the commander task " + taskID,
+			Value:   jobID,
+		})
+	}
+	if err := env.Validate(); err != nil {
+		return err
+	}
+
+	if c.auth != nil {
+		env = c.auth.AttachActor(ctx, env)
+		ctx = c.auth.InjectActor(ctx, env)
+	}
+	if env.Actor != nil || !env.Scope.isEmpty() {
+		ctx = ContextWithEnvelope(ctx, env)
+	}
+
+	return c.Execute(ctx, &ExecutionMessage{
+		JobID:          jobID,
+		Parameters:     copyParams(env.Params),
+		IdempotencyKey: env.IdempotencyKey,
+	})
+}
+
+// ExecuteEncodedEnvelope decodes a JSON envelope with opts (size limits,
+// sanitizers) and runs it through ExecuteEnvelope.
+func (c *TaskCommander) ExecuteEncodedEnvelope(ctx context.Context, jobID string, data []byte, opts ...EnvelopeOption) error {
+	env, err := DecodeEnvelope(data, opts...)
+	if err != nil {
+		return err
+	}
+	return c.ExecuteEnvelope(ctx, jobID, env)
+}
@@ -0,0 +1,143 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCommanderExecuteEnvelope(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("report", Config{})}
+	cmd := NewTaskCommander(task).WithAuthAdapter(GoAuthAdapter{Authenticator: staticAuthenticator{
+		actor: map[string]any{"actor_id": "svc-1", "role": "system", "tenant_id": "acme"},
+	}})
+
+	params := map[string]any{"month": "2026-09"}
+	require.NoError(t, cmd.ExecuteEnvelope(context.Background(), "", Envelope{
+		Params:         params,
+		IdempotencyKey: "report-2026-09",
+	}))
+
+	require.Len(t, task.msgs, 1)
+	msg := task.msgs[0]
+	assert.Equal(t, "report", msg.JobID)
+	assert.Equal(t, "2026-09", msg.Parameters["month"])
+	assert.Equal(t, "report-2026-09", msg.IdempotencyKey)
+
+	actor, scope, ok := ActorFromContext(task.ctxs[0])
+	require.True(t, ok)
+	assert.Equal(t, "svc-1", actor.ID)
+	assert.Equal(t, "acme", scope.TenantID)
+
+	msg.Parameters["month"] = "changed"
+	assert.Equal(t, "2026-09", params["month"], "envelope params are copied")
+}
+
+func TestTaskCommanderExecuteEnvelopeRejects(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("report", Config{})}
+	cmd := NewTaskCommander(task)
+
+	err := cmd.ExecuteEnvelope(context.Background(), "other", Envelope{})
+	assert.ErrorContains(t, err, "does not match")
+
+	err = cmd.ExecuteEnvelope(context.Background(), "report", Envelope{Actor: &Actor{ID: "a", IsImpersonated: true}})
+	assert.Error(t, err)
+
+	err = cmd.ExecuteEncodedEnvelope(context.Background(), "report", []byte(`{"params":`))
+	assert.Error(t, err)
+	assert.Empty(t, task.msgs)
+
+	require.NoError(t, cmd.ExecuteEncodedEnvelope(context.Background(), "report", []byte(`{"params":{"n":1}}`)))
+	require.Len(t, task.msgs, 1)
+	assert.EqualValues(t, 1, task.msgs[0].Parameters["n"])
+}
+
+func TestTaskCommanderTenantOverlays(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("export", Config{
+		Env:      map[string]string{"REGION": "eu", "LEVEL": "info"},
+		Metadata: map[string]any{"driver": "postgres", "dsn": "postgres://default"},
+	})}
+	tenants := NewTenantConfigs().
+		WithConnection("acme-db", SQLConnection{Driver: "postgres", DSN: "postgres://acme:${secret:acme_db}@db/acme"}).
+		WithTenant("acme", TenantOverlay{
+			Env:            map[string]string{"LEVEL": "debug"},
+			Connection:     "acme-db",
+			MaxConcurrency: 2,
+		}).
+		WithTenant("broken", TenantOverlay{Connection: "missing"})
+	cmd := NewTaskCommander(task).WithTenantConfigs(tenants)
+
+	require.NoError(t, cmd.ExecuteEnvelope(context.Background(), "", Envelope{Scope: Scope{TenantID: "acme"}}))
+	cfg := task.msgs[0].Config
+	assert.Equal(t, map[string]string{"REGION": "eu", "LEVEL": "debug"}, cfg.Env)
+	assert.Equal(t, "postgres://acme:${secret:acme_db}@db/acme", cfg.Metadata["dsn"])
+	assert.Equal(t, 2, cfg.MaxConcurrency)
+	assert.Equal(t, "acme", TenantScopeExtractor(task.msgs[0]))
+	assert.Equal(t, "postgres://default", task.GetConfig().Metadata["dsn"], "task config is not mutated")
+
+	require.NoError(t, cmd.ExecuteEnvelope(context.Background(), "", Envelope{Scope: Scope{TenantID: "other"}}))
+	assert.Equal(t, "postgres://default", task.msgs[1].Config.Metadata["dsn"])
+
+	err := cmd.ExecuteEnvelope(context.Background(), "", Envelope{Scope: Scope{TenantID: "broken"}})
+	assert.ErrorContains(t, err, "unknown connection")
+	assert.Len(t, task.msgs, 2)
+}
+
+func TestTaskCommanderExecutionAuthorizer(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("export", Config{})}
+	audit := NewMemoryAuditSink()
+	cmd := NewTaskCommander(task).
+		WithAuditSink(audit).
+		WithExecutionAuthorizer(PolicyExecutionAuthorizer(RolePolicy(map[AuthzAction][]string{
+			AuthzActionTriggerJob: {"admin", "operator"},
+		})))
+
+	admin := ContextWithActor(context.Background(), &Actor{ID: "u-1", Role: "admin"}, Scope{})
+	require.NoError(t, cmd.Execute(admin, &ExecutionMessage{JobID: "export"}))
+
+	owner := ContextWithActor(context.Background(), &Actor{ID: "u-2", Role: "viewer", ResourceRoles: map[string]string{"export": "operator"}}, Scope{})
+	require.NoError(t, cmd.Execute(owner, &ExecutionMessage{JobID: "export"}))
+
+	require.NoError(t, cmd.Execute(context.Background(), &ExecutionMessage{JobID: "export"}), "scheduled runs carry no actor")
+
+	viewer := ContextWithActor(context.Background(), &Actor{ID: "u-3", Role: "viewer"}, Scope{})
+	err := cmd.Execute(viewer, &ExecutionMessage{JobID: "export"})
+	assert.True(t, IsForbidden(err))
+	assert.Len(t, task.msgs, 3)
+
+	entries := audit.Entries()
+	last := entries[len(entries)-1]
+	assert.Equal(t, AuditActionJobDenied, last.Action)
+	assert.Equal(t, "role not permitted", last.Metadata["reason"])
+}
+
+func TestTaskCommanderExecutionAuthorizerReason(t *testing.T) {
+	task := &capturingTask{stubTask: newStubTask("export", Config{})}
+	var seen ExecutionAuthzRequest
+	audit := NewMemoryAuditSink()
+	cmd := NewTaskCommander(task).WithAuditSink(audit).WithExecutionAuthorizer(ExecutionAuthorizerFunc(
+		func(_ context.Context, req ExecutionAuthzRequest) (ExecutionDecision, error) {
+			seen = req
+			if req.Parameters["limit"] == 10 {
+				return AllowExecution(), nil
+			}
+			return DenyExecution("limit must be 10"), nil
+		}))
+
+	ctx := ContextWithActor(context.Background(), &Actor{ID: "u-1"}, Scope{TenantID: "acme"})
+	require.NoError(t, cmd.Execute(ctx, &ExecutionMessage{JobID: "export", Parameters: map[string]any{"limit": 10, "token": "t"}}))
+	assert.Equal(t, "u-1", seen.Actor.ID)
+	assert.Equal(t, "acme", seen.Scope.TenantID)
+	assert.Equal(t, "t", seen.Parameters["token"], "authorizers see raw parameters")
+
+	err := cmd.Execute(ctx, &ExecutionMessage{JobID: "export", Parameters: map[string]any{"limit": 50, "token": "t"}})
+	assert.True(t, IsForbidden(err))
+	assert.Len(t, task.msgs, 1)
+
+	entries := audit.Entries()
+	denied := entries[len(entries)-1]
+	assert.Equal(t, AuditActionJobDenied, denied.Action)
+	assert.Equal(t, map[string]any{"limit": 50, "token": RedactedValue}, denied.Metadata["parameters"])
+}
@@ -3,9 +3,13 @@ package job
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 )
 
 type taskCreator struct {
+	mu             sync.RWMutex
 	engines        []Engine
 	errorHandler   func(Task, error)
 	sourceProvider SourceProvider
@@ -13,6 +17,13 @@ type taskCreator struct {
 	loggerProvider LoggerProvider
 	taskIDProvider TaskIDProvider
 	eventHandlers  []TaskEventHandler
+	configDefaults ConfigDefaults
+	secrets        SecretsProvider
+	profiles       ProfileStore
+	invoker        JobInvoker
+	overrides      OverrideStore
+	globalEnv      map[string]string
+	namespace      string
 }
 
 func NewTaskCreator(provider SourceProvider, engines []Engine) *taskCreator {
@@ -74,6 +85,87 @@ func (f *taskCreator) SetTaskIDProvider(provider TaskIDProvider) {
 	f.applyTaskIDProvider()
 }
 
+// WithNamespace prefixes the IDs of the tasks this creator discovers, e.g.
+// "db:" or "git:", so creators feeding one Runner cannot collide. Overrides
+// and skip events use the prefixed IDs.
+func (f *taskCreator) WithNamespace(prefix string) *taskCreator {
+	f.SetNamespace(prefix)
+	return f
+}
+
+// SetNamespace satisfies NamespaceAware.
+func (f *taskCreator) SetNamespace(prefix string) {
+	f.namespace = prefix
+}
+
+// Namespace returns the prefix applied to discovered task IDs.
+func (f *taskCreator) Namespace() string {
+	return f.namespace
+}
+
+// WithConfigDefaults sets config applied to every discovered script beneath its
+// own metadata.
+func (f *taskCreator) WithConfigDefaults(cfg Config) *taskCreator {
+	f.configDefaults.Global = cfg
+	return f
+}
+
+// WithEngineConfigDefaults sets a per-engine profile (e.g. "sql") layered over
+// the global defaults.
+func (f *taskCreator) WithEngineConfigDefaults(engineType string, cfg Config) *taskCreator {
+	f.configDefaults = f.configDefaults.withEngine(engineType, cfg)
+	return f
+}
+
+// SetConfigDefaults satisfies ConfigDefaultsAware.
+func (f *taskCreator) SetConfigDefaults(defaults ConfigDefaults) {
+	f.configDefaults = defaults
+}
+
+// WithSecretsProvider sets the provider engines use to resolve `${secret:...}` references.
+func (f *taskCreator) WithSecretsProvider(provider SecretsProvider) *taskCreator {
+	f.SetSecretsProvider(provider)
+	return f
+}
+
+// SetSecretsProvider satisfies SecretsAware.
+func (f *taskCreator) SetSecretsProvider(provider SecretsProvider) {
+	f.secrets = provider
+}
+
+// WithProfileStore sets the store engines use to resolve connection profiles.
+func (f *taskCreator) WithProfileStore(store ProfileStore) *taskCreator {
+	f.SetProfileStore(store)
+	return f
+}
+
+// SetProfileStore satisfies ProfileStoreAware.
+func (f *taskCreator) SetProfileStore(store ProfileStore) {
+	f.profiles = store
+}
+
+// WithJobInvoker sets the invoker engines use for script-to-script invocation.
+func (f *taskCreator) WithJobInvoker(invoker JobInvoker) *taskCreator {
+	f.SetJobInvoker(invoker)
+	return f
+}
+
+// SetJobInvoker satisfies JobInvokerAware.
+func (f *taskCreator) SetJobInvoker(invoker JobInvoker) {
+	f.invoker = invoker
+}
+
+// WithOverrideStore sets the operator overrides merged on top of script metadata.
+func (f *taskCreator) WithOverrideStore(store OverrideStore) *taskCreator {
+	f.SetOverrideStore(store)
+	return f
+}
+
+// SetOverrideStore satisfies OverrideStoreAware.
+func (f *taskCreator) SetOverrideStore(store OverrideStore) {
+	f.overrides = store
+}
+
 // AddTaskEventHandler registers an observer for task registration events.
 func (f *taskCreator) AddTaskEventHandler(handler TaskEventHandler) {
 	if handler != nil {
@@ -81,6 +173,48 @@ func (f *taskCreator) AddTaskEventHandler(handler TaskEventHandler) {
 	}
 }
 
+// AddEngine registers engine for the next CreateTasks call, replacing an
+// engine with the same Name. The engine receives the creator's logger, task
+// ID provider, config defaults and secrets. Safe to call while discovery runs;
+// an in-flight CreateTasks keeps the engines it started with.
+func (f *taskCreator) AddEngine(engine Engine) {
+	if engine == nil {
+		return
+	}
+	f.configureEngine(engine)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, existing := range f.engines {
+		if existing.Name() == engine.Name() {
+			f.engines[i] = engine
+			return
+		}
+	}
+	f.engines = append(f.engines, engine)
+}
+
+// RemoveEngine drops the engine called name and reports whether it was
+// registered. Tasks it created stay registered until the next discovery.
+func (f *taskCreator) RemoveEngine(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, existing := range f.engines {
+		if existing.Name() == name {
+			f.engines = append(f.engines[:i:i], f.engines[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Engines returns the registered engines in matching order.
+func (f *taskCreator) Engines() []Engine {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]Engine(nil), f.engines...)
+}
+
 // WithErrorHandler sets a custom error handler
 func (f *taskCreator) WithErrorHandler(handler func(Task, error)) *taskCreator {
 	f.errorHandler = handler
@@ -88,13 +222,27 @@ func (f *taskCreator) WithErrorHandler(handler func(Task, error)) *taskCreator {
 }
 
 func (r *taskCreator) CreateTasks(ctx context.Context) ([]Task, error) {
-	r.applyTaskIDProvider()
+	engines := r.Engines()
+	for _, engine := range engines {
+		r.applyEngineOptions(engine)
+	}
+
+	if reporter, ok := r.sourceProvider.(ScriptSkipReporter); ok {
+		reporter.SetScriptSkipHandler(r.reportProviderSkip)
+	}
 
 	scripts, err := r.sourceProvider.ListScripts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list scripts: %w", err)
 	}
 
+	var overrides map[string]TaskOverride
+	if r.overrides != nil {
+		if overrides, err = r.overrides.LoadOverrides(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load task overrides: %w", err)
+		}
+	}
+
 	var tasks []Task
 
 	for _, script := range scripts {
@@ -110,36 +258,56 @@ func (r *taskCreator) CreateTasks(ctx context.Context) ([]Task, error) {
 		} else if scriptID == "" {
 			scriptID = DefaultTaskIDProvider(script.Path)
 		}
+		scriptID = NamespaceTaskID(r.namespace, scriptID)
 
-		var compatibleEngine Engine
-		for _, engine := range r.engines {
-			if engine.CanHandle(script.Path) {
-				compatibleEngine = engine
-				break
-			}
-		}
-
-		if compatibleEngine == nil {
+		compatibleEngine, engineErr := selectEngine(engines, script)
+		if engineErr != nil {
 			r.logger.Warn("task skipped: no compatible engine", "script_path", script.Path, "task_id", scriptID)
 			r.emitTaskEvent(TaskEvent{
-				Type:       TaskEventRegistrationFailed,
+				Type:       TaskEventSkipped,
 				TaskID:     scriptID,
 				ScriptPath: script.Path,
-				Err:        fmt.Errorf("no compatible engine for script %s", script.Path),
+				Reason:     SkipReasonNoEngine,
+				Err:        engineErr,
 			})
 			continue
 		}
 
+		parseStart := time.Now()
 		task, err := compatibleEngine.ParseJob(script.Path, script.Content)
+		parseDuration := time.Since(parseStart)
 		if err != nil {
 			regErr := fmt.Errorf("failed to parse task %s: %w", script.Path, err)
 			r.errorHandler(task, regErr)
 			r.emitTaskEvent(TaskEvent{
-				Type:       TaskEventRegistrationFailed,
-				TaskID:     scriptID,
+				Type:          TaskEventRegistrationFailed,
+				TaskID:        scriptID,
+				ScriptPath:    script.Path,
+				Reason:        SkipReasonParseError,
+				Engine:        compatibleEngine.Name(),
+				ParseDuration: parseDuration,
+				Task:          task,
+				Err:           regErr,
+			})
+			continue
+		}
+		if v, ok := task.(interface{ setParseDuration(time.Duration) }); ok {
+			v.setParseDuration(parseDuration)
+		}
+		if r.namespace != "" {
+			r.applyNamespace(task)
+		}
+		if override, ok := overrides[task.GetID()]; ok && !override.isZero() {
+			r.applyOverride(task, override)
+		}
+		if disabled, _ := task.GetConfig().Metadata[DisabledMetadataKey].(bool); disabled {
+			r.logger.Info("task skipped: disabled in metadata", "script_path", script.Path, "task_id", task.GetID())
+			r.emitTaskEvent(TaskEvent{
+				Type:       TaskEventSkipped,
+				TaskID:     task.GetID(),
 				ScriptPath: script.Path,
+				Reason:     SkipReasonDisabled,
 				Task:       task,
-				Err:        regErr,
 			})
 			continue
 		}
@@ -150,30 +318,129 @@ func (r *taskCreator) CreateTasks(ctx context.Context) ([]Task, error) {
 	return tasks, nil
 }
 
+// selectEngine picks the engine named by the script's ScriptMetaEngine
+// metadata, falling back to the first engine that handles the path.
+func selectEngine(engines []Engine, script ScriptInfo) (Engine, error) {
+	if hint, _ := script.Meta[ScriptMetaEngine].(string); strings.TrimSpace(hint) != "" {
+		for _, engine := range engines {
+			if engineMatches(engine, hint) {
+				return engine, nil
+			}
+		}
+		return nil, fmt.Errorf("no engine %q for script %s", hint, script.Path)
+	}
+	for _, engine := range engines {
+		if engine.CanHandle(script.Path) {
+			return engine, nil
+		}
+	}
+	return nil, fmt.Errorf("no compatible engine for script %s", script.Path)
+}
+
+func engineMatches(engine Engine, hint string) bool {
+	hint = strings.ToLower(strings.TrimSpace(hint))
+	name := engine.Name()
+	return name == hint || name == "engine:"+hint || engine.CanHandle("script."+strings.TrimPrefix(hint, "."))
+}
+
 func (r *taskCreator) applyTaskIDProvider() {
 	if r.taskIDProvider == nil {
 		return
 	}
-	for _, engine := range r.engines {
+	for _, engine := range r.Engines() {
 		if aware, ok := engine.(TaskIDProviderAware); ok {
 			aware.SetTaskIDProvider(r.taskIDProvider)
 		}
 	}
 }
 
+// configureEngine hands the creator's logger and options to a new engine.
+func (r *taskCreator) configureEngine(engine Engine) {
+	r.applyEngineLogger(engine)
+	r.applyEngineOptions(engine)
+}
+
+// applyEngineOptions pushes the task ID provider, config defaults, secrets
+// provider, profile store, job invoker and global env to engine.
+func (r *taskCreator) applyEngineOptions(engine Engine) {
+	if aware, ok := engine.(TaskIDProviderAware); ok && r.taskIDProvider != nil {
+		aware.SetTaskIDProvider(r.taskIDProvider)
+	}
+	if aware, ok := engine.(ConfigDefaultsAware); ok && !r.configDefaults.isZero() {
+		aware.SetConfigDefaults(r.configDefaults)
+	}
+	if aware, ok := engine.(SecretsAware); ok && r.secrets != nil {
+		aware.SetSecretsProvider(r.secrets)
+	}
+	if aware, ok := engine.(ProfileStoreAware); ok && r.profiles != nil {
+		aware.SetProfileStore(r.profiles)
+	}
+	if aware, ok := engine.(JobInvokerAware); ok && r.invoker != nil {
+		aware.SetJobInvoker(r.invoker)
+	}
+	if aware, ok := engine.(GlobalEnvAware); ok && len(r.globalEnv) > 0 {
+		aware.SetGlobalEnv(r.globalEnv)
+	}
+}
+
+func (r *taskCreator) applyNamespace(task Task) {
+	target, ok := task.(interface{ setID(string) })
+	if !ok {
+		r.logger.Warn("task namespace ignored: task does not support renaming", "task_id", task.GetID(), "namespace", r.namespace)
+		return
+	}
+	target.setID(NamespaceTaskID(r.namespace, task.GetID()))
+}
+
+func (r *taskCreator) applyOverride(task Task, override TaskOverride) {
+	target, ok := task.(interface{ applyOverride(TaskOverride) })
+	if !ok {
+		r.logger.Warn("task override ignored: task does not support overrides", "task_id", task.GetID())
+		return
+	}
+	target.applyOverride(override)
+	r.logger.Info("task override applied", "task_id", task.GetID(), "schedule", task.GetConfig().Schedule, "timeout", task.GetConfig().Timeout)
+}
+
+// reportProviderSkip turns a script the source provider left out into a
+// TaskEventSkipped.
+func (r *taskCreator) reportProviderSkip(path string, reason SkipReason, err error) {
+	id := DefaultTaskIDProvider(path)
+	if r.taskIDProvider != nil {
+		id = r.taskIDProvider(path)
+	}
+	id = NamespaceTaskID(r.namespace, id)
+	r.logger.Debug("task skipped by source provider", "script_path", path, "reason", reason)
+	r.emitTaskEvent(TaskEvent{
+		Type:       TaskEventSkipped,
+		TaskID:     id,
+		ScriptPath: path,
+		Reason:     reason,
+		Err:        err,
+	})
+}
+
 func (r *taskCreator) emitTaskEvent(event TaskEvent) {
+	event = describeTaskEvent(event)
 	for _, handler := range r.eventHandlers {
 		handler(event)
 	}
 }
 
 func (r *taskCreator) applyLoggerProvider() {
-	for _, engine := range r.engines {
-		switch eng := engine.(type) {
-		case LoggerProviderAware:
-			eng.SetLoggerProvider(r.loggerProvider)
-		case LoggerAware:
-			eng.SetLogger(r.loggerProvider.GetLogger(engine.Name()))
-		}
+	for _, engine := range r.Engines() {
+		r.applyEngineLogger(engine)
+	}
+}
+
+func (r *taskCreator) applyEngineLogger(engine Engine) {
+	if r.loggerProvider == nil {
+		return
+	}
+	switch eng := engine.(type) {
+	case LoggerProviderAware:
+		eng.SetLoggerProvider(r.loggerProvider)
+	case LoggerAware:
+		eng.SetLogger(r.loggerProvider.GetLogger(engine.Name()))
 	}
 }
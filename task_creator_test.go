@@ -9,6 +9,7 @@ import (
 	"github.com/goliatone/go-job"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockEngine struct {
@@ -162,7 +163,9 @@ func TestCreateTasksWithDeadline(t *testing.T) {
 	}, nil)
 
 	mockEngine.On("CanHandle", "testdata/example.js").Return(true)
-	mockEngine.On("ParseJob", "testdata/example.js", mock.Anything).Return(&MockTask{}, nil)
+	task := &MockTask{}
+	task.On("GetConfig").Return(job.Config{}).Maybe()
+	mockEngine.On("ParseJob", "testdata/example.js", mock.Anything).Return(task, nil)
 
 	creator := job.NewTaskCreator(mockProvider, engines)
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second))
@@ -183,7 +186,9 @@ func TestTaskCreator_CreateTasks(t *testing.T) {
 	}, nil)
 
 	mockEngine.On("CanHandle", "testdata/example.js").Return(true)
-	mockEngine.On("ParseJob", "testdata/example.js", mock.Anything).Return(&MockTask{}, nil)
+	task := &MockTask{}
+	task.On("GetConfig").Return(job.Config{}).Maybe()
+	mockEngine.On("ParseJob", "testdata/example.js", mock.Anything).Return(task, nil)
 
 	creator := job.NewTaskCreator(mockProvider, engines)
 	tasks, err := creator.CreateTasks(context.Background())
@@ -191,3 +196,29 @@ func TestTaskCreator_CreateTasks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, tasks, 1)
 }
+
+func TestCreateTasksReportsInvalidEnumsAsRegistrationFailures(t *testing.T) {
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/export.sh", Content: []byte("# config\n# metadata:\n#   dedup_policy: Merge\n\necho ok")},
+			{Path: "jobs/retry.sh", Content: []byte("# config\n# backoff:\n#   strategy: linear\n\necho hi")},
+		},
+	}
+
+	var events []job.TaskEvent
+	creator := job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner()})
+	creator.AddTaskEventHandler(func(event job.TaskEvent) { events = append(events, event) })
+
+	tasks, err := creator.CreateTasks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	msg, err := job.BuildExecutionMessageForTask(tasks[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, job.DedupPolicyMerge, msg.DedupPolicy, "the declared policy applies to runs")
+
+	require.Len(t, events, 1)
+	assert.Equal(t, job.TaskEventRegistrationFailed, events[0].Type)
+	assert.Equal(t, "jobs/retry.sh", events[0].ScriptPath)
+	assert.ErrorContains(t, events[0].Err, `invalid backoff strategy "linear", expected one of none|fixed|exponential`)
+}
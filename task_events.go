@@ -1,13 +1,21 @@
 package job
 
-import "path/filepath"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
 
 // TaskIDProvider defines the strategy used to derive a task identifier from a script path.
 type TaskIDProvider func(scriptPath string) string
 
 // DefaultTaskIDProvider preserves the existing behaviour of using the filename as the task ID.
+// Both "/" and "\" are treated as separators so IDs match across operating systems.
 func DefaultTaskIDProvider(scriptPath string) string {
-	return filepath.Base(scriptPath)
+	return path.Base(slashPath(scriptPath))
 }
 
 // TaskEventType discriminates between different kinds of task registration events.
@@ -18,15 +26,165 @@ const (
 	TaskEventRegistered TaskEventType = "registered"
 	// TaskEventRegistrationFailed signals that a task failed to register.
 	TaskEventRegistrationFailed TaskEventType = "registration_failed"
+	// TaskEventUpdated signals that a reload replaced a task whose content changed.
+	TaskEventUpdated TaskEventType = "updated"
+	// TaskEventRemoved signals that a reload dropped a task no longer discovered.
+	TaskEventRemoved TaskEventType = "removed"
+	// TaskEventSkipped signals that a script was deliberately not registered; see Reason.
+	TaskEventSkipped TaskEventType = "skipped"
 )
 
 // TaskEvent captures contextual information about task registration outcomes.
+// The descriptive fields (Engine, Schedule, ConfigSummary, Checksum,
+// ParseDuration) are filled from Task on events without an error; failure
+// events carry whatever was known when the failure occurred.
 type TaskEvent struct {
 	Type       TaskEventType
 	TaskID     string
 	ScriptPath string
-	Task       Task
-	Err        error
+	// ExistingPath is the script path of the task already holding TaskID when
+	// the event results from a duplicate ID.
+	ExistingPath string
+	// Reason explains TaskEventSkipped and, where known, registration failures.
+	Reason        SkipReason
+	Engine        string
+	Schedule      string
+	ConfigSummary map[string]any
+	// Checksum is the sha256 of the script content without its metadata block.
+	Checksum string
+	// PreviousChecksum is the Checksum of the task replaced by TaskEventUpdated.
+	PreviousChecksum string
+	// DiffSize counts the script lines added plus removed by TaskEventUpdated.
+	DiffSize int
+	// ScriptChecksum identifies the script file version; runs of the task
+	// record the same value in ExecutionMessage.ScriptChecksum.
+	ScriptChecksum string
+	ParseDuration  time.Duration
+	Task           Task
+	Err            error
+}
+
+// describeTaskEvent fills the descriptive fields from event.Task. Tasks attached
+// to failures may be partially built, so they are left alone.
+func describeTaskEvent(event TaskEvent) TaskEvent {
+	task := event.Task
+	if task == nil || event.Err != nil {
+		return event
+	}
+	if event.Engine == "" {
+		if engine := task.GetEngine(); engine != nil {
+			event.Engine = engine.Name()
+		}
+	}
+	cfg := task.GetConfig()
+	if event.Schedule == "" {
+		event.Schedule = cfg.Schedule
+		if event.Schedule == "" {
+			event.Schedule = task.GetHandlerConfig().Expression
+		}
+	}
+	if event.ConfigSummary == nil {
+		event.ConfigSummary = summarizeConfig(cfg)
+	}
+	if event.Checksum == "" {
+		event.Checksum = taskContentChecksum(task)
+	}
+	if event.ScriptChecksum == "" {
+		if v, ok := task.(interface{ GetScriptChecksum() string }); ok {
+			event.ScriptChecksum = v.GetScriptChecksum()
+		}
+	}
+	if event.ParseDuration == 0 {
+		if v, ok := task.(interface{ GetParseDuration() time.Duration }); ok {
+			event.ParseDuration = v.GetParseDuration()
+		}
+	}
+	return event
+}
+
+func taskContentChecksum(task Task) string {
+	v, ok := task.(interface{ GetScriptContent() string })
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(v.GetScriptContent()))
+	return hex.EncodeToString(sum[:])
+}
+
+// scriptDiffSize counts the lines present in only one of old and new. Lines
+// are compared as multisets, so moving a line does not count as a change.
+func scriptDiffSize(old, new string) int {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(old, "\n") {
+		counts[line]++
+	}
+	for _, line := range strings.Split(new, "\n") {
+		counts[line]--
+	}
+	size := 0
+	for _, n := range counts {
+		if n < 0 {
+			n = -n
+		}
+		size += n
+	}
+	return size
+}
+
+// summarizeConfig lists the non-default config values. Env values are omitted
+// because they commonly hold credentials; only the keys are reported.
+func summarizeConfig(cfg Config) map[string]any {
+	summary := map[string]any{}
+	if cfg.Retries != 0 {
+		summary["retries"] = cfg.Retries
+	}
+	if cfg.Timeout != 0 {
+		summary["timeout"] = cfg.Timeout.String()
+	}
+	if cfg.WarnAfter != 0 {
+		summary["warn_after"] = cfg.WarnAfter.String()
+	}
+	if !cfg.Deadline.IsZero() {
+		summary["deadline"] = cfg.Deadline
+	}
+	if cfg.NoTimeout {
+		summary["no_timeout"] = true
+	}
+	if cfg.RunOnce {
+		summary["run_once"] = true
+	}
+	if cfg.MaxRuns != 0 {
+		summary["max_runs"] = cfg.MaxRuns
+	}
+	if cfg.MaxConcurrency != 0 {
+		summary["max_concurrency"] = cfg.MaxConcurrency
+	}
+	if cfg.Transaction {
+		summary["transaction"] = true
+	}
+	if cfg.ExitOnError {
+		summary["exit_on_error"] = true
+	}
+	if cfg.ScriptType != "" {
+		summary["script_type"] = cfg.ScriptType
+	}
+	if len(cfg.Env) > 0 {
+		keys := make([]string, 0, len(cfg.Env))
+		for key := range cfg.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		summary["env_keys"] = keys
+	}
+	if len(cfg.Metadata) > 0 {
+		keys := make([]string, 0, len(cfg.Metadata))
+		for key := range cfg.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		summary["metadata_keys"] = keys
+	}
+	return summary
 }
 
 // TaskEventHandler consumes task registration events emitted by the runner lifecycle.
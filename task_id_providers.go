@@ -0,0 +1,123 @@
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	slugUnsafe          = regexp.MustCompile(`[^a-z0-9._]+`)
+	taskIDPlaceholder   = regexp.MustCompile(`\{\{\s*([a-z]+)\s*\}\}`)
+	taskIDTemplateUnset = "-./"
+)
+
+// RelativePathTaskIDProvider derives IDs from the script path relative to
+// root, slugified so separators become dashes: "root/reports/daily.js" yields
+// "reports-daily.js". Paths outside root are slugified as given.
+func RelativePathTaskIDProvider(root string) TaskIDProvider {
+	return func(scriptPath string) string {
+		return slugTaskID(relativeScriptPath(root, scriptPath))
+	}
+}
+
+// ContentHashTaskIDProvider appends the first 8 hex characters of the
+// script's SHA-256 to the ID from base (DefaultTaskIDProvider when nil), so
+// identical basenames with different content do not collide. read loads the
+// script and defaults to os.ReadFile; pass SourceProvider.GetScript for
+// sources that are not on disk. When the script cannot be read the base ID
+// is returned unchanged.
+func ContentHashTaskIDProvider(base TaskIDProvider, read func(path string) ([]byte, error)) TaskIDProvider {
+	if base == nil {
+		base = DefaultTaskIDProvider
+	}
+	if read == nil {
+		read = os.ReadFile
+	}
+	return func(scriptPath string) string {
+		id := base(scriptPath)
+		content, err := read(scriptPath)
+		if err != nil {
+			return id
+		}
+		sum := sha256.Sum256(content)
+		return id + "-" + hex.EncodeToString(sum[:])[:8]
+	}
+}
+
+// TemplateTaskIDProvider builds IDs from a template evaluated against the
+// script path relative to root. Supported placeholders:
+//
+//	{{dir}}   parent directory name ("reports")
+//	{{path}}  relative path without extension ("nightly/reports/daily")
+//	{{base}}  file name without extension ("daily")
+//	{{file}}  file name with extension ("daily.js")
+//	{{ext}}   extension without the dot ("js")
+//	{{hash}}  first 8 hex characters of the relative path's SHA-256
+//
+// Leading and trailing dots, dashes and slashes left by empty placeholders
+// are trimmed, so "{{dir}}.{{base}}" yields "daily" for a script at the root.
+func TemplateTaskIDProvider(root, template string) (TaskIDProvider, error) {
+	if strings.TrimSpace(template) == "" {
+		return nil, fmt.Errorf("task ID template is empty")
+	}
+	for _, match := range taskIDPlaceholder.FindAllStringSubmatch(template, -1) {
+		switch match[1] {
+		case "dir", "path", "base", "file", "ext", "hash":
+		default:
+			return nil, fmt.Errorf("unknown task ID placeholder %q", match[0])
+		}
+	}
+
+	return func(scriptPath string) string {
+		rel := relativeScriptPath(root, scriptPath)
+		file := path.Base(rel)
+		ext := path.Ext(file)
+		dir := path.Base(path.Dir(rel))
+		if dir == "." || dir == "/" {
+			dir = ""
+		}
+		sum := sha256.Sum256([]byte(rel))
+
+		values := map[string]string{
+			"dir":  dir,
+			"path": strings.TrimSuffix(rel, ext),
+			"base": strings.TrimSuffix(file, ext),
+			"file": file,
+			"ext":  strings.TrimPrefix(ext, "."),
+			"hash": hex.EncodeToString(sum[:])[:8],
+		}
+		id := taskIDPlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+			return values[taskIDPlaceholder.FindStringSubmatch(match)[1]]
+		})
+		return strings.Trim(id, taskIDTemplateUnset)
+	}, nil
+}
+
+// relativeScriptPath returns scriptPath relative to root with "/" separators.
+// Both paths are normalized first, so a Windows path and its Linux
+// equivalent produce the same result.
+func relativeScriptPath(root, scriptPath string) string {
+	p := path.Clean(slashPath(scriptPath))
+	if root != "" {
+		base := path.Clean(slashPath(root))
+		switch {
+		case base == ".":
+		case base == "/":
+			p = strings.TrimPrefix(p, "/")
+		case p == base:
+			p = "."
+		case strings.HasPrefix(p, base+"/"):
+			p = strings.TrimPrefix(p, base+"/")
+		}
+	}
+	return strings.TrimPrefix(p, "/")
+}
+
+func slugTaskID(path string) string {
+	return strings.Trim(slugUnsafe.ReplaceAllString(strings.ToLower(path), "-"), "-")
+}
@@ -0,0 +1,63 @@
+package job_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativePathTaskIDProvider(t *testing.T) {
+	provider := job.RelativePathTaskIDProvider("/srv/jobs")
+
+	assert.Equal(t, "reports-daily.js", provider("/srv/jobs/reports/daily.js"))
+	assert.Equal(t, "billing-daily.js", provider("/srv/jobs/Billing/daily.js"))
+	assert.Equal(t, "other-x.sh", provider("/other/x.sh"))
+
+	windows := job.RelativePathTaskIDProvider(`C:\srv\jobs`)
+	assert.Equal(t, "reports-daily.js", windows(`C:\srv\jobs\reports\daily.js`))
+}
+
+func TestDefaultTaskIDProviderAcceptsBothSeparators(t *testing.T) {
+	assert.Equal(t, "daily.js", job.DefaultTaskIDProvider("jobs/reports/daily.js"))
+	assert.Equal(t, "daily.js", job.DefaultTaskIDProvider(`jobs\reports\daily.js`))
+}
+
+func TestContentHashTaskIDProvider(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a", "sync.js")
+	b := filepath.Join(dir, "b", "sync.js")
+	require.NoError(t, os.MkdirAll(filepath.Dir(a), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(b), 0o755))
+	require.NoError(t, os.WriteFile(a, []byte("console.log('a')"), 0o644))
+	require.NoError(t, os.WriteFile(b, []byte("console.log('b')"), 0o644))
+
+	provider := job.ContentHashTaskIDProvider(nil, nil)
+	idA, idB := provider(a), provider(b)
+
+	assert.Regexp(t, `^sync\.js-[0-9a-f]{8}$`, idA)
+	assert.NotEqual(t, idA, idB)
+	assert.Equal(t, idA, provider(a))
+	assert.Equal(t, "missing.js", provider(filepath.Join(dir, "missing.js")))
+}
+
+func TestTemplateTaskIDProvider(t *testing.T) {
+	provider, err := job.TemplateTaskIDProvider("/srv/jobs", "{{dir}}.{{base}}")
+	require.NoError(t, err)
+
+	assert.Equal(t, "reports.daily", provider("/srv/jobs/nightly/reports/daily.js"))
+	assert.Equal(t, "daily", provider("/srv/jobs/daily.js"))
+
+	provider, err = job.TemplateTaskIDProvider("/srv/jobs", "{{ path }}.{{ext}}")
+	require.NoError(t, err)
+	assert.Equal(t, "nightly/reports/daily.js", provider("/srv/jobs/nightly/reports/daily.js"))
+	assert.Equal(t, "nightly/reports/daily.js", provider(`\srv\jobs\nightly\reports\daily.js`))
+
+	_, err = job.TemplateTaskIDProvider("", "{{folder}}")
+	assert.Error(t, err)
+	_, err = job.TemplateTaskIDProvider("", " ")
+	assert.Error(t, err)
+}
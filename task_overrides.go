@@ -0,0 +1,144 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TaskOverride replaces timing fields of a discovered task. Zero fields leave
+// the script metadata untouched; a Timeout override also clears no_timeout.
+type TaskOverride struct {
+	Schedule string
+	Timeout  time.Duration
+	Retries  *int
+}
+
+func (o TaskOverride) isZero() bool {
+	return o.Schedule == "" && o.Timeout == 0 && o.Retries == nil
+}
+
+// apply merges the override on top of cfg.
+func (o TaskOverride) apply(cfg Config) Config {
+	if o.Schedule != "" {
+		cfg.Schedule = o.Schedule
+	}
+	if o.Timeout > 0 {
+		cfg.Timeout = o.Timeout
+		cfg.NoTimeout = false
+	}
+	if o.Retries != nil {
+		cfg.Retries = *o.Retries
+	}
+	return cfg
+}
+
+// OverrideStore supplies operator overrides keyed by task ID. Overrides are
+// loaded once per discovery pass and merged on top of script metadata, so
+// timings can be retuned without editing vendor-supplied scripts.
+type OverrideStore interface {
+	LoadOverrides(ctx context.Context) (map[string]TaskOverride, error)
+}
+
+// OverrideStoreAware task creators accept an OverrideStore.
+type OverrideStoreAware interface {
+	SetOverrideStore(OverrideStore)
+}
+
+// MemoryOverrideStore keeps overrides in memory; safe for concurrent use.
+type MemoryOverrideStore struct {
+	mu        sync.RWMutex
+	overrides map[string]TaskOverride
+}
+
+// NewMemoryOverrideStore returns an empty in-memory store.
+func NewMemoryOverrideStore() *MemoryOverrideStore {
+	return &MemoryOverrideStore{overrides: make(map[string]TaskOverride)}
+}
+
+// Set stores the override for taskID.
+func (s *MemoryOverrideStore) Set(taskID string, override TaskOverride) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[taskID] = override
+}
+
+// Delete removes the override for taskID.
+func (s *MemoryOverrideStore) Delete(taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides, taskID)
+}
+
+// LoadOverrides implements OverrideStore.
+func (s *MemoryOverrideStore) LoadOverrides(context.Context) (map[string]TaskOverride, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]TaskOverride, len(s.overrides))
+	for id, override := range s.overrides {
+		out[id] = override
+	}
+	return out, nil
+}
+
+// FileOverrideStore reads overrides from a YAML or JSON file mapping task IDs
+// to overrides. The file is re-read on every load; a missing file means no
+// overrides.
+//
+//	nightly-report.js:
+//	  schedule: "0 3 * * *"
+//	  timeout: 10m
+//	  retries: 2
+type FileOverrideStore struct {
+	Path string
+}
+
+// NewFileOverrideStore returns a store reading path.
+func NewFileOverrideStore(path string) *FileOverrideStore {
+	return &FileOverrideStore{Path: path}
+}
+
+type fileOverride struct {
+	Schedule string `yaml:"schedule"`
+	Timeout  string `yaml:"timeout"`
+	Retries  *int   `yaml:"retries"`
+}
+
+// LoadOverrides implements OverrideStore.
+func (s *FileOverrideStore) LoadOverrides(context.Context) (map[string]TaskOverride, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]TaskOverride{}, nil
+		}
+		return nil, fmt.Errorf("failed to read overrides %s: %w", s.Path, err)
+	}
+
+	raw := map[string]fileOverride{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides %s: %w", s.Path, err)
+	}
+
+	overrides := make(map[string]TaskOverride, len(raw))
+	for id, entry := range raw {
+		override := TaskOverride{Schedule: entry.Schedule, Retries: entry.Retries}
+		if entry.Timeout != "" {
+			d, err := parseConfigDuration(entry.Timeout)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid timeout %q for task %s in %s", entry.Timeout, id, s.Path)
+			}
+			override.Timeout = d
+		}
+		overrides[id] = override
+	}
+	return overrides, nil
+}
+
+func (j *baseTask) applyOverride(override TaskOverride) {
+	j.config = override.apply(j.config)
+	j.handlerOpts = handlerOptionsFor(j.config)
+}
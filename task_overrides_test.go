@@ -0,0 +1,87 @@
+package job_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerAppliesFileOverrides(t *testing.T) {
+	overridesPath := filepath.Join(t.TempDir(), "overrides.yaml")
+	require.NoError(t, os.WriteFile(overridesPath, []byte(`
+vendor.sh:
+  schedule: "0 3 * * *"
+  timeout: 10m
+  retries: 4
+`), 0o644))
+
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{
+			{Path: "jobs/vendor.sh", Content: []byte("# config\n# schedule: \"*/5 * * * *\"\n# no_timeout: true\n\necho vendor")},
+			{Path: "jobs/local.sh", Content: []byte("# config\n# schedule: \"@hourly\"\n\necho local")},
+		},
+	}
+
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner()})),
+		job.WithOverrideStore(job.NewFileOverrideStore(overridesPath)),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	tasks := map[string]job.Task{}
+	for _, task := range runner.RegisteredTasks() {
+		tasks[task.GetID()] = task
+	}
+	require.Len(t, tasks, 2)
+
+	vendor := tasks["vendor.sh"]
+	assert.Equal(t, "0 3 * * *", vendor.GetConfig().Schedule)
+	assert.Equal(t, 10*time.Minute, vendor.GetConfig().Timeout)
+	assert.False(t, vendor.GetConfig().NoTimeout)
+	assert.Equal(t, 4, vendor.GetConfig().Retries)
+	assert.Equal(t, "0 3 * * *", vendor.GetHandlerConfig().Expression)
+	assert.Equal(t, 10*time.Minute, vendor.GetHandlerConfig().Timeout)
+	assert.False(t, vendor.GetHandlerConfig().NoTimeout)
+
+	assert.Equal(t, "@hourly", tasks["local.sh"].GetConfig().Schedule)
+}
+
+func TestFileOverrideStore(t *testing.T) {
+	dir := t.TempDir()
+
+	overrides, err := job.NewFileOverrideStore(filepath.Join(dir, "missing.yaml")).LoadOverrides(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	path := filepath.Join(dir, "overrides.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"a.js": {"timeout": "soon"}}`), 0o644))
+	_, err = job.NewFileOverrideStore(path).LoadOverrides(context.Background())
+	assert.Error(t, err)
+}
+
+func TestMemoryOverrideStoreAppliedOnReload(t *testing.T) {
+	store := job.NewMemoryOverrideStore()
+	provider := &staticSourceProvider{
+		scripts: []job.ScriptInfo{{Path: "jobs/task.js", Content: []byte("console.log('x')")}},
+	}
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewJSRunner()})),
+		job.WithOverrideStore(store),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	store.Set("task.js", job.TaskOverride{Schedule: "@daily"})
+	result, err := runner.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task.js"}, result.Updated)
+
+	tasks := runner.RegisteredTasks()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "@daily", tasks[0].GetConfig().Schedule)
+}
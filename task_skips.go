@@ -0,0 +1,61 @@
+package job
+
+// SkipReason explains why a script was not registered. It is set on
+// TaskEventSkipped and, where known, on registration failures.
+type SkipReason string
+
+const (
+	// SkipReasonNoEngine: no engine handles the script.
+	SkipReasonNoEngine SkipReason = "no_engine"
+	// SkipReasonIgnored: an ignore glob, path or rule matched the script.
+	SkipReasonIgnored SkipReason = "ignored"
+	// SkipReasonSymlink: the symlink policy excluded the script.
+	SkipReasonSymlink SkipReason = "symlink"
+	// SkipReasonTooLarge: the script exceeded the provider's size limit.
+	SkipReasonTooLarge SkipReason = "too_large"
+	// SkipReasonDisabled: the script sets `disabled: true` in its metadata.
+	SkipReasonDisabled SkipReason = "disabled"
+	// SkipReasonDuplicateID: another script already holds the task ID.
+	SkipReasonDuplicateID SkipReason = "duplicate_id"
+	// SkipReasonParseError: the engine failed to parse the script. Emitted on
+	// TaskEventRegistrationFailed.
+	SkipReasonParseError SkipReason = "parse_error"
+)
+
+// DisabledMetadataKey names the script metadata flag that keeps a script
+// from being registered.
+const DisabledMetadataKey = "disabled"
+
+// ScriptSkipHandler receives scripts a SourceProvider left out of
+// ListScripts. err carries detail where there is any.
+type ScriptSkipHandler func(path string, reason SkipReason, err error)
+
+// ScriptSkipReporter source providers can implement this so task creators
+// turn their silent skips into TaskEventSkipped events.
+type ScriptSkipReporter interface {
+	SetScriptSkipHandler(ScriptSkipHandler)
+}
+
+// SkipCounts returns how many scripts were skipped per reason since the
+// runner was created, across Start and Reload.
+func (r *Runner) SkipCounts() map[SkipReason]int {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+	out := make(map[SkipReason]int, len(r.skipCounts))
+	for reason, n := range r.skipCounts {
+		out[reason] = n
+	}
+	return out
+}
+
+func (r *Runner) countSkip(event TaskEvent) {
+	if event.Type != TaskEventSkipped || event.Reason == "" {
+		return
+	}
+	r.mx.Lock()
+	if r.skipCounts == nil {
+		r.skipCounts = make(map[SkipReason]int)
+	}
+	r.skipCounts[event.Reason]++
+	r.mx.Unlock()
+}
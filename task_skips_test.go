@@ -0,0 +1,54 @@
+package job_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerCountsSkipsPerReason(t *testing.T) {
+	provider := job.NewFileSystemSourceProvider("", fstest.MapFS{
+		"ok.sh":          {Data: []byte("echo ok")},
+		"off.sh":         {Data: []byte("# config\n# metadata:\n#   disabled: true\n\necho off")},
+		"notes.txt":      {Data: []byte("hello")},
+		"big.sh":         {Data: bytes.Repeat([]byte("x"), 1024)},
+		"vendor/lib.sh":  {Data: []byte("echo lib")},
+		"vendor/more.sh": {Data: []byte("echo more")},
+	}).WithIgnoreGlobs("vendor").WithMaxFileSize(512).WithSkipOversized()
+
+	var skipped []job.TaskEvent
+	runner := job.NewRunner(
+		job.WithTaskCreator(job.NewTaskCreator(provider, []job.Engine{job.NewShellRunner()})),
+		job.WithTaskEventHandler(func(event job.TaskEvent) {
+			if event.Type == job.TaskEventSkipped {
+				skipped = append(skipped, event)
+			}
+		}),
+	)
+	require.NoError(t, runner.Start(context.Background()))
+
+	require.Len(t, runner.RegisteredTasks(), 1)
+	assert.Equal(t, map[job.SkipReason]int{
+		job.SkipReasonIgnored:  1,
+		job.SkipReasonTooLarge: 1,
+		job.SkipReasonNoEngine: 1,
+		job.SkipReasonDisabled: 1,
+	}, runner.SkipCounts())
+
+	byPath := map[string]job.SkipReason{}
+	for _, event := range skipped {
+		byPath[event.ScriptPath] = event.Reason
+	}
+	assert.Equal(t, map[string]job.SkipReason{
+		"vendor":    job.SkipReasonIgnored,
+		"big.sh":    job.SkipReasonTooLarge,
+		"notes.txt": job.SkipReasonNoEngine,
+		"off.sh":    job.SkipReasonDisabled,
+	}, byPath)
+	assert.Empty(t, runner.Health(context.Background()).DiscoveryErrors)
+}
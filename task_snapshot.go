@@ -0,0 +1,130 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SnapshotFormat selects how WriteTaskSnapshot encodes a snapshot.
+type SnapshotFormat string
+
+const (
+	SnapshotYAML SnapshotFormat = "yaml"
+	SnapshotJSON SnapshotFormat = "json"
+)
+
+// TaskSnapshot is the reviewable shape of a discovered task, meant for
+// golden files so schedule and config drift shows up in diffs.
+type TaskSnapshot struct {
+	ID       string   `json:"id" yaml:"id"`
+	Path     string   `json:"path,omitempty" yaml:"path,omitempty"`
+	Engine   string   `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Schedule string   `json:"schedule" yaml:"schedule"`
+	Timeout  string   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries  int      `json:"retries" yaml:"retries"`
+	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Config lists the remaining non-default settings (see TaskEvent.ConfigSummary).
+	Config   map[string]any `json:"config,omitempty" yaml:"config,omitempty"`
+	Checksum string         `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+}
+
+// SnapshotOptions controls TaskSnapshots rendering.
+type SnapshotOptions struct {
+	// Root makes task paths relative to it, with "/" separators, so snapshots
+	// are stable across checkouts.
+	Root string
+	// IncludeChecksum adds the script content checksum, surfacing body edits
+	// as well as config changes.
+	IncludeChecksum bool
+}
+
+// TaskSnapshots renders tasks sorted by ID.
+func TaskSnapshots(tasks []Task, opts SnapshotOptions) []TaskSnapshot {
+	snapshots := make([]TaskSnapshot, 0, len(tasks))
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		snapshots = append(snapshots, taskSnapshot(task, opts))
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+	return snapshots
+}
+
+// WriteTaskSnapshot encodes snapshots in format, defaulting to YAML.
+func WriteTaskSnapshot(w io.Writer, snapshots []TaskSnapshot, format SnapshotFormat) error {
+	if snapshots == nil {
+		snapshots = []TaskSnapshot{}
+	}
+	switch format {
+	case SnapshotJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snapshots)
+	case SnapshotYAML, "":
+		out, err := yaml.Marshal(snapshots)
+		if err != nil {
+			return fmt.Errorf("failed to encode task snapshot: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("unsupported snapshot format %q", format)
+	}
+}
+
+func taskSnapshot(task Task, opts SnapshotOptions) TaskSnapshot {
+	cfg := task.GetConfig()
+	snapshot := TaskSnapshot{
+		ID:       task.GetID(),
+		Path:     snapshotPath(taskScriptPath(task), opts.Root),
+		Schedule: cfg.Schedule,
+		Retries:  cfg.Retries,
+		Tags:     stringList(cfg.Metadata["tags"]),
+	}
+	if snapshot.Schedule == "" {
+		snapshot.Schedule = task.GetHandlerConfig().Expression
+	}
+	if engine := task.GetEngine(); engine != nil {
+		snapshot.Engine = engine.Name()
+	}
+	if cfg.NoTimeout {
+		snapshot.Timeout = "none"
+	} else if cfg.Timeout != 0 {
+		snapshot.Timeout = cfg.Timeout.String()
+	}
+	sort.Strings(snapshot.Tags)
+
+	config := summarizeConfig(cfg)
+	for _, key := range []string{"retries", "timeout", "no_timeout"} {
+		delete(config, key)
+	}
+	if len(config) > 0 {
+		snapshot.Config = config
+	}
+
+	if opts.IncludeChecksum {
+		if v, ok := task.(interface{ GetScriptContent() string }); ok {
+			snapshot.Checksum = ScriptChecksum([]byte(v.GetScriptContent()))
+		}
+	}
+	return snapshot
+}
+
+func snapshotPath(path, root string) string {
+	if path == "" {
+		return ""
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -0,0 +1,70 @@
+package job_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSnapshotsRenderSortedDeterministicYAML(t *testing.T) {
+	tasks := []job.Task{
+		job.NewBaseTask("sync.js", "/srv/jobs/sync.js", "js", job.Config{
+			Schedule: "@hourly",
+			Timeout:  time.Minute,
+			Retries:  2,
+			Metadata: map[string]any{"tags": []any{"nightly", "billing"}, "owner": "data"},
+		}, "sync()", noopEngine{}),
+		job.NewBaseTask("archive.sh", "/srv/jobs/archive.sh", "shell", job.Config{
+			Schedule:  "0 3 * * *",
+			NoTimeout: true,
+			RunOnce:   true,
+		}, "tar", noopEngine{}),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, job.WriteTaskSnapshot(&buf, job.TaskSnapshots(tasks, job.SnapshotOptions{Root: "/srv/jobs"}), job.SnapshotYAML))
+
+	assert.Equal(t, `- id: archive.sh
+  path: archive.sh
+  engine: noop
+  schedule: 0 3 * * *
+  timeout: none
+  retries: 0
+  config:
+    run_once: true
+- id: sync.js
+  path: sync.js
+  engine: noop
+  schedule: '@hourly'
+  timeout: 1m0s
+  retries: 2
+  tags:
+  - billing
+  - nightly
+  config:
+    metadata_keys:
+    - owner
+    - tags
+`, buf.String())
+}
+
+func TestTaskSnapshotsJSONWithChecksum(t *testing.T) {
+	task := job.NewBaseTask("a.js", "jobs/a.js", "js", job.Config{Schedule: "@daily"}, "body", noopEngine{})
+
+	var buf bytes.Buffer
+	snapshots := job.TaskSnapshots([]job.Task{task}, job.SnapshotOptions{IncludeChecksum: true})
+	require.NoError(t, job.WriteTaskSnapshot(&buf, snapshots, job.SnapshotJSON))
+
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 1)
+	assert.Equal(t, "jobs/a.js", decoded[0]["path"])
+	assert.Equal(t, job.ScriptChecksum([]byte("body")), decoded[0]["checksum"])
+
+	assert.Error(t, job.WriteTaskSnapshot(&buf, snapshots, "toml"))
+}
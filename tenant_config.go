@@ -0,0 +1,123 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goliatone/go-errors"
+)
+
+// TenantMetadataKey is the Config.Metadata key set to the tenant ID when a
+// tenant overlay is applied; TenantScopeExtractor reads it.
+const TenantMetadataKey = "tenant_id"
+
+// SQLConnection names a driver and DSN. DSNs may hold `${secret:NAME}` refs.
+type SQLConnection struct {
+	Driver string
+	DSN    string
+}
+
+// TenantOverlay is per-tenant config merged into execution messages. Env and
+// Metadata merge per key over the task config; Connection selects a named
+// SQLConnection for the SQL engine; MaxConcurrency caps concurrent runs.
+type TenantOverlay struct {
+	Env            map[string]string
+	Metadata       map[string]any
+	Connection     string
+	MaxConcurrency int
+}
+
+// TenantConfigs holds tenant overlays and the named connections they refer
+// to. It is safe for concurrent use.
+type TenantConfigs struct {
+	mu          sync.RWMutex
+	overlays    map[string]TenantOverlay
+	connections map[string]SQLConnection
+}
+
+// NewTenantConfigs returns an empty overlay set.
+func NewTenantConfigs() *TenantConfigs {
+	return &TenantConfigs{
+		overlays:    make(map[string]TenantOverlay),
+		connections: make(map[string]SQLConnection),
+	}
+}
+
+// WithTenant registers the overlay for tenantID.
+func (t *TenantConfigs) WithTenant(tenantID string, overlay TenantOverlay) *TenantConfigs {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.overlays[tenantID] = overlay
+	return t
+}
+
+// RemoveTenant drops the overlay for tenantID.
+func (t *TenantConfigs) RemoveTenant(tenantID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.overlays, tenantID)
+}
+
+// WithConnection registers a named connection overlays can refer to.
+func (t *TenantConfigs) WithConnection(name string, conn SQLConnection) *TenantConfigs {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.connections[name] = conn
+	return t
+}
+
+// Apply merges the overlay registered for scope.TenantID into msg.Config.
+// Messages without a tenant, or for tenants without an overlay, are left
+// untouched. An overlay naming an unknown connection is an error so a tenant
+// never falls back to the job's default database.
+func (t *TenantConfigs) Apply(msg *ExecutionMessage, scope Scope) error {
+	if t == nil || msg == nil || scope.TenantID == "" {
+		return nil
+	}
+	t.mu.RLock()
+	overlay, ok := t.overlays[scope.TenantID]
+	conn, hasConn := t.connections[overlay.Connection]
+	t.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	metadata := mergeAnyMaps(msg.Config.Metadata, overlay.Metadata)
+	metadata = mergeAnyMaps(metadata, map[string]any{TenantMetadataKey: scope.TenantID})
+	if overlay.Connection != "" {
+		if !hasConn {
+			return errors.New(fmt.Sprintf("tenant %s references unknown connection %q", scope.TenantID, overlay.Connection), errors.CategoryBadInput).
+				WithTextCode("TENANT_CONNECTION_UNKNOWN").
+				WithMetadata(map[string]any{"tenant_id": scope.TenantID, "connection": overlay.Connection})
+		}
+		metadata["driver"] = conn.Driver
+		metadata["dsn"] = conn.DSN
+	}
+
+	msg.Config.Metadata = metadata
+	msg.Config.Env = mergeStringMaps(msg.Config.Env, overlay.Env)
+	if overlay.MaxConcurrency > 0 {
+		msg.Config.MaxConcurrency = overlay.MaxConcurrency
+	}
+	return nil
+}
+
+// TenantScopeExtractor keys concurrency limits by tenant, for use with
+// TaskCommander.WithScopeExtractor.
+func TenantScopeExtractor(msg *ExecutionMessage) string {
+	if msg == nil {
+		return ""
+	}
+	tenant, _ := msg.Config.Metadata[TenantMetadataKey].(string)
+	return tenant
+}
+
+// WithTenantConfigs merges tenant overlays into messages executed with an
+// actor scope on the context (see ContextWithEnvelope and ExecuteEnvelope).
+func (c *TaskCommander) WithTenantConfigs(configs *TenantConfigs) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.tenants = configs
+	return c
+}
@@ -0,0 +1,47 @@
+package job
+
+import "context"
+
+const (
+	// TraceIDEnvVar is the environment variable (shell) and global (JS) exposing the trace ID to scripts.
+	TraceIDEnvVar = "JOB_TRACE_ID"
+	// PostgresTraceIDStatement sets a transaction-local session variable readable via current_setting('job.trace_id').
+	PostgresTraceIDStatement = "SELECT set_config('job.trace_id', $1, true)"
+)
+
+type traceIDContextKey struct{}
+
+// ContextWithTraceID stores a correlation/trace ID on the context.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if traceID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDContextKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID stored with ContextWithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	traceID, _ := ctx.Value(traceIDContextKey{}).(string)
+	return traceID
+}
+
+// resolveTraceID makes sure msg and ctx agree on the trace ID, preferring the
+// message value and falling back to the one carried by the context.
+func resolveTraceID(ctx context.Context, msg *ExecutionMessage) context.Context {
+	if msg == nil {
+		return ctx
+	}
+	if msg.TraceID == "" {
+		msg.TraceID = TraceIDFromContext(ctx)
+	}
+	if msg.TraceID != "" && TraceIDFromContext(ctx) != msg.TraceID {
+		ctx = ContextWithTraceID(ctx, msg.TraceID)
+	}
+	return ctx
+}
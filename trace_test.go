@@ -0,0 +1,53 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceIDDerivedFromContext(t *testing.T) {
+	engine := &recordingEngine{}
+	task := job.NewBaseTask("trace-task", "/tmp/trace.js", "js", job.Config{}, "noop", engine)
+
+	ctx := job.ContextWithTraceID(context.Background(), "trace-123")
+	require.NoError(t, task.Execute(ctx, &job.ExecutionMessage{}))
+
+	require.NotNil(t, engine.lastMsg)
+	assert.Equal(t, "trace-123", engine.lastMsg.TraceID)
+	assert.Equal(t, "trace-123", job.TraceIDFromContext(engine.lastCtx))
+}
+
+func TestTraceIDMessageOverridesContext(t *testing.T) {
+	engine := &recordingEngine{}
+	task := job.NewBaseTask("trace-task", "/tmp/trace.js", "js", job.Config{}, "noop", engine)
+
+	ctx := job.ContextWithTraceID(context.Background(), "from-ctx")
+	require.NoError(t, job.NewTaskCommander(task).Execute(ctx, &job.ExecutionMessage{TraceID: "from-msg"}))
+
+	assert.Equal(t, "from-msg", engine.lastMsg.TraceID)
+	assert.Equal(t, "from-msg", job.TraceIDFromContext(engine.lastCtx))
+}
+
+func TestTraceIDExposedToScripts(t *testing.T) {
+	shell := job.NewShellRunner()
+	err := shell.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "sh",
+		ScriptPath: "trace.sh",
+		TraceID:    "abc",
+		Parameters: map[string]any{"script": `test "$JOB_TRACE_ID" = "abc"`},
+	})
+	require.NoError(t, err)
+
+	js := job.NewJSRunner()
+	err = js.Execute(context.Background(), &job.ExecutionMessage{
+		JobID:      "js",
+		ScriptPath: "trace.js",
+		TraceID:    "abc",
+		Parameters: map[string]any{"script": `if (JOB_TRACE_ID !== "abc") { throw new Error("missing trace id") }`},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,395 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultIdempotencyHeader carries the idempotency key for webhook triggers.
+	DefaultIdempotencyHeader = "Idempotency-Key"
+	// DefaultTraceIDHeader carries an upstream trace ID for webhook triggers.
+	DefaultTraceIDHeader = "X-Trace-Id"
+	// DefaultCallbackURLHeader carries the callback URL for webhook triggers
+	// whose body is a raw params object.
+	DefaultCallbackURLHeader = "X-Callback-Url"
+	// EnvelopeContentType marks a webhook body as an Envelope. Bodies sent
+	// with any other content type are decoded as a raw params object.
+	EnvelopeContentType = "application/vnd.go-job.envelope+json"
+
+	webhookStatusAccepted  = "accepted"
+	webhookStatusSucceeded = "succeeded"
+	webhookStatusFailed    = "failed"
+	webhookStatusDuplicate = "duplicate"
+)
+
+// WebhookTriggerResponse is the JSON body returned by WebhookTriggerHandler.
+type WebhookTriggerResponse struct {
+	RunID   string  `json:"run_id,omitempty"`
+	JobID   string  `json:"job_id,omitempty"`
+	TraceID string  `json:"trace_id,omitempty"`
+	Result  *Result `json:"result,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// WebhookTriggerOption customises a WebhookTriggerHandler.
+type WebhookTriggerOption func(*WebhookTriggerHandler)
+
+// WithWebhookAuthAdapter attaches actor/scope from the request context using the adapter.
+func WithWebhookAuthAdapter(adapter GoAuthAdapter) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.auth = &adapter
+	}
+}
+
+// WithWebhookTrustEnvelopeActor accepts actor/scope supplied in the request body.
+// By default they are discarded and only the authenticator is trusted.
+func WithWebhookTrustEnvelopeActor(trust bool) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.trustActor = trust
+	}
+}
+
+// WithWebhookEnvelopeOptions configures envelope decoding (size limits, sanitizers).
+func WithWebhookEnvelopeOptions(opts ...EnvelopeOption) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.envelopeOpts = append(h.envelopeOpts, opts...)
+	}
+}
+
+// WithWebhookIdempotencyHeader changes the header read for idempotency keys.
+func WithWebhookIdempotencyHeader(header string) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		if header != "" {
+			h.idempotencyHeader = header
+		}
+	}
+}
+
+// WithWebhookDedupPolicy sets the policy applied when an idempotency key is present.
+func WithWebhookDedupPolicy(policy DeduplicationPolicy) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.dedupPolicy = policy
+	}
+}
+
+// WithWebhookJobResolver overrides how the target job ID is read from the request.
+func WithWebhookJobResolver(fn func(*http.Request) string) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		if fn != nil {
+			h.resolveJob = fn
+		}
+	}
+}
+
+// WithWebhookCommander customises the TaskCommander used for each trigger.
+// Pass Runner.Commander so triggers honour the runner's maintenance mode,
+// hooks, run cache and history; by default only task toggles are honoured.
+func WithWebhookCommander(fn func(Task) *TaskCommander) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		if fn != nil {
+			h.commander = fn
+		}
+	}
+}
+
+// WithWebhookAuthorizer checks the resolved actor against policy before triggering.
+func WithWebhookAuthorizer(policy AuthzPolicy) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.authorize = policy
+	}
+}
+
+// WithWebhookAsync responds with 202 Accepted and runs the job in the background.
+// Requests beyond the async limit get 503 Service Unavailable.
+func WithWebhookAsync(async bool) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.async = async
+	}
+}
+
+// WithWebhookAsyncLimit bounds how many background runs WithWebhookAsync keeps
+// in flight; defaults to DefaultAsyncLimit.
+func WithWebhookAsyncLimit(limit int) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.asyncLimit = limit
+	}
+}
+
+// WithWebhookResultCallbacks delivers results to the callback_url of
+// envelopes that set one, typically with WithWebhookAsync.
+func WithWebhookResultCallbacks(sender *ResultCallbackSender) WebhookTriggerOption {
+	return func(h *WebhookTriggerHandler) {
+		h.callbacks = sender
+	}
+}
+
+// WebhookTriggerHandler is an http.Handler that triggers a registered job from a
+// POST request. The body is an Envelope when sent as EnvelopeContentType and a
+// raw JSON object of params otherwise.
+type WebhookTriggerHandler struct {
+	registry          Registry
+	auth              *GoAuthAdapter
+	trustActor        bool
+	envelopeOpts      []EnvelopeOption
+	idempotencyHeader string
+	dedupPolicy       DeduplicationPolicy
+	resolveJob        func(*http.Request) string
+	commander         func(Task) *TaskCommander
+	authorize         AuthzPolicy
+	async             bool
+	asyncLimit        int
+	background        *asyncGroup
+	callbacks         *ResultCallbackSender
+}
+
+// NewWebhookTriggerHandler builds a handler resolving jobs from registry. By default
+// the job ID is read from the `job` path value (e.g. "POST /jobs/{job}/trigger")
+// or the `job` query parameter.
+func NewWebhookTriggerHandler(registry Registry, opts ...WebhookTriggerOption) *WebhookTriggerHandler {
+	h := &WebhookTriggerHandler{
+		registry:          registry,
+		idempotencyHeader: DefaultIdempotencyHeader,
+		dedupPolicy:       DedupPolicyDrop,
+		resolveJob:        defaultWebhookJobResolver,
+		commander:         RegistryCommander(registry),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	h.background = newAsyncGroup(h.asyncLimit)
+	return h
+}
+
+// Shutdown stops accepting background runs and waits for those in flight to
+// finish or ctx to end. Later async requests get 503 Service Unavailable.
+func (h *WebhookTriggerHandler) Shutdown(ctx context.Context) error {
+	if h == nil || h.background == nil {
+		return nil
+	}
+	return h.background.Close(ctx)
+}
+
+func defaultWebhookJobResolver(r *http.Request) string {
+	if id := r.PathValue("job"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("job")
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookTriggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeWebhookResponse(w, http.StatusMethodNotAllowed, WebhookTriggerResponse{Error: "method not allowed"})
+		return
+	}
+
+	jobID := h.resolveJob(r)
+	if jobID == "" || h.registry == nil {
+		writeWebhookResponse(w, http.StatusNotFound, WebhookTriggerResponse{Error: "job not found"})
+		return
+	}
+	env, err := h.decodeEnvelope(w, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeWebhookResponse(w, status, WebhookTriggerResponse{JobID: jobID, Error: err.Error()})
+		return
+	}
+
+	ctx := r.Context()
+	if h.auth != nil {
+		env = h.auth.AttachActor(ctx, env)
+		ctx = h.auth.InjectActor(ctx, env)
+	}
+	if env.Actor != nil || !env.Scope.isEmpty() {
+		ctx = ContextWithEnvelope(ctx, env)
+	}
+	// Authorize before reporting whether the job exists, so denied callers
+	// cannot probe for job IDs.
+	task, ok := h.registry.Get(jobID)
+	authzReq := AuthzRequest{Action: AuthzActionTriggerJob, JobID: jobID}
+	if ok && task != nil {
+		authzReq.Metadata = task.GetConfig().Metadata
+	}
+	if err := Authorize(ctx, h.authorize, authzReq); err != nil {
+		writeWebhookResponse(w, http.StatusForbidden, WebhookTriggerResponse{JobID: jobID, Error: err.Error()})
+		return
+	}
+	if !ok || task == nil {
+		writeWebhookResponse(w, http.StatusNotFound, WebhookTriggerResponse{JobID: jobID, Error: "job not found"})
+		return
+	}
+
+	runID := NewRunID()
+	ctx = ContextWithRunID(ctx, runID)
+	traceID := r.Header.Get(DefaultTraceIDHeader)
+	if traceID == "" {
+		traceID = TraceIDFromContext(ctx)
+	}
+	if traceID == "" {
+		traceID = runID
+	}
+	ctx = ContextWithTraceID(ctx, traceID)
+
+	msg := &ExecutionMessage{
+		JobID:          jobID,
+		Parameters:     env.Params,
+		IdempotencyKey: env.IdempotencyKey,
+		CallbackURL:    env.CallbackURL,
+		TraceID:        traceID,
+	}
+	if msg.IdempotencyKey != "" {
+		msg.DedupPolicy = h.dedupPolicy
+	}
+
+	resp := WebhookTriggerResponse{RunID: runID, JobID: jobID, TraceID: traceID}
+	cmd := h.commander(task)
+	if h.callbacks != nil {
+		cmd = cmd.WithResultCallbacks(h.callbacks)
+	}
+
+	if h.async {
+		bgCtx := context.WithoutCancel(ctx)
+		if !h.background.Go(func() { _ = cmd.Execute(bgCtx, msg) }) {
+			w.Header().Set("Retry-After", "1")
+			writeWebhookResponse(w, http.StatusServiceUnavailable, WebhookTriggerResponse{JobID: jobID, Error: "too many background runs"})
+			return
+		}
+		resp.Result = &Result{Status: webhookStatusAccepted}
+		writeWebhookResponse(w, http.StatusAccepted, resp)
+		return
+	}
+
+	start := time.Now()
+	report, err := cmd.ExecuteWithReport(ctx, msg)
+	result := report.Result
+	if result.Status == "" {
+		result.Status = webhookStatusSucceeded
+	}
+	if result.Duration == 0 {
+		result.Duration = time.Since(start)
+	}
+	resp.Result = &result
+
+	switch {
+	case err == nil:
+		writeWebhookResponse(w, http.StatusOK, resp)
+	case stderrors.Is(err, ErrIdempotentDrop):
+		resp.Result.Status = webhookStatusDuplicate
+		writeWebhookResponse(w, http.StatusOK, resp)
+	case stderrors.Is(err, ErrQuotaExceeded):
+		resp.Result.Status = webhookStatusFailed
+		resp.Result.Message = err.Error()
+		resp.Error = err.Error()
+		if denial, ok := QuotaDenialFrom(err); ok {
+			resp.Result.Metadata = map[string]any{"quota": denial}
+			if wait := time.Until(denial.ResetAt); wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((wait+time.Second-1)/time.Second)))
+			}
+		}
+		writeWebhookResponse(w, http.StatusTooManyRequests, resp)
+	case stderrors.Is(err, ErrMaintenanceMode):
+		resp.Result.Status = ResultStatusMaintenance
+		resp.Result.Message = err.Error()
+		resp.Error = err.Error()
+		writeWebhookResponse(w, http.StatusServiceUnavailable, resp)
+	case stderrors.Is(err, ErrTaskDisabled):
+		resp.Result.Status = ResultStatusDisabled
+		resp.Result.Message = err.Error()
+		resp.Error = err.Error()
+		writeWebhookResponse(w, http.StatusConflict, resp)
+	default:
+		resp.Result.Status = webhookStatusFailed
+		if resp.Result.Message == "" {
+			resp.Result.Message = err.Error()
+		}
+		resp.Error = err.Error()
+		writeWebhookResponse(w, http.StatusInternalServerError, resp)
+	}
+}
+
+// decodeEnvelope reads the request body as an Envelope when it is sent as
+// EnvelopeContentType, otherwise as a raw params object. Bodies over the
+// envelope size limit fail with *http.MaxBytesError. The idempotency and
+// callback URL headers win over the body.
+func (h *WebhookTriggerHandler) decodeEnvelope(w http.ResponseWriter, r *http.Request) (Envelope, error) {
+	cfg := buildEnvelopeConfig(h.envelopeOpts...)
+	body := r.Body
+	if cfg.maxBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, int64(cfg.maxBytes))
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	var env Envelope
+	if len(bytes.TrimSpace(data)) > 0 {
+		if isEnvelopeRequest(r) {
+			env, err = DecodeEnvelope(data, h.envelopeOpts...)
+		} else {
+			// the reader already enforced the size limit on the raw body
+			env, err = decodeParamsEnvelope(data, cfg.sanitizer)
+		}
+		if err != nil {
+			return Envelope{}, err
+		}
+	}
+
+	if !h.trustActor {
+		env.Actor = nil
+		env.Scope = Scope{}
+	}
+	key := strings.TrimSpace(r.Header.Get(h.idempotencyHeader))
+	callbackURL := strings.TrimSpace(r.Header.Get(DefaultCallbackURLHeader))
+	if key != "" || callbackURL != "" {
+		if key != "" {
+			env.IdempotencyKey = key
+		}
+		if callbackURL != "" {
+			env.CallbackURL = callbackURL
+		}
+		if err := env.Validate(); err != nil {
+			return Envelope{}, err
+		}
+	}
+	return env, nil
+}
+
+func isEnvelopeRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == EnvelopeContentType
+}
+
+// decodeParamsEnvelope decodes a raw body as a single params object. Only
+// Params comes from the body; the idempotency key and callback URL come from
+// headers.
+func decodeParamsEnvelope(data []byte, sanitizer EnvelopeSanitizer) (Envelope, error) {
+	var params map[string]any
+	if err := json.Unmarshal(data, &params); err != nil {
+		return Envelope{}, fmt.Errorf("decode params: %w", err)
+	}
+	return Envelope{Params: sanitizeParams(params, sanitizer), RawContentBytes: len(data)}, nil
+}
+
+func writeWebhookResponse(w http.ResponseWriter, status int, resp WebhookTriggerResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,265 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturingTask struct {
+	*stubTask
+	msgs []*ExecutionMessage
+	ctxs []context.Context
+}
+
+func (t *capturingTask) Execute(ctx context.Context, msg *ExecutionMessage) error {
+	t.msgs = append(t.msgs, msg)
+	t.ctxs = append(t.ctxs, ctx)
+	return nil
+}
+
+type staticAuthenticator struct{ actor map[string]any }
+
+func (a staticAuthenticator) ActorFromContext(context.Context) (any, bool) { return a.actor, true }
+func (a staticAuthenticator) WithActorContext(ctx context.Context, _ any) context.Context {
+	return ctx
+}
+
+func TestWebhookTriggerHandlerRunsAddressedJob(t *testing.T) {
+	reg := newStubRegistry()
+	task := &capturingTask{stubTask: newStubTask("report", Config{})}
+	require.NoError(t, reg.Add(task))
+	tracker := NewIdempotencyTracker()
+
+	handler := NewWebhookTriggerHandler(reg,
+		WithWebhookAuthAdapter(GoAuthAdapter{Authenticator: staticAuthenticator{
+			actor: map[string]any{"actor_id": "svc-1", "tenant_id": "acme"},
+		}}),
+		WithWebhookIdempotencyHeader("X-Delivery"),
+		WithWebhookCommander(func(task Task) *TaskCommander {
+			return NewTaskCommander(task).WithIdempotencyTracker(tracker)
+		}),
+	)
+	mux := http.NewServeMux()
+	mux.Handle("POST /jobs/{job}/trigger", handler)
+
+	body := `{"params":{"month":"2026-01"},"actor":{"id":"spoofed"}}`
+	req := httptest.NewRequest(http.MethodPost, "/jobs/report/trigger", strings.NewReader(body))
+	req.Header.Set("Content-Type", EnvelopeContentType)
+	req.Header.Set("X-Delivery", "delivery-1")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp WebhookTriggerResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "report", resp.JobID)
+	assert.NotEmpty(t, resp.RunID)
+	require.NotNil(t, resp.Result)
+	assert.Equal(t, "succeeded", resp.Result.Status)
+
+	require.Len(t, task.msgs, 1)
+	assert.Equal(t, "2026-01", task.msgs[0].Parameters["month"])
+	assert.Equal(t, "delivery-1", task.msgs[0].IdempotencyKey)
+	assert.Equal(t, resp.RunID, RunIDFromContext(task.ctxs[0]))
+
+	actor, scope, ok := ActorFromContext(task.ctxs[0])
+	require.True(t, ok)
+	assert.Equal(t, "svc-1", actor.ID)
+	assert.Equal(t, "acme", scope.TenantID)
+}
+
+func TestWebhookTriggerHandlerRawParamsAndErrors(t *testing.T) {
+	reg := newStubRegistry()
+	task := &capturingTask{stubTask: newStubTask("sync", Config{})}
+	require.NoError(t, reg.Add(task))
+	handler := NewWebhookTriggerHandler(reg)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`{"full":true}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, true, task.msgs[0].Parameters["full"])
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?job=sync", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`not json`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// a raw params body is one JSON object; anything after it is rejected
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync",
+		strings.NewReader(`{}, "callback_url":"http://example.com/cb", "idempotency_key":"x"`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	require.Len(t, task.msgs, 1)
+
+	// envelope keys in a raw params body stay params
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`{"params":"p","scope":"s"}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, map[string]any{"params": "p", "scope": "s"}, task.msgs[1].Parameters)
+
+	req := httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`{"params":{"n":1},"idempotency_key":"`+strings.Repeat("k", MaxIdempotencyKeyLength+1)+`"}`))
+	req.Header.Set("Content-Type", EnvelopeContentType+"; charset=utf-8")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	small := NewWebhookTriggerHandler(reg, WithWebhookEnvelopeOptions(WithEnvelopeMaxBytes(16)))
+	rec = httptest.NewRecorder()
+	small.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`{"payload":"`+strings.Repeat("x", 32)+`"}`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	rec = httptest.NewRecorder()
+	small.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", strings.NewReader(`{"n":1}`)))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestWebhookTriggerHandlerAuthorizesBeforeLookup(t *testing.T) {
+	reg := newStubRegistry()
+	require.NoError(t, reg.Add(&capturingTask{stubTask: newStubTask("sync", Config{})}))
+	handler := NewWebhookTriggerHandler(reg, WithWebhookAuthorizer(RolePolicy(map[AuthzAction][]string{
+		AuthzActionTriggerJob: {"operator"},
+	})))
+
+	for _, jobID := range []string{"sync", "missing"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job="+jobID, nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code, jobID)
+	}
+
+	ctx := ContextWithActor(context.Background(), &Actor{ID: "o", Role: "operator"}, Scope{})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=missing", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWebhookTriggerHandlerQuotaDenied(t *testing.T) {
+	reg := newStubRegistry()
+	require.NoError(t, reg.Add(&capturingTask{stubTask: newStubTask("sync", Config{})}))
+
+	quota := NewRunRateQuota(QuotaGlobalRate, 1, time.Minute)
+	handler := NewWebhookTriggerHandler(reg, WithWebhookCommander(func(task Task) *TaskCommander {
+		return NewTaskCommander(task).WithQuotaChecker(quota)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	require.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	var resp WebhookTriggerResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Result)
+	assert.Equal(t, QuotaGlobalRate, resp.Result.Metadata["quota"].(map[string]any)["quota"])
+}
+
+func TestWebhookTriggerHandlerHonoursRunnerState(t *testing.T) {
+	reg := NewMemoryRegistry()
+	task := &capturingTask{stubTask: newStubTask("sync", Config{})}
+	require.NoError(t, reg.Add(task))
+	runner := NewRunner(WithRegistry(reg))
+	handler := NewWebhookTriggerHandler(reg, WithWebhookCommander(runner.Commander))
+
+	runner.Maintenance().Enable("upgrade")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	assert.Empty(t, task.msgs)
+
+	runner.Maintenance().Disable()
+	require.NoError(t, reg.SetEnabled("sync", false))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	// the default commander still honours task toggles
+	rec = httptest.NewRecorder()
+	NewWebhookTriggerHandler(reg).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Empty(t, task.msgs)
+}
+
+type reportingTask struct {
+	*stubTask
+	result Result
+}
+
+func (t *reportingTask) Execute(ctx context.Context, _ *ExecutionMessage) error {
+	ReportResult(ctx, t.result)
+	return nil
+}
+
+func TestWebhookTriggerHandlerReturnsReportedResult(t *testing.T) {
+	reg := newStubRegistry()
+	require.NoError(t, reg.Add(&reportingTask{stubTask: newStubTask("sync", Config{}), result: Result{
+		Status:   ResultStatusSkipped,
+		Message:  "nothing to sync",
+		Metadata: map[string]any{"rows": 0, "api_token": "secret"},
+	}}))
+
+	rec := httptest.NewRecorder()
+	NewWebhookTriggerHandler(reg).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp WebhookTriggerResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Result)
+	assert.Equal(t, ResultStatusSkipped, resp.Result.Status)
+	assert.Equal(t, "nothing to sync", resp.Result.Message)
+	assert.EqualValues(t, 0, resp.Result.Metadata["rows"])
+	assert.NotEqual(t, "secret", resp.Result.Metadata["api_token"])
+}
+
+type blockingTask struct {
+	*stubTask
+	release chan struct{}
+}
+
+func (t *blockingTask) Execute(context.Context, *ExecutionMessage) error {
+	<-t.release
+	return nil
+}
+
+func TestWebhookTriggerHandlerBoundsAsyncRuns(t *testing.T) {
+	reg := newStubRegistry()
+	task := &blockingTask{stubTask: newStubTask("sync", Config{}), release: make(chan struct{})}
+	require.NoError(t, reg.Add(task))
+	handler := NewWebhookTriggerHandler(reg, WithWebhookAsync(true), WithWebhookAsyncLimit(1))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, handler.Shutdown(ctx), context.DeadlineExceeded, "shutdown waits for the run in flight")
+
+	close(task.release)
+	require.NoError(t, handler.Shutdown(context.Background()))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?job=sync", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "no background runs after shutdown")
+}
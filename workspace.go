@@ -0,0 +1,323 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-errors"
+)
+
+// WorkspaceEnvVar exposes the run workspace directory to shell scripts and,
+// as a global of the same name, to JS scripts. SQL scripts reference it as
+// the `:workspace` named parameter.
+const WorkspaceEnvVar = "JOB_WORKSPACE"
+
+// Artifact describes a file collected from a run workspace.
+type Artifact struct {
+	RunID string `json:"run_id"`
+	JobID string `json:"job_id"`
+	// Name is the file path relative to the workspace, with "/" separators.
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ArtifactStore persists files collected from run workspaces.
+type ArtifactStore interface {
+	PutArtifact(ctx context.Context, artifact Artifact, content io.Reader) error
+}
+
+// WorkspaceErrorHandler receives artifact collection and cleanup failures,
+// which do not change the run outcome.
+type WorkspaceErrorHandler func(jobID, runID string, err error)
+
+// WorkspaceManager creates an isolated directory per run and, once the run
+// finishes, copies files matching the task's `artifacts` metadata globs into
+// an ArtifactStore before removing the directory.
+type WorkspaceManager struct {
+	root    string
+	store   ArtifactStore
+	onError WorkspaceErrorHandler
+}
+
+// NewWorkspaceManager creates run workspaces under root (os.TempDir() when
+// empty) and collects artifacts into store (nil skips collection).
+func NewWorkspaceManager(root string, store ArtifactStore) *WorkspaceManager {
+	return &WorkspaceManager{root: root, store: store}
+}
+
+// WithErrorHandler reports artifact collection and cleanup failures.
+func (m *WorkspaceManager) WithErrorHandler(handler WorkspaceErrorHandler) *WorkspaceManager {
+	m.onError = handler
+	return m
+}
+
+// Workspace is the directory assigned to a single run.
+type Workspace struct {
+	Dir   string
+	RunID string
+	JobID string
+}
+
+// Open creates the workspace for a run.
+func (m *WorkspaceManager) Open(jobID, runID string) (*Workspace, error) {
+	root := m.root
+	if root == "" {
+		root = os.TempDir()
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, workspaceError("failed to create workspace root", err, jobID, runID)
+	}
+	dir, err := os.MkdirTemp(root, "run-"+sanitizeWorkspaceName(runID)+"-")
+	if err != nil {
+		return nil, workspaceError("failed to create run workspace", err, jobID, runID)
+	}
+	return &Workspace{Dir: dir, RunID: runID, JobID: jobID}, nil
+}
+
+// Finish collects artifacts matching patterns and removes the workspace.
+// Failures go to the error handler.
+func (m *WorkspaceManager) Finish(ctx context.Context, ws *Workspace, patterns []string) []Artifact {
+	if ws == nil {
+		return nil
+	}
+	artifacts, err := m.Collect(ctx, ws, patterns)
+	if err != nil {
+		m.reportError(ws, err)
+	}
+	if err := os.RemoveAll(ws.Dir); err != nil {
+		m.reportError(ws, workspaceError("failed to remove run workspace", err, ws.JobID, ws.RunID))
+	}
+	return artifacts
+}
+
+// Collect copies workspace files matching any glob into the store. Globs are
+// matched against the slash separated relative path, with "**" spanning
+// directories; a pattern without a "/" also matches the file name at any depth.
+func (m *WorkspaceManager) Collect(ctx context.Context, ws *Workspace, patterns []string) ([]Artifact, error) {
+	if m.store == nil || ws == nil || len(patterns) == 0 {
+		return nil, nil
+	}
+
+	var artifacts []Artifact
+	err := filepath.WalkDir(ws.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(ws.Dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !matchArtifact(name, patterns) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		artifact := Artifact{RunID: ws.RunID, JobID: ws.JobID, Name: name, Size: info.Size(), ModTime: info.ModTime()}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		putErr := m.store.PutArtifact(ctx, artifact, file)
+		file.Close()
+		if putErr != nil {
+			return fmt.Errorf("artifact %s: %w", name, putErr)
+		}
+		artifacts = append(artifacts, artifact)
+		return nil
+	})
+	if err != nil {
+		return artifacts, workspaceError("failed to collect artifacts", err, ws.JobID, ws.RunID)
+	}
+	return artifacts, nil
+}
+
+func (m *WorkspaceManager) reportError(ws *Workspace, err error) {
+	if m.onError != nil {
+		m.onError(ws.JobID, ws.RunID, err)
+	}
+}
+
+func matchArtifact(name string, patterns []string) bool {
+	base := path.Base(name)
+	for _, pattern := range patterns {
+		if matchGlob(pattern, name) {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// artifactPatterns reads the `artifacts` metadata (a list or comma separated string).
+func artifactPatterns(cfg Config) []string {
+	return stringList(cfg.Metadata["artifacts"])
+}
+
+// sanitizeWorkspaceName makes name a single path segment: separators become
+// underscores, and "." and ".." become underscores too, so the segment never
+// refers to the current or parent directory.
+func sanitizeWorkspaceName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "." || name == ".." {
+		return strings.Repeat("_", len(name))
+	}
+	return name
+}
+
+// withinDir reports whether target is root or a path below it.
+func withinDir(root, target string) bool {
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+func workspaceError(msg string, err error, jobID, runID string) error {
+	return errors.Wrap(err, errors.CategoryInternal, msg).
+		WithTextCode("JOB_WORKSPACE_ERROR").
+		WithMetadata(map[string]any{"job_id": jobID, "run_id": runID})
+}
+
+type workspaceKey struct{}
+
+// ContextWithWorkspace attaches the run workspace directory to ctx.
+func ContextWithWorkspace(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, dir)
+}
+
+// WorkspaceFromContext returns the run workspace directory, if any.
+func WorkspaceFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	dir, ok := ctx.Value(workspaceKey{}).(string)
+	return dir, ok && dir != ""
+}
+
+// WithWorkspaces gives every run its own workspace directory and collects
+// artifacts once all attempts finish.
+func (c *TaskCommander) WithWorkspaces(manager *WorkspaceManager) *TaskCommander {
+	if c == nil {
+		return nil
+	}
+	c.workspaces = manager
+	return c
+}
+
+// WithWorkspaces gives scheduled runs their own workspace directory.
+func (m *CronManager) WithWorkspaces(manager *WorkspaceManager) *CronManager {
+	m.workspaces = manager
+	return m
+}
+
+var _ ArtifactStore = &MemoryArtifactStore{}
+
+// MemoryArtifactStore keeps artifacts in memory, keyed by run.
+type MemoryArtifactStore struct {
+	mu   sync.RWMutex
+	runs map[string]map[string]storedArtifact
+}
+
+type storedArtifact struct {
+	Artifact
+	content []byte
+}
+
+// NewMemoryArtifactStore returns an empty in-memory store.
+func NewMemoryArtifactStore() *MemoryArtifactStore {
+	return &MemoryArtifactStore{runs: make(map[string]map[string]storedArtifact)}
+}
+
+func (s *MemoryArtifactStore) PutArtifact(_ context.Context, artifact Artifact, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs[artifact.RunID] == nil {
+		s.runs[artifact.RunID] = make(map[string]storedArtifact)
+	}
+	s.runs[artifact.RunID][artifact.Name] = storedArtifact{Artifact: artifact, content: data}
+	return nil
+}
+
+// List returns the artifacts of runID ordered by name.
+func (s *MemoryArtifactStore) List(runID string) []Artifact {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Artifact, 0, len(s.runs[runID]))
+	for _, stored := range s.runs[runID] {
+		out = append(out, stored.Artifact)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Open returns the content of an artifact.
+func (s *MemoryArtifactStore) Open(runID, name string) (io.Reader, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.runs[runID][name]
+	if !ok {
+		return nil, false
+	}
+	return bytes.NewReader(stored.content), true
+}
+
+var _ ArtifactStore = DirArtifactStore("")
+
+// DirArtifactStore copies artifacts to <dir>/<run_id>/<name>.
+type DirArtifactStore string
+
+func (d DirArtifactStore) PutArtifact(_ context.Context, artifact Artifact, content io.Reader) error {
+	runDir := filepath.Join(string(d), sanitizeWorkspaceName(artifact.RunID))
+	target := filepath.Join(runDir, filepath.FromSlash(artifact.Name))
+	if !withinDir(runDir, target) || target == runDir {
+		return fmt.Errorf("artifact %q escapes the run directory", artifact.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,105 @@
+package job_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceCollectsArtifactsAndCleansUp(t *testing.T) {
+	root := t.TempDir()
+	store := job.NewMemoryArtifactStore()
+	manager := job.NewWorkspaceManager(root, store)
+
+	script := `mkdir -p "$JOB_WORKSPACE/out" && echo report > "$JOB_WORKSPACE/out/report.csv" && echo skip > "$JOB_WORKSPACE/debug.log"`
+	cfg := job.Config{Metadata: map[string]any{"artifacts": []any{"*.csv"}}}
+	task := job.NewBaseTask("export.sh", "jobs/export.sh", "shell", cfg, script, job.NewShellRunner())
+
+	ctx := job.ContextWithRunID(context.Background(), "run-1")
+	require.NoError(t, job.NewTaskCommander(task).WithWorkspaces(manager).Execute(ctx, &job.ExecutionMessage{}))
+
+	artifacts := store.List("run-1")
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "out/report.csv", artifacts[0].Name)
+	assert.Equal(t, "export.sh", artifacts[0].JobID)
+
+	content, ok := store.Open("run-1", "out/report.csv")
+	require.True(t, ok)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "report\n", string(data))
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestWorkspaceExposedToEngines(t *testing.T) {
+	dir := t.TempDir()
+	ctx := job.ContextWithWorkspace(context.Background(), dir)
+
+	err := job.NewJSRunner().Execute(ctx, &job.ExecutionMessage{
+		JobID:      "js",
+		ScriptPath: "ws.js",
+		Parameters: map[string]any{"script": `if (JOB_WORKSPACE !== job.workspace || !job.workspace) { throw new Error("missing workspace") }`},
+	})
+	require.NoError(t, err)
+
+	dbPath := filepath.Join(t.TempDir(), "ws.db")
+	err = job.NewSQLRunner().Execute(ctx, &job.ExecutionMessage{
+		JobID:      "sql",
+		ScriptPath: "ws.sql",
+		Config:     job.Config{Metadata: map[string]any{"driver": "sqlite3", "dsn": dbPath}},
+		Parameters: map[string]any{"script": "CREATE TABLE runs (dir TEXT);\nINSERT INTO runs (dir) VALUES (:workspace);"},
+	})
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	var got string
+	require.NoError(t, db.QueryRow("SELECT dir FROM runs").Scan(&got))
+	assert.Equal(t, dir, got)
+}
+
+func TestDirArtifactStore(t *testing.T) {
+	root := t.TempDir()
+	work := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(work, "result.json"), []byte(`{}`), 0o644))
+
+	manager := job.NewWorkspaceManager("", job.DirArtifactStore(root))
+	artifacts, err := manager.Collect(context.Background(), &job.Workspace{Dir: work, RunID: "run-2", JobID: "j"}, []string{"result.json"})
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+
+	data, err := os.ReadFile(filepath.Join(root, "run-2", "result.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+}
+
+func TestDirArtifactStoreStaysUnderRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "artifacts")
+	store := job.DirArtifactStore(root)
+
+	for _, runID := range []string{"..", ".", "../.."} {
+		require.NoError(t, store.PutArtifact(context.Background(), job.Artifact{RunID: runID, Name: "result.json"}, strings.NewReader("{}")), runID)
+	}
+	_, err := os.Stat(filepath.Join(parent, "result.json"))
+	assert.True(t, os.IsNotExist(err), "a run ID of .. must not write beside the root")
+	_, err = os.Stat(filepath.Join(root, "__", "result.json"))
+	assert.NoError(t, err)
+
+	err = store.PutArtifact(context.Background(), job.Artifact{RunID: "run-1", Name: "../../escape.json"}, strings.NewReader("{}"))
+	assert.ErrorContains(t, err, "escapes the run directory")
+	_, err = os.Stat(filepath.Join(parent, "escape.json"))
+	assert.True(t, os.IsNotExist(err))
+}